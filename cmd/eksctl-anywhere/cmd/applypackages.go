@@ -63,6 +63,16 @@ func applyPackages(ctx context.Context) error {
 		deps.Kubectl,
 	)
 
+	toApply, err := validatePackagesFile(ctx, deps, apo.fileName, kubeConfig)
+	if err != nil {
+		return err
+	}
+	diff, err := packages.DiffPackages(ctx, toApply, kubeConfig)
+	if err != nil {
+		return err
+	}
+	fmt.Print(diff)
+
 	curatedpackages.PrintLicense()
 	err = packages.ApplyPackages(ctx, apo.fileName, kubeConfig)
 	if err != nil {
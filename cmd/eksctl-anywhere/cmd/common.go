@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -83,3 +89,36 @@ func WithClusterSpec(spec *cluster.Spec) func(config *PackageConfig) {
 		config.spec = spec
 	}
 }
+
+// validatePackagesFile validates the configuration of the packages in fileName against the schemas
+// in the active bundle of the cluster each package belongs to.
+func validatePackagesFile(ctx context.Context, deps *dependencies.Dependencies, fileName, kubeConfig string) ([]packagesv1.Package, error) {
+	packages, err := curatedpackages.ReadPackagesFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	packagesByCluster := map[string][]packagesv1.Package{}
+	for _, p := range packages {
+		clusterName := strings.TrimPrefix(p.Namespace, constants.EksaPackagesName+"-")
+		if p.Namespace == "" || clusterName == p.Namespace {
+			logger.V(4).Info("Skipping configuration validation for package outside of a cluster packages namespace", "package", p.Name, "namespace", p.Namespace)
+			continue
+		}
+		packagesByCluster[clusterName] = append(packagesByCluster[clusterName], p)
+	}
+
+	for clusterName, clusterPackages := range packagesByCluster {
+		b := curatedpackages.NewBundleReader(kubeConfig, clusterName, curatedpackages.Cluster, deps.Kubectl, nil, nil)
+		bundle, err := b.GetLatestBundle(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("getting active package bundle for cluster %s: %v", clusterName, err)
+		}
+		client := curatedpackages.NewPackageClient(deps.Kubectl, curatedpackages.WithBundle(bundle))
+		if err := client.ValidatePackages(clusterPackages); err != nil {
+			return nil, err
+		}
+	}
+
+	return packages, nil
+}
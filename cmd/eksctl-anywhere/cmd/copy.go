@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// copyCmd represents the copy command
+var copyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy resources",
+	Long:  "Use eksctl anywhere copy to copy resources, such as curated packages, to a private registry",
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd)
+}
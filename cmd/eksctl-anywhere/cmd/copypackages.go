@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/docker"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/helm"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+// copyPackagesCmd represents the packages command
+var copyPackagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "Copy curated packages to a private registry",
+	Long: `Copy the curated packages bundles, with all their images and helm charts, and the package controller
+to a private registry, so curated packages can be installed in disconnected environments.
+Use the private registry as registryMirrorConfiguration.endpoint in your cluster config to install them.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return copyPackagesCommand.Call(cmd.Context())
+	},
+}
+
+func init() {
+	copyCmd.AddCommand(copyPackagesCmd)
+
+	copyPackagesCmd.Flags().StringVar(&copyPackagesCommand.dstRegistry, "dst-registry", "", "Registry where to copy the curated packages artifacts")
+	if err := copyPackagesCmd.MarkFlagRequired("dst-registry"); err != nil {
+		log.Fatalf("Cannot mark 'dst-registry' as required: %s", err)
+	}
+	copyPackagesCmd.Flags().StringSliceVar(&copyPackagesCommand.kubeVersions, "kube-version", nil, "Kubernetes versions to copy the curated packages for, separated by commas. Defaults to all the supported versions")
+	copyPackagesCmd.Flags().BoolVar(&copyPackagesCommand.insecure, "insecure", false, "Flag to indicate skipping TLS verification while copying helm charts")
+}
+
+var copyPackagesCommand = CopyPackagesCommand{}
+
+type CopyPackagesCommand struct {
+	dstRegistry  string
+	kubeVersions []string
+	insecure     bool
+}
+
+func (c CopyPackagesCommand) Call(ctx context.Context) error {
+	username, password, err := config.ReadCredentials()
+	if err != nil {
+		return err
+	}
+
+	helmOpts := []executables.HelmOpt{}
+	if c.insecure {
+		helmOpts = append(helmOpts, executables.WithInsecure())
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithManifestReader().
+		WithHelm(helmOpts...).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer deps.Close(ctx)
+
+	artifactsFolder := "tmp-eks-a-packages-copy"
+	dockerClient := executables.BuildDockerExecutable()
+	copyPackages := artifacts.CopyPackages{
+		Reader:       curatedpackages.NewPackageReader(deps.ManifestReader),
+		Version:      version.Get(),
+		KubeVersions: c.kubeVersions,
+		ImageMover: docker.NewImageMover(
+			docker.NewOriginalRegistrySource(dockerClient),
+			docker.NewRegistryDestination(dockerClient, c.dstRegistry),
+		),
+		ChartDownloader: helm.NewChartRegistryDownloader(deps.Helm, artifactsFolder),
+		ChartImporter: helm.NewChartRegistryImporter(
+			deps.Helm, artifactsFolder,
+			c.dstRegistry,
+			username,
+			password,
+		),
+		BundleDownloader:   oras.NewBundleDownloader(artifactsFolder),
+		BundleImporter:     oras.NewFileRegistryImporter(c.dstRegistry, username, password, artifactsFolder),
+		TmpArtifactsFolder: artifactsFolder,
+	}
+
+	if err = copyPackages.Run(ctx); err != nil {
+		return err
+	}
+
+	logger.Info("Curated packages copied. Set registryMirrorConfiguration.endpoint to the destination registry in your cluster config to install them", "registry", c.dstRegistry)
+	return nil
+}
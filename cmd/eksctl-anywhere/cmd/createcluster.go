@@ -13,6 +13,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
@@ -23,6 +24,7 @@ import (
 type createClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	validationRunOptions
 	forceClean            bool
 	skipIpCheck           bool
 	hardwareCSVPath       string
@@ -49,6 +51,10 @@ func init() {
 	createClusterCmd.Flags().StringVar(&cc.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
+	applyValidationRunFlags(createClusterCmd.Flags(), &cc.validationRunOptions)
+	if err := createClusterCmd.Flags().MarkDeprecated("skip-ip-check", fmt.Sprintf("use --skip-validations %s,%s instead", providers.ControlPlaneIPCheckID, providers.TinkerbellIPCheckID)); err != nil {
+		log.Fatalf("Error marking flag as deprecated: %v", err)
+	}
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
 
 	if err := createClusterCmd.MarkFlagRequired("filename"); err != nil {
@@ -59,6 +65,11 @@ func init() {
 func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
+	report, err := cc.newReport()
+	if err != nil {
+		return err
+	}
+
 	clusterConfigFileExist := validations.FileExists(cc.fileName)
 	if !clusterConfigFileExist {
 		return fmt.Errorf("the cluster config file %s does not exist", cc.fileName)
@@ -87,8 +98,6 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		}
 	}
 
-	validations.CheckDockerAllocatedMemory(ctx, docker)
-
 	kubeconfigPath := kubeconfig.FromClusterName(clusterConfig.Name)
 	if validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
 		return fmt.Errorf(
@@ -101,6 +110,14 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	if err != nil {
 		return err
 	}
+	if cc.skipIpCheck {
+		cc.skipValidations = append(cc.skipValidations, providers.ControlPlaneIPCheckID, providers.TinkerbellIPCheckID)
+	}
+	overrides, err := cc.overrides(clusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
+	clusterSpec.Cluster.AddSkippedValidations(overrides.Skip)
 
 	cliConfig := buildCliConfig(clusterSpec)
 	dirs, err := cc.directoriesToMount(clusterSpec, cliConfig, cc.installPackages)
@@ -117,7 +134,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		WithBootstrapper().
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, clusterManagerOpts...).
-		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck, cc.hardwareCSVPath, cc.forceClean, cc.tinkerbellBootstrapIP).
+		WithProvider(cc.fileName, clusterSpec.Cluster, cc.hardwareCSVPath, cc.forceClean, cc.tinkerbellBootstrapIP).
 		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
 		WithWriter().
 		WithEksdInstaller().
@@ -160,8 +177,12 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		ManagementCluster: getManagementCluster(clusterSpec),
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
+		Report:            report,
+		Overrides:         overrides,
+		DockerExecutable:  docker,
 	}
 	createValidations := createvalidations.New(validationOpts)
+	createCluster.WithValidationOpts(validationOpts.RunnerOpts()...)
 
 	if features.UseNewWorkflows().IsActive() {
 		err = (management.CreateCluster{
@@ -172,6 +193,9 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	} else {
 		err = createCluster.Run(ctx, clusterSpec, createValidations, cc.forceClean)
 	}
+	if writeErr := cc.writeReport(report); writeErr != nil && err == nil {
+		err = writeErr
+	}
 
 	cleanup(deps, &err)
 	return err
@@ -62,6 +62,10 @@ func createPackages(ctx context.Context) error {
 		deps.Kubectl,
 	)
 
+	if _, err := validatePackagesFile(ctx, deps, cpo.fileName, kubeConfig); err != nil {
+		return err
+	}
+
 	curatedpackages.PrintLicense()
 	err = packages.CreatePackages(ctx, cpo.fileName, kubeConfig)
 	if err != nil {
@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -29,6 +31,7 @@ type downloadArtifactsOptions struct {
 	fileName    string
 	dryRun      bool
 	retainDir   bool
+	verifyOnly  bool
 }
 
 var downloadArtifactsopts = &downloadArtifactsOptions{}
@@ -39,6 +42,7 @@ func init() {
 	downloadArtifactsCmd.Flags().StringVarP(&downloadArtifactsopts.downloadDir, "download-dir", "d", "eks-anywhere-downloads", "Directory to download the artifacts to")
 	downloadArtifactsCmd.Flags().BoolVarP(&downloadArtifactsopts.dryRun, "dry-run", "", false, "Print the manifest URIs without downloading them")
 	downloadArtifactsCmd.Flags().BoolVarP(&downloadArtifactsopts.retainDir, "retain-dir", "r", false, "Do not delete the download folder after creating a tarball")
+	downloadArtifactsCmd.Flags().BoolVarP(&downloadArtifactsopts.verifyOnly, "verify-only", "", false, "Verify the digests of the artifacts in an existing tarball without downloading them")
 }
 
 var downloadArtifactsCmd = &cobra.Command{
@@ -56,6 +60,10 @@ var downloadArtifactsCmd = &cobra.Command{
 }
 
 func downloadArtifacts(context context.Context, opts *downloadArtifactsOptions) error {
+	if opts.verifyOnly {
+		return verifyArtifactsTarball(opts.downloadDir)
+	}
+
 	factory := dependencies.NewFactory()
 	deps, err := factory.
 		WithFileReader().
@@ -72,10 +80,12 @@ func downloadArtifacts(context context.Context, opts *downloadArtifactsOptions)
 		return err
 	}
 
+	digests := &files.DigestManifest{}
+
 	// download the eks-a-release.yaml
 	if !opts.dryRun {
 		releaseManifestURL := releases.ManifestURL()
-		if err := downloadArtifact(filepath.Join(opts.downloadDir, filepath.Base(releaseManifestURL)), releaseManifestURL, reader); err != nil {
+		if err := downloadArtifact(opts.downloadDir, filepath.Base(releaseManifestURL), releaseManifestURL, reader, digests); err != nil {
 			return fmt.Errorf("downloading release manifest: %v", err)
 		}
 	}
@@ -93,11 +103,11 @@ func downloadArtifacts(context context.Context, opts *downloadArtifactsOptions)
 					continue
 				}
 
-				filePath := filepath.Join(opts.downloadDir, bundle.KubeVersion, component, filepath.Base(*manifest))
-				if err = downloadArtifact(filePath, *manifest, reader); err != nil {
+				artifactPath := filepath.Join(bundle.KubeVersion, component, filepath.Base(*manifest))
+				if err = downloadArtifact(opts.downloadDir, artifactPath, *manifest, reader, digests); err != nil {
 					return fmt.Errorf("downloading artifact for component %s: %v", component, err)
 				}
-				*manifest = filePath
+				*manifest = filepath.Join(opts.downloadDir, artifactPath)
 			}
 		}
 		bundles.Spec.VersionsBundles[i] = bundle
@@ -113,6 +123,10 @@ func downloadArtifacts(context context.Context, opts *downloadArtifactsOptions)
 	}
 
 	if !opts.dryRun {
+		if err = writeDigestManifest(opts.downloadDir, digests, bundleReleaseFilePath); err != nil {
+			return err
+		}
+
 		if err = createTarball(opts.downloadDir); err != nil {
 			return err
 		}
@@ -136,31 +150,213 @@ func preRunDownloadArtifactsCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func downloadArtifact(filePath, artifactUri string, reader *files.Reader) error {
+// downloadArtifact streams the artifact to artifactPath, relative to downloadDir, and records its digests.
+func downloadArtifact(downloadDir, artifactPath, artifactUri string, reader *files.Reader, digests *files.DigestManifest) error {
 	logger.V(3).Info(fmt.Sprintf("Downloading artifact: %s", artifactUri))
 
+	filePath := filepath.Join(downloadDir, artifactPath)
 	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
 		return err
 	}
 
 	logger.V(3).Info(fmt.Sprintf("Creating local artifact file: %s", filePath))
 
-	contents, err := reader.ReadFile(artifactUri)
+	file, err := os.Create(filePath)
 	if err != nil {
 		return err
 	}
-	if err = ioutil.WriteFile(filePath, contents, 0o644); err != nil {
+	defer file.Close()
+
+	// Manifests don't have published digests, they are computed so they can be audited after the transfer
+	checksums, err := reader.DownloadUnverified(artifactUri, file)
+	if err != nil {
 		return err
 	}
 
+	digests.Add(files.ArtifactDigest{
+		Path:       filepath.ToSlash(artifactPath),
+		URI:        artifactUri,
+		Unverified: true,
+		Checksums:  checksums,
+	})
+
 	logger.V(3).Info(fmt.Sprintf("Successfully downloaded artifact %s to %s", artifactUri, filePath))
 
 	return nil
 }
 
+// writeDigestManifest records the digests of the local files and writes the digest manifest in downloadDir.
+func writeDigestManifest(downloadDir string, digests *files.DigestManifest, localFiles ...string) error {
+	for _, localFile := range localFiles {
+		artifactPath, err := filepath.Rel(downloadDir, localFile)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(localFile)
+		if err != nil {
+			return err
+		}
+		checksums, err := files.Compute(localFile, file, nil)
+		file.Close()
+		if err != nil {
+			return err
+		}
+
+		digests.Add(files.ArtifactDigest{
+			Path:      filepath.ToSlash(artifactPath),
+			Checksums: checksums,
+		})
+	}
+
+	if unverified := digests.Unverified(); len(unverified) > 0 {
+		logger.Info(fmt.Sprintf("Warning: %d artifacts have no published checksum, their digests were recorded as unverified in %s", len(unverified), files.DigestManifestFileName))
+		for _, artifact := range unverified {
+			logger.V(3).Info("Unverified artifact", "artifact", artifact.URI)
+		}
+	}
+
+	content, err := digests.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(downloadDir, files.DigestManifestFileName), content, 0o644)
+}
+
+// verifyArtifactsTarball verifies the digest of the tarball created for downloadDir and the digests of every
+// artifact in it against its digest manifest.
+func verifyArtifactsTarball(downloadDir string) error {
+	// The tarball entries are walked from the cleaned directory path, so the
+	// prefix to trim from them must be cleaned too
+	downloadDir = filepath.Clean(downloadDir)
+	tarFileName := fmt.Sprintf("%s.tar.gz", downloadDir)
+	if err := verifyTarballChecksum(tarFileName); err != nil {
+		return err
+	}
+
+	tarFile, err := os.Open(tarFileName)
+	if err != nil {
+		return err
+	}
+	defer tarFile.Close()
+
+	gzipReader, err := gzip.NewReader(tarFile)
+	if err != nil {
+		return fmt.Errorf("reading tarball %s: %v", tarFileName, err)
+	}
+	defer gzipReader.Close()
+
+	prefix := filepath.ToSlash(downloadDir) + "/"
+	var digests *files.DigestManifest
+	actual := map[string]files.Checksums{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading tarball %s: %v", tarFileName, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		artifactPath := strings.TrimPrefix(header.Name, prefix)
+		if artifactPath == files.DigestManifestFileName {
+			content, err := io.ReadAll(tarReader)
+			if err != nil {
+				return fmt.Errorf("reading tarball %s: %v", tarFileName, err)
+			}
+			if digests, err = files.ParseDigestManifest(content); err != nil {
+				return err
+			}
+			continue
+		}
+
+		checksums, err := files.Compute(artifactPath, tarReader, nil)
+		if err != nil {
+			return err
+		}
+		actual[artifactPath] = checksums
+	}
+
+	if digests == nil {
+		return fmt.Errorf("tarball %s doesn't contain a digest manifest", tarFileName)
+	}
+
+	for _, artifact := range digests.Artifacts {
+		checksums, ok := actual[artifact.Path]
+		if !ok {
+			return fmt.Errorf("artifact %s is missing from tarball %s", artifact.Path, tarFileName)
+		}
+		if err = files.CompareChecksums(artifact.Path, artifact.Checksums, checksums); err != nil {
+			return err
+		}
+	}
+
+	for artifactPath := range actual {
+		if _, ok := digests.Get(artifactPath); !ok {
+			return fmt.Errorf("artifact %s in tarball %s is not in the digest manifest", artifactPath, tarFileName)
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Verified the digests of %d artifacts in %s", len(digests.Artifacts), tarFileName))
+
+	return nil
+}
+
+// verifyTarballChecksum verifies the tarball against the sha256 file written alongside it. If the file is not
+// present, files.AllowUnverified decides whether the tarball can be used.
+func verifyTarballChecksum(tarFileName string) error {
+	content, err := ioutil.ReadFile(tarballChecksumFileName(tarFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return files.AllowUnverified(tarFileName)
+	}
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return fmt.Errorf("invalid checksum file for tarball %s", tarFileName)
+	}
+
+	tarFile, err := os.Open(tarFileName)
+	if err != nil {
+		return err
+	}
+	defer tarFile.Close()
+
+	_, err = files.Verify(tarFileName, tarFile, nil, files.Checksums{SHA256: fields[0]})
+	return err
+}
+
+func tarballChecksumFileName(tarFileName string) string {
+	return tarFileName + ".sha256"
+}
+
+// writeTarballChecksum writes the sha256 of the tarball alongside it, in the format used by sha256sum.
+func writeTarballChecksum(tarFileName string) error {
+	tarFile, err := os.Open(tarFileName)
+	if err != nil {
+		return err
+	}
+	defer tarFile.Close()
+
+	checksums, err := files.Compute(tarFileName, tarFile, nil)
+	if err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("%s  %s\n", checksums.SHA256, filepath.Base(tarFileName))
+	return ioutil.WriteFile(tarballChecksumFileName(tarFileName), []byte(content), 0o644)
+}
+
 func createTarball(downloadDir string) error {
 	var buf bytes.Buffer
-	tarFileName := fmt.Sprintf("%s.tar.gz", downloadDir)
+	tarFileName := fmt.Sprintf("%s.tar.gz", filepath.Clean(downloadDir))
 	tarFile, err := os.Create(tarFileName)
 	if err != nil {
 		return err
@@ -205,7 +401,10 @@ func createTarball(downloadDir string) error {
 	if _, err = io.Copy(tarFile, &buf); err != nil {
 		return err
 	}
+	if err = tarFile.Close(); err != nil {
+		return err
+	}
 	logger.V(3).Info(fmt.Sprintf("Successfully created downloads tarball %s", tarFileName))
 
-	return nil
+	return writeTarballChecksum(tarFileName)
 }
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -12,9 +14,12 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/pkg/api"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterconfig"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
@@ -31,6 +36,12 @@ var generateClusterConfigCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if viper.GetBool("interactive") || viper.GetBool("from-env") {
+			return generateClusterConfigFromAnswers(cmd.Context(), clusterName)
+		}
+		if viper.GetString("provider") == "" {
+			return fmt.Errorf("required flag(s) \"provider\" not set")
+		}
 		err = generateClusterConfig(clusterName)
 		if err != nil {
 			return fmt.Errorf("generating eks-a cluster config: %v", err) // need to have better error handling here in own func
@@ -50,11 +61,83 @@ func preRunGenerateClusterConfig(cmd *cobra.Command, args []string) {
 
 func init() {
 	generateCmd.AddCommand(generateClusterConfigCmd)
-	generateClusterConfigCmd.Flags().StringP("provider", "p", "", "Provider to use (vsphere or tinkerbell or docker)")
-	err := generateClusterConfigCmd.MarkFlagRequired("provider")
+	generateClusterConfigCmd.Flags().StringP("provider", "p", "", "Provider to use (vsphere or tinkerbell or docker). Required unless --interactive or --from-env are set")
+	generateClusterConfigCmd.Flags().BoolP("interactive", "i", false, "Ask for the cluster configuration, discovering and validating the provider resources")
+	generateClusterConfigCmd.Flags().Bool("from-env", false, fmt.Sprintf("Read the cluster configuration from %s* environment variables, discovering and validating the provider resources", clusterconfig.EnvPrefix))
+	generateClusterConfigCmd.MarkFlagsMutuallyExclusive("interactive", "from-env")
+}
+
+// generateClusterConfigFromAnswers builds the cluster config asking for its values, either interactively or
+// from environment variables. Questions are written to stderr so the config can be redirected to a file.
+func generateClusterConfigFromAnswers(ctx context.Context, clusterName string) error {
+	var prompter clusterconfig.Prompter
+	if viper.GetBool("from-env") {
+		prompter = clusterconfig.NewEnvPrompter()
+	} else {
+		prompter = clusterconfig.NewTerminalPrompter(os.Stdin, os.Stderr)
+	}
+
+	provider := strings.ToLower(viper.GetString("provider"))
+	if provider == "" {
+		var err error
+		if provider, err = clusterconfig.AskProvider(prompter, generateSupportedProviders()); err != nil {
+			return err
+		}
+	}
+
+	var opts []clusterconfig.GeneratorOpt
+	switch provider {
+	case constants.VSphereProviderName:
+		deps, err := dependencies.NewFactory().WithGovc().Build(ctx)
+		if err != nil {
+			return err
+		}
+		defer close(ctx, deps)
+		opts = append(opts, clusterconfig.WithVSphereClient(deps.Govc))
+	case constants.CloudStackProviderName:
+		if !features.IsActive(features.CloudStackProvider()) {
+			return fmt.Errorf("the cloudstack infrastructure provider is still under development")
+		}
+		execConfig, err := decoder.ParseCloudStackSecret()
+		if err != nil {
+			return fmt.Errorf("reading cloudstack credentials: %v", err)
+		}
+		deps, err := dependencies.NewFactory().WithCmk().Build(ctx)
+		if err != nil {
+			return err
+		}
+		defer close(ctx, deps)
+		profiles := make([]string, 0, len(execConfig.Profiles))
+		for _, profile := range execConfig.Profiles {
+			profiles = append(profiles, profile.Name)
+		}
+		opts = append(opts, clusterconfig.WithCloudStackClient(deps.Cmk, profiles))
+	case constants.SnowProviderName:
+		if !features.IsActive(features.SnowProvider()) {
+			return fmt.Errorf("the snow infrastructure provider is still under development")
+		}
+	}
+
+	config, err := clusterconfig.NewGenerator(prompter, opts...).Generate(ctx, clusterName, provider)
 	if err != nil {
-		log.Fatalf("marking flag as required: %v", err)
+		return fmt.Errorf("generating eks-a cluster config: %v", err)
 	}
+
+	fmt.Println(string(config))
+	return nil
+}
+
+// generateSupportedProviders returns the providers a cluster config can be generated for interactively.
+func generateSupportedProviders() []string {
+	supported := []string{constants.DockerProviderName, constants.VSphereProviderName, constants.TinkerbellProviderName}
+	if features.IsActive(features.CloudStackProvider()) {
+		supported = append(supported, constants.CloudStackProviderName)
+	}
+	if features.IsActive(features.SnowProvider()) {
+		supported = append(supported, constants.SnowProviderName)
+	}
+
+	return supported
 }
 
 func generateClusterConfig(clusterName string) error {
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type generateTinkerbellTemplateConfigOptions struct {
+	fileName              string
+	hardwareCSVPath       string
+	machineConfig         string
+	osFamily              string
+	tinkerbellBootstrapIP string
+	outputPath            string
+}
+
+var gttcOpts = &generateTinkerbellTemplateConfigOptions{}
+
+var generateTinkerbellTemplateConfigCmd = &cobra.Command{
+	Use:   "tinkerbelltemplateconfig -f <cluster-config-file> -z <hardware-csv> [flags]",
+	Short: "Generate TinkerbellTemplateConfig",
+	Long: `
+Generate the default TinkerbellTemplateConfig of the Tinkerbell machine configs in the cluster config,
+rendered with the disks and network layout of the hardware matching their hardware selectors.
+`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         gttcOpts.generateTinkerbellTemplateConfig,
+}
+
+func init() {
+	generateCmd.AddCommand(generateTinkerbellTemplateConfigCmd)
+
+	flags := generateTinkerbellTemplateConfigCmd.Flags()
+	flags.StringVarP(&gttcOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	applyTinkerbellHardwareFlag(flags, &gttcOpts.hardwareCSVPath)
+	flags.StringVar(&gttcOpts.machineConfig, "machine-config", "", "Name of the TinkerbellMachineConfig to generate the template for. Defaults to all machine configs used by the cluster")
+	flags.StringVar(&gttcOpts.osFamily, "os-family", "", "Override the OS family of the machine configs: ubuntu, redhat or bottlerocket")
+	flags.StringVar(&gttcOpts.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
+	flags.StringVarP(&gttcOpts.outputPath, "output", "o", "", "Path to output the TinkerbellTemplateConfig YAML. Defaults to stdout")
+
+	for _, flag := range []string{"filename", TinkerbellHardwareCSVFlagName} {
+		if err := generateTinkerbellTemplateConfigCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (o *generateTinkerbellTemplateConfigOptions) generateTinkerbellTemplateConfig(cmd *cobra.Command, _ []string) error {
+	osFamily := v1alpha1.OSFamily(o.osFamily)
+	switch osFamily {
+	case "", v1alpha1.Ubuntu, v1alpha1.RedHat, v1alpha1.Bottlerocket:
+	default:
+		return fmt.Errorf("unsupported os family %s, use one of: %s, %s, %s", o.osFamily, v1alpha1.Ubuntu, v1alpha1.RedHat, v1alpha1.Bottlerocket)
+	}
+
+	spec, err := newTinkerbellClusterSpec(o.fileName)
+	if err != nil {
+		return err
+	}
+
+	machines, err := readTinkerbellHardware(o.hardwareCSVPath)
+	if err != nil {
+		return err
+	}
+
+	tinkerbellIP := o.tinkerbellBootstrapIP
+	if tinkerbellIP == "" {
+		localIP, err := networkutils.GetLocalIP()
+		if err != nil {
+			return err
+		}
+		tinkerbellIP = localIP.String()
+	}
+
+	machineConfigs, err := o.machineConfigs(spec)
+	if err != nil {
+		return err
+	}
+
+	resources := make([][]byte, 0, len(machineConfigs))
+	for _, machineConfig := range machineConfigs {
+		if osFamily != "" {
+			machineConfig.Spec.OSFamily = osFamily
+		}
+
+		templateConfig, err := tinkerbell.NewDefaultTemplateConfig(spec, machineConfig, machines, tinkerbellIP)
+		if err != nil {
+			return err
+		}
+
+		resource, err := yaml.Marshal(templateConfig.ConvertConfigToConfigGenerateStruct())
+		if err != nil {
+			return fmt.Errorf("generating tinkerbell template config yaml: %v", err)
+		}
+		resources = append(resources, resource)
+	}
+
+	content := templater.AppendYamlResources(resources...)
+	if o.outputPath == "" {
+		fmt.Print(string(content))
+		return nil
+	}
+
+	return os.WriteFile(o.outputPath, content, 0o644)
+}
+
+// machineConfigs returns the machine config selected with the machine-config flag or, when not set,
+// the machine configs used by the control plane, the external etcd and the worker node groups.
+func (o *generateTinkerbellTemplateConfigOptions) machineConfigs(spec *tinkerbell.ClusterSpec) ([]*v1alpha1.TinkerbellMachineConfig, error) {
+	if o.machineConfig != "" {
+		machineConfig, ok := spec.MachineConfigs[o.machineConfig]
+		if !ok {
+			return nil, fmt.Errorf("TinkerbellMachineConfig %s not found in %s", o.machineConfig, o.fileName)
+		}
+		return []*v1alpha1.TinkerbellMachineConfig{machineConfig}, nil
+	}
+
+	machineConfigs := []*v1alpha1.TinkerbellMachineConfig{spec.ControlPlaneMachineConfig()}
+	if spec.HasExternalEtcd() {
+		machineConfigs = append(machineConfigs, spec.ExternalEtcdMachineConfig())
+	}
+	for _, group := range spec.WorkerNodeGroupConfigurations() {
+		machineConfigs = append(machineConfigs, spec.WorkerNodeGroupMachineConfig(group))
+	}
+
+	var used []*v1alpha1.TinkerbellMachineConfig
+	seen := map[string]bool{}
+	for _, machineConfig := range machineConfigs {
+		if machineConfig == nil || seen[machineConfig.Name] {
+			continue
+		}
+		seen[machineConfig.Name] = true
+		used = append(used, machineConfig)
+	}
+
+	return used, nil
+}
+
+func newTinkerbellClusterSpec(fileName string) (*tinkerbell.ClusterSpec, error) {
+	clusterSpec, err := cluster.NewSpecFromClusterConfig(fileName, version.Get())
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterSpec.Cluster.Spec.DatacenterRef.Kind != v1alpha1.TinkerbellDatacenterKind {
+		return nil, fmt.Errorf("cluster %s doesn't use the tinkerbell provider", clusterSpec.Cluster.Name)
+	}
+
+	datacenterConfig, err := v1alpha1.GetTinkerbellDatacenterConfig(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", fileName, err)
+	}
+
+	machineConfigs, err := v1alpha1.GetTinkerbellMachineConfigs(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get machine config from file %s: %v", fileName, err)
+	}
+
+	return tinkerbell.NewClusterSpec(clusterSpec, machineConfigs, datacenterConfig), nil
+}
+
+func readTinkerbellHardware(path string) ([]hardware.Machine, error) {
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("csv: %v", err)
+	}
+
+	machines, err := hardware.SelectMachines(reader, nil, labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("csv: %v", err)
+	}
+
+	return machines, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+var hardwareCmd = &cobra.Command{
+	Use:   "hardware",
+	Short: "Manage bare metal hardware",
+	Long:  "Use eksctl anywhere hardware to manage the power and boot of bare metal machines through their BMC",
+}
+
+func init() {
+	rootCmd.AddCommand(hardwareCmd)
+}
+
+// hardwareSelectionOptions selects machines from a hardware CSV by hostname or label selector.
+type hardwareSelectionOptions struct {
+	csvPath   string
+	hostnames []string
+	selector  string
+}
+
+func applyHardwareSelectionFlags(cmd *cobra.Command, opts *hardwareSelectionOptions) {
+	flags := cmd.Flags()
+	applyTinkerbellHardwareFlag(flags, &opts.csvPath)
+	flags.StringSliceVar(&opts.hostnames, "hostnames", nil, "Comma separated hostnames of the machines to act on")
+	flags.StringVarP(&opts.selector, "selector", "l", "", "Label selector of the machines to act on, e.g. type=worker")
+
+	if err := cmd.MarkFlagRequired(TinkerbellHardwareCSVFlagName); err != nil {
+		panic(err)
+	}
+}
+
+// selectMachines returns the machines of the hardware CSV matching the hostnames or the selector.
+// Every selected machine must have a BMC.
+func (opts *hardwareSelectionOptions) selectMachines() ([]hardware.Machine, error) {
+	if len(opts.hostnames) == 0 && opts.selector == "" {
+		return nil, fmt.Errorf("at least one of --hostnames or --selector is required")
+	}
+
+	var selector labels.Selector
+	if opts.selector != "" {
+		var err error
+		selector, err = labels.Parse(opts.selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing selector: %v", err)
+		}
+	}
+
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(opts.csvPath)
+	if err != nil {
+		return nil, err
+	}
+
+	machines, err := hardware.SelectMachines(reader, opts.hostnames, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(machines) == 0 {
+		return nil, fmt.Errorf("no hardware matches selector %s", opts.selector)
+	}
+
+	for _, m := range machines {
+		if !m.HasBMC() {
+			return nil, fmt.Errorf("hardware %s has no BMC configuration", m.Hostname)
+		}
+	}
+
+	return machines, nil
+}
+
+func bmcConnection(m hardware.Machine) bmc.Connection {
+	return bmc.Connection{
+		Host:     m.BMCIPAddress,
+		Username: m.BMCUsername,
+		Password: m.BMCPassword,
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+var hardwarePowerCmd = &cobra.Command{
+	Use:   "power",
+	Short: "Manage the power of bare metal machines",
+	Long:  "Use eksctl anywhere hardware power to power on, power off, restart or get the power state of machines through their BMC",
+}
+
+type powerOperation func(m *bmc.Manager, ctx context.Context, c bmc.Connection) error
+
+func init() {
+	hardwareCmd.AddCommand(hardwarePowerCmd)
+
+	hardwarePowerCmd.AddCommand(newHardwarePowerCmd("on", "Power on machines", (*bmc.Manager).PowerOn))
+	hardwarePowerCmd.AddCommand(newHardwarePowerCmd("off", "Power off machines", (*bmc.Manager).PowerOff))
+	hardwarePowerCmd.AddCommand(newHardwarePowerCmd("cycle", "Restart machines, powering on the ones that are off", (*bmc.Manager).PowerCycle))
+
+	statusOpts := &hardwareSelectionOptions{}
+	statusCmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Get the power state of machines",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return powerStatus(cmd.Context(), statusOpts)
+		},
+	}
+	applyHardwareSelectionFlags(statusCmd, statusOpts)
+	hardwarePowerCmd.AddCommand(statusCmd)
+}
+
+func newHardwarePowerCmd(use, short string, op powerOperation) *cobra.Command {
+	opts := &hardwareSelectionOptions{}
+	cmd := &cobra.Command{
+		Use:          use,
+		Short:        short,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			machines, err := opts.selectMachines()
+			if err != nil {
+				return err
+			}
+			return forEachMachine(machines, func(m hardware.Machine) error {
+				if err := op(bmc.NewManager(), cmd.Context(), bmcConnection(m)); err != nil {
+					return err
+				}
+				logger.Info("Power operation succeeded", "operation", use, "hardware", m.Hostname)
+				return nil
+			})
+		},
+	}
+	applyHardwareSelectionFlags(cmd, opts)
+	return cmd
+}
+
+func powerStatus(ctx context.Context, opts *hardwareSelectionOptions) error {
+	machines, err := opts.selectMachines()
+	if err != nil {
+		return err
+	}
+
+	manager := bmc.NewManager()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "HOSTNAME\tBMC\tPOWER")
+	err = forEachMachine(machines, func(m hardware.Machine) error {
+		state, err := manager.PowerStatus(ctx, bmcConnection(m))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", m.Hostname, m.BMCIPAddress, state)
+		return nil
+	})
+	if flushErr := w.Flush(); flushErr != nil && err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// forEachMachine runs fn for every machine, carrying on after failures so a single unreachable BMC
+// doesn't stop the operation on the rest of the machines.
+func forEachMachine(machines []hardware.Machine, fn func(hardware.Machine) error) error {
+	var failed []string
+	for _, m := range machines {
+		if err := fn(m); err != nil {
+			logger.Info("Error: hardware operation failed", "hardware", m.Hostname, "error", err)
+			failed = append(failed, m.Hostname)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("operation failed for hardware: %v", failed)
+	}
+	return nil
+}
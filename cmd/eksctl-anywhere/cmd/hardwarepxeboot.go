@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type pxeBootOptions struct {
+	hardwareSelectionOptions
+	efiBoot bool
+}
+
+var pbOpts = &pxeBootOptions{}
+
+var hardwarePXEBootCmd = &cobra.Command{
+	Use:          "pxe-boot",
+	Short:        "Network boot machines",
+	Long:         "Use eksctl anywhere hardware pxe-boot to set machines to boot from the network on their next boot and restart them",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pbOpts.pxeBoot(cmd.Context())
+	},
+}
+
+func init() {
+	hardwareCmd.AddCommand(hardwarePXEBootCmd)
+	applyHardwareSelectionFlags(hardwarePXEBootCmd, &pbOpts.hardwareSelectionOptions)
+	hardwarePXEBootCmd.Flags().BoolVar(&pbOpts.efiBoot, "efi-boot", false, "Boot the machines in EFI mode")
+}
+
+func (opts *pxeBootOptions) pxeBoot(ctx context.Context) error {
+	machines, err := opts.selectMachines()
+	if err != nil {
+		return err
+	}
+
+	manager := bmc.NewManager()
+	return forEachMachine(machines, func(m hardware.Machine) error {
+		if err := manager.PXEBoot(ctx, bmcConnection(m), opts.efiBoot); err != nil {
+			return err
+		}
+		logger.Info("Machine set to PXE boot", "hardware", m.Hostname)
+		return nil
+	})
+}
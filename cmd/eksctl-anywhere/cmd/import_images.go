@@ -14,7 +14,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/docker"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/helm"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 )
 
 // imagesCmd represents the images command
@@ -73,7 +72,7 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 		return err
 	}
 
-	bundle, err := bundles.Read(deps.ManifestReader, c.BundlesFile)
+	bundle, err := deps.ManifestReader.ReadBundles(c.BundlesFile)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,112 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type PackageReader interface {
+	ReadBundlesForVersion(eksaVersion string) (*releasev1.Bundles, error)
+	ReadPackageImagesFromBundles(ctx context.Context, bundles *releasev1.Bundles) ([]releasev1.Image, error)
+	ReadPackageChartsFromBundles(ctx context.Context, bundles *releasev1.Bundles) ([]releasev1.Image, error)
+}
+
+// CopyPackages copies the curated packages bundles, with all their images and charts, and the package controller
+// from their original registries to a private registry, so packages can be installed in disconnected environments.
+type CopyPackages struct {
+	Reader             PackageReader
+	Version            version.Info
+	KubeVersions       []string
+	ImageMover         ImageMover
+	ChartDownloader    ChartDownloader
+	ChartImporter      ChartImporter
+	BundleDownloader   ManifestDownloader
+	BundleImporter     FileImporter
+	TmpArtifactsFolder string
+}
+
+func (c CopyPackages) Run(ctx context.Context) error {
+	if err := os.MkdirAll(c.TmpArtifactsFolder, os.ModePerm); err != nil {
+		return fmt.Errorf("creating tmp artifact folder: %v", err)
+	}
+
+	b, err := c.Reader.ReadBundlesForVersion(c.Version.GitVersion)
+	if err != nil {
+		return fmt.Errorf("reading bundles: %v", err)
+	}
+
+	b, err = bundlesForKubeVersions(b, c.KubeVersions)
+	if err != nil {
+		return err
+	}
+
+	images, err := c.Reader.ReadPackageImagesFromBundles(ctx, b)
+	if err != nil {
+		return fmt.Errorf("reading package images: %v", err)
+	}
+
+	charts, err := c.Reader.ReadPackageChartsFromBundles(ctx, b)
+	if err != nil {
+		return fmt.Errorf("reading package charts: %v", err)
+	}
+
+	logger.Info("Copying curated packages images", "images", len(images))
+	if err = c.ImageMover.Move(ctx, artifactNames(images)...); err != nil {
+		return err
+	}
+
+	logger.Info("Copying curated packages charts", "charts", len(charts))
+	if err = c.ChartDownloader.Download(ctx, artifactNames(charts)...); err != nil {
+		return err
+	}
+
+	if err = c.ChartImporter.Import(ctx, artifactNames(charts)...); err != nil {
+		return err
+	}
+
+	logger.Info("Copying curated packages bundles")
+	if err = c.BundleDownloader.Download(ctx, b); err != nil {
+		return fmt.Errorf("downloading curated packages bundles: %v", err)
+	}
+
+	if err = c.BundleImporter.Push(ctx, b); err != nil {
+		return fmt.Errorf("pushing curated packages bundles: %v", err)
+	}
+
+	if err := os.RemoveAll(c.TmpArtifactsFolder); err != nil {
+		return fmt.Errorf("deleting tmp artifact folder: %v", err)
+	}
+
+	return nil
+}
+
+// bundlesForKubeVersions returns a copy of b only including the VersionsBundles for kubeVersions.
+// If kubeVersions is empty, all the VersionsBundles are included.
+func bundlesForKubeVersions(b *releasev1.Bundles, kubeVersions []string) (*releasev1.Bundles, error) {
+	if len(kubeVersions) == 0 {
+		return b, nil
+	}
+
+	filtered := b.DeepCopy()
+	filtered.Spec.VersionsBundles = nil
+	for _, kubeVersion := range kubeVersions {
+		found := false
+		for _, vb := range b.Spec.VersionsBundles {
+			if vb.KubeVersion == kubeVersion {
+				filtered.Spec.VersionsBundles = append(filtered.Spec.VersionsBundles, vb)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("kubernetes version %s is not supported by bundles manifest %d", kubeVersion, b.Spec.Number)
+		}
+	}
+
+	return filtered, nil
+}
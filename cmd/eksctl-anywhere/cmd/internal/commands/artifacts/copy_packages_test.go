@@ -0,0 +1,178 @@
+package artifacts_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts/mocks"
+	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type copyPackagesTest struct {
+	*WithT
+	ctx              context.Context
+	reader           *mocks.MockPackageReader
+	mover            *mocks.MockImageMover
+	chartDownloader  *mocks.MockChartDownloader
+	chartImporter    *mocks.MockChartImporter
+	bundleDownloader *mocks.MockManifestDownloader
+	bundleImporter   *mocks.MockFileImporter
+	command          *artifacts.CopyPackages
+	images, charts   []releasev1.Image
+	bundles          *releasev1.Bundles
+	version          version.Info
+}
+
+func newCopyPackagesTest(t *testing.T) *copyPackagesTest {
+	tmpFolder := "tmp-folder"
+	t.Cleanup(func() {
+		os.RemoveAll(tmpFolder)
+	})
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockPackageReader(ctrl)
+	mover := mocks.NewMockImageMover(ctrl)
+	chartDownloader := mocks.NewMockChartDownloader(ctrl)
+	chartImporter := mocks.NewMockChartImporter(ctrl)
+	bundleDownloader := mocks.NewMockManifestDownloader(ctrl)
+	bundleImporter := mocks.NewMockFileImporter(ctrl)
+	v := version.Info{GitVersion: "v1.0.0"}
+
+	return &copyPackagesTest{
+		WithT:            NewWithT(t),
+		ctx:              context.Background(),
+		reader:           reader,
+		mover:            mover,
+		chartDownloader:  chartDownloader,
+		chartImporter:    chartImporter,
+		bundleDownloader: bundleDownloader,
+		bundleImporter:   bundleImporter,
+		version:          v,
+		images: []releasev1.Image{
+			{
+				Name: "package controller",
+				URI:  "public.ecr.aws/eks-anywhere/eks-anywhere-packages:v0.2.0",
+			},
+			{
+				Name: "harbor",
+				URI:  "783794618700.dkr.ecr.us-west-2.amazonaws.com/harbor/harbor-core@sha256:abc",
+			},
+		},
+		charts: []releasev1.Image{
+			{
+				Name: "package controller chart",
+				URI:  "public.ecr.aws/eks-anywhere/eks-anywhere-packages:0.2.0",
+			},
+		},
+		bundles: &releasev1.Bundles{
+			Spec: releasev1.BundlesSpec{
+				Number: 10,
+				VersionsBundles: []releasev1.VersionsBundle{
+					{KubeVersion: "1.22"},
+					{KubeVersion: "1.23"},
+				},
+			},
+		},
+		command: &artifacts.CopyPackages{
+			Reader:             reader,
+			Version:            v,
+			ImageMover:         mover,
+			ChartDownloader:    chartDownloader,
+			ChartImporter:      chartImporter,
+			BundleDownloader:   bundleDownloader,
+			BundleImporter:     bundleImporter,
+			TmpArtifactsFolder: tmpFolder,
+		},
+	}
+}
+
+func TestCopyPackagesRunAllKubeVersions(t *testing.T) {
+	tt := newCopyPackagesTest(t)
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	tt.reader.EXPECT().ReadPackageImagesFromBundles(tt.ctx, tt.bundles).Return(tt.images, nil)
+	tt.reader.EXPECT().ReadPackageChartsFromBundles(tt.ctx, tt.bundles).Return(tt.charts, nil)
+	tt.mover.EXPECT().Move(tt.ctx, "public.ecr.aws/eks-anywhere/eks-anywhere-packages:v0.2.0", "783794618700.dkr.ecr.us-west-2.amazonaws.com/harbor/harbor-core@sha256:abc")
+	tt.chartDownloader.EXPECT().Download(tt.ctx, "public.ecr.aws/eks-anywhere/eks-anywhere-packages:0.2.0")
+	tt.chartImporter.EXPECT().Import(tt.ctx, "public.ecr.aws/eks-anywhere/eks-anywhere-packages:0.2.0")
+	tt.bundleDownloader.EXPECT().Download(tt.ctx, tt.bundles)
+	tt.bundleImporter.EXPECT().Push(tt.ctx, tt.bundles)
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(Succeed())
+}
+
+func TestCopyPackagesRunSelectedKubeVersions(t *testing.T) {
+	tt := newCopyPackagesTest(t)
+	tt.command.KubeVersions = []string{"1.23"}
+	wantBundles := tt.bundles.DeepCopy()
+	wantBundles.Spec.VersionsBundles = []releasev1.VersionsBundle{{KubeVersion: "1.23"}}
+
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	tt.reader.EXPECT().ReadPackageImagesFromBundles(tt.ctx, wantBundles).Return(tt.images, nil)
+	tt.reader.EXPECT().ReadPackageChartsFromBundles(tt.ctx, wantBundles).Return(tt.charts, nil)
+	tt.mover.EXPECT().Move(tt.ctx, gomock.Any(), gomock.Any())
+	tt.chartDownloader.EXPECT().Download(tt.ctx, gomock.Any())
+	tt.chartImporter.EXPECT().Import(tt.ctx, gomock.Any())
+	tt.bundleDownloader.EXPECT().Download(tt.ctx, wantBundles)
+	tt.bundleImporter.EXPECT().Push(tt.ctx, wantBundles)
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(Succeed())
+}
+
+func TestCopyPackagesRunUnsupportedKubeVersion(t *testing.T) {
+	tt := newCopyPackagesTest(t)
+	tt.command.KubeVersions = []string{"1.23", "1.19"}
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("kubernetes version 1.19 is not supported by bundles manifest 10"))
+}
+
+func TestCopyPackagesRunErrorReadingImages(t *testing.T) {
+	tt := newCopyPackagesTest(t)
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	tt.reader.EXPECT().ReadPackageImagesFromBundles(tt.ctx, tt.bundles).Return(nil, errors.New("error pulling bundle"))
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("reading package images: error pulling bundle"))
+}
+
+func TestCopyPackagesRunErrorMovingImages(t *testing.T) {
+	tt := newCopyPackagesTest(t)
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	tt.reader.EXPECT().ReadPackageImagesFromBundles(tt.ctx, tt.bundles).Return(tt.images, nil)
+	tt.reader.EXPECT().ReadPackageChartsFromBundles(tt.ctx, tt.bundles).Return(tt.charts, nil)
+	tt.mover.EXPECT().Move(tt.ctx, gomock.Any(), gomock.Any()).Return(errors.New("error pushing images"))
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("error pushing images"))
+}
+
+func TestCopyPackagesRunErrorDownloadingBundles(t *testing.T) {
+	tt := newCopyPackagesTest(t)
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	tt.reader.EXPECT().ReadPackageImagesFromBundles(tt.ctx, tt.bundles).Return(tt.images, nil)
+	tt.reader.EXPECT().ReadPackageChartsFromBundles(tt.ctx, tt.bundles).Return(tt.charts, nil)
+	tt.mover.EXPECT().Move(tt.ctx, gomock.Any(), gomock.Any())
+	tt.chartDownloader.EXPECT().Download(tt.ctx, gomock.Any())
+	tt.chartImporter.EXPECT().Import(tt.ctx, gomock.Any())
+	tt.bundleDownloader.EXPECT().Download(tt.ctx, tt.bundles).Return(errors.New("error pulling bundle"))
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("downloading curated packages bundles: error pulling bundle"))
+}
+
+func TestCopyPackagesRunErrorPushingBundles(t *testing.T) {
+	tt := newCopyPackagesTest(t)
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	tt.reader.EXPECT().ReadPackageImagesFromBundles(tt.ctx, tt.bundles).Return(tt.images, nil)
+	tt.reader.EXPECT().ReadPackageChartsFromBundles(tt.ctx, tt.bundles).Return(tt.charts, nil)
+	tt.mover.EXPECT().Move(tt.ctx, gomock.Any(), gomock.Any())
+	tt.chartDownloader.EXPECT().Download(tt.ctx, gomock.Any())
+	tt.chartImporter.EXPECT().Import(tt.ctx, gomock.Any())
+	tt.bundleDownloader.EXPECT().Download(tt.ctx, tt.bundles)
+	tt.bundleImporter.EXPECT().Push(tt.ctx, tt.bundles).Return(errors.New("error pushing bundle"))
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("pushing curated packages bundles: error pushing bundle"))
+}
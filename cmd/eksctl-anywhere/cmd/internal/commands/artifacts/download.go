@@ -25,7 +25,7 @@ type ChartDownloader interface {
 }
 
 type ManifestDownloader interface {
-	Download(ctx context.Context, bundles *releasev1.Bundles)
+	Download(ctx context.Context, bundles *releasev1.Bundles) error
 }
 
 type Packager interface {
@@ -69,7 +69,9 @@ func (d Download) Run(ctx context.Context) error {
 
 	charts := d.Reader.ReadChartsFromBundles(ctx, b)
 
-	d.ManifestDownloader.Download(ctx, b)
+	if err := d.ManifestDownloader.Download(ctx, b); err != nil {
+		logger.Info("Warning: downloading curated packages bundles", "error", err)
+	}
 
 	if err := d.ChartDownloader.Download(ctx, artifactNames(charts)...); err != nil {
 		return err
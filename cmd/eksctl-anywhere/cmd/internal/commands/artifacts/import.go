@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/aws/eks-anywhere/pkg/logger"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -22,7 +23,7 @@ type ChartImporter interface {
 }
 
 type FileImporter interface {
-	Push(ctx context.Context, bundles *releasev1.Bundles)
+	Push(ctx context.Context, bundles *releasev1.Bundles) error
 }
 
 func (i Import) Run(ctx context.Context) error {
@@ -41,7 +42,9 @@ func (i Import) Run(ctx context.Context) error {
 		return err
 	}
 
-	i.FileImporter.Push(ctx, i.Bundles)
+	if err := i.FileImporter.Push(ctx, i.Bundles); err != nil {
+		logger.Info("Warning: importing curated packages bundles", "error", err)
+	}
 
 	if err := os.RemoveAll(i.TmpArtifactsFolder); err != nil {
 		return fmt.Errorf("deleting tmp artifact import folder: %v", err)
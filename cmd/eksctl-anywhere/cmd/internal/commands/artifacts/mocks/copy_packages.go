@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cmd/eksctl-anywhere/cmd/internal/commands/artifacts/copy_packages.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPackageReader is a mock of PackageReader interface.
+type MockPackageReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPackageReaderMockRecorder
+}
+
+// MockPackageReaderMockRecorder is the mock recorder for MockPackageReader.
+type MockPackageReaderMockRecorder struct {
+	mock *MockPackageReader
+}
+
+// NewMockPackageReader creates a new mock instance.
+func NewMockPackageReader(ctrl *gomock.Controller) *MockPackageReader {
+	mock := &MockPackageReader{ctrl: ctrl}
+	mock.recorder = &MockPackageReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPackageReader) EXPECT() *MockPackageReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadBundlesForVersion mocks base method.
+func (m *MockPackageReader) ReadBundlesForVersion(eksaVersion string) (*v1alpha1.Bundles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBundlesForVersion", eksaVersion)
+	ret0, _ := ret[0].(*v1alpha1.Bundles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBundlesForVersion indicates an expected call of ReadBundlesForVersion.
+func (mr *MockPackageReaderMockRecorder) ReadBundlesForVersion(eksaVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBundlesForVersion", reflect.TypeOf((*MockPackageReader)(nil).ReadBundlesForVersion), eksaVersion)
+}
+
+// ReadPackageChartsFromBundles mocks base method.
+func (m *MockPackageReader) ReadPackageChartsFromBundles(ctx context.Context, bundles *v1alpha1.Bundles) ([]v1alpha1.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPackageChartsFromBundles", ctx, bundles)
+	ret0, _ := ret[0].([]v1alpha1.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPackageChartsFromBundles indicates an expected call of ReadPackageChartsFromBundles.
+func (mr *MockPackageReaderMockRecorder) ReadPackageChartsFromBundles(ctx, bundles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPackageChartsFromBundles", reflect.TypeOf((*MockPackageReader)(nil).ReadPackageChartsFromBundles), ctx, bundles)
+}
+
+// ReadPackageImagesFromBundles mocks base method.
+func (m *MockPackageReader) ReadPackageImagesFromBundles(ctx context.Context, bundles *v1alpha1.Bundles) ([]v1alpha1.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPackageImagesFromBundles", ctx, bundles)
+	ret0, _ := ret[0].([]v1alpha1.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPackageImagesFromBundles indicates an expected call of ReadPackageImagesFromBundles.
+func (mr *MockPackageReaderMockRecorder) ReadPackageImagesFromBundles(ctx, bundles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPackageImagesFromBundles", reflect.TypeOf((*MockPackageReader)(nil).ReadPackageImagesFromBundles), ctx, bundles)
+}
@@ -187,9 +187,11 @@ func (m *MockManifestDownloader) EXPECT() *MockManifestDownloaderMockRecorder {
 }
 
 // Download mocks base method.
-func (m *MockManifestDownloader) Download(ctx context.Context, bundles *v1alpha1.Bundles) {
+func (m *MockManifestDownloader) Download(ctx context.Context, bundles *v1alpha1.Bundles) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Download", ctx, bundles)
+	ret := m.ctrl.Call(m, "Download", ctx, bundles)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Download indicates an expected call of Download.
@@ -78,9 +78,11 @@ func (m *MockFileImporter) EXPECT() *MockFileImporterMockRecorder {
 }
 
 // Push mocks base method.
-func (m *MockFileImporter) Push(ctx context.Context, bundles *v1alpha1.Bundles) {
+func (m *MockFileImporter) Push(ctx context.Context, bundles *v1alpha1.Bundles) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Push", ctx, bundles)
+	ret := m.ctrl.Call(m, "Push", ctx, bundles)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Push indicates an expected call of Push.
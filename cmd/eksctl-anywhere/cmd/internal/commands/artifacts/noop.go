@@ -8,6 +8,10 @@ import (
 
 type Noop struct{}
 
-func (*Noop) Download(ctx context.Context, bundles *releasev1.Bundles) {}
+func (*Noop) Download(ctx context.Context, bundles *releasev1.Bundles) error {
+	return nil
+}
 
-func (*Noop) Push(ctx context.Context, bundles *releasev1.Bundles) {}
+func (*Noop) Push(ctx context.Context, bundles *releasev1.Bundles) error {
+	return nil
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
@@ -62,6 +64,76 @@ func buildClusterManagerOpts(t timeoutOptions) ([]clustermanager.ClusterManagerO
 	}, nil
 }
 
+type validationRunOptions struct {
+	output          string
+	outputFile      string
+	skipValidations []string
+}
+
+func applyValidationRunFlags(flagSet *pflag.FlagSet, v *validationRunOptions) {
+	flagSet.StringVar(&v.output, "output", "", fmt.Sprintf("Output a report of the validation results in the given format %v", validations.ReportFormats))
+	flagSet.StringVar(&v.outputFile, "output-file", "", "File to write the validation report to instead of stdout")
+	flagSet.StringSliceVar(&v.skipValidations, "skip-validations", nil, fmt.Sprintf("Comma separated list of IDs of validations to skip %v", validations.OverridableIDs()))
+}
+
+// overrides returns the validation overrides for the skipped validations and the validations
+// the cluster config downgrades to warnings. It fails if they refer to validations that can't be
+// overridden.
+func (v validationRunOptions) overrides(cluster *v1alpha1.Cluster) (validations.Overrides, error) {
+	overrides := validations.Overrides{
+		Skip: v.skipValidations,
+		Warn: cluster.ValidationWarnings(),
+	}
+	if err := overrides.Validate(); err != nil {
+		return validations.Overrides{}, err
+	}
+	return overrides, nil
+}
+
+// newReport returns a report to record validation results in, or nil if no report was requested.
+func (v validationRunOptions) newReport() (*validations.Report, error) {
+	if v.output == "" {
+		if v.outputFile != "" {
+			return nil, fmt.Errorf("--output-file requires --output")
+		}
+		return nil, nil
+	}
+
+	if _, err := validations.ParseReportFormat(v.output); err != nil {
+		return nil, err
+	}
+
+	return validations.NewReport(), nil
+}
+
+// writeReport writes report in the requested format. It's a noop if report is nil.
+func (v validationRunOptions) writeReport(report *validations.Report) error {
+	if report == nil {
+		return nil
+	}
+
+	format, err := validations.ParseReportFormat(v.output)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if v.outputFile != "" {
+		f, err := os.Create(v.outputFile)
+		if err != nil {
+			return fmt.Errorf("creating validation report file: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := report.Write(w, format); err != nil {
+		return fmt.Errorf("writing validation report: %v", err)
+	}
+
+	return nil
+}
+
 type clusterOptions struct {
 	fileName             string
 	bundlesOverride      string
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type rollbackPackagesOptions struct {
+	// kubeConfig is an optional kubeconfig file to use when querying an
+	// existing cluster.
+	kubeConfig  string
+	clusterName string
+	waitTimeout time.Duration
+}
+
+var rpo = &rollbackPackagesOptions{}
+
+func init() {
+	rollbackCmd.AddCommand(rollbackPackagesCommand)
+
+	rollbackPackagesCommand.Flags().StringVar(&rpo.kubeConfig, "kubeconfig",
+		"", "Path to an optional kubeconfig file to use.")
+	rollbackPackagesCommand.Flags().StringVar(&rpo.clusterName, "cluster",
+		"", "Cluster to rollback the packages for.")
+	rollbackPackagesCommand.Flags().DurationVar(&rpo.waitTimeout, "wait-timeout",
+		10*time.Minute, "Time to wait for the packages to converge to the previous bundle")
+
+	err := rollbackPackagesCommand.MarkFlagRequired("cluster")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+var rollbackPackagesCommand = &cobra.Command{
+	Use:          "packages",
+	Short:        "Rollback all curated packages to the previous bundle",
+	Long:         "Sets the active package bundle back to the one active before the last packages upgrade and waits for the packages to converge",
+	PreRunE:      preRunPackages,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rollbackPackages(cmd.Context()); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func rollbackPackages(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(rpo.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	b := curatedpackages.NewBundleReader(
+		kubeConfig,
+		rpo.clusterName,
+		curatedpackages.Cluster,
+		deps.Kubectl,
+		nil,
+		nil,
+	)
+	activeController, err := b.GetActiveController(ctx)
+	if err != nil {
+		return err
+	}
+	bundleName, err := b.RollbackBundle(ctx, activeController)
+	if err != nil {
+		return err
+	}
+	logger.Info("Package bundle rolled back", "bundle", bundleName)
+
+	bundle, err := b.GetPackageBundle(ctx, bundleName)
+	if err != nil {
+		return err
+	}
+
+	packages := curatedpackages.NewPackageClient(deps.Kubectl)
+	if err := packages.WaitForPackagesConverged(ctx, bundle, kubeConfig, rpo.clusterName, rpo.waitTimeout); err != nil {
+		return err
+	}
+	logger.Info("Packages converged", "bundle", bundleName)
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 type upgradeClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	validationRunOptions
 	wConfig               string
 	forceClean            bool
 	hardwareCSVPath       string
@@ -47,6 +48,7 @@ func init() {
 	applyTimeoutFlags(upgradeClusterCmd.Flags(), &uc.timeoutOptions)
 	applyTinkerbellHardwareFlag(upgradeClusterCmd.Flags(), &uc.hardwareCSVPath)
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
+	applyValidationRunFlags(upgradeClusterCmd.Flags(), &uc.validationRunOptions)
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 
 	if err := upgradeClusterCmd.MarkFlagRequired("filename"); err != nil {
@@ -57,6 +59,11 @@ func init() {
 func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 
+	report, err := uc.newReport()
+	if err != nil {
+		return err
+	}
+
 	clusterConfigFileExist := validations.FileExists(uc.fileName)
 	if !clusterConfigFileExist {
 		return fmt.Errorf("the cluster config file %s does not exist", uc.fileName)
@@ -80,7 +87,10 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
-
+	overrides, err := uc.overrides(clusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
 	cliConfig := buildCliConfig(clusterSpec)
 	dirs, err := uc.directoriesToMount(clusterSpec, cliConfig)
 	if err != nil {
@@ -96,13 +106,14 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		WithBootstrapper().
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, clusterManagerOpts...).
-		WithProvider(uc.fileName, clusterSpec.Cluster, cc.skipIpCheck, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP).
+		WithProvider(uc.fileName, clusterSpec.Cluster, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP).
 		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
 		WithWriter().
 		WithCAPIManager().
 		WithEksdUpgrader().
 		WithEksdInstaller().
 		WithKubectl().
+		WithPackageInstaller(clusterSpec, "").
 		Build(ctx)
 	if err != nil {
 		return err
@@ -118,6 +129,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		deps.Writer,
 		deps.EksdUpgrader,
 		deps.EksdInstaller,
+		deps.PackageInstaller,
 	)
 
 	workloadCluster := &types.Cluster{
@@ -132,6 +144,14 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		managementCluster = clusterSpec.ManagementCluster
 	}
 
+	// Keep the validations skipped by previous operations, applying the new spec would drop them otherwise.
+	currentCluster, err := deps.Kubectl.GetEksaCluster(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return fmt.Errorf("getting cluster %s: %v", clusterSpec.Cluster.Name, err)
+	}
+	clusterSpec.Cluster.AddSkippedValidations(currentCluster.SkippedValidations())
+	clusterSpec.Cluster.AddSkippedValidations(overrides.Skip)
+
 	validationOpts := &validations.Opts{
 		Kubectl:           deps.Kubectl,
 		Spec:              clusterSpec,
@@ -139,10 +159,16 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		ManagementCluster: managementCluster,
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
+		Report:            report,
+		Overrides:         overrides,
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
+	upgradeCluster.WithValidationOpts(validationOpts.RunnerOpts()...)
 
 	err = upgradeCluster.Run(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations, uc.forceClean)
+	if writeErr := uc.writeReport(report); writeErr != nil && err == nil {
+		err = writeErr
+	}
 	cleanup(deps, &err)
 	return err
 }
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type upgradePlanPackagesOptions struct {
+	bundleVersion string
+	// kubeConfig is an optional kubeconfig file to use when querying an
+	// existing cluster.
+	kubeConfig  string
+	clusterName string
+}
+
+var uppo = &upgradePlanPackagesOptions{}
+
+func init() {
+	upgradePlanCmd.AddCommand(upgradePlanPackagesCommand)
+
+	upgradePlanPackagesCommand.Flags().StringVar(&uppo.bundleVersion, "bundle-version",
+		"", "Bundle version to plan the upgrade to")
+	upgradePlanPackagesCommand.Flags().StringVar(&uppo.kubeConfig, "kubeconfig",
+		"", "Path to an optional kubeconfig file to use.")
+	upgradePlanPackagesCommand.Flags().StringVar(&uppo.clusterName, "cluster",
+		"", "Cluster to plan the packages upgrade for.")
+
+	err := upgradePlanPackagesCommand.MarkFlagRequired("bundle-version")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+	err = upgradePlanPackagesCommand.MarkFlagRequired("cluster")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+var upgradePlanPackagesCommand = &cobra.Command{
+	Use:          "packages",
+	Short:        "Provides the package versions for the next packages upgrade",
+	Long:         "Provides the current and target versions of every installed curated package when upgrading to a bundle version",
+	PreRunE:      preRunPackages,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := upgradePlanPackages(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to display packages upgrade plan: %v", err)
+		}
+		return nil
+	},
+}
+
+func upgradePlanPackages(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(uppo.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	b := curatedpackages.NewBundleReader(
+		kubeConfig,
+		uppo.clusterName,
+		curatedpackages.Cluster,
+		deps.Kubectl,
+		nil,
+		nil,
+	)
+	activeController, err := b.GetActiveController(ctx)
+	if err != nil {
+		return err
+	}
+	currentBundle, err := b.GetPackageBundle(ctx, activeController.Spec.ActiveBundle)
+	if err != nil {
+		return err
+	}
+	targetBundle, err := b.GetPackageBundle(ctx, uppo.bundleVersion)
+	if err != nil {
+		return err
+	}
+
+	packages := curatedpackages.NewPackageClient(deps.Kubectl)
+	installed, err := packages.GetInstalledPackages(ctx, kubeConfig, uppo.clusterName)
+	if err != nil {
+		return err
+	}
+
+	plan := curatedpackages.PlanPackagesUpgrade(installed, currentBundle, targetBundle)
+	return curatedpackages.DisplayPackagesUpgradePlan(os.Stdout, plan)
+}
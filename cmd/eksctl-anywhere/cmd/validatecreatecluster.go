@@ -19,6 +19,7 @@ import (
 
 type validateOptions struct {
 	clusterOptions
+	validationRunOptions
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 }
@@ -39,6 +40,7 @@ func init() {
 	applyTinkerbellHardwareFlag(validateCreateClusterCmd.Flags(), &valOpt.hardwareCSVPath)
 	validateCreateClusterCmd.Flags().StringVarP(&valOpt.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
 	validateCreateClusterCmd.Flags().StringVar(&valOpt.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
+	applyValidationRunFlags(validateCreateClusterCmd.Flags(), &valOpt.validationRunOptions)
 
 	if err := validateCreateClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -48,6 +50,11 @@ func init() {
 func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
+	report, err := valOpt.newReport()
+	if err != nil {
+		return err
+	}
+
 	clusterSpec, err := cluster.NewSpecFromClusterConfig(valOpt.fileName, version.Get())
 	if err != nil {
 		return err
@@ -59,6 +66,11 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 		}
 	}
 
+	overrides, err := valOpt.overrides(clusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
+
 	cliConfig := buildCliConfig(clusterSpec)
 	dirs, err := valOpt.directoriesToMount(clusterSpec, cliConfig)
 	if err != nil {
@@ -74,7 +86,7 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 		WithWriterFolder(tmpPath).
 		WithDocker().
 		WithKubectl().
-		WithProvider(valOpt.fileName, clusterSpec.Cluster, false, valOpt.hardwareCSVPath, true, valOpt.tinkerbellBootstrapIP).
+		WithProvider(valOpt.fileName, clusterSpec.Cluster, valOpt.hardwareCSVPath, true, valOpt.tinkerbellBootstrapIP).
 		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
 		Build(ctx)
 	if err != nil {
@@ -93,12 +105,18 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 		ManagementCluster: getManagementCluster(clusterSpec),
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
+		Report:            report,
+		Overrides:         overrides,
+		DockerExecutable:  deps.DockerClient,
 	}
 
 	createValidations := createvalidations.New(validationOpts)
 
-	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOpsFlux, createValidations, deps.DockerClient)
+	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOpsFlux, createValidations, deps.DockerClient, validationOpts.RunnerOpts()...)
 	err = commandVal.Validate(ctx)
+	if writeErr := valOpt.writeReport(report); writeErr != nil && err == nil {
+		err = writeErr
+	}
 
 	cleanupDirectory(tmpPath)
 	return err
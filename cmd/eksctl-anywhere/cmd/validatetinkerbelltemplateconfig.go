@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+)
+
+type validateTinkerbellTemplateConfigOptions struct {
+	fileName        string
+	hardwareCSVPath string
+}
+
+var vttcOpts = &validateTinkerbellTemplateConfigOptions{}
+
+var validateTinkerbellTemplateConfigCmd = &cobra.Command{
+	Use:   "tinkerbelltemplateconfig -f <cluster-config-file> -z <hardware-csv> [flags]",
+	Short: "Validate TinkerbellTemplateConfig",
+	Long: `
+Validate the TinkerbellTemplateConfigs in the cluster config: action images must be Tinkerbell action
+images of the bundle or be hosted in the registry mirror, actions must set the environment variables
+their images require and the disks they write to must exist in the hardware selected by the machine
+configs referencing the template.
+`,
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         vttcOpts.validateTinkerbellTemplateConfig,
+}
+
+func init() {
+	validateCmd.AddCommand(validateTinkerbellTemplateConfigCmd)
+
+	flags := validateTinkerbellTemplateConfigCmd.Flags()
+	flags.StringVarP(&vttcOpts.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	applyTinkerbellHardwareFlag(flags, &vttcOpts.hardwareCSVPath)
+
+	for _, flag := range []string{"filename", TinkerbellHardwareCSVFlagName} {
+		if err := validateTinkerbellTemplateConfigCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (o *validateTinkerbellTemplateConfigOptions) validateTinkerbellTemplateConfig(cmd *cobra.Command, _ []string) error {
+	spec, err := newTinkerbellClusterSpec(o.fileName)
+	if err != nil {
+		return err
+	}
+
+	machines, err := readTinkerbellHardware(o.hardwareCSVPath)
+	if err != nil {
+		return err
+	}
+
+	if len(spec.TinkerbellTemplateConfigs) == 0 {
+		logger.Info("No TinkerbellTemplateConfig found, the default templates will be used")
+		return nil
+	}
+
+	if err := tinkerbell.ValidateTemplateConfigs(spec, machines); err != nil {
+		return err
+	}
+
+	logger.MarkPass("TinkerbellTemplateConfig validated")
+	return nil
+}
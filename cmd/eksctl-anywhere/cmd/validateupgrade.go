@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var validateUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Validate upgrade resources",
+	Long:  "Use eksctl anywhere validate upgrade to validate the upgrade action on resources, such as cluster",
+}
+
+func init() {
+	validateCmd.AddCommand(validateUpgradeCmd)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradecluster"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+type validateUpgradeOptions struct {
+	clusterOptions
+	validationRunOptions
+	wConfig         string
+	hardwareCSVPath string
+}
+
+var valUpgradeOpt = &validateUpgradeOptions{}
+
+var validateUpgradeClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file> [flags]",
+	Short:        "Validate upgrade cluster",
+	Long:         "Use eksctl anywhere validate upgrade cluster to run the upgrade cluster validations against the existing cluster without upgrading it",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         valUpgradeOpt.validateUpgradeCluster,
+}
+
+func init() {
+	validateUpgradeCmd.AddCommand(validateUpgradeClusterCmd)
+	applyClusterOptionFlags(validateUpgradeClusterCmd.Flags(), &valUpgradeOpt.clusterOptions)
+	applyTinkerbellHardwareFlag(validateUpgradeClusterCmd.Flags(), &valUpgradeOpt.hardwareCSVPath)
+	validateUpgradeClusterCmd.Flags().StringVarP(&valUpgradeOpt.wConfig, "w-config", "w", "", "Kubeconfig file to use when validating the upgrade of a workload cluster")
+	applyValidationRunFlags(validateUpgradeClusterCmd.Flags(), &valUpgradeOpt.validationRunOptions)
+
+	if err := validateUpgradeClusterCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (valOpt *validateUpgradeOptions) validateUpgradeCluster(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	report, err := valOpt.newReport()
+	if err != nil {
+		return err
+	}
+
+	clusterSpec, err := newClusterSpec(valOpt.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.Config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.TinkerbellDatacenterKind {
+		if err := checkTinkerbellFlags(cmd.Flags(), valOpt.hardwareCSVPath, Upgrade); err != nil {
+			return err
+		}
+	}
+
+	overrides, err := valOpt.overrides(clusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := valOpt.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	tmpPath, err := os.MkdirTemp("./", "tmpValidate")
+	if err != nil {
+		return err
+	}
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithExecutableMountDirs(dirs...).
+		WithWriterFolder(tmpPath).
+		WithCliConfig(cliConfig).
+		WithKubectl().
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(valOpt.fileName, clusterSpec.Cluster, valOpt.hardwareCSVPath, false, "").
+		Build(ctx)
+	if err != nil {
+		cleanupDirectory(tmpPath)
+		return err
+	}
+	defer close(ctx, deps)
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, valOpt.wConfig),
+	}
+
+	managementCluster := workloadCluster
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	validationOpts := &validations.Opts{
+		Kubectl:           deps.Kubectl,
+		Spec:              clusterSpec,
+		WorkloadCluster:   workloadCluster,
+		ManagementCluster: managementCluster,
+		Provider:          deps.Provider,
+		CliConfig:         cliConfig,
+		Report:            report,
+		Overrides:         overrides,
+	}
+	upgradeValidations := upgradevalidations.New(validationOpts)
+
+	commandVal := upgradecluster.NewValidations(clusterSpec, managementCluster, deps.Provider, deps.ClusterManager, upgradeValidations, validationOpts.RunnerOpts()...)
+	err = commandVal.Validate(ctx)
+	if writeErr := valOpt.writeReport(report); writeErr != nil && err == nil {
+		err = writeErr
+	}
+
+	cleanupDirectory(tmpPath)
+	return err
+}
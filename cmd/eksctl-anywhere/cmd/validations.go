@@ -22,7 +22,6 @@ func commonValidation(ctx context.Context, clusterConfigFile string) (*v1alpha1.
 			return nil, fmt.Errorf("failed to validate docker desktop: %v", err)
 		}
 	}
-	validations.CheckDockerAllocatedMemory(ctx, docker)
 	clusterConfigFileExist := validations.FileExists(clusterConfigFile)
 	if !clusterConfigFileExist {
 		return nil, fmt.Errorf("the cluster config file %s does not exist", clusterConfigFile)
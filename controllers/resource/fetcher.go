@@ -26,6 +26,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -493,7 +494,12 @@ func (r *CapiResourceFetcher) ExistingVSphereWorkerMachineConfig(ctx context.Con
 		return nil, err
 	}
 	users := existingKubeadmConfigTemplate.Spec.Template.Spec.Users
-	return MapMachineTemplateToVSphereMachineConfigSpec(vsMachineTemplate, users)
+	vsMachineConfig, err := MapMachineTemplateToVSphereMachineConfigSpec(vsMachineTemplate, users)
+	if err != nil {
+		return nil, err
+	}
+	vsMachineConfig.Spec.HostOSConfiguration = MapKubeadmConfigTemplateToHostOSConfiguration(*existingKubeadmConfigTemplate)
+	return vsMachineConfig, nil
 }
 
 func (r *CapiResourceFetcher) ExistingCloudStackDatacenterConfig(ctx context.Context, cs *anywherev1.Cluster, wnc anywherev1.WorkerNodeGroupConfiguration) (*anywherev1.CloudStackDatacenterConfig, error) {
@@ -525,7 +531,16 @@ func (r *CapiResourceFetcher) ExistingCloudStackWorkerMachineConfig(ctx context.
 	if err != nil {
 		return nil, err
 	}
-	return MapMachineTemplateToCloudStackMachineConfigSpec(csMachineTemplate)
+	existingKubeadmConfigTemplate, err := r.KubeadmConfigTemplate(ctx, cs, wnc)
+	if err != nil {
+		return nil, err
+	}
+	csMachineConfig, err := MapMachineTemplateToCloudStackMachineConfigSpec(csMachineTemplate)
+	if err != nil {
+		return nil, err
+	}
+	csMachineConfig.Spec.HostOSConfiguration = MapKubeadmConfigTemplateToHostOSConfiguration(*existingKubeadmConfigTemplate)
+	return csMachineConfig, nil
 }
 
 func (r *CapiResourceFetcher) ExistingWorkerNodeGroupConfig(ctx context.Context, cs *anywherev1.Cluster, wnc anywherev1.WorkerNodeGroupConfiguration) (*anywherev1.WorkerNodeGroupConfiguration, error) {
@@ -679,6 +694,20 @@ func MapKubeadmConfigTemplateToWorkerNodeGroupConfiguration(template kubeadmv1.K
 	return wnSpec
 }
 
+// MapKubeadmConfigTemplateToHostOSConfiguration rebuilds the host OS configuration rendered in a KubeadmConfigTemplate.
+func MapKubeadmConfigTemplateToHostOSConfiguration(template kubeadmv1.KubeadmConfigTemplate) *anywherev1.HostOSConfiguration {
+	spec := template.Spec.Template.Spec
+	var ntpServers []string
+	if spec.NTP != nil {
+		ntpServers = spec.NTP.Servers
+	}
+	files := make([]common.HostOSFile, 0, len(spec.Files))
+	for _, file := range spec.Files {
+		files = append(files, common.HostOSFile{Path: file.Path, Content: file.Content})
+	}
+	return common.HostOSConfigFromRendered(ntpServers, files)
+}
+
 func convertStringToLabelsMap(labels string) map[string]string {
 	if labels == "" {
 		return nil
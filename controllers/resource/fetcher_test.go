@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/node/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -512,6 +513,50 @@ func TestMapMachineTemplateToCloudStackWorkerMachineConfigSpec(t *testing.T) {
 	}
 }
 
+func TestMapKubeadmConfigTemplateToHostOSConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	template := kubeadmv1.KubeadmConfigTemplate{
+		Spec: kubeadmv1.KubeadmConfigTemplateSpec{
+			Template: kubeadmv1.KubeadmConfigTemplateResource{
+				Spec: kubeadmv1.KubeadmConfigSpec{
+					NTP: &kubeadmv1.NTP{
+						Servers: []string{"time.example.com"},
+					},
+					Files: []kubeadmv1.File{
+						{
+							Path:    "/etc/containerd/config_append.toml",
+							Content: "mirror",
+						},
+						{
+							Path:    "/usr/local/share/ca-certificates/corp-ca.crt",
+							Content: "-----BEGIN CERTIFICATE-----\nMIIBAQ==\n-----END CERTIFICATE-----\n",
+						},
+						{
+							Path:    "/etc/sysctl.d/99-eksa.conf",
+							Content: "net.ipv4.ip_forward = 1\nvm.max_map_count = 262144\n",
+						},
+						{
+							Path:    "/etc/systemd/journald.conf.d/99-eksa.conf",
+							Content: "[Journal]\nSystemMaxUse=1G\n",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	want := &anywherev1.HostOSConfiguration{
+		NTPConfiguration: &anywherev1.NTPConfiguration{Servers: []string{"time.example.com"}},
+		CertBundles: []anywherev1.CertBundle{
+			{Name: "corp-ca", Data: "-----BEGIN CERTIFICATE-----\nMIIBAQ==\n-----END CERTIFICATE-----"},
+		},
+		SysctlSettings:        map[string]string{"net.ipv4.ip_forward": "1", "vm.max_map_count": "262144"},
+		JournaldConfiguration: &anywherev1.JournaldConfiguration{SystemMaxUse: "1G"},
+	}
+	got := resource.MapKubeadmConfigTemplateToHostOSConfiguration(template)
+	g.Expect(got.Equal(want)).To(BeTrue(), "got %+v", got)
+}
+
 func TestFetchCloudStackCluster(t *testing.T) {
 	tests := []struct {
 		name    string
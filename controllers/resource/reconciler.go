@@ -13,11 +13,14 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	anywhereTypes "github.com/aws/eks-anywhere/pkg/types"
 )
 
+const machineDeploymentKind = "MachineDeployment"
+
 type Reconciler interface {
 	Reconcile(ctx context.Context, objectKey types.NamespacedName, dryRun bool) error
 }
@@ -208,6 +211,81 @@ func (cor *clusterReconciler) Reconcile(ctx context.Context, objectKey types.Nam
 }
 
 func (cor *clusterReconciler) applyTemplates(ctx context.Context, cs *anywherev1.Cluster, resources []*unstructured.Unstructured, dryRun bool) error {
+	if dryRun || !cs.Spec.WorkerNodeGroupUpgradeStrategy.IsSequential() {
+		return cor.applyResources(ctx, cs, resources, dryRun)
+	}
+	return cor.applyTemplatesSequentially(ctx, cs, resources)
+}
+
+// applyTemplatesSequentially applies the objects of each worker node group one group at a time, following the
+// upgrade order. The objects of a group are only applied once the machine deployments of all the previous groups
+// are rolled out. Until then, an error is returned so the cluster is reconciled again later.
+func (cor *clusterReconciler) applyTemplatesSequentially(ctx context.Context, cs *anywherev1.Cluster, resources []*unstructured.Unstructured) error {
+	byMachineDeployment, shared := groupWorkerNodeGroupResources(resources)
+	if err := cor.applyResources(ctx, cs, shared, false); err != nil {
+		return err
+	}
+
+	workerNodeGroups := cs.WorkerNodeGroupConfigurationsInUpgradeOrder()
+	for i, workerNodeGroup := range workerNodeGroups {
+		mdName := fmt.Sprintf("%s-%s", cs.Name, workerNodeGroup.Name)
+		if err := cor.applyResources(ctx, cs, byMachineDeployment[mdName], false); err != nil {
+			return err
+		}
+
+		if i == len(workerNodeGroups)-1 {
+			break
+		}
+
+		md, err := cor.MachineDeployment(ctx, cs, workerNodeGroup)
+		if err != nil {
+			return err
+		}
+		if err := clusterapi.MachineDeploymentReady(md); err != nil {
+			return fmt.Errorf("waiting for worker node group %s to be rolled out before upgrading %s: %v", workerNodeGroup.Name, workerNodeGroups[i+1].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// groupWorkerNodeGroupResources groups each machine deployment with the templates it references,
+// indexed by machine deployment name. The objects not referenced by any machine deployment are returned apart.
+func groupWorkerNodeGroupResources(resources []*unstructured.Unstructured) (map[string][]*unstructured.Unstructured, []*unstructured.Unstructured) {
+	type objectKey struct {
+		kind, name string
+	}
+
+	owners := map[objectKey]string{}
+	for _, resource := range resources {
+		if resource.GetKind() != machineDeploymentKind {
+			continue
+		}
+		owners[objectKey{kind: resource.GetKind(), name: resource.GetName()}] = resource.GetName()
+		for _, ref := range [][]string{
+			{"spec", "template", "spec", "bootstrap", "configRef"},
+			{"spec", "template", "spec", "infrastructureRef"},
+		} {
+			kind, _, _ := unstructured.NestedString(resource.Object, append(ref, "kind")...)
+			name, _, _ := unstructured.NestedString(resource.Object, append(ref, "name")...)
+			owners[objectKey{kind: kind, name: name}] = resource.GetName()
+		}
+	}
+
+	byMachineDeployment := map[string][]*unstructured.Unstructured{}
+	var shared []*unstructured.Unstructured
+	for _, resource := range resources {
+		if owner, ok := owners[objectKey{kind: resource.GetKind(), name: resource.GetName()}]; ok {
+			byMachineDeployment[owner] = append(byMachineDeployment[owner], resource)
+		} else {
+			shared = append(shared, resource)
+		}
+	}
+
+	return byMachineDeployment, shared
+}
+
+func (cor *clusterReconciler) applyResources(ctx context.Context, cs *anywherev1.Cluster, resources []*unstructured.Unstructured, dryRun bool) error {
 	for _, resource := range resources {
 		kind := resource.GetKind()
 		name := resource.GetName()
@@ -28,6 +28,7 @@ import (
 	"github.com/aws/eks-anywhere/controllers/resource/mocks"
 	"github.com/aws/eks-anywhere/internal/test"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/features"
 )
 
@@ -1489,3 +1490,93 @@ func TestClusterReconcilerReconcileNutanix(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterReconcilerReconcileDockerSequentialWorkerNodeGroups(t *testing.T) {
+	tests := []struct {
+		name          string
+		md1Ready      bool
+		wantErr       string
+		wantAppliedMD []string
+	}{
+		{
+			name:          "next worker node group waits for the previous one",
+			md1Ready:      false,
+			wantErr:       "waiting for worker node group md-1 to be rolled out before upgrading md-0",
+			wantAppliedMD: []string{"test-cluster-md-1"},
+		},
+		{
+			name:          "next worker node group applied once the previous one is rolled out",
+			md1Ready:      true,
+			wantAppliedMD: []string{"test-cluster-md-1", "test-cluster-md-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			fetcher := mocks.NewMockResourceFetcher(mockCtrl)
+			resourceUpdater := mocks.NewMockResourceUpdater(mockCtrl)
+
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "test-cluster"
+				s.Cluster.Namespace = "namespaceA"
+				s.Cluster.Spec.KubernetesVersion = anywherev1.Kube122
+				s.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: "test-cluster"}
+				s.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+					{Name: "md-0", Count: 1},
+					{Name: "md-1", Count: 1},
+				}
+				s.Cluster.Spec.WorkerNodeGroupUpgradeStrategy = &anywherev1.WorkerNodeGroupUpgradeStrategy{
+					Type:  anywherev1.SequentialWorkerNodeGroupUpgrade,
+					Order: []string{"md-1"},
+				}
+				s.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.22.6-eks-1-22-1"
+			})
+			eksaCluster := spec.Cluster.DeepCopy()
+
+			machineDeployment := func(name string, ready bool) *clusterv1.MachineDeployment {
+				replicas := int32(1)
+				md := &clusterv1.MachineDeployment{}
+				md.Name = name
+				md.Spec.Replicas = &replicas
+				md.Spec.Template.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: name + "-1"}
+				md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{Kind: "DockerMachineTemplate", Name: name + "-1"}
+				if ready {
+					md.Status = clusterv1.MachineDeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1}
+				}
+				return md
+			}
+
+			fetcher.EXPECT().FetchCluster(ctx, gomock.Any()).Return(eksaCluster, nil)
+			fetcher.EXPECT().FetchAppliedSpec(ctx, gomock.Any()).Return(spec, nil)
+			fetcher.EXPECT().ExistingKubeVersion(ctx, gomock.Any()).Return("v1.22.6-eks-1-22-1", nil)
+			fetcher.EXPECT().ExistingControlPlaneKindNodeImage(ctx, gomock.Any()).Return("kind-node", nil)
+			fetcher.EXPECT().ControlPlane(ctx, gomock.Any()).Return(&controlplanev1.KubeadmControlPlane{}, nil)
+			fetcher.EXPECT().ExistingWorkerNodeGroupConfig(ctx, gomock.Any(), gomock.Any()).Return(&anywherev1.WorkerNodeGroupConfiguration{}, nil).AnyTimes()
+			fetcher.EXPECT().ExistingWorkerKindNodeImage(ctx, gomock.Any(), gomock.Any()).Return("old-kind-node", nil).AnyTimes()
+			fetcher.EXPECT().MachineDeployment(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, cs *anywherev1.Cluster, wnc anywherev1.WorkerNodeGroupConfiguration) (*clusterv1.MachineDeployment, error) {
+					return machineDeployment(fmt.Sprintf("%s-%s", cs.Name, wnc.Name), wnc.Name == "md-0" || tt.md1Ready), nil
+				},
+			).AnyTimes()
+			fetcher.EXPECT().Fetch(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, errors.NewNotFound(schema.GroupResource{Group: "testgroup", Resource: "testresource"}, ""))
+
+			var appliedMDs []string
+			resourceUpdater.EXPECT().ForceApplyTemplate(ctx, gomock.Any(), false).Do(func(_ context.Context, template *unstructured.Unstructured, _ bool) {
+				if template.GetKind() == "MachineDeployment" {
+					appliedMDs = append(appliedMDs, template.GetName())
+				}
+			}).Return(nil).AnyTimes()
+
+			cor := resource.NewClusterReconciler(fetcher, resourceUpdater, test.FakeNow, logr.Discard())
+			err := cor.Reconcile(ctx, types.NamespacedName{Name: "test-cluster", Namespace: "namespaceA"}, false)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAppliedMD, appliedMDs)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/manifests/signature"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/version"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -52,6 +53,7 @@ func NewFullClusterSpec(t *testing.T, clusterConfigFile string) *cluster.Spec {
 		version.Info{GitVersion: "v0.0.0-dev"},
 		cluster.WithReleasesManifest("embed:///testdata/releases.yaml"),
 		cluster.WithEmbedFS(configFS),
+		cluster.WithManifestSignatureVerifier(signature.NewSkipVerifier()),
 	)
 	if err != nil {
 		t.Fatalf("can't build cluster spec for tests: %v", err)
@@ -3,6 +3,7 @@ package envtest
 import (
 	"context"
 	"fmt"
+	"os"
 	"path"
 	"path/filepath"
 	goruntime "runtime"
@@ -191,6 +192,28 @@ func (e *Environment) Manager() manager.Manager {
 	return e.manager
 }
 
+// KubeconfigFile writes a kubeconfig file with admin access to the test environment API server
+// and returns its path. The file is removed when the test finishes.
+func (e *Environment) KubeconfigFile(t *testing.T) string {
+	t.Helper()
+	user, err := e.env.AddUser(envtest.User{Name: "admin", Groups: []string{"system:masters"}}, nil)
+	if err != nil {
+		t.Fatalf("adding envtest user: %v", err)
+	}
+
+	kubeconfig, err := user.KubeConfig()
+	if err != nil {
+		t.Fatalf("building envtest kubeconfig: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "envtest.kubeconfig")
+	if err = os.WriteFile(path, kubeconfig, 0o600); err != nil {
+		t.Fatalf("writing envtest kubeconfig: %v", err)
+	}
+
+	return path
+}
+
 func (e *Environment) CreateNamespaceForTest(ctx context.Context, t *testing.T) string {
 	t.Helper()
 	name := strings.ReplaceAll(t.Name(), "/", "-")
@@ -46,6 +46,8 @@ type CloudStackMachineConfigSpec struct {
 	UserCustomDetails map[string]string `json:"userCustomDetails,omitempty"`
 	// Symlinks create soft symbolic links folders. One use case is to use data disk to store logs
 	Symlinks SymlinkMaps `json:"symlinks,omitempty"`
+	// HostOSConfiguration configures NTP, trusted CA certificates, sysctl settings and journald limits on the host OS
+	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
 }
 
 type SymlinkMaps map[string]string
@@ -203,7 +205,8 @@ func (c *CloudStackMachineConfig) GetName() string {
 }
 
 func (c *CloudStackMachineConfig) Validate() error {
-	return nil
+	// CloudStack only supports RedHat based templates
+	return ValidateHostOSConfig(c.Spec.HostOSConfiguration, RedHat)
 }
 
 // CloudStackMachineConfigStatus defines the observed state of CloudStackMachineConfig
@@ -273,7 +276,7 @@ func (c *CloudStackMachineConfigSpec) Equal(o *CloudStackMachineConfigSpec) bool
 			return false
 		}
 	}
-	return true
+	return c.HostOSConfiguration.Equal(o.HostOSConfiguration)
 }
 
 func (c *CloudStackMachineConfig) ConvertConfigToConfigGenerateStruct() *CloudStackMachineConfigGenerate {
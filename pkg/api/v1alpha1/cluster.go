@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -178,6 +180,9 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateMirrorConfig,
 	validatePodIAMConfig,
 	validateControlPlaneLabels,
+	validateUpgradeRolloutStrategy,
+	validateWorkerNodeGroupUpgradeStrategy,
+	validateWorkerNodeGroupKubernetesVersions,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -305,6 +310,45 @@ func (c *Cluster) ProxyConfiguration() map[string]string {
 	}
 }
 
+// WorkerNodeGroupConfigurationsInUpgradeOrder returns the worker node groups in the order
+// they should be upgraded: first the ones listed in the sequential upgrade order and then
+// the rest in the order they are declared.
+func (c *Cluster) WorkerNodeGroupConfigurationsInUpgradeOrder() []WorkerNodeGroupConfiguration {
+	groups := make([]WorkerNodeGroupConfiguration, 0, len(c.Spec.WorkerNodeGroupConfigurations))
+	if !c.Spec.WorkerNodeGroupUpgradeStrategy.IsSequential() {
+		return append(groups, c.Spec.WorkerNodeGroupConfigurations...)
+	}
+
+	byName := make(map[string]WorkerNodeGroupConfiguration, len(c.Spec.WorkerNodeGroupConfigurations))
+	for _, group := range c.Spec.WorkerNodeGroupConfigurations {
+		byName[group.Name] = group
+	}
+
+	for _, name := range c.Spec.WorkerNodeGroupUpgradeStrategy.Order {
+		if group, ok := byName[name]; ok {
+			groups = append(groups, group)
+			delete(byName, name)
+		}
+	}
+
+	for _, group := range c.Spec.WorkerNodeGroupConfigurations {
+		if _, ok := byName[group.Name]; ok {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// WorkerNodeGroupKubernetesVersion returns the Kubernetes version for the worker node group,
+// which is the cluster Kubernetes version unless the group sets its own.
+func (c *Cluster) WorkerNodeGroupKubernetesVersion(workerNodeGroupConfig WorkerNodeGroupConfiguration) KubernetesVersion {
+	if workerNodeGroupConfig.KubernetesVersion != nil {
+		return *workerNodeGroupConfig.KubernetesVersion
+	}
+	return c.Spec.KubernetesVersion
+}
+
 func (c *Cluster) IsReconcilePaused() bool {
 	if s, ok := c.Annotations[pausedAnnotation]; ok {
 		return s == "true"
@@ -473,6 +517,122 @@ func validateAutoscalingConfig(autoscalingConfig *AutoScalingConfiguration) erro
 	return nil
 }
 
+func validateUpgradeRolloutStrategy(clusterConfig *Cluster) error {
+	if err := validateControlPlaneUpgradeRolloutStrategy(clusterConfig.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy); err != nil {
+		return fmt.Errorf("validating control plane upgradeRolloutStrategy: %v", err)
+	}
+
+	for _, workerNodeGroupConfig := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if err := validateWorkerNodesUpgradeRolloutStrategy(workerNodeGroupConfig.UpgradeRolloutStrategy); err != nil {
+			return fmt.Errorf("validating upgradeRolloutStrategy for worker node group %s: %v", workerNodeGroupConfig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateControlPlaneUpgradeRolloutStrategy(strategy *ControlPlaneUpgradeRolloutStrategy) error {
+	if strategy == nil {
+		return nil
+	}
+	if strategy.Type != "" && strategy.Type != RollingUpdateStrategyType {
+		return fmt.Errorf("rollout strategy type %s is not supported, only %s is supported", strategy.Type, RollingUpdateStrategyType)
+	}
+	if strategy.RollingUpdate.MaxSurge != 0 && strategy.RollingUpdate.MaxSurge != 1 {
+		return errors.New("maxSurge must be 0 or 1")
+	}
+
+	return nil
+}
+
+func validateWorkerNodesUpgradeRolloutStrategy(strategy *WorkerNodesUpgradeRolloutStrategy) error {
+	if strategy == nil {
+		return nil
+	}
+	if strategy.Type != "" && strategy.Type != RollingUpdateStrategyType {
+		return fmt.Errorf("rollout strategy type %s is not supported, only %s is supported", strategy.Type, RollingUpdateStrategyType)
+	}
+	if strategy.RollingUpdate.MaxSurge < 0 || strategy.RollingUpdate.MaxUnavailable < 0 {
+		return errors.New("maxSurge and maxUnavailable must be non negative")
+	}
+	if strategy.RollingUpdate.MaxSurge == 0 && strategy.RollingUpdate.MaxUnavailable == 0 {
+		return errors.New("maxSurge and maxUnavailable can't both be 0")
+	}
+
+	return nil
+}
+
+func validateWorkerNodeGroupUpgradeStrategy(clusterConfig *Cluster) error {
+	strategy := clusterConfig.Spec.WorkerNodeGroupUpgradeStrategy
+	if strategy == nil {
+		return nil
+	}
+
+	switch strategy.Type {
+	case "", ParallelWorkerNodeGroupUpgrade:
+		if len(strategy.Order) != 0 {
+			return errors.New("workerNodeGroupUpgradeStrategy order can only be set for Sequential upgrades")
+		}
+		return nil
+	case SequentialWorkerNodeGroupUpgrade:
+	default:
+		return fmt.Errorf("workerNodeGroupUpgradeStrategy type %s is not supported, supported types: %s, %s", strategy.Type, ParallelWorkerNodeGroupUpgrade, SequentialWorkerNodeGroupUpgrade)
+	}
+
+	workerNodeGroupNames := make(map[string]struct{}, len(clusterConfig.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroupConfig := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		workerNodeGroupNames[workerNodeGroupConfig.Name] = struct{}{}
+	}
+
+	ordered := make(map[string]struct{}, len(strategy.Order))
+	for _, name := range strategy.Order {
+		if _, ok := workerNodeGroupNames[name]; !ok {
+			return fmt.Errorf("workerNodeGroupUpgradeStrategy order references unknown worker node group %s", name)
+		}
+		if _, ok := ordered[name]; ok {
+			return fmt.Errorf("workerNodeGroupUpgradeStrategy order contains duplicated worker node group %s", name)
+		}
+		ordered[name] = struct{}{}
+	}
+
+	return nil
+}
+
+// SupportedKubeletMinorVersionSkew is the maximum number of minor versions the kubelet
+// of a worker node group can be behind the control plane.
+const SupportedKubeletMinorVersionSkew = 2
+
+func validateWorkerNodeGroupKubernetesVersions(clusterConfig *Cluster) error {
+	var controlPlaneVersion *version.Version
+	for _, workerNodeGroupConfig := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroupConfig.KubernetesVersion == nil {
+			continue
+		}
+
+		workerVersion, err := version.ParseGeneric(string(*workerNodeGroupConfig.KubernetesVersion))
+		if err != nil {
+			return fmt.Errorf("parsing kubernetesVersion %s for worker node group %s: %v", *workerNodeGroupConfig.KubernetesVersion, workerNodeGroupConfig.Name, err)
+		}
+
+		if controlPlaneVersion == nil {
+			controlPlaneVersion, err = version.ParseGeneric(string(clusterConfig.Spec.KubernetesVersion))
+			if err != nil {
+				return fmt.Errorf("parsing cluster kubernetesVersion %s: %v", clusterConfig.Spec.KubernetesVersion, err)
+			}
+		}
+
+		if controlPlaneVersion.LessThan(workerVersion) {
+			return fmt.Errorf("kubernetesVersion %s for worker node group %s can't be newer than the cluster kubernetesVersion %s", *workerNodeGroupConfig.KubernetesVersion, workerNodeGroupConfig.Name, clusterConfig.Spec.KubernetesVersion)
+		}
+
+		if int(controlPlaneVersion.Minor())-int(workerVersion.Minor()) > SupportedKubeletMinorVersionSkew {
+			return fmt.Errorf("kubernetesVersion %s for worker node group %s can't be older than the cluster kubernetesVersion %s by more than %d minor versions", *workerNodeGroupConfig.KubernetesVersion, workerNodeGroupConfig.Name, clusterConfig.Spec.KubernetesVersion, SupportedKubeletMinorVersionSkew)
+		}
+	}
+
+	return nil
+}
+
 func validateNodeLabels(labels map[string]string, fldPath *field.Path) error {
 	errList := validation.ValidateLabels(labels, fldPath)
 	if len(errList) != 0 {
@@ -592,12 +752,44 @@ func validateCNIConfig(cniConfig *CNIConfig) error {
 }
 
 func validateCiliumConfig(cilium *CiliumConfig) error {
-	if cilium.PolicyEnforcementMode == "" {
-		return nil
-	}
-	if !validCiliumPolicyEnforcementModes[cilium.PolicyEnforcementMode] {
+	if cilium.PolicyEnforcementMode != "" && !validCiliumPolicyEnforcementModes[cilium.PolicyEnforcementMode] {
 		return fmt.Errorf("cilium policyEnforcementMode \"%s\" not supported", cilium.PolicyEnforcementMode)
 	}
+	if cilium.KubeProxyReplacement != "" && !validCiliumKubeProxyReplacementModes[cilium.KubeProxyReplacement] {
+		return fmt.Errorf("cilium kubeProxyReplacement \"%s\" not supported", cilium.KubeProxyReplacement)
+	}
+	if cilium.RoutingMode != "" && !validCiliumRoutingModes[cilium.RoutingMode] {
+		return fmt.Errorf("cilium routingMode \"%s\" not supported", cilium.RoutingMode)
+	}
+	if cilium.Hubble != nil {
+		if cilium.Hubble.Relay && !cilium.Hubble.Enabled {
+			return errors.New("cilium hubble relay requires hubble to be enabled")
+		}
+		if cilium.Hubble.UI && !cilium.Hubble.Relay {
+			return errors.New("cilium hubble ui requires hubble relay to be enabled")
+		}
+	}
+	return validateCiliumHelmValues(cilium.HelmValues)
+}
+
+func validateCiliumHelmValues(helmValues map[string]string) error {
+	keys := make([]string, 0, len(helmValues))
+	for key := range helmValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		valueType, ok := allowedCiliumHelmValues[key]
+		if !ok {
+			return fmt.Errorf("cilium helm value \"%s\" can't be overridden", key)
+		}
+		if valueType == ciliumHelmValueBool {
+			if _, err := strconv.ParseBool(helmValues[key]); err != nil {
+				return fmt.Errorf("cilium helm value \"%s\" must be a boolean, got \"%s\"", key, helmValues[key])
+			}
+		}
+	}
 	return nil
 }
 
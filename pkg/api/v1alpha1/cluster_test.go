@@ -1857,6 +1857,48 @@ func TestClusterNetworkEquals(t *testing.T) {
 				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{PolicyEnforcementMode: "default"}},
 			},
 		},
+		{
+			name: "previous != new, same cilium cni, diff hubble configuration",
+			want: false,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{Hubble: &CiliumHubbleConfig{Enabled: true}}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{Hubble: &CiliumHubbleConfig{Enabled: true, Relay: true}}},
+			},
+		},
+		{
+			name: "previous != new, same cilium cni, diff helm values",
+			want: false,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{HelmValues: map[string]string{"bpf.masquerade": "true"}}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{HelmValues: map[string]string{"bpf.masquerade": "false"}}},
+			},
+		},
+		{
+			name: "previous == new, same cilium cni, same options",
+			want: true,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{
+					Hubble:                     &CiliumHubbleConfig{Enabled: true},
+					KubeProxyReplacement:       "partial",
+					EgressMasqueradeInterfaces: "eth0",
+					RoutingMode:                "native",
+					HelmValues:                 map[string]string{"bpf.masquerade": "true"},
+				}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{
+					Hubble:                     &CiliumHubbleConfig{Enabled: true},
+					KubeProxyReplacement:       "partial",
+					EgressMasqueradeInterfaces: "eth0",
+					RoutingMode:                "native",
+					HelmValues:                 map[string]string{"bpf.masquerade": "true"},
+				}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2209,6 +2251,103 @@ func TestValidateCNIConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid cilium options",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Hubble: &CiliumHubbleConfig{
+							Enabled: true,
+							Relay:   true,
+							UI:      true,
+						},
+						KubeProxyReplacement:       "partial",
+						EgressMasqueradeInterfaces: "eth0",
+						RoutingMode:                "native",
+						HelmValues: map[string]string{
+							"bpf.masquerade":         "true",
+							"loadBalancer.algorithm": "maglev",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid cilium kube-proxy replacement mode",
+			wantErr: fmt.Errorf("validating cniConfig: cilium kubeProxyReplacement \"invalid\" not supported"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						KubeProxyReplacement: "invalid",
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid cilium routing mode",
+			wantErr: fmt.Errorf("validating cniConfig: cilium routingMode \"invalid\" not supported"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						RoutingMode: "invalid",
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium hubble relay without hubble",
+			wantErr: fmt.Errorf("validating cniConfig: cilium hubble relay requires hubble to be enabled"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Hubble: &CiliumHubbleConfig{
+							Relay: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium hubble ui without relay",
+			wantErr: fmt.Errorf("validating cniConfig: cilium hubble ui requires hubble relay to be enabled"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Hubble: &CiliumHubbleConfig{
+							Enabled: true,
+							UI:      true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium helm value not allowed",
+			wantErr: fmt.Errorf("validating cniConfig: cilium helm value \"image.repository\" can't be overridden"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						HelmValues: map[string]string{
+							"image.repository": "my-registry/cilium",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium helm value invalid boolean",
+			wantErr: fmt.Errorf("validating cniConfig: cilium helm value \"bpf.masquerade\" must be a boolean, got \"yes please\""),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						HelmValues: map[string]string{
+							"bpf.masquerade": "yes please",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2352,6 +2491,266 @@ func TestValidateAutoscalingConfig(t *testing.T) {
 	}
 }
 
+func TestValidateUpgradeRolloutStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		cluster *Cluster
+	}{
+		{
+			name:    "no rollout strategy",
+			wantErr: "",
+			cluster: &Cluster{},
+		},
+		{
+			name:    "valid rollout strategies",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{
+							Type:          RollingUpdateStrategyType,
+							RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 1},
+						},
+					},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{
+							Name: "md-0",
+							UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{
+								RollingUpdate: WorkerNodesRollingUpdateParams{MaxSurge: 0, MaxUnavailable: 2},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "control plane unsupported type",
+			wantErr: "rollout strategy type Recreate is not supported",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "Recreate"},
+					},
+				},
+			},
+		},
+		{
+			name:    "control plane max surge too big",
+			wantErr: "maxSurge must be 0 or 1",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{
+							RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "worker negative max unavailable",
+			wantErr: "maxSurge and maxUnavailable must be non negative",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{
+							Name: "md-0",
+							UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{
+								RollingUpdate: WorkerNodesRollingUpdateParams{MaxSurge: 1, MaxUnavailable: -1},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "worker max surge and max unavailable 0",
+			wantErr: "maxSurge and maxUnavailable can't both be 0",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{
+							Name:                   "md-0",
+							UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateUpgradeRolloutStrategy(tt.cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateWorkerNodeGroupUpgradeStrategy(t *testing.T) {
+	workerNodeGroups := []WorkerNodeGroupConfiguration{{Name: "md-0"}, {Name: "md-1"}}
+	tests := []struct {
+		name     string
+		wantErr  string
+		strategy *WorkerNodeGroupUpgradeStrategy
+	}{
+		{
+			name:     "no strategy",
+			wantErr:  "",
+			strategy: nil,
+		},
+		{
+			name:     "parallel",
+			wantErr:  "",
+			strategy: &WorkerNodeGroupUpgradeStrategy{Type: ParallelWorkerNodeGroupUpgrade},
+		},
+		{
+			name:     "parallel with order",
+			wantErr:  "order can only be set for Sequential upgrades",
+			strategy: &WorkerNodeGroupUpgradeStrategy{Type: ParallelWorkerNodeGroupUpgrade, Order: []string{"md-0"}},
+		},
+		{
+			name:     "sequential with order",
+			wantErr:  "",
+			strategy: &WorkerNodeGroupUpgradeStrategy{Type: SequentialWorkerNodeGroupUpgrade, Order: []string{"md-1", "md-0"}},
+		},
+		{
+			name:     "unsupported type",
+			wantErr:  "workerNodeGroupUpgradeStrategy type Random is not supported",
+			strategy: &WorkerNodeGroupUpgradeStrategy{Type: "Random"},
+		},
+		{
+			name:     "unknown worker node group",
+			wantErr:  "references unknown worker node group md-2",
+			strategy: &WorkerNodeGroupUpgradeStrategy{Type: SequentialWorkerNodeGroupUpgrade, Order: []string{"md-2"}},
+		},
+		{
+			name:     "duplicated worker node group",
+			wantErr:  "contains duplicated worker node group md-0",
+			strategy: &WorkerNodeGroupUpgradeStrategy{Type: SequentialWorkerNodeGroupUpgrade, Order: []string{"md-0", "md-0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations:  workerNodeGroups,
+					WorkerNodeGroupUpgradeStrategy: tt.strategy,
+				},
+			}
+			err := validateWorkerNodeGroupUpgradeStrategy(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterWorkerNodeGroupConfigurationsInUpgradeOrder(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{Name: "md-0"}, {Name: "md-1"}, {Name: "md-2"}},
+			WorkerNodeGroupUpgradeStrategy: &WorkerNodeGroupUpgradeStrategy{
+				Type:  SequentialWorkerNodeGroupUpgrade,
+				Order: []string{"md-2"},
+			},
+		},
+	}
+	g.Expect(cluster.WorkerNodeGroupConfigurationsInUpgradeOrder()).To(Equal(
+		[]WorkerNodeGroupConfiguration{{Name: "md-2"}, {Name: "md-0"}, {Name: "md-1"}},
+	))
+}
+
+func TestValidateWorkerNodeGroupKubernetesVersions(t *testing.T) {
+	kube120 := Kube120
+	kube121 := Kube121
+	kube122 := Kube122
+	kube123 := Kube123
+	kube124 := Kube124
+	invalid := KubernetesVersion("invalid")
+	tests := []struct {
+		name          string
+		wantErr       string
+		workerVersion *KubernetesVersion
+	}{
+		{
+			name:          "no worker node group version",
+			wantErr:       "",
+			workerVersion: nil,
+		},
+		{
+			name:          "same as cluster version",
+			wantErr:       "",
+			workerVersion: &kube123,
+		},
+		{
+			name:          "older than cluster version",
+			wantErr:       "",
+			workerVersion: &kube122,
+		},
+		{
+			name:          "older than cluster version by the supported skew",
+			wantErr:       "",
+			workerVersion: &kube121,
+		},
+		{
+			name:          "older than cluster version by more than the supported skew",
+			wantErr:       "kubernetesVersion 1.20 for worker node group md-0 can't be older than the cluster kubernetesVersion 1.23 by more than 2 minor versions",
+			workerVersion: &kube120,
+		},
+		{
+			name:          "newer than cluster version",
+			wantErr:       "kubernetesVersion 1.24 for worker node group md-0 can't be newer than the cluster kubernetesVersion 1.23",
+			workerVersion: &kube124,
+		},
+		{
+			name:          "invalid version",
+			wantErr:       "parsing kubernetesVersion invalid for worker node group md-0",
+			workerVersion: &invalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					KubernetesVersion: Kube123,
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{Name: "md-0", KubernetesVersion: tt.workerVersion},
+					},
+				},
+			}
+			err := validateWorkerNodeGroupKubernetesVersions(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	g := NewWithT(t)
+	kube122 := Kube122
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			KubernetesVersion: Kube123,
+		},
+	}
+	g.Expect(cluster.WorkerNodeGroupKubernetesVersion(WorkerNodeGroupConfiguration{Name: "md-0"})).To(Equal(Kube123))
+	g.Expect(cluster.WorkerNodeGroupKubernetesVersion(WorkerNodeGroupConfiguration{Name: "md-1", KubernetesVersion: &kube122})).To(Equal(Kube122))
+}
+
 func TestClusterRegistryMirror(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,10 @@ const (
 	// cluster object
 	managementAnnotation = "anywhere.eks.amazonaws.com/managed-by"
 
+	// skippedValidationsAnnotation records the IDs of the validations skipped when creating or
+	// upgrading the cluster, for audit purposes.
+	skippedValidationsAnnotation = "anywhere.eks.amazonaws.com/skipped-validations"
+
 	// defaultEksaNamespace is the default namespace for EKS-A resources when not specified.
 	defaultEksaNamespace = "default"
 )
@@ -51,6 +56,10 @@ type ClusterSpec struct {
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
 	// BundlesRef contains a reference to the Bundles containing the desired dependencies for the cluster
 	BundlesRef *BundlesRef `json:"bundlesRef,omitempty"`
+	// WorkerNodeGroupUpgradeStrategy defines how worker node groups are upgraded relative to each other.
+	WorkerNodeGroupUpgradeStrategy *WorkerNodeGroupUpgradeStrategy `json:"workerNodeGroupUpgradeStrategy,omitempty"`
+	// Validations configures how the CLI validations for the cluster are run.
+	Validations *ValidationsConfig `json:"validations,omitempty"`
 }
 
 func (n *Cluster) Equal(o *Cluster) bool {
@@ -96,6 +105,9 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.BundlesRef.Equal(o.Spec.BundlesRef) {
 		return false
 	}
+	if !n.Spec.WorkerNodeGroupUpgradeStrategy.Equal(o.Spec.WorkerNodeGroupUpgradeStrategy) {
+		return false
+	}
 
 	return true
 }
@@ -166,6 +178,107 @@ type ControlPlaneConfiguration struct {
 	Taints []corev1.Taint `json:"taints,omitempty"`
 	// Labels define the labels to assign to the node
 	Labels map[string]string `json:"labels,omitempty"`
+	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
+	// of the control plane nodes.
+	UpgradeRolloutStrategy *ControlPlaneUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+}
+
+// UpgradeRolloutStrategyType defines the types of upgrade rollout strategies.
+type UpgradeRolloutStrategyType string
+
+// RollingUpdateStrategyType replaces the old machines by new one using rolling update.
+const RollingUpdateStrategyType UpgradeRolloutStrategyType = "RollingUpdate"
+
+// ControlPlaneUpgradeRolloutStrategy indicates rolling upgrade parameters for the control plane.
+type ControlPlaneUpgradeRolloutStrategy struct {
+	Type          UpgradeRolloutStrategyType      `json:"type,omitempty"`
+	RollingUpdate ControlPlaneRollingUpdateParams `json:"rollingUpdate,omitempty"`
+}
+
+// ControlPlaneRollingUpdateParams is API for rolling update strategy knobs.
+type ControlPlaneRollingUpdateParams struct {
+	// MaxSurge is the maximum number of control plane machines that can be scheduled above the
+	// desired number of machines during an upgrade. Only 0 and 1 are supported.
+	MaxSurge int `json:"maxSurge"`
+}
+
+func (n *ControlPlaneUpgradeRolloutStrategy) Equal(o *ControlPlaneUpgradeRolloutStrategy) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Type == o.Type && n.RollingUpdate == o.RollingUpdate
+}
+
+// WorkerNodesUpgradeRolloutStrategy indicates rolling upgrade parameters for a worker node group.
+type WorkerNodesUpgradeRolloutStrategy struct {
+	Type          UpgradeRolloutStrategyType     `json:"type,omitempty"`
+	RollingUpdate WorkerNodesRollingUpdateParams `json:"rollingUpdate,omitempty"`
+}
+
+// WorkerNodesRollingUpdateParams is API for rolling update strategy knobs.
+type WorkerNodesRollingUpdateParams struct {
+	// MaxSurge is the maximum number of machines that can be scheduled above the
+	// desired number of machines during an upgrade.
+	MaxSurge int `json:"maxSurge"`
+	// MaxUnavailable is the maximum number of machines that can be unavailable during an upgrade.
+	MaxUnavailable int `json:"maxUnavailable"`
+}
+
+func (n *WorkerNodesUpgradeRolloutStrategy) Equal(o *WorkerNodesUpgradeRolloutStrategy) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Type == o.Type && n.RollingUpdate == o.RollingUpdate
+}
+
+// WorkerNodeGroupUpgradeStrategyType defines how worker node groups are upgraded relative to each other.
+type WorkerNodeGroupUpgradeStrategyType string
+
+const (
+	// ParallelWorkerNodeGroupUpgrade upgrades all the worker node groups at the same time.
+	ParallelWorkerNodeGroupUpgrade WorkerNodeGroupUpgradeStrategyType = "Parallel"
+	// SequentialWorkerNodeGroupUpgrade upgrades the worker node groups one after another,
+	// waiting for each group to be healthy before moving to the next one.
+	SequentialWorkerNodeGroupUpgrade WorkerNodeGroupUpgradeStrategyType = "Sequential"
+)
+
+// WorkerNodeGroupUpgradeStrategy defines the order in which worker node groups are upgraded.
+type WorkerNodeGroupUpgradeStrategy struct {
+	// Type is either Parallel or Sequential. Defaults to Parallel.
+	Type WorkerNodeGroupUpgradeStrategyType `json:"type,omitempty"`
+	// Order lists the worker node group names in the order they are upgraded when Type is Sequential.
+	// Groups not listed are upgraded afterwards, in the order they are declared in the cluster spec.
+	Order []string `json:"order,omitempty"`
+}
+
+func (n *WorkerNodeGroupUpgradeStrategy) Equal(o *WorkerNodeGroupUpgradeStrategy) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if n.Type != o.Type || len(n.Order) != len(o.Order) {
+		return false
+	}
+	// order is relevant here, so SliceEqual can't be used
+	for i := range n.Order {
+		if n.Order[i] != o.Order[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSequential returns true if worker node groups must be upgraded one after another.
+func (n *WorkerNodeGroupUpgradeStrategy) IsSequential() bool {
+	return n != nil && n.Type == SequentialWorkerNodeGroupUpgrade
 }
 
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
@@ -209,7 +322,7 @@ func (n *ControlPlaneConfiguration) Equal(o *ControlPlaneConfiguration) bool {
 		return false
 	}
 	return n.Count == o.Count && n.Endpoint.Equal(o.Endpoint) && n.MachineGroupRef.Equal(o.MachineGroupRef) &&
-		TaintsSliceEqual(n.Taints, o.Taints) && LabelsMapEqual(n.Labels, o.Labels)
+		TaintsSliceEqual(n.Taints, o.Taints) && LabelsMapEqual(n.Labels, o.Labels) && n.UpgradeRolloutStrategy.Equal(o.UpgradeRolloutStrategy)
 }
 
 type Endpoint struct {
@@ -240,6 +353,12 @@ type WorkerNodeGroupConfiguration struct {
 	Taints []corev1.Taint `json:"taints,omitempty"`
 	// Labels define the labels to assign to the node
 	Labels map[string]string `json:"labels,omitempty"`
+	// UpgradeRolloutStrategy determines the rollout strategy to use for rolling upgrades
+	// of the worker nodes in this group.
+	UpgradeRolloutStrategy *WorkerNodesUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// KubernetesVersion defines the Kubernetes version for the worker nodes in this group.
+	// It defaults to the cluster Kubernetes version and can't be newer than it.
+	KubernetesVersion *KubernetesVersion `json:"kubernetesVersion,omitempty"`
 }
 
 func generateWorkerNodeGroupKey(c WorkerNodeGroupConfiguration) (key string) {
@@ -275,7 +394,51 @@ func WorkerNodeGroupConfigurationsSliceEqual(a, b []WorkerNodeGroupConfiguration
 		return false
 	}
 
-	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) && WorkerNodeGroupConfigurationsLabelsMapEqual(a, b)
+	return WorkerNodeGroupConfigurationSliceTaintsEqual(a, b) &&
+		WorkerNodeGroupConfigurationsLabelsMapEqual(a, b) &&
+		WorkerNodeGroupConfigurationsUpgradeRolloutStrategyEqual(a, b) &&
+		WorkerNodeGroupConfigurationsKubernetesVersionEqual(a, b)
+}
+
+func WorkerNodeGroupConfigurationsKubernetesVersionEqual(a, b []WorkerNodeGroupConfiguration) bool {
+	m := make(map[string]*KubernetesVersion, len(a))
+	for _, nodeGroup := range a {
+		m[nodeGroup.Name] = nodeGroup.KubernetesVersion
+	}
+
+	for _, nodeGroup := range b {
+		kubeVersion, ok := m[nodeGroup.Name]
+		if !ok {
+			// added/removed node groups are immaterial for this comparison
+			continue
+		}
+		if kubeVersion == nil && nodeGroup.KubernetesVersion == nil {
+			continue
+		}
+		if kubeVersion == nil || nodeGroup.KubernetesVersion == nil || *kubeVersion != *nodeGroup.KubernetesVersion {
+			return false
+		}
+	}
+	return true
+}
+
+func WorkerNodeGroupConfigurationsUpgradeRolloutStrategyEqual(a, b []WorkerNodeGroupConfiguration) bool {
+	m := make(map[string]*WorkerNodesUpgradeRolloutStrategy, len(a))
+	for _, nodeGroup := range a {
+		m[nodeGroup.Name] = nodeGroup.UpgradeRolloutStrategy
+	}
+
+	for _, nodeGroup := range b {
+		strategy, ok := m[nodeGroup.Name]
+		if !ok {
+			// added/removed node groups are immaterial for this comparison
+			continue
+		}
+		if !strategy.Equal(nodeGroup.UpgradeRolloutStrategy) {
+			return false
+		}
+	}
+	return true
 }
 
 func WorkerNodeGroupConfigurationSliceTaintsEqual(a, b []WorkerNodeGroupConfiguration) bool {
@@ -407,7 +570,39 @@ func (n *CiliumConfig) Equal(o *CiliumConfig) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.PolicyEnforcementMode == o.PolicyEnforcementMode
+	return n.PolicyEnforcementMode == o.PolicyEnforcementMode &&
+		n.Hubble.Equal(o.Hubble) &&
+		n.KubeProxyReplacement == o.KubeProxyReplacement &&
+		n.EgressMasqueradeInterfaces == o.EgressMasqueradeInterfaces &&
+		n.RoutingMode == o.RoutingMode &&
+		LabelsMapEqual(n.HelmValues, o.HelmValues)
+}
+
+func (n *CiliumHubbleConfig) Equal(o *CiliumHubbleConfig) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return *n == *o
+}
+
+// HelmValueOverrides returns the Cilium Helm chart value overrides, indexed by their dot separated
+// path and converted to their chart type. It assumes the overrides have been validated.
+func (n *CiliumConfig) HelmValueOverrides() map[string]interface{} {
+	overrides := make(map[string]interface{}, len(n.HelmValues))
+	for key, value := range n.HelmValues {
+		if allowedCiliumHelmValues[key] == ciliumHelmValueBool {
+			b, err := strconv.ParseBool(value)
+			if err == nil {
+				overrides[key] = b
+				continue
+			}
+		}
+		overrides[key] = value
+	}
+	return overrides
 }
 
 func (n *KindnetdConfig) Equal(o *KindnetdConfig) bool {
@@ -575,8 +770,49 @@ type CNIConfig struct {
 type CiliumConfig struct {
 	// PolicyEnforcementMode determines communication allowed between pods. Accepted values are default, always, never.
 	PolicyEnforcementMode CiliumPolicyEnforcementMode `json:"policyEnforcementMode,omitempty"`
+
+	// Hubble configures the Hubble observability layer. If not set, the Cilium chart defaults are used.
+	// +optional
+	Hubble *CiliumHubbleConfig `json:"hubble,omitempty"`
+
+	// KubeProxyReplacement determines how much of kube-proxy functionality is replaced by Cilium.
+	// Accepted values are disabled, partial, strict. If not set, the Cilium chart default is used.
+	// +optional
+	KubeProxyReplacement CiliumKubeProxyReplacementMode `json:"kubeProxyReplacement,omitempty"`
+
+	// EgressMasqueradeInterfaces limits the network interfaces on which masquerading is performed.
+	// +optional
+	EgressMasqueradeInterfaces string `json:"egressMasqueradeInterfaces,omitempty"`
+
+	// RoutingMode determines how pod traffic is routed between nodes. Accepted values are tunnel, native.
+	// Defaults to tunnel.
+	// +optional
+	RoutingMode CiliumRoutingMode `json:"routingMode,omitempty"`
+
+	// HelmValues overrides Cilium Helm chart values. Keys are dot separated value paths and only
+	// a limited set of values can be overridden.
+	// +optional
+	HelmValues map[string]string `json:"helmValues,omitempty"`
 }
 
+// CiliumHubbleConfig configures Hubble and its components.
+type CiliumHubbleConfig struct {
+	// Enabled enables Hubble in the Cilium agent.
+	Enabled bool `json:"enabled"`
+
+	// Relay deploys the Hubble relay. Requires Hubble to be enabled.
+	// +optional
+	Relay bool `json:"relay,omitempty"`
+
+	// UI deploys the Hubble UI. Requires the Hubble relay to be enabled.
+	// +optional
+	UI bool `json:"ui,omitempty"`
+}
+
+type CiliumKubeProxyReplacementMode string
+
+type CiliumRoutingMode string
+
 type KindnetdConfig struct{}
 
 const (
@@ -602,6 +838,52 @@ var validCiliumPolicyEnforcementModes = map[CiliumPolicyEnforcementMode]bool{
 	CiliumPolicyModeNever:   true,
 }
 
+const (
+	CiliumKubeProxyReplacementDisabled CiliumKubeProxyReplacementMode = "disabled"
+	CiliumKubeProxyReplacementPartial  CiliumKubeProxyReplacementMode = "partial"
+	CiliumKubeProxyReplacementStrict   CiliumKubeProxyReplacementMode = "strict"
+)
+
+var validCiliumKubeProxyReplacementModes = map[CiliumKubeProxyReplacementMode]bool{
+	CiliumKubeProxyReplacementDisabled: true,
+	CiliumKubeProxyReplacementPartial:  true,
+	CiliumKubeProxyReplacementStrict:   true,
+}
+
+const (
+	CiliumRoutingModeTunnel CiliumRoutingMode = "tunnel"
+	CiliumRoutingModeNative CiliumRoutingMode = "native"
+)
+
+var validCiliumRoutingModes = map[CiliumRoutingMode]bool{
+	CiliumRoutingModeTunnel: true,
+	CiliumRoutingModeNative: true,
+}
+
+// ciliumHelmValueType is the type of a Cilium Helm chart value that can be overridden.
+type ciliumHelmValueType int
+
+const (
+	ciliumHelmValueString ciliumHelmValueType = iota
+	ciliumHelmValueBool
+)
+
+// allowedCiliumHelmValues is the list of Cilium Helm chart values that can be overridden through
+// CiliumConfig.HelmValues. Values managed by EKS Anywhere, like images or the IPAM mode, can't be overridden.
+var allowedCiliumHelmValues = map[string]ciliumHelmValueType{
+	"autoDirectNodeRoutes":    ciliumHelmValueBool,
+	"bandwidthManager":        ciliumHelmValueBool,
+	"bpf.lbExternalClusterIP": ciliumHelmValueBool,
+	"bpf.masquerade":          ciliumHelmValueBool,
+	"enableIPv4Masquerade":    ciliumHelmValueBool,
+	"endpointRoutes.enabled":  ciliumHelmValueBool,
+	"ipv4NativeRoutingCIDR":   ciliumHelmValueString,
+	"loadBalancer.algorithm":  ciliumHelmValueString,
+	"loadBalancer.mode":       ciliumHelmValueString,
+	"localRedirectPolicy":     ciliumHelmValueBool,
+	"monitorAggregation":      ciliumHelmValueString,
+}
+
 // ClusterStatus defines the observed state of Cluster
 type ClusterStatus struct {
 	// Descriptive message about a fatal problem while reconciling a cluster
@@ -709,6 +991,13 @@ func (n *PodIAMConfig) Equal(o *PodIAMConfig) bool {
 	return n.ServiceAccountIssuer == o.ServiceAccountIssuer
 }
 
+// ValidationsConfig configures how the CLI validations for a cluster are run.
+type ValidationsConfig struct {
+	// Warnings lists the IDs of validations that only report a warning when they fail, instead
+	// of failing the command.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 // AutoScalingConfiguration defines the configuration for the node autoscaling feature
 type AutoScalingConfiguration struct {
 	// MinCount defines the minimum number of nodes for the associated resource group.
@@ -785,6 +1074,53 @@ func (c *Cluster) SetManagedBy(managementClusterName string) {
 	c.Spec.ManagementCluster.Name = managementClusterName
 }
 
+// AddSkippedValidations records ids as validations skipped when creating or upgrading the
+// cluster. IDs already recorded are kept, so the record accumulates every validation skipped
+// over the lifetime of the cluster.
+func (c *Cluster) AddSkippedValidations(ids []string) {
+	skipped := c.SkippedValidations()
+	recorded := make(map[string]struct{}, len(skipped))
+	for _, id := range skipped {
+		recorded[id] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := recorded[id]; !ok {
+			recorded[id] = struct{}{}
+			skipped = append(skipped, id)
+		}
+	}
+
+	if len(skipped) == 0 {
+		return
+	}
+
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+
+	c.Annotations[skippedValidationsAnnotation] = strings.Join(skipped, ",")
+}
+
+// SkippedValidations returns the IDs of the validations skipped when creating or upgrading the
+// cluster.
+func (c *Cluster) SkippedValidations() []string {
+	ids, ok := c.Annotations[skippedValidationsAnnotation]
+	if !ok || ids == "" {
+		return nil
+	}
+
+	return strings.Split(ids, ",")
+}
+
+// ValidationWarnings returns the IDs of the validations configured to only report a warning.
+func (c *Cluster) ValidationWarnings() []string {
+	if c.Spec.Validations == nil {
+		return nil
+	}
+
+	return c.Spec.Validations.Warnings
+}
+
 func (c *Cluster) SetSelfManaged() {
 	c.Spec.ManagementCluster.Name = c.Name
 }
@@ -144,6 +144,40 @@ func TestClusterSetManagedBy(t *testing.T) {
 	g.Expect(c.ManagedBy()).To(Equal(managementClusterName))
 }
 
+func TestClusterAddSkippedValidations(t *testing.T) {
+	g := NewWithT(t)
+	c := &v1alpha1.Cluster{}
+	c.AddSkippedValidations([]string{"docker-memory", "validate-cluster-name"})
+
+	g.Expect(c.Annotations).To(HaveKeyWithValue("anywhere.eks.amazonaws.com/skipped-validations", "docker-memory,validate-cluster-name"))
+	g.Expect(c.SkippedValidations()).To(Equal([]string{"docker-memory", "validate-cluster-name"}))
+
+	c.AddSkippedValidations([]string{"validate-cluster-name", "bmc-reachable"})
+	g.Expect(c.SkippedValidations()).To(Equal([]string{"docker-memory", "validate-cluster-name", "bmc-reachable"}))
+
+	c.AddSkippedValidations(nil)
+	g.Expect(c.SkippedValidations()).To(Equal([]string{"docker-memory", "validate-cluster-name", "bmc-reachable"}))
+}
+
+func TestClusterAddSkippedValidationsNone(t *testing.T) {
+	g := NewWithT(t)
+	c := &v1alpha1.Cluster{}
+	c.AddSkippedValidations(nil)
+
+	g.Expect(c.Annotations).ToNot(HaveKey("anywhere.eks.amazonaws.com/skipped-validations"))
+	g.Expect(c.SkippedValidations()).To(BeEmpty())
+}
+
+func TestClusterValidationWarnings(t *testing.T) {
+	c := &v1alpha1.Cluster{}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidationWarnings()).To(BeEmpty())
+
+	c.Spec.Validations = &v1alpha1.ValidationsConfig{Warnings: []string{"docker-memory"}}
+	g.Expect(c.ValidationWarnings()).To(Equal([]string{"docker-memory"}))
+}
+
 func TestClusterSetSelfManaged(t *testing.T) {
 	c := &v1alpha1.Cluster{}
 	c.SetSelfManaged()
@@ -122,6 +122,21 @@ func validateBundlesRefCluster(new, old *Cluster) field.ErrorList {
 	return allErrs
 }
 
+// clusterNetworkImmutableFieldsEqual compares two cluster networks ignoring the Cilium options,
+// which can be updated and are reconciled by the controller.
+func clusterNetworkImmutableFieldsEqual(new, old *ClusterNetwork) bool {
+	newNetwork := new.DeepCopy()
+	oldNetwork := old.DeepCopy()
+	newCNIConfig := getCNIConfig(newNetwork)
+	oldCNIConfig := getCNIConfig(oldNetwork)
+	if newCNIConfig != nil && newCNIConfig.Cilium != nil && oldCNIConfig != nil && oldCNIConfig.Cilium != nil {
+		*newCNIConfig.Cilium = CiliumConfig{}
+		*oldCNIConfig.Cilium = CiliumConfig{}
+	}
+
+	return newNetwork.Equal(oldNetwork)
+}
+
 func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 	if old.IsReconcilePaused() {
 		return nil
@@ -148,7 +163,7 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("datacenterRef"), fmt.Sprintf("field is immutable %v", new.Spec.DatacenterRef)))
 	}
 
-	if !new.Spec.ClusterNetwork.Equal(&old.Spec.ClusterNetwork) {
+	if !clusterNetworkImmutableFieldsEqual(&new.Spec.ClusterNetwork, &old.Spec.ClusterNetwork) {
 		allErrs = append(
 			allErrs,
 			field.Forbidden(specPath.Child("ClusterNetwork"), fmt.Sprintf("field is immutable %v", new.Spec.ClusterNetwork)))
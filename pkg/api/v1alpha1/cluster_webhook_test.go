@@ -583,27 +583,39 @@ func TestClusterValidateUpdateClusterNetworkNewEmptyImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
 
-func TestClusterValidateUpdateClusterNetworkCiliumConfigImmutable(t *testing.T) {
-	cOld := &v1alpha1.Cluster{
-		Spec: v1alpha1.ClusterSpec{
-			ClusterNetwork: v1alpha1.ClusterNetwork{
-				CNIConfig: &v1alpha1.CNIConfig{
-					Cilium: &v1alpha1.CiliumConfig{
-						PolicyEnforcementMode: "default",
-					},
-				},
-			},
+func TestClusterValidateUpdateClusterNetworkCiliumConfigMutable(t *testing.T) {
+	cOld := createCluster()
+	cOld.Spec.ProxyConfiguration = nil
+	cOld.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+		Cilium: &v1alpha1.CiliumConfig{
+			PolicyEnforcementMode: "default",
 		},
 	}
 	c := cOld.DeepCopy()
-	c.Spec.ClusterNetwork = v1alpha1.ClusterNetwork{
-		CNIConfig: &v1alpha1.CNIConfig{
-			Cilium: &v1alpha1.CiliumConfig{
-				PolicyEnforcementMode: "always",
+	c.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+		Cilium: &v1alpha1.CiliumConfig{
+			PolicyEnforcementMode: "always",
+			Hubble: &v1alpha1.CiliumHubbleConfig{
+				Enabled: true,
 			},
+			RoutingMode: v1alpha1.CiliumRoutingModeNative,
 		},
 	}
 
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
+func TestClusterValidateUpdateClusterNetworkCiliumToKindnetdImmutable(t *testing.T) {
+	cOld := createCluster()
+	cOld.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+		Cilium: &v1alpha1.CiliumConfig{},
+	}
+	c := cOld.DeepCopy()
+	c.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+		Kindnetd: &v1alpha1.KindnetdConfig{},
+	}
+
 	g := NewWithT(t)
 	g.Expect(c.ValidateUpdate(cOld)).NotTo(Succeed())
 }
@@ -1,10 +1,21 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const DockerDatacenterKind = "DockerDatacenterConfig"
+const (
+	DockerDatacenterKind = "DockerDatacenterConfig"
+
+	defaultDockerMirroredRegistry         = "public.ecr.aws"
+	defaultDockerPortMappingListenAddress = "127.0.0.1"
+	dockerSocketPath                      = "/var/run/docker.sock"
+)
 
 // Used for generating yaml for generate clusterconfig command
 func NewDockerDatacenterConfigGenerate(clusterName string) *DockerDatacenterConfigGenerate {
@@ -40,3 +51,91 @@ func GetDockerDatacenterConfig(fileName string) (*DockerDatacenterConfig, error)
 	}
 	return &clusterConfig, nil
 }
+
+// MirroredRegistries returns the registries pulled through the mirror.
+func (r *DockerRegistryMirror) MirroredRegistries() []string {
+	if len(r.Registries) == 0 {
+		return []string{defaultDockerMirroredRegistry}
+	}
+	return r.Registries
+}
+
+// Host returns the host and port of the mirror endpoint.
+func (r *DockerRegistryMirror) Host() string {
+	u, err := url.Parse(r.Endpoint)
+	if err != nil {
+		return r.Endpoint
+	}
+	return u.Host
+}
+
+// Address returns the host address the port is published on.
+func (m DockerPortMapping) Address() string {
+	if m.ListenAddress == "" {
+		return defaultDockerPortMappingListenAddress
+	}
+	return m.ListenAddress
+}
+
+func validateDockerMounts(mounts []DockerMount) error {
+	containerPaths := make(map[string]struct{}, len(mounts))
+	for _, m := range mounts {
+		if !path.IsAbs(m.HostPath) {
+			return fmt.Errorf("DockerDatacenterConfig extra mount hostPath %q must be an absolute path", m.HostPath)
+		}
+		if !path.IsAbs(m.ContainerPath) {
+			return fmt.Errorf("DockerDatacenterConfig extra mount containerPath %q must be an absolute path", m.ContainerPath)
+		}
+		containerPath := path.Clean(m.ContainerPath)
+		if containerPath == dockerSocketPath {
+			return fmt.Errorf("DockerDatacenterConfig extra mount containerPath %s is reserved", dockerSocketPath)
+		}
+		if _, ok := containerPaths[containerPath]; ok {
+			return fmt.Errorf("DockerDatacenterConfig extra mount containerPath %s is duplicated", containerPath)
+		}
+		containerPaths[containerPath] = struct{}{}
+	}
+	return nil
+}
+
+func validateDockerRegistryMirror(mirror *DockerRegistryMirror) error {
+	if mirror == nil {
+		return nil
+	}
+	if mirror.Endpoint == "" {
+		return fmt.Errorf("DockerDatacenterConfig registryMirror endpoint is not set or is empty")
+	}
+	u, err := url.Parse(mirror.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("DockerDatacenterConfig registryMirror endpoint %q must be an http or https url", mirror.Endpoint)
+	}
+	for _, r := range mirror.Registries {
+		if r == "" {
+			return fmt.Errorf("DockerDatacenterConfig registryMirror registries cannot be empty")
+		}
+	}
+	return nil
+}
+
+func validateDockerPortMappings(mappings []DockerPortMapping) error {
+	hostPorts := make(map[int]struct{}, len(mappings))
+	for _, m := range mappings {
+		if m.WorkerNodeGroup == "" {
+			return fmt.Errorf("DockerDatacenterConfig port mapping workerNodeGroup is not set or is empty")
+		}
+		if m.HostPort < 1 || m.HostPort > 65535 {
+			return fmt.Errorf("DockerDatacenterConfig port mapping hostPort %d is not a valid port", m.HostPort)
+		}
+		if m.NodePort < 1 || m.NodePort > 65535 {
+			return fmt.Errorf("DockerDatacenterConfig port mapping nodePort %d is not a valid port", m.NodePort)
+		}
+		if m.ListenAddress != "" && net.ParseIP(m.ListenAddress) == nil {
+			return fmt.Errorf("DockerDatacenterConfig port mapping listenAddress %q is not a valid ip", m.ListenAddress)
+		}
+		if _, ok := hostPorts[m.HostPort]; ok {
+			return fmt.Errorf("DockerDatacenterConfig port mapping hostPort %d is duplicated", m.HostPort)
+		}
+		hostPorts[m.HostPort] = struct{}{}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -65,3 +66,99 @@ func TestGetDockerDatacenterConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerDatacenterConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.DockerDatacenterConfigSpec
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				ExtraMounts: []v1alpha1.DockerMount{
+					{HostPath: "/data", ContainerPath: "/mnt/data", ReadOnly: true},
+				},
+				RegistryMirror: &v1alpha1.DockerRegistryMirror{Endpoint: "http://192.168.1.2:5000"},
+				PortMappings: []v1alpha1.DockerPortMapping{
+					{WorkerNodeGroup: "md-0", HostPort: 8080, NodePort: 30080},
+					{WorkerNodeGroup: "md-0", HostPort: 8443, NodePort: 30443, ListenAddress: "0.0.0.0"},
+				},
+			},
+		},
+		{
+			name: "relative host path",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				ExtraMounts: []v1alpha1.DockerMount{{HostPath: "data", ContainerPath: "/mnt/data"}},
+			},
+			wantErr: "hostPath \"data\" must be an absolute path",
+		},
+		{
+			name: "docker socket container path",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				ExtraMounts: []v1alpha1.DockerMount{{HostPath: "/data", ContainerPath: "/var/run/docker.sock"}},
+			},
+			wantErr: "containerPath /var/run/docker.sock is reserved",
+		},
+		{
+			name: "duplicated container path",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				ExtraMounts: []v1alpha1.DockerMount{
+					{HostPath: "/data", ContainerPath: "/mnt/data"},
+					{HostPath: "/other", ContainerPath: "/mnt/data/"},
+				},
+			},
+			wantErr: "containerPath /mnt/data is duplicated",
+		},
+		{
+			name: "mirror without endpoint",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				RegistryMirror: &v1alpha1.DockerRegistryMirror{},
+			},
+			wantErr: "registryMirror endpoint is not set or is empty",
+		},
+		{
+			name: "mirror endpoint without scheme",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				RegistryMirror: &v1alpha1.DockerRegistryMirror{Endpoint: "192.168.1.2:5000"},
+			},
+			wantErr: "must be an http or https url",
+		},
+		{
+			name: "invalid host port",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				PortMappings: []v1alpha1.DockerPortMapping{{WorkerNodeGroup: "md-0", HostPort: 70000, NodePort: 30080}},
+			},
+			wantErr: "hostPort 70000 is not a valid port",
+		},
+		{
+			name: "invalid listen address",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				PortMappings: []v1alpha1.DockerPortMapping{{WorkerNodeGroup: "md-0", HostPort: 8080, NodePort: 30080, ListenAddress: "localhost"}},
+			},
+			wantErr: "listenAddress \"localhost\" is not a valid ip",
+		},
+		{
+			name: "duplicated host port",
+			spec: v1alpha1.DockerDatacenterConfigSpec{
+				PortMappings: []v1alpha1.DockerPortMapping{
+					{WorkerNodeGroup: "md-0", HostPort: 8080, NodePort: 30080},
+					{WorkerNodeGroup: "md-1", HostPort: 8080, NodePort: 30081},
+				},
+			},
+			wantErr: "hostPort 8080 is duplicated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &v1alpha1.DockerDatacenterConfig{Spec: tt.spec}
+			err := config.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
@@ -8,8 +8,45 @@ import (
 
 // DockerDatacenterConfigSpec defines the desired state of DockerDatacenterConfig
 type DockerDatacenterConfigSpec struct { // Important: Run "make generate" to regenerate code after modifying this file
-	// Foo is an example field of DockerDatacenterConfig. Edit DockerDatacenter_types.go to remove/update
-	// Foo string `json:"foo,omitempty"`
+	// ExtraMounts are host paths mounted in every node container of the cluster.
+	ExtraMounts []DockerMount `json:"extraMounts,omitempty"`
+	// RegistryMirror configures containerd in the nodes to pull images through a local registry mirror.
+	RegistryMirror *DockerRegistryMirror `json:"registryMirror,omitempty"`
+	// PortMappings publishes ports of the nodes of a worker node group on the host, to expose
+	// ingress controllers and load balancers running in the cluster.
+	PortMappings []DockerPortMapping `json:"portMappings,omitempty"`
+}
+
+// DockerMount describes a host path mounted in the node containers.
+type DockerMount struct {
+	// HostPath is the absolute path in the host to mount.
+	HostPath string `json:"hostPath"`
+	// ContainerPath is the absolute path where the host path is mounted in the node containers.
+	ContainerPath string `json:"containerPath"`
+	// ReadOnly mounts the host path as read only.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// DockerRegistryMirror describes a registry mirror used by the nodes to pull images.
+type DockerRegistryMirror struct {
+	// Endpoint is the url of the mirror, e.g. http://kind-registry:5000.
+	Endpoint string `json:"endpoint"`
+	// Registries are the registries pulled through the mirror. Defaults to public.ecr.aws.
+	Registries []string `json:"registries,omitempty"`
+	// InsecureSkipVerify skips the verification of the mirror tls certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// DockerPortMapping publishes a port of the nodes of a worker node group on the host.
+type DockerPortMapping struct {
+	// WorkerNodeGroup is the name of the worker node group the traffic is sent to.
+	WorkerNodeGroup string `json:"workerNodeGroup"`
+	// HostPort is the port published on the host.
+	HostPort int `json:"hostPort"`
+	// NodePort is the port the traffic is sent to on the nodes, usually the hostPort or nodePort of an ingress controller.
+	NodePort int `json:"nodePort"`
+	// ListenAddress is the host address the port is published on. Defaults to 127.0.0.1.
+	ListenAddress string `json:"listenAddress,omitempty"`
 }
 
 // DockerDatacenterConfigStatus defines the observed state of DockerDatacenterConfig
@@ -72,7 +109,13 @@ func (d *DockerDatacenterConfig) Marshallable() Marshallable {
 }
 
 func (d *DockerDatacenterConfig) Validate() error {
-	return nil
+	if err := validateDockerMounts(d.Spec.ExtraMounts); err != nil {
+		return err
+	}
+	if err := validateDockerRegistryMirror(d.Spec.RegistryMirror); err != nil {
+		return err
+	}
+	return validateDockerPortMappings(d.Spec.PortMappings)
 }
 
 // +kubebuilder:object:generate=false
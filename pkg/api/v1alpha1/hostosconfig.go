@@ -0,0 +1,185 @@
+package v1alpha1
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+var (
+	journaldSizeRegex     = regexp.MustCompile(`^\d+[KMGTPE]?$`)
+	journaldTimeSpanRegex = regexp.MustCompile(`^(\d+ ?(us|usec|ms|msec|s|sec|second|seconds|m|min|minute|minutes|h|hr|hour|hours|d|day|days|w|week|weeks|M|month|months|y|year|years)? ?)+$`)
+	certBundleNameRegex   = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	bootconfigKeyRegex    = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+)
+
+// ValidateHostOSConfig validates the host OS configuration of a machine config for the given OS family.
+func ValidateHostOSConfig(hostOSConfig *HostOSConfiguration, osFamily OSFamily) error {
+	if hostOSConfig == nil {
+		return nil
+	}
+
+	if osFamily == Bottlerocket {
+		return validateBottlerocketHostOSConfig(hostOSConfig)
+	}
+
+	if hostOSConfig.BottlerocketConfiguration != nil {
+		return fmt.Errorf("bottlerocketConfiguration can only be used with osFamily: \"%s\"", Bottlerocket)
+	}
+
+	if err := validateNTPConfig(hostOSConfig.NTPConfiguration); err != nil {
+		return err
+	}
+
+	if err := validateCertBundles(hostOSConfig.CertBundles); err != nil {
+		return err
+	}
+
+	for key, value := range hostOSConfig.SysctlSettings {
+		if key == "" {
+			return errors.New("sysctlSettings keys can not be empty")
+		}
+		if strings.ContainsAny(key, "= \t\n") {
+			return fmt.Errorf("sysctlSettings key %q is not valid", key)
+		}
+		if strings.ContainsAny(value, "\n") {
+			return fmt.Errorf("sysctlSettings value for %s can not contain new lines", key)
+		}
+	}
+
+	return validateJournaldConfig(hostOSConfig.JournaldConfiguration)
+}
+
+// validateBottlerocketHostOSConfig validates the host OS configuration of a bottlerocket machine.
+// The bottlerocket bootstrap format doesn't render ntp, files nor preKubeadmCommands, so only the
+// settings passed to the kernel on boot are supported.
+func validateBottlerocketHostOSConfig(hostOSConfig *HostOSConfiguration) error {
+	if hostOSConfig.NTPConfiguration != nil {
+		return fmt.Errorf("hostOSConfiguration.ntpConfiguration is not supported for osFamily: \"%s\"", Bottlerocket)
+	}
+
+	if len(hostOSConfig.CertBundles) > 0 {
+		return fmt.Errorf("hostOSConfiguration.certBundles is not supported for osFamily: \"%s\"", Bottlerocket)
+	}
+
+	if hostOSConfig.JournaldConfiguration != nil {
+		return fmt.Errorf("hostOSConfiguration.journaldConfiguration is not supported for osFamily: \"%s\"", Bottlerocket)
+	}
+
+	for key, value := range hostOSConfig.SysctlSettings {
+		if !bootconfigKeyRegex.MatchString(key) {
+			return fmt.Errorf("sysctlSettings key %q can only contain alphanumeric characters, '.', '_' and '-' for osFamily: \"%s\"", key, Bottlerocket)
+		}
+		if err := validateBootconfigValue(value); err != nil {
+			return fmt.Errorf("sysctlSettings value for %s %v", key, err)
+		}
+	}
+
+	if hostOSConfig.BottlerocketConfiguration == nil || hostOSConfig.BottlerocketConfiguration.Boot == nil {
+		return nil
+	}
+
+	for key, values := range hostOSConfig.BottlerocketConfiguration.Boot.BootKernelParameters {
+		if !bootconfigKeyRegex.MatchString(key) {
+			return fmt.Errorf("bootKernelParameters key %q can only contain alphanumeric characters, '.', '_' and '-'", key)
+		}
+		for _, value := range values {
+			if err := validateBootconfigValue(value); err != nil {
+				return fmt.Errorf("bootKernelParameters value for %s %v", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateBootconfigValue(value string) error {
+	if strings.ContainsAny(value, "\"\n") {
+		return errors.New("can not contain quotes or new lines")
+	}
+	return nil
+}
+
+func validateNTPConfig(ntpConfig *NTPConfiguration) error {
+	if ntpConfig == nil {
+		return nil
+	}
+
+	if len(ntpConfig.Servers) == 0 {
+		return errors.New("ntpConfiguration.servers can not be empty")
+	}
+
+	for _, server := range ntpConfig.Servers {
+		if server == "" {
+			return errors.New("ntpConfiguration.servers can not contain empty values")
+		}
+		if strings.ContainsAny(server, " \t\n") {
+			return fmt.Errorf("ntpConfiguration.servers %q is not a valid server", server)
+		}
+	}
+
+	return nil
+}
+
+func validateCertBundles(certBundles []CertBundle) error {
+	names := make(map[string]struct{}, len(certBundles))
+	for _, bundle := range certBundles {
+		if bundle.Name == "" {
+			return errors.New("certBundles name can not be empty")
+		}
+		if !certBundleNameRegex.MatchString(bundle.Name) {
+			return fmt.Errorf("certBundles name %q can only contain alphanumeric characters, '.', '_' and '-'", bundle.Name)
+		}
+		if _, ok := names[bundle.Name]; ok {
+			return fmt.Errorf("certBundles name %s is duplicated", bundle.Name)
+		}
+		names[bundle.Name] = struct{}{}
+
+		if block, _ := pem.Decode([]byte(bundle.Data)); block == nil {
+			return fmt.Errorf("certBundles %s data is not a valid PEM encoded certificate", bundle.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateJournaldConfig(journaldConfig *JournaldConfiguration) error {
+	if journaldConfig == nil {
+		return nil
+	}
+
+	if journaldConfig.SystemMaxUse != "" && !journaldSizeRegex.MatchString(journaldConfig.SystemMaxUse) {
+		return fmt.Errorf("journaldConfiguration.systemMaxUse %s is not a valid size", journaldConfig.SystemMaxUse)
+	}
+
+	if journaldConfig.SystemMaxFileSize != "" && !journaldSizeRegex.MatchString(journaldConfig.SystemMaxFileSize) {
+		return fmt.Errorf("journaldConfiguration.systemMaxFileSize %s is not a valid size", journaldConfig.SystemMaxFileSize)
+	}
+
+	if journaldConfig.MaxRetentionSec != "" && !journaldTimeSpanRegex.MatchString(journaldConfig.MaxRetentionSec) {
+		return fmt.Errorf("journaldConfiguration.maxRetentionSec %s is not a valid time span", journaldConfig.MaxRetentionSec)
+	}
+
+	return nil
+}
+
+// Equal returns true if both host OS configurations render the same settings on the host.
+// A nil configuration is equal to an empty one.
+func (c *HostOSConfiguration) Equal(o *HostOSConfiguration) bool {
+	return equality.Semantic.DeepEqual(c.normalized(), o.normalized())
+}
+
+func (c *HostOSConfiguration) normalized() *HostOSConfiguration {
+	if c == nil {
+		return &HostOSConfiguration{}
+	}
+	n := c.DeepCopy()
+	for i := range n.CertBundles {
+		n.CertBundles[i].Data = strings.TrimSpace(n.CertBundles[i].Data)
+	}
+	return n
+}
@@ -0,0 +1,279 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const testCertBundleData = `-----BEGIN CERTIFICATE-----
+MIIBAQ==
+-----END CERTIFICATE-----
+`
+
+func TestValidateHostOSConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		hostOSConfig *HostOSConfiguration
+		osFamily     OSFamily
+		wantErr      string
+	}{
+		{
+			name:         "nil config",
+			hostOSConfig: nil,
+			osFamily:     Ubuntu,
+		},
+		{
+			name: "valid ubuntu config",
+			hostOSConfig: &HostOSConfiguration{
+				NTPConfiguration: &NTPConfiguration{Servers: []string{"time.example.com"}},
+				CertBundles:      []CertBundle{{Name: "corp-ca", Data: testCertBundleData}},
+				SysctlSettings:   map[string]string{"vm.max_map_count": "262144"},
+				JournaldConfiguration: &JournaldConfiguration{
+					SystemMaxUse:      "1G",
+					SystemMaxFileSize: "100M",
+					MaxRetentionSec:   "1week",
+				},
+			},
+			osFamily: Ubuntu,
+		},
+		{
+			name:         "empty bottlerocket config",
+			hostOSConfig: &HostOSConfiguration{},
+			osFamily:     Bottlerocket,
+		},
+		{
+			name: "valid bottlerocket config",
+			hostOSConfig: &HostOSConfiguration{
+				SysctlSettings: map[string]string{"vm.max_map_count": "262144"},
+				BottlerocketConfiguration: &BottlerocketConfiguration{
+					Boot: &BottlerocketBootSettings{
+						BootKernelParameters: map[string][]string{"console": {"tty0", "ttyS0,115200n8"}, "quiet": nil},
+					},
+				},
+			},
+			osFamily: Bottlerocket,
+		},
+		{
+			name: "bottlerocket ntp servers",
+			hostOSConfig: &HostOSConfiguration{
+				NTPConfiguration: &NTPConfiguration{Servers: []string{"time.example.com"}},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "hostOSConfiguration.ntpConfiguration is not supported for osFamily: \"bottlerocket\"",
+		},
+		{
+			name: "bottlerocket cert bundles",
+			hostOSConfig: &HostOSConfiguration{
+				CertBundles: []CertBundle{{Name: "corp-ca", Data: testCertBundleData}},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "hostOSConfiguration.certBundles is not supported for osFamily: \"bottlerocket\"",
+		},
+		{
+			name: "bottlerocket journald",
+			hostOSConfig: &HostOSConfiguration{
+				JournaldConfiguration: &JournaldConfiguration{SystemMaxUse: "1G"},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "hostOSConfiguration.journaldConfiguration is not supported for osFamily: \"bottlerocket\"",
+		},
+		{
+			name: "bottlerocket sysctl key with slashes",
+			hostOSConfig: &HostOSConfiguration{
+				SysctlSettings: map[string]string{"net/ipv4/ip_forward": "1"},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "sysctlSettings key \"net/ipv4/ip_forward\" can only contain alphanumeric characters",
+		},
+		{
+			name: "bottlerocket sysctl value with quotes",
+			hostOSConfig: &HostOSConfiguration{
+				SysctlSettings: map[string]string{"vm.max_map_count": "1\" }"},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "sysctlSettings value for vm.max_map_count can not contain quotes or new lines",
+		},
+		{
+			name: "bottlerocket invalid boot kernel parameter",
+			hostOSConfig: &HostOSConfiguration{
+				BottlerocketConfiguration: &BottlerocketConfiguration{
+					Boot: &BottlerocketBootSettings{
+						BootKernelParameters: map[string][]string{"console }": {"tty0"}},
+					},
+				},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "bootKernelParameters key \"console }\" can only contain alphanumeric characters",
+		},
+		{
+			name: "bottlerocket boot kernel parameter value with new lines",
+			hostOSConfig: &HostOSConfiguration{
+				BottlerocketConfiguration: &BottlerocketConfiguration{
+					Boot: &BottlerocketBootSettings{
+						BootKernelParameters: map[string][]string{"console": {"tty0\n}"}},
+					},
+				},
+			},
+			osFamily: Bottlerocket,
+			wantErr:  "bootKernelParameters value for console can not contain quotes or new lines",
+		},
+		{
+			name: "bottlerocket configuration on ubuntu",
+			hostOSConfig: &HostOSConfiguration{
+				BottlerocketConfiguration: &BottlerocketConfiguration{},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "bottlerocketConfiguration can only be used with osFamily: \"bottlerocket\"",
+		},
+		{
+			name: "empty ntp servers",
+			hostOSConfig: &HostOSConfiguration{
+				NTPConfiguration: &NTPConfiguration{},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "ntpConfiguration.servers can not be empty",
+		},
+		{
+			name: "empty ntp server value",
+			hostOSConfig: &HostOSConfiguration{
+				NTPConfiguration: &NTPConfiguration{Servers: []string{""}},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "ntpConfiguration.servers can not contain empty values",
+		},
+		{
+			name: "cert bundle without name",
+			hostOSConfig: &HostOSConfiguration{
+				CertBundles: []CertBundle{{Data: testCertBundleData}},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "certBundles name can not be empty",
+		},
+		{
+			name: "duplicated cert bundle name",
+			hostOSConfig: &HostOSConfiguration{
+				CertBundles: []CertBundle{{Name: "ca", Data: testCertBundleData}, {Name: "ca", Data: testCertBundleData}},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "certBundles name ca is duplicated",
+		},
+		{
+			name: "cert bundle with invalid data",
+			hostOSConfig: &HostOSConfiguration{
+				CertBundles: []CertBundle{{Name: "ca", Data: "not a certificate"}},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "certBundles ca data is not a valid PEM encoded certificate",
+		},
+		{
+			name: "ntp server with spaces",
+			hostOSConfig: &HostOSConfiguration{
+				NTPConfiguration: &NTPConfiguration{Servers: []string{"time.example.com other"}},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "ntpConfiguration.servers \"time.example.com other\" is not a valid server",
+		},
+		{
+			name: "cert bundle name with path",
+			hostOSConfig: &HostOSConfiguration{
+				CertBundles: []CertBundle{{Name: "../ca", Data: testCertBundleData}},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "certBundles name \"../ca\" can only contain alphanumeric characters",
+		},
+		{
+			name: "invalid sysctl key",
+			hostOSConfig: &HostOSConfiguration{
+				SysctlSettings: map[string]string{"vm.max_map_count = 1\nkernel.pid_max": "1"},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "is not valid",
+		},
+		{
+			name: "sysctl value with new lines",
+			hostOSConfig: &HostOSConfiguration{
+				SysctlSettings: map[string]string{"vm.max_map_count": "1\nkernel.pid_max = 1"},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "sysctlSettings value for vm.max_map_count can not contain new lines",
+		},
+		{
+			name: "invalid journald size",
+			hostOSConfig: &HostOSConfiguration{
+				JournaldConfiguration: &JournaldConfiguration{SystemMaxUse: "1GiB"},
+			},
+			osFamily: RedHat,
+			wantErr:  "journaldConfiguration.systemMaxUse 1GiB is not a valid size",
+		},
+		{
+			name: "valid journald max retention with multiple units",
+			hostOSConfig: &HostOSConfiguration{
+				JournaldConfiguration: &JournaldConfiguration{MaxRetentionSec: "1d 12h"},
+			},
+			osFamily: Ubuntu,
+		},
+		{
+			name: "invalid journald max retention",
+			hostOSConfig: &HostOSConfiguration{
+				JournaldConfiguration: &JournaldConfiguration{MaxRetentionSec: "1 fortnight\nStorage=none"},
+			},
+			osFamily: Ubuntu,
+			wantErr:  "journaldConfiguration.maxRetentionSec 1 fortnight\nStorage=none is not a valid time span",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateHostOSConfig(tt.hostOSConfig, tt.osFamily)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestHostOSConfigurationEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *HostOSConfiguration
+		want bool
+	}{
+		{
+			name: "both nil",
+			want: true,
+		},
+		{
+			name: "nil and empty",
+			a:    &HostOSConfiguration{SysctlSettings: map[string]string{}},
+			want: true,
+		},
+		{
+			name: "cert data differs in trailing whitespace",
+			a:    &HostOSConfiguration{CertBundles: []CertBundle{{Name: "ca", Data: testCertBundleData}}},
+			b:    &HostOSConfiguration{CertBundles: []CertBundle{{Name: "ca", Data: testCertBundleData + "\n"}}},
+			want: true,
+		},
+		{
+			name: "ntp servers differ",
+			a:    &HostOSConfiguration{NTPConfiguration: &NTPConfiguration{Servers: []string{"a"}}},
+			b:    &HostOSConfiguration{NTPConfiguration: &NTPConfiguration{Servers: []string{"b"}}},
+			want: false,
+		},
+		{
+			name: "sysctl settings differ",
+			a:    &HostOSConfiguration{SysctlSettings: map[string]string{"a": "1"}},
+			b:    &HostOSConfiguration{SysctlSettings: map[string]string{"a": "2"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.a.Equal(tt.b)).To(Equal(tt.want))
+			g.Expect(tt.b.Equal(tt.a)).To(Equal(tt.want))
+		})
+	}
+}
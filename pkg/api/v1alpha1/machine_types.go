@@ -13,3 +13,65 @@ type UserConfiguration struct {
 	Name              string   `json:"name"`
 	SshAuthorizedKeys []string `json:"sshAuthorizedKeys"`
 }
+
+// HostOSConfiguration defines the configuration settings on the host OS.
+// It is rendered into the cloud-config of the machines, or into the boot configuration of Bottlerocket machines.
+type HostOSConfiguration struct {
+	// +optional
+	NTPConfiguration *NTPConfiguration `json:"ntpConfiguration,omitempty"`
+
+	// CertBundles are additional trusted CA certificates installed on the host.
+	// +optional
+	CertBundles []CertBundle `json:"certBundles,omitempty"`
+
+	// SysctlSettings are kernel parameters applied at runtime with sysctl.
+	// On Bottlerocket they are passed to the kernel on boot.
+	// +optional
+	SysctlSettings map[string]string `json:"sysctlSettings,omitempty"`
+
+	// +optional
+	BottlerocketConfiguration *BottlerocketConfiguration `json:"bottlerocketConfiguration,omitempty"`
+
+	// +optional
+	JournaldConfiguration *JournaldConfiguration `json:"journaldConfiguration,omitempty"`
+}
+
+// NTPConfiguration defines the NTP configuration on the host OS.
+type NTPConfiguration struct {
+	// Servers defines a list of NTP servers to be configured on the host OS.
+	Servers []string `json:"servers"`
+}
+
+// CertBundle defines a trusted CA certificate installed on the host OS.
+type CertBundle struct {
+	// Name is the name of the certificate bundle.
+	Name string `json:"name"`
+	// Data is the PEM encoded CA certificate.
+	Data string `json:"data"`
+}
+
+// BottlerocketConfiguration defines the Bottlerocket configuration on the host OS.
+type BottlerocketConfiguration struct {
+	// Boot defines the boot settings for bottlerocket.
+	// +optional
+	Boot *BottlerocketBootSettings `json:"boot,omitempty"`
+}
+
+// BottlerocketBootSettings defines the boot settings for bottlerocket.
+type BottlerocketBootSettings struct {
+	// BootKernelParameters are the kernel parameters passed on boot, keyed by parameter name.
+	BootKernelParameters map[string][]string `json:"bootKernelParameters,omitempty"`
+}
+
+// JournaldConfiguration defines the systemd-journald limits on the host OS.
+type JournaldConfiguration struct {
+	// SystemMaxUse limits the disk space used by the journal, e.g. 1G.
+	// +optional
+	SystemMaxUse string `json:"systemMaxUse,omitempty"`
+	// SystemMaxFileSize limits the size of individual journal files, e.g. 100M.
+	// +optional
+	SystemMaxFileSize string `json:"systemMaxFileSize,omitempty"`
+	// MaxRetentionSec is the maximum time to keep journal entries, e.g. 1week.
+	// +optional
+	MaxRetentionSec string `json:"maxRetentionSec,omitempty"`
+}
@@ -14,6 +14,7 @@ const (
 	DefaultSnowSshKeyName                   = "default"
 	DefaultSnowInstanceType                 = SbeCLarge
 	DefaultSnowPhysicalNetworkConnectorType = SFPPlus
+	DefaultSnowPlacementStrategy            = SpreadPlacement
 	MinimumContainerVolumeSize              = 8
 )
 
@@ -61,6 +62,12 @@ func validateSnowMachineConfig(config *SnowMachineConfig) error {
 		return errors.New("SnowMachineConfig Devices must contain at least one device IP")
 	}
 
+	switch config.Spec.PlacementStrategy {
+	case "", SpreadPlacement, PinnedPlacement:
+	default:
+		return fmt.Errorf("SnowMachineConfig PlacementStrategy %s is not supported, please use one of the following: %s, %s", config.Spec.PlacementStrategy, SpreadPlacement, PinnedPlacement)
+	}
+
 	return nil
 }
 
@@ -74,4 +81,9 @@ func setSnowMachineConfigDefaults(config *SnowMachineConfig) {
 		config.Spec.PhysicalNetworkConnector = DefaultSnowPhysicalNetworkConnectorType
 		logger.V(1).Info("SnowMachineConfig PhysicalNetworkConnector is empty. Using default", "default physical network connector", DefaultSnowPhysicalNetworkConnectorType)
 	}
+
+	if config.Spec.PlacementStrategy == "" {
+		config.Spec.PlacementStrategy = DefaultSnowPlacementStrategy
+		logger.V(1).Info("SnowMachineConfig PlacementStrategy is empty. Using default", "default placement strategy", DefaultSnowPlacementStrategy)
+	}
 }
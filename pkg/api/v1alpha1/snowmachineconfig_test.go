@@ -24,6 +24,7 @@ func TestSnowMachineConfigSetDefaults(t *testing.T) {
 				Spec: SnowMachineConfigSpec{
 					InstanceType:             DefaultSnowInstanceType,
 					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					PlacementStrategy:        DefaultSnowPlacementStrategy,
 				},
 			},
 		},
@@ -38,6 +39,7 @@ func TestSnowMachineConfigSetDefaults(t *testing.T) {
 				Spec: SnowMachineConfigSpec{
 					InstanceType:             "instance-type-1",
 					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					PlacementStrategy:        DefaultSnowPlacementStrategy,
 				},
 			},
 		},
@@ -53,6 +55,22 @@ func TestSnowMachineConfigSetDefaults(t *testing.T) {
 					SshKeyName:               "ssh-name",
 					InstanceType:             DefaultSnowInstanceType,
 					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					PlacementStrategy:        DefaultSnowPlacementStrategy,
+				},
+			},
+		},
+		{
+			name: "placement strategy exists",
+			before: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					PlacementStrategy: PinnedPlacement,
+				},
+			},
+			after: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					InstanceType:             DefaultSnowInstanceType,
+					PhysicalNetworkConnector: DefaultSnowPhysicalNetworkConnectorType,
+					PlacementStrategy:        PinnedPlacement,
 				},
 			},
 		},
@@ -67,6 +85,7 @@ func TestSnowMachineConfigSetDefaults(t *testing.T) {
 				Spec: SnowMachineConfigSpec{
 					PhysicalNetworkConnector: "network-1",
 					InstanceType:             DefaultSnowInstanceType,
+					PlacementStrategy:        DefaultSnowPlacementStrategy,
 				},
 			},
 		},
@@ -142,6 +161,42 @@ func TestSnowMachineConfigValidate(t *testing.T) {
 			},
 			wantErr: "ContainersVolume.Size must be no smaller than 8 Gi",
 		},
+		{
+			name: "valid pinned placement",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:             "ami-1",
+					InstanceType:      DefaultSnowInstanceType,
+					Devices:           []string{"1.2.3.4"},
+					PlacementStrategy: PinnedPlacement,
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "pinned placement with multiple devices",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:             "ami-1",
+					InstanceType:      DefaultSnowInstanceType,
+					Devices:           []string{"1.2.3.4", "1.2.3.5"},
+					PlacementStrategy: PinnedPlacement,
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid placement strategy",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:             "ami-1",
+					InstanceType:      DefaultSnowInstanceType,
+					Devices:           []string{"1.2.3.4"},
+					PlacementStrategy: "random",
+				},
+			},
+			wantErr: "PlacementStrategy random is not supported",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -14,12 +14,17 @@ const (
 	SbeCXLarge  SnowInstanceType = "sbe-c.xlarge"
 	SbeC2XLarge SnowInstanceType = "sbe-c.2xlarge"
 	SbeC4XLarge SnowInstanceType = "sbe-c.4xlarge"
+
+	SpreadPlacement SnowPlacementStrategy = "spread"
+	PinnedPlacement SnowPlacementStrategy = "pinned"
 )
 
 type PhysicalNetworkConnectorType string
 
 type SnowInstanceType string
 
+type SnowPlacementStrategy string
+
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 // Important: Run "make generate" to regenerate code after modifying this file
 
@@ -44,6 +49,12 @@ type SnowMachineConfigSpec struct {
 	// Devices contains a device ip list assigned by the user to provision machines.
 	Devices []string `json:"devices,omitempty"`
 
+	// PlacementStrategy determines how the machines using this config are placed on the devices.
+	// Valid values: "spread" (default) distributes the machines across all the devices and "pinned"
+	// places all the machines of each worker node group on a single device, the n-th worker node group
+	// using this config on the n-th device. Control plane and etcd machines are always spread.
+	PlacementStrategy SnowPlacementStrategy `json:"placementStrategy,omitempty"`
+
 	// ContainersVolume provides the configuration options for the containers data storage volume.
 	ContainersVolume *snowv1.Volume `json:"containersVolume,omitempty"`
 }
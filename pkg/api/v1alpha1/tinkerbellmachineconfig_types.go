@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"encoding/json"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,6 +15,69 @@ type TinkerbellMachineConfigSpec struct {
 	TemplateRef      Ref                 `json:"templateRef,omitempty"`
 	OSFamily         OSFamily            `json:"osFamily"`
 	Users            []UserConfiguration `json:"users,omitempty"`
+	// +optional
+	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+	// InstallDisk selects the disk the operating system is installed on for each machine by the
+	// attributes of the disks listed in its hardware, instead of using the hardware disk.
+	// +optional
+	InstallDisk *DiskSelector `json:"installDisk,omitempty"`
+	// AdditionalDisks are formatted and mounted on each machine after the operating system is installed.
+	// +optional
+	AdditionalDisks []AdditionalDisk `json:"additionalDisks,omitempty"`
+	// DeprovisionPolicy wipes the disks of each machine and powers it off through its BMC when it
+	// leaves the cluster, on cluster deletion or scale down. The hardware is only returned to the
+	// pool of available hardware once its disks are wiped.
+	// +optional
+	DeprovisionPolicy *DeprovisionPolicy `json:"deprovisionPolicy,omitempty"`
+}
+
+// DiskWipeMethod is the method used to wipe the disks of a machine.
+type DiskWipeMethod string
+
+const (
+	// SecureEraseDiskWipe issues a secure discard of all the blocks of each disk.
+	SecureEraseDiskWipe DiskWipeMethod = "secureErase"
+	// ZeroDiskWipe overwrites each disk with zeroes.
+	ZeroDiskWipe DiskWipeMethod = "zero"
+)
+
+// DeprovisionPolicy defines how a machine is deprovisioned when it leaves a cluster.
+type DeprovisionPolicy struct {
+	// WipeMethod is the method used to wipe the disks: secureErase or zero.
+	WipeMethod DiskWipeMethod `json:"wipeMethod"`
+	// WipeImage is the image of the action wiping the disks. It must provide the util-linux
+	// blkdiscard command.
+	WipeImage string `json:"wipeImage"`
+}
+
+// DiskSelector selects a disk of a machine by its attributes. A disk must match all the attributes set.
+type DiskSelector struct {
+	// MinSize is the minimum size of the disk.
+	// +optional
+	MinSize *resource.Quantity `json:"minSize,omitempty"`
+	// MaxSize is the maximum size of the disk.
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+	// Model is a substring of the disk model.
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Rotational selects hard drives when true and solid state drives when false.
+	// +optional
+	Rotational *bool `json:"rotational,omitempty"`
+	// ByID is a shell pattern matched against the /dev/disk/by-id path of the disk, e.g. /dev/disk/by-id/nvme-*.
+	// +optional
+	ByID string `json:"byId,omitempty"`
+}
+
+// AdditionalDisk is a disk formatted and mounted on a machine.
+type AdditionalDisk struct {
+	// Selector selects the disk among the disks of the machine that aren't already used.
+	Selector DiskSelector `json:"selector"`
+	// MountPath is the absolute path the disk is mounted on, e.g. /var/lib/containerd.
+	MountPath string `json:"mountPath"`
+	// FSType is the file system the disk is formatted with. Defaults to ext4.
+	// +optional
+	FSType string `json:"fsType,omitempty"`
 }
 
 // HardwareSelector models a simple key-value selector used in Tinkerbell provisioning.
@@ -38,6 +38,8 @@ warnings:
 
 func getDiskPart(disk string) string {
 	switch {
+	case strings.HasPrefix(disk, "/dev/disk/by-id/"):
+		return fmt.Sprintf("%s-part", disk)
 	case strings.Contains(disk, "nvme"):
 		return fmt.Sprintf("%sp", disk)
 	default:
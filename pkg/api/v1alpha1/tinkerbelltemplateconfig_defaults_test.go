@@ -305,3 +305,15 @@ func givenVersionBundle() v1alpha1.VersionsBundle {
 		},
 	}
 }
+
+func TestGetDiskPart(t *testing.T) {
+	for disk, want := range map[string]string{
+		"/dev/sda":                     "/dev/sda",
+		"/dev/nvme0n1":                 "/dev/nvme0n1p",
+		"/dev/disk/by-id/nvme-SAMSUNG": "/dev/disk/by-id/nvme-SAMSUNG-part",
+	} {
+		if got := getDiskPart(disk); got != want {
+			t.Errorf("getDiskPart(%s) = %s, want %s", disk, got, want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -15,7 +16,10 @@ const VSphereDatacenterKind = "VSphereDatacenterConfig"
 type folderType string
 
 const (
-	networkFolderType folderType = "network"
+	networkFolderType   folderType = "network"
+	hostFolderType      folderType = "host"
+	datastoreFolderType folderType = "datastore"
+	vmFolderType        folderType = "vm"
 )
 
 // Used for generating yaml for generate clusterconfig command
@@ -77,3 +81,43 @@ func validatePath(foldType folderType, folderPath string, datacenter string) err
 
 	return nil
 }
+
+func setFailureDomainDefaults(failureDomain *VSphereFailureDomain, datacenter string) {
+	failureDomain.ComputeCluster = generateFullVCenterPath(hostFolderType, failureDomain.ComputeCluster, datacenter)
+	failureDomain.Datastore = generateFullVCenterPath(datastoreFolderType, failureDomain.Datastore, datacenter)
+	failureDomain.Folder = generateFullVCenterPath(vmFolderType, failureDomain.Folder, datacenter)
+	failureDomain.Network = generateFullVCenterPath(networkFolderType, failureDomain.Network, datacenter)
+}
+
+func validateFailureDomains(failureDomains []VSphereFailureDomain, datacenter string) error {
+	names := make(map[string]struct{}, len(failureDomains))
+	for _, failureDomain := range failureDomains {
+		if errs := validation.IsDNS1123Label(failureDomain.Name); len(errs) > 0 {
+			return fmt.Errorf("VSphereDatacenterConfig failure domain name %q is invalid: %s", failureDomain.Name, strings.Join(errs, ", "))
+		}
+		if _, ok := names[failureDomain.Name]; ok {
+			return fmt.Errorf("VSphereDatacenterConfig failure domain %s is duplicated", failureDomain.Name)
+		}
+		names[failureDomain.Name] = struct{}{}
+
+		if len(failureDomain.ComputeCluster) <= 0 {
+			return fmt.Errorf("VSphereDatacenterConfig failure domain %s computeCluster is not set or is empty", failureDomain.Name)
+		}
+		if len(failureDomain.ResourcePool) <= 0 {
+			return fmt.Errorf("VSphereDatacenterConfig failure domain %s resourcePool is not set or is empty", failureDomain.Name)
+		}
+		if len(failureDomain.Datastore) <= 0 {
+			return fmt.Errorf("VSphereDatacenterConfig failure domain %s datastore is not set or is empty", failureDomain.Name)
+		}
+		if err := validatePath(hostFolderType, failureDomain.ComputeCluster, datacenter); err != nil {
+			return fmt.Errorf("VSphereDatacenterConfig failure domain %s computeCluster: %v", failureDomain.Name, err)
+		}
+		if failureDomain.Network != "" {
+			if err := validatePath(networkFolderType, failureDomain.Network, datacenter); err != nil {
+				return fmt.Errorf("VSphereDatacenterConfig failure domain %s network: %v", failureDomain.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
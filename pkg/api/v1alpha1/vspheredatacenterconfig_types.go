@@ -19,6 +19,25 @@ type VSphereDatacenterConfigSpec struct {
 	Server     string `json:"server"`
 	Thumbprint string `json:"thumbprint"`
 	Insecure   bool   `json:"insecure"`
+	// FailureDomains are the vSphere failure domains machines can be spread across.
+	// Each failure domain maps to a compute cluster, resource pool and datastore of the datacenter.
+	FailureDomains []VSphereFailureDomain `json:"failureDomains,omitempty"`
+}
+
+// VSphereFailureDomain defines a set of vSphere resources machines can be placed in.
+type VSphereFailureDomain struct {
+	// Name is the name of the failure domain referenced by the machine configs.
+	Name string `json:"name"`
+	// ComputeCluster is the vSphere compute cluster of the failure domain.
+	ComputeCluster string `json:"computeCluster"`
+	// ResourcePool is the resource pool machines are created in.
+	ResourcePool string `json:"resourcePool"`
+	// Datastore is the datastore machines are created in.
+	Datastore string `json:"datastore"`
+	// Folder is the VM folder machines are created in. Defaults to the folder of the machine config.
+	Folder string `json:"folder,omitempty"`
+	// Network is the VM network of the failure domain. Defaults to the network of the datacenter config.
+	Network string `json:"network,omitempty"`
 }
 
 // VSphereDatacenterConfigStatus defines the observed state of VSphereDatacenterConfig
@@ -76,6 +95,9 @@ func (v *VSphereDatacenterConfig) ClearPauseAnnotation() {
 
 func (v *VSphereDatacenterConfig) SetDefaults() {
 	v.Spec.Network = generateFullVCenterPath(networkFolderType, v.Spec.Network, v.Spec.Datacenter)
+	for i := range v.Spec.FailureDomains {
+		setFailureDomainDefaults(&v.Spec.FailureDomains[i], v.Spec.Datacenter)
+	}
 
 	if v.Spec.Insecure {
 		logger.Info("Warning: VSphereDatacenterConfig configured in insecure mode")
@@ -100,6 +122,20 @@ func (v *VSphereDatacenterConfig) ValidateFields() error {
 		return err
 	}
 
+	if err := validateFailureDomains(v.Spec.FailureDomains, v.Spec.Datacenter); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FailureDomain returns the failure domain with the given name, or nil if it's not defined.
+func (v *VSphereDatacenterConfig) FailureDomain(name string) *VSphereFailureDomain {
+	for i := range v.Spec.FailureDomains {
+		if v.Spec.FailureDomains[i].Name == name {
+			return &v.Spec.FailureDomains[i]
+		}
+	}
 	return nil
 }
 
@@ -121,6 +121,102 @@ func TestVSphereDatacenterConfigSetDefaults(t *testing.T) {
 	g.Expect(sOld.Spec.Network).To(Equal("/datacenter/network/network-1"))
 }
 
+func TestVSphereDatacenterConfigSetDefaultsFailureDomains(t *testing.T) {
+	g := NewWithT(t)
+
+	sOld := vsphereDatacenterConfig()
+	sOld.Spec.FailureDomains = []v1alpha1.VSphereFailureDomain{
+		{
+			Name:           "fd-1",
+			ComputeCluster: "cluster-1",
+			ResourcePool:   "*/Resources",
+			Datastore:      "datastore-1",
+			Network:        "network-1",
+		},
+	}
+	sOld.Default()
+
+	g.Expect(sOld.Spec.FailureDomains[0]).To(Equal(v1alpha1.VSphereFailureDomain{
+		Name:           "fd-1",
+		ComputeCluster: "/datacenter/host/cluster-1",
+		ResourcePool:   "*/Resources",
+		Datastore:      "/datacenter/datastore/datastore-1",
+		Network:        "/datacenter/network/network-1",
+	}))
+}
+
+func TestVSphereDatacenterValidateUpdateFailureDomains(t *testing.T) {
+	failureDomain := v1alpha1.VSphereFailureDomain{
+		Name:           "fd-1",
+		ComputeCluster: "cluster-1",
+		ResourcePool:   "*/Resources",
+		Datastore:      "datastore-1",
+	}
+	tests := []struct {
+		name           string
+		failureDomains func() []v1alpha1.VSphereFailureDomain
+		wantErr        string
+	}{
+		{
+			name: "valid",
+			failureDomains: func() []v1alpha1.VSphereFailureDomain {
+				fd2 := failureDomain
+				fd2.Name = "fd-2"
+				return []v1alpha1.VSphereFailureDomain{failureDomain, fd2}
+			},
+		},
+		{
+			name: "duplicated name",
+			failureDomains: func() []v1alpha1.VSphereFailureDomain {
+				return []v1alpha1.VSphereFailureDomain{failureDomain, failureDomain}
+			},
+			wantErr: "failure domain fd-1 is duplicated",
+		},
+		{
+			name: "invalid name",
+			failureDomains: func() []v1alpha1.VSphereFailureDomain {
+				fd := failureDomain
+				fd.Name = "FD_1"
+				return []v1alpha1.VSphereFailureDomain{fd}
+			},
+			wantErr: "failure domain name \"FD_1\" is invalid",
+		},
+		{
+			name: "missing datastore",
+			failureDomains: func() []v1alpha1.VSphereFailureDomain {
+				fd := failureDomain
+				fd.Datastore = ""
+				return []v1alpha1.VSphereFailureDomain{fd}
+			},
+			wantErr: "failure domain fd-1 datastore is not set or is empty",
+		},
+		{
+			name: "compute cluster outside of host folder",
+			failureDomains: func() []v1alpha1.VSphereFailureDomain {
+				fd := failureDomain
+				fd.ComputeCluster = "/datacenter/vm/cluster-1"
+				return []v1alpha1.VSphereFailureDomain{fd}
+			},
+			wantErr: "failure domain fd-1 computeCluster: invalid path",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			vOld := vsphereDatacenterConfig()
+			c := vOld.DeepCopy()
+			c.Spec.FailureDomains = tt.failureDomains()
+
+			err := c.ValidateUpdate(&vOld)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
 func vsphereDatacenterConfig() v1alpha1.VSphereDatacenterConfig {
 	return v1alpha1.VSphereDatacenterConfig{
 		TypeMeta:   metav1.TypeMeta{},
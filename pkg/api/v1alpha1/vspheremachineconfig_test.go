@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -224,3 +225,19 @@ func TestGetVSphereMachineConfigs(t *testing.T) {
 		})
 	}
 }
+
+func TestVSphereMachineConfigValidateBottlerocketHostOSConfig(t *testing.T) {
+	g := NewWithT(t)
+	config := &VSphereMachineConfig{
+		Spec: VSphereMachineConfigSpec{
+			OSFamily: Bottlerocket,
+			HostOSConfiguration: &HostOSConfiguration{
+				SysctlSettings: map[string]string{"vm.max_map_count": "262144"},
+			},
+		},
+	}
+	g.Expect(config.Validate()).To(MatchError("hostOSConfiguration is not supported for osFamily: \"bottlerocket\" on vSphere"))
+
+	config.Spec.HostOSConfiguration = &HostOSConfiguration{}
+	g.Expect(config.Validate()).To(Succeed())
+}
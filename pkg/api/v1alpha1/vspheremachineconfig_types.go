@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,6 +20,12 @@ type VSphereMachineConfigSpec struct {
 	StoragePolicyName string              `json:"storagePolicyName,omitempty"`
 	Template          string              `json:"template,omitempty"`
 	Users             []UserConfiguration `json:"users,omitempty"`
+	// FailureDomains are the names of the datacenter config failure domains the machines are spread across.
+	// Worker node machine configs support at most one failure domain.
+	// +optional
+	FailureDomains []string `json:"failureDomains,omitempty"`
+	// +optional
+	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
 }
 
 func (c *VSphereMachineConfig) PauseReconcile() {
@@ -122,7 +130,13 @@ func (c *VSphereMachineConfig) SetDefaults() {
 }
 
 func (c *VSphereMachineConfig) Validate() error {
-	return nil
+	// Bottlerocket settings are only rendered in the boot configuration written when provisioning bare metal
+	// machines, vSphere machines boot with the configuration of their template.
+	if c.Spec.OSFamily == Bottlerocket && !c.Spec.HostOSConfiguration.Equal(nil) {
+		return fmt.Errorf("hostOSConfiguration is not supported for osFamily: \"%s\" on vSphere", Bottlerocket)
+	}
+
+	return ValidateHostOSConfig(c.Spec.HostOSConfiguration, c.Spec.OSFamily)
 }
 
 // +kubebuilder:object:generate=false
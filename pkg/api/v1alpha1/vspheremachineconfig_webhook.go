@@ -135,6 +135,13 @@ func validateImmutableFieldsVSphereMachineConfig(new, old *VSphereMachineConfig)
 		)
 	}
 
+	if !reflect.DeepEqual(old.Spec.FailureDomains, new.Spec.FailureDomains) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("failureDomains"), "field is immutable"),
+		)
+	}
+
 	if old.Spec.MemoryMiB != new.Spec.MemoryMiB {
 		allErrs = append(
 			allErrs,
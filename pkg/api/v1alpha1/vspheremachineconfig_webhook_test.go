@@ -53,6 +53,27 @@ func TestWorkloadWorkersVSphereMachineValidateUpdateTemplateSuccess(t *testing.T
 	g.Expect(c.ValidateUpdate(&vOld)).To(Succeed())
 }
 
+func TestManagementCPVSphereMachineValidateUpdateFailureDomainsImmutable(t *testing.T) {
+	vOld := vsphereMachineConfig()
+	vOld.SetControlPlane()
+	vOld.Spec.FailureDomains = []string{"fd-1"}
+	c := vOld.DeepCopy()
+
+	c.Spec.FailureDomains = []string{"fd-1", "fd-2"}
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(&vOld)).NotTo(Succeed())
+}
+
+func TestManagementWorkersVSphereMachineValidateUpdateFailureDomainsSuccess(t *testing.T) {
+	vOld := vsphereMachineConfig()
+	vOld.Spec.FailureDomains = []string{"fd-1"}
+	c := vOld.DeepCopy()
+
+	c.Spec.FailureDomains = []string{"fd-2"}
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(&vOld)).To(Succeed())
+}
+
 func TestManagementEtcdVSphereMachineValidateUpdateTemplateImmutable(t *testing.T) {
 	vOld := vsphereMachineConfig()
 	vOld.SetEtcd()
@@ -222,6 +222,22 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalDisk) DeepCopyInto(out *AdditionalDisk) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalDisk.
+func (in *AdditionalDisk) DeepCopy() *AdditionalDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingConfiguration) DeepCopyInto(out *AutoScalingConfiguration) {
 	*out = *in
@@ -237,6 +253,56 @@ func (in *AutoScalingConfiguration) DeepCopy() *AutoScalingConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketBootSettings) DeepCopyInto(out *BottlerocketBootSettings) {
+	*out = *in
+	if in.BootKernelParameters != nil {
+		in, out := &in.BootKernelParameters, &out.BootKernelParameters
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketBootSettings.
+func (in *BottlerocketBootSettings) DeepCopy() *BottlerocketBootSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketBootSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BottlerocketConfiguration) DeepCopyInto(out *BottlerocketConfiguration) {
+	*out = *in
+	if in.Boot != nil {
+		in, out := &in.Boot, &out.Boot
+		*out = new(BottlerocketBootSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BottlerocketConfiguration.
+func (in *BottlerocketConfiguration) DeepCopy() *BottlerocketConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BottlerocketConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundlesRef) DeepCopyInto(out *BundlesRef) {
 	*out = *in
@@ -258,7 +324,7 @@ func (in *CNIConfig) DeepCopyInto(out *CNIConfig) {
 	if in.Cilium != nil {
 		in, out := &in.Cilium, &out.Cilium
 		*out = new(CiliumConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Kindnetd != nil {
 		in, out := &in.Kindnetd, &out.Kindnetd
@@ -277,9 +343,36 @@ func (in *CNIConfig) DeepCopy() *CNIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertBundle) DeepCopyInto(out *CertBundle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertBundle.
+func (in *CertBundle) DeepCopy() *CertBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(CertBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
 	*out = *in
+	if in.Hubble != nil {
+		in, out := &in.Hubble, &out.Hubble
+		*out = new(CiliumHubbleConfig)
+		**out = **in
+	}
+	if in.HelmValues != nil {
+		in, out := &in.HelmValues, &out.HelmValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumConfig.
@@ -292,6 +385,21 @@ func (in *CiliumConfig) DeepCopy() *CiliumConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumHubbleConfig) DeepCopyInto(out *CiliumHubbleConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumHubbleConfig.
+func (in *CiliumHubbleConfig) DeepCopy() *CiliumHubbleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumHubbleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudStackAvailabilityZone) DeepCopyInto(out *CloudStackAvailabilityZone) {
 	*out = *in
@@ -502,6 +610,11 @@ func (in *CloudStackMachineConfigSpec) DeepCopyInto(out *CloudStackMachineConfig
 			(*out)[key] = val
 		}
 	}
+	if in.HostOSConfiguration != nil {
+		in, out := &in.HostOSConfiguration, &out.HostOSConfiguration
+		*out = new(HostOSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudStackMachineConfigSpec.
@@ -712,6 +825,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(BundlesRef)
 		**out = **in
 	}
+	if in.WorkerNodeGroupUpgradeStrategy != nil {
+		in, out := &in.WorkerNodeGroupUpgradeStrategy, &out.WorkerNodeGroupUpgradeStrategy
+		*out = new(WorkerNodeGroupUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = new(ValidationsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -783,6 +906,11 @@ func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration
 			(*out)[key] = val
 		}
 	}
+	if in.UpgradeRolloutStrategy != nil {
+		in, out := &in.UpgradeRolloutStrategy, &out.UpgradeRolloutStrategy
+		*out = new(ControlPlaneUpgradeRolloutStrategy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfiguration.
@@ -795,6 +923,37 @@ func (in *ControlPlaneConfiguration) DeepCopy() *ControlPlaneConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneRollingUpdateParams) DeepCopyInto(out *ControlPlaneRollingUpdateParams) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneRollingUpdateParams.
+func (in *ControlPlaneRollingUpdateParams) DeepCopy() *ControlPlaneRollingUpdateParams {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneRollingUpdateParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopyInto(out *ControlPlaneUpgradeRolloutStrategy) {
+	*out = *in
+	out.RollingUpdate = in.RollingUpdate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeRolloutStrategy.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopy() *ControlPlaneUpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNS) DeepCopyInto(out *DNS) {
 	*out = *in
@@ -815,12 +974,57 @@ func (in *DNS) DeepCopy() *DNS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprovisionPolicy) DeepCopyInto(out *DeprovisionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprovisionPolicy.
+func (in *DeprovisionPolicy) DeepCopy() *DeprovisionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprovisionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskSelector) DeepCopyInto(out *DiskSelector) {
+	*out = *in
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Rotational != nil {
+		in, out := &in.Rotational, &out.Rotational
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskSelector.
+func (in *DiskSelector) DeepCopy() *DiskSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DockerDatacenterConfig) DeepCopyInto(out *DockerDatacenterConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -877,6 +1081,21 @@ func (in *DockerDatacenterConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DockerDatacenterConfigSpec) DeepCopyInto(out *DockerDatacenterConfigSpec) {
 	*out = *in
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = make([]DockerMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegistryMirror != nil {
+		in, out := &in.RegistryMirror, &out.RegistryMirror
+		*out = new(DockerRegistryMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PortMappings != nil {
+		in, out := &in.PortMappings, &out.PortMappings
+		*out = make([]DockerPortMapping, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerDatacenterConfigSpec.
@@ -904,6 +1123,56 @@ func (in *DockerDatacenterConfigStatus) DeepCopy() *DockerDatacenterConfigStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerMount) DeepCopyInto(out *DockerMount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerMount.
+func (in *DockerMount) DeepCopy() *DockerMount {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerPortMapping) DeepCopyInto(out *DockerPortMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerPortMapping.
+func (in *DockerPortMapping) DeepCopy() *DockerPortMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerPortMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerRegistryMirror) DeepCopyInto(out *DockerRegistryMirror) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerRegistryMirror.
+func (in *DockerRegistryMirror) DeepCopy() *DockerRegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerRegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EksdReleaseRef) DeepCopyInto(out *EksdReleaseRef) {
 	*out = *in
@@ -1225,6 +1494,63 @@ func (in HardwareSelector) DeepCopy() HardwareSelector {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOSConfiguration) DeepCopyInto(out *HostOSConfiguration) {
+	*out = *in
+	if in.NTPConfiguration != nil {
+		in, out := &in.NTPConfiguration, &out.NTPConfiguration
+		*out = new(NTPConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertBundles != nil {
+		in, out := &in.CertBundles, &out.CertBundles
+		*out = make([]CertBundle, len(*in))
+		copy(*out, *in)
+	}
+	if in.SysctlSettings != nil {
+		in, out := &in.SysctlSettings, &out.SysctlSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BottlerocketConfiguration != nil {
+		in, out := &in.BottlerocketConfiguration, &out.BottlerocketConfiguration
+		*out = new(BottlerocketConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JournaldConfiguration != nil {
+		in, out := &in.JournaldConfiguration, &out.JournaldConfiguration
+		*out = new(JournaldConfiguration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOSConfiguration.
+func (in *HostOSConfiguration) DeepCopy() *HostOSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JournaldConfiguration) DeepCopyInto(out *JournaldConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JournaldConfiguration.
+func (in *JournaldConfiguration) DeepCopy() *JournaldConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(JournaldConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KindnetdConfig) DeepCopyInto(out *KindnetdConfig) {
 	*out = *in
@@ -1295,6 +1621,26 @@ func (in *MapUsers) DeepCopy() *MapUsers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NTPConfiguration) DeepCopyInto(out *NTPConfiguration) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NTPConfiguration.
+func (in *NTPConfiguration) DeepCopy() *NTPConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NTPConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nodes) DeepCopyInto(out *Nodes) {
 	*out = *in
@@ -2184,6 +2530,28 @@ func (in *TinkerbellMachineConfigSpec) DeepCopyInto(out *TinkerbellMachineConfig
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostOSConfiguration != nil {
+		in, out := &in.HostOSConfiguration, &out.HostOSConfiguration
+		*out = new(HostOSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallDisk != nil {
+		in, out := &in.InstallDisk, &out.InstallDisk
+		*out = new(DiskSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalDisks != nil {
+		in, out := &in.AdditionalDisks, &out.AdditionalDisks
+		*out = make([]AdditionalDisk, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeprovisionPolicy != nil {
+		in, out := &in.DeprovisionPolicy, &out.DeprovisionPolicy
+		*out = new(DeprovisionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellMachineConfigSpec.
@@ -2326,7 +2694,7 @@ func (in *VSphereDatacenterConfig) DeepCopyInto(out *VSphereDatacenterConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -2383,6 +2751,11 @@ func (in *VSphereDatacenterConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereDatacenterConfigSpec) DeepCopyInto(out *VSphereDatacenterConfigSpec) {
 	*out = *in
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]VSphereFailureDomain, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDatacenterConfigSpec.
@@ -2415,6 +2788,21 @@ func (in *VSphereDatacenterConfigStatus) DeepCopy() *VSphereDatacenterConfigStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereFailureDomain) DeepCopyInto(out *VSphereFailureDomain) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereFailureDomain.
+func (in *VSphereFailureDomain) DeepCopy() *VSphereFailureDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereFailureDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereMachineConfig) DeepCopyInto(out *VSphereMachineConfig) {
 	*out = *in
@@ -2484,6 +2872,16 @@ func (in *VSphereMachineConfigSpec) DeepCopyInto(out *VSphereMachineConfigSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostOSConfiguration != nil {
+		in, out := &in.HostOSConfiguration, &out.HostOSConfiguration
+		*out = new(HostOSConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineConfigSpec.
@@ -2511,6 +2909,26 @@ func (in *VSphereMachineConfigStatus) DeepCopy() *VSphereMachineConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationsConfig) DeepCopyInto(out *ValidationsConfig) {
+	*out = *in
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationsConfig.
+func (in *ValidationsConfig) DeepCopy() *ValidationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfiguration) {
 	*out = *in
@@ -2538,6 +2956,16 @@ func (in *WorkerNodeGroupConfiguration) DeepCopyInto(out *WorkerNodeGroupConfigu
 			(*out)[key] = val
 		}
 	}
+	if in.UpgradeRolloutStrategy != nil {
+		in, out := &in.UpgradeRolloutStrategy, &out.UpgradeRolloutStrategy
+		*out = new(WorkerNodesUpgradeRolloutStrategy)
+		**out = **in
+	}
+	if in.KubernetesVersion != nil {
+		in, out := &in.KubernetesVersion, &out.KubernetesVersion
+		*out = new(KubernetesVersion)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroupConfiguration.
@@ -2549,3 +2977,54 @@ func (in *WorkerNodeGroupConfiguration) DeepCopy() *WorkerNodeGroupConfiguration
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerNodeGroupUpgradeStrategy) DeepCopyInto(out *WorkerNodeGroupUpgradeStrategy) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodeGroupUpgradeStrategy.
+func (in *WorkerNodeGroupUpgradeStrategy) DeepCopy() *WorkerNodeGroupUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerNodeGroupUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerNodesRollingUpdateParams) DeepCopyInto(out *WorkerNodesRollingUpdateParams) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodesRollingUpdateParams.
+func (in *WorkerNodesRollingUpdateParams) DeepCopy() *WorkerNodesRollingUpdateParams {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerNodesRollingUpdateParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerNodesUpgradeRolloutStrategy) DeepCopyInto(out *WorkerNodesUpgradeRolloutStrategy) {
+	*out = *in
+	out.RollingUpdate = in.RollingUpdate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerNodesUpgradeRolloutStrategy.
+func (in *WorkerNodesUpgradeRolloutStrategy) DeepCopy() *WorkerNodesUpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerNodesUpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 
@@ -34,3 +35,46 @@ func (c *Client) SnowballDeviceSoftwareVersion(ctx context.Context) (string, err
 	}
 	return *out.InstalledVersion, nil
 }
+
+// capacityUnitMultipliers converts the amounts of the units a device reports capacities in to
+// bytes for sizes and to a plain number for counts.
+var capacityUnitMultipliers = map[string]int64{
+	"":       1,
+	"number": 1,
+	"count":  1,
+	"b":      1,
+	"byte":   1,
+	"bytes":  1,
+	"kb":     1 << 10,
+	"kib":    1 << 10,
+	"mb":     1 << 20,
+	"mib":    1 << 20,
+	"gb":     1 << 30,
+	"gib":    1 << 30,
+	"tb":     1 << 40,
+	"tib":    1 << 40,
+}
+
+// SnowballDeviceCapacities returns the available amount of each resource reported by the device,
+// indexed by capacity name, e.g. "vCPU" or "Memory". Sizes are returned in bytes whatever the unit
+// the device reports them in.
+func (c *Client) SnowballDeviceCapacities(ctx context.Context) (map[string]int64, error) {
+	out, err := c.snowballDevice.DescribeDevice(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("describing snowball device: %v", err)
+	}
+
+	capacities := make(map[string]int64, len(out.DeviceCapacities))
+	for _, capacity := range out.DeviceCapacities {
+		if capacity.Name == nil || capacity.Available == nil {
+			continue
+		}
+		unit := aws.ToString(capacity.Unit)
+		multiplier, ok := capacityUnitMultipliers[strings.ToLower(unit)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported unit %s for snowball device capacity %s", unit, *capacity.Name)
+		}
+		capacities[*capacity.Name] = *capacity.Available * multiplier
+	}
+	return capacities, nil
+}
@@ -86,3 +86,77 @@ func TestSnowballDeviceSoftwareVersionDescribeDeviceSoftwareError(t *testing.T)
 	g.Expect(err).NotTo(Succeed())
 	g.Expect(got).To(Equal(""))
 }
+
+func TestSnowballDeviceCapacitiesSuccess(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	out := &snowballdevice.DescribeDeviceOutput{
+		DeviceCapacities: []types.Capacity{
+			{
+				Name:      str("vCPU"),
+				Unit:      str("Number"),
+				Available: ptr(104),
+				Total:     ptr(104),
+			},
+			{
+				Name:      str("Memory"),
+				Unit:      str("Byte"),
+				Available: ptr(400000000000),
+				Total:     ptr(416000000000),
+			},
+			{
+				Name:      str("IP Address"),
+				Available: ptr(30),
+			},
+			{
+				Name:      str("HDD Storage"),
+				Unit:      str("GB"),
+				Available: ptr(2),
+			},
+			{
+				Name: str("SSD Storage"),
+			},
+		},
+	}
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(out, nil)
+	got, err := g.client.SnowballDeviceCapacities(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(map[string]int64{
+		"vCPU":        104,
+		"Memory":      400000000000,
+		"IP Address":  30,
+		"HDD Storage": 2 * 1024 * 1024 * 1024,
+	}))
+}
+
+func TestSnowballDeviceCapacitiesUnsupportedUnit(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	out := &snowballdevice.DescribeDeviceOutput{
+		DeviceCapacities: []types.Capacity{
+			{
+				Name:      str("Memory"),
+				Unit:      str("Pages"),
+				Available: ptr(100),
+			},
+		},
+	}
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(out, nil)
+	got, err := g.client.SnowballDeviceCapacities(g.ctx)
+	g.Expect(err).To(MatchError("unsupported unit Pages for snowball device capacity Memory"))
+	g.Expect(got).To(BeNil())
+}
+
+func TestSnowballDeviceCapacitiesDescribeDeviceError(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(nil, errors.New("error"))
+	got, err := g.client.SnowballDeviceCapacities(g.ctx)
+	g.Expect(err).NotTo(Succeed())
+	g.Expect(got).To(BeNil())
+}
+
+func ptr(i int64) *int64 {
+	return &i
+}
+
+func str(s string) *string {
+	return &s
+}
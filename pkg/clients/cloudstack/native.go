@@ -0,0 +1,289 @@
+package cloudstack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const (
+	preflightTimeoutEnvVar  = "CLOUDSTACK_PREFLIGHT_TIMEOUT"
+	defaultPreflightTimeout = 30 * time.Second
+	defaultPageSize         = 500
+)
+
+// NativeClient talks to the CloudStack API directly, signing the requests with the api and secret keys of
+// each profile. It can be used as a drop-in replacement of the cmk executable. List responses are fetched
+// page by page and cached for the lifetime of the client, so repeated lookups across availability zones and
+// machine configs only hit the API once.
+type NativeClient struct {
+	profiles   map[string]decoder.CloudStackProfileConfig
+	httpClient func(profile decoder.CloudStackProfileConfig) *http.Client
+	pageSize   int
+	cache      map[string][]json.RawMessage
+	lock       sync.Mutex
+}
+
+type NativeClientOpt func(*NativeClient)
+
+// WithNativeClientPageSize sets the number of items requested per page in list calls.
+func WithNativeClientPageSize(pageSize int) NativeClientOpt {
+	return func(c *NativeClient) {
+		c.pageSize = pageSize
+	}
+}
+
+func NewNativeClient(configs []decoder.CloudStackProfileConfig, opts ...NativeClientOpt) *NativeClient {
+	profiles := map[string]decoder.CloudStackProfileConfig{}
+	for _, config := range configs {
+		profiles[config.Name] = config
+	}
+
+	c := &NativeClient{
+		profiles:   profiles,
+		httpClient: newHTTPClient,
+		pageSize:   defaultPageSize,
+		cache:      map[string][]json.RawMessage{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func newHTTPClient(profile decoder.CloudStackProfileConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if verify, err := strconv.ParseBool(profile.VerifySsl); err == nil && !verify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+func (c *NativeClient) GetManagementApiEndpoint(profile string) (string, error) {
+	config, exist := c.profiles[profile]
+	if exist {
+		return config.ManagementUrl, nil
+	}
+	return "", fmt.Errorf("profile %s does not exist", profile)
+}
+
+// ValidateCloudStackConnection makes an authenticated call to ensure that the endpoint and credentials are valid.
+func (c *NativeClient) ValidateCloudStackConnection(ctx context.Context, profile string) error {
+	if _, err := c.call(ctx, profile, "listCapabilities", url.Values{}); err != nil {
+		return fmt.Errorf("validating cloudstack connection: %v", err)
+	}
+	logger.MarkPass("Connected to CloudStack server")
+	return nil
+}
+
+// apiError is the error body returned by the CloudStack API.
+type apiError struct {
+	ErrorCode int    `json:"errorcode"`
+	ErrorText string `json:"errortext"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("cloudstack api error %d: %s", e.ErrorCode, e.ErrorText)
+}
+
+// call makes a signed GET request for an API command and returns the content of its response object.
+func (c *NativeClient) call(ctx context.Context, profile, command string, params url.Values) (json.RawMessage, error) {
+	config, exist := c.profiles[profile]
+	if !exist {
+		return nil, fmt.Errorf("profile %s does not exist", profile)
+	}
+
+	timeout, err := preflightTimeout()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Set("command", command)
+	query.Set("response", "json")
+	query.Set("apiKey", config.ApiKey)
+	query.Set("signature", sign(query, config.SecretKey))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.ManagementUrl+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("building %s request: %v", command, err)
+	}
+
+	logger.V(6).Info("Calling CloudStack API", "command", command, "profile", profile)
+	resp, err := c.httpClient(config).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s: %v", command, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s response: %v", command, err)
+	}
+
+	content, err := responseContent(command, body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s response: %v", command, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiError{}
+		if err = json.Unmarshal(content, apiErr); err != nil || apiErr.ErrorText == "" {
+			return nil, fmt.Errorf("calling %s: unexpected status %d", command, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("calling %s: %w", command, apiErr)
+	}
+
+	return content, nil
+}
+
+// responseContent extracts the object inside the "<command>response" key of the response body.
+func responseContent(command string, body []byte) (json.RawMessage, error) {
+	response := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(command) + "response"
+	for k, v := range response {
+		if strings.ToLower(k) == key {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("response doesn't contain %s", key)
+}
+
+// sign computes the request signature: the HMAC-SHA1 of the sorted and lower-cased query string.
+func sign(params url.Values, secretKey string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		value := strings.ReplaceAll(url.QueryEscape(params.Get(k)), "+", "%20")
+		pairs = append(pairs, k+"="+value)
+	}
+
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(strings.ToLower(strings.Join(pairs, "&"))))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func preflightTimeout() (time.Duration, error) {
+	timeout, isSet := os.LookupEnv(preflightTimeoutEnvVar)
+	if !isSet {
+		return defaultPreflightTimeout, nil
+	}
+
+	seconds, err := strconv.ParseUint(timeout, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number: %v", preflightTimeoutEnvVar, err)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// list calls a list API command and returns the items under responseKey from all the pages.
+// Results are cached by profile, command and params.
+func (c *NativeClient) list(ctx context.Context, profile, command, responseKey string, params url.Values) ([]json.RawMessage, error) {
+	cacheKey := strings.Join([]string{profile, command, params.Encode()}, "|")
+	c.lock.Lock()
+	cached, ok := c.cache[cacheKey]
+	c.lock.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var items []json.RawMessage
+	for page := 1; ; page++ {
+		pageParams := url.Values{}
+		for k, v := range params {
+			pageParams[k] = v
+		}
+		pageParams.Set("page", strconv.Itoa(page))
+		pageParams.Set("pagesize", strconv.Itoa(c.pageSize))
+
+		content, err := c.call(ctx, profile, command, pageParams)
+		if err != nil {
+			return nil, err
+		}
+
+		response := map[string]json.RawMessage{}
+		if err = json.Unmarshal(content, &response); err != nil {
+			return nil, fmt.Errorf("parsing %s response: %v", command, err)
+		}
+
+		count := 0
+		if raw, ok := response["count"]; ok {
+			if err = json.Unmarshal(raw, &count); err != nil {
+				return nil, fmt.Errorf("parsing %s response count: %v", command, err)
+			}
+		}
+
+		var pageItems []json.RawMessage
+		if raw, ok := response[responseKey]; ok {
+			if err = json.Unmarshal(raw, &pageItems); err != nil {
+				return nil, fmt.Errorf("parsing %s response: %v", command, err)
+			}
+		}
+		items = append(items, pageItems...)
+
+		if len(pageItems) < c.pageSize || len(items) >= count {
+			break
+		}
+	}
+
+	c.lock.Lock()
+	c.cache[cacheKey] = items
+	c.lock.Unlock()
+
+	return items, nil
+}
+
+// listInto works like list, unmarshalling the items into the slice pointed by out.
+func (c *NativeClient) listInto(ctx context.Context, profile, command, responseKey string, params url.Values, out interface{}) error {
+	items, err := c.list(ctx, profile, command, responseKey, params)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("parsing %s response: %v", command, err)
+	}
+
+	return nil
+}
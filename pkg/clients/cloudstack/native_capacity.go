@@ -0,0 +1,95 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+type account struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	VmAvailable     string `json:"vmavailable"`
+	CpuAvailable    string `json:"cpuavailable"`
+	MemoryAvailable string `json:"memoryavailable"`
+}
+
+type capacity struct {
+	Type          int   `json:"type"`
+	CapacityTotal int64 `json:"capacitytotal"`
+	CapacityUsed  int64 `json:"capacityused"`
+}
+
+func (c *NativeClient) listAccounts(ctx context.Context, profile string, name string, domainId string) ([]account, error) {
+	params := url.Values{}
+	if len(name) > 0 {
+		params.Set("name", name)
+	}
+	if len(domainId) > 0 {
+		params.Set("domainid", domainId)
+	}
+
+	accounts := []account{}
+	if err := c.listInto(ctx, profile, "listAccounts", "account", params, &accounts); err != nil {
+		return nil, fmt.Errorf("getting accounts info: %v", err)
+	}
+
+	return accounts, nil
+}
+
+// GetServiceOffering returns the compute resources allocated by a service offering.
+func (c *NativeClient) GetServiceOffering(ctx context.Context, profile string, zoneId string, offering v1alpha1.CloudStackResourceIdentifier) (*decoder.CloudStackServiceOffering, error) {
+	o, err := c.getServiceOffering(ctx, profile, zoneId, offering)
+	if err != nil {
+		return nil, err
+	}
+	return &decoder.CloudStackServiceOffering{
+		CpuNumber: o.CpuNumber,
+		CpuSpeed:  o.CpuSpeed,
+		Memory:    o.Memory,
+	}, nil
+}
+
+// ListAccounts returns the resources available to the accounts matching the given name and domain.
+// When no name is provided, it returns every account visible to the api key.
+func (c *NativeClient) ListAccounts(ctx context.Context, profile string, name string, domainId string) ([]decoder.CloudStackAccount, error) {
+	accounts, err := c.listAccounts(ctx, profile, name, domainId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]decoder.CloudStackAccount, 0, len(accounts))
+	for _, a := range accounts {
+		result = append(result, decoder.CloudStackAccount{
+			Name:            a.Name,
+			VmAvailable:     a.VmAvailable,
+			CpuAvailable:    a.CpuAvailable,
+			MemoryAvailable: a.MemoryAvailable,
+		})
+	}
+	return result, nil
+}
+
+// ListZoneCapacity returns the capacity of a zone. Listing capacity requires root admin permissions.
+func (c *NativeClient) ListZoneCapacity(ctx context.Context, profile string, zoneId string) ([]decoder.CloudStackCapacity, error) {
+	params := url.Values{}
+	params.Set("zoneid", zoneId)
+
+	capacities := []capacity{}
+	if err := c.listInto(ctx, profile, "listCapacity", "capacity", params, &capacities); err != nil {
+		return nil, fmt.Errorf("getting capacity info: %v", err)
+	}
+
+	result := make([]decoder.CloudStackCapacity, 0, len(capacities))
+	for _, capacity := range capacities {
+		result = append(result, decoder.CloudStackCapacity{
+			Type:  capacity.Type,
+			Total: capacity.CapacityTotal,
+			Used:  capacity.CapacityUsed,
+		})
+	}
+	return result, nil
+}
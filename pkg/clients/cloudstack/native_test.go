@@ -0,0 +1,363 @@
+package cloudstack_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+const (
+	profile   = "global"
+	apiKey    = "test-api-key"
+	secretKey = "test-secret-key"
+)
+
+type item map[string]interface{}
+
+type apiResponse struct {
+	status int
+	body   interface{}
+}
+
+// fakeAPI is a minimal CloudStack API server. It verifies the request signatures, paginates list responses
+// and records the number of calls made for each command.
+type fakeAPI struct {
+	t         *testing.T
+	responses map[string]func(query url.Values) apiResponse
+	calls     map[string]int
+	lock      sync.Mutex
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *httptest.Server) {
+	api := &fakeAPI{
+		t:         t,
+		responses: map[string]func(query url.Values) apiResponse{},
+		calls:     map[string]int{},
+	}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return api, server
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	command := query.Get("command")
+
+	f.lock.Lock()
+	f.calls[command]++
+	f.lock.Unlock()
+
+	key := strings.ToLower(command) + "response"
+	if query.Get("apiKey") != apiKey || query.Get("signature") != expectedSignature(query) {
+		writeResponse(w, key, apiResponse{
+			status: http.StatusUnauthorized,
+			body:   item{"errorcode": 401, "errortext": "unable to verify user credentials and/or request signature"},
+		})
+		return
+	}
+
+	response, ok := f.responses[command]
+	if !ok {
+		writeResponse(w, key, apiResponse{
+			status: 432,
+			body:   item{"errorcode": 432, "errortext": "The given command does not exist or it is not available for user"},
+		})
+		return
+	}
+
+	writeResponse(w, key, response(query))
+}
+
+func (f *fakeAPI) callsFor(command string) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.calls[command]
+}
+
+func (f *fakeAPI) list(command, responseKey string, items ...item) {
+	f.responses[command] = func(query url.Values) apiResponse {
+		matching := []item{}
+		for _, i := range items {
+			if matches(i, query, "id") && matches(i, query, "name") {
+				matching = append(matching, i)
+			}
+		}
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		pageSize, _ := strconv.Atoi(query.Get("pagesize"))
+		start, end := (page-1)*pageSize, page*pageSize
+		if start > len(matching) {
+			start = len(matching)
+		}
+		if end > len(matching) {
+			end = len(matching)
+		}
+
+		body := item{"count": len(matching)}
+		if end > start {
+			body[responseKey] = matching[start:end]
+		}
+		return apiResponse{status: http.StatusOK, body: body}
+	}
+}
+
+func matches(i item, query url.Values, field string) bool {
+	if !query.Has(field) {
+		return true
+	}
+	return i[field] == query.Get(field)
+}
+
+func writeResponse(w http.ResponseWriter, key string, response apiResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.status)
+	_ = json.NewEncoder(w).Encode(item{key: response.body})
+}
+
+func expectedSignature(query url.Values) string {
+	var pairs []string
+	for k, v := range query {
+		if k == "signature" {
+			continue
+		}
+		pairs = append(pairs, strings.ToLower(k+"="+strings.ReplaceAll(url.QueryEscape(v[0]), "+", "%20")))
+	}
+	sort.Strings(pairs)
+
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(strings.Join(pairs, "&")))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+type nativeClientTest struct {
+	*WithT
+	ctx    context.Context
+	api    *fakeAPI
+	client *cloudstack.NativeClient
+}
+
+func newNativeClientTest(t *testing.T, opts ...cloudstack.NativeClientOpt) *nativeClientTest {
+	api, server := newFakeAPI(t)
+	profiles := []decoder.CloudStackProfileConfig{
+		{
+			Name:          profile,
+			ApiKey:        apiKey,
+			SecretKey:     secretKey,
+			ManagementUrl: server.URL + "/client/api",
+			VerifySsl:     "false",
+		},
+	}
+
+	return &nativeClientTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		api:    api,
+		client: cloudstack.NewNativeClient(profiles, opts...),
+	}
+}
+
+func TestNativeClientGetManagementApiEndpoint(t *testing.T) {
+	tt := newNativeClientTest(t)
+
+	endpoint, err := tt.client.GetManagementApiEndpoint(profile)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(endpoint).To(HaveSuffix("/client/api"))
+
+	_, err = tt.client.GetManagementApiEndpoint("other")
+	tt.Expect(err).To(MatchError("profile other does not exist"))
+}
+
+func TestNativeClientValidateCloudStackConnection(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.responses["listCapabilities"] = func(url.Values) apiResponse {
+		return apiResponse{status: http.StatusOK, body: item{"capability": item{"cloudstackversion": "4.16.1.0"}}}
+	}
+
+	tt.Expect(tt.client.ValidateCloudStackConnection(tt.ctx, profile)).To(Succeed())
+}
+
+func TestNativeClientValidateCloudStackConnectionInvalidCredentials(t *testing.T) {
+	api, server := newFakeAPI(t)
+	client := cloudstack.NewNativeClient([]decoder.CloudStackProfileConfig{
+		{
+			Name:          profile,
+			ApiKey:        apiKey,
+			SecretKey:     "wrong-secret-key",
+			ManagementUrl: server.URL,
+		},
+	})
+	g := NewWithT(t)
+
+	err := client.ValidateCloudStackConnection(context.Background(), profile)
+	g.Expect(err).To(MatchError("validating cloudstack connection: calling listCapabilities: cloudstack api error 401: unable to verify user credentials and/or request signature"))
+	g.Expect(api.callsFor("listCapabilities")).To(Equal(1))
+}
+
+func TestNativeClientSignsParamsWithSpaces(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listServiceOfferings", "serviceoffering", item{"id": "offering-1", "name": "Large Instance"})
+
+	tt.Expect(tt.client.ValidateServiceOfferingPresent(tt.ctx, profile, "zone-1", v1alpha1.CloudStackResourceIdentifier{Name: "Large Instance"})).To(Succeed())
+}
+
+func TestNativeClientValidateNetworkPresentPaginated(t *testing.T) {
+	tt := newNativeClientTest(t, cloudstack.WithNativeClientPageSize(2))
+	tt.api.list("listNetworks", "network",
+		item{"id": "net-1", "name": "net1"},
+		item{"id": "net-2", "name": "net2"},
+		item{"id": "net-3", "name": "net3"},
+		item{"id": "net-4", "name": "net4"},
+		item{"id": "net-5", "name": "net5"},
+	)
+
+	tt.Expect(tt.client.ValidateNetworkPresent(tt.ctx, profile, "", v1alpha1.CloudStackResourceIdentifier{Name: "net5"}, "zone-1", "")).To(Succeed())
+	tt.Expect(tt.api.callsFor("listNetworks")).To(Equal(3))
+
+	// All the pages are cached, looking up another network doesn't call the API again
+	tt.Expect(tt.client.ValidateNetworkPresent(tt.ctx, profile, "", v1alpha1.CloudStackResourceIdentifier{Name: "net1"}, "zone-1", "")).To(Succeed())
+	tt.Expect(tt.client.ValidateNetworkPresent(tt.ctx, profile, "", v1alpha1.CloudStackResourceIdentifier{Name: "net6"}, "zone-1", "")).To(
+		MatchError("network { net6} not found in zoneRef zone-1"),
+	)
+	tt.Expect(tt.api.callsFor("listNetworks")).To(Equal(3))
+}
+
+func TestNativeClientValidateZoneAndGetId(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listZones", "zone", item{"id": "zone-1", "name": "zone1"}, item{"id": "zone-2", "name": "zone2"})
+
+	for i := 0; i < 2; i++ {
+		id, err := tt.client.ValidateZoneAndGetId(tt.ctx, profile, v1alpha1.CloudStackZone{Name: "zone2"})
+		tt.Expect(err).To(BeNil())
+		tt.Expect(id).To(Equal("zone-2"))
+	}
+	tt.Expect(tt.api.callsFor("listZones")).To(Equal(1))
+
+	_, err := tt.client.ValidateZoneAndGetId(tt.ctx, profile, v1alpha1.CloudStackZone{Name: "zone3"})
+	tt.Expect(err).To(MatchError(ContainSubstring("not found")))
+}
+
+func TestNativeClientValidateDomainAndGetId(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listDomains", "domain",
+		item{"id": "domain-1", "name": "domain1", "path": "ROOT/domain1"},
+		item{"id": "domain-2", "name": "domain1", "path": "ROOT/parent/domain1"},
+	)
+
+	id, err := tt.client.ValidateDomainAndGetId(tt.ctx, profile, "parent/domain1")
+	tt.Expect(err).To(BeNil())
+	tt.Expect(id).To(Equal("domain-2"))
+
+	_, err = tt.client.ValidateDomainAndGetId(tt.ctx, profile, "other/domain1")
+	tt.Expect(err).To(MatchError("domain(s) found for domain name other/domain1, but not found a domain with domain path ROOT/other/domain1"))
+}
+
+func TestNativeClientValidateTemplatePresentDuplicate(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listTemplates", "template", item{"id": "template-1", "name": "ubuntu"}, item{"id": "template-2", "name": "ubuntu"})
+
+	err := tt.client.ValidateTemplatePresent(tt.ctx, profile, "", "zone-1", "", v1alpha1.CloudStackResourceIdentifier{Name: "ubuntu"})
+	tt.Expect(err).To(MatchError("duplicate templates { ubuntu} found"))
+}
+
+func TestNativeClientValidateDiskOfferingPresentCustomized(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listDiskOfferings", "diskoffering", item{"id": "disk-1", "name": "Custom", "iscustomized": true})
+	offering := v1alpha1.CloudStackResourceDiskOffering{
+		CloudStackResourceIdentifier: v1alpha1.CloudStackResourceIdentifier{Name: "Custom"},
+	}
+
+	tt.Expect(tt.client.ValidateDiskOfferingPresent(tt.ctx, profile, "zone-1", offering)).To(MatchError("disk offering size 0 <= 0 for customized disk offering"))
+
+	offering.CustomSize = 10
+	tt.Expect(tt.client.ValidateDiskOfferingPresent(tt.ctx, profile, "zone-1", offering)).To(Succeed())
+}
+
+func TestNativeClientValidateAffinityGroupsPresent(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listAffinityGroups", "affinitygroup", item{"id": "group-1", "name": "group1"})
+
+	tt.Expect(tt.client.ValidateAffinityGroupsPresent(tt.ctx, profile, "domain-1", "admin", []string{"group-1"})).To(Succeed())
+	tt.Expect(tt.client.ValidateAffinityGroupsPresent(tt.ctx, profile, "domain-1", "admin", []string{"group-1", "group-2"})).To(MatchError("affinity group group-2 not found"))
+	tt.Expect(tt.api.callsFor("listAffinityGroups")).To(Equal(2))
+}
+
+func TestNativeClientValidateAccountPresent(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listAccounts", "account", item{"id": "account-1", "name": "admin"})
+
+	tt.Expect(tt.client.ValidateAccountPresent(tt.ctx, profile, "", "domain-1")).To(Succeed())
+	tt.Expect(tt.client.ValidateAccountPresent(tt.ctx, profile, "admin", "domain-1")).To(Succeed())
+	tt.Expect(tt.client.ValidateAccountPresent(tt.ctx, profile, "user", "domain-1")).To(MatchError("account user not found"))
+}
+
+func TestNativeClientUnsupportedCommand(t *testing.T) {
+	tt := newNativeClientTest(t)
+
+	err := tt.client.ValidateServiceOfferingPresent(tt.ctx, profile, "zone-1", v1alpha1.CloudStackResourceIdentifier{Name: "large"})
+	tt.Expect(err).To(MatchError(ContainSubstring("cloudstack api error 432")))
+}
+
+func TestNativeClientGetServiceOffering(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listServiceOfferings", "serviceoffering",
+		item{"id": "offering-1", "name": "large", "cpunumber": 4, "cpuspeed": 2000, "memory": 8192},
+	)
+
+	offering, err := tt.client.GetServiceOffering(tt.ctx, profile, "zone-1", v1alpha1.CloudStackResourceIdentifier{Name: "large"})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(offering).To(Equal(&decoder.CloudStackServiceOffering{CpuNumber: 4, CpuSpeed: 2000, Memory: 8192}))
+
+	_, err = tt.client.GetServiceOffering(tt.ctx, profile, "zone-1", v1alpha1.CloudStackResourceIdentifier{Name: "xlarge"})
+	tt.Expect(err).To(MatchError("service offering { xlarge} not found"))
+}
+
+func TestNativeClientListAccounts(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listAccounts", "account",
+		item{"id": "account-1", "name": "admin", "vmavailable": "Unlimited", "cpuavailable": "16", "memoryavailable": "28672"},
+		item{"id": "account-2", "name": "dev"},
+	)
+
+	tt.Expect(tt.client.ListAccounts(tt.ctx, profile, "", "domain-1")).To(Equal([]decoder.CloudStackAccount{
+		{Name: "admin", VmAvailable: "Unlimited", CpuAvailable: "16", MemoryAvailable: "28672"},
+		{Name: "dev"},
+	}))
+}
+
+func TestNativeClientListZoneCapacity(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.api.list("listCapacity", "capacity",
+		item{"type": 0, "capacitytotal": 64 << 30, "capacityused": 32 << 30},
+		item{"type": 1, "capacitytotal": 100000, "capacityused": 50000},
+	)
+
+	tt.Expect(tt.client.ListZoneCapacity(tt.ctx, profile, "zone-1")).To(Equal([]decoder.CloudStackCapacity{
+		{Type: decoder.CloudStackCapacityTypeMemory, Total: 64 << 30, Used: 32 << 30},
+		{Type: decoder.CloudStackCapacityTypeCPU, Total: 100000, Used: 50000},
+	}))
+}
+
+func TestNativeClientListZoneCapacityNotAllowed(t *testing.T) {
+	tt := newNativeClientTest(t)
+
+	_, err := tt.client.ListZoneCapacity(tt.ctx, profile, "zone-1")
+	tt.Expect(err).To(MatchError(ContainSubstring("getting capacity info")))
+	tt.Expect(tt.api.callsFor("listCapacity")).To(Equal(1))
+}
@@ -0,0 +1,241 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	rootDomain      = "ROOT"
+	domainDelimiter = "/"
+)
+
+type resourceIdentifier struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type serviceOffering struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	CpuNumber int    `json:"cpunumber"`
+	CpuSpeed  int    `json:"cpuspeed"`
+	Memory    int    `json:"memory"`
+}
+
+type diskOffering struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Customized bool   `json:"iscustomized"`
+}
+
+type domain struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+func identifierParams(identifier v1alpha1.CloudStackResourceIdentifier) url.Values {
+	params := url.Values{}
+	if len(identifier.Id) > 0 {
+		params.Set("id", identifier.Id)
+	} else {
+		params.Set("name", identifier.Name)
+	}
+	return params
+}
+
+// setDomainAndAccount adds the domain and account filters. Account must be specified with a domainId,
+// domainId can be specified without account.
+func setDomainAndAccount(params url.Values, domainId, account string) {
+	if len(domainId) > 0 {
+		params.Set("domainid", domainId)
+		if len(account) > 0 {
+			params.Set("account", account)
+		}
+	}
+}
+
+func (c *NativeClient) ValidateTemplatePresent(ctx context.Context, profile string, domainId string, zoneId string, account string, template v1alpha1.CloudStackResourceIdentifier) error {
+	params := identifierParams(template)
+	params.Set("templatefilter", "all")
+	params.Set("listall", "true")
+	params.Set("zoneid", zoneId)
+	setDomainAndAccount(params, domainId, account)
+
+	templates := []resourceIdentifier{}
+	if err := c.listInto(ctx, profile, "listTemplates", "template", params, &templates); err != nil {
+		return fmt.Errorf("getting templates info: %v", err)
+	}
+
+	if len(templates) > 1 {
+		return fmt.Errorf("duplicate templates %s found", template)
+	} else if len(templates) == 0 {
+		return fmt.Errorf("template %s not found", template)
+	}
+	return nil
+}
+
+func (c *NativeClient) getServiceOffering(ctx context.Context, profile string, zoneId string, offering v1alpha1.CloudStackResourceIdentifier) (*serviceOffering, error) {
+	params := identifierParams(offering)
+	params.Set("zoneid", zoneId)
+
+	offerings := []serviceOffering{}
+	if err := c.listInto(ctx, profile, "listServiceOfferings", "serviceoffering", params, &offerings); err != nil {
+		return nil, fmt.Errorf("getting service offerings info: %v", err)
+	}
+
+	if len(offerings) > 1 {
+		return nil, fmt.Errorf("duplicate service offering %s found", offering)
+	} else if len(offerings) == 0 {
+		return nil, fmt.Errorf("service offering %s not found", offering)
+	}
+
+	return &offerings[0], nil
+}
+
+func (c *NativeClient) ValidateServiceOfferingPresent(ctx context.Context, profile string, zoneId string, offering v1alpha1.CloudStackResourceIdentifier) error {
+	_, err := c.getServiceOffering(ctx, profile, zoneId, offering)
+	return err
+}
+
+func (c *NativeClient) ValidateDiskOfferingPresent(ctx context.Context, profile string, zoneId string, offering v1alpha1.CloudStackResourceDiskOffering) error {
+	params := identifierParams(offering.CloudStackResourceIdentifier)
+	params.Set("zoneid", zoneId)
+
+	offerings := []diskOffering{}
+	if err := c.listInto(ctx, profile, "listDiskOfferings", "diskoffering", params, &offerings); err != nil {
+		return fmt.Errorf("getting disk offerings info: %v", err)
+	}
+
+	if len(offerings) > 1 {
+		return fmt.Errorf("duplicate disk offering ID/Name %s/%s found", offering.Id, offering.Name)
+	} else if len(offerings) == 0 {
+		return fmt.Errorf("disk offering ID/Name %s/%s not found", offering.Id, offering.Name)
+	}
+
+	if offerings[0].Customized && offering.CustomSize <= 0 {
+		return fmt.Errorf("disk offering size %d <= 0 for customized disk offering", offering.CustomSize)
+	}
+	if !offerings[0].Customized && offering.CustomSize > 0 {
+		return fmt.Errorf("disk offering size %d > 0 for non-customized disk offering", offering.CustomSize)
+	}
+	return nil
+}
+
+func (c *NativeClient) ValidateAffinityGroupsPresent(ctx context.Context, profile string, domainId string, account string, affinityGroupIds []string) error {
+	for _, affinityGroupId := range affinityGroupIds {
+		params := url.Values{}
+		params.Set("id", affinityGroupId)
+		setDomainAndAccount(params, domainId, account)
+
+		affinityGroups := []resourceIdentifier{}
+		if err := c.listInto(ctx, profile, "listAffinityGroups", "affinitygroup", params, &affinityGroups); err != nil {
+			return fmt.Errorf("getting affinity group info: %v", err)
+		}
+
+		if len(affinityGroups) > 1 {
+			return fmt.Errorf("duplicate affinity group %s found", affinityGroupId)
+		} else if len(affinityGroups) == 0 {
+			return fmt.Errorf("affinity group %s not found", affinityGroupId)
+		}
+	}
+	return nil
+}
+
+func (c *NativeClient) ValidateZoneAndGetId(ctx context.Context, profile string, zone v1alpha1.CloudStackZone) (string, error) {
+	zones := []resourceIdentifier{}
+	if err := c.listInto(ctx, profile, "listZones", "zone", identifierParams(v1alpha1.CloudStackResourceIdentifier{Id: zone.Id, Name: zone.Name}), &zones); err != nil {
+		return "", fmt.Errorf("getting zones info: %v", err)
+	}
+
+	if len(zones) > 1 {
+		return "", fmt.Errorf("duplicate zone %s found", zone)
+	} else if len(zones) == 0 {
+		return "", fmt.Errorf("zone %s not found", zone)
+	}
+	return zones[0].Id, nil
+}
+
+func (c *NativeClient) ValidateDomainAndGetId(ctx context.Context, profile string, domainPath string) (string, error) {
+	// The list domains API does not support querying by domain path, so here we extract the domain name which is the last part of the input domain
+	tokens := strings.Split(domainPath, domainDelimiter)
+	params := url.Values{}
+	params.Set("name", tokens[len(tokens)-1])
+	params.Set("listall", "true")
+
+	domains := []domain{}
+	if err := c.listInto(ctx, profile, "listDomains", "domain", params, &domains); err != nil {
+		return "", fmt.Errorf("getting domain info: %v", err)
+	}
+	if len(domains) == 0 {
+		return "", fmt.Errorf("domain %s not found", domainPath)
+	}
+
+	fullPath := rootDomain
+	if domainPath != rootDomain {
+		fullPath = strings.Join([]string{rootDomain, domainPath}, domainDelimiter)
+	}
+	for _, d := range domains {
+		if d.Path == fullPath {
+			return d.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("domain(s) found for domain name %s, but not found a domain with domain path %s", domainPath, fullPath)
+}
+
+// ValidateNetworkPresent lists all the networks in the zone once and filters them by name, since the API doesn't support a name filter.
+func (c *NativeClient) ValidateNetworkPresent(ctx context.Context, profile string, domainId string, network v1alpha1.CloudStackResourceIdentifier, zoneId string, account string) error {
+	params := url.Values{}
+	setDomainAndAccount(params, domainId, account)
+	params.Set("zoneid", zoneId)
+	if len(network.Id) > 0 {
+		params.Set("id", network.Id)
+	}
+
+	networks := []resourceIdentifier{}
+	if err := c.listInto(ctx, profile, "listNetworks", "network", params, &networks); err != nil {
+		return fmt.Errorf("getting network info: %v", err)
+	}
+
+	if len(network.Name) > 0 {
+		byName := []resourceIdentifier{}
+		for _, n := range networks {
+			if n.Name == network.Name {
+				byName = append(byName, n)
+			}
+		}
+		networks = byName
+	}
+
+	if len(networks) > 1 {
+		return fmt.Errorf("duplicate network %s found", network)
+	} else if len(networks) == 0 {
+		return fmt.Errorf("network %s not found in zoneRef %s", network, zoneId)
+	}
+	return nil
+}
+
+func (c *NativeClient) ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error {
+	// If account is not specified then no need to check its presence
+	if len(account) == 0 {
+		return nil
+	}
+
+	accounts, err := c.listAccounts(ctx, profile, account, domainId)
+	if err != nil {
+		return err
+	}
+
+	if len(accounts) > 1 {
+		return fmt.Errorf("duplicate account %s found", account)
+	} else if len(accounts) == 0 {
+		return fmt.Errorf("account %s not found", account)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
+)
+
+const (
+	defaultNamespace     = "default"
+	deletionPollInterval = time.Second
+	deletionTimeout      = 2 * time.Minute
+)
+
+// ApplyManifest server-side applies all the objects in a multi document yaml manifest.
+// Namespaced objects without a namespace are created in the provided one.
+func (c *RuntimeClient) ApplyManifest(ctx context.Context, kubeconfig string, data []byte, namespace string) error {
+	cl, objs, err := c.manifestObjects(kubeconfig, data, namespace)
+	if err != nil {
+		return fmt.Errorf("applying manifest: %v", err)
+	}
+
+	for i := range objs {
+		if err = serverSideApply(ctx, cl, &objs[i]); err != nil {
+			return fmt.Errorf("applying %s: %v", objectName(&objs[i]), err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyManifestForce works like ApplyManifest, but objects that can't be updated
+// because of invalid or conflicting changes are deleted and created again.
+func (c *RuntimeClient) ApplyManifestForce(ctx context.Context, kubeconfig string, data []byte) error {
+	cl, objs, err := c.manifestObjects(kubeconfig, data, "")
+	if err != nil {
+		return fmt.Errorf("applying manifest with force: %v", err)
+	}
+
+	for i := range objs {
+		obj := &objs[i]
+		err = serverSideApply(ctx, cl, obj.DeepCopy())
+		if apierrors.IsInvalid(err) || apierrors.IsConflict(err) {
+			err = recreate(ctx, cl, obj)
+		}
+		if err != nil {
+			return fmt.Errorf("applying %s with force: %v", objectName(obj), err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteManifest deletes all the objects in a multi document yaml manifest.
+func (c *RuntimeClient) DeleteManifest(ctx context.Context, kubeconfig string, data []byte) error {
+	cl, objs, err := c.manifestObjects(kubeconfig, data, "")
+	if err != nil {
+		return fmt.Errorf("deleting manifest: %v", err)
+	}
+
+	for i := range objs {
+		if err = cl.Delete(ctx, &objs[i]); err != nil {
+			return fmt.Errorf("deleting %s: %v", objectName(&objs[i]), err)
+		}
+	}
+
+	return nil
+}
+
+func (c *RuntimeClient) manifestObjects(kubeconfig string, data []byte, namespace string) (client.Client, []unstructured.Unstructured, error) {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objs, err := unstructuredutil.YamlToUnstructured(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing manifest: %v", err)
+	}
+
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	for i := range objs {
+		obj := &objs[i]
+		if obj.GetNamespace() != "" {
+			continue
+		}
+		gvk := obj.GroupVersionKind()
+		mapping, err := cl.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting rest mapping for %s: %v", objectName(obj), err)
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			obj.SetNamespace(namespace)
+		}
+	}
+
+	return cl, objs, nil
+}
+
+func recreate(ctx context.Context, cl client.Client, obj *unstructured.Unstructured) error {
+	if err := cl.Delete(ctx, obj.DeepCopy()); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	key := client.ObjectKeyFromObject(obj)
+	err := wait.PollImmediate(deletionPollInterval, deletionTimeout, func() (bool, error) {
+		err := cl.Get(ctx, key, obj.DeepCopy())
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for %s to be deleted: %v", objectName(obj), err)
+	}
+
+	return serverSideApply(ctx, cl, obj)
+}
+
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
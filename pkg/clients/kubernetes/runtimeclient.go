@@ -0,0 +1,277 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager is the field owner used for all the server-side apply calls made by the CLI.
+const FieldManager = "eks-a-cli"
+
+// ClientBuilder builds a kubernetes API client authenticated with the credentials of a kubeconfig file.
+type ClientBuilder func(kubeconfig string, scheme *runtime.Scheme) (client.WithWatch, error)
+
+// RuntimeClient is a kubernetes API client that talks directly to the kube API server from the CLI process,
+// without shelling out to kubectl. Like UnAuthClient, it takes a kubeconfig file on every call in order to
+// authenticate, building and caching one controller-runtime client per kubeconfig file.
+type RuntimeClient struct {
+	scheme        *runtime.Scheme
+	clientBuilder ClientBuilder
+	clients       map[string]client.WithWatch
+	lock          sync.Mutex
+}
+
+type RuntimeClientOpt func(*RuntimeClient)
+
+// WithClientBuilder overrides the function used to build the clients for each kubeconfig file.
+func WithClientBuilder(builder ClientBuilder) RuntimeClientOpt {
+	return func(c *RuntimeClient) {
+		c.clientBuilder = builder
+	}
+}
+
+func NewRuntimeClient(opts ...RuntimeClientOpt) *RuntimeClient {
+	c := &RuntimeClient{
+		scheme:        runtime.NewScheme(),
+		clientBuilder: NewClientFromKubeconfig,
+		clients:       map[string]client.WithWatch{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Init initializes the client internal API scheme
+// It has always be invoked at least once before making any API call
+// It is not thread safe
+func (c *RuntimeClient) Init() error {
+	return addToScheme(c.scheme, append(schemeAdders, clientgoscheme.AddToScheme)...)
+}
+
+// NewClientFromKubeconfig builds a client for the cluster in the current context of a kubeconfig file.
+// If the kubeconfig is empty, it follows the same loading rules as kubectl.
+// Types not registered in the scheme can still be used as unstructured objects.
+func NewClientFromKubeconfig(kubeconfig string, scheme *runtime.Scheme) (client.WithWatch, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig %s: %v", kubeconfig, err)
+	}
+
+	// The dynamic mapper refreshes its cache when it can't find a type, so CRDs
+	// installed by previous calls can be used right away.
+	mapper, err := apiutil.NewDynamicRESTMapper(config)
+	if err != nil {
+		return nil, fmt.Errorf("building rest mapper for %s: %v", kubeconfig, err)
+	}
+
+	return client.NewWithWatch(config, client.Options{Scheme: scheme, Mapper: mapper})
+}
+
+func (c *RuntimeClient) client(kubeconfig string) (client.WithWatch, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cl, ok := c.clients[kubeconfig]; ok {
+		return cl, nil
+	}
+
+	cl, err := c.clientBuilder(kubeconfig, c.scheme)
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes client: %v", err)
+	}
+	c.clients[kubeconfig] = cl
+
+	return cl, nil
+}
+
+// GetObject performs a GET call to the kube API server authenticating with a kubeconfig file
+// and unmarshalls the response into the provided Object.
+// The resourceType is only used for objects not registered in the client scheme. It accepts the same
+// formats as kubectl: resource, resource.group or Kind.version.group.
+// If the object is not found, it returns an error implementing apimachinery errors.APIStatus.
+func (c *RuntimeClient) GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	key := client.ObjectKey{Name: name, Namespace: namespace}
+	if o, ok := obj.(client.Object); ok && c.isRegistered(obj) {
+		return cl.Get(ctx, key, o)
+	}
+
+	u, err := c.unstructuredFor(cl, resourceType, obj)
+	if err != nil {
+		return fmt.Errorf("getting %s: %v", resourceType, err)
+	}
+	if err = cl.Get(ctx, key, u); err != nil {
+		return err
+	}
+
+	return fromUnstructured(u, obj)
+}
+
+// ListObjects lists all the objects of a resource type in a namespace. If the namespace is empty,
+// it lists the objects across all namespaces.
+func (c *RuntimeClient) ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list ObjectList) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if c.isRegistered(list) {
+		return cl.List(ctx, list, client.InNamespace(namespace))
+	}
+
+	gvk, err := kindFor(cl.RESTMapper(), resourceType)
+	if err != nil {
+		return fmt.Errorf("listing %s: %v", resourceType, err)
+	}
+	u := &unstructured.UnstructuredList{}
+	u.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err = cl.List(ctx, u, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	return fromUnstructured(u, list)
+}
+
+// Delete performs a DELETE call to the kube API server authenticating with a kubeconfig file.
+func (c *RuntimeClient) Delete(ctx context.Context, resourceType, name, namespace, kubeconfig string) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := kindFor(cl.RESTMapper(), resourceType)
+	if err != nil {
+		return fmt.Errorf("deleting %s %s in namespace %s: %v", name, resourceType, namespace, err)
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(name)
+	u.SetNamespace(namespace)
+	if err = cl.Delete(ctx, u); err != nil {
+		return fmt.Errorf("deleting %s %s in namespace %s: %w", name, resourceType, namespace, err)
+	}
+
+	return nil
+}
+
+// Apply creates or updates the object with a server-side apply, taking ownership of all its fields.
+func (c *RuntimeClient) Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	u, err := c.toUnstructured(obj)
+	if err != nil {
+		return fmt.Errorf("applying object: %v", err)
+	}
+
+	if err = serverSideApply(ctx, cl, u); err != nil {
+		return fmt.Errorf("applying object: %v", err)
+	}
+
+	return nil
+}
+
+// serverSideApply applies obj forcing ownership of the fields it sets. This replaces a client-side
+// kubectl apply, which always overwrites the fields in the applied config: the CLI is the source of
+// truth for the objects it applies. Without force, re-applying objects created by previous CLI
+// versions (owned by the kubectl-client-side-apply manager) or also applied by the eks-a controller
+// would fail with field conflicts. Fields not present in obj keep their current owners.
+func serverSideApply(ctx context.Context, cl client.Client, obj *unstructured.Unstructured) error {
+	// Server side apply rejects objects with managed fields and
+	// uses the resource version as a precondition.
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	return cl.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+func (c *RuntimeClient) isRegistered(obj runtime.Object) bool {
+	if _, ok := obj.(runtime.Unstructured); ok {
+		return false
+	}
+	_, err := apiutil.GVKForObject(obj, c.scheme)
+	return err == nil
+}
+
+func (c *RuntimeClient) unstructuredFor(cl client.Client, resourceType string, obj runtime.Object) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	if gvk := obj.GetObjectKind().GroupVersionKind(); !gvk.Empty() {
+		u.SetGroupVersionKind(gvk)
+		return u, nil
+	}
+
+	gvk, err := kindFor(cl.RESTMapper(), resourceType)
+	if err != nil {
+		return nil, err
+	}
+	u.SetGroupVersionKind(gvk)
+
+	return u, nil
+}
+
+func (c *RuntimeClient) toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+
+	if u.GroupVersionKind().Empty() {
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return nil, err
+		}
+		u.SetGroupVersionKind(gvk)
+	}
+
+	return u, nil
+}
+
+func fromUnstructured(u runtime.Unstructured, obj runtime.Object) error {
+	if out, ok := obj.(runtime.Unstructured); ok {
+		out.SetUnstructuredContent(u.UnstructuredContent())
+		return nil
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), obj)
+}
+
+// kindFor resolves a kubectl style resource type (resource, resource.group or Kind.version.group)
+// to the kind of the API served for it.
+func kindFor(mapper meta.RESTMapper, resourceType string) (schema.GroupVersionKind, error) {
+	fullySpecified, groupResource := schema.ParseResourceArg(resourceType)
+	if fullySpecified != nil {
+		if gvk, err := mapper.KindFor(*fullySpecified); err == nil {
+			return gvk, nil
+		}
+	}
+
+	gvk, err := mapper.KindFor(groupResource.WithVersion(""))
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("resolving resource type %s: %v", resourceType, err)
+	}
+
+	return gvk, nil
+}
@@ -0,0 +1,116 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+)
+
+func newEnvtestRuntimeClient(t *testing.T) (*kubernetes.RuntimeClient, string, string) {
+	t.Helper()
+	c := kubernetes.NewRuntimeClient()
+	if err := c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	return c, env.KubeconfigFile(t), env.CreateNamespaceForTest(context.Background(), t)
+}
+
+func TestRuntimeClientEnvtestApplyManifest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, kubeconfig, ns := newEnvtestRuntimeClient(t)
+
+	manifest := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm-2
+`)
+	g.Expect(c.ApplyManifest(ctx, kubeconfig, manifest, ns)).To(Succeed())
+
+	updated := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  key: new-value
+`)
+	g.Expect(c.ApplyManifest(ctx, kubeconfig, updated, ns)).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(env.APIReader().Get(ctx, client.ObjectKey{Name: "cm", Namespace: ns}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(HaveKeyWithValue("key", "new-value"))
+	g.Expect(cm.ManagedFields).To(ContainElement(HaveField("Manager", kubernetes.FieldManager)))
+
+	g.Expect(c.DeleteManifest(ctx, kubeconfig, []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm-2
+  namespace: `+ns))).To(Succeed())
+}
+
+func TestRuntimeClientEnvtestApplyAndGetObject(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, kubeconfig, ns := newEnvtestRuntimeClient(t)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cm",
+			Namespace: ns,
+		},
+		Data: map[string]string{"key": "value"},
+	}
+	g.Expect(c.Apply(ctx, kubeconfig, cm)).To(Succeed())
+
+	got := &corev1.ConfigMap{}
+	g.Expect(c.GetObject(ctx, "configmap", "cm", ns, kubeconfig, got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(cm.Data))
+
+	g.Expect(c.Delete(ctx, "configmap", "cm", ns, kubeconfig)).To(Succeed())
+}
+
+func TestRuntimeClientEnvtestWaitForCondition(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c, kubeconfig, ns := newEnvtestRuntimeClient(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: ns,
+		},
+	}
+	g.Expect(env.Client().Create(ctx, cluster)).To(Succeed())
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		cluster.Status.Conditions = clusterv1.Conditions{
+			{
+				Type:               clusterv1.ReadyCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		if err := env.Client().Status().Update(ctx, cluster); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	g.Expect(c.WaitForCondition(ctx, kubeconfig, 10*time.Second, "Ready", "clusters.cluster.x-k8s.io", "my-cluster", ns)).To(Succeed())
+}
@@ -0,0 +1,256 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+)
+
+const (
+	kubeconfig = "k.kubeconfig"
+	namespace  = "eksa-system"
+)
+
+type runtimeClientTest struct {
+	*WithT
+	ctx        context.Context
+	c          *kubernetes.RuntimeClient
+	fakeClient client.WithWatch
+}
+
+func newRuntimeClientTest(t *testing.T, objs ...runtime.Object) *runtimeClientTest {
+	tt := &runtimeClientTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(clusterv1.GroupVersion.WithKind("Cluster"), meta.RESTScopeNamespace)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(rufiov1alpha1.GroupVersion.WithKind("BaseboardManagement"), meta.RESTScopeNamespace)
+
+	tt.c = kubernetes.NewRuntimeClient(
+		kubernetes.WithClientBuilder(func(k string, scheme *runtime.Scheme) (client.WithWatch, error) {
+			tt.Expect(k).To(Equal(kubeconfig))
+			if tt.fakeClient == nil {
+				tt.fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithRuntimeObjects(objs...).Build()
+			}
+			return tt.fakeClient, nil
+		}),
+	)
+	tt.Expect(tt.c.Init()).To(Succeed())
+
+	return tt
+}
+
+func capiCluster(opts ...func(*clusterv1.Cluster)) *clusterv1.Cluster {
+	c := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: namespace,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func withCondition(conditionType clusterv1.ConditionType, status corev1.ConditionStatus) func(*clusterv1.Cluster) {
+	return func(c *clusterv1.Cluster) {
+		c.Status.Conditions = append(c.Status.Conditions, clusterv1.Condition{Type: conditionType, Status: status})
+	}
+}
+
+func TestRuntimeClientGetObjectRegisteredType(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster())
+
+	got := &clusterv1.Cluster{}
+	tt.Expect(tt.c.GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "my-cluster", namespace, kubeconfig, got)).To(Succeed())
+	tt.Expect(got.Name).To(Equal("my-cluster"))
+}
+
+func TestRuntimeClientGetObjectNotFound(t *testing.T) {
+	tt := newRuntimeClientTest(t)
+
+	err := tt.c.GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "my-cluster", namespace, kubeconfig, &clusterv1.Cluster{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "error should be not found")
+}
+
+func TestRuntimeClientGetObjectUnstructured(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster())
+
+	got := &unstructured.Unstructured{}
+	tt.Expect(tt.c.GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "my-cluster", namespace, kubeconfig, got)).To(Succeed())
+	tt.Expect(got.GetName()).To(Equal("my-cluster"))
+	tt.Expect(got.GetKind()).To(Equal("Cluster"))
+}
+
+func TestRuntimeClientGetObjectNotRegisteredType(t *testing.T) {
+	bmc := &unstructured.Unstructured{}
+	bmc.SetGroupVersionKind(rufiov1alpha1.GroupVersion.WithKind("BaseboardManagement"))
+	bmc.SetName("bmc-1")
+	bmc.SetNamespace(namespace)
+	tt := newRuntimeClientTest(t, bmc)
+
+	got := &rufiov1alpha1.BaseboardManagement{}
+	tt.Expect(tt.c.GetObject(tt.ctx, "baseboardmanagements.bmc.tinkerbell.org", "bmc-1", namespace, kubeconfig, got)).To(Succeed())
+	tt.Expect(got.Name).To(Equal("bmc-1"))
+}
+
+func TestRuntimeClientGetObjectInvalidResourceType(t *testing.T) {
+	tt := newRuntimeClientTest(t)
+
+	err := tt.c.GetObject(tt.ctx, "machines.fake.io", "m", namespace, kubeconfig, &unstructured.Unstructured{})
+	tt.Expect(err).To(MatchError(ContainSubstring("resolving resource type machines.fake.io")))
+}
+
+func TestRuntimeClientListObjects(t *testing.T) {
+	other := capiCluster()
+	other.Name = "other-cluster"
+	tt := newRuntimeClientTest(t, capiCluster(), other)
+
+	got := &clusterv1.ClusterList{}
+	tt.Expect(tt.c.ListObjects(tt.ctx, "clusters.cluster.x-k8s.io", namespace, kubeconfig, got)).To(Succeed())
+	tt.Expect(got.Items).To(HaveLen(2))
+}
+
+func TestRuntimeClientDelete(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster())
+
+	tt.Expect(tt.c.Delete(tt.ctx, "clusters.cluster.x-k8s.io", "my-cluster", namespace, kubeconfig)).To(Succeed())
+	err := tt.fakeClient.Get(tt.ctx, client.ObjectKey{Name: "my-cluster", Namespace: namespace}, &clusterv1.Cluster{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "cluster should be deleted")
+}
+
+func TestRuntimeClientDeleteKindVersionGroup(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster())
+
+	tt.Expect(tt.c.Delete(tt.ctx, "Cluster.v1beta1.cluster.x-k8s.io", "my-cluster", namespace, kubeconfig)).To(Succeed())
+}
+
+func TestRuntimeClientDeleteNotFound(t *testing.T) {
+	tt := newRuntimeClientTest(t)
+
+	err := tt.c.Delete(tt.ctx, "clusters.cluster.x-k8s.io", "my-cluster", namespace, kubeconfig)
+	tt.Expect(err).To(MatchError(ContainSubstring("deleting my-cluster clusters.cluster.x-k8s.io in namespace eksa-system")))
+}
+
+func TestRuntimeClientWaitForConditionAlreadyMet(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster(withCondition(clusterv1.ReadyCondition, corev1.ConditionTrue)))
+
+	tt.Expect(tt.c.WaitForCondition(tt.ctx, kubeconfig, time.Second, "Ready", "clusters.cluster.x-k8s.io", "my-cluster", namespace)).To(Succeed())
+}
+
+func TestRuntimeClientWaitForConditionFalse(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster(withCondition(clusterv1.ControlPlaneReadyCondition, corev1.ConditionFalse)))
+
+	tt.Expect(tt.c.WaitForCondition(tt.ctx, kubeconfig, time.Second, "ControlPlaneReady=false", "clusters.cluster.x-k8s.io", "my-cluster", namespace)).To(Succeed())
+}
+
+func TestRuntimeClientWaitForConditionMetAfterUpdate(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster(withCondition(clusterv1.ReadyCondition, corev1.ConditionFalse)))
+	// Build the fake client before updating it from another goroutine
+	tt.Expect(tt.c.GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "my-cluster", namespace, kubeconfig, &clusterv1.Cluster{})).To(Succeed())
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cluster := &clusterv1.Cluster{}
+		if err := tt.fakeClient.Get(tt.ctx, client.ObjectKey{Name: "my-cluster", Namespace: namespace}, cluster); err != nil {
+			t.Error(err)
+			return
+		}
+		cluster.Status.Conditions[0].Status = corev1.ConditionTrue
+		if err := tt.fakeClient.Update(tt.ctx, cluster); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tt.Expect(tt.c.WaitForCondition(tt.ctx, kubeconfig, 5*time.Second, "Ready", "clusters.cluster.x-k8s.io", "my-cluster", namespace)).To(Succeed())
+}
+
+func TestRuntimeClientWaitForConditionTimeout(t *testing.T) {
+	tt := newRuntimeClientTest(t, capiCluster(withCondition(clusterv1.ReadyCondition, corev1.ConditionFalse)))
+
+	err := tt.c.WaitForCondition(tt.ctx, kubeconfig, 100*time.Millisecond, "Ready", "clusters.cluster.x-k8s.io", "my-cluster", namespace)
+	tt.Expect(err).To(MatchError("timed out waiting for condition Ready on clusters.cluster.x-k8s.io/my-cluster"))
+	tt.Expect(err).To(MatchError(kubernetes.ErrWaitTimeout))
+}
+
+func TestRuntimeClientWaitForConditionNotFound(t *testing.T) {
+	tt := newRuntimeClientTest(t)
+
+	err := tt.c.WaitForCondition(tt.ctx, kubeconfig, time.Second, "Ready", "clusters.cluster.x-k8s.io", "my-cluster", namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("not found")))
+}
+
+func TestRuntimeClientWaitForConditionAll(t *testing.T) {
+	other := capiCluster(withCondition(clusterv1.ReadyCondition, corev1.ConditionTrue))
+	other.Name = "other-cluster"
+	tt := newRuntimeClientTest(t, capiCluster(withCondition(clusterv1.ReadyCondition, corev1.ConditionTrue)), other)
+
+	tt.Expect(tt.c.WaitForCondition(tt.ctx, kubeconfig, time.Second, "Ready", "clusters.cluster.x-k8s.io", "", namespace)).To(Succeed())
+}
+
+func TestRuntimeClientWaitForConditionAllNoResources(t *testing.T) {
+	tt := newRuntimeClientTest(t)
+
+	err := tt.c.WaitForCondition(tt.ctx, kubeconfig, time.Second, "Ready", "clusters.cluster.x-k8s.io", "", namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("no matching resources found")))
+}
+
+func TestRuntimeClientApplyManifestInvalidYaml(t *testing.T) {
+	tt := newRuntimeClientTest(t)
+
+	err := tt.c.ApplyManifest(tt.ctx, kubeconfig, []byte("kind: [}"), namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("parsing manifest")))
+}
+
+func TestRuntimeClientApplyManifestUnknownType(t *testing.T) {
+	tt := newRuntimeClientTest(t)
+
+	manifest := []byte(`apiVersion: fake.io/v1
+kind: Machine
+metadata:
+  name: m
+`)
+	err := tt.c.ApplyManifest(tt.ctx, kubeconfig, manifest, namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting rest mapping for Machine m")))
+}
+
+func TestRuntimeClientDeleteManifest(t *testing.T) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}}
+	tt := newRuntimeClientTest(t, cm)
+
+	manifest := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+	tt.Expect(tt.c.DeleteManifest(tt.ctx, kubeconfig, manifest)).To(Succeed())
+	err := tt.fakeClient.Get(tt.ctx, client.ObjectKey{Name: "cm", Namespace: "default"}, &corev1.ConfigMap{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "configmap should be deleted")
+}
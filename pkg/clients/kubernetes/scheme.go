@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -20,10 +22,12 @@ var schemeAdders = []schemeAdder{
 	snowv1.AddToScheme,
 	cloudstackv1.AddToScheme,
 	bootstrapv1.AddToScheme,
+	tinkv1alpha1.AddToScheme,
+	rufiov1alpha1.AddToScheme,
 }
 
 func addToScheme(scheme *runtime.Scheme, schemeAdder ...schemeAdder) error {
-	for _, adder := range schemeAdders {
+	for _, adder := range schemeAdder {
 		if err := adder(scheme); err != nil {
 			return err
 		}
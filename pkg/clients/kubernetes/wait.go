@@ -0,0 +1,198 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// ErrWaitTimeout is returned when a condition isn't met before the wait timeout.
+var ErrWaitTimeout = errors.New("timed out waiting for condition")
+
+// WaitForCondition blocks until the object of resourceType with the given name has the condition, or until
+// all the objects of that type in the namespace have it if name is empty. It uses the same condition format
+// as kubectl wait: "Type" waits for the condition to be true and "Type=value" waits for any other status.
+// Instead of polling, it watches the objects and evaluates the condition on every change.
+func (c *RuntimeClient) WaitForCondition(ctx context.Context, kubeconfig string, timeout time.Duration, condition, resourceType, name, namespace string) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := kindFor(cl.RESTMapper(), resourceType)
+	if err != nil {
+		return fmt.Errorf("waiting for condition %s: %v", condition, err)
+	}
+
+	w := &conditionWaiter{
+		client:    cl,
+		gvk:       gvk,
+		name:      name,
+		namespace: namespace,
+	}
+	w.conditionType, w.conditionStatus = parseCondition(condition)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		done, err := w.waitOnce(ctx)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w %s on %s", ErrWaitTimeout, condition, w.target(resourceType))
+		}
+		if err != nil {
+			return fmt.Errorf("waiting for condition %s on %s: %v", condition, w.target(resourceType), err)
+		}
+		if done {
+			return nil
+		}
+
+		logger.V(6).Info("Watch closed before the condition was met, restarting", "condition", condition, "resource", w.target(resourceType))
+	}
+}
+
+func parseCondition(condition string) (conditionType, status string) {
+	conditionType, status, found := strings.Cut(condition, "=")
+	if !found {
+		status = "true"
+	}
+	return conditionType, status
+}
+
+type conditionWaiter struct {
+	client                         client.WithWatch
+	gvk                            schema.GroupVersionKind
+	name, namespace                string
+	conditionType, conditionStatus string
+}
+
+func (w *conditionWaiter) target(resourceType string) string {
+	if w.name == "" {
+		return resourceType
+	}
+	return resourceType + "/" + w.name
+}
+
+// waitOnce lists the objects to get their current state and then watches them until all of them have the
+// condition or until the watch is closed by the server, in which case it returns false.
+func (w *conditionWaiter) waitOnce(ctx context.Context) (done bool, err error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(w.gvk.GroupVersion().WithKind(w.gvk.Kind + "List"))
+	if err = w.client.List(ctx, list, client.InNamespace(w.namespace)); err != nil {
+		return false, err
+	}
+
+	pending := map[string]bool{}
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if w.matchesName(obj) {
+			pending[obj.GetName()] = !w.hasCondition(obj)
+		}
+	}
+
+	if len(pending) == 0 {
+		if w.name != "" {
+			return false, apierrors.NewNotFound(schema.GroupResource{Group: w.gvk.Group, Resource: w.gvk.Kind}, w.name)
+		}
+		return false, errors.New("no matching resources found")
+	}
+
+	if allMet(pending) {
+		return true, nil
+	}
+
+	watchList := &unstructured.UnstructuredList{}
+	watchList.SetGroupVersionKind(list.GroupVersionKind())
+	watcher, err := w.client.Watch(ctx, watchList, client.InNamespace(w.namespace), &client.ListOptions{
+		Raw: &metav1.ListOptions{ResourceVersion: list.GetResourceVersion()},
+	})
+	if err != nil {
+		return false, err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, nil
+			}
+
+			switch event.Type {
+			case watch.Error:
+				return false, apierrors.FromObject(event.Object)
+			case watch.Deleted:
+				obj, ok := asUnstructured(event.Object)
+				if ok && w.matchesName(obj) {
+					delete(pending, obj.GetName())
+				}
+			case watch.Added, watch.Modified:
+				obj, ok := asUnstructured(event.Object)
+				if ok && w.matchesName(obj) {
+					pending[obj.GetName()] = !w.hasCondition(obj)
+				}
+			}
+
+			if len(pending) > 0 && allMet(pending) {
+				return true, nil
+			}
+		}
+	}
+}
+
+func (w *conditionWaiter) matchesName(obj *unstructured.Unstructured) bool {
+	return w.name == "" || obj.GetName() == w.name
+}
+
+func (w *conditionWaiter) hasCondition(obj *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		if !strings.EqualFold(conditionType, w.conditionType) {
+			continue
+		}
+		status, _, _ := unstructured.NestedString(condition, "status")
+		return strings.EqualFold(status, w.conditionStatus)
+	}
+
+	return false
+}
+
+func asUnstructured(obj runtime.Object) (*unstructured.Unstructured, bool) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, true
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, false
+	}
+	return &unstructured.Unstructured{Object: content}, true
+}
+
+func allMet(pending map[string]bool) bool {
+	for _, isPending := range pending {
+		if isPending {
+			return false
+		}
+	}
+	return true
+}
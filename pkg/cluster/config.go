@@ -5,6 +5,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 )
@@ -23,6 +24,7 @@ type Config struct {
 	GitOpsConfig             *anywherev1.GitOpsConfig
 	FluxConfig               *anywherev1.FluxConfig
 	SnowCredentialsSecret    *v1.Secret
+	Packages                 map[string]*packagesv1.Package
 }
 
 func (c *Config) VsphereMachineConfig(name string) *anywherev1.VSphereMachineConfig {
@@ -84,6 +86,13 @@ func (c *Config) DeepCopy() *Config {
 		c2.AWSIAMConfigs[k] = v.DeepCopy()
 	}
 
+	if c.Packages != nil {
+		c2.Packages = make(map[string]*packagesv1.Package, len(c.Packages))
+	}
+	for k, v := range c.Packages {
+		c2.Packages[k] = v.DeepCopy()
+	}
+
 	return c2
 }
 
@@ -64,7 +64,22 @@ func BuildSpecForCluster(ctx context.Context, cluster *v1alpha1.Cluster, bundles
 	if err != nil {
 		return nil, err
 	}
-	return BuildSpecFromBundles(cluster, bundles, WithEksdRelease(eksd), WithGitOpsConfig(gitOpsConfig), WithFluxConfig(fluxConfig), WithOIDCConfig(oidcConfig), WithAWSIamConfig(awsIamConfig))
+	return BuildSpecFromBundles(cluster, bundles,
+		WithEksdRelease(eksd),
+		withEksdReleaseFetch(workerNodeGroupEksdReleaseFetch(ctx, eksdReleaseFetch)),
+		WithGitOpsConfig(gitOpsConfig),
+		WithFluxConfig(fluxConfig),
+		WithOIDCConfig(oidcConfig),
+		WithAWSIamConfig(awsIamConfig),
+	)
+}
+
+// workerNodeGroupEksdReleaseFetch returns an eksdReleaseFetch reading the EKS-D releases of the worker
+// node group Kubernetes versions from the cluster.
+func workerNodeGroupEksdReleaseFetch(ctx context.Context, fetch EksdReleaseFetch) eksdReleaseFetch {
+	return func(versionsBundle *v1alpha1release.VersionsBundle) (*eksdv1alpha1.Release, error) {
+		return fetch(ctx, versionsBundle.EksD.Name, constants.EksaSystemNamespace)
+	}
 }
 
 func GetBundlesForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch BundlesFetch) (*v1alpha1release.Bundles, error) {
@@ -209,5 +224,15 @@ func BuildSpec(ctx context.Context, client Client, cluster *v1alpha1.Cluster) (*
 		return nil, err
 	}
 
+	if err := spec.initWorkerNodeGroupVersionsBundles(func(versionsBundle *v1alpha1release.VersionsBundle) (*eksdv1alpha1.Release, error) {
+		eksdRelease := &eksdv1alpha1.Release{}
+		if err := client.Get(ctx, versionsBundle.EksD.Name, constants.EksaSystemNamespace, eksdRelease); err != nil {
+			return nil, err
+		}
+		return eksdRelease, nil
+	}); err != nil {
+		return nil, err
+	}
+
 	return spec, nil
 }
@@ -338,6 +338,97 @@ func TestBuildSpecInitError(t *testing.T) {
 	tt.Expect(err).To(MatchError(ContainSubstring("is no present in eksd release")))
 }
 
+func TestBuildSpecWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	tt := newBuildSpecTest(t)
+	kube122 := anywherev1.Kube122
+	tt.cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+		{
+			Name: "md-0",
+		},
+		{
+			Name:              "md-1",
+			KubernetesVersion: &kube122,
+		},
+	}
+	tt.bundles.Spec.VersionsBundles = append(tt.bundles.Spec.VersionsBundles, releasev1.VersionsBundle{
+		KubeVersion: "1.22",
+		EksD: releasev1.EksDRelease{
+			Name: "eksd-122",
+		},
+	})
+	tt.expectGetBundles()
+	tt.expectGetEksd()
+	tt.client.EXPECT().Get(tt.ctx, "eksd-122", "eksa-system", &eksdv1.Release{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			o := obj.(*eksdv1.Release)
+			o.Status = tt.eksdRelease.Status
+			return nil
+		},
+	)
+
+	spec, err := cluster.BuildSpec(tt.ctx, tt.client, tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(spec.WorkerNodeGroupVersionsBundle(tt.cluster.Spec.WorkerNodeGroupConfigurations[0])).To(Equal(spec.VersionsBundle))
+	tt.Expect(spec.WorkerNodeGroupVersionsBundle(tt.cluster.Spec.WorkerNodeGroupConfigurations[1])).To(Equal(&cluster.VersionsBundle{
+		VersionsBundle: &tt.bundles.Spec.VersionsBundles[1],
+		KubeDistro:     tt.kubeDistro,
+	}))
+}
+
+func TestBuildSpecWorkerNodeGroupUnsupportedKubernetesVersionError(t *testing.T) {
+	tt := newBuildSpecTest(t)
+	kube122 := anywherev1.Kube122
+	tt.cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+		{
+			Name:              "md-0",
+			KubernetesVersion: &kube122,
+		},
+	}
+	tt.bundles.Spec.Number = 2
+	tt.expectGetBundles()
+	tt.expectGetEksd()
+
+	_, err := cluster.BuildSpec(tt.ctx, tt.client, tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("worker node group md-0: kubernetes version 1.22 is not supported by bundles manifest 2")))
+}
+
+func TestBuildSpecForClusterWorkerNodeGroupEksdReleaseFromCluster(t *testing.T) {
+	tt := newBuildSpecTest(t)
+	kube122 := anywherev1.Kube122
+	tt.cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+		{
+			Name:              "md-0",
+			KubernetesVersion: &kube122,
+		},
+	}
+	tt.bundles.Spec.VersionsBundles = append(tt.bundles.Spec.VersionsBundles, releasev1.VersionsBundle{
+		KubeVersion: "1.22",
+		EksD: releasev1.EksDRelease{
+			Name: "eksd-122",
+			// The manifest URL is not reachable, the release must be read from the cluster.
+			EksDReleaseUrl: "https://unreachable.example.com/eksd-122.yaml",
+		},
+	})
+
+	bundlesFetch := func(_ context.Context, _, _ string) (*releasev1.Bundles, error) {
+		return tt.bundles, nil
+	}
+	var fetched []string
+	eksdReleaseFetch := func(_ context.Context, name, namespace string) (*eksdv1.Release, error) {
+		tt.Expect(namespace).To(Equal("eksa-system"))
+		fetched = append(fetched, name)
+		return tt.eksdRelease, nil
+	}
+
+	spec, err := cluster.BuildSpecForCluster(tt.ctx, tt.cluster, bundlesFetch, eksdReleaseFetch, nil, nil, nil, nil)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(fetched).To(Equal([]string{"eksd-123", "eksd-122"}))
+	tt.Expect(spec.WorkerNodeGroupVersionsBundle(tt.cluster.Spec.WorkerNodeGroupConfigurations[0])).To(Equal(&cluster.VersionsBundle{
+		VersionsBundle: &tt.bundles.Spec.VersionsBundles[1],
+		KubeDistro:     tt.kubeDistro,
+	}))
+}
+
 func wantKubeDistroForEksdRelease() (*eksdv1.Release, *cluster.KubeDistro) {
 	eksdRelease := &eksdv1.Release{
 		ObjectMeta: metav1.ObjectMeta{
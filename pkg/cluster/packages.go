@@ -0,0 +1,69 @@
+package cluster
+
+import (
+	"fmt"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// PackageKind is the kind of the curated packages objects that can be included in the cluster config.
+const PackageKind = "Package"
+
+func packagesEntry() *ConfigManagerEntry {
+	return &ConfigManagerEntry{
+		APIObjectMapping: map[string]APIObjectGenerator{
+			PackageKind: func() APIObject {
+				return &packagesv1.Package{}
+			},
+		},
+		Processors: []ParsedProcessor{processPackages},
+		Defaulters: []Defaulter{
+			func(c *Config) error {
+				for _, p := range c.Packages {
+					if p.Namespace == "" {
+						p.Namespace = PackagesNamespace(c.Cluster.Name)
+					}
+				}
+				return nil
+			},
+		},
+		Validations: []Validation{
+			func(c *Config) error {
+				for _, p := range c.Packages {
+					if p.Spec.PackageName == "" {
+						return fmt.Errorf("package %s: packageName is required", p.Name)
+					}
+				}
+				return nil
+			},
+			func(c *Config) error {
+				namespace := PackagesNamespace(c.Cluster.Name)
+				for _, p := range c.Packages {
+					if p.Namespace != "" && p.Namespace != namespace {
+						return fmt.Errorf("package %s: namespace %s is not the cluster packages namespace %s", p.Name, p.Namespace, namespace)
+					}
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// PackagesNamespace returns the namespace where the curated packages of a cluster live.
+func PackagesNamespace(clusterName string) string {
+	return constants.EksaPackagesName + "-" + clusterName
+}
+
+func processPackages(c *Config, objects ObjectLookup) {
+	for _, o := range objects {
+		p, ok := o.(*packagesv1.Package)
+		if !ok {
+			continue
+		}
+		if c.Packages == nil {
+			c.Packages = map[string]*packagesv1.Package{}
+		}
+		c.Packages[p.Name] = p
+	}
+}
@@ -0,0 +1,112 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const clusterWithoutPackages = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  kubernetesVersion: "1.23"
+`
+
+const clusterWithPackages = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  kubernetesVersion: "1.23"
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+spec:
+  packageName: harbor
+  config: |
+    externalURL: https://harbor.local
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-metallb
+  namespace: eksa-packages-my-cluster
+spec:
+  packageName: metallb
+`
+
+func TestParseConfigPackages(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := cluster.ParseConfig([]byte(clusterWithPackages))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Packages).To(HaveLen(2))
+	g.Expect(config.Packages["my-harbor"].Spec.PackageName).To(Equal("harbor"))
+	g.Expect(config.Packages["my-harbor"].Spec.Config).To(Equal("externalURL: https://harbor.local"))
+	g.Expect(config.Packages["my-metallb"].Spec.PackageName).To(Equal("metallb"))
+}
+
+func TestValidateConfigPackagesSuccess(t *testing.T) {
+	g := NewWithT(t)
+	config := clusterConfigFromFile(t, "testdata/cluster_1_19.yaml")
+	config.Packages = map[string]*packagesv1.Package{
+		"my-harbor": {
+			ObjectMeta: metav1.ObjectMeta{Name: "my-harbor"},
+			Spec:       packagesv1.PackageSpec{PackageName: "harbor"},
+		},
+	}
+
+	g.Expect(cluster.SetConfigDefaults(config)).To(Succeed())
+	g.Expect(config.Packages["my-harbor"].Namespace).To(Equal("eksa-packages-" + config.Cluster.Name))
+	g.Expect(cluster.ValidateConfig(config)).To(Succeed())
+}
+
+func TestParseConfigNoPackages(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := cluster.ParseConfig([]byte(clusterWithoutPackages))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Packages).To(BeEmpty())
+}
+
+func TestValidateConfigPackagesWrongNamespace(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := cluster.ParseConfig([]byte(clusterWithPackages))
+	g.Expect(err).NotTo(HaveOccurred())
+	config.Packages["my-metallb"].Namespace = "default"
+
+	g.Expect(cluster.ValidateConfig(config)).To(MatchError(ContainSubstring(
+		"package my-metallb: namespace default is not the cluster packages namespace eksa-packages-my-cluster",
+	)))
+}
+
+func TestValidateConfigPackagesMissingPackageName(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := cluster.ParseConfig([]byte(clusterWithPackages))
+	g.Expect(err).NotTo(HaveOccurred())
+	config.Packages["my-harbor"].Spec.PackageName = ""
+
+	g.Expect(cluster.ValidateConfig(config)).To(MatchError(ContainSubstring("package my-harbor: packageName is required")))
+}
+
+func TestConfigDeepCopyPackages(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := cluster.ParseConfig([]byte(clusterWithPackages))
+	g.Expect(err).NotTo(HaveOccurred())
+	c2 := config.DeepCopy()
+	g.Expect(c2.Packages).To(Equal(config.Packages))
+
+	c2.Packages["my-harbor"].Spec.PackageName = "changed"
+	g.Expect(config.Packages["my-harbor"].Spec.PackageName).To(Equal("harbor"))
+}
@@ -70,6 +70,7 @@ func snowEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			ValidateSnowControlPlanePlacement,
 		},
 	}
 }
@@ -123,6 +124,37 @@ func SetSnowMachineConfigsAnnotations(c *Config) error {
 	return nil
 }
 
+// ValidateSnowControlPlanePlacement validates the machine configs of the control plane and etcd
+// machines spread them across the devices, since only worker node groups can be pinned to a device.
+func ValidateSnowControlPlanePlacement(c *Config) error {
+	if c.SnowMachineConfigs == nil {
+		return nil
+	}
+
+	refs := map[string]*anywherev1.Ref{
+		"control plane": c.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef,
+	}
+	if c.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		refs["etcd"] = c.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef
+	}
+
+	for _, group := range []string{"control plane", "etcd"} {
+		ref := refs[group]
+		if ref == nil {
+			continue
+		}
+		m, ok := c.SnowMachineConfigs[ref.Name]
+		if !ok {
+			continue
+		}
+		if m.Spec.PlacementStrategy == anywherev1.PinnedPlacement {
+			return fmt.Errorf("SnowMachineConfig %s used by the %s machines can't use PlacementStrategy %s, %s machines are always spread across the devices", m.Name, group, anywherev1.PinnedPlacement, group)
+		}
+	}
+
+	return nil
+}
+
 func getSnowDatacenter(ctx context.Context, client Client, c *Config) error {
 	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.SnowDatacenterKind {
 		return nil
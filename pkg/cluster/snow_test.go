@@ -123,6 +123,69 @@ func TestSetSnowMachineConfigsAnnotations(t *testing.T) {
 	}
 }
 
+func TestValidateSnowControlPlanePlacement(t *testing.T) {
+	tests := []struct {
+		name      string
+		etcd      bool
+		cpPlace   anywherev1.SnowPlacementStrategy
+		etcdPlace anywherev1.SnowPlacementStrategy
+		wantErr   string
+	}{
+		{
+			name:    "spread control plane",
+			cpPlace: anywherev1.SpreadPlacement,
+		},
+		{
+			name:    "pinned control plane",
+			cpPlace: anywherev1.PinnedPlacement,
+			wantErr: "SnowMachineConfig cp-machine used by the control plane machines can't use PlacementStrategy pinned",
+		},
+		{
+			name:      "pinned etcd",
+			etcd:      true,
+			cpPlace:   anywherev1.SpreadPlacement,
+			etcdPlace: anywherev1.PinnedPlacement,
+			wantErr:   "SnowMachineConfig etcd-machine used by the etcd machines can't use PlacementStrategy pinned",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &cluster.Config{
+				Cluster: &anywherev1.Cluster{
+					Spec: anywherev1.ClusterSpec{
+						ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+							MachineGroupRef: &anywherev1.Ref{Name: "cp-machine"},
+						},
+					},
+				},
+				SnowMachineConfigs: map[string]*anywherev1.SnowMachineConfig{
+					"cp-machine": {
+						ObjectMeta: metav1.ObjectMeta{Name: "cp-machine"},
+						Spec:       anywherev1.SnowMachineConfigSpec{PlacementStrategy: tt.cpPlace},
+					},
+					"etcd-machine": {
+						ObjectMeta: metav1.ObjectMeta{Name: "etcd-machine"},
+						Spec:       anywherev1.SnowMachineConfigSpec{PlacementStrategy: tt.etcdPlace},
+					},
+				},
+			}
+			if tt.etcd {
+				config.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{
+					MachineGroupRef: &anywherev1.Ref{Name: "etcd-machine"},
+				}
+			}
+
+			err := cluster.ValidateSnowControlPlanePlacement(config)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
 func TestDefaultConfigClientBuilderSnowCluster(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
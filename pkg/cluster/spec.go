@@ -12,8 +12,10 @@ import (
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/manifests/signature"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -28,37 +30,44 @@ var releasesManifestURL string
 
 type Spec struct {
 	*Config
-	OIDCConfig                *eksav1alpha1.OIDCConfig
-	AWSIamConfig              *eksav1alpha1.AWSIamConfig
-	releasesManifestURL       string
-	bundlesManifestURL        string
-	configFS                  embed.FS
-	userAgent                 string
-	reader                    *files.Reader
-	VersionsBundle            *VersionsBundle
-	eksdRelease               *eksdv1alpha1.Release
+	OIDCConfig          *eksav1alpha1.OIDCConfig
+	AWSIamConfig        *eksav1alpha1.AWSIamConfig
+	releasesManifestURL string
+	bundlesManifestURL  string
+	configFS            embed.FS
+	userAgent           string
+	reader              *files.Reader
+	signatureVerifier   *signature.Verifier
+	VersionsBundle      *VersionsBundle
+	eksdRelease         *eksdv1alpha1.Release
+	// fetchEksdRelease retrieves the EKS-D releases of the worker node group Kubernetes versions.
+	// By default, they are read from the URLs in the bundle.
+	fetchEksdRelease          eksdReleaseFetch
 	Bundles                   *v1alpha1.Bundles
 	ManagementCluster         *types.Cluster
 	TinkerbellTemplateConfigs map[string]*eksav1alpha1.TinkerbellTemplateConfig
+	// WorkerNodeGroupVersionsBundles holds the VersionsBundles for the worker node group
+	// Kubernetes versions that differ from the cluster Kubernetes version
+	WorkerNodeGroupVersionsBundles map[eksav1alpha1.KubernetesVersion]*VersionsBundle
 }
 
 func (s *Spec) DeepCopy() *Spec {
 	return &Spec{
-		Config:              s.Config.DeepCopy(),
-		OIDCConfig:          s.OIDCConfig.DeepCopy(),
-		AWSIamConfig:        s.AWSIamConfig.DeepCopy(),
-		releasesManifestURL: s.releasesManifestURL,
-		bundlesManifestURL:  s.bundlesManifestURL,
-		configFS:            s.configFS,
-		reader:              s.reader,
-		userAgent:           s.userAgent,
-		VersionsBundle: &VersionsBundle{
-			VersionsBundle: s.VersionsBundle.VersionsBundle.DeepCopy(),
-			KubeDistro:     s.VersionsBundle.KubeDistro.deepCopy(),
-		},
-		eksdRelease:               s.eksdRelease.DeepCopy(),
-		Bundles:                   s.Bundles.DeepCopy(),
-		TinkerbellTemplateConfigs: s.TinkerbellTemplateConfigs,
+		Config:                         s.Config.DeepCopy(),
+		OIDCConfig:                     s.OIDCConfig.DeepCopy(),
+		AWSIamConfig:                   s.AWSIamConfig.DeepCopy(),
+		releasesManifestURL:            s.releasesManifestURL,
+		bundlesManifestURL:             s.bundlesManifestURL,
+		configFS:                       s.configFS,
+		reader:                         s.reader,
+		signatureVerifier:              s.signatureVerifier,
+		userAgent:                      s.userAgent,
+		VersionsBundle:                 s.VersionsBundle.deepCopy(),
+		eksdRelease:                    s.eksdRelease.DeepCopy(),
+		fetchEksdRelease:               s.fetchEksdRelease,
+		Bundles:                        s.Bundles.DeepCopy(),
+		TinkerbellTemplateConfigs:      s.TinkerbellTemplateConfigs,
+		WorkerNodeGroupVersionsBundles: deepCopyVersionsBundles(s.WorkerNodeGroupVersionsBundles),
 	}
 }
 
@@ -67,6 +76,24 @@ type VersionsBundle struct {
 	KubeDistro *KubeDistro
 }
 
+func (vb *VersionsBundle) deepCopy() *VersionsBundle {
+	return &VersionsBundle{
+		VersionsBundle: vb.VersionsBundle.DeepCopy(),
+		KubeDistro:     vb.KubeDistro.deepCopy(),
+	}
+}
+
+func deepCopyVersionsBundles(v map[eksav1alpha1.KubernetesVersion]*VersionsBundle) map[eksav1alpha1.KubernetesVersion]*VersionsBundle {
+	if v == nil {
+		return nil
+	}
+	c := make(map[eksav1alpha1.KubernetesVersion]*VersionsBundle, len(v))
+	for kubeVersion, vb := range v {
+		c[kubeVersion] = vb.deepCopy()
+	}
+	return c
+}
+
 type KubeDistro struct {
 	Kubernetes          VersionedRepository
 	CoreDNS             VersionedRepository
@@ -110,6 +137,14 @@ func WithOverrideBundlesManifest(fileURL string) SpecOpt {
 	}
 }
 
+// WithManifestSignatureVerifier sets the Verifier used to check the signature of the
+// releases and bundles manifests. By default, the Verifier is built from the environment.
+func WithManifestSignatureVerifier(verifier *signature.Verifier) SpecOpt {
+	return func(s *Spec) {
+		s.signatureVerifier = verifier
+	}
+}
+
 func WithManagementCluster(cluster *types.Cluster) SpecOpt {
 	return func(s *Spec) {
 		s.ManagementCluster = cluster
@@ -128,6 +163,14 @@ func WithEksdRelease(release *eksdv1alpha1.Release) SpecOpt {
 	}
 }
 
+// withEksdReleaseFetch sets how the EKS-D releases of the worker node group Kubernetes versions
+// are retrieved when building the Spec from bundles.
+func withEksdReleaseFetch(fetch eksdReleaseFetch) SpecOpt {
+	return func(s *Spec) {
+		s.fetchEksdRelease = fetch
+	}
+}
+
 func WithFluxConfig(fluxConfig *eksav1alpha1.FluxConfig) SpecOpt {
 	return func(s *Spec) {
 		s.FluxConfig = fluxConfig
@@ -216,6 +259,10 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 		return nil, err
 	}
 
+	if err = s.initWorkerNodeGroupVersionsBundles(s.readEksdRelease); err != nil {
+		return nil, err
+	}
+
 	switch s.Cluster.Spec.DatacenterRef.Kind {
 	case eksav1alpha1.TinkerbellDatacenterKind:
 		templateConfigs, err := eksav1alpha1.GetTinkerbellTemplateConfig(clusterConfigPath)
@@ -266,6 +313,76 @@ func (s *Spec) init(config *Config, bundles *v1alpha1.Bundles, versionsBundle *v
 	return nil
 }
 
+// WorkerNodeGroupVersionsBundle returns the VersionsBundle for the Kubernetes version of the worker node group.
+func (s *Spec) WorkerNodeGroupVersionsBundle(workerNodeGroupConfig eksav1alpha1.WorkerNodeGroupConfiguration) *VersionsBundle {
+	kubeVersion := s.Cluster.WorkerNodeGroupKubernetesVersion(workerNodeGroupConfig)
+	if vb, ok := s.WorkerNodeGroupVersionsBundles[kubeVersion]; ok {
+		return vb
+	}
+	return s.VersionsBundle
+}
+
+type eksdReleaseFetch func(versionsBundle *v1alpha1.VersionsBundle) (*eksdv1alpha1.Release, error)
+
+// initWorkerNodeGroupVersionsBundles builds the VersionsBundles for all worker node group
+// Kubernetes versions that differ from the cluster one
+func (s *Spec) initWorkerNodeGroupVersionsBundles(fetchEksdRelease eksdReleaseFetch) error {
+	s.WorkerNodeGroupVersionsBundles = nil
+	for _, workerNodeGroupConfig := range s.Cluster.Spec.WorkerNodeGroupConfigurations {
+		kubeVersion := s.Cluster.WorkerNodeGroupKubernetesVersion(workerNodeGroupConfig)
+		if kubeVersion == s.Cluster.Spec.KubernetesVersion {
+			continue
+		}
+		if _, ok := s.WorkerNodeGroupVersionsBundles[kubeVersion]; ok {
+			continue
+		}
+
+		versionsBundle, err := getVersionsBundleForKubernetesVersion(kubeVersion, s.Bundles)
+		if err != nil {
+			return fmt.Errorf("worker node group %s: %v", workerNodeGroupConfig.Name, err)
+		}
+
+		eksd, err := fetchEksdRelease(versionsBundle)
+		if err != nil {
+			return fmt.Errorf("fetching eksd release for kubernetes version %s: %v", kubeVersion, err)
+		}
+
+		kubeDistro, err := buildKubeDistro(eksd)
+		if err != nil {
+			return err
+		}
+
+		if s.WorkerNodeGroupVersionsBundles == nil {
+			s.WorkerNodeGroupVersionsBundles = map[eksav1alpha1.KubernetesVersion]*VersionsBundle{}
+		}
+		s.WorkerNodeGroupVersionsBundles[kubeVersion] = &VersionsBundle{
+			VersionsBundle: versionsBundle,
+			KubeDistro:     kubeDistro,
+		}
+	}
+
+	return nil
+}
+
+func (s *Spec) readEksdRelease(versionsBundle *v1alpha1.VersionsBundle) (*eksdv1alpha1.Release, error) {
+	return bundles.ReadEKSD(s.reader, *versionsBundle)
+}
+
+// workerNodeGroupEksdRelease retrieves the EKS-D release of a worker node group Kubernetes version with
+// fetchEksdRelease when set. Like for the cluster Kubernetes version, it falls back to the manifest URL
+// in the bundle when the release can't be retrieved that way.
+func (s *Spec) workerNodeGroupEksdRelease(versionsBundle *v1alpha1.VersionsBundle) (*eksdv1alpha1.Release, error) {
+	if s.fetchEksdRelease != nil {
+		eksd, err := s.fetchEksdRelease(versionsBundle)
+		if err == nil {
+			return eksd, nil
+		}
+		logger.V(4).Info("EKS-D release objects cannot be retrieved from the cluster. Fetching EKS-D release manifest from the URL in the bundle", "kubernetesVersion", versionsBundle.KubeVersion)
+	}
+
+	return s.readEksdRelease(versionsBundle)
+}
+
 func BuildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundlesManifest *v1alpha1.Bundles, opts ...SpecOpt) (*Spec, error) {
 	s := NewSpec(opts...)
 
@@ -293,6 +410,10 @@ func BuildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundlesManifest *v1alph
 		KubeDistro:     kubeDistro,
 	}
 
+	if err = s.initWorkerNodeGroupVersionsBundles(s.workerNodeGroupEksdRelease); err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
 
@@ -301,13 +422,17 @@ func (s *Spec) newReader() *files.Reader {
 }
 
 func (s *Spec) GetBundles(cliVersion version.Info) (*v1alpha1.Bundles, error) {
+	opts := []manifests.ReaderOpt{manifests.WithReleasesManifest(s.releasesManifestURL)}
+	if s.signatureVerifier != nil {
+		opts = append(opts, manifests.WithSignatureVerifier(s.signatureVerifier))
+	}
+	manifestReader := manifests.NewReader(s.reader, opts...)
 	bundlesURL := s.bundlesManifestURL
 	if bundlesURL == "" {
-		manifestReader := manifests.NewReader(s.reader, manifests.WithReleasesManifest(s.releasesManifestURL))
 		return manifestReader.ReadBundlesForVersion(cliVersion.GitVersion)
 	}
 
-	return bundles.Read(s.reader, bundlesURL)
+	return manifestReader.ReadBundles(bundlesURL)
 }
 
 func (s *Spec) KubeDistroImages() []v1alpha1.Image {
@@ -11,6 +11,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/manifests/signature"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -18,6 +19,8 @@ import (
 //go:embed testdata
 var testdataFS embed.FS
 
+var skipSignature = cluster.WithManifestSignatureVerifier(signature.NewSkipVerifier())
+
 func TestNewSpecInvalidClusterConfig(t *testing.T) {
 	v := version.Info{}
 	if _, err := cluster.NewSpecFromClusterConfig("testdata/cluster_invalid_kinds.yaml", v); err == nil {
@@ -102,7 +105,7 @@ func TestNewSpecError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			v := version.Info{GitVersion: tt.cliVersion}
-			if _, err := cluster.NewSpecFromClusterConfig(tt.clusterConfigFile, v, cluster.WithReleasesManifest(tt.releaseURL)); err == nil {
+			if _, err := cluster.NewSpecFromClusterConfig(tt.clusterConfigFile, v, cluster.WithReleasesManifest(tt.releaseURL), skipSignature); err == nil {
 				t.Fatal("NewSpec() error nil, want err not nil")
 			}
 		})
@@ -116,6 +119,7 @@ func TestNewSpecValidEmbedManifest(t *testing.T) {
 		v,
 		cluster.WithReleasesManifest("embed:///testdata/simple_release.yaml"),
 		cluster.WithEmbedFS(testdataFS),
+		skipSignature,
 	)
 	if err != nil {
 		t.Fatalf("NewSpec() error = %v, want err nil", err)
@@ -124,7 +128,7 @@ func TestNewSpecValidEmbedManifest(t *testing.T) {
 
 func TestNewSpecValid(t *testing.T) {
 	v := version.Info{GitVersion: "v0.0.1"}
-	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v, cluster.WithReleasesManifest("testdata/simple_release.yaml"))
+	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v, cluster.WithReleasesManifest("testdata/simple_release.yaml"), skipSignature)
 	if err != nil {
 		t.Fatalf("NewSpec() error = %v, want err nil", err)
 	}
@@ -178,6 +182,7 @@ func TestNewSpecFromClusterConfigTinkerbellValid(t *testing.T) {
 	}
 	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_tinkerbell_1_19.yaml", v,
 		cluster.WithReleasesManifest("testdata/simple_release.yaml"),
+		skipSignature,
 	)
 
 	g.Expect(err).NotTo(HaveOccurred())
@@ -190,6 +195,7 @@ func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	gotSpec, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v,
 		cluster.WithReleasesManifest("testdata/invalid_release_version.yaml"),
 		cluster.WithOverrideBundlesManifest("testdata/simple_bundle.yaml"),
+		skipSignature,
 	)
 	if err != nil {
 		t.Fatalf("NewSpec() error = %v, want err nil", err)
@@ -203,6 +203,7 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 	}
 
 	SetIdentityAuthInKubeadmControlPlane(kcp, clusterSpec)
+	SetUpgradeRolloutStrategyInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy)
 
 	return kcp, nil
 }
@@ -250,7 +251,7 @@ func KubeadmConfigTemplate(clusterSpec *cluster.Spec, workerNodeGroupConfig anyw
 func MachineDeployment(clusterSpec *cluster.Spec, workerNodeGroupConfig anywherev1.WorkerNodeGroupConfiguration, bootstrapObject, infrastructureObject APIObject) clusterv1.MachineDeployment {
 	clusterName := clusterSpec.Cluster.GetName()
 	replicas := int32(workerNodeGroupConfig.Count)
-	version := clusterSpec.VersionsBundle.KubeDistro.Kubernetes.Tag
+	version := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfig).KubeDistro.Kubernetes.Tag
 
 	md := &clusterv1.MachineDeployment{
 		TypeMeta: metav1.TypeMeta{
@@ -294,6 +295,7 @@ func MachineDeployment(clusterSpec *cluster.Spec, workerNodeGroupConfig anywhere
 	}
 
 	ConfigureAutoscalingInMachineDeployment(md, workerNodeGroupConfig.AutoScalingConfiguration)
+	SetUpgradeRolloutStrategyInMachineDeployment(md, workerNodeGroupConfig.UpgradeRolloutStrategy)
 
 	return *md
 }
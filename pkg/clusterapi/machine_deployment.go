@@ -0,0 +1,38 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MachineDeploymentReady checks that a machine deployment has finished rolling out:
+// all the replicas are up to date, ready and available and there are no old machines left.
+func MachineDeploymentReady(md *clusterv1.MachineDeployment) error {
+	if md.Status.ObservedGeneration != md.Generation {
+		return fmt.Errorf("machine deployment %s status needs to be refreshed: observed generation is %d, want %d", md.Name, md.Status.ObservedGeneration, md.Generation)
+	}
+
+	var replicas int32
+	if md.Spec.Replicas != nil {
+		replicas = *md.Spec.Replicas
+	}
+
+	if md.Status.UpdatedReplicas != replicas {
+		return fmt.Errorf("%d machine deployment replicas are not updated yet", replicas-md.Status.UpdatedReplicas)
+	}
+
+	if md.Status.Replicas != replicas {
+		return fmt.Errorf("machine deployment has %d replicas, want %d", md.Status.Replicas, replicas)
+	}
+
+	if md.Status.ReadyReplicas != replicas {
+		return fmt.Errorf("%d machine deployment replicas are not ready", replicas-md.Status.ReadyReplicas)
+	}
+
+	if md.Status.UnavailableReplicas != 0 {
+		return fmt.Errorf("%d machine deployment replicas are unavailable", md.Status.UnavailableReplicas)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestMachineDeploymentReady(t *testing.T) {
+	replicas := int32(2)
+	tests := []struct {
+		name    string
+		status  clusterv1.MachineDeploymentStatus
+		wantErr string
+	}{
+		{
+			name:   "rolled out",
+			status: clusterv1.MachineDeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+		},
+		{
+			name:    "status not refreshed",
+			status:  clusterv1.MachineDeploymentStatus{ObservedGeneration: 0, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+			wantErr: "status needs to be refreshed",
+		},
+		{
+			name:    "replicas not updated",
+			status:  clusterv1.MachineDeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 2},
+			wantErr: "1 machine deployment replicas are not updated yet",
+		},
+		{
+			name:    "old machines left",
+			status:  clusterv1.MachineDeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 2, ReadyReplicas: 2},
+			wantErr: "machine deployment has 3 replicas, want 2",
+		},
+		{
+			name:    "replicas not ready",
+			status:  clusterv1.MachineDeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 1},
+			wantErr: "1 machine deployment replicas are not ready",
+		},
+		{
+			name:    "replicas unavailable",
+			status:  clusterv1.MachineDeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2, UnavailableReplicas: 1},
+			wantErr: "1 machine deployment replicas are unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			md := &clusterv1.MachineDeployment{}
+			md.Generation = 1
+			md.Spec.Replicas = &replicas
+			md.Status = tt.status
+			err := clusterapi.MachineDeploymentReady(md)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestSetUpgradeRolloutStrategyInKubeadmControlPlane(t *testing.T) {
+	tests := []struct {
+		name            string
+		rolloutStrategy *v1alpha1.ControlPlaneUpgradeRolloutStrategy
+		want            *controlplanev1.KubeadmControlPlane
+	}{
+		{
+			name:            "no upgrade rollout strategy",
+			rolloutStrategy: nil,
+			want:            wantKubeadmControlPlane(),
+		},
+		{
+			name: "with maxSurge",
+			rolloutStrategy: &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+				RollingUpdate: v1alpha1.ControlPlaneRollingUpdateParams{
+					MaxSurge: 0,
+				},
+			},
+			want: func() *controlplanev1.KubeadmControlPlane {
+				k := wantKubeadmControlPlane()
+				maxSurge := intstr.FromInt(0)
+				k.Spec.RolloutStrategy = &controlplanev1.RolloutStrategy{
+					Type: controlplanev1.RollingUpdateStrategyType,
+					RollingUpdate: &controlplanev1.RollingUpdate{
+						MaxSurge: &maxSurge,
+					},
+				}
+				return k
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newApiBuilerTest(t)
+			got := wantKubeadmControlPlane()
+			clusterapi.SetUpgradeRolloutStrategyInKubeadmControlPlane(got, tt.rolloutStrategy)
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetUpgradeRolloutStrategyInMachineDeployment(t *testing.T) {
+	tests := []struct {
+		name            string
+		rolloutStrategy *v1alpha1.WorkerNodesUpgradeRolloutStrategy
+		want            clusterv1.MachineDeployment
+	}{
+		{
+			name:            "no upgrade rollout strategy",
+			rolloutStrategy: nil,
+			want:            wantMachineDeployment(),
+		},
+		{
+			name: "with maxSurge and maxUnavailable",
+			rolloutStrategy: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+				RollingUpdate: v1alpha1.WorkerNodesRollingUpdateParams{
+					MaxSurge:       1,
+					MaxUnavailable: 2,
+				},
+			},
+			want: func() clusterv1.MachineDeployment {
+				md := wantMachineDeployment()
+				maxSurge := intstr.FromInt(1)
+				maxUnavailable := intstr.FromInt(2)
+				md.Spec.Strategy = &clusterv1.MachineDeploymentStrategy{
+					Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+					RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+						MaxSurge:       &maxSurge,
+						MaxUnavailable: &maxUnavailable,
+					},
+				}
+				return md
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newApiBuilerTest(t)
+			got := wantMachineDeployment()
+			clusterapi.SetUpgradeRolloutStrategyInMachineDeployment(&got, tt.rolloutStrategy)
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,663 @@
+package clusterconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/pkg/api"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const vSphereServerKey = "VSPHERE_SERVER"
+
+var removeFromDefaultConfig = []string{"spec.clusterNetwork.dns"}
+
+// VSphereClient discovers the vSphere resources a cluster can be created with.
+type VSphereClient interface {
+	ConfigureServer(server string, insecure bool) error
+	ConfigureCertThumbprint(ctx context.Context, server, thumbprint string) error
+	ListDatacenters(ctx context.Context) ([]string, error)
+	ListNetworks(ctx context.Context, datacenter string) ([]string, error)
+	ListDatastores(ctx context.Context, datacenter string) ([]string, error)
+	ListResourcePools(ctx context.Context, datacenter string) ([]string, error)
+	ListTemplates(ctx context.Context, datacenter string) ([]string, error)
+}
+
+// CloudStackClient discovers and validates the CloudStack resources a cluster can be created with.
+type CloudStackClient interface {
+	ListZones(ctx context.Context, profile string) ([]string, error)
+	ListNetworks(ctx context.Context, profile string, zoneId string) ([]string, error)
+	ListServiceOfferings(ctx context.Context, profile string, zoneId string) ([]string, error)
+	ListTemplates(ctx context.Context, profile string, zoneId string) ([]string, error)
+	ValidateZoneAndGetId(ctx context.Context, profile string, zone v1alpha1.CloudStackZone) (string, error)
+	ValidateDomainAndGetId(ctx context.Context, profile string, domain string) (string, error)
+	ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error
+	GetManagementApiEndpoint(profile string) (string, error)
+}
+
+// Generator builds a cluster config from the answers to the Questions it asks through a Prompter.
+// Whenever possible, the options offered are discovered from the provider and answers are validated
+// before moving to the next Question.
+type Generator struct {
+	prompter           Prompter
+	vsphere            VSphereClient
+	cloudstack         CloudStackClient
+	cloudstackProfiles []string
+}
+
+// GeneratorOpt allows to customize a Generator.
+type GeneratorOpt func(*Generator)
+
+// WithVSphereClient sets the client used to discover vSphere resources.
+func WithVSphereClient(client VSphereClient) GeneratorOpt {
+	return func(g *Generator) {
+		g.vsphere = client
+	}
+}
+
+// WithCloudStackClient sets the client used to discover CloudStack resources and the profiles
+// available in the CloudStack credentials.
+func WithCloudStackClient(client CloudStackClient, profiles []string) GeneratorOpt {
+	return func(g *Generator) {
+		g.cloudstack = client
+		g.cloudstackProfiles = profiles
+	}
+}
+
+// NewGenerator builds a Generator.
+func NewGenerator(prompter Prompter, opts ...GeneratorOpt) *Generator {
+	g := &Generator{
+		prompter: prompter,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// AskProvider asks which of supportedProviders to generate the cluster config for.
+func AskProvider(prompter Prompter, supportedProviders []string) (string, error) {
+	return prompter.Ask(Question{
+		Key:     "provider",
+		Message: "Provider",
+		Options: supportedProviders,
+	})
+}
+
+// Generate asks for the configuration of a cluster named clusterName for provider and returns
+// the cluster config as a multi-document yaml.
+func (g *Generator) Generate(ctx context.Context, clusterName, provider string) ([]byte, error) {
+	kubernetesVersion, err := g.prompter.Ask(Question{
+		Key:     "kubernetes-version",
+		Message: "Kubernetes version",
+		Default: string(v1alpha1.GetClusterDefaultKubernetesVersion()),
+		Options: []string{string(v1alpha1.Kube121), string(v1alpha1.Kube122), string(v1alpha1.Kube123), string(v1alpha1.Kube124)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint string
+	if provider != constants.DockerProviderName {
+		endpoint, err = g.prompter.Ask(Question{
+			Key:     "control-plane-endpoint",
+			Message: "Control plane endpoint IP",
+			Validate: func(ip string) error {
+				return networkutils.ValidateIP(ip)
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var config *generatedConfig
+	switch provider {
+	case constants.DockerProviderName:
+		config, err = g.docker(clusterName)
+	case constants.VSphereProviderName:
+		config, err = g.vSphere(ctx, clusterName)
+	case constants.CloudStackProviderName:
+		config, err = g.cloudStack(ctx, clusterName)
+	case constants.TinkerbellProviderName:
+		config, err = g.tinkerbell(clusterName, endpoint)
+	case constants.SnowProviderName:
+		config, err = g.snow(clusterName)
+	default:
+		return nil, fmt.Errorf("generating a cluster config is not supported for provider %s", provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := v1alpha1.NewClusterGenerate(clusterName, config.clusterOpts...)
+	cluster.Spec.KubernetesVersion = v1alpha1.KubernetesVersion(kubernetesVersion)
+	if endpoint != "" {
+		cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: endpoint}
+	}
+
+	return marshal(cluster, config.objects...)
+}
+
+// generatedConfig holds the provider specific parts of a cluster config.
+type generatedConfig struct {
+	clusterOpts []v1alpha1.ClusterGenerateOpt
+	objects     []interface{}
+}
+
+func (c *generatedConfig) add(opts []v1alpha1.ClusterGenerateOpt, objects ...interface{}) {
+	c.clusterOpts = append(c.clusterOpts, opts...)
+	c.objects = append(c.objects, objects...)
+}
+
+func marshal(cluster *v1alpha1.ClusterGenerate, objects ...interface{}) ([]byte, error) {
+	clusterYaml, err := yaml.Marshal(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("generating cluster yaml: %v", err)
+	}
+
+	clusterYaml, err = api.CleanupPathsFromYaml(clusterYaml, removeFromDefaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cleaning up paths from yaml: %v", err)
+	}
+
+	resources := [][]byte{clusterYaml}
+	for _, object := range objects {
+		objectYaml, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, fmt.Errorf("generating cluster yaml: %v", err)
+		}
+		resources = append(resources, objectYaml)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+// nodeCounts asks for the number of nodes of the cluster. External etcd is only asked for when
+// withEtcd is true and it's reported back so callers only add the etcd machine config when needed.
+func (g *Generator) nodeCounts(controlPlaneDefault, etcdDefault, workerDefault int, withEtcd bool) (opts []v1alpha1.ClusterGenerateOpt, externalEtcd bool, err error) {
+	controlPlaneCount, err := g.askCount("control-plane-count", "Control plane node count", controlPlaneDefault, 1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	opts = []v1alpha1.ClusterGenerateOpt{v1alpha1.ControlPlaneConfigCount(controlPlaneCount)}
+
+	if withEtcd {
+		etcd, err := g.prompter.Ask(Question{
+			Key:     "etcd-count",
+			Message: "External etcd node count (0 for stacked etcd)",
+			Default: strconv.Itoa(etcdDefault),
+			Validate: func(s string) error {
+				if !contains([]string{"0", "1", "3", "5"}, s) {
+					return errors.New("must be 0, 1, 3 or 5")
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		if etcdCount, _ := strconv.Atoi(etcd); etcdCount > 0 {
+			opts = append(opts, v1alpha1.ExternalETCDConfigCount(etcdCount))
+			externalEtcd = true
+		}
+	}
+
+	workerCount, err := g.askCount("worker-count", "Worker node count", workerDefault, 1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	opts = append(opts,
+		v1alpha1.WorkerNodeConfigCount(workerCount),
+		v1alpha1.WorkerNodeConfigName(constants.DefaultWorkerNodeGroupName),
+	)
+
+	return opts, externalEtcd, nil
+}
+
+func (g *Generator) askCount(key, message string, defaultCount, min int) (int, error) {
+	return g.askBoundedCount(key, message, defaultCount, min, -1)
+}
+
+// askBoundedCount asks for a number between min and max. A negative max means there is no upper bound.
+func (g *Generator) askBoundedCount(key, message string, defaultCount, min, max int) (int, error) {
+	answer, err := g.prompter.Ask(Question{
+		Key:     key,
+		Message: message,
+		Default: strconv.Itoa(defaultCount),
+		Validate: func(s string) error {
+			count, err := strconv.Atoi(s)
+			if err != nil {
+				return errors.New("must be a number")
+			}
+			if count < min {
+				return fmt.Errorf("must be at least %d", min)
+			}
+			if max >= 0 && count > max {
+				return fmt.Errorf("must be at most %d", max)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(answer)
+}
+
+// askFromList asks to pick one of the values returned by list, failing if there are none.
+func (g *Generator) askFromList(q Question, kind string, list func() ([]string, error)) (string, error) {
+	options, err := list()
+	if err != nil {
+		return "", fmt.Errorf("discovering %s: %v", kind, err)
+	}
+	if len(options) == 0 && !q.Optional {
+		return "", fmt.Errorf("no %s found", kind)
+	}
+
+	q.Options = options
+	if len(options) == 1 && q.Default == "" {
+		q.Default = options[0]
+	}
+
+	return g.prompter.Ask(q)
+}
+
+func (g *Generator) askOSFamily() (v1alpha1.OSFamily, error) {
+	osFamily, err := g.prompter.Ask(Question{
+		Key:     "os-family",
+		Message: "OS family",
+		Default: string(v1alpha1.Bottlerocket),
+		Options: []string{string(v1alpha1.Bottlerocket), string(v1alpha1.Ubuntu)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return v1alpha1.OSFamily(osFamily), nil
+}
+
+// askUsers asks for the ssh key of the default user. When empty, a key is generated during cluster creation.
+func (g *Generator) askUsers(name string) ([]v1alpha1.UserConfiguration, error) {
+	key, err := g.prompter.Ask(Question{
+		Key:      "ssh-authorized-key",
+		Message:  "SSH authorized key (leave empty to generate one)",
+		Optional: true,
+		Validate: func(key string) error {
+			if !strings.HasPrefix(key, "ssh-") && !strings.HasPrefix(key, "ecdsa-") {
+				return errors.New("must be a public key in authorized_keys format")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []v1alpha1.UserConfiguration{{Name: name, SshAuthorizedKeys: []string{key}}}, nil
+}
+
+func defaultUser(osFamily v1alpha1.OSFamily, bottlerocketUser, ubuntuUser string) string {
+	if osFamily == v1alpha1.Ubuntu {
+		return ubuntuUser
+	}
+	return bottlerocketUser
+}
+
+func (g *Generator) docker(clusterName string) (*generatedConfig, error) {
+	opts, _, err := g.nodeCounts(1, 1, 1, true)
+	if err != nil {
+		return nil, err
+	}
+
+	datacenterConfig := v1alpha1.NewDockerDatacenterConfigGenerate(clusterName)
+	config := &generatedConfig{}
+	config.add(append(opts, v1alpha1.WithDatacenterRef(datacenterConfig)), datacenterConfig)
+
+	return config, nil
+}
+
+func (g *Generator) vSphere(ctx context.Context, clusterName string) (*generatedConfig, error) {
+	if g.vsphere == nil {
+		return nil, errors.New("vsphere client is not configured")
+	}
+
+	datacenterConfig := v1alpha1.NewVSphereDatacenterConfigGenerate(clusterName)
+	spec := &datacenterConfig.Spec
+
+	var err error
+	if spec.Server, err = g.prompter.Ask(Question{
+		Key:     "vsphere-server",
+		Message: "vCenter server",
+		Default: os.Getenv(vSphereServerKey),
+	}); err != nil {
+		return nil, err
+	}
+
+	insecure, err := g.prompter.Ask(Question{
+		Key:     "vsphere-insecure",
+		Message: "Skip vCenter certificate verification",
+		Default: "false",
+		Options: []string{"true", "false"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	spec.Insecure = insecure == "true"
+
+	if !spec.Insecure {
+		if spec.Thumbprint, err = g.prompter.Ask(Question{
+			Key:      "vsphere-thumbprint",
+			Message:  "vCenter TLS thumbprint, required for self-signed certificates",
+			Optional: true,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Discovery has to reach the server and trust its certificate the way the answers say, no matter
+	// the server configured in the environment.
+	if err = g.vsphere.ConfigureServer(spec.Server, spec.Insecure); err != nil {
+		return nil, fmt.Errorf("configuring vCenter server: %v", err)
+	}
+	if spec.Thumbprint != "" {
+		if err = g.vsphere.ConfigureCertThumbprint(ctx, spec.Server, spec.Thumbprint); err != nil {
+			return nil, fmt.Errorf("configuring vCenter certificate thumbprint: %v", err)
+		}
+	}
+
+	if spec.Datacenter, err = g.askFromList(Question{Key: "vsphere-datacenter", Message: "Datacenter"}, "datacenters", func() ([]string, error) {
+		return g.vsphere.ListDatacenters(ctx)
+	}); err != nil {
+		return nil, err
+	}
+
+	if spec.Network, err = g.askFromList(Question{Key: "vsphere-network", Message: "Network"}, "networks", func() ([]string, error) {
+		return g.vsphere.ListNetworks(ctx, spec.Datacenter)
+	}); err != nil {
+		return nil, err
+	}
+
+	machineSpec := v1alpha1.NewVSphereMachineConfigGenerate(clusterName).Spec
+	if machineSpec.Datastore, err = g.askFromList(Question{Key: "vsphere-datastore", Message: "Datastore"}, "datastores", func() ([]string, error) {
+		return g.vsphere.ListDatastores(ctx, spec.Datacenter)
+	}); err != nil {
+		return nil, err
+	}
+
+	if machineSpec.ResourcePool, err = g.askFromList(Question{Key: "vsphere-resource-pool", Message: "Resource pool"}, "resource pools", func() ([]string, error) {
+		return g.vsphere.ListResourcePools(ctx, spec.Datacenter)
+	}); err != nil {
+		return nil, err
+	}
+
+	if machineSpec.Folder, err = g.prompter.Ask(Question{
+		Key:      "vsphere-folder",
+		Message:  "VM folder",
+		Optional: true,
+	}); err != nil {
+		return nil, err
+	}
+
+	if machineSpec.OSFamily, err = g.askOSFamily(); err != nil {
+		return nil, err
+	}
+
+	if machineSpec.Template, err = g.askFromList(Question{
+		Key:      "vsphere-template",
+		Message:  "Template (leave empty to import the default one)",
+		Optional: true,
+	}, "templates", func() ([]string, error) {
+		return g.vsphere.ListTemplates(ctx, spec.Datacenter)
+	}); err != nil {
+		return nil, err
+	}
+
+	if machineSpec.Users, err = g.askUsers(defaultUser(machineSpec.OSFamily, "ec2-user", "capv")); err != nil {
+		return nil, err
+	}
+
+	opts, externalEtcd, err := g.nodeCounts(2, 3, 2, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// need to default control plane config name to something different from the cluster name based on assumption
+	// in controller code
+	cpMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
+	workerMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(clusterName)
+	etcdMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(providers.GetEtcdNodeName(clusterName))
+	cpMachineConfig.Spec, workerMachineConfig.Spec, etcdMachineConfig.Spec = machineSpec, machineSpec, machineSpec
+
+	opts = append(opts,
+		v1alpha1.WithDatacenterRef(datacenterConfig),
+		v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
+		v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
+		v1alpha1.WithEtcdMachineGroupRef(etcdMachineConfig),
+	)
+
+	config := &generatedConfig{}
+	config.add(opts, datacenterConfig, cpMachineConfig, workerMachineConfig)
+	if externalEtcd {
+		config.add(nil, etcdMachineConfig)
+	}
+
+	return config, nil
+}
+
+func (g *Generator) cloudStack(ctx context.Context, clusterName string) (*generatedConfig, error) {
+	if g.cloudstack == nil {
+		return nil, errors.New("cloudstack client is not configured")
+	}
+
+	datacenterConfig := v1alpha1.NewCloudStackDatacenterConfigGenerate(clusterName)
+	az := &datacenterConfig.Spec.AvailabilityZones[0]
+
+	var err error
+	if az.CredentialsRef, err = g.askFromList(Question{
+		Key:     "cloudstack-credentials-ref",
+		Message: "CloudStack credentials profile",
+		Default: defaultOption(g.cloudstackProfiles, decoder.CloudStackGlobalAZ),
+	}, "credential profiles", func() ([]string, error) {
+		return g.cloudstackProfiles, nil
+	}); err != nil {
+		return nil, err
+	}
+	profile := az.CredentialsRef
+
+	var domainId string
+	if az.Domain, err = g.prompter.Ask(Question{
+		Key:     "cloudstack-domain",
+		Message: "Domain",
+		Default: "ROOT",
+		Validate: func(domain string) error {
+			id, err := g.cloudstack.ValidateDomainAndGetId(ctx, profile, domain)
+			domainId = id
+			return err
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	if az.Account, err = g.prompter.Ask(Question{
+		Key:      "cloudstack-account",
+		Message:  "Account",
+		Optional: true,
+		Validate: func(account string) error {
+			return g.cloudstack.ValidateAccountPresent(ctx, profile, account, domainId)
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	if az.Zone.Name, err = g.askFromList(Question{Key: "cloudstack-zone", Message: "Zone"}, "zones", func() ([]string, error) {
+		return g.cloudstack.ListZones(ctx, profile)
+	}); err != nil {
+		return nil, err
+	}
+
+	zoneId, err := g.cloudstack.ValidateZoneAndGetId(ctx, profile, az.Zone)
+	if err != nil {
+		return nil, fmt.Errorf("validating zone %s: %v", az.Zone.Name, err)
+	}
+
+	if az.Zone.Network.Name, err = g.askFromList(Question{Key: "cloudstack-network", Message: "Network"}, "networks", func() ([]string, error) {
+		return g.cloudstack.ListNetworks(ctx, profile, zoneId)
+	}); err != nil {
+		return nil, err
+	}
+
+	if az.ManagementApiEndpoint, err = g.cloudstack.GetManagementApiEndpoint(profile); err != nil {
+		return nil, err
+	}
+
+	machineSpec := v1alpha1.NewCloudStackMachineConfigGenerate(clusterName).Spec
+	if machineSpec.ComputeOffering.Name, err = g.askFromList(Question{Key: "cloudstack-compute-offering", Message: "Compute offering"}, "service offerings", func() ([]string, error) {
+		return g.cloudstack.ListServiceOfferings(ctx, profile, zoneId)
+	}); err != nil {
+		return nil, err
+	}
+
+	if machineSpec.Template.Name, err = g.askFromList(Question{Key: "cloudstack-template", Message: "Template"}, "templates", func() ([]string, error) {
+		return g.cloudstack.ListTemplates(ctx, profile, zoneId)
+	}); err != nil {
+		return nil, err
+	}
+
+	if machineSpec.Users, err = g.askUsers("capc"); err != nil {
+		return nil, err
+	}
+
+	opts, externalEtcd, err := g.nodeCounts(2, 3, 2, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// need to default control plane config name to something different from the cluster name based on assumption
+	// in controller code
+	cpMachineConfig := v1alpha1.NewCloudStackMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
+	workerMachineConfig := v1alpha1.NewCloudStackMachineConfigGenerate(clusterName)
+	etcdMachineConfig := v1alpha1.NewCloudStackMachineConfigGenerate(providers.GetEtcdNodeName(clusterName))
+	cpMachineConfig.Spec, workerMachineConfig.Spec, etcdMachineConfig.Spec = machineSpec, machineSpec, machineSpec
+
+	opts = append(opts,
+		v1alpha1.WithDatacenterRef(datacenterConfig),
+		v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
+		v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
+		v1alpha1.WithEtcdMachineGroupRef(etcdMachineConfig),
+	)
+
+	config := &generatedConfig{}
+	config.add(opts, datacenterConfig, cpMachineConfig, workerMachineConfig)
+	if externalEtcd {
+		config.add(nil, etcdMachineConfig)
+	}
+
+	return config, nil
+}
+
+func (g *Generator) snow(clusterName string) (*generatedConfig, error) {
+	machineSpec := v1alpha1.NewSnowMachineConfigGenerate(clusterName).Spec
+
+	devices, err := g.prompter.Ask(Question{
+		Key:     "snow-devices",
+		Message: "Snow device IPs, comma separated",
+		Validate: func(devices string) error {
+			for _, device := range strings.Split(devices, ",") {
+				if err := networkutils.ValidateIP(strings.TrimSpace(device)); err != nil {
+					return fmt.Errorf("device %v", err)
+				}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range strings.Split(devices, ",") {
+		machineSpec.Devices = append(machineSpec.Devices, strings.TrimSpace(device))
+	}
+
+	if machineSpec.AMIID, err = g.prompter.Ask(Question{
+		Key:      "snow-ami-id",
+		Message:  "AMI ID (leave empty to use the default one)",
+		Optional: true,
+	}); err != nil {
+		return nil, err
+	}
+
+	instanceType, err := g.prompter.Ask(Question{
+		Key:     "snow-instance-type",
+		Message: "Instance type",
+		Default: string(v1alpha1.DefaultSnowInstanceType),
+		Options: []string{string(v1alpha1.SbeCLarge), string(v1alpha1.SbeCXLarge), string(v1alpha1.SbeC2XLarge), string(v1alpha1.SbeC4XLarge)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	machineSpec.InstanceType = v1alpha1.SnowInstanceType(instanceType)
+
+	connector, err := g.prompter.Ask(Question{
+		Key:     "snow-physical-network-connector",
+		Message: "Physical network connector",
+		Default: string(v1alpha1.DefaultSnowPhysicalNetworkConnectorType),
+		Options: []string{string(v1alpha1.SFPPlus), string(v1alpha1.QSFP)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	machineSpec.PhysicalNetworkConnector = v1alpha1.PhysicalNetworkConnectorType(connector)
+
+	if machineSpec.SshKeyName, err = g.prompter.Ask(Question{
+		Key:     "snow-ssh-key-name",
+		Message: "SSH key pair name",
+		Default: v1alpha1.DefaultSnowSshKeyName,
+	}); err != nil {
+		return nil, err
+	}
+
+	opts, _, err := g.nodeCounts(3, 0, 3, false)
+	if err != nil {
+		return nil, err
+	}
+
+	datacenterConfig := v1alpha1.NewSnowDatacenterConfigGenerate(clusterName)
+	cpMachineConfig := v1alpha1.NewSnowMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
+	workerMachineConfig := v1alpha1.NewSnowMachineConfigGenerate(clusterName)
+	cpMachineConfig.Spec, workerMachineConfig.Spec = machineSpec, machineSpec
+
+	opts = append(opts,
+		v1alpha1.WithDatacenterRef(datacenterConfig),
+		v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
+		v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
+	)
+
+	config := &generatedConfig{}
+	config.add(opts, datacenterConfig, cpMachineConfig, workerMachineConfig)
+
+	return config, nil
+}
+
+func defaultOption(options []string, preferred string) string {
+	if contains(options, preferred) {
+		return preferred
+	}
+
+	return ""
+}
@@ -0,0 +1,289 @@
+package clusterconfig_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterconfig"
+	"github.com/aws/eks-anywhere/pkg/clusterconfig/mocks"
+)
+
+type generatorTest struct {
+	*WithT
+	ctx        context.Context
+	env        map[string]string
+	vsphere    *mocks.MockVSphereClient
+	cloudstack *mocks.MockCloudStackClient
+}
+
+func newGeneratorTest(t *testing.T, env map[string]string) *generatorTest {
+	ctrl := gomock.NewController(t)
+	return &generatorTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		env:        env,
+		vsphere:    mocks.NewMockVSphereClient(ctrl),
+		cloudstack: mocks.NewMockCloudStackClient(ctrl),
+	}
+}
+
+func (tt *generatorTest) generator() *clusterconfig.Generator {
+	prompter := clusterconfig.NewEnvPrompter(clusterconfig.WithLookupEnv(func(key string) (string, bool) {
+		v, ok := tt.env[key]
+		return v, ok
+	}))
+
+	return clusterconfig.NewGenerator(prompter,
+		clusterconfig.WithVSphereClient(tt.vsphere),
+		clusterconfig.WithCloudStackClient(tt.cloudstack, []string{"global", "other"}),
+	)
+}
+
+func TestAskProvider(t *testing.T) {
+	g := NewWithT(t)
+	prompter := clusterconfig.NewEnvPrompter(clusterconfig.WithLookupEnv(func(key string) (string, bool) {
+		return "vsphere", key == "EKSA_GENERATE_PROVIDER"
+	}))
+
+	g.Expect(clusterconfig.AskProvider(prompter, []string{"docker", "vsphere"})).To(Equal("vsphere"))
+}
+
+func TestGenerateDocker(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_ETCD_COUNT": "0",
+	})
+
+	got, err := tt.generator().Generate(tt.ctx, "test-cluster", "docker")
+	tt.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(got), "testdata/expected_docker.yaml")
+}
+
+func TestGenerateVSphere(t *testing.T) {
+	t.Setenv("VSPHERE_SERVER", "vcenter.example.com")
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_KUBERNETES_VERSION":     "1.24",
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_VSPHERE_NETWORK":        "/SDDC/network/VM Network",
+		"EKSA_GENERATE_VSPHERE_THUMBPRINT":     "AB:CD",
+		"EKSA_GENERATE_VSPHERE_DATASTORE":      "/SDDC/datastore/ds2",
+		"EKSA_GENERATE_VSPHERE_TEMPLATE":       "/SDDC/vm/Templates/bottlerocket",
+		"EKSA_GENERATE_SSH_AUTHORIZED_KEY":     "ssh-rsa AAAAB3",
+		"EKSA_GENERATE_ETCD_COUNT":             "3",
+		"EKSA_GENERATE_WORKER_COUNT":           "3",
+	})
+
+	gomock.InOrder(
+		tt.vsphere.EXPECT().ConfigureServer("vcenter.example.com", false),
+		tt.vsphere.EXPECT().ConfigureCertThumbprint(tt.ctx, "vcenter.example.com", "AB:CD"),
+		tt.vsphere.EXPECT().ListDatacenters(tt.ctx).Return([]string{"SDDC"}, nil),
+	)
+	tt.vsphere.EXPECT().ListNetworks(tt.ctx, "SDDC").Return([]string{"/SDDC/network/VM Network", "/SDDC/network/Other"}, nil)
+	tt.vsphere.EXPECT().ListDatastores(tt.ctx, "SDDC").Return([]string{"/SDDC/datastore/ds1", "/SDDC/datastore/ds2"}, nil)
+	tt.vsphere.EXPECT().ListResourcePools(tt.ctx, "SDDC").Return([]string{"/SDDC/host/Cluster-1/Resources"}, nil)
+	tt.vsphere.EXPECT().ListTemplates(tt.ctx, "SDDC").Return([]string{"/SDDC/vm/Templates/bottlerocket"}, nil)
+
+	got, err := tt.generator().Generate(tt.ctx, "test-cluster", "vsphere")
+	tt.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(got), "testdata/expected_vsphere.yaml")
+}
+
+func TestGenerateVSphereInvalidNetwork(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_VSPHERE_SERVER":         "vcenter.example.com",
+		"EKSA_GENERATE_VSPHERE_NETWORK":        "/SDDC/network/Missing",
+	})
+
+	tt.vsphere.EXPECT().ConfigureServer("vcenter.example.com", false)
+	tt.vsphere.EXPECT().ListDatacenters(tt.ctx).Return([]string{"SDDC"}, nil)
+	tt.vsphere.EXPECT().ListNetworks(tt.ctx, "SDDC").Return([]string{"/SDDC/network/VM Network", "/SDDC/network/Other"}, nil)
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "vsphere")
+	tt.Expect(err).To(MatchError(ContainSubstring("EKSA_GENERATE_VSPHERE_NETWORK: /SDDC/network/Missing is not one of")))
+}
+
+func TestGenerateVSphereNoDatacenters(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_VSPHERE_SERVER":         "vcenter.example.com",
+	})
+
+	tt.vsphere.EXPECT().ConfigureServer("vcenter.example.com", false)
+	tt.vsphere.EXPECT().ListDatacenters(tt.ctx).Return(nil, nil)
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "vsphere")
+	tt.Expect(err).To(MatchError("no datacenters found"))
+}
+
+func TestGenerateVSphereListError(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_VSPHERE_SERVER":         "vcenter.example.com",
+	})
+
+	tt.vsphere.EXPECT().ConfigureServer("vcenter.example.com", false)
+	tt.vsphere.EXPECT().ListDatacenters(tt.ctx).Return(nil, errors.New("unauthorized"))
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "vsphere")
+	tt.Expect(err).To(MatchError("discovering datacenters: unauthorized"))
+}
+
+func TestGenerateVSphereServerFromAnswers(t *testing.T) {
+	t.Setenv("VSPHERE_SERVER", "env.example.com")
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_VSPHERE_SERVER":         "vcenter.example.com",
+		"EKSA_GENERATE_VSPHERE_INSECURE":       "true",
+	})
+
+	gomock.InOrder(
+		tt.vsphere.EXPECT().ConfigureServer("vcenter.example.com", true),
+		tt.vsphere.EXPECT().ListDatacenters(tt.ctx).Return(nil, errors.New("unauthorized")),
+	)
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "vsphere")
+	tt.Expect(err).To(MatchError("discovering datacenters: unauthorized"))
+}
+
+func TestGenerateVSphereConfigureServerError(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_VSPHERE_SERVER":         "vcenter.example.com",
+	})
+
+	tt.vsphere.EXPECT().ConfigureServer("vcenter.example.com", false).Return(errors.New("setenv failed"))
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "vsphere")
+	tt.Expect(err).To(MatchError("configuring vCenter server: setenv failed"))
+}
+
+func TestGenerateInvalidEndpoint(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "not-an-ip",
+	})
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "vsphere")
+	tt.Expect(err).To(MatchError(ContainSubstring("EKSA_GENERATE_CONTROL_PLANE_ENDPOINT: is invalid")))
+}
+
+func TestGenerateCloudStack(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT":      "10.0.0.10",
+		"EKSA_GENERATE_CLOUDSTACK_DOMAIN":           "domain1",
+		"EKSA_GENERATE_CLOUDSTACK_ACCOUNT":          "admin",
+		"EKSA_GENERATE_CLOUDSTACK_COMPUTE_OFFERING": "Large Instance",
+		"EKSA_GENERATE_SSH_AUTHORIZED_KEY":          "ssh-rsa AAAAB3",
+		"EKSA_GENERATE_ETCD_COUNT":                  "0",
+	})
+	zone := v1alpha1.CloudStackZone{Name: "zone1"}
+
+	tt.cloudstack.EXPECT().ValidateDomainAndGetId(tt.ctx, "global", "domain1").Return("domain-id", nil)
+	tt.cloudstack.EXPECT().ValidateAccountPresent(tt.ctx, "global", "admin", "domain-id").Return(nil)
+	tt.cloudstack.EXPECT().ListZones(tt.ctx, "global").Return([]string{"zone1"}, nil)
+	tt.cloudstack.EXPECT().ValidateZoneAndGetId(tt.ctx, "global", zone).Return("zone-id", nil)
+	tt.cloudstack.EXPECT().ListNetworks(tt.ctx, "global", "zone-id").Return([]string{"net1"}, nil)
+	tt.cloudstack.EXPECT().GetManagementApiEndpoint("global").Return("http://10.0.0.1:8080/client/api", nil)
+	tt.cloudstack.EXPECT().ListServiceOfferings(tt.ctx, "global", "zone-id").Return([]string{"Small Instance", "Large Instance"}, nil)
+	tt.cloudstack.EXPECT().ListTemplates(tt.ctx, "global", "zone-id").Return([]string{"rhel8-kube-v1.23"}, nil)
+
+	got, err := tt.generator().Generate(tt.ctx, "test-cluster", "cloudstack")
+	tt.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(got), "testdata/expected_cloudstack.yaml")
+}
+
+func TestGenerateCloudStackInvalidDomain(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_CLOUDSTACK_DOMAIN":      "missing",
+	})
+
+	tt.cloudstack.EXPECT().ValidateDomainAndGetId(tt.ctx, "global", "missing").Return("", errors.New("domain not found"))
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "cloudstack")
+	tt.Expect(err).To(MatchError("invalid value for EKSA_GENERATE_CLOUDSTACK_DOMAIN: domain not found"))
+}
+
+func TestGenerateTinkerbell(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT":  "10.10.10.100",
+		"EKSA_GENERATE_TINKERBELL_HARDWARE_CSV": "testdata/hardware.csv",
+		"EKSA_GENERATE_TINKERBELL_IP":           "10.10.10.101",
+		"EKSA_GENERATE_CONTROL_PLANE_COUNT":     "3",
+		"EKSA_GENERATE_WORKER_COUNT":            "2",
+		"EKSA_GENERATE_OS_FAMILY":               "ubuntu",
+	})
+
+	got, err := tt.generator().Generate(tt.ctx, "test-cluster", "tinkerbell")
+	tt.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(got), "testdata/expected_tinkerbell.yaml")
+}
+
+func TestGenerateTinkerbellErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name: "missing csv",
+			env: map[string]string{
+				"EKSA_GENERATE_TINKERBELL_HARDWARE_CSV": "testdata/missing.csv",
+			},
+			wantErr: "invalid value for EKSA_GENERATE_TINKERBELL_HARDWARE_CSV",
+		},
+		{
+			name: "tinkerbell ip same as endpoint",
+			env: map[string]string{
+				"EKSA_GENERATE_TINKERBELL_HARDWARE_CSV": "testdata/hardware.csv",
+				"EKSA_GENERATE_TINKERBELL_IP":           "10.10.10.100",
+			},
+			wantErr: "invalid value for EKSA_GENERATE_TINKERBELL_IP: must be different from the control plane endpoint",
+		},
+		{
+			name: "not enough hardware",
+			env: map[string]string{
+				"EKSA_GENERATE_TINKERBELL_HARDWARE_CSV": "testdata/hardware.csv",
+				"EKSA_GENERATE_TINKERBELL_IP":           "10.10.10.101",
+				"EKSA_GENERATE_CONTROL_PLANE_COUNT":     "5",
+			},
+			wantErr: "invalid value for EKSA_GENERATE_CONTROL_PLANE_COUNT: must be at most 3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.env["EKSA_GENERATE_CONTROL_PLANE_ENDPOINT"] = "10.10.10.100"
+			tt := newGeneratorTest(t, tc.env)
+
+			_, err := tt.generator().Generate(tt.ctx, "test-cluster", "tinkerbell")
+			tt.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
+
+func TestGenerateSnow(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+		"EKSA_GENERATE_SNOW_DEVICES":           "10.0.0.1, 10.0.0.2",
+		"EKSA_GENERATE_SNOW_INSTANCE_TYPE":     "sbe-c.xlarge",
+	})
+
+	got, err := tt.generator().Generate(tt.ctx, "test-cluster", "snow")
+	tt.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(got), "testdata/expected_snow.yaml")
+}
+
+func TestGenerateUnsupportedProvider(t *testing.T) {
+	tt := newGeneratorTest(t, map[string]string{
+		"EKSA_GENERATE_CONTROL_PLANE_ENDPOINT": "10.0.0.10",
+	})
+
+	_, err := tt.generator().Generate(tt.ctx, "test-cluster", "nutanix")
+	tt.Expect(err).To(MatchError("generating a cluster config is not supported for provider nutanix"))
+}
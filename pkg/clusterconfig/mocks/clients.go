@@ -0,0 +1,281 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/clusterconfig (interfaces: VSphereClient,CloudStackClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVSphereClient is a mock of VSphereClient interface.
+type MockVSphereClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockVSphereClientMockRecorder
+}
+
+// MockVSphereClientMockRecorder is the mock recorder for MockVSphereClient.
+type MockVSphereClientMockRecorder struct {
+	mock *MockVSphereClient
+}
+
+// NewMockVSphereClient creates a new mock instance.
+func NewMockVSphereClient(ctrl *gomock.Controller) *MockVSphereClient {
+	mock := &MockVSphereClient{ctrl: ctrl}
+	mock.recorder = &MockVSphereClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVSphereClient) EXPECT() *MockVSphereClientMockRecorder {
+	return m.recorder
+}
+
+// ConfigureCertThumbprint mocks base method.
+func (m *MockVSphereClient) ConfigureCertThumbprint(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureCertThumbprint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureCertThumbprint indicates an expected call of ConfigureCertThumbprint.
+func (mr *MockVSphereClientMockRecorder) ConfigureCertThumbprint(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureCertThumbprint", reflect.TypeOf((*MockVSphereClient)(nil).ConfigureCertThumbprint), arg0, arg1, arg2)
+}
+
+// ConfigureServer mocks base method.
+func (m *MockVSphereClient) ConfigureServer(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureServer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureServer indicates an expected call of ConfigureServer.
+func (mr *MockVSphereClientMockRecorder) ConfigureServer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureServer", reflect.TypeOf((*MockVSphereClient)(nil).ConfigureServer), arg0, arg1)
+}
+
+// ListDatacenters mocks base method.
+func (m *MockVSphereClient) ListDatacenters(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatacenters", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatacenters indicates an expected call of ListDatacenters.
+func (mr *MockVSphereClientMockRecorder) ListDatacenters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatacenters", reflect.TypeOf((*MockVSphereClient)(nil).ListDatacenters), arg0)
+}
+
+// ListDatastores mocks base method.
+func (m *MockVSphereClient) ListDatastores(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatastores", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatastores indicates an expected call of ListDatastores.
+func (mr *MockVSphereClientMockRecorder) ListDatastores(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatastores", reflect.TypeOf((*MockVSphereClient)(nil).ListDatastores), arg0, arg1)
+}
+
+// ListNetworks mocks base method.
+func (m *MockVSphereClient) ListNetworks(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworks", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworks indicates an expected call of ListNetworks.
+func (mr *MockVSphereClientMockRecorder) ListNetworks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworks", reflect.TypeOf((*MockVSphereClient)(nil).ListNetworks), arg0, arg1)
+}
+
+// ListResourcePools mocks base method.
+func (m *MockVSphereClient) ListResourcePools(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcePools", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcePools indicates an expected call of ListResourcePools.
+func (mr *MockVSphereClientMockRecorder) ListResourcePools(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcePools", reflect.TypeOf((*MockVSphereClient)(nil).ListResourcePools), arg0, arg1)
+}
+
+// ListTemplates mocks base method.
+func (m *MockVSphereClient) ListTemplates(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockVSphereClientMockRecorder) ListTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockVSphereClient)(nil).ListTemplates), arg0, arg1)
+}
+
+// MockCloudStackClient is a mock of CloudStackClient interface.
+type MockCloudStackClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudStackClientMockRecorder
+}
+
+// MockCloudStackClientMockRecorder is the mock recorder for MockCloudStackClient.
+type MockCloudStackClientMockRecorder struct {
+	mock *MockCloudStackClient
+}
+
+// NewMockCloudStackClient creates a new mock instance.
+func NewMockCloudStackClient(ctrl *gomock.Controller) *MockCloudStackClient {
+	mock := &MockCloudStackClient{ctrl: ctrl}
+	mock.recorder = &MockCloudStackClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudStackClient) EXPECT() *MockCloudStackClientMockRecorder {
+	return m.recorder
+}
+
+// GetManagementApiEndpoint mocks base method.
+func (m *MockCloudStackClient) GetManagementApiEndpoint(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManagementApiEndpoint", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManagementApiEndpoint indicates an expected call of GetManagementApiEndpoint.
+func (mr *MockCloudStackClientMockRecorder) GetManagementApiEndpoint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagementApiEndpoint", reflect.TypeOf((*MockCloudStackClient)(nil).GetManagementApiEndpoint), arg0)
+}
+
+// ListNetworks mocks base method.
+func (m *MockCloudStackClient) ListNetworks(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworks indicates an expected call of ListNetworks.
+func (mr *MockCloudStackClientMockRecorder) ListNetworks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworks", reflect.TypeOf((*MockCloudStackClient)(nil).ListNetworks), arg0, arg1, arg2)
+}
+
+// ListServiceOfferings mocks base method.
+func (m *MockCloudStackClient) ListServiceOfferings(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceOfferings", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceOfferings indicates an expected call of ListServiceOfferings.
+func (mr *MockCloudStackClientMockRecorder) ListServiceOfferings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceOfferings", reflect.TypeOf((*MockCloudStackClient)(nil).ListServiceOfferings), arg0, arg1, arg2)
+}
+
+// ListTemplates mocks base method.
+func (m *MockCloudStackClient) ListTemplates(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockCloudStackClientMockRecorder) ListTemplates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockCloudStackClient)(nil).ListTemplates), arg0, arg1, arg2)
+}
+
+// ListZones mocks base method.
+func (m *MockCloudStackClient) ListZones(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZones", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZones indicates an expected call of ListZones.
+func (mr *MockCloudStackClientMockRecorder) ListZones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZones", reflect.TypeOf((*MockCloudStackClient)(nil).ListZones), arg0, arg1)
+}
+
+// ValidateAccountPresent mocks base method.
+func (m *MockCloudStackClient) ValidateAccountPresent(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAccountPresent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateAccountPresent indicates an expected call of ValidateAccountPresent.
+func (mr *MockCloudStackClientMockRecorder) ValidateAccountPresent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccountPresent", reflect.TypeOf((*MockCloudStackClient)(nil).ValidateAccountPresent), arg0, arg1, arg2, arg3)
+}
+
+// ValidateDomainAndGetId mocks base method.
+func (m *MockCloudStackClient) ValidateDomainAndGetId(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDomainAndGetId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDomainAndGetId indicates an expected call of ValidateDomainAndGetId.
+func (mr *MockCloudStackClientMockRecorder) ValidateDomainAndGetId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDomainAndGetId", reflect.TypeOf((*MockCloudStackClient)(nil).ValidateDomainAndGetId), arg0, arg1, arg2)
+}
+
+// ValidateZoneAndGetId mocks base method.
+func (m *MockCloudStackClient) ValidateZoneAndGetId(arg0 context.Context, arg1 string, arg2 v1alpha1.CloudStackZone) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateZoneAndGetId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateZoneAndGetId indicates an expected call of ValidateZoneAndGetId.
+func (mr *MockCloudStackClientMockRecorder) ValidateZoneAndGetId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateZoneAndGetId", reflect.TypeOf((*MockCloudStackClient)(nil).ValidateZoneAndGetId), arg0, arg1, arg2)
+}
@@ -0,0 +1,177 @@
+package clusterconfig
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix of the environment variables read by the env Prompter.
+const EnvPrefix = "EKSA_GENERATE_"
+
+// Question is a single value asked while generating a cluster config.
+type Question struct {
+	// Key uniquely identifies the question. It's also used to build the environment variable
+	// the env Prompter reads the answer from.
+	Key string
+	// Message is shown to the user when asking interactively.
+	Message string
+	// Default is used when the answer is empty.
+	Default string
+	// Options restricts the valid answers. When set, interactive answers can also be the option number.
+	Options []string
+	// Optional allows empty answers when there is no Default.
+	Optional bool
+	// Validate is run against the answer, after applying the Default.
+	Validate func(string) error
+}
+
+// EnvVar returns the environment variable the answer to q is read from in non interactive mode.
+func (q Question) EnvVar() string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(q.Key))
+}
+
+// resolve applies the Default to answer and checks it's valid.
+func (q Question) resolve(answer string) (string, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		answer = q.Default
+	}
+
+	if answer == "" {
+		if q.Optional {
+			return "", nil
+		}
+		return "", errors.New("a value is required")
+	}
+
+	if len(q.Options) > 0 && !contains(q.Options, answer) {
+		return "", fmt.Errorf("%s is not one of: %s", answer, strings.Join(q.Options, ", "))
+	}
+
+	if q.Validate != nil {
+		if err := q.Validate(answer); err != nil {
+			return "", err
+		}
+	}
+
+	return answer, nil
+}
+
+// Prompter gets answers for Questions.
+type Prompter interface {
+	Ask(q Question) (string, error)
+}
+
+// TerminalPrompter asks Questions interactively, writing them to out and reading answers from in.
+// Invalid answers are reported and the Question is asked again.
+type TerminalPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewTerminalPrompter builds a TerminalPrompter.
+func NewTerminalPrompter(in io.Reader, out io.Writer) *TerminalPrompter {
+	return &TerminalPrompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Ask implements Prompter.
+func (p *TerminalPrompter) Ask(q Question) (string, error) {
+	for {
+		p.printQuestion(q)
+
+		line, err := p.in.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			return "", fmt.Errorf("reading answer for %s: %v", q.Key, err)
+		}
+
+		answer, err := q.resolve(p.optionFromNumber(q, line))
+		if err == nil {
+			return answer, nil
+		}
+
+		fmt.Fprintf(p.out, "Invalid value: %v\n", err)
+	}
+}
+
+func (p *TerminalPrompter) printQuestion(q Question) {
+	for i, option := range q.Options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, option)
+	}
+
+	fmt.Fprint(p.out, q.Message)
+	switch {
+	case q.Default != "":
+		fmt.Fprintf(p.out, " [%s]", q.Default)
+	case q.Optional:
+		fmt.Fprint(p.out, " (optional)")
+	}
+	fmt.Fprint(p.out, ": ")
+}
+
+// optionFromNumber translates an answer that is an option number into the option value.
+func (p *TerminalPrompter) optionFromNumber(q Question, answer string) string {
+	answer = strings.TrimSpace(answer)
+	if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(q.Options) && !contains(q.Options, answer) {
+		return q.Options[i-1]
+	}
+
+	return answer
+}
+
+// EnvPrompter answers Questions from environment variables, see Question.EnvVar.
+// It never retries, an invalid or missing value is an error.
+type EnvPrompter struct {
+	lookupEnv func(string) (string, bool)
+}
+
+// EnvPrompterOpt allows to customize an EnvPrompter.
+type EnvPrompterOpt func(*EnvPrompter)
+
+// WithLookupEnv sets the func used to read environment variables. It defaults to os.LookupEnv.
+func WithLookupEnv(lookupEnv func(string) (string, bool)) EnvPrompterOpt {
+	return func(p *EnvPrompter) {
+		p.lookupEnv = lookupEnv
+	}
+}
+
+// NewEnvPrompter builds an EnvPrompter.
+func NewEnvPrompter(opts ...EnvPrompterOpt) *EnvPrompter {
+	p := &EnvPrompter{
+		lookupEnv: os.LookupEnv,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Ask implements Prompter.
+func (p *EnvPrompter) Ask(q Question) (string, error) {
+	value, _ := p.lookupEnv(q.EnvVar())
+	answer, err := q.resolve(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid value for %s: %v", q.EnvVar(), err)
+	}
+
+	return answer, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package clusterconfig_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/clusterconfig"
+)
+
+func TestQuestionEnvVar(t *testing.T) {
+	g := NewWithT(t)
+	q := clusterconfig.Question{Key: "vsphere-datacenter"}
+	g.Expect(q.EnvVar()).To(Equal("EKSA_GENERATE_VSPHERE_DATACENTER"))
+}
+
+func TestTerminalPrompterAsk(t *testing.T) {
+	tests := []struct {
+		name     string
+		question clusterconfig.Question
+		input    string
+		want     string
+	}{
+		{
+			name:     "value",
+			question: clusterconfig.Question{Key: "name", Message: "Name"},
+			input:    "my-cluster\n",
+			want:     "my-cluster",
+		},
+		{
+			name:     "default",
+			question: clusterconfig.Question{Key: "name", Message: "Name", Default: "default"},
+			input:    "\n",
+			want:     "default",
+		},
+		{
+			name:     "optional",
+			question: clusterconfig.Question{Key: "name", Message: "Name", Optional: true},
+			input:    "\n",
+			want:     "",
+		},
+		{
+			name:     "option number",
+			question: clusterconfig.Question{Key: "dc", Message: "Datacenter", Options: []string{"dc1", "dc2"}},
+			input:    "2\n",
+			want:     "dc2",
+		},
+		{
+			name:     "option value",
+			question: clusterconfig.Question{Key: "dc", Message: "Datacenter", Options: []string{"dc1", "dc2"}},
+			input:    "dc1\n",
+			want:     "dc1",
+		},
+		{
+			name:     "retry invalid option",
+			question: clusterconfig.Question{Key: "dc", Message: "Datacenter", Options: []string{"dc1", "dc2"}},
+			input:    "dc3\n3\ndc2\n",
+			want:     "dc2",
+		},
+		{
+			name: "retry failed validation",
+			question: clusterconfig.Question{
+				Key:     "name",
+				Message: "Name",
+				Validate: func(s string) error {
+					if s != "valid" {
+						return errors.New("invalid")
+					}
+					return nil
+				},
+			},
+			input: "\nother\nvalid",
+			want:  "valid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			out := &bytes.Buffer{}
+			p := clusterconfig.NewTerminalPrompter(strings.NewReader(tt.input), out)
+
+			g.Expect(p.Ask(tt.question)).To(Equal(tt.want))
+			g.Expect(out.String()).To(ContainSubstring(tt.question.Message))
+		})
+	}
+}
+
+func TestTerminalPrompterAskPrintsOptions(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	p := clusterconfig.NewTerminalPrompter(strings.NewReader("\n"), out)
+
+	_, err := p.Ask(clusterconfig.Question{Key: "dc", Message: "Datacenter", Default: "dc1", Options: []string{"dc1", "dc2"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.String()).To(Equal("  1) dc1\n  2) dc2\nDatacenter [dc1]: "))
+}
+
+func TestTerminalPrompterAskEOF(t *testing.T) {
+	g := NewWithT(t)
+	p := clusterconfig.NewTerminalPrompter(strings.NewReader("\n"), &bytes.Buffer{})
+
+	_, err := p.Ask(clusterconfig.Question{Key: "name", Message: "Name"})
+	g.Expect(err).To(MatchError(ContainSubstring("reading answer for name")))
+}
+
+func TestEnvPrompterAsk(t *testing.T) {
+	g := NewWithT(t)
+	env := map[string]string{"EKSA_GENERATE_DC": "dc2"}
+	p := clusterconfig.NewEnvPrompter(clusterconfig.WithLookupEnv(func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}))
+
+	g.Expect(p.Ask(clusterconfig.Question{Key: "dc", Options: []string{"dc1", "dc2"}})).To(Equal("dc2"))
+	g.Expect(p.Ask(clusterconfig.Question{Key: "name", Default: "default"})).To(Equal("default"))
+
+	_, err := p.Ask(clusterconfig.Question{Key: "name"})
+	g.Expect(err).To(MatchError("invalid value for EKSA_GENERATE_NAME: a value is required"))
+
+	_, err = p.Ask(clusterconfig.Question{Key: "dc", Options: []string{"dc1"}})
+	g.Expect(err).To(MatchError("invalid value for EKSA_GENERATE_DC: dc2 is not one of: dc1"))
+}
@@ -0,0 +1,200 @@
+package clusterconfig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func (g *Generator) tinkerbell(clusterName, endpoint string) (*generatedConfig, error) {
+	var machines []hardware.Machine
+	if _, err := g.prompter.Ask(Question{
+		Key:     "tinkerbell-hardware-csv",
+		Message: "Hardware CSV file",
+		Default: "hardware.csv",
+		Validate: func(path string) (err error) {
+			machines, err = readHardwareCSV(path)
+			return err
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	datacenterConfig := v1alpha1.NewTinkerbellDatacenterConfigGenerate(clusterName)
+	var err error
+	if datacenterConfig.Spec.TinkerbellIP, err = g.prompter.Ask(Question{
+		Key:     "tinkerbell-ip",
+		Message: "Tinkerbell IP",
+		Validate: func(ip string) error {
+			if err := networkutils.ValidateIP(ip); err != nil {
+				return err
+			}
+			if ip == endpoint {
+				return errors.New("must be different from the control plane endpoint")
+			}
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	labels := hardwareLabels(machines)
+	if len(labels) == 0 {
+		return nil, errors.New("no labels found in hardware csv, labels are required to select the hardware for each node group")
+	}
+
+	cpSelector, cpCount, err := g.askHardwareSelection("control-plane", "Control plane", "type=cp", labels, machines)
+	if err != nil {
+		return nil, err
+	}
+
+	workerLabels := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label != cpSelector {
+			workerLabels = append(workerLabels, label)
+		}
+	}
+	if len(workerLabels) == 0 {
+		return nil, errors.New("no labels left in hardware csv to select worker hardware")
+	}
+
+	workerSelector, workerCount, err := g.askHardwareSelection("worker", "Worker", "type=worker", workerLabels, machines)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both labels could be set on the same hardware, which can only be used by one node.
+	available := 0
+	for _, machine := range machines {
+		if hardware.LabelsMatchSelector(selectorFromLabel(cpSelector), machine.Labels) ||
+			hardware.LabelsMatchSelector(selectorFromLabel(workerSelector), machine.Labels) {
+			available++
+		}
+	}
+	if cpCount+workerCount > available {
+		return nil, fmt.Errorf("%d control plane and %d worker nodes require %d machines but only %d match labels %s and %s",
+			cpCount, workerCount, cpCount+workerCount, available, cpSelector, workerSelector)
+	}
+
+	osFamily, err := g.askOSFamily()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := g.askUsers("ec2-user")
+	if err != nil {
+		return nil, err
+	}
+
+	cpMachineConfig := v1alpha1.NewTinkerbellMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
+	workerMachineConfig := v1alpha1.NewTinkerbellMachineConfigGenerate(clusterName)
+	for machineConfig, selector := range map[*v1alpha1.TinkerbellMachineConfigGenerate]string{
+		cpMachineConfig:     cpSelector,
+		workerMachineConfig: workerSelector,
+	} {
+		machineConfig.Spec.HardwareSelector = selectorFromLabel(selector)
+		machineConfig.Spec.OSFamily = osFamily
+		machineConfig.Spec.Users = users
+	}
+
+	config := &generatedConfig{}
+	config.add([]v1alpha1.ClusterGenerateOpt{
+		v1alpha1.ControlPlaneConfigCount(cpCount),
+		v1alpha1.WorkerNodeConfigCount(workerCount),
+		v1alpha1.WorkerNodeConfigName(constants.DefaultWorkerNodeGroupName),
+		v1alpha1.WithDatacenterRef(datacenterConfig),
+		v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
+		v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
+	}, datacenterConfig, cpMachineConfig, workerMachineConfig)
+
+	return config, nil
+}
+
+// askHardwareSelection asks for the label selecting the hardware of a node group and its node count,
+// which can't exceed the amount of hardware matching the label.
+func (g *Generator) askHardwareSelection(key, name, defaultLabel string, labels []string, machines []hardware.Machine) (selector string, count int, err error) {
+	if selector, err = g.prompter.Ask(Question{
+		Key:     key + "-hardware-selector",
+		Message: fmt.Sprintf("%s hardware label", name),
+		Default: defaultOption(labels, defaultLabel),
+		Options: labels,
+	}); err != nil {
+		return "", 0, err
+	}
+
+	available := 0
+	for _, machine := range machines {
+		if hardware.LabelsMatchSelector(selectorFromLabel(selector), machine.Labels) {
+			available++
+		}
+	}
+
+	count, err = g.askBoundedCount(key+"-count", fmt.Sprintf("%s node count (%d available)", name, available), 1, 1, available)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return selector, count, nil
+}
+
+func readHardwareCSV(path string) ([]hardware.Machine, error) {
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := hardware.NewDefaultMachineValidator()
+	var machines []hardware.Machine
+	for {
+		machine, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validator.Validate(machine); err != nil {
+			return nil, fmt.Errorf("invalid hardware %s: %v", machine.Hostname, err)
+		}
+
+		machines = append(machines, machine)
+	}
+
+	if len(machines) == 0 {
+		return nil, errors.New("no hardware found")
+	}
+
+	return machines, nil
+}
+
+// hardwareLabels returns the distinct key=value labels in machines, sorted.
+func hardwareLabels(machines []hardware.Machine) []string {
+	seen := map[string]struct{}{}
+	for _, machine := range machines {
+		for key, value := range machine.Labels {
+			seen[key+"="+value] = struct{}{}
+		}
+	}
+
+	labels := make([]string, 0, len(seen))
+	for label := range seen {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	return labels
+}
+
+func selectorFromLabel(label string) v1alpha1.HardwareSelector {
+	keyValue := strings.SplitN(label, "=", 2)
+	return v1alpha1.HardwareSelector{keyValue[0]: keyValue[1]}
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
@@ -87,6 +86,7 @@ type ClusterClient interface {
 	DeleteAWSIamConfig(ctx context.Context, managementCluster *types.Cluster, awsIamConfigName, awsIamConfigNamespace string) error
 	DeleteEKSACluster(ctx context.Context, managementCluster *types.Cluster, eksaClusterName, eksaClusterNamespace string) error
 	DeletePackageResources(ctx context.Context, managementCluster *types.Cluster, clusterName string) error
+	DeleteKubeProxy(ctx context.Context, cluster *types.Cluster) error
 	InitInfrastructure(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error
 	WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error
 	SaveLog(ctx context.Context, cluster *types.Cluster, deployment *types.Deployment, fileName string, writer filewriter.FileWriter) error
@@ -359,6 +359,10 @@ func (c *ClusterManager) DeleteCluster(ctx context.Context, managementCluster, c
 				return err
 			}
 
+			if err := provider.PostClusterDelete(ctx, clusterToDelete); err != nil {
+				return err
+			}
+
 			return provider.PostClusterDeleteValidate(ctx, managementCluster)
 		},
 	)
@@ -393,7 +397,7 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		logger.V(3).Info("Waiting for external etcd upgrade to be in progress")
 		err = c.clusterClient.WaitForManagedExternalEtcdNotReady(ctx, managementCluster, etcdInProgressStr, newClusterSpec.Cluster.Name)
 		if err != nil {
-			if !strings.Contains(fmt.Sprint(err), "timed out waiting for the condition on clusters") {
+			if !errors.Is(err, kubernetes.ErrWaitTimeout) {
 				return fmt.Errorf("error waiting for external etcd upgrade not ready: %v", err)
 			} else {
 				logger.V(3).Info("Timed out while waiting for external etcd to be in progress, likely caused by no external etcd upgrade")
@@ -417,7 +421,7 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 	logger.V(3).Info("Waiting for control plane upgrade to be in progress")
 	err = c.clusterClient.WaitForControlPlaneNotReady(ctx, managementCluster, controlPlaneInProgressStr, newClusterSpec.Cluster.Name)
 	if err != nil {
-		if !strings.Contains(fmt.Sprint(err), "timed out waiting for the condition on clusters") {
+		if !errors.Is(err, kubernetes.ErrWaitTimeout) {
 			return fmt.Errorf("error waiting for control plane not ready: %v", err)
 		} else {
 			logger.V(3).Info("Timed out while waiting for control plane to be in progress, likely caused by no control plane upgrade")
@@ -457,9 +461,21 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("waiting for workload cluster control plane replicas to be ready: %v", err)
 	}
 
-	err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, mdContent, constants.EksaSystemNamespace)
-	if err != nil {
-		return fmt.Errorf("applying capi machine deployment spec: %v", err)
+	logger.V(3).Info("Run post control plane ready operations")
+	if err = provider.PostControlPlaneReady(ctx, newClusterSpec, managementCluster); err != nil {
+		return fmt.Errorf("running post control plane ready operations: %v", err)
+	}
+
+	if newClusterSpec.Cluster.Spec.WorkerNodeGroupUpgradeStrategy.IsSequential() {
+		logger.V(3).Info("Upgrading worker node groups sequentially")
+		if err = c.upgradeWorkerNodeGroupsSequentially(ctx, managementCluster, newClusterSpec, mdContent); err != nil {
+			return err
+		}
+	} else {
+		err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, mdContent, constants.EksaSystemNamespace)
+		if err != nil {
+			return fmt.Errorf("applying capi machine deployment spec: %v", err)
+		}
 	}
 
 	if err = c.removeOldWorkerNodeGroups(ctx, managementCluster, provider, currentSpec, newClusterSpec); err != nil {
@@ -477,6 +493,11 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return err
 	}
 
+	logger.V(3).Info("Run post worker nodes ready operations")
+	if err = provider.PostWorkerNodesReady(ctx, newClusterSpec, managementCluster); err != nil {
+		return fmt.Errorf("running post worker nodes ready operations: %v", err)
+	}
+
 	logger.V(3).Info("Waiting for workload cluster capi components to be ready after upgrade")
 	err = c.waitForCAPI(ctx, eksaMgmtCluster, provider, externalEtcdTopology)
 	if err != nil {
@@ -572,12 +593,35 @@ func (c *ClusterManager) InstallNetworking(ctx context.Context, cluster *types.C
 	if err = c.clusterClient.ApplyKubeSpecFromBytes(ctx, cluster, networkingManifestContent); err != nil {
 		return fmt.Errorf("applying networking manifest spec: %v", err)
 	}
-	return nil
+	return c.removeKubeProxyIfReplaced(ctx, cluster, clusterSpec)
 }
 
 func (c *ClusterManager) UpgradeNetworking(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec, provider providers.Provider) (*types.ChangeDiff, error) {
 	providerNamespaces := getProviderNamespaces(provider.GetDeployments())
-	return c.networking.Upgrade(ctx, cluster, currentSpec, newSpec, providerNamespaces)
+	changeDiff, err := c.networking.Upgrade(ctx, cluster, currentSpec, newSpec, providerNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.removeKubeProxyIfReplaced(ctx, cluster, newSpec); err != nil {
+		return nil, err
+	}
+	return changeDiff, nil
+}
+
+// removeKubeProxyIfReplaced deletes the kube-proxy addon installed by kubeadm when Cilium
+// is configured to fully replace it. Otherwise both would program the service rules.
+func (c *ClusterManager) removeKubeProxyIfReplaced(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	cni := clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig
+	if cni == nil || cni.Cilium == nil || cni.Cilium.KubeProxyReplacement != v1alpha1.CiliumKubeProxyReplacementStrict {
+		return nil
+	}
+
+	logger.V(3).Info("Removing kube-proxy, replaced by Cilium")
+	if err := c.clusterClient.DeleteKubeProxy(ctx, cluster); err != nil {
+		return fmt.Errorf("removing kube-proxy: %v", err)
+	}
+	return nil
 }
 
 func getProviderNamespaces(providerDeployments map[string][]string) []string {
@@ -15,6 +15,7 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
@@ -86,6 +87,67 @@ func TestClusterManagerInstallNetworkingClientError(t *testing.T) {
 	}
 }
 
+func TestClusterManagerInstallNetworkingKubeProxyReplacementStrict(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	networkingManifest := []byte("cilium")
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Cilium: &v1alpha1.CiliumConfig{KubeProxyReplacement: v1alpha1.CiliumKubeProxyReplacementStrict},
+		}
+	})
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GetDeployments()
+	m.networking.EXPECT().GenerateManifest(ctx, clusterSpec, []string{}).Return(networkingManifest, nil)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, networkingManifest)
+	m.client.EXPECT().DeleteKubeProxy(ctx, workloadCluster)
+
+	if err := c.InstallNetworking(ctx, workloadCluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.InstallNetworking() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallNetworkingKubeProxyDeleteError(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	networkingManifest := []byte("cilium")
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Cilium: &v1alpha1.CiliumConfig{KubeProxyReplacement: v1alpha1.CiliumKubeProxyReplacementStrict},
+		}
+	})
+
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.provider.EXPECT().GetDeployments()
+	m.networking.EXPECT().GenerateManifest(ctx, clusterSpec, []string{}).Return(networkingManifest, nil)
+	m.client.EXPECT().ApplyKubeSpecFromBytes(ctx, workloadCluster, networkingManifest)
+	m.client.EXPECT().DeleteKubeProxy(ctx, workloadCluster).Return(errors.New("error from client"))
+
+	g := NewWithT(t)
+	g.Expect(c.InstallNetworking(ctx, workloadCluster, clusterSpec, m.provider)).To(MatchError(ContainSubstring("removing kube-proxy: error from client")))
+}
+
+func TestClusterManagerUpgradeNetworkingKubeProxyReplacementStrict(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{}
+	currentSpec := test.NewClusterSpec()
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Cilium: &v1alpha1.CiliumConfig{KubeProxyReplacement: v1alpha1.CiliumKubeProxyReplacementStrict},
+		}
+	})
+	wantDiff := &types.ChangeDiff{}
+
+	c, m := newClusterManager(t)
+	m.provider.EXPECT().GetDeployments()
+	m.networking.EXPECT().Upgrade(ctx, workloadCluster, currentSpec, newSpec, []string{}).Return(wantDiff, nil)
+	m.client.EXPECT().DeleteKubeProxy(ctx, workloadCluster)
+
+	g := NewWithT(t)
+	g.Expect(c.UpgradeNetworking(ctx, workloadCluster, currentSpec, newSpec, m.provider)).To(BeIdenticalTo(wantDiff))
+}
+
 func TestClusterManagerInstallStorageClassSuccess(t *testing.T) {
 	ctx := context.Background()
 	cluster := &types.Cluster{}
@@ -465,6 +527,8 @@ func TestClusterManagerUpgradeSelfManagedClusterSuccess(t *testing.T) {
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -508,6 +572,8 @@ func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdSuccess(t *test
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
 	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
@@ -553,8 +619,10 @@ func TestClusterManagerUpgradeSelfManagedClusterWithUnstackedEtcdTimeoutNotReady
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, mCluster, "1h0m0s", clusterName)
-	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName).Return(errors.New("timed out waiting for the condition on clusters"))
+	tt.mocks.client.EXPECT().WaitForManagedExternalEtcdNotReady(tt.ctx, mCluster, "1m", clusterName).Return(fmt.Errorf("executing wait: %w", kubernetes.ErrWaitTimeout))
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -655,6 +723,8 @@ func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -673,6 +743,177 @@ func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerUpgradeWorkloadClusterSequentialWorkerNodeGroupsSuccess(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	cpContent := []byte("cp")
+	md0Content := []byte(`apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: cluster-name-md-0-1
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-0
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          kind: KubeadmConfigTemplate
+          name: cluster-name-md-0-1
+      infrastructureRef:
+        kind: VSphereMachineTemplate
+        name: cluster-name-md-0-2
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: cluster-name-md-0-2
+`)
+	md1Content := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-1
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          kind: KubeadmConfigTemplate
+          name: cluster-name-md-1-1
+      infrastructureRef:
+        kind: VSphereMachineTemplate
+        name: cluster-name-md-1-2
+`)
+
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{Name: "md-0", Count: 1},
+		{Name: "md-1", Count: 1},
+	}
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupUpgradeStrategy = &v1alpha1.WorkerNodeGroupUpgradeStrategy{
+		Type:  v1alpha1.SequentialWorkerNodeGroupUpgrade,
+		Order: []string{"md-1"},
+	}
+	replicas := int32(1)
+	readyMachineDeployment := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{Replicas: &replicas},
+		Status: clusterv1.MachineDeploymentStatus{
+			Replicas:        1,
+			UpdatedReplicas: 1,
+			ReadyReplicas:   1,
+		},
+	}
+	md := &clusterv1.MachineDeployment{}
+
+	var applied [][]byte
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, gomock.Any(), tt.clusterSpec).Return(cpContent, append(md0Content, append([]byte("---\n"), md1Content...)...), nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte, _ string) error {
+			applied = append(applied, data)
+			return nil
+		},
+	).Times(3)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-1", gomock.Any(), gomock.Any()).Return(readyMachineDeployment, nil),
+		tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.Any(), gomock.Any()).Return(readyMachineDeployment, nil),
+	)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(md, nil).AnyTimes()
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, md, mCluster.KubeconfigFile).AnyTimes()
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, mCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().CountMachineDeploymentReplicasReady(tt.ctx, mCluster.Name, mCluster.KubeconfigFile).Return(0, 0, nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(Succeed())
+	tt.Expect(applied).To(HaveLen(3))
+	tt.Expect(applied[0]).To(Equal(cpContent))
+	tt.Expect(string(applied[1])).To(ContainSubstring("name: cluster-name-md-1"))
+	tt.Expect(string(applied[1])).NotTo(ContainSubstring("cluster-name-md-0"))
+	tt.Expect(string(applied[2])).To(ContainSubstring("name: cluster-name-md-0-1"))
+	tt.Expect(string(applied[2])).To(ContainSubstring("name: cluster-name-md-0-2"))
+	tt.Expect(string(applied[2])).NotTo(ContainSubstring("cluster-name-md-1"))
+}
+
+func TestClusterManagerUpgradeWorkloadClusterSequentialWorkerNodeGroupsNotReady(t *testing.T) {
+	mgmtClusterName := "cluster-name"
+	workClusterName := "cluster-name-w"
+
+	mCluster := &types.Cluster{
+		Name:               mgmtClusterName,
+		ExistingManagement: true,
+	}
+	wCluster := &types.Cluster{
+		Name: workClusterName,
+	}
+
+	mdContent := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-0
+`)
+
+	tt := newSpecChangedTest(t, clustermanager.WithMachineBackoff(1*time.Nanosecond), clustermanager.WithMachineMaxWait(50*time.Microsecond), clustermanager.WithMachineMinWait(100*time.Microsecond))
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{Name: "md-0", Count: 1},
+	}
+	tt.clusterSpec.Cluster.Spec.WorkerNodeGroupUpgradeStrategy = &v1alpha1.WorkerNodeGroupUpgradeStrategy{
+		Type: v1alpha1.SequentialWorkerNodeGroupUpgrade,
+	}
+	replicas := int32(1)
+	notReadyMachineDeployment := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{Replicas: &replicas},
+		Status: clusterv1.MachineDeploymentStatus{
+			Replicas:        2,
+			UpdatedReplicas: 1,
+			ReadyReplicas:   1,
+		},
+	}
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, mCluster, mgmtClusterName).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, mCluster.KubeconfigFile, mCluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, gomock.Any(), tt.clusterSpec).Return([]byte("cp"), mdContent, nil)
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, gomock.Any(), tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
+	tt.mocks.client.EXPECT().GetMachineDeployment(tt.ctx, "cluster-name-md-0", gomock.Any(), gomock.Any()).Return(notReadyMachineDeployment, nil).MinTimes(1)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, mCluster.Name).Return(nil)
+	tt.mocks.writer.EXPECT().Write(mgmtClusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.IdentityProviderRefs[0].Name, mCluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(nil, nil)
+	tt.mocks.networking.EXPECT().RunPostControlPlaneUpgradeSetup(tt.ctx, wCluster).Return(nil)
+
+	tt.Expect(tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider)).To(
+		MatchError(ContainSubstring("waiting for worker node group md-0 to be ready")),
+	)
+}
+
 func TestClusterManagerUpgradeWorkloadClusterAWSIamConfigSuccess(t *testing.T) {
 	mgmtClusterName := "cluster-name"
 	workClusterName := "cluster-name-w"
@@ -699,6 +940,8 @@ func TestClusterManagerUpgradeWorkloadClusterAWSIamConfigSuccess(t *testing.T) {
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -739,6 +982,8 @@ func TestClusterManagerUpgradeCloudStackWorkloadClusterSuccess(t *testing.T) {
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -777,6 +1022,8 @@ func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyErrorOnce(t *testing.
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -818,6 +1065,8 @@ func TestClusterManagerUpgradeWorkloadClusterWaitForMDReadyUnreadyOnce(t *testin
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -892,6 +1141,7 @@ func TestClusterManagerUpgradeWorkloadClusterGetMachineDeploymentError(t *testin
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
@@ -925,6 +1175,7 @@ func TestClusterManagerUpgradeWorkloadClusterRemoveOldWorkerNodeGroupsError(t *t
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, mCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", mgmtClusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", mgmtClusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil)
@@ -998,6 +1249,8 @@ func TestClusterManagerUpgradeWorkloadClusterWaitForCAPITimeout(t *testing.T) {
 	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
 	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
 	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.provider.EXPECT().PostControlPlaneReady(tt.ctx, tt.clusterSpec, mCluster)
+	tt.mocks.provider.EXPECT().PostWorkerNodesReady(tt.ctx, tt.clusterSpec, mCluster)
 	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "1h0m0s", clusterName).MaxTimes(2)
 	tt.mocks.client.EXPECT().WaitForControlPlaneNotReady(tt.ctx, mCluster, "1m", clusterName)
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
@@ -1985,3 +2238,26 @@ func TestClusterManagerDeletePackageResources(t *testing.T) {
 	err := tt.clusterManager.DeletePackageResources(tt.ctx, tt.cluster, tt.clusterName)
 	tt.Expect(err).To(BeNil())
 }
+
+func TestClusterManagerDeleteClusterSelfManaged(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"}
+
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster),
+		tt.mocks.provider.EXPECT().PostClusterDelete(tt.ctx, tt.cluster),
+		tt.mocks.provider.EXPECT().PostClusterDeleteValidate(tt.ctx, managementCluster),
+	)
+
+	tt.Expect(tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerDeleteClusterPostClusterDeleteError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	managementCluster := &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"}
+
+	tt.mocks.client.EXPECT().DeleteCluster(tt.ctx, managementCluster, tt.cluster)
+	tt.mocks.provider.EXPECT().PostClusterDelete(tt.ctx, tt.cluster).Return(errors.New("removing ingress"))
+
+	tt.Expect(tt.clusterManager.DeleteCluster(tt.ctx, managementCluster, tt.cluster, tt.mocks.provider, tt.clusterSpec)).To(MatchError("removing ingress"))
+}
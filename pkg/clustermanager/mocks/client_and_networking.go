@@ -172,6 +172,20 @@ func (mr *MockClusterClientMockRecorder) DeleteGitOpsConfig(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitOpsConfig", reflect.TypeOf((*MockClusterClient)(nil).DeleteGitOpsConfig), arg0, arg1, arg2, arg3)
 }
 
+// DeleteKubeProxy mocks base method.
+func (m *MockClusterClient) DeleteKubeProxy(arg0 context.Context, arg1 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKubeProxy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKubeProxy indicates an expected call of DeleteKubeProxy.
+func (mr *MockClusterClientMockRecorder) DeleteKubeProxy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKubeProxy", reflect.TypeOf((*MockClusterClient)(nil).DeleteKubeProxy), arg0, arg1)
+}
+
 // DeleteOIDCConfig mocks base method.
 func (m *MockClusterClient) DeleteOIDCConfig(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
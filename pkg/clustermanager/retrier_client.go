@@ -87,3 +87,11 @@ func (c *retrierClient) RemoveAnnotationInNamespace(ctx context.Context, resourc
 		},
 	)
 }
+
+func (c *retrierClient) DeleteKubeProxy(ctx context.Context, cluster *types.Cluster) error {
+	return c.Retry(
+		func() error {
+			return c.ClusterClient.DeleteKubeProxy(ctx, cluster)
+		},
+	)
+}
@@ -0,0 +1,164 @@
+package clustermanager
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const machineDeploymentKind = "MachineDeployment"
+
+// upgradeWorkerNodeGroupsSequentially applies the capi spec of one worker node group at a time,
+// following the order defined in the cluster spec, and waits for each machine deployment
+// to be fully rolled out and healthy before moving to the next one.
+func (c *ClusterManager) upgradeWorkerNodeGroupsSequentially(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, mdContent []byte) error {
+	specs, err := splitWorkerNodeGroupsSpec(mdContent)
+	if err != nil {
+		return fmt.Errorf("splitting capi machine deployment spec: %v", err)
+	}
+
+	if len(specs.shared) > 0 {
+		if err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, specs.shared, constants.EksaSystemNamespace); err != nil {
+			return fmt.Errorf("applying capi machine deployment spec: %v", err)
+		}
+	}
+
+	for _, workerNodeGroup := range clusterSpec.Cluster.WorkerNodeGroupConfigurationsInUpgradeOrder() {
+		machineDeploymentName := clusterapi.MachineDeploymentName(clusterSpec, workerNodeGroup)
+		spec, ok := specs.byMachineDeployment[machineDeploymentName]
+		if !ok {
+			return fmt.Errorf("capi spec for worker node group %s not found", workerNodeGroup.Name)
+		}
+
+		logger.V(3).Info("Upgrading worker node group", "name", workerNodeGroup.Name)
+		if err = c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, spec, constants.EksaSystemNamespace); err != nil {
+			return fmt.Errorf("applying capi machine deployment spec for worker node group %s: %v", workerNodeGroup.Name, err)
+		}
+
+		logger.V(3).Info("Waiting for worker node group to be ready after upgrade", "name", workerNodeGroup.Name)
+		if err = c.waitForMachineDeploymentReady(ctx, managementCluster, machineDeploymentName, workerNodeGroup.Count); err != nil {
+			return fmt.Errorf("waiting for worker node group %s to be ready: %v", workerNodeGroup.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterManager) waitForMachineDeploymentReady(ctx context.Context, managementCluster *types.Cluster, machineDeploymentName string, count int) error {
+	isMdReady := func() error {
+		md, err := c.clusterClient.GetMachineDeployment(ctx, machineDeploymentName, executables.WithKubeconfig(managementCluster.KubeconfigFile), executables.WithNamespace(constants.EksaSystemNamespace))
+		if err != nil {
+			return err
+		}
+		return clusterapi.MachineDeploymentReady(md)
+	}
+
+	timeout := time.Duration(count) * c.machineMaxWait
+	if timeout <= c.machinesMinWait {
+		timeout = c.machinesMinWait
+	}
+
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, c.machineBackoff
+	}))
+	if err := r.Retry(isMdReady); err != nil {
+		return fmt.Errorf("retries exhausted waiting for machinedeployment %s to be ready: %v", machineDeploymentName, err)
+	}
+	return nil
+}
+
+type workerNodeGroupsSpec struct {
+	// byMachineDeployment holds, for each machine deployment, the machine deployment
+	// and the bootstrap and infrastructure templates it references.
+	byMachineDeployment map[string][]byte
+	// shared holds the objects not referenced by any machine deployment.
+	shared []byte
+}
+
+type objectKey struct {
+	kind, name string
+}
+
+// splitWorkerNodeGroupsSpec splits the multi-document machine deployments spec generated by
+// a provider in groups of objects that can be applied independently for each worker node group.
+func splitWorkerNodeGroupsSpec(content []byte) (*workerNodeGroupsSpec, error) {
+	type document struct {
+		key     objectKey
+		content []byte
+	}
+
+	var documents []document
+	owners := map[objectKey]string{}
+	yamlReader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		b, err := yamlReader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("reading yaml document: %v", err)
+		}
+
+		md := &clusterv1.MachineDeployment{}
+		if err = yaml.Unmarshal(b, md); err != nil {
+			return nil, fmt.Errorf("invalid yaml kubernetes object: %v", err)
+		}
+
+		if md.Kind == "" {
+			continue
+		}
+
+		key := objectKey{kind: md.Kind, name: md.Name}
+		documents = append(documents, document{key: key, content: b})
+
+		if md.Kind != machineDeploymentKind {
+			continue
+		}
+
+		owners[key] = md.Name
+		if ref := md.Spec.Template.Spec.Bootstrap.ConfigRef; ref != nil {
+			owners[objectKey{kind: ref.Kind, name: ref.Name}] = md.Name
+		}
+		infraRef := md.Spec.Template.Spec.InfrastructureRef
+		owners[objectKey{kind: infraRef.Kind, name: infraRef.Name}] = md.Name
+	}
+
+	grouped := map[string][][]byte{}
+	var shared [][]byte
+	for _, d := range documents {
+		if owner, ok := owners[d.key]; ok {
+			grouped[owner] = append(grouped[owner], d.content)
+		} else {
+			shared = append(shared, d.content)
+		}
+	}
+
+	specs := &workerNodeGroupsSpec{
+		byMachineDeployment: make(map[string][]byte, len(grouped)),
+	}
+	for name, objs := range grouped {
+		specs.byMachineDeployment[name] = templater.AppendYamlResources(objs...)
+	}
+	if len(shared) > 0 {
+		specs.shared = templater.AppendYamlResources(shared...)
+	}
+
+	return specs, nil
+}
@@ -2,12 +2,14 @@ package clustermarshaller
 
 import (
 	"fmt"
+	"sort"
 
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/internal/pkg/api"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -46,6 +48,15 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 		}
 	}
 
+	packageNames := make([]string, 0, len(clusterSpec.Packages))
+	for name := range clusterSpec.Packages {
+		packageNames = append(packageNames, name)
+	}
+	sort.Strings(packageNames)
+	for _, name := range packageNames {
+		marshallables = append(marshallables, curatedpackages.NewDisplayablePackage(clusterSpec.Packages[name]))
+	}
+
 	resources := make([][]byte, 0, len(marshallables))
 	for _, marshallable := range marshallables {
 		resource, err := yaml.Marshal(marshallable)
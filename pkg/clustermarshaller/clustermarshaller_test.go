@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -313,3 +314,59 @@ func TestWriteClusterConfigWithFluxConfig(t *testing.T) {
 
 	test.AssertFilesEquals(t, gotFile, "testdata/expected_marshalled_cluster_flux_config.yaml")
 }
+
+func TestWriteClusterConfigWithPackages(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.APIVersion = v1alpha1.GroupVersion.String()
+		s.Cluster.TypeMeta.Kind = v1alpha1.ClusterKind
+		s.Cluster.Name = "mycluster"
+		s.Packages = map[string]*packagesv1.Package{
+			"my-metallb": {
+				TypeMeta: v1.TypeMeta{
+					Kind:       cluster.PackageKind,
+					APIVersion: packagesv1.GroupVersion.String(),
+				},
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "my-metallb",
+					Namespace: "eksa-packages-mycluster",
+				},
+				Spec: packagesv1.PackageSpec{
+					PackageName: "metallb",
+				},
+			},
+			"my-harbor": {
+				TypeMeta: v1.TypeMeta{
+					Kind:       cluster.PackageKind,
+					APIVersion: packagesv1.GroupVersion.String(),
+				},
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "my-harbor",
+					Namespace: "eksa-packages-mycluster",
+				},
+				Spec: packagesv1.PackageSpec{
+					PackageName: "harbor",
+					Config:      "externalURL: https://harbor.local\n",
+				},
+			},
+		}
+		s.Cluster.SetSelfManaged()
+	})
+
+	datacenterConfig := &v1alpha1.DockerDatacenterConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       v1alpha1.DockerDatacenterKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: "config",
+		},
+	}
+	g := NewWithT(t)
+
+	folder, writer := test.NewWriter(t)
+	gotFile := filepath.Join(folder, "mycluster-eks-a-cluster.yaml")
+
+	g.Expect(clustermarshaller.WriteClusterConfig(clusterSpec, datacenterConfig, nil, writer)).To(Succeed())
+
+	test.AssertFilesEquals(t, gotFile, "testdata/expected_marshalled_cluster_packages.yaml")
+}
@@ -10,6 +10,9 @@ const (
 	AwsAccessKeyIdEnv         = "AWS_ACCESS_KEY_ID"
 	AwsSecretAccessKeyEnv     = "AWS_SECRET_ACCESS_KEY"
 	EksaRegionEnv             = "EKSA_AWS_REGION"
+
+	EksaManifestSigningPublicKeysEnv         = "EKSA_MANIFEST_SIGNING_PUBLIC_KEYS"
+	EksaSkipManifestSignatureVerificationEnv = "EKSA_SKIP_MANIFEST_SIGNATURE_VERIFICATION"
 )
 
 type CliConfig struct {
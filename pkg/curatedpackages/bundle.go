@@ -16,6 +16,10 @@ import (
 
 const (
 	ImageRepositoryName = "eks-anywhere-packages-bundles"
+
+	// PreviousActiveBundleAnnotation records in the PackageBundleController the active bundle
+	// before the last upgrade, so it can be rolled back.
+	PreviousActiveBundleAnnotation = "anywhere.eks.amazonaws.com/previous-active-bundle"
 )
 
 type Reader interface {
@@ -71,14 +75,15 @@ func (b *BundleReader) getActiveBundleFromCluster(ctx context.Context) (*package
 	if err != nil {
 		return nil, err
 	}
-	bundle, err := b.getPackageBundle(ctx, bundleController.Spec.ActiveBundle)
+	bundle, err := b.GetPackageBundle(ctx, bundleController.Spec.ActiveBundle)
 	if err != nil {
 		return nil, err
 	}
 	return bundle, nil
 }
 
-func (b *BundleReader) getPackageBundle(ctx context.Context, bundleName string) (*packagesv1.PackageBundle, error) {
+// GetPackageBundle returns a package bundle available in the cluster.
+func (b *BundleReader) GetPackageBundle(ctx context.Context, bundleName string) (*packagesv1.PackageBundle, error) {
 	params := []string{"get", "packageBundle", "-o", "json", "--kubeconfig", b.kubeConfig, "--namespace", constants.EksaPackagesName, bundleName}
 	if bundleName == "" {
 		return nil, fmt.Errorf("no bundle name specified")
@@ -108,6 +113,12 @@ func (b *BundleReader) GetActiveController(ctx context.Context) (*packagesv1.Pac
 }
 
 func (b *BundleReader) UpgradeBundle(ctx context.Context, controller *packagesv1.PackageBundleController, newBundleVersion string) error {
+	if previous := controller.Spec.ActiveBundle; previous != "" && previous != newBundleVersion {
+		if controller.Annotations == nil {
+			controller.Annotations = map[string]string{}
+		}
+		controller.Annotations[PreviousActiveBundleAnnotation] = previous
+	}
 	controller.Spec.ActiveBundle = newBundleVersion
 	controllerYaml, err := yaml.Marshal(controller)
 	if err != nil {
@@ -136,3 +147,18 @@ func GetPackageBundleRef(vb releasev1.VersionsBundle) (string, error) {
 	registryBaseRef := fmt.Sprintf("%s/%s/%s:%s", controllerImage[0], controllerImage[1], "eks-anywhere-packages-bundles", latestBundle)
 	return registryBaseRef, nil
 }
+
+// RollbackBundle sets the active bundle of the controller back to the one active before the last upgrade
+// and returns its name. The bundle rolled back from is recorded as the previous one, so a rollback can be undone.
+func (b *BundleReader) RollbackBundle(ctx context.Context, controller *packagesv1.PackageBundleController) (string, error) {
+	previous := controller.Annotations[PreviousActiveBundleAnnotation]
+	if previous == "" {
+		return "", fmt.Errorf("no previous active bundle recorded for package bundle controller %s", controller.Name)
+	}
+
+	if err := b.UpgradeBundle(ctx, controller, previous); err != nil {
+		return "", fmt.Errorf("rolling back to bundle %s: %v", previous, err)
+	}
+
+	return previous, nil
+}
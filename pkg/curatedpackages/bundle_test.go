@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
@@ -194,6 +195,9 @@ func TestUpgradeBundleSucceeds(t *testing.T) {
 	params := []string{"apply", "-f", "-", "--kubeconfig", tt.kubeConfig}
 	newBundle := "new-bundle"
 	expectedCtrl := packagesv1.PackageBundleController{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{curatedpackages.PreviousActiveBundleAnnotation: tt.activeBundle},
+		},
 		Spec: packagesv1.PackageBundleControllerSpec{
 			ActiveBundle: newBundle,
 		},
@@ -214,6 +218,7 @@ func TestUpgradeBundleSucceeds(t *testing.T) {
 	err = tt.Command.UpgradeBundle(tt.ctx, tt.bundleCtrl, newBundle)
 	tt.Expect(err).To(BeNil())
 	tt.Expect(tt.bundleCtrl.Spec.ActiveBundle).To(Equal(newBundle))
+	tt.Expect(tt.bundleCtrl.Annotations[curatedpackages.PreviousActiveBundleAnnotation]).To(Equal(tt.activeBundle))
 }
 
 func TestUpgradeBundleFails(t *testing.T) {
@@ -221,6 +226,9 @@ func TestUpgradeBundleFails(t *testing.T) {
 	params := []string{"apply", "-f", "-", "--kubeconfig", tt.kubeConfig}
 	newBundle := "new-bundle"
 	expectedCtrl := packagesv1.PackageBundleController{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{curatedpackages.PreviousActiveBundleAnnotation: tt.activeBundle},
+		},
 		Spec: packagesv1.PackageBundleControllerSpec{
 			ActiveBundle: newBundle,
 		},
@@ -242,6 +250,53 @@ func TestUpgradeBundleFails(t *testing.T) {
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestRollbackBundleSucceeds(t *testing.T) {
+	tt := newBundleTest(t)
+	params := []string{"apply", "-f", "-", "--kubeconfig", tt.kubeConfig}
+	tt.bundleCtrl.Annotations = map[string]string{curatedpackages.PreviousActiveBundleAnnotation: "v1.21-999"}
+	expectedCtrl := packagesv1.PackageBundleController{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{curatedpackages.PreviousActiveBundleAnnotation: tt.activeBundle},
+		},
+		Spec: packagesv1.PackageBundleControllerSpec{
+			ActiveBundle: "v1.21-999",
+		},
+	}
+	ctrl, err := yaml.Marshal(expectedCtrl)
+	tt.Expect(err).To(BeNil())
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, ctrl, params).Return(bytes.Buffer{}, nil)
+
+	tt.Command = curatedpackages.NewBundleReader(
+		tt.kubeConfig,
+		tt.cluster,
+		curatedpackages.Cluster,
+		tt.kubectl,
+		tt.bundleManager,
+		tt.registry,
+	)
+
+	bundle, err := tt.Command.RollbackBundle(tt.ctx, tt.bundleCtrl)
+	tt.Expect(err).To(BeNil())
+	tt.Expect(bundle).To(Equal("v1.21-999"))
+}
+
+func TestRollbackBundleNoPreviousBundle(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.bundleCtrl.Name = "eksa-packages-controller"
+
+	tt.Command = curatedpackages.NewBundleReader(
+		tt.kubeConfig,
+		tt.cluster,
+		curatedpackages.Cluster,
+		tt.kubectl,
+		tt.bundleManager,
+		tt.registry,
+	)
+
+	_, err := tt.Command.RollbackBundle(tt.ctx, tt.bundleCtrl)
+	tt.Expect(err).To(MatchError("no previous active bundle recorded for package bundle controller eksa-packages-controller"))
+}
+
 func convertJsonToBytes(obj interface{}) bytes.Buffer {
 	b, _ := json.Marshal(obj)
 	return *bytes.NewBuffer(b)
@@ -0,0 +1,140 @@
+package curatedpackages
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+// ReadPackagesFile reads the Package objects from a multi document yaml file, ignoring other kinds.
+func ReadPackagesFile(fileName string) ([]packagesv1.Package, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("reading packages file: %v", err)
+	}
+
+	var packages []packagesv1.Package
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading packages file: %v", err)
+		}
+
+		meta := &metav1.TypeMeta{}
+		if err := yaml.Unmarshal(doc, meta); err != nil {
+			return nil, fmt.Errorf("parsing packages file: %v", err)
+		}
+		if meta.Kind != kind {
+			continue
+		}
+
+		p := packagesv1.Package{}
+		if err := yaml.UnmarshalStrict(doc, &p); err != nil {
+			return nil, fmt.Errorf("parsing package: %v", err)
+		}
+		packages = append(packages, p)
+	}
+
+	return packages, nil
+}
+
+// DiffPackages returns a unified diff between the configuration of the packages running in the cluster
+// and the configuration of the given packages. Packages not installed yet are diffed against an empty configuration.
+func (pc *PackageClient) DiffPackages(ctx context.Context, packages []packagesv1.Package, kubeConfig string) (string, error) {
+	var diffs []string
+	for _, p := range packages {
+		current, err := pc.getPackage(ctx, p.Name, p.Namespace, kubeConfig)
+		if err != nil {
+			return "", err
+		}
+
+		currentValues := ""
+		if current != nil {
+			if currentValues, err = normalizeConfig(current.Spec.Config); err != nil {
+				return "", fmt.Errorf("reading current configuration of package %s: %v", p.Name, err)
+			}
+		}
+		newValues, err := normalizeConfig(p.Spec.Config)
+		if err != nil {
+			return "", fmt.Errorf("reading configuration of package %s: %v", p.Name, err)
+		}
+
+		if currentValues == newValues {
+			diffs = append(diffs, fmt.Sprintf("No configuration changes for package %s\n", p.Name))
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(currentValues),
+			B:        splitLines(newValues),
+			FromFile: "current/" + p.Name,
+			ToFile:   "new/" + p.Name,
+			Context:  3,
+		})
+		if err != nil {
+			return "", fmt.Errorf("generating configuration diff for package %s: %v", p.Name, err)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return strings.Join(diffs, ""), nil
+}
+
+func (pc *PackageClient) getPackage(ctx context.Context, name, namespace, kubeConfig string) (*packagesv1.Package, error) {
+	params := []string{"get", "packages", name, "-o", "json", "--ignore-not-found", "--kubeconfig", kubeConfig, "--namespace", namespace}
+	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting package %s: %v", name, err)
+	}
+	if len(bytes.TrimSpace(stdOut.Bytes())) == 0 {
+		return nil, nil
+	}
+
+	p := &packagesv1.Package{}
+	if err := json.Unmarshal(stdOut.Bytes(), p); err != nil {
+		return nil, fmt.Errorf("unmarshaling package %s: %v", name, err)
+	}
+	return p, nil
+}
+
+// normalizeConfig returns the yaml configuration with sorted keys, so two equivalent configurations
+// don't produce a diff.
+func normalizeConfig(config string) (string, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(config), &values); err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(s, "\n"))
+}
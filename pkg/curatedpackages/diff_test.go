@@ -0,0 +1,118 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+)
+
+const packagesFile = `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor
+  config: |
+    externalURL: https://harbor.local
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+`
+
+func TestReadPackagesFile(t *testing.T) {
+	g := NewWithT(t)
+	fileName := filepath.Join(t.TempDir(), "packages.yaml")
+	g.Expect(os.WriteFile(fileName, []byte(packagesFile), 0o644)).To(Succeed())
+
+	packages, err := curatedpackages.ReadPackagesFile(fileName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(packages).To(HaveLen(1))
+	g.Expect(packages[0].Name).To(Equal("my-harbor"))
+	g.Expect(packages[0].Namespace).To(Equal("eksa-packages-billy"))
+	g.Expect(packages[0].Spec.Config).To(Equal("externalURL: https://harbor.local\n"))
+}
+
+func TestReadPackagesFileMissing(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := curatedpackages.ReadPackagesFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	g.Expect(err).To(MatchError(ContainSubstring("reading packages file")))
+}
+
+func newDiffPackage(config string) packagesv1.Package {
+	p := packagesv1.Package{Spec: packagesv1.PackageSpec{PackageName: "harbor", Config: config}}
+	p.Name = "my-harbor"
+	p.Namespace = "eksa-packages-billy"
+	return p
+}
+
+func expectGetPackage(k *mocks.MockKubectlRunner, ctx context.Context, out string, err error) {
+	params := []string{"get", "packages", "my-harbor", "-o", "json", "--ignore-not-found", "--kubeconfig", "kubeconfig", "--namespace", "eksa-packages-billy"}
+	k.EXPECT().ExecuteCommand(ctx, params).Return(*bytes.NewBufferString(out), err)
+}
+
+func TestDiffPackagesChanged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	expectGetPackage(k, ctx, `{"spec":{"packageName":"harbor","config":"externalURL: https://old.local\nlogLevel: info\n"}}`, nil)
+	client := curatedpackages.NewPackageClient(k)
+
+	diff, err := client.DiffPackages(ctx, []packagesv1.Package{newDiffPackage("logLevel: info\nexternalURL: https://harbor.local\n")}, "kubeconfig")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal(`--- current/my-harbor
++++ new/my-harbor
+@@ -1,2 +1,2 @@
+-externalURL: https://old.local
++externalURL: https://harbor.local
+ logLevel: info
+`))
+}
+
+func TestDiffPackagesNotInstalled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	expectGetPackage(k, ctx, "", nil)
+	client := curatedpackages.NewPackageClient(k)
+
+	diff, err := client.DiffPackages(ctx, []packagesv1.Package{newDiffPackage("externalURL: https://harbor.local\n")}, "kubeconfig")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(ContainSubstring("+externalURL: https://harbor.local"))
+}
+
+func TestDiffPackagesUnchanged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	expectGetPackage(k, ctx, `{"spec":{"packageName":"harbor","config":"a: 1\nb: 2\n"}}`, nil)
+	client := curatedpackages.NewPackageClient(k)
+
+	diff, err := client.DiffPackages(ctx, []packagesv1.Package{newDiffPackage("b: 2\na: 1\n")}, "kubeconfig")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal("No configuration changes for package my-harbor\n"))
+}
+
+func TestDiffPackagesGetError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	expectGetPackage(k, ctx, "", errors.New("connection refused"))
+	client := curatedpackages.NewPackageClient(k)
+
+	_, err := client.DiffPackages(ctx, []packagesv1.Package{newDiffPackage("")}, "kubeconfig")
+	g.Expect(err).To(MatchError("getting package my-harbor: connection refused"))
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -22,20 +24,25 @@ func NewBundleDownloader(dstFolder string) *BundleDownloader {
 	}
 }
 
-func (bd *BundleDownloader) Download(ctx context.Context, bundles *releasev1.Bundles) {
+// Download pulls the package bundles referenced in bundles and writes them to the destination folder.
+// It keeps going after a failure and returns all the errors aggregated.
+func (bd *BundleDownloader) Download(ctx context.Context, bundles *releasev1.Bundles) error {
+	var errs []error
 	artifacts := ReadFilesFromBundles(bundles)
 	for _, a := range UniqueCharts(artifacts) {
 		data, err := curatedpackages.PullLatestBundle(ctx, a)
 		if err != nil {
-			fmt.Printf("unable to download bundle %v \n", err)
+			errs = append(errs, fmt.Errorf("unable to download bundle %s: %v", a, err))
 			continue
 		}
 		bundleName := strings.Replace(filepath.Base(a), ":", "-", 1)
 		err = writeToFile(bd.dstFolder, bundleName, data)
 		if err != nil {
-			fmt.Printf("unable to write to file %v \n", err)
+			errs = append(errs, err)
 		}
 	}
+
+	return utilerrors.NewAggregate(errs)
 }
 
 func UniqueCharts(charts []string) []string {
@@ -2,12 +2,14 @@ package oras
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/utils/urls"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -27,7 +29,10 @@ func NewFileRegistryImporter(registry, username, password, srcFolder string) *Fi
 	}
 }
 
-func (fr *FileRegistryImporter) Push(ctx context.Context, bundles *releasev1.Bundles) {
+// Push pushes the package bundles referenced in bundles from the source folder to the registry.
+// It keeps going after a failure and returns all the errors aggregated.
+func (fr *FileRegistryImporter) Push(ctx context.Context, bundles *releasev1.Bundles) error {
+	var errs []error
 	artifacts := ReadFilesFromBundles(bundles)
 	for _, a := range UniqueCharts(artifacts) {
 		updatedChartURL := urls.ReplaceHost(a, fr.registry)
@@ -35,14 +40,16 @@ func (fr *FileRegistryImporter) Push(ctx context.Context, bundles *releasev1.Bun
 		chartFilepath := filepath.Join(fr.srcFolder, fileName)
 		data, err := os.ReadFile(chartFilepath)
 		if err != nil {
-			logger.Info("Warning: reading file", "error", err)
+			errs = append(errs, fmt.Errorf("reading bundle file: %v", err))
 			continue
 		}
 		err = curatedpackages.PushBundle(ctx, updatedChartURL, fileName, data)
 		if err != nil {
-			logger.Info("Warning: Failed  to push to registry", "error", err)
+			errs = append(errs, fmt.Errorf("pushing bundle %s to registry: %v", updatedChartURL, err))
 		}
 	}
+
+	return utilerrors.NewAggregate(errs)
 }
 
 func ChartFileName(chart string) string {
@@ -122,6 +122,9 @@ func (pc *PackageClient) InstallPackage(ctx context.Context, bp *packagesv1.Bund
 	if err != nil {
 		return err
 	}
+	if err := ValidatePackageConfig(bp, "", configString); err != nil {
+		return err
+	}
 
 	p := convertBundlePackageToPackage(*bp, customName, clusterName, pc.bundle.APIVersion, configString)
 	displayPackage := NewDisplayablePackage(&p)
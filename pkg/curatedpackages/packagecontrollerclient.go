@@ -40,6 +40,7 @@ type PackageControllerClient struct {
 	httpProxy             string
 	httpsProxy            string
 	noProxy               []string
+	registryMirror        string
 	// activeBundleTimeout is the timeout to activate a bundle on installation.
 	activeBundleTimeout time.Duration
 }
@@ -81,6 +82,12 @@ func (pc *PackageControllerClient) InstallController(ctx context.Context) error
 	clusterName := fmt.Sprintf("clusterName=%s", pc.clusterName)
 	values := []string{sourceRegistry, clusterName}
 
+	// With a registry mirror, the package charts and images are pulled from the same repository as the
+	// package bundles, which is where `copy packages` and `import images` push them.
+	if pc.registryMirror != "" {
+		values = append(values, fmt.Sprintf("defaultRegistry=%s", registry), fmt.Sprintf("defaultImageRegistry=%s", registry))
+	}
+
 	// Provide proxy details for curated packages helm chart when proxy details provided
 	if pc.httpProxy != "" {
 		httpProxy := fmt.Sprintf("proxy.HTTP_PROXY=%s", pc.httpProxy)
@@ -258,3 +265,9 @@ func WithManagementClusterName(managementClusterName string) func(client *Packag
 		config.managementClusterName = managementClusterName
 	}
 }
+
+func WithRegistryMirror(registryMirror string) func(client *PackageControllerClient) {
+	return func(config *PackageControllerClient) {
+		config.registryMirror = registryMirror
+	}
+}
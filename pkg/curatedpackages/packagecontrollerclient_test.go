@@ -108,6 +108,39 @@ func TestInstallControllerSuccess(t *testing.T) {
 	}
 }
 
+func TestInstallControllerWithRegistryMirror(t *testing.T) {
+	tt := newPackageControllerTest(t)
+	tt.ociUri = "1.2.3.4:443/eks-anywhere/eks-anywhere-packages"
+	tt.command = curatedpackages.NewPackageControllerClient(
+		tt.chartInstaller, tt.kubectl, tt.clusterName, tt.kubeConfig, tt.ociUri, tt.chartName, tt.chartVersion,
+		curatedpackages.WithEksaSecretAccessKey(tt.eksaAccessKey),
+		curatedpackages.WithEksaRegion(tt.eksaRegion),
+		curatedpackages.WithEksaAccessKeyId(tt.eksaAccessId),
+		curatedpackages.WithRegistryMirror("1.2.3.4:443"),
+	)
+
+	values := []string{
+		"sourceRegistry=1.2.3.4:443/eks-anywhere",
+		"clusterName=billy",
+		"defaultRegistry=1.2.3.4:443/eks-anywhere",
+		"defaultImageRegistry=1.2.3.4:443/eks-anywhere",
+	}
+	params := []string{"create", "-f", "-", "--kubeconfig", tt.kubeConfig}
+	dat, err := os.ReadFile("testdata/awssecret_test.yaml")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, dat, params).Return(bytes.Buffer{}, nil)
+	params = []string{"create", "job", jobName, "--from=" + cronJobName, "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(bytes.Buffer{}, nil)
+	tt.chartInstaller.EXPECT().InstallChart(tt.ctx, tt.chartName, "oci://"+tt.ociUri, tt.chartVersion, tt.kubeConfig, "eksa-packages", values).Return(nil)
+	any := gomock.Any()
+	tt.kubectl.EXPECT().
+		GetObject(any, any, any, any, any, any).
+		DoAndReturn(getPBCSuccess(t)).
+		AnyTimes()
+
+	tt.Expect(tt.command.InstallController(tt.ctx)).To(Succeed())
+}
+
 func getPBCSuccess(t *testing.T) func(context.Context, string, string, string, string, *packagesv1.PackageBundleController) error {
 	return func(_ context.Context, _, _, _, _ string, obj *packagesv1.PackageBundleController) error {
 		pbc := &packagesv1.PackageBundleController{
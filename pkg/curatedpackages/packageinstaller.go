@@ -2,12 +2,23 @@ package curatedpackages
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
 
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
+// ClusterConfigPackageLabel marks the packages applied from the cluster config, so the ones
+// removed from it can be deleted when the cluster is upgraded. Its value is the cluster name.
+const ClusterConfigPackageLabel = "anywhere.eks.amazonaws.com/cluster-config"
+
 type PackageController interface {
 	InstallController(ctx context.Context) error
 	IsInstalled(ctx context.Context) bool
@@ -70,13 +81,106 @@ func (pi *Installer) installPackagesController(ctx context.Context) error {
 }
 
 func (pi *Installer) installPackages(ctx context.Context) error {
-	if pi.packagesLocation == "" {
-		return nil
+	kubeConfig := kubeconfig.FromClusterName(pi.spec.Cluster.Name)
+	if pi.packagesLocation != "" {
+		err := pi.packageClient.CreatePackages(ctx, pi.packagesLocation, kubeConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	return pi.applyClusterConfigPackages(ctx, kubeConfig)
+}
+
+// ReconcileCuratedPackages makes the packages in the cluster match the ones declared in the cluster config:
+// it applies the declared packages and deletes the ones previously applied from the cluster config that
+// are not declared anymore. If the package controller is not installed yet, it's installed along
+// with the declared packages.
+func (pi *Installer) ReconcileCuratedPackages(ctx context.Context) error {
+	if !pi.packageController.IsInstalled(ctx) {
+		if len(pi.spec.Packages) == 0 {
+			return nil
+		}
+		return pi.InstallCuratedPackages(ctx)
 	}
+
+	logger.Info("Reconciling curated packages from cluster config")
 	kubeConfig := kubeconfig.FromClusterName(pi.spec.Cluster.Name)
-	err := pi.packageClient.CreatePackages(ctx, pi.packagesLocation, kubeConfig)
-	if err != nil {
+	if err := pi.applyClusterConfigPackages(ctx, kubeConfig); err != nil {
 		return err
 	}
+
+	return pi.deleteRemovedClusterConfigPackages(ctx, kubeConfig)
+}
+
+func (pi *Installer) applyClusterConfigPackages(ctx context.Context, kubeConfig string) error {
+	if len(pi.spec.Packages) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(pi.spec.Packages))
+	for name := range pi.spec.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resources := make([][]byte, 0, len(names))
+	for _, name := range names {
+		p := pi.spec.Packages[name].DeepCopy()
+		if p.Namespace == "" {
+			p.Namespace = cluster.PackagesNamespace(pi.spec.Cluster.Name)
+		}
+		if p.Labels == nil {
+			p.Labels = map[string]string{}
+		}
+		p.Labels[ClusterConfigPackageLabel] = pi.spec.Cluster.Name
+		content, err := yaml.Marshal(NewDisplayablePackage(p))
+		if err != nil {
+			return fmt.Errorf("marshalling package %s: %v", name, err)
+		}
+		resources = append(resources, content)
+	}
+
+	logger.V(3).Info("Applying curated packages from cluster config", "packages", names)
+	params := []string{"apply", "-f", "-", "--kubeconfig", kubeConfig}
+	if _, err := pi.kubectl.ExecuteFromYaml(ctx, templater.AppendYamlResources(resources...), params...); err != nil {
+		return fmt.Errorf("applying curated packages from cluster config: %v", err)
+	}
+
+	return nil
+}
+
+func (pi *Installer) deleteRemovedClusterConfigPackages(ctx context.Context, kubeConfig string) error {
+	namespace := cluster.PackagesNamespace(pi.spec.Cluster.Name)
+	params := []string{
+		"get", "packages", "-o", "json", "--kubeconfig", kubeConfig, "--namespace", namespace,
+		"--selector", fmt.Sprintf("%s=%s", ClusterConfigPackageLabel, pi.spec.Cluster.Name),
+	}
+	stdOut, err := pi.kubectl.ExecuteCommand(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("getting curated packages applied from cluster config: %v", err)
+	}
+
+	list := &packagesv1.PackageList{}
+	if err := json.Unmarshal(stdOut.Bytes(), list); err != nil {
+		return fmt.Errorf("unmarshaling curated packages applied from cluster config: %v", err)
+	}
+
+	var removed []string
+	for _, p := range list.Items {
+		if _, ok := pi.spec.Packages[p.Name]; !ok {
+			removed = append(removed, p.Name)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	logger.Info("Deleting curated packages removed from cluster config", "packages", removed)
+	params = append([]string{"delete", "packages", "--kubeconfig", kubeConfig, "--namespace", namespace}, removed...)
+	if _, err := pi.kubectl.ExecuteCommand(ctx, params...); err != nil {
+		return fmt.Errorf("deleting curated packages removed from cluster config: %v", err)
+	}
+
 	return nil
 }
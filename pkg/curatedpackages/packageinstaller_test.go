@@ -1,6 +1,7 @@
 package curatedpackages_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
@@ -108,3 +110,109 @@ func TestPackageInstallerFailWhenPackageFails(t *testing.T) {
 	err := tt.command.InstallCuratedPackages(tt.ctx)
 	tt.Expect(err).NotTo(BeNil())
 }
+
+func (tt *packageInstallerTest) withClusterConfigPackages(names ...string) {
+	tt.spec.Packages = map[string]*packagesv1.Package{}
+	for _, name := range names {
+		tt.spec.Packages[name] = &packagesv1.Package{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Spec:       packagesv1.PackageSpec{PackageName: "harbor"},
+		}
+	}
+}
+
+func (tt *packageInstallerTest) expectApplyClusterConfigPackages(err error) {
+	params := []string{"apply", "-f", "-", "--kubeconfig", tt.kubeConfigPath}
+	tt.kubectlRunner.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), params).DoAndReturn(
+		func(_ context.Context, content []byte, _ ...string) (bytes.Buffer, error) {
+			tt.Expect(string(content)).To(ContainSubstring("namespace: eksa-packages-test-cluster"))
+			tt.Expect(string(content)).To(ContainSubstring(curatedpackages.ClusterConfigPackageLabel + ": test-cluster"))
+			return bytes.Buffer{}, err
+		},
+	)
+}
+
+func TestPackageInstallerSuccessWithClusterConfigPackages(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.withClusterConfigPackages("my-harbor")
+
+	tt.packageClient.EXPECT().CreatePackages(tt.ctx, tt.packagePath, tt.kubeConfigPath).Return(nil)
+	tt.kubectlRunner.EXPECT().HasResource(tt.ctx, "crd", "certificates.cert-manager.io", tt.kubeConfigPath, "cert-manager").Return(true, nil)
+	tt.packageControllerClient.EXPECT().InstallController(tt.ctx).Return(nil)
+	tt.expectApplyClusterConfigPackages(nil)
+
+	tt.Expect(tt.command.InstallCuratedPackages(tt.ctx)).To(Succeed())
+	tt.Expect(tt.spec.Packages["my-harbor"].Labels).To(BeEmpty())
+}
+
+func TestPackageInstallerReconcileControllerNotInstalledNoPackages(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+
+	tt.packageControllerClient.EXPECT().IsInstalled(tt.ctx).Return(false)
+
+	tt.Expect(tt.command.ReconcileCuratedPackages(tt.ctx)).To(Succeed())
+}
+
+func TestPackageInstallerReconcileControllerNotInstalled(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.withClusterConfigPackages("my-harbor")
+
+	tt.packageControllerClient.EXPECT().IsInstalled(tt.ctx).Return(false)
+	tt.packageClient.EXPECT().CreatePackages(tt.ctx, tt.packagePath, tt.kubeConfigPath).Return(nil)
+	tt.kubectlRunner.EXPECT().HasResource(tt.ctx, "crd", "certificates.cert-manager.io", tt.kubeConfigPath, "cert-manager").Return(true, nil)
+	tt.packageControllerClient.EXPECT().InstallController(tt.ctx).Return(nil)
+	tt.expectApplyClusterConfigPackages(nil)
+
+	tt.Expect(tt.command.ReconcileCuratedPackages(tt.ctx)).To(Succeed())
+}
+
+func TestPackageInstallerReconcileDeletesRemovedPackages(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.withClusterConfigPackages("my-harbor")
+
+	tt.packageControllerClient.EXPECT().IsInstalled(tt.ctx).Return(true)
+	tt.expectApplyClusterConfigPackages(nil)
+	getParams := []string{
+		"get", "packages", "-o", "json", "--kubeconfig", tt.kubeConfigPath, "--namespace", "eksa-packages-test-cluster",
+		"--selector", curatedpackages.ClusterConfigPackageLabel + "=test-cluster",
+	}
+	tt.kubectlRunner.EXPECT().ExecuteCommand(tt.ctx, getParams).Return(
+		*bytes.NewBufferString(`{"items":[{"metadata":{"name":"my-harbor"}},{"metadata":{"name":"old-redis"}}]}`), nil,
+	)
+	deleteParams := []string{"delete", "packages", "--kubeconfig", tt.kubeConfigPath, "--namespace", "eksa-packages-test-cluster", "old-redis"}
+	tt.kubectlRunner.EXPECT().ExecuteCommand(tt.ctx, deleteParams).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.command.ReconcileCuratedPackages(tt.ctx)).To(Succeed())
+}
+
+func TestPackageInstallerReconcileNothingRemoved(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.withClusterConfigPackages("my-harbor")
+
+	tt.packageControllerClient.EXPECT().IsInstalled(tt.ctx).Return(true)
+	tt.expectApplyClusterConfigPackages(nil)
+	tt.kubectlRunner.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(
+		*bytes.NewBufferString(`{"items":[{"metadata":{"name":"my-harbor"}}]}`), nil,
+	)
+
+	tt.Expect(tt.command.ReconcileCuratedPackages(tt.ctx)).To(Succeed())
+}
+
+func TestPackageInstallerReconcileApplyFails(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+	tt.withClusterConfigPackages("my-harbor")
+
+	tt.packageControllerClient.EXPECT().IsInstalled(tt.ctx).Return(true)
+	tt.expectApplyClusterConfigPackages(errors.New("apply failed"))
+
+	tt.Expect(tt.command.ReconcileCuratedPackages(tt.ctx)).To(MatchError("applying curated packages from cluster config: apply failed"))
+}
+
+func TestPackageInstallerReconcileGetFails(t *testing.T) {
+	tt := newPackageInstallerTest(t)
+
+	tt.packageControllerClient.EXPECT().IsInstalled(tt.ctx).Return(true)
+	tt.kubectlRunner.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("get failed"))
+
+	tt.Expect(tt.command.ReconcileCuratedPackages(tt.ctx)).To(MatchError("getting curated packages applied from cluster config: get failed"))
+}
@@ -180,3 +180,72 @@ func TestPackageReaderReadChartsFromBundlesFailWhenWrongURI(t *testing.T) {
 
 	tt.Expect(images).To(BeEmpty())
 }
+
+func TestPackageReaderReadPackageImagesFromBundlesInvalidKubeVersion(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1",
+					PackageController: releasev1.PackageBundle{
+						Version: "test-version",
+						Controller: releasev1.Image{
+							URI: tt.registry + "/ctrl:v1",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := tt.command.ReadPackageImagesFromBundles(tt.ctx, bundles)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("unable to parse kubeversion 1")))
+}
+
+func TestPackageReaderReadPackageImagesFromBundlesFailWhenWrongBundle(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.21",
+					PackageController: releasev1.PackageBundle{
+						Version: "test-version",
+						Controller: releasev1.Image{
+							URI: "fake_registry/fake_env/ctrl:v1",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := tt.command.ReadPackageImagesFromBundles(tt.ctx, bundles)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("reading images from package bundle fake_registry/fake_env/eks-anywhere-packages-bundles:v1-21-latest")))
+}
+
+func TestPackageReaderReadPackageChartsFromBundlesFailWhenWrongURI(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.21",
+					PackageController: releasev1.PackageBundle{
+						Version: "test-version",
+						Controller: releasev1.Image{
+							URI: "fake_registry/fake_env/ctrl:v1",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := tt.command.ReadPackageChartsFromBundles(tt.ctx, bundles)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("reading charts from package bundle fake_registry/fake_env/eks-anywhere-packages-bundles:v1-21-latest")))
+}
@@ -74,6 +74,46 @@ func (r *PackageReader) ReadChartsFromBundles(ctx context.Context, b *releasev1.
 	return images
 }
 
+// ReadPackageImagesFromBundles returns the package controller images and the images of all the curated packages
+// in the package bundles referenced by b, without the EKS-A core images.
+func (r *PackageReader) ReadPackageImagesFromBundles(ctx context.Context, b *releasev1.Bundles) ([]releasev1.Image, error) {
+	var images []releasev1.Image
+	for _, vb := range b.Spec.VersionsBundles {
+		images = append(images, vb.PackageController.Controller, vb.PackageController.TokenRefresher)
+		artifact, err := GetPackageBundleRef(vb)
+		if err != nil {
+			return nil, err
+		}
+		packageImages, err := r.fetchImagesFromBundle(ctx, vb, artifact)
+		if err != nil {
+			return nil, fmt.Errorf("reading images from package bundle %s: %v", artifact, err)
+		}
+		images = append(images, packageImages...)
+	}
+
+	return images, nil
+}
+
+// ReadPackageChartsFromBundles returns the package controller chart and the charts of all the curated packages
+// in the package bundles referenced by b, without the EKS-A core charts.
+func (r *PackageReader) ReadPackageChartsFromBundles(ctx context.Context, b *releasev1.Bundles) ([]releasev1.Image, error) {
+	var charts []releasev1.Image
+	for _, vb := range b.Spec.VersionsBundles {
+		charts = append(charts, vb.PackageController.HelmChart)
+		artifact, err := GetPackageBundleRef(vb)
+		if err != nil {
+			return nil, err
+		}
+		packagesHelmChart, err := fetchPackagesHelmChart(ctx, vb, artifact)
+		if err != nil {
+			return nil, fmt.Errorf("reading charts from package bundle %s: %v", artifact, err)
+		}
+		charts = append(charts, packagesHelmChart...)
+	}
+
+	return charts, nil
+}
+
 func fetchPackagesHelmChart(ctx context.Context, versionsBundle releasev1.VersionsBundle, artifact string) ([]releasev1.Image, error) {
 	data, err := PullLatestBundle(ctx, artifact)
 	if err != nil {
@@ -0,0 +1,130 @@
+package curatedpackages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+const (
+	rootField                    = "(root)"
+	additionalPropertyNotAllowed = "additional_property_not_allowed"
+)
+
+// ValidatePackages validates the configuration of each package against the json schema
+// published in the bundle for the package version.
+func (pc *PackageClient) ValidatePackages(packages []packagesv1.Package) error {
+	for _, p := range packages {
+		bp, err := pc.GetPackageFromBundle(p.Spec.PackageName)
+		if err != nil {
+			return fmt.Errorf("validating package %s: %v", p.Name, err)
+		}
+		if err := ValidatePackageConfig(bp, p.Spec.PackageVersion, p.Spec.Config); err != nil {
+			return fmt.Errorf("validating package %s: %v", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidatePackageConfig validates a package yaml configuration against the json schema of the package version.
+// When the version is empty, the first version in the bundle is used. Packages without schema are not validated.
+// Keys are only reported as unknown for objects whose schema sets additionalProperties to false.
+// All the invalid and unknown keys are reported in a single error, with their full path.
+func ValidatePackageConfig(bp *packagesv1.BundlePackage, version string, config string) error {
+	schema, err := packageSchema(bp, version)
+	if err != nil {
+		return err
+	}
+	if len(schema) == 0 || strings.TrimSpace(config) == "" {
+		return nil
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(config), &values); err != nil {
+		return fmt.Errorf("parsing package configuration: %v", err)
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewGoLoader(values))
+	if err != nil {
+		return fmt.Errorf("validating package configuration against schema: %v", err)
+	}
+
+	problems := map[string]struct{}{}
+	for _, e := range result.Errors() {
+		if e.Type() == additionalPropertyNotAllowed {
+			problems[unknownKeyProblem(fieldPath(e.Field(), e.Details()["property"]))] = struct{}{}
+			continue
+		}
+		problems[fmt.Sprintf("%s: %s", fieldPath(e.Field(), nil), e.Description())] = struct{}{}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(problems))
+	for p := range problems {
+		messages = append(messages, p)
+	}
+	sort.Strings(messages)
+
+	return fmt.Errorf("invalid configuration for package %s:\n\t%s", bp.Name, strings.Join(messages, "\n\t"))
+}
+
+func packageSchema(bp *packagesv1.BundlePackage, version string) ([]byte, error) {
+	versions := bp.Source.Versions
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	selected := versions[0]
+	if version != "" {
+		found := false
+		for _, v := range versions {
+			if v.Name == version || v.Digest == version {
+				selected, found = v, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("version %s of package %s not found in bundle", version, bp.Name)
+		}
+	}
+	if selected.Schema == "" {
+		return nil, nil
+	}
+
+	withVersion := bp.DeepCopy()
+	withVersion.Source.Versions = []packagesv1.SourceVersion{selected}
+	schema, err := withVersion.GetJsonSchema()
+	if err != nil {
+		return nil, fmt.Errorf("reading schema for package %s: %v", bp.Name, err)
+	}
+
+	return schema, nil
+}
+
+func fieldPath(parent string, key interface{}) string {
+	if parent == rootField {
+		parent = ""
+	}
+	if key == nil {
+		if parent == "" {
+			return rootField
+		}
+		return parent
+	}
+	if parent == "" {
+		return fmt.Sprintf("%v", key)
+	}
+	return fmt.Sprintf("%s.%v", parent, key)
+}
+
+func unknownKeyProblem(path string) string {
+	return fmt.Sprintf("%s: unknown key", path)
+}
@@ -0,0 +1,127 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+const harborSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"properties": {
+		"externalURL": {"type": "string"},
+		"expose": {
+			"type": "object",
+			"properties": {
+				"tls": {
+					"type": "object",
+					"additionalProperties": false,
+					"properties": {
+						"enabled": {"type": "boolean"}
+					}
+				}
+			}
+		},
+		"labels": {
+			"type": "object",
+			"properties": {},
+			"additionalProperties": {"type": "string"}
+		}
+	}
+}`
+
+func encodeSchema(t *testing.T, schema string) string {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(schema)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func harborBundlePackage(t *testing.T) *packagesv1.BundlePackage {
+	return &packagesv1.BundlePackage{
+		Name: "harbor",
+		Source: packagesv1.BundlePackageSource{
+			Versions: []packagesv1.SourceVersion{
+				{Name: "2.5.0", Schema: encodeSchema(t, harborSchema)},
+				{Name: "2.4.0"},
+			},
+		},
+	}
+}
+
+func TestValidatePackageConfigValid(t *testing.T) {
+	g := NewWithT(t)
+	config := "externalURL: https://harbor.local\nexpose:\n  tls:\n    enabled: true\nlabels:\n  team: a\n"
+
+	g.Expect(curatedpackages.ValidatePackageConfig(harborBundlePackage(t), "", config)).To(Succeed())
+}
+
+func TestValidatePackageConfigEmptyConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(curatedpackages.ValidatePackageConfig(harborBundlePackage(t), "2.5.0", "")).To(Succeed())
+}
+
+func TestValidatePackageConfigNoSchema(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(curatedpackages.ValidatePackageConfig(harborBundlePackage(t), "2.4.0", "anything: goes")).To(Succeed())
+}
+
+func TestValidatePackageConfigUnknownVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(curatedpackages.ValidatePackageConfig(harborBundlePackage(t), "1.0.0", "")).To(MatchError("version 1.0.0 of package harbor not found in bundle"))
+}
+
+func TestValidatePackageConfigInvalidKeys(t *testing.T) {
+	g := NewWithT(t)
+	config := "externalUrl: https://harbor.local\nexpose:\n  tls:\n    enabled: \"yes\"\n    enable: true\n"
+
+	err := curatedpackages.ValidatePackageConfig(harborBundlePackage(t), "2.5.0", config)
+	g.Expect(err).To(MatchError(
+		"invalid configuration for package harbor:\n" +
+			"\texpose.tls.enable: unknown key\n" +
+			"\texpose.tls.enabled: Invalid type. Expected: boolean, given: string",
+	))
+}
+
+func TestValidatePackageConfigUndeclaredKeysAllowed(t *testing.T) {
+	g := NewWithT(t)
+	config := "externalUrl: https://harbor.local\nexpose:\n  ingress:\n    host: harbor.local\n"
+
+	g.Expect(curatedpackages.ValidatePackageConfig(harborBundlePackage(t), "2.5.0", config)).To(Succeed())
+}
+
+func TestValidatePackageConfigInvalidYaml(t *testing.T) {
+	g := NewWithT(t)
+
+	err := curatedpackages.ValidatePackageConfig(harborBundlePackage(t), "", "not: [valid")
+	g.Expect(err).To(MatchError(ContainSubstring("parsing package configuration")))
+}
+
+func TestValidatePackagesNotInBundle(t *testing.T) {
+	g := NewWithT(t)
+	client := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(&packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{Packages: []packagesv1.BundlePackage{*harborBundlePackage(t)}},
+	}))
+	packages := []packagesv1.Package{
+		{Spec: packagesv1.PackageSpec{PackageName: "harbor", Config: "externalURL: a"}},
+		{Spec: packagesv1.PackageSpec{PackageName: "redis"}},
+	}
+	packages[1].Name = "my-redis"
+
+	g.Expect(client.ValidatePackages(packages)).To(MatchError("validating package my-redis: package redis not found"))
+}
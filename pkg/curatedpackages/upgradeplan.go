@@ -0,0 +1,171 @@
+package curatedpackages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	versionNotAvailable     = "not available"
+	convergedCheckInterval  = 5 * time.Second
+	convergedPackageDetails = "%s (state: %s, current version: %s, expected version: %s)"
+	// packageChangelogURL is the documentation page of a curated package version.
+	// It takes the package name and the semantic version of the package.
+	packageChangelogURL = "https://anywhere.eks.amazonaws.com/docs/reference/packagespec/%s/v%s/"
+)
+
+// packageSemver matches the semantic version at the start of a package source version name,
+// for example 2.5.1 in v2.5.1-4324383d8c5383bded5f7378efb98b4d50af827b.
+var packageSemver = regexp.MustCompile(`^v?(\d+\.\d+\.\d+)`)
+
+// PackageUpgrade describes the upgrade of an installed package between two bundles.
+type PackageUpgrade struct {
+	Name           string
+	PackageName    string
+	CurrentVersion string
+	TargetVersion  string
+	Changelog      string
+}
+
+// PlanPackagesUpgrade returns, for each installed package, the current version and the version it will be
+// upgraded to when the target bundle becomes active.
+func PlanPackagesUpgrade(packages []packagesv1.Package, current, target *packagesv1.PackageBundle) []PackageUpgrade {
+	plan := make([]PackageUpgrade, 0, len(packages))
+	for _, p := range packages {
+		upgrade := PackageUpgrade{
+			Name:           p.Name,
+			PackageName:    p.Spec.PackageName,
+			CurrentVersion: p.Status.CurrentVersion,
+			TargetVersion:  versionNotAvailable,
+		}
+		if upgrade.CurrentVersion == "" {
+			upgrade.CurrentVersion = versionNotAvailable
+			if v, ok := bundleVersion(current, p); ok {
+				upgrade.CurrentVersion = v.Name
+			}
+		}
+		if v, ok := bundleVersion(target, p); ok {
+			upgrade.TargetVersion = v.Name
+			upgrade.Changelog = changelog(p.Spec.PackageName, v.Name)
+		}
+		plan = append(plan, upgrade)
+	}
+
+	sort.Slice(plan, func(i, j int) bool {
+		return plan[i].Name < plan[j].Name
+	})
+
+	return plan
+}
+
+// DisplayPackagesUpgradePlan pretty-prints a table with the packages upgrade plan.
+func DisplayPackagesUpgradePlan(w io.Writer, plan []PackageUpgrade) error {
+	lines := append([][]string{}, upgradePlanHeaderLines...)
+	for _, u := range plan {
+		link := u.Changelog
+		if link == "" {
+			link = "-"
+		}
+		lines = append(lines, []string{u.Name, u.PackageName, u.CurrentVersion, u.TargetVersion, link})
+	}
+
+	tw := newCPTabwriter(w, nil)
+	defer tw.Flush()
+	return tw.writeTable(lines)
+}
+
+var upgradePlanHeaderLines = [][]string{
+	{"NAME", "PACKAGE", "CURRENT VERSION", "TARGET VERSION", "CHANGELOG"},
+}
+
+// GetInstalledPackages returns the packages installed in a cluster.
+func (pc *PackageClient) GetInstalledPackages(ctx context.Context, kubeConfig, clusterName string) ([]packagesv1.Package, error) {
+	params := []string{"get", "packages", "-o", "json", "--kubeconfig", kubeConfig, "--namespace", constants.EksaPackagesName + "-" + clusterName}
+	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting installed packages: %v", err)
+	}
+
+	list := &packagesv1.PackageList{}
+	if err := json.Unmarshal(stdOut.Bytes(), list); err != nil {
+		return nil, fmt.Errorf("unmarshaling installed packages: %v", err)
+	}
+	return list.Items, nil
+}
+
+// WaitForPackagesConverged waits until all the packages installed in the cluster that are part of the bundle
+// are installed with the version the bundle provides for them.
+func (pc *PackageClient) WaitForPackagesConverged(ctx context.Context, bundle *packagesv1.PackageBundle, kubeConfig, clusterName string, timeout time.Duration) error {
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, convergedCheckInterval
+	}))
+
+	err := r.Retry(func() error {
+		packages, err := pc.GetInstalledPackages(ctx, kubeConfig, clusterName)
+		if err != nil {
+			return err
+		}
+
+		var pending []string
+		for _, p := range packages {
+			v, ok := bundleVersion(bundle, p)
+			if !ok {
+				continue
+			}
+			if p.Status.State != packagesv1.StateInstalled || (p.Status.CurrentVersion != v.Name && p.Status.CurrentVersion != v.Digest) {
+				pending = append(pending, fmt.Sprintf(convergedPackageDetails, p.Name, p.Status.State, p.Status.CurrentVersion, v.Name))
+			}
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("packages not converged: %s", strings.Join(pending, ", "))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for packages to converge to bundle %s: %v", bundle.Name, err)
+	}
+
+	return nil
+}
+
+// bundleVersion returns the version of a package provided by a bundle: the pinned version
+// if the package specifies one or the first one otherwise.
+func bundleVersion(bundle *packagesv1.PackageBundle, p packagesv1.Package) (packagesv1.SourceVersion, bool) {
+	if bundle == nil {
+		return packagesv1.SourceVersion{}, false
+	}
+	for _, bp := range bundle.Spec.Packages {
+		if !strings.EqualFold(bp.Name, p.Spec.PackageName) || len(bp.Source.Versions) == 0 {
+			continue
+		}
+		if p.Spec.PackageVersion == "" {
+			return bp.Source.Versions[0], true
+		}
+		for _, v := range bp.Source.Versions {
+			if v.Name == p.Spec.PackageVersion || v.Digest == p.Spec.PackageVersion {
+				return v, true
+			}
+		}
+	}
+	return packagesv1.SourceVersion{}, false
+}
+
+// changelog returns the link to the documentation of the given version of a package, derived from the
+// package name and the semantic version in the source version name. It's empty if the version isn't semantic.
+func changelog(packageName, version string) string {
+	m := packageSemver.FindStringSubmatch(version)
+	if m == nil {
+		return ""
+	}
+	return fmt.Sprintf(packageChangelogURL, strings.ToLower(packageName), m[1])
+}
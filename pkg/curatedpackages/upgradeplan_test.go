@@ -0,0 +1,146 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+)
+
+func newPlanBundle(name string, versions ...string) *packagesv1.PackageBundle {
+	sourceVersions := make([]packagesv1.SourceVersion, 0, len(versions))
+	for _, v := range versions {
+		sourceVersions = append(sourceVersions, packagesv1.SourceVersion{Name: v, Digest: "sha256:" + v})
+	}
+	return &packagesv1.PackageBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				{Name: "harbor", Source: packagesv1.BundlePackageSource{Versions: sourceVersions}},
+			},
+		},
+	}
+}
+
+func newInstalledPackage(name, packageName, currentVersion string, state packagesv1.StateEnum) packagesv1.Package {
+	return packagesv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "eksa-packages-billy"},
+		Spec:       packagesv1.PackageSpec{PackageName: packageName},
+		Status:     packagesv1.PackageStatus{CurrentVersion: currentVersion, State: state},
+	}
+}
+
+func TestPlanPackagesUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	current := newPlanBundle("v1-21-1000", "2.4.2")
+	target := newPlanBundle("v1-21-1001", "2.5.0")
+	installed := []packagesv1.Package{
+		newInstalledPackage("my-harbor", "harbor", "", ""),
+		newInstalledPackage("my-flux", "flux", "0.1.0", packagesv1.StateInstalled),
+	}
+
+	plan := curatedpackages.PlanPackagesUpgrade(installed, current, target)
+	g.Expect(plan).To(Equal([]curatedpackages.PackageUpgrade{
+		{Name: "my-flux", PackageName: "flux", CurrentVersion: "0.1.0", TargetVersion: "not available"},
+		{Name: "my-harbor", PackageName: "harbor", CurrentVersion: "2.4.2", TargetVersion: "2.5.0", Changelog: "https://anywhere.eks.amazonaws.com/docs/reference/packagespec/harbor/v2.5.0/"},
+	}))
+}
+
+func TestPlanPackagesUpgradePinnedVersion(t *testing.T) {
+	g := NewWithT(t)
+	target := newPlanBundle("v1-21-1001", "2.5.0", "2.4.2")
+	p := newInstalledPackage("my-harbor", "harbor", "2.4.2", packagesv1.StateInstalled)
+	p.Spec.PackageVersion = "2.4.2"
+
+	plan := curatedpackages.PlanPackagesUpgrade([]packagesv1.Package{p}, nil, target)
+	g.Expect(plan).To(HaveLen(1))
+	g.Expect(plan[0].TargetVersion).To(Equal("2.4.2"))
+	g.Expect(plan[0].Changelog).To(Equal("https://anywhere.eks.amazonaws.com/docs/reference/packagespec/harbor/v2.4.2/"))
+}
+
+func TestPlanPackagesUpgradeChangelogFromSourceVersion(t *testing.T) {
+	g := NewWithT(t)
+	target := newPlanBundle("v1-21-1001", "v2.5.1-4324383d8c5383bded5f7378efb98b4d50af827b")
+	p := newInstalledPackage("my-harbor", "harbor", "2.5.0", packagesv1.StateInstalled)
+
+	plan := curatedpackages.PlanPackagesUpgrade([]packagesv1.Package{p}, nil, target)
+	g.Expect(plan).To(HaveLen(1))
+	g.Expect(plan[0].Changelog).To(Equal("https://anywhere.eks.amazonaws.com/docs/reference/packagespec/harbor/v2.5.1/"))
+}
+
+func TestPlanPackagesUpgradeNoChangelogForNonSemanticVersion(t *testing.T) {
+	g := NewWithT(t)
+	target := newPlanBundle("v1-21-1001", "latest")
+	p := newInstalledPackage("my-harbor", "harbor", "2.5.0", packagesv1.StateInstalled)
+
+	plan := curatedpackages.PlanPackagesUpgrade([]packagesv1.Package{p}, nil, target)
+	g.Expect(plan).To(HaveLen(1))
+	g.Expect(plan[0].TargetVersion).To(Equal("latest"))
+	g.Expect(plan[0].Changelog).To(BeEmpty())
+}
+
+func TestDisplayPackagesUpgradePlan(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	plan := []curatedpackages.PackageUpgrade{
+		{Name: "my-harbor", PackageName: "harbor", CurrentVersion: "2.4.2", TargetVersion: "2.5.0", Changelog: "https://example.com/harbor"},
+		{Name: "my-flux", PackageName: "flux", CurrentVersion: "0.1.0", TargetVersion: "not available"},
+	}
+
+	g.Expect(curatedpackages.DisplayPackagesUpgradePlan(buf, plan)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring("CHANGELOG"))
+	g.Expect(buf.String()).To(MatchRegexp(`my-harbor\s+harbor\s+2\.4\.2\s+2\.5\.0\s+https://example\.com/harbor`))
+	g.Expect(buf.String()).To(MatchRegexp(`my-flux\s+flux\s+0\.1\.0\s+not available\s+-`))
+}
+
+func expectGetInstalledPackages(k *mocks.MockKubectlRunner, ctx context.Context, out string, err error) {
+	params := []string{"get", "packages", "-o", "json", "--kubeconfig", "kubeconfig", "--namespace", "eksa-packages-billy"}
+	k.EXPECT().ExecuteCommand(ctx, params).Return(*bytes.NewBufferString(out), err)
+}
+
+func TestGetInstalledPackages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	expectGetInstalledPackages(k, ctx, `{"items":[{"metadata":{"name":"my-harbor"},"spec":{"packageName":"harbor"}}]}`, nil)
+	client := curatedpackages.NewPackageClient(k)
+
+	packages, err := client.GetInstalledPackages(ctx, "kubeconfig", "billy")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(packages).To(HaveLen(1))
+	g.Expect(packages[0].Spec.PackageName).To(Equal("harbor"))
+}
+
+func TestGetInstalledPackagesError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	expectGetInstalledPackages(k, ctx, "", errors.New("connection refused"))
+	client := curatedpackages.NewPackageClient(k)
+
+	_, err := client.GetInstalledPackages(ctx, "kubeconfig", "billy")
+	g.Expect(err).To(MatchError("getting installed packages: connection refused"))
+}
+
+func TestWaitForPackagesConverged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	expectGetInstalledPackages(k, ctx, `{"items":[
+		{"metadata":{"name":"my-harbor"},"spec":{"packageName":"harbor"},"status":{"state":"installed","currentVersion":"sha256:2.4.2"}},
+		{"metadata":{"name":"my-flux"},"spec":{"packageName":"flux"},"status":{"state":"installing"}}
+	]}`, nil)
+	client := curatedpackages.NewPackageClient(k)
+
+	err := client.WaitForPackagesConverged(ctx, newPlanBundle("v1-21-1000", "2.4.2"), "kubeconfig", "billy", time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	cloudstackclient "github.com/aws/eks-anywhere/pkg/clients/cloudstack"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
@@ -21,6 +22,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
@@ -51,7 +53,9 @@ type Dependencies struct {
 	DockerClient              *executables.Docker
 	Kubectl                   *executables.Kubectl
 	Govc                      *executables.Govc
+	VSphereClient             vsphere.ProviderGovcClient
 	Cmk                       *executables.Cmk
+	CloudStackClient          cloudstack.ProviderCmkClient
 	SnowAwsClientRegistry     *snow.AwsClientRegistry
 	SnowConfigManager         *snow.ConfigManager
 	Writer                    filewriter.FileWriter
@@ -273,12 +277,12 @@ func (f *Factory) WithExecutableBuilder() *Factory {
 	return f
 }
 
-func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, skipIpCheck bool, hardwareCSVPath string, force bool, tinkerbellBootstrapIp string) *Factory {
+func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, hardwareCSVPath string, force bool, tinkerbellBootstrapIp string) *Factory {
 	switch clusterConfig.Spec.DatacenterRef.Kind {
 	case v1alpha1.VSphereDatacenterKind:
-		f.WithKubectl().WithGovc().WithWriter().WithCAPIClusterResourceSetManager()
+		f.WithKubectl().WithVSphereClient().WithWriter().WithCAPIClusterResourceSetManager()
 	case v1alpha1.CloudStackDatacenterKind:
-		f.WithKubectl().WithCmk().WithWriter()
+		f.WithKubectl().WithCloudStackClient().WithWriter()
 	case v1alpha1.DockerDatacenterKind:
 		f.WithDocker().WithKubectl()
 	case v1alpha1.TinkerbellDatacenterKind:
@@ -312,11 +316,10 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				datacenterConfig,
 				machineConfigs,
 				clusterConfig,
-				f.dependencies.Govc,
+				f.dependencies.VSphereClient,
 				f.dependencies.Kubectl,
 				f.dependencies.Writer,
 				time.Now,
-				skipIpCheck,
 				f.dependencies.ResourceSetManager,
 			)
 
@@ -331,13 +334,12 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				return fmt.Errorf("unable to get machine config from file %s: %v", clusterConfigFile, err)
 			}
 
-			f.dependencies.Provider = cloudstack.NewProvider(datacenterConfig, machineConfigs, clusterConfig, f.dependencies.Kubectl, f.dependencies.Cmk, f.dependencies.Writer, time.Now, skipIpCheck, logger.Get())
+			f.dependencies.Provider = cloudstack.NewProvider(datacenterConfig, machineConfigs, clusterConfig, f.dependencies.Kubectl, f.dependencies.CloudStackClient, f.dependencies.Writer, time.Now, logger.Get())
 
 		case v1alpha1.SnowDatacenterKind:
 			f.dependencies.Provider = snow.NewProvider(
 				f.dependencies.UnAuthKubeClient,
 				f.dependencies.SnowConfigManager,
-				skipIpCheck,
 			)
 
 		case v1alpha1.TinkerbellDatacenterKind:
@@ -374,7 +376,6 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 				tinkerbellIp,
 				time.Now,
 				force,
-				skipIpCheck,
 			)
 			if err != nil {
 				return err
@@ -429,7 +430,16 @@ func (f *Factory) WithKubectl() *Factory {
 			return nil
 		}
 
-		f.dependencies.Kubectl = f.executablesConfig.builder.BuildKubectlExecutable()
+		var opts []executables.KubectlConfigOpt
+		if features.IsActive(features.InProcessKubectl()) {
+			client := kubernetes.NewRuntimeClient()
+			if err := client.Init(); err != nil {
+				return fmt.Errorf("initializing in-process kubernetes client: %v", err)
+			}
+			opts = append(opts, executables.WithKubernetesAPIClient(client))
+		}
+
+		f.dependencies.Kubectl = f.executablesConfig.builder.BuildKubectlExecutable(opts...)
 		return nil
 	})
 
@@ -453,6 +463,34 @@ func (f *Factory) WithGovc() *Factory {
 	return f
 }
 
+// WithVSphereClient builds the client used for vSphere operations. It talks to the vSphere APIs directly
+// if the native vSphere client feature is active, otherwise it uses the govc executable.
+func (f *Factory) WithVSphereClient() *Factory {
+	nativeClient := features.IsActive(features.VSphereNativeClient())
+	if !nativeClient {
+		f.WithGovc()
+	}
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.VSphereClient != nil {
+			return nil
+		}
+
+		if !nativeClient {
+			f.dependencies.VSphereClient = f.dependencies.Govc
+			return nil
+		}
+
+		client := govmomi.NewNativeClient()
+		f.dependencies.VSphereClient = client
+		f.dependencies.closers = append(f.dependencies.closers, client)
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithCmk() *Factory {
 	f.WithExecutableBuilder().WithWriter()
 
@@ -475,6 +513,37 @@ func (f *Factory) WithCmk() *Factory {
 	return f
 }
 
+// WithCloudStackClient builds the client used for CloudStack operations. It talks to the CloudStack API directly
+// if the native CloudStack client feature is active, otherwise it uses the cmk executable.
+func (f *Factory) WithCloudStackClient() *Factory {
+	nativeClient := features.IsActive(features.CloudStackNativeClient())
+	if !nativeClient {
+		f.WithCmk()
+	}
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.CloudStackClient != nil {
+			return nil
+		}
+
+		if !nativeClient {
+			f.dependencies.CloudStackClient = f.dependencies.Cmk
+			return nil
+		}
+
+		execConfig, err := decoder.ParseCloudStackSecret()
+		if err != nil {
+			return fmt.Errorf("building cloudstack client: %v", err)
+		}
+
+		f.dependencies.CloudStackClient = cloudstackclient.NewNativeClient(execConfig.Profiles)
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithSnowConfigManager() *Factory {
 	f.WithAwsSnow().WithWriter()
 
@@ -864,6 +933,7 @@ func (f *Factory) WithPackageControllerClient(spec *cluster.Spec) *Factory {
 			curatedpackages.WithHTTPSProxy(httpsProxy),
 			curatedpackages.WithNoProxy(noProxy),
 			curatedpackages.WithManagementClusterName(getManagementClusterName(spec)),
+			curatedpackages.WithRegistryMirror(spec.Cluster.RegistryMirror()),
 		)
 		return nil
 	})
@@ -1053,7 +1123,7 @@ func (f *Factory) WithUnAuthKubeClient() *Factory {
 }
 
 func (f *Factory) WithVSphereValidator() *Factory {
-	f.WithGovc()
+	f.WithVSphereClient()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.VSphereValidator != nil {
@@ -1061,7 +1131,7 @@ func (f *Factory) WithVSphereValidator() *Factory {
 		}
 		vcb := govmomi.NewVMOMIClientBuilder()
 		v := vsphere.NewValidator(
-			f.dependencies.Govc,
+			f.dependencies.VSphereClient,
 			&networkutils.DefaultNetClient{},
 			vcb,
 		)
@@ -1074,14 +1144,14 @@ func (f *Factory) WithVSphereValidator() *Factory {
 }
 
 func (f *Factory) WithVSphereDefaulter() *Factory {
-	f.WithGovc()
+	f.WithVSphereClient()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.VSphereDefaulter != nil {
 			return nil
 		}
 
-		f.dependencies.VSphereDefaulter = vsphere.NewDefaulter(f.dependencies.Govc)
+		f.dependencies.VSphereDefaulter = vsphere.NewDefaulter(f.dependencies.VSphereClient)
 
 		return nil
 	})
@@ -11,10 +11,13 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	cloudstackclient "github.com/aws/eks-anywhere/pkg/clients/cloudstack"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -60,7 +63,7 @@ func TestFactoryBuildWithProvidervSphere(t *testing.T) {
 	tt := newTest(t, vsphere)
 	deps, err := dependencies.NewFactory().
 		WithLocalExecutables().
-		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
 		Build(context.Background())
 
 	tt.Expect(err).To(BeNil())
@@ -72,7 +75,7 @@ func TestFactoryBuildWithProviderTinkerbell(t *testing.T) {
 	tt := newTest(t, tinkerbell)
 	deps, err := dependencies.NewFactory().
 		WithLocalExecutables().
-		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
 		Build(context.Background())
 
 	tt.Expect(err).To(BeNil())
@@ -115,7 +118,7 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 		WithBootstrapper().
 		WithCliConfig(&tt.cliConfig).
 		WithClusterManager(tt.clusterSpec.Cluster).
-		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
 		WithGitOpsFlux(tt.clusterSpec.Cluster, tt.clusterSpec.FluxConfig, nil).
 		WithWriter().
 		WithEksdInstaller().
@@ -351,6 +354,66 @@ func TestFactoryBuildWithExecutablesUsingDocker(t *testing.T) {
 	tt.Expect(deps.Helm).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithVSphereClientGovc(t *testing.T) {
+	tt := newTest(t, vsphere)
+	features.ClearCache()
+	deps, err := dependencies.NewFactory().
+		UseExecutablesDockerClient(dummyDockerClient{}).
+		UseExecutableImage("myimage").
+		WithVSphereClient().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Govc).NotTo(BeNil())
+	tt.Expect(deps.VSphereClient).To(BeIdenticalTo(deps.Govc))
+}
+
+func TestFactoryBuildWithVSphereClientNative(t *testing.T) {
+	tt := newTest(t, vsphere)
+	t.Setenv(features.VSphereNativeClientEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	deps, err := dependencies.NewFactory().
+		WithVSphereClient().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Govc).To(BeNil())
+	tt.Expect(deps.VSphereClient).To(BeAssignableToTypeOf(&govmomi.NativeClient{}))
+}
+
+func TestFactoryBuildWithKubectlInProcess(t *testing.T) {
+	tt := newTest(t, vsphere)
+	t.Setenv(features.InProcessKubectlEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithKubectl().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Kubectl).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithCloudStackNativeClient(t *testing.T) {
+	configString := test.ReadFile(t, "testdata/cloudstack_config_multiple_profiles.ini")
+	encodedConfig := base64.StdEncoding.EncodeToString([]byte(configString))
+	t.Setenv(decoder.EksacloudStackCloudConfigB64SecretKey, encodedConfig)
+	t.Setenv(features.CloudStackNativeClientEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithCloudStackClient().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.CloudStackClient).To(BeAssignableToTypeOf(&cloudstackclient.NativeClient{}))
+	tt.Expect(deps.Cmk).To(BeNil())
+}
+
 type dummyDockerClient struct{}
 
 func (b dummyDockerClient) PullImage(ctx context.Context, image string) error {
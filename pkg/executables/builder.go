@@ -40,8 +40,8 @@ func (b *ExecutablesBuilder) BuildClusterCtlExecutable(writer filewriter.FileWri
 	return NewClusterctl(b.executableBuilder.Build(clusterCtlPath), writer)
 }
 
-func (b *ExecutablesBuilder) BuildKubectlExecutable() *Kubectl {
-	return NewKubectl(b.executableBuilder.Build(kubectlPath))
+func (b *ExecutablesBuilder) BuildKubectlExecutable(opts ...KubectlConfigOpt) *Kubectl {
+	return NewKubectl(b.executableBuilder.Build(kubectlPath), opts...)
 }
 
 func (b *ExecutablesBuilder) BuildGovcExecutable(writer filewriter.FileWriter, opts ...GovcOpt) *Govc {
@@ -42,12 +42,17 @@ func newClusterctlTest(t *testing.T) *clusterctlTest {
 	ctrl := gomock.NewController(t)
 	_, writer := test.NewWriter(t)
 	e := mockexecutables.NewMockExecutable(ctrl)
+	clusterName := "cluster-name"
+	// The clusterctl overrides layer is written to a folder named after the cluster in the working directory.
+	t.Cleanup(func() {
+		os.RemoveAll(clusterName)
+	})
 
 	return &clusterctlTest{
 		WithT: NewWithT(t),
 		ctx:   context.Background(),
 		cluster: &types.Cluster{
-			Name:           "cluster-name",
+			Name:           clusterName,
 			KubeconfigFile: "config/c.kubeconfig",
 		},
 		e:              e,
@@ -81,6 +81,11 @@ func (c *Cmk) ValidateTemplatePresent(ctx context.Context, profile string, domai
 }
 
 func (c *Cmk) ValidateServiceOfferingPresent(ctx context.Context, profile string, zoneId string, serviceOffering v1alpha1.CloudStackResourceIdentifier) error {
+	_, err := c.getServiceOffering(ctx, profile, zoneId, serviceOffering)
+	return err
+}
+
+func (c *Cmk) getServiceOffering(ctx context.Context, profile string, zoneId string, serviceOffering v1alpha1.CloudStackResourceIdentifier) (*cmkServiceOffering, error) {
 	command := newCmkCommand("list serviceofferings")
 	if len(serviceOffering.Id) > 0 {
 		applyCmkArgs(&command, withCloudStackId(serviceOffering.Id))
@@ -90,26 +95,26 @@ func (c *Cmk) ValidateServiceOfferingPresent(ctx context.Context, profile string
 	applyCmkArgs(&command, withCloudStackZoneId(zoneId))
 	result, err := c.exec(ctx, profile, command...)
 	if err != nil {
-		return fmt.Errorf("getting service offerings info - %s: %v", result.String(), err)
+		return nil, fmt.Errorf("getting service offerings info - %s: %v", result.String(), err)
 	}
 	if result.Len() == 0 {
-		return fmt.Errorf("service offering %s not found", serviceOffering)
+		return nil, fmt.Errorf("service offering %s not found", serviceOffering)
 	}
 
 	response := struct {
 		CmkServiceOfferings []cmkServiceOffering `json:"serviceoffering"`
 	}{}
 	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
-		return fmt.Errorf("parsing response into json: %v", err)
+		return nil, fmt.Errorf("parsing response into json: %v", err)
 	}
 	offerings := response.CmkServiceOfferings
 	if len(offerings) > 1 {
-		return fmt.Errorf("duplicate service offering %s found", serviceOffering)
+		return nil, fmt.Errorf("duplicate service offering %s found", serviceOffering)
 	} else if len(offerings) == 0 {
-		return fmt.Errorf("service offering %s not found", serviceOffering)
+		return nil, fmt.Errorf("service offering %s not found", serviceOffering)
 	}
 
-	return nil
+	return &offerings[0], nil
 }
 
 func (c *Cmk) ValidateDiskOfferingPresent(ctx context.Context, profile string, zoneId string, diskOffering v1alpha1.CloudStackResourceDiskOffering) error {
@@ -307,6 +312,68 @@ func (c *Cmk) ValidateNetworkPresent(ctx context.Context, profile string, domain
 	return nil
 }
 
+// ListZones returns the names of the zones available to profile.
+func (c *Cmk) ListZones(ctx context.Context, profile string) ([]string, error) {
+	return c.listNames(ctx, profile, "zone", newCmkCommand("list zones"))
+}
+
+// ListNetworks returns the names of the networks in zoneId available to profile.
+func (c *Cmk) ListNetworks(ctx context.Context, profile string, zoneId string) ([]string, error) {
+	command := newCmkCommand("list networks")
+	applyCmkArgs(&command, withCloudStackZoneId(zoneId))
+	return c.listNames(ctx, profile, "network", command)
+}
+
+// ListServiceOfferings returns the names of the service offerings in zoneId available to profile.
+func (c *Cmk) ListServiceOfferings(ctx context.Context, profile string, zoneId string) ([]string, error) {
+	command := newCmkCommand("list serviceofferings")
+	applyCmkArgs(&command, withCloudStackZoneId(zoneId))
+	return c.listNames(ctx, profile, "serviceoffering", command)
+}
+
+// ListTemplates returns the names of the templates in zoneId available to profile.
+func (c *Cmk) ListTemplates(ctx context.Context, profile string, zoneId string) ([]string, error) {
+	command := newCmkCommand("list templates")
+	applyCmkArgs(&command, appendArgs("templatefilter=all"), appendArgs("listall=true"), withCloudStackZoneId(zoneId))
+	return c.listNames(ctx, profile, "template", command)
+}
+
+// listNames runs a cmk list command and returns the names of the resources listed under key in
+// the response.
+func (c *Cmk) listNames(ctx context.Context, profile string, key string, command []string) ([]string, error) {
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return nil, fmt.Errorf("listing %ss - %s: %v", key, result.String(), err)
+	}
+	if result.Len() == 0 {
+		return nil, nil
+	}
+
+	response := map[string]json.RawMessage{}
+	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("parsing response into json: %v", err)
+	}
+
+	var resources []cmkResourceIdentifier
+	if raw, ok := response[key]; ok {
+		if err = json.Unmarshal(raw, &resources); err != nil {
+			return nil, fmt.Errorf("parsing %s response into json: %v", key, err)
+		}
+	}
+
+	names := make([]string, 0, len(resources))
+	seen := map[string]struct{}{}
+	for _, resource := range resources {
+		if _, ok := seen[resource.Name]; ok {
+			continue
+		}
+		seen[resource.Name] = struct{}{}
+		names = append(names, resource.Name)
+	}
+
+	return names, nil
+}
+
 func (c *Cmk) ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error {
 	// If account is not specified then no need to check its presence
 	if len(account) == 0 {
@@ -338,6 +405,84 @@ func (c *Cmk) ValidateAccountPresent(ctx context.Context, profile string, accoun
 	return nil
 }
 
+// GetServiceOffering returns the compute resources allocated by a service offering.
+func (c *Cmk) GetServiceOffering(ctx context.Context, profile string, zoneId string, serviceOffering v1alpha1.CloudStackResourceIdentifier) (*decoder.CloudStackServiceOffering, error) {
+	offering, err := c.getServiceOffering(ctx, profile, zoneId, serviceOffering)
+	if err != nil {
+		return nil, err
+	}
+	return &decoder.CloudStackServiceOffering{
+		CpuNumber: offering.CpuNumber,
+		CpuSpeed:  offering.CpuSpeed,
+		Memory:    offering.Memory,
+	}, nil
+}
+
+// ListAccounts returns the resources available to the accounts matching the given name and domain.
+// When no name is provided, it returns every account visible to the api key.
+func (c *Cmk) ListAccounts(ctx context.Context, profile string, account string, domainId string) ([]decoder.CloudStackAccount, error) {
+	command := newCmkCommand("list accounts")
+	if len(account) > 0 {
+		applyCmkArgs(&command, withCloudStackName(account))
+	}
+	if len(domainId) > 0 {
+		applyCmkArgs(&command, withCloudStackDomainId(domainId))
+	}
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return nil, fmt.Errorf("getting accounts info - %s: %v", result.String(), err)
+	}
+
+	response := struct {
+		CmkAccounts []cmkAccount `json:"account"`
+	}{}
+	if result.Len() > 0 {
+		if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+			return nil, fmt.Errorf("parsing response into json: %v", err)
+		}
+	}
+
+	accounts := make([]decoder.CloudStackAccount, 0, len(response.CmkAccounts))
+	for _, a := range response.CmkAccounts {
+		accounts = append(accounts, decoder.CloudStackAccount{
+			Name:            a.Name,
+			VmAvailable:     a.VmAvailable,
+			CpuAvailable:    a.CpuAvailable,
+			MemoryAvailable: a.MemoryAvailable,
+		})
+	}
+	return accounts, nil
+}
+
+// ListZoneCapacity returns the capacity of a zone. Listing capacity requires root admin permissions.
+func (c *Cmk) ListZoneCapacity(ctx context.Context, profile string, zoneId string) ([]decoder.CloudStackCapacity, error) {
+	command := newCmkCommand("list capacity")
+	applyCmkArgs(&command, withCloudStackZoneId(zoneId))
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return nil, fmt.Errorf("getting capacity info - %s: %v", result.String(), err)
+	}
+
+	response := struct {
+		CmkCapacities []cmkCapacity `json:"capacity"`
+	}{}
+	if result.Len() > 0 {
+		if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+			return nil, fmt.Errorf("parsing response into json: %v", err)
+		}
+	}
+
+	capacities := make([]decoder.CloudStackCapacity, 0, len(response.CmkCapacities))
+	for _, capacity := range response.CmkCapacities {
+		capacities = append(capacities, decoder.CloudStackCapacity{
+			Type:  capacity.Type,
+			Total: capacity.CapacityTotal,
+			Used:  capacity.CapacityUsed,
+		})
+	}
+	return capacities, nil
+}
+
 func NewCmk(executable Executable, writer filewriter.FileWriter, configs []decoder.CloudStackProfileConfig) *Cmk {
 	configMap := map[string]decoder.CloudStackProfileConfig{}
 	for _, config := range configs {
@@ -488,8 +633,17 @@ type cmkDomain struct {
 }
 
 type cmkAccount struct {
-	RoleType string `json:"roletype"`
-	Domain   string `json:"domain"`
-	Id       string `json:"id"`
-	Name     string `json:"name"`
+	RoleType        string `json:"roletype"`
+	Domain          string `json:"domain"`
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	VmAvailable     string `json:"vmavailable"`
+	CpuAvailable    string `json:"cpuavailable"`
+	MemoryAvailable string `json:"memoryavailable"`
+}
+
+type cmkCapacity struct {
+	Type          int   `json:"type"`
+	CapacityTotal int64 `json:"capacitytotal"`
+	CapacityUsed  int64 `json:"capacityused"`
 }
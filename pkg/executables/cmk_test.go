@@ -993,6 +993,120 @@ func TestCmkListOperations(t *testing.T) {
 	}
 }
 
+func TestCmkGetServiceOffering(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, []string{"-c", configFilePath, "list", "serviceofferings", fmt.Sprintf("name=\"%s\"", resourceName.Name), fmt.Sprintf("zoneid=\"%s\"", zoneId)}).
+		Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_serviceoffering_singular.json")), nil)
+
+	cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+	offering, err := cmk.GetServiceOffering(ctx, execConfig.Profiles[0].Name, zoneId, resourceName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(offering).To(Equal(&decoder.CloudStackServiceOffering{CpuNumber: 1, CpuSpeed: 1000, Memory: 1024}))
+}
+
+func TestCmkListAccounts(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	listAccounts := []string{"-c", configFilePath, "list", "accounts", fmt.Sprintf("name=\"%s\"", accountName), fmt.Sprintf("domainid=\"%s\"", domainId)}
+
+	tests := []struct {
+		testName     string
+		responseFile string
+		want         []decoder.CloudStackAccount
+	}{
+		{
+			testName:     "account found",
+			responseFile: "testdata/cmk_list_account_singular.json",
+			want:         []decoder.CloudStackAccount{{Name: "admin", VmAvailable: "Unlimited", CpuAvailable: "Unlimited", MemoryAvailable: "Unlimited"}},
+		},
+		{
+			testName:     "account not found",
+			responseFile: "testdata/cmk_list_empty_response.json",
+			want:         []decoder.CloudStackAccount{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+
+			var tctx testContext
+			tctx.SaveContext()
+			defer tctx.RestoreContext()
+
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			executable.EXPECT().Execute(ctx, listAccounts).
+				Return(*bytes.NewBufferString(test.ReadFile(t, tt.responseFile)), nil)
+
+			cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+			g.Expect(cmk.ListAccounts(ctx, execConfig.Profiles[0].Name, accountName, domainId)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCmkListZoneCapacity(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	listCapacity := []string{"-c", configFilePath, "list", "capacity", fmt.Sprintf("zoneid=\"%s\"", zoneId)}
+
+	tests := []struct {
+		testName      string
+		capacityError error
+		want          []decoder.CloudStackCapacity
+		wantErr       string
+	}{
+		{
+			testName: "capacity listed",
+			want: []decoder.CloudStackCapacity{
+				{Type: decoder.CloudStackCapacityTypeMemory, Total: 68719476736, Used: 34359738368},
+				{Type: decoder.CloudStackCapacityTypeCPU, Total: 10000, Used: 8000},
+			},
+		},
+		{
+			testName:      "capacity not listable",
+			capacityError: errors.New("not allowed"),
+			wantErr:       "getting capacity info",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+
+			var tctx testContext
+			tctx.SaveContext()
+			defer tctx.RestoreContext()
+
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			executable.EXPECT().Execute(ctx, listCapacity).
+				Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_capacity.json")), tt.capacityError)
+
+			cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+			capacities, err := cmk.ListZoneCapacity(ctx, execConfig.Profiles[0].Name, zoneId)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(capacities).To(Equal(tt.want))
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
 func TestCmkGetManagementApiEndpoint(t *testing.T) {
 	_, writer := test.NewWriter(t)
 	mockCtrl := gomock.NewController(t)
@@ -1016,3 +1130,105 @@ func TestCmkGetManagementApiEndpoint(t *testing.T) {
 	_, err = cmk.GetManagementApiEndpoint("xxx")
 	tt.Expect(err).NotTo(BeNil())
 }
+
+func TestCmkListResourceNames(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	tests := []struct {
+		testName          string
+		argumentsExecCall []string
+		jsonResponseFile  string
+		cmkFunc           func(cmk *executables.Cmk, ctx context.Context) ([]string, error)
+		want              []string
+	}{
+		{
+			testName:          "list zones",
+			jsonResponseFile:  "testdata/cmk_list_zone_singular.json",
+			argumentsExecCall: []string{"-c", configFilePath, "list", "zones"},
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListZones(ctx, execConfig.Profiles[0].Name)
+			},
+			want: []string{"zone1"},
+		},
+		{
+			testName:          "list networks",
+			jsonResponseFile:  "testdata/cmk_list_network_singular.json",
+			argumentsExecCall: []string{"-c", configFilePath, "list", "networks", fmt.Sprintf("zoneid=\"%s\"", zoneId)},
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListNetworks(ctx, execConfig.Profiles[0].Name, zoneId)
+			},
+			want: []string{"TEST_RESOURCE"},
+		},
+		{
+			testName:          "list service offerings",
+			jsonResponseFile:  "testdata/cmk_list_serviceoffering_singular.json",
+			argumentsExecCall: []string{"-c", configFilePath, "list", "serviceofferings", fmt.Sprintf("zoneid=\"%s\"", zoneId)},
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListServiceOfferings(ctx, execConfig.Profiles[0].Name, zoneId)
+			},
+			want: []string{"Medium Instance"},
+		},
+		{
+			testName:         "list templates",
+			jsonResponseFile: "testdata/cmk_list_template_multiple.json",
+			argumentsExecCall: []string{
+				"-c", configFilePath,
+				"list", "templates", "templatefilter=all", "listall=true", fmt.Sprintf("zoneid=\"%s\"", zoneId),
+			},
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListTemplates(ctx, execConfig.Profiles[0].Name, zoneId)
+			},
+			want: []string{"CentOS 5.5(64-bit) no GUI (KVM)"},
+		},
+		{
+			testName:          "list zones empty response",
+			jsonResponseFile:  "testdata/cmk_list_empty_response.json",
+			argumentsExecCall: []string{"-c", configFilePath, "list", "zones"},
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListZones(ctx, execConfig.Profiles[0].Name)
+			},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			fileContent := test.ReadFile(t, tt.jsonResponseFile)
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+
+			var tctx testContext
+			tctx.SaveContext()
+			defer tctx.RestoreContext()
+
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			executable.EXPECT().Execute(ctx, tt.argumentsExecCall).Return(*bytes.NewBufferString(fileContent), nil)
+			cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+
+			names, err := tt.cmkFunc(cmk, ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(names).To(ConsistOf(tt.want))
+		})
+	}
+}
+
+func TestCmkListZonesInvalidResponse(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, []string{"-c", configFilePath, "list", "zones"}).
+		Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_non_json_response.txt")), nil)
+	cmk := executables.NewCmk(executable, writer, execConfig.Profiles)
+
+	_, err := cmk.ListZones(ctx, execConfig.Profiles[0].Name)
+	g.Expect(err).To(MatchError(ContainSubstring("parsing response into json")))
+}
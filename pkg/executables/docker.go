@@ -3,6 +3,7 @@ package executables
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -151,6 +152,55 @@ func (d *Docker) ForceRemove(ctx context.Context, name string) error {
 	return nil
 }
 
+// WriteFileToContainer writes content to a file in a running container.
+func (d *Docker) WriteFileToContainer(ctx context.Context, name, path string, content []byte) error {
+	params := []string{"exec", "-i", name, "cp", "/dev/stdin", path}
+
+	if _, err := d.ExecuteWithStdin(ctx, content, params...); err != nil {
+		return fmt.Errorf("writing file %s to docker container %s: %v", path, name, err)
+	}
+	return nil
+}
+
+// KillContainer sends a signal to a running container.
+func (d *Docker) KillContainer(ctx context.Context, name, signal string) error {
+	params := []string{"kill", "-s", signal, name}
+
+	if _, err := d.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("sending signal %s to docker container %s: %v", signal, name, err)
+	}
+	return nil
+}
+
+// GetContainerImage returns the image a container was created from.
+func (d *Docker) GetContainerImage(ctx context.Context, name string) (string, error) {
+	params := []string{"container", "inspect", "--format", "{{.Config.Image}}", name}
+
+	stdout, err := d.Execute(ctx, params...)
+	if err != nil {
+		return "", fmt.Errorf("getting image for docker container %s: %v", name, err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// GetContainerPortBindings returns the ports a container publishes on the host,
+// with the format hostIP:hostPort:containerPort/protocol, sorted.
+func (d *Docker) GetContainerPortBindings(ctx context.Context, name string) ([]string, error) {
+	params := []string{
+		"container", "inspect",
+		"--format", "{{range $port, $bindings := .HostConfig.PortBindings}}{{range $bindings}}{{.HostIp}}:{{.HostPort}}:{{$port}} {{end}}{{end}}",
+		name,
+	}
+
+	stdout, err := d.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting port bindings for docker container %s: %v", name, err)
+	}
+	bindings := strings.Fields(stdout.String())
+	sort.Strings(bindings)
+	return bindings, nil
+}
+
 // CheckContainerExistence checks whether a Docker container with the provided name exists
 // It returns true if a container with the name exists, false if it doesn't and an error if it encounters some other error
 func (d *Docker) CheckContainerExistence(ctx context.Context, name string) (bool, error) {
@@ -251,6 +251,49 @@ func TestDockerForceRemoveFailure(t *testing.T) {
 	assert.EqualError(t, err, expectedError, "Error should be: %v, got: %v", expectedError, err)
 }
 
+func TestDockerWriteFileToContainerSuccess(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	content := []byte("global\n")
+	executable.EXPECT().ExecuteWithStdin(ctx, content, "exec", "-i", "basic_test", "cp", "/dev/stdin", "/etc/config")
+
+	if err := d.WriteFileToContainer(ctx, "basic_test", "/etc/config", content); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestDockerWriteFileToContainerFailure(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	content := []byte("global\n")
+	executable.EXPECT().ExecuteWithStdin(ctx, content, "exec", "-i", "basic_test", "cp", "/dev/stdin", "/etc/config").Return(bytes.Buffer{}, errors.New("no such container"))
+
+	err := d.WriteFileToContainer(ctx, "basic_test", "/etc/config", content)
+	assert.EqualError(t, err, "writing file /etc/config to docker container basic_test: no such container")
+}
+
+func TestDockerKillContainerSuccess(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "kill", "-s", "SIGHUP", "basic_test")
+
+	if err := d.KillContainer(ctx, "basic_test", "SIGHUP"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
 func TestDockerCheckContainerExistenceExists(t *testing.T) {
 	ctx := context.Background()
 	mockCtrl := gomock.NewController(t)
@@ -300,3 +343,46 @@ func TestDockerCheckContainerExistenceOtherError(t *testing.T) {
 	assert.False(t, exists)
 	assert.EqualError(t, err, expectedError, "Error should be: %v, got: %v", expectedError, err)
 }
+
+func TestDockerGetContainerImage(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "container", "inspect", "--format", "{{.Config.Image}}", "basic_test").Return(*bytes.NewBufferString("haproxy:v0.11.1\n"), nil)
+
+	image, err := d.GetContainerImage(ctx, "basic_test")
+	assert.Nil(t, err)
+	assert.Equal(t, "haproxy:v0.11.1", image)
+}
+
+func TestDockerGetContainerPortBindings(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "container", "inspect", "--format", gomock.Any(), "basic_test").Return(
+		*bytes.NewBufferString("127.0.0.1:8080:8080/tcp 0.0.0.0:8443:8443/tcp \n"), nil,
+	)
+
+	bindings, err := d.GetContainerPortBindings(ctx, "basic_test")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0.0.0.0:8443:8443/tcp", "127.0.0.1:8080:8080/tcp"}, bindings)
+}
+
+func TestDockerGetContainerPortBindingsError(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	d := executables.NewDocker(executable)
+
+	executable.EXPECT().Execute(ctx, "container", "inspect", "--format", gomock.Any(), "basic_test").Return(bytes.Buffer{}, errors.New("No such container"))
+
+	_, err := d.GetContainerPortBindings(ctx, "basic_test")
+	assert.EqualError(t, err, "getting port bindings for docker container basic_test: No such container")
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/retrier"
@@ -62,6 +63,7 @@ const (
 
 type Govc struct {
 	writer filewriter.FileWriter
+	reader *files.Reader
 	Executable
 	*retrier.Retrier
 	requiredEnvs *syncSlice
@@ -76,6 +78,7 @@ func NewGovc(executable Executable, writer filewriter.FileWriter, opts ...GovcOp
 
 	g := &Govc{
 		writer:       writer,
+		reader:       files.NewReader(),
 		Executable:   executable,
 		Retrier:      retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
 		requiredEnvs: envVars,
@@ -358,14 +361,54 @@ func (g *Govc) DeployTemplateFromLibrary(ctx context.Context, templateDir, templ
 	return nil
 }
 
-func (g *Govc) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
-	logger.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
-	if _, err := g.exec(ctx, "library.import", "-k", "-pull", "-n", name, library, ovaURL); err != nil {
+// ImportTemplate imports the OVA from the given URL into the library. Without checksums, files.AllowUnverified
+// decides whether vCenter can pull the OVA directly. Since govc can't make vCenter verify the pulled file, when
+// any checksum is set the OVA is downloaded and verified locally and that same file is uploaded to the library.
+func (g *Govc) ImportTemplate(ctx context.Context, library, ovaURL, name string, checksums files.Checksums) error {
+	if checksums.IsEmpty() {
+		if err := files.AllowUnverified(ovaURL); err != nil {
+			return fmt.Errorf("importing template: %v", err)
+		}
+		logger.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
+		if _, err := g.exec(ctx, "library.import", "-k", "-pull", "-n", name, library, ovaURL); err != nil {
+			return fmt.Errorf("importing template: %v", err)
+		}
+		return nil
+	}
+
+	ovaPath, err := g.downloadVerifiedOVA(ovaURL, name, checksums)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(ovaPath)
+
+	logger.V(4).Info("Importing verified template", "ova", ovaPath, "templateName", name)
+	if _, err := g.exec(ctx, "library.import", "-k", "-n", name, library, ovaPath); err != nil {
 		return fmt.Errorf("importing template: %v", err)
 	}
 	return nil
 }
 
+func (g *Govc) downloadVerifiedOVA(ovaURL, name string, checksums files.Checksums) (string, error) {
+	logger.V(4).Info("Downloading and verifying ova", "ova", ovaURL)
+	ovaPath := filepath.Join(g.writer.TempDir(), name+".ova")
+	f, err := os.Create(ovaPath)
+	if err != nil {
+		return "", fmt.Errorf("creating ova file: %v", err)
+	}
+
+	_, err = g.reader.Download(ovaURL, f, checksums)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(ovaPath)
+		return "", fmt.Errorf("verifying ova: %v", err)
+	}
+
+	return ovaPath, nil
+}
+
 func (g *Govc) DeployTemplate(ctx context.Context, library, templateName, vmName, deployFolder, datacenter, datastore, network, resourcePool string, deployOptionsOverride []byte) error {
 	envMap, err := g.validateAndSetupCreds()
 	if err != nil {
@@ -485,7 +528,7 @@ func (g *Govc) markVMAsTemplate(ctx context.Context, datacenter, vmName string)
 	return nil
 }
 
-func (g *Govc) getEnvMap() (map[string]string, error) {
+func (g *Govc) getEnvMap(requireDatacenter bool) (map[string]string, error) {
 	if g.envMap != nil {
 		return g.envMap, nil
 	}
@@ -495,6 +538,9 @@ func (g *Govc) getEnvMap() (map[string]string, error) {
 		if env, ok := os.LookupEnv(key); ok && len(env) > 0 {
 			envMap[key] = env
 		} else {
+			if key == govcDatacenterKey && !requireDatacenter {
+				continue
+			}
 			if key != govcInsecure {
 				return nil, fmt.Errorf("warning required env not set %s", key)
 			}
@@ -509,6 +555,12 @@ func (g *Govc) getEnvMap() (map[string]string, error) {
 }
 
 func (g *Govc) validateAndSetupCreds() (map[string]string, error) {
+	return g.setupCreds(true)
+}
+
+// setupCreds validates the credentials are set and returns the env vars to run govc with.
+// GOVC_DATACENTER is only validated when requireDatacenter is true.
+func (g *Govc) setupCreds(requireDatacenter bool) (map[string]string, error) {
 	if g.envMap != nil {
 		return g.envMap, nil
 	}
@@ -537,11 +589,11 @@ func (g *Govc) validateAndSetupCreds() (map[string]string, error) {
 	} else if govcURL, ok := os.LookupEnv(govcURLKey); !ok || len(govcURL) <= 0 {
 		return nil, fmt.Errorf("%s is not set or is empty: %t", govcURLKey, ok)
 	}
-	if govcDatacenter, ok := os.LookupEnv(govcDatacenterKey); !ok || len(govcDatacenter) <= 0 {
+	if govcDatacenter, ok := os.LookupEnv(govcDatacenterKey); requireDatacenter && (!ok || len(govcDatacenter) <= 0) {
 		return nil, fmt.Errorf("%s is not set or is empty: %t", govcDatacenterKey, ok)
 	}
 
-	envMap, err := g.getEnvMap()
+	envMap, err := g.getEnvMap(requireDatacenter)
 	if err != nil {
 		return nil, fmt.Errorf("%v", err)
 	}
@@ -634,6 +686,20 @@ func (g *Govc) GetCertThumbprint(ctx context.Context) (string, error) {
 	return data[1], nil
 }
 
+// ConfigureServer points govc at the vCenter server, skipping the verification of its certificate
+// when insecure. It takes precedence over the server set in the environment.
+func (g *Govc) ConfigureServer(server string, insecure bool) error {
+	if err := os.Setenv(vSphereServerKey, server); err != nil {
+		return fmt.Errorf("unable to set %s: %v", vSphereServerKey, err)
+	}
+
+	if err := os.Setenv(govcInsecure, strconv.FormatBool(insecure)); err != nil {
+		return fmt.Errorf("unable to set %s: %v", govcInsecure, err)
+	}
+
+	return nil
+}
+
 func (g *Govc) ConfigureCertThumbprint(ctx context.Context, server, thumbprint string) error {
 	path, err := g.writer.Write(filepath.Base(govcTlsHostsFile), []byte(fmt.Sprintf("%s %s", server, thumbprint)))
 	if err != nil {
@@ -696,6 +762,88 @@ func (g *Govc) NetworkExists(ctx context.Context, network string) (bool, error)
 	return exists, nil
 }
 
+// ListDatacenters returns the names of the datacenters in vCenter. Unlike most operations, listing
+// doesn't need GOVC_DATACENTER to be set.
+func (g *Govc) ListDatacenters(ctx context.Context) ([]string, error) {
+	envMap, err := g.setupCreds(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	paths, err := g.find(ctx, envMap, "/", "d")
+	if err != nil {
+		return nil, fmt.Errorf("listing datacenters: %v", err)
+	}
+
+	datacenters := make([]string, 0, len(paths))
+	for _, path := range paths {
+		datacenters = append(datacenters, strings.TrimPrefix(path, "/"))
+	}
+
+	return datacenters, nil
+}
+
+// ListNetworks returns the paths of the networks in datacenter.
+func (g *Govc) ListNetworks(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listInDatacenter(ctx, datacenter, "network", "n")
+}
+
+// ListDatastores returns the paths of the datastores in datacenter.
+func (g *Govc) ListDatastores(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listInDatacenter(ctx, datacenter, "datastore", "s")
+}
+
+// ListResourcePools returns the paths of the resource pools in datacenter.
+func (g *Govc) ListResourcePools(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listInDatacenter(ctx, datacenter, "host", "p")
+}
+
+// ListTemplates returns the paths of the VM templates in datacenter.
+func (g *Govc) ListTemplates(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listInDatacenter(ctx, datacenter, "vm", "m", "-config.template", "true")
+}
+
+func (g *Govc) listInDatacenter(ctx context.Context, datacenter, folder, objectType string, filters ...string) ([]string, error) {
+	// The datacenter is part of the search path so GOVC_DATACENTER doesn't need to be set.
+	envMap, err := g.setupCreds(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	paths, err := g.find(ctx, envMap, fmt.Sprintf("/%s/%s", datacenter, folder), objectType, filters...)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s in datacenter %s: %v", folder, datacenter, err)
+	}
+
+	return paths, nil
+}
+
+func (g *Govc) find(ctx context.Context, envMap map[string]string, root, objectType string, filters ...string) ([]string, error) {
+	params := append([]string{"find", root, "-type", objectType}, filters...)
+
+	var paths []string
+	err := g.Retry(func() error {
+		response, err := g.ExecuteWithEnv(ctx, envMap, params...)
+		if err != nil {
+			return err
+		}
+
+		paths = nil
+		for _, line := range strings.Split(response.String(), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				paths = append(paths, line)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
 func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, _ *bool) error {
 	envMap, err := g.validateAndSetupCreds()
 	if err != nil {
@@ -755,45 +903,131 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 		logger.MarkPass("Folder validated")
 	}
 
+	machineConfig.Spec.ResourcePool, err = g.findResourcePool(ctx, envMap, "/"+datacenterConfig.Spec.Datacenter, machineConfig.Spec.ResourcePool, datacenterConfig.Spec.Datacenter)
+	if err != nil {
+		return err
+	}
+
+	logger.MarkPass("Resource pool validated")
+	return nil
+}
+
+// findResourcePool looks up the resource pool under root and returns its full inventory path.
+func (g *Govc) findResourcePool(ctx context.Context, envMap map[string]string, root, resourcePool, datacenter string) (string, error) {
 	var poolInfoResponse bytes.Buffer
-	params = []string{"find", "-json", "/" + datacenterConfig.Spec.Datacenter, "-type", "p", "-name", filepath.Base(machineConfig.Spec.ResourcePool)}
+	var err error
+	params := []string{"find", "-json", root, "-type", "p", "-name", filepath.Base(resourcePool)}
 	err = g.Retry(func() error {
 		poolInfoResponse, err = g.ExecuteWithEnv(ctx, envMap, params...)
 		return err
 	})
 	if err != nil {
-		return fmt.Errorf("getting resource pool: %v", err)
+		return "", fmt.Errorf("getting resource pool: %v", err)
 	}
 
 	poolInfoJson := poolInfoResponse.String()
 	poolInfoJson = strings.TrimSuffix(poolInfoJson, "\n")
 	if poolInfoJson == "null" || poolInfoJson == "" {
-		return fmt.Errorf("resource pool '%s' not found", machineConfig.Spec.ResourcePool)
+		return "", fmt.Errorf("resource pool '%s' not found", resourcePool)
 	}
 
 	poolInfo := make([]string, 0)
 	if err = json.Unmarshal([]byte(poolInfoJson), &poolInfo); err != nil {
-		return fmt.Errorf("failed unmarshalling govc response: %v", err)
+		return "", fmt.Errorf("failed unmarshalling govc response: %v", err)
 	}
 
-	machineConfig.Spec.ResourcePool = strings.TrimPrefix(machineConfig.Spec.ResourcePool, "*/")
+	resourcePool = strings.TrimPrefix(resourcePool, "*/")
 	bPoolFound := false
 	var foundPool string
 	for _, p := range poolInfo {
-		if strings.HasSuffix(p, machineConfig.Spec.ResourcePool) {
+		if strings.HasSuffix(p, resourcePool) {
 			if bPoolFound {
-				return fmt.Errorf("specified resource pool '%s' maps to multiple paths within the datacenter '%s'", machineConfig.Spec.ResourcePool, datacenterConfig.Spec.Datacenter)
+				return "", fmt.Errorf("specified resource pool '%s' maps to multiple paths within the datacenter '%s'", resourcePool, datacenter)
 			}
 			bPoolFound = true
 			foundPool = p
 		}
 	}
 	if !bPoolFound {
-		return fmt.Errorf("resource pool '%s' not found", machineConfig.Spec.ResourcePool)
+		return "", fmt.Errorf("resource pool '%s' not found", resourcePool)
+	}
+
+	return foundPool, nil
+}
+
+// ValidateVCenterSetupFailureDomain validates the compute cluster, datastore, resource pool and network of a failure domain
+// exist in vCenter. The resource pool is updated with its full inventory path.
+func (g *Govc) ValidateVCenterSetupFailureDomain(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, failureDomain *v1alpha1.VSphereFailureDomain) error {
+	envMap, err := g.validateAndSetupCreds()
+	if err != nil {
+		return fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	var clusterResponse bytes.Buffer
+	params := []string{"find", "-maxdepth=1", filepath.Dir(failureDomain.ComputeCluster), "-type", "c", "-name", filepath.Base(failureDomain.ComputeCluster)}
+	err = g.Retry(func() error {
+		clusterResponse, err = g.ExecuteWithEnv(ctx, envMap, params...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("getting compute cluster: %v", err)
+	}
+	if strings.TrimSpace(clusterResponse.String()) == "" {
+		return fmt.Errorf("compute cluster '%s' not found", failureDomain.ComputeCluster)
+	}
+
+	params = []string{"datastore.info", failureDomain.Datastore}
+	err = g.Retry(func() error {
+		_, err = g.ExecuteWithEnv(ctx, envMap, params...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get datastore: %v", err)
+	}
+
+	failureDomain.ResourcePool, err = g.findResourcePool(ctx, envMap, failureDomain.ComputeCluster, failureDomain.ResourcePool, datacenterConfig.Spec.Datacenter)
+	if err != nil {
+		return err
+	}
+
+	if failureDomain.Network != "" {
+		exists, err := g.NetworkExists(ctx, failureDomain.Network)
+		if err != nil {
+			return fmt.Errorf("failed checking if network '%s' exists: %v", failureDomain.Network, err)
+		}
+		if !exists {
+			return fmt.Errorf("network '%s' not found", failureDomain.Network)
+		}
+	}
+
+	return nil
+}
+
+// CreateVMAntiAffinityRule creates a DRS rule in the compute cluster that keeps the given VMs on separate hosts.
+// An existing rule with the same name is replaced.
+func (g *Govc) CreateVMAntiAffinityRule(ctx context.Context, computeCluster, name string, vms []string) error {
+	rules, err := g.exec(ctx, "cluster.rule.ls", "-cluster", computeCluster)
+	if err != nil {
+		return fmt.Errorf("listing DRS rules in compute cluster %s: %v", computeCluster, err)
+	}
+
+	scanner := bufio.NewScanner(&rules)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) != name {
+			continue
+		}
+		if _, err = g.exec(ctx, "cluster.rule.remove", "-cluster", computeCluster, "-name", name); err != nil {
+			return fmt.Errorf("removing DRS rule %s: %v", name, err)
+		}
+		break
+	}
+
+	params := []string{"cluster.rule.create", "-cluster", computeCluster, "-name", name, "-enable", "-anti-affinity"}
+	params = append(params, vms...)
+	if _, err = g.exec(ctx, params...); err != nil {
+		return fmt.Errorf("creating DRS rule %s: %v", name, err)
 	}
-	machineConfig.Spec.ResourcePool = foundPool
 
-	logger.MarkPass("Resource pool validated")
 	return nil
 }
 
@@ -21,7 +21,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
 const (
@@ -920,7 +923,7 @@ func TestImportTemplateSuccess(t *testing.T) {
 	_, g, executable, env := setup(t)
 	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-pull", "-n", name, templateLibrary, ovaURL).Return(*bytes.NewBufferString(""), nil)
 
-	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name); err != nil {
+	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, files.Checksums{}); err != nil {
 		t.Fatalf("Govc.ImportTemplate() err = %v, want err nil", err)
 	}
 }
@@ -933,11 +936,66 @@ func TestImportTemplateError(t *testing.T) {
 	_, g, executable, env := setup(t)
 	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-pull", "-n", name, templateLibrary, ovaURL).Return(bytes.Buffer{}, errors.New("error from execute with env"))
 
-	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name); err == nil {
+	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, files.Checksums{}); err == nil {
 		t.Fatal("Govc.ImportTemplate() err = nil, want err not nil")
 	}
 }
 
+func TestImportTemplateMissingChecksumsStrict(t *testing.T) {
+	ovaURL := "ovaURL"
+	name := "name"
+	ctx := context.Background()
+	t.Setenv(features.StrictArtifactVerificationEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+
+	_, g, _, _ := setup(t)
+
+	err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, files.Checksums{})
+	if err == nil || !strings.Contains(err.Error(), "no published checksum to verify [ovaURL]") {
+		t.Fatalf("Govc.ImportTemplate() err = %v, want missing checksum error", err)
+	}
+}
+
+func TestImportTemplateVerifyChecksum(t *testing.T) {
+	ovaURL := "testdata/template.ova"
+	name := "name"
+	ctx := context.Background()
+	checksums := files.Checksums{SHA256: "635cdda76fd6cb374a66518378d9cfcf777df2b88abd68bc06ceed9b611e2cfa"}
+
+	dir, g, executable, env := setup(t)
+	ovaPath := filepath.Join(dir, "generated", name+".ova")
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "library.import", "-k", "-n", name, templateLibrary, ovaPath).DoAndReturn(
+		func(_ context.Context, _ map[string]string, _ ...string) (bytes.Buffer, error) {
+			if !validations.FileExists(ovaPath) {
+				t.Errorf("verified ova %s should exist when importing it", ovaPath)
+			}
+			return bytes.Buffer{}, nil
+		},
+	)
+
+	if err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, checksums); err != nil {
+		t.Fatalf("Govc.ImportTemplate() err = %v, want err nil", err)
+	}
+	if validations.FileExists(ovaPath) {
+		t.Errorf("verified ova %s should be removed after importing it", ovaPath)
+	}
+}
+
+func TestImportTemplateChecksumMismatch(t *testing.T) {
+	ovaURL := "testdata/template.ova"
+	name := "name"
+	ctx := context.Background()
+	checksums := files.Checksums{SHA512: "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce"}
+
+	_, g, _, _ := setup(t)
+
+	err := g.ImportTemplate(ctx, templateLibrary, ovaURL, name, checksums)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch for [testdata/template.ova]: expected sha512") {
+		t.Fatalf("Govc.ImportTemplate() err = %v, want checksum mismatch error", err)
+	}
+}
+
 func TestDeleteTemplateSuccess(t *testing.T) {
 	template := "template"
 	resourcePool := "resourcePool"
@@ -1481,3 +1539,169 @@ func TestGovcSetGroupRoleOnObject(t *testing.T) {
 		}
 	}
 }
+
+func TestGovcValidateVCenterSetupFailureDomainSuccess(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "SDDC-Datacenter"},
+	}
+	failureDomain := &v1alpha1.VSphereFailureDomain{
+		Name:           "fd-1",
+		ComputeCluster: "/SDDC-Datacenter/host/Cluster-1",
+		ResourcePool:   "*/Resources",
+		Datastore:      "/SDDC-Datacenter/datastore/WorkloadDatastore",
+		Network:        "/SDDC-Datacenter/network/sddc-cgw-network-1",
+	}
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-maxdepth=1", "/SDDC-Datacenter/host", "-type", "c", "-name", "Cluster-1").Return(*bytes.NewBufferString("/SDDC-Datacenter/host/Cluster-1"), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "datastore.info", "/SDDC-Datacenter/datastore/WorkloadDatastore").Return(bytes.Buffer{}, nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", "/SDDC-Datacenter/host/Cluster-1", "-type", "p", "-name", "Resources").Return(*bytes.NewBufferString(`["/SDDC-Datacenter/host/Cluster-1/Resources"]`), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-maxdepth=1", "/SDDC-Datacenter/network", "-type", "n", "-name", "sddc-cgw-network-1").Return(*bytes.NewBufferString("/SDDC-Datacenter/network/sddc-cgw-network-1"), nil)
+
+	gt := NewWithT(t)
+	gt.Expect(g.ValidateVCenterSetupFailureDomain(ctx, datacenterConfig, failureDomain)).To(Succeed())
+	gt.Expect(failureDomain.ResourcePool).To(Equal("/SDDC-Datacenter/host/Cluster-1/Resources"))
+}
+
+func TestGovcValidateVCenterSetupFailureDomainComputeClusterNotFound(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "SDDC-Datacenter"},
+	}
+	failureDomain := &v1alpha1.VSphereFailureDomain{
+		Name:           "fd-1",
+		ComputeCluster: "/SDDC-Datacenter/host/Cluster-1",
+		ResourcePool:   "*/Resources",
+		Datastore:      "/SDDC-Datacenter/datastore/WorkloadDatastore",
+	}
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-maxdepth=1", "/SDDC-Datacenter/host", "-type", "c", "-name", "Cluster-1").Return(bytes.Buffer{}, nil)
+
+	gt := NewWithT(t)
+	gt.Expect(g.ValidateVCenterSetupFailureDomain(ctx, datacenterConfig, failureDomain)).To(MatchError(ContainSubstring("compute cluster '/SDDC-Datacenter/host/Cluster-1' not found")))
+}
+
+func TestGovcCreateVMAntiAffinityRuleReplacesExisting(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	computeCluster := "/SDDC-Datacenter/host/Cluster-1"
+	name := "test-control-plane-anti-affinity"
+	vms := []string{"/SDDC-Datacenter/vm/test/test-abcde", "/SDDC-Datacenter/vm/test/test-fghij"}
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", computeCluster).Return(*bytes.NewBufferString("other-rule\n" + name + "\n"), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.remove", "-cluster", computeCluster, "-name", name).Return(bytes.Buffer{}, nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.create", "-cluster", computeCluster, "-name", name, "-enable", "-anti-affinity", vms[0], vms[1]).Return(bytes.Buffer{}, nil)
+
+	gt := NewWithT(t)
+	gt.Expect(g.CreateVMAntiAffinityRule(ctx, computeCluster, name, vms)).To(Succeed())
+}
+
+func TestGovcCreateVMAntiAffinityRuleError(t *testing.T) {
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	computeCluster := "/SDDC-Datacenter/host/Cluster-1"
+	name := "test-etcd-anti-affinity"
+	vms := []string{"/SDDC-Datacenter/vm/test/test-etcd-abcde", "/SDDC-Datacenter/vm/test/test-etcd-fghij"}
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.ls", "-cluster", computeCluster).Return(bytes.Buffer{}, nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "cluster.rule.create", "-cluster", computeCluster, "-name", name, "-enable", "-anti-affinity", vms[0], vms[1]).Return(bytes.Buffer{}, errors.New("no permission"))
+
+	gt := NewWithT(t)
+	gt.Expect(g.CreateVMAntiAffinityRule(ctx, computeCluster, name, vms)).To(MatchError(ContainSubstring("creating DRS rule test-etcd-anti-affinity: no permission")))
+}
+
+func TestGovcListDatacentersWithoutDatacenterEnv(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, govc, executable, _ := setup(t)
+	os.Unsetenv(govcDatacenter)
+	env := map[string]string{
+		govcUsername: "vsphere_username",
+		govcPassword: "vsphere_password",
+		govcURL:      "vsphere_server",
+		govcInsecure: "false",
+	}
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/", "-type", "d").Return(*bytes.NewBufferString("/SDDC-Datacenter\n/Other-Datacenter\n"), nil)
+
+	datacenters, err := govc.ListDatacenters(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(datacenters).To(Equal([]string{"SDDC-Datacenter", "Other-Datacenter"}))
+}
+
+func TestGovcConfigureServer(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, govc, executable, _ := setup(t)
+	os.Unsetenv(govcDatacenter)
+	env := map[string]string{
+		govcUsername: "vsphere_username",
+		govcPassword: "vsphere_password",
+		govcURL:      "vcenter.example.com",
+		govcInsecure: "true",
+	}
+
+	g.Expect(govc.ConfigureServer("vcenter.example.com", true)).To(Succeed())
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/", "-type", "d").Return(*bytes.NewBufferString("/SDDC-Datacenter\n"), nil)
+
+	datacenters, err := govc.ListDatacenters(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(datacenters).To(Equal([]string{"SDDC-Datacenter"}))
+}
+
+func TestGovcListInDatacenter(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     func(*executables.Govc, context.Context, string) ([]string, error)
+		wantArgs []string
+	}{
+		{
+			name:     "networks",
+			list:     (*executables.Govc).ListNetworks,
+			wantArgs: []string{"find", "/SDDC-Datacenter/network", "-type", "n"},
+		},
+		{
+			name:     "datastores",
+			list:     (*executables.Govc).ListDatastores,
+			wantArgs: []string{"find", "/SDDC-Datacenter/datastore", "-type", "s"},
+		},
+		{
+			name:     "resource pools",
+			list:     (*executables.Govc).ListResourcePools,
+			wantArgs: []string{"find", "/SDDC-Datacenter/host", "-type", "p"},
+		},
+		{
+			name:     "templates",
+			list:     (*executables.Govc).ListTemplates,
+			wantArgs: []string{"find", "/SDDC-Datacenter/vm", "-type", "m", "-config.template", "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			_, govc, executable, env := setup(t)
+
+			executable.EXPECT().ExecuteWithEnv(ctx, env, tt.wantArgs).Return(*bytes.NewBufferString("/SDDC-Datacenter/a\n\n/SDDC-Datacenter/b\n"), nil)
+
+			paths, err := tt.list(govc, ctx, "SDDC-Datacenter")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(paths).To(Equal([]string{"/SDDC-Datacenter/a", "/SDDC-Datacenter/b"}))
+		})
+	}
+}
+
+func TestGovcListNetworksError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, govc, executable, env := setup(t)
+	govc.Retrier = retrier.NewWithMaxRetries(1, 0)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "/SDDC-Datacenter/network", "-type", "n").Return(bytes.Buffer{}, errors.New("not found"))
+
+	_, err := govc.ListNetworks(ctx, "SDDC-Datacenter")
+	g.Expect(err).To(MatchError(ContainSubstring("listing network in datacenter SDDC-Datacenter")))
+}
@@ -51,11 +51,16 @@ var (
 	capiClustersResourceType             = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
 	eksaClusterResourceType              = fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)
 	eksaVSphereDatacenterResourceType    = fmt.Sprintf("vspheredatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
+	eksaDockerDatacenterResourceType     = fmt.Sprintf("dockerdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaVSphereMachineResourceType       = fmt.Sprintf("vspheremachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaTinkerbellDatacenterResourceType = fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaTinkerbellMachineResourceType    = fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	TinkerbellHardwareResourceType       = fmt.Sprintf("hardware.%s", tinkv1alpha1.GroupVersion.Group)
 	rufioBaseboardManagementResourceType = fmt.Sprintf("baseboardmanagements.%s", rufiov1alpha1.GroupVersion.Group)
+	RufioBMCJobResourceType              = fmt.Sprintf("bmcjobs.%s", rufiov1alpha1.GroupVersion.Group)
+	TinkerbellWorkflowResourceType       = fmt.Sprintf("workflows.%s", tinkv1alpha1.GroupVersion.Group)
+	TinkerbellTemplateResourceType       = fmt.Sprintf("templates.%s", tinkv1alpha1.GroupVersion.Group)
+	tinkerbellMachineResourceType        = "tinkerbellmachines.infrastructure.cluster.x-k8s.io"
 	eksaCloudStackDatacenterResourceType = fmt.Sprintf("cloudstackdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaCloudStackMachineResourceType    = fmt.Sprintf("cloudstackmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaAwsResourceType                  = fmt.Sprintf("awsdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
@@ -70,10 +75,12 @@ var (
 	eksdReleaseType                      = fmt.Sprintf("releases.%s", eksdv1alpha1.GroupVersion.Group)
 	kubectlConnectionRefusedRegex        = regexp.MustCompile("The connection to the server .* was refused")
 	kubectlIoTimeoutRegex                = regexp.MustCompile("Unable to connect to the server.*i/o timeout.*")
+	kubectlWaitTimeoutRegex              = regexp.MustCompile("timed out waiting for the condition")
 )
 
 type Kubectl struct {
 	Executable
+	apiClient KubernetesAPIClient
 }
 
 func (k *Kubectl) SearchCloudStackMachineConfig(ctx context.Context, name string, kubeconfigFile string, namespace string) ([]*v1alpha1.CloudStackMachineConfig, error) {
@@ -157,21 +164,35 @@ type VersionResponse struct {
 	ServerVersion version.Info `json:"serverVersion"`
 }
 
-func NewKubectl(executable Executable) *Kubectl {
-	return &Kubectl{
+func NewKubectl(executable Executable, opts ...KubectlConfigOpt) *Kubectl {
+	k := &Kubectl{
 		Executable: executable,
 	}
+
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
 }
 
 func (k *Kubectl) GetNamespace(ctx context.Context, kubeconfig string, namespace string) error {
+	if k.apiClient != nil {
+		return k.apiClient.GetObject(ctx, "namespace", namespace, "", kubeconfig, &corev1.Namespace{})
+	}
+
 	params := []string{"get", "namespace", namespace, "--kubeconfig", kubeconfig}
 	_, err := k.Execute(ctx, params...)
 	return err
 }
 
 func (k *Kubectl) CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error {
-	params := []string{"create", "namespace", namespace, "--kubeconfig", kubeconfig}
-	_, err := k.Execute(ctx, params...)
+	var err error
+	if k.apiClient != nil {
+		err = k.createNamespaceWithAPIClient(ctx, kubeconfig, namespace)
+	} else {
+		_, err = k.Execute(ctx, "create", "namespace", namespace, "--kubeconfig", kubeconfig)
+	}
 	if err != nil {
 		return fmt.Errorf("creating namespace %v: %v", namespace, err)
 	}
@@ -228,6 +249,13 @@ func (k *Kubectl) ApplyKubeSpecWithNamespace(ctx context.Context, cluster *types
 }
 
 func (k *Kubectl) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	if k.apiClient != nil {
+		if err := k.apiClient.ApplyManifest(ctx, cluster.KubeconfigFile, data, ""); err != nil {
+			return fmt.Errorf("executing apply: %v", err)
+		}
+		return nil
+	}
+
 	params := []string{"apply", "-f", "-"}
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
@@ -245,6 +273,13 @@ func (k *Kubectl) ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, clust
 		return nil
 	}
 
+	if k.apiClient != nil {
+		if err := k.apiClient.ApplyManifest(ctx, cluster.KubeconfigFile, data, namespace); err != nil {
+			return fmt.Errorf("executing apply: %v", err)
+		}
+		return nil
+	}
+
 	params := []string{"apply", "-f", "-", "--namespace", namespace}
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
@@ -257,6 +292,13 @@ func (k *Kubectl) ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, clust
 }
 
 func (k *Kubectl) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	if k.apiClient != nil {
+		if err := k.apiClient.ApplyManifestForce(ctx, cluster.KubeconfigFile, data); err != nil {
+			return fmt.Errorf("executing apply --force: %v", err)
+		}
+		return nil
+	}
+
 	params := []string{"apply", "-f", "-", "--force"}
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
@@ -269,6 +311,13 @@ func (k *Kubectl) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *type
 }
 
 func (k *Kubectl) DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	if k.apiClient != nil {
+		if err := k.apiClient.DeleteManifest(ctx, cluster.KubeconfigFile, data); err != nil {
+			return fmt.Errorf("executing delete: %v", err)
+		}
+		return nil
+	}
+
 	params := []string{"delete", "-f", "-"}
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
@@ -353,6 +402,10 @@ func (k *Kubectl) WaitForBaseboardManagements(ctx context.Context, cluster *type
 }
 
 func (k *Kubectl) Wait(ctx context.Context, kubeconfig string, timeout string, forCondition string, property string, namespace string, opts ...KubectlOpt) error {
+	if k.apiClient != nil {
+		return k.waitWithAPIClient(ctx, kubeconfig, timeout, forCondition, property, namespace, opts...)
+	}
+
 	// On each retry kubectl wait timeout values will have to be adjusted to only wait for the remaining timeout duration.
 	//  Here we establish an absolute timeout time for this based on the caller-specified timeout.
 	timeoutDuration, err := time.ParseDuration(timeout)
@@ -371,7 +424,7 @@ func (k *Kubectl) Wait(ctx context.Context, kubeconfig string, timeout string, f
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("executing wait: %v", err)
+		return fmt.Errorf("executing wait: %w", err)
 	}
 	return nil
 }
@@ -399,7 +452,7 @@ func kubectlWaitRetryPolicy(totalRetries int, err error) (retry bool, wait time.
 func (k *Kubectl) wait(ctx context.Context, kubeconfig string, timeoutTime time.Time, forCondition string, property string, namespace string, opts ...KubectlOpt) error {
 	secondsRemainingUntilTimeout := time.Until(timeoutTime).Seconds()
 	if secondsRemainingUntilTimeout <= minimumWaitTimeout {
-		return fmt.Errorf("error: timed out waiting for condition %v on %v", forCondition, property)
+		return fmt.Errorf("error: %w %v on %v", kubernetes.ErrWaitTimeout, forCondition, property)
 	}
 	kubectlTimeoutString := fmt.Sprintf("%.*fs", timeoutPrecision, secondsRemainingUntilTimeout)
 	params := []string{
@@ -409,6 +462,9 @@ func (k *Kubectl) wait(ctx context.Context, kubeconfig string, timeoutTime time.
 	applyOpts(&params, opts...)
 	_, err := k.Execute(ctx, params...)
 	if err != nil {
+		if kubectlWaitTimeoutRegex.MatchString(err.Error()) {
+			return fmt.Errorf("executing wait: %v: %w", err, kubernetes.ErrWaitTimeout)
+		}
 		return fmt.Errorf("executing wait: %v", err)
 	}
 	return nil
@@ -433,6 +489,13 @@ func (k *Kubectl) DeleteEksaMachineConfig(ctx context.Context, eksaMachineConfig
 }
 
 func (k *Kubectl) DeleteEKSACluster(ctx context.Context, managementCluster *types.Cluster, eksaClusterName, eksaClusterNamespace string) error {
+	if k.apiClient != nil {
+		if err := k.deleteWithAPIClient(ctx, eksaClusterResourceType, eksaClusterName, eksaClusterNamespace, managementCluster.KubeconfigFile, true); err != nil {
+			return fmt.Errorf("deleting eksa cluster %s apply: %v", eksaClusterName, err)
+		}
+		return nil
+	}
+
 	params := []string{"delete", eksaClusterResourceType, eksaClusterName, "--kubeconfig", managementCluster.KubeconfigFile, "--namespace", eksaClusterNamespace, "--ignore-not-found=true"}
 	_, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -442,6 +505,13 @@ func (k *Kubectl) DeleteEKSACluster(ctx context.Context, managementCluster *type
 }
 
 func (k *Kubectl) DeleteGitOpsConfig(ctx context.Context, managementCluster *types.Cluster, gitOpsConfigName, gitOpsConfigNamespace string) error {
+	if k.apiClient != nil {
+		if err := k.deleteWithAPIClient(ctx, eksaGitOpsResourceType, gitOpsConfigName, gitOpsConfigNamespace, managementCluster.KubeconfigFile, true); err != nil {
+			return fmt.Errorf("deleting gitops config %s apply: %v", gitOpsConfigName, err)
+		}
+		return nil
+	}
+
 	params := []string{"delete", eksaGitOpsResourceType, gitOpsConfigName, "--kubeconfig", managementCluster.KubeconfigFile, "--namespace", gitOpsConfigNamespace, "--ignore-not-found=true"}
 	_, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -473,6 +543,16 @@ func (k *Kubectl) DeletePackageResources(ctx context.Context, managementCluster
 	return nil
 }
 
+// DeleteKubeProxy removes the kube-proxy daemonset and its configmap from the cluster, if present.
+func (k *Kubectl) DeleteKubeProxy(ctx context.Context, cluster *types.Cluster) error {
+	params := []string{"delete", "daemonset,configmap", "kube-proxy", "--kubeconfig", cluster.KubeconfigFile, "--namespace", constants.KubeSystemNamespace, "--ignore-not-found=true"}
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("deleting kube-proxy: %v", err)
+	}
+	return nil
+}
+
 func (k *Kubectl) DeleteSecret(ctx context.Context, managementCluster *types.Cluster, secretName, namespace string) error {
 	params := []string{"delete", "secret", secretName, "--kubeconfig", managementCluster.KubeconfigFile, "--namespace", namespace}
 	_, err := k.Execute(ctx, params...)
@@ -483,6 +563,13 @@ func (k *Kubectl) DeleteSecret(ctx context.Context, managementCluster *types.Clu
 }
 
 func (k *Kubectl) DeleteOIDCConfig(ctx context.Context, managementCluster *types.Cluster, oidcConfigName, oidcConfigNamespace string) error {
+	if k.apiClient != nil {
+		if err := k.deleteWithAPIClient(ctx, eksaOIDCResourceType, oidcConfigName, oidcConfigNamespace, managementCluster.KubeconfigFile, true); err != nil {
+			return fmt.Errorf("deleting oidc config %s apply: %v", oidcConfigName, err)
+		}
+		return nil
+	}
+
 	params := []string{"delete", eksaOIDCResourceType, oidcConfigName, "--kubeconfig", managementCluster.KubeconfigFile, "--namespace", oidcConfigNamespace, "--ignore-not-found=true"}
 	_, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -492,6 +579,13 @@ func (k *Kubectl) DeleteOIDCConfig(ctx context.Context, managementCluster *types
 }
 
 func (k *Kubectl) DeleteAWSIamConfig(ctx context.Context, managementCluster *types.Cluster, awsIamConfigName, awsIamConfigNamespace string) error {
+	if k.apiClient != nil {
+		if err := k.deleteWithAPIClient(ctx, eksaAwsIamResourceType, awsIamConfigName, awsIamConfigNamespace, managementCluster.KubeconfigFile, true); err != nil {
+			return fmt.Errorf("deleting awsIam config %s apply: %v", awsIamConfigName, err)
+		}
+		return nil
+	}
+
 	params := []string{"delete", eksaAwsIamResourceType, awsIamConfigName, "--kubeconfig", managementCluster.KubeconfigFile, "--namespace", awsIamConfigNamespace, "--ignore-not-found=true"}
 	_, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -501,6 +595,13 @@ func (k *Kubectl) DeleteAWSIamConfig(ctx context.Context, managementCluster *typ
 }
 
 func (k *Kubectl) DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster) error {
+	if k.apiClient != nil {
+		if err := k.deleteWithAPIClient(ctx, capiClustersResourceType, clusterToDelete.Name, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, false); err != nil {
+			return fmt.Errorf("deleting cluster %s apply: %v", clusterToDelete.Name, err)
+		}
+		return nil
+	}
+
 	params := []string{"delete", capiClustersResourceType, clusterToDelete.Name, "--kubeconfig", managementCluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace}
 	_, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1240,6 +1341,14 @@ func (k *Kubectl) SearchVsphereDatacenterConfig(ctx context.Context, datacenterN
 }
 
 func (k *Kubectl) GetEksaFluxConfig(ctx context.Context, gitOpsConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.FluxConfig, error) {
+	if k.apiClient != nil {
+		response := &v1alpha1.FluxConfig{}
+		if err := k.apiClient.GetObject(ctx, eksaFluxConfigResourceType, gitOpsConfigName, namespace, kubeconfigFile, response); err != nil {
+			return nil, fmt.Errorf("getting eksa FluxConfig: %v", err)
+		}
+		return response, nil
+	}
+
 	params := []string{"get", eksaFluxConfigResourceType, gitOpsConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1256,6 +1365,14 @@ func (k *Kubectl) GetEksaFluxConfig(ctx context.Context, gitOpsConfigName string
 }
 
 func (k *Kubectl) GetEksaGitOpsConfig(ctx context.Context, gitOpsConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.GitOpsConfig, error) {
+	if k.apiClient != nil {
+		response := &v1alpha1.GitOpsConfig{}
+		if err := k.apiClient.GetObject(ctx, eksaGitOpsResourceType, gitOpsConfigName, namespace, kubeconfigFile, response); err != nil {
+			return nil, fmt.Errorf("getting eksa GitOpsConfig: %v", err)
+		}
+		return response, nil
+	}
+
 	params := []string{"get", eksaGitOpsResourceType, gitOpsConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1272,6 +1389,14 @@ func (k *Kubectl) GetEksaGitOpsConfig(ctx context.Context, gitOpsConfigName stri
 }
 
 func (k *Kubectl) GetEksaOIDCConfig(ctx context.Context, oidcConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.OIDCConfig, error) {
+	if k.apiClient != nil {
+		response := &v1alpha1.OIDCConfig{}
+		if err := k.apiClient.GetObject(ctx, eksaOIDCResourceType, oidcConfigName, namespace, kubeconfigFile, response); err != nil {
+			return nil, fmt.Errorf("getting eksa OIDCConfig: %v", err)
+		}
+		return response, nil
+	}
+
 	params := []string{"get", eksaOIDCResourceType, oidcConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1288,6 +1413,14 @@ func (k *Kubectl) GetEksaOIDCConfig(ctx context.Context, oidcConfigName string,
 }
 
 func (k *Kubectl) GetEksaAWSIamConfig(ctx context.Context, awsIamConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.AWSIamConfig, error) {
+	if k.apiClient != nil {
+		response := &v1alpha1.AWSIamConfig{}
+		if err := k.apiClient.GetObject(ctx, eksaAwsIamResourceType, awsIamConfigName, namespace, kubeconfigFile, response); err != nil {
+			return nil, fmt.Errorf("getting eksa AWSIamConfig: %v", err)
+		}
+		return response, nil
+	}
+
 	params := []string{"get", eksaAwsIamResourceType, awsIamConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1320,6 +1453,14 @@ func (k *Kubectl) GetEksaTinkerbellDatacenterConfig(ctx context.Context, tinkerb
 }
 
 func (k *Kubectl) GetEksaVSphereDatacenterConfig(ctx context.Context, vsphereDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error) {
+	if k.apiClient != nil {
+		response := &v1alpha1.VSphereDatacenterConfig{}
+		if err := k.apiClient.GetObject(ctx, eksaVSphereDatacenterResourceType, vsphereDatacenterConfigName, namespace, kubeconfigFile, response); err != nil {
+			return nil, fmt.Errorf("getting eksa vsphere cluster: %v", err)
+		}
+		return response, nil
+	}
+
 	params := []string{"get", eksaVSphereDatacenterResourceType, vsphereDatacenterConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1335,6 +1476,23 @@ func (k *Kubectl) GetEksaVSphereDatacenterConfig(ctx context.Context, vsphereDat
 	return response, nil
 }
 
+// GetEksaDockerDatacenterConfig returns the DockerDatacenterConfig with the given name.
+func (k *Kubectl) GetEksaDockerDatacenterConfig(ctx context.Context, dockerDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.DockerDatacenterConfig, error) {
+	params := []string{"get", eksaDockerDatacenterResourceType, dockerDatacenterConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting eksa docker datacenter config: %v", err)
+	}
+
+	response := &v1alpha1.DockerDatacenterConfig{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("parsing get eksa docker datacenter config response: %v", err)
+	}
+
+	return response, nil
+}
+
 func (k *Kubectl) GetEksaTinkerbellMachineConfig(ctx context.Context, tinkerbellMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.TinkerbellMachineConfig, error) {
 	params := []string{"get", eksaTinkerbellMachineResourceType, tinkerbellMachineConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
@@ -1404,7 +1562,88 @@ func (k *Kubectl) GetProvisionedTinkerbellHardware(ctx context.Context, kubeconf
 	return list.Items, nil
 }
 
+// GetTinkerbellMachineNames retrieves the names of the TinkerbellMachines of a cluster. The names
+// are the values of the ownerName label of the Hardware provisioned for the cluster.
+func (k *Kubectl) GetTinkerbellMachineNames(ctx context.Context, kubeconfig, namespace, clusterName string) ([]string, error) {
+	params := []string{
+		"get", tinkerbellMachineResourceType,
+		"-l", fmt.Sprintf("%s=%s", clusterv1.ClusterLabelName, clusterName),
+		"--kubeconfig", kubeconfig,
+		"-o", "jsonpath={.items[*].metadata.name}",
+		"--namespace", namespace,
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting tinkerbell machines of cluster %s: %v", clusterName, err)
+	}
+
+	return strings.Fields(stdOut.String()), nil
+}
+
+// GetTinkerbellHardware retrieves a Tinkerbell Hardware.
+func (k *Kubectl) GetTinkerbellHardware(ctx context.Context, name, namespace, kubeconfig string) (*tinkv1alpha1.Hardware, error) {
+	obj := &tinkv1alpha1.Hardware{}
+	if err := k.GetObject(ctx, TinkerbellHardwareResourceType, name, namespace, kubeconfig, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// PatchTinkerbellHardware applies a JSON merge patch to a Tinkerbell Hardware and returns the
+// patched Hardware. A patch setting metadata.resourceVersion fails if the Hardware changed since
+// that version.
+func (k *Kubectl) PatchTinkerbellHardware(ctx context.Context, name, namespace, kubeconfig string, patch []byte) (*tinkv1alpha1.Hardware, error) {
+	params := []string{
+		"patch", TinkerbellHardwareResourceType, name,
+		"--type", "merge",
+		"-p", string(patch),
+		"-o", "json",
+		"--kubeconfig", kubeconfig,
+		"--namespace", namespace,
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("patching hardware %s: %v", name, err)
+	}
+
+	obj := &tinkv1alpha1.Hardware{}
+	if err := json.Unmarshal(stdOut.Bytes(), obj); err != nil {
+		return nil, fmt.Errorf("parsing patched hardware %s: %v", name, err)
+	}
+
+	return obj, nil
+}
+
+// GetTinkerbellWorkflow retrieves a Tinkerbell Workflow.
+func (k *Kubectl) GetTinkerbellWorkflow(ctx context.Context, name, namespace, kubeconfig string) (*tinkv1alpha1.Workflow, error) {
+	obj := &tinkv1alpha1.Workflow{}
+	if err := k.GetObject(ctx, TinkerbellWorkflowResourceType, name, namespace, kubeconfig, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// GetRufioBMCJob retrieves a Rufio BMCJob.
+func (k *Kubectl) GetRufioBMCJob(ctx context.Context, name, namespace, kubeconfig string) (*rufiov1alpha1.BMCJob, error) {
+	obj := &rufiov1alpha1.BMCJob{}
+	if err := k.GetObject(ctx, RufioBMCJobResourceType, name, namespace, kubeconfig, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
 func (k *Kubectl) GetEksaVSphereMachineConfig(ctx context.Context, vsphereMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error) {
+	if k.apiClient != nil {
+		response := &v1alpha1.VSphereMachineConfig{}
+		if err := k.apiClient.GetObject(ctx, eksaVSphereMachineResourceType, vsphereMachineConfigName, namespace, kubeconfigFile, response); err != nil {
+			return nil, fmt.Errorf("getting eksa vsphere cluster: %v", err)
+		}
+		return response, nil
+	}
+
 	params := []string{"get", eksaVSphereMachineResourceType, vsphereMachineConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1484,6 +1723,14 @@ func (k *Kubectl) ValidateNodesVersion(ctx context.Context, kubeconfig string, k
 }
 
 func (k *Kubectl) GetBundles(ctx context.Context, kubeconfigFile, name, namespace string) (*releasev1alpha1.Bundles, error) {
+	if k.apiClient != nil {
+		response := &releasev1alpha1.Bundles{}
+		if err := k.apiClient.GetObject(ctx, bundlesResourceType, name, namespace, kubeconfigFile, response); err != nil {
+			return nil, fmt.Errorf("getting Bundles: %v", err)
+		}
+		return response, nil
+	}
+
 	params := []string{"get", bundlesResourceType, name, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -1671,6 +1918,10 @@ func (k *Kubectl) get(ctx context.Context, resourceType, namespace, kubeconfig s
 		opt(o)
 	}
 
+	if k.apiClient != nil {
+		return k.getWithAPIClient(ctx, resourceType, namespace, kubeconfig, obj, o.name)
+	}
+
 	params := []string{"get", "--ignore-not-found", "--namespace", namespace, "-o", "json", "--kubeconfig", kubeconfig, resourceType}
 	if o.name != "" {
 		params = append(params, o.name)
@@ -1698,6 +1949,13 @@ func (k *Kubectl) get(ctx context.Context, resourceType, namespace, kubeconfig s
 }
 
 func (k *Kubectl) Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error {
+	if k.apiClient != nil {
+		if err := k.apiClient.Apply(ctx, kubeconfig, obj); err != nil {
+			return fmt.Errorf("applying object: %v", err)
+		}
+		return nil
+	}
+
 	b, err := yaml.Marshal(obj)
 	if err != nil {
 		return fmt.Errorf("marshalling object: %v", err)
@@ -1741,6 +1999,10 @@ func (k *Kubectl) ExecuteCommand(ctx context.Context, opts ...string) (bytes.Buf
 
 // Delete performs a DELETE call to the kube API server authenticating with a kubeconfig file
 func (k *Kubectl) Delete(ctx context.Context, resourceType, name, namespace, kubeconfig string) error {
+	if k.apiClient != nil {
+		return k.apiClient.Delete(ctx, resourceType, name, namespace, kubeconfig)
+	}
+
 	if _, err := k.Execute(ctx, "delete", resourceType, name, "--namespace", namespace, "--kubeconfig", kubeconfig); err != nil {
 		return fmt.Errorf("deleting %s %s in namespace %s: %v", name, resourceType, namespace, err)
 	}
@@ -0,0 +1,108 @@
+package executables
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+)
+
+// KubernetesAPIClient talks to the kube API server directly from the CLI process.
+// When configured, Kubectl uses it instead of running the kubectl binary for the operations built on
+// its core get, list, apply, delete and wait methods, the ClusterManager waits, manifest applies and
+// deletes, the typed getters of the eksa config objects and bundles and the curatedpackages
+// KubectlRunner GetObject and HasResource. Any other operation still runs kubectl, so this is a partial,
+// experimental path: operations with label or field selectors, jsonpath outputs, patches, logs and the
+// raw ExecuteCommand calls are the remaining follow-up work.
+type KubernetesAPIClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string, list kubernetes.ObjectList) error
+	Delete(ctx context.Context, resourceType, name, namespace, kubeconfig string) error
+	Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error
+	ApplyManifest(ctx context.Context, kubeconfig string, data []byte, namespace string) error
+	ApplyManifestForce(ctx context.Context, kubeconfig string, data []byte) error
+	DeleteManifest(ctx context.Context, kubeconfig string, data []byte) error
+	WaitForCondition(ctx context.Context, kubeconfig string, timeout time.Duration, condition, resourceType, name, namespace string) error
+}
+
+type KubectlConfigOpt func(*Kubectl)
+
+// WithKubernetesAPIClient makes Kubectl use the API client for the operations it supports.
+func WithKubernetesAPIClient(client KubernetesAPIClient) KubectlConfigOpt {
+	return func(k *Kubectl) {
+		k.apiClient = client
+	}
+}
+
+func (k *Kubectl) waitWithAPIClient(ctx context.Context, kubeconfig string, timeout string, forCondition string, property string, namespace string, opts ...KubectlOpt) error {
+	timeoutDuration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("unparsable timeout specified: %v", err)
+	}
+	if timeoutDuration < 0 {
+		return fmt.Errorf("negative timeout specified: %v", timeoutDuration)
+	}
+
+	resourceType, name, _ := strings.Cut(property, "/")
+	if waitsForAll(opts...) {
+		name = ""
+	}
+
+	if err = k.apiClient.WaitForCondition(ctx, kubeconfig, timeoutDuration, forCondition, resourceType, name, namespace); err != nil {
+		return fmt.Errorf("executing wait: %w", err)
+	}
+
+	return nil
+}
+
+func waitsForAll(opts ...KubectlOpt) bool {
+	params := []string{}
+	applyOpts(&params, opts...)
+	for _, p := range params {
+		if p == "--all" {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *Kubectl) getWithAPIClient(ctx context.Context, resourceType, namespace, kubeconfig string, obj runtime.Object, name string) error {
+	if name != "" {
+		return k.apiClient.GetObject(ctx, resourceType, name, namespace, kubeconfig, obj)
+	}
+
+	list, ok := obj.(kubernetes.ObjectList)
+	if !ok {
+		return fmt.Errorf("getting %s: %T is not a list", resourceType, obj)
+	}
+	return k.apiClient.ListObjects(ctx, resourceType, namespace, kubeconfig, list)
+}
+
+func (k *Kubectl) createNamespaceWithAPIClient(ctx context.Context, kubeconfig string, namespace string) error {
+	ns := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+	}
+
+	return k.apiClient.Apply(ctx, kubeconfig, ns)
+}
+
+func (k *Kubectl) deleteWithAPIClient(ctx context.Context, resourceType, name, namespace, kubeconfig string, ignoreNotFound bool) error {
+	err := k.apiClient.Delete(ctx, resourceType, name, namespace, kubeconfig)
+	if ignoreNotFound && apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,195 @@
+package executables_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type kubectlAPIClientTest struct {
+	*WithT
+	k          *executables.Kubectl
+	ctx        context.Context
+	cluster    *types.Cluster
+	e          *mockexecutables.MockExecutable
+	client     *mockexecutables.MockKubernetesAPIClient
+	kubeconfig string
+}
+
+func newKubectlAPIClientTest(t *testing.T) *kubectlAPIClientTest {
+	ctrl := gomock.NewController(t)
+	e := mockexecutables.NewMockExecutable(ctrl)
+	client := mockexecutables.NewMockKubernetesAPIClient(ctrl)
+	cluster := &types.Cluster{
+		KubeconfigFile: "c.kubeconfig",
+		Name:           "test-cluster",
+	}
+
+	return &kubectlAPIClientTest{
+		WithT:      NewWithT(t),
+		k:          executables.NewKubectl(e, executables.WithKubernetesAPIClient(client)),
+		ctx:        context.Background(),
+		cluster:    cluster,
+		e:          e,
+		client:     client,
+		kubeconfig: cluster.KubeconfigFile,
+	}
+}
+
+func TestKubectlAPIClientApplyKubeSpecFromBytes(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	data := []byte("data")
+	tt.client.EXPECT().ApplyManifest(tt.ctx, tt.kubeconfig, data, "")
+
+	tt.Expect(tt.k.ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, data)).To(Succeed())
+}
+
+func TestKubectlAPIClientApplyKubeSpecFromBytesWithNamespace(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	data := []byte("data")
+	tt.client.EXPECT().ApplyManifest(tt.ctx, tt.kubeconfig, data, "ns")
+
+	tt.Expect(tt.k.ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, data, "ns")).To(Succeed())
+}
+
+func TestKubectlAPIClientApplyKubeSpecFromBytesForceError(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	data := []byte("data")
+	tt.client.EXPECT().ApplyManifestForce(tt.ctx, tt.kubeconfig, data).Return(errors.New("invalid"))
+
+	tt.Expect(tt.k.ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, data)).To(MatchError("executing apply --force: invalid"))
+}
+
+func TestKubectlAPIClientDeleteKubeSpecFromBytes(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	data := []byte("data")
+	tt.client.EXPECT().DeleteManifest(tt.ctx, tt.kubeconfig, data)
+
+	tt.Expect(tt.k.DeleteKubeSpecFromBytes(tt.ctx, tt.cluster, data)).To(Succeed())
+}
+
+func TestKubectlAPIClientWaitForClusterReady(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().WaitForCondition(tt.ctx, tt.kubeconfig, 5*time.Minute, "Ready", "clusters.cluster.x-k8s.io", "test-cluster", "eksa-system")
+
+	tt.Expect(tt.k.WaitForClusterReady(tt.ctx, tt.cluster, "5m", "test-cluster")).To(Succeed())
+}
+
+func TestKubectlAPIClientWaitForBaseboardManagements(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().WaitForCondition(tt.ctx, tt.kubeconfig, time.Minute, "Contactable", "baseboardmanagements.bmc.tinkerbell.org", "", "eksa-system")
+
+	tt.Expect(tt.k.WaitForBaseboardManagements(tt.ctx, tt.cluster, "1m", "Contactable", "eksa-system")).To(Succeed())
+}
+
+func TestKubectlAPIClientWaitError(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().WaitForCondition(tt.ctx, tt.kubeconfig, time.Minute, "Available", "deployments", "capi-controller", "capi-system").Return(errors.New("timed out"))
+
+	err := tt.k.WaitForDeployment(tt.ctx, tt.cluster, "1m", "Available", "capi-controller", "capi-system")
+	tt.Expect(err).To(MatchError("executing wait: timed out"))
+}
+
+func TestKubectlAPIClientWaitTimeout(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().WaitForCondition(tt.ctx, tt.kubeconfig, time.Minute, "ControlPlaneReady=false", "clusters.cluster.x-k8s.io", "test-cluster", "eksa-system").Return(kubernetes.ErrWaitTimeout)
+
+	err := tt.k.WaitForControlPlaneNotReady(tt.ctx, tt.cluster, "1m", "test-cluster")
+	tt.Expect(errors.Is(err, kubernetes.ErrWaitTimeout)).To(BeTrue(), "error should be a wait timeout")
+}
+
+func TestKubectlAPIClientWaitInvalidTimeout(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+
+	tt.Expect(tt.k.WaitForClusterReady(tt.ctx, tt.cluster, "forever", "test-cluster")).To(MatchError(ContainSubstring("unparsable timeout specified")))
+}
+
+func TestKubectlAPIClientGetObject(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	obj := &clusterv1.Cluster{}
+	tt.client.EXPECT().GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "test-cluster", "eksa-system", tt.kubeconfig, obj)
+
+	tt.Expect(tt.k.GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "test-cluster", "eksa-system", tt.kubeconfig, obj)).To(Succeed())
+}
+
+func TestKubectlAPIClientListObjects(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	list := &clusterv1.ClusterList{}
+	tt.client.EXPECT().ListObjects(tt.ctx, "clusters.cluster.x-k8s.io", "eksa-system", tt.kubeconfig, list)
+
+	tt.Expect(tt.k.ListObjects(tt.ctx, "clusters.cluster.x-k8s.io", "eksa-system", tt.kubeconfig, list)).To(Succeed())
+}
+
+func TestKubectlAPIClientDelete(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().Delete(tt.ctx, "clusters.cluster.x-k8s.io", "test-cluster", "eksa-system", tt.kubeconfig)
+
+	tt.Expect(tt.k.Delete(tt.ctx, "clusters.cluster.x-k8s.io", "test-cluster", "eksa-system", tt.kubeconfig)).To(Succeed())
+}
+
+func TestKubectlAPIClientCreateNamespaceIfNotPresent(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().GetObject(tt.ctx, "namespace", "eksa-system", "", tt.kubeconfig, &corev1.Namespace{}).Return(errors.New("not found"))
+	tt.client.EXPECT().Apply(tt.ctx, tt.kubeconfig, gomock.AssignableToTypeOf(&corev1.Namespace{})).DoAndReturn(
+		func(_ context.Context, _ string, ns *corev1.Namespace) error {
+			tt.Expect(ns.Name).To(Equal("eksa-system"))
+			return nil
+		},
+	)
+
+	tt.Expect(tt.k.CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfig, "eksa-system")).To(Succeed())
+}
+
+func TestKubectlAPIClientGetEksaGitOpsConfig(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().GetObject(tt.ctx, "gitopsconfigs.anywhere.eks.amazonaws.com", "gitops", "default", tt.kubeconfig, &v1alpha1.GitOpsConfig{}).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj *v1alpha1.GitOpsConfig) error {
+			obj.Name = "gitops"
+			return nil
+		},
+	)
+
+	got, err := tt.k.GetEksaGitOpsConfig(tt.ctx, "gitops", tt.kubeconfig, "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Name).To(Equal("gitops"))
+}
+
+func TestKubectlAPIClientGetBundlesError(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	tt.client.EXPECT().GetObject(tt.ctx, "bundles.anywhere.eks.amazonaws.com", "bundles-1", "default", tt.kubeconfig, &releasev1alpha1.Bundles{}).Return(errors.New("error"))
+
+	_, err := tt.k.GetBundles(tt.ctx, tt.kubeconfig, "bundles-1", "default")
+	tt.Expect(err).To(MatchError(ContainSubstring("getting Bundles: error")))
+}
+
+func TestKubectlAPIClientDeleteGitOpsConfigNotFound(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "gitopsconfigs"}, "gitops")
+	tt.client.EXPECT().Delete(tt.ctx, "gitopsconfigs.anywhere.eks.amazonaws.com", "gitops", "default", tt.kubeconfig).Return(fmt.Errorf("deleting: %w", notFound))
+
+	tt.Expect(tt.k.DeleteGitOpsConfig(tt.ctx, tt.cluster, "gitops", "default")).To(Succeed())
+}
+
+func TestKubectlAPIClientDeleteClusterNotFound(t *testing.T) {
+	tt := newKubectlAPIClientTest(t)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "clusters"}, "test-cluster")
+	tt.client.EXPECT().Delete(tt.ctx, "clusters.cluster.x-k8s.io", "test-cluster", "eksa-system", tt.kubeconfig).Return(notFound)
+
+	tt.Expect(tt.k.DeleteCluster(tt.ctx, tt.cluster, tt.cluster)).To(MatchError(ContainSubstring("deleting cluster test-cluster")))
+}
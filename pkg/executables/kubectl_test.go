@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
@@ -364,6 +366,23 @@ func TestWaitForTimeout(t *testing.T) {
 	if err == nil || err.Error() != "error: timed out waiting for condition myCondition on myProperty" {
 		t.Errorf("kubectl private wait didn't timeout")
 	}
+	if !errors.Is(err, kubernetes.ErrWaitTimeout) {
+		t.Errorf("kubectl private wait timeout should be ErrWaitTimeout")
+	}
+}
+
+func TestKubectlWaitForControlPlaneNotReadyTimeout(t *testing.T) {
+	tt := newKubectlTest(t)
+	timeout := "5m"
+	expectedTimeout := "300.00s"
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"wait", "--timeout", expectedTimeout, "--for=condition=ControlPlaneReady=false", "clusters.cluster.x-k8s.io/test", "--kubeconfig", tt.cluster.KubeconfigFile, "-n", "eksa-system",
+	).Return(bytes.Buffer{}, errors.New("error: timed out waiting for the condition on clusters/test"))
+
+	err := tt.k.WaitForControlPlaneNotReady(tt.ctx, tt.cluster, timeout, "test")
+	tt.Expect(errors.Is(err, kubernetes.ErrWaitTimeout)).To(BeTrue(), "error should be a wait timeout")
 }
 
 func TestKubectlWaitForService(t *testing.T) {
@@ -619,6 +638,9 @@ func TestKubectlGetMachines(t *testing.T) {
 			jsonResponseFile: "testdata/kubectl_machines_no_node_ref_no_labels.json",
 			wantMachines: []types.Machine{
 				{
+					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -661,6 +683,9 @@ func TestKubectlGetMachines(t *testing.T) {
 					},
 				},
 				{
+					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
@@ -690,6 +715,7 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
@@ -705,6 +731,7 @@ func TestKubectlGetMachines(t *testing.T) {
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
@@ -727,6 +754,7 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
@@ -780,6 +808,7 @@ func TestKubectlGetMachines(t *testing.T) {
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
@@ -820,6 +849,7 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name": "eksa-test-capd",
 							"cluster.x-k8s.io/etcd-cluster": "",
@@ -2204,6 +2234,33 @@ func TestKubectlGetEksaFluxConfig(t *testing.T) {
 	}
 }
 
+func TestKubectlGetEksaDockerDatacenterConfig(t *testing.T) {
+	kubeconfig := "/my/kubeconfig"
+	namespace := "eksa-system"
+	eksaDockerDatacenterResourceType := fmt.Sprintf("dockerdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
+
+	returnConfig := &v1alpha1.DockerDatacenterConfig{
+		Spec: v1alpha1.DockerDatacenterConfigSpec{
+			ExtraMounts: []v1alpha1.DockerMount{{HostPath: "/data", ContainerPath: "/data"}},
+		},
+	}
+	returnConfigBytes, err := json.Marshal(returnConfig)
+	if err != nil {
+		t.Errorf("failed to create output object for test")
+	}
+
+	k, ctx, _, e := newKubectl(t)
+	expectedParam := []string{"get", eksaDockerDatacenterResourceType, "testDockerConfig", "-o", "json", "--kubeconfig", kubeconfig, "--namespace", namespace}
+	e.EXPECT().Execute(ctx, gomock.Eq(expectedParam)).Return(*bytes.NewBuffer(returnConfigBytes), nil)
+	got, err := k.GetEksaDockerDatacenterConfig(ctx, "testDockerConfig", kubeconfig, namespace)
+	if err != nil {
+		t.Errorf("Kubectl.GetEksaDockerDatacenterConfig() error = %v, want error = nil", err)
+	}
+	if !reflect.DeepEqual(got, returnConfig) {
+		t.Errorf("Kubectl.GetEksaDockerDatacenterConfig() = %v, want %v", got, returnConfig)
+	}
+}
+
 func TestKubectlDeleteFluxConfig(t *testing.T) {
 	namespace := "eksa-system"
 	kubeconfig := "/my/kubeconfig"
@@ -2542,6 +2599,30 @@ func TestKubectlHasResourceWithGetError(t *testing.T) {
 	tt.Expect(has).To(BeFalse())
 }
 
+func TestKubectlDeleteKubeProxy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("golden path", func(t *testing.T) {
+		tt := newKubectlTest(t)
+		tt.e.EXPECT().Execute(
+			tt.ctx,
+			"delete", "daemonset,configmap", "kube-proxy", "--kubeconfig", tt.kubeconfig, "--namespace", "kube-system", "--ignore-not-found=true",
+		).Return(bytes.Buffer{}, nil)
+
+		tt.Expect(tt.k.DeleteKubeProxy(tt.ctx, tt.cluster)).To(Succeed())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		tt := newKubectlTest(t)
+		tt.e.EXPECT().Execute(
+			tt.ctx,
+			"delete", "daemonset,configmap", "kube-proxy", "--kubeconfig", tt.kubeconfig, "--namespace", "kube-system", "--ignore-not-found=true",
+		).Return(bytes.Buffer{}, fmt.Errorf("bam"))
+
+		tt.Expect(tt.k.DeleteKubeProxy(tt.ctx, tt.cluster)).To(MatchError(ContainSubstring("bam")))
+	})
+}
+
 func TestKubectlDeletePackageResources(t *testing.T) {
 	t.Parallel()
 
@@ -2583,3 +2664,153 @@ func TestKubectlDeletePackageResources(t *testing.T) {
 		tt.Expect(tt.k.DeletePackageResources(tt.ctx, tt.cluster, "clusterName")).To(MatchError(ContainSubstring("boom")))
 	})
 }
+
+func TestKubectlGetTinkerbellMachineNames(t *testing.T) {
+	tt := newKubectlTest(t)
+	kubeconfig := "foo/bar"
+
+	params := []string{
+		"get", "tinkerbellmachines.infrastructure.cluster.x-k8s.io",
+		"-l", "cluster.x-k8s.io/cluster-name=test-cluster",
+		"--kubeconfig", kubeconfig,
+		"-o", "jsonpath={.items[*].metadata.name}",
+		"--namespace", tt.namespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Eq(params)).Return(*bytes.NewBufferString("machine-1 machine-2"), nil)
+
+	names, err := tt.k.GetTinkerbellMachineNames(tt.ctx, kubeconfig, tt.namespace, "test-cluster")
+	tt.Expect(err).To(Succeed())
+	tt.Expect(names).To(Equal([]string{"machine-1", "machine-2"}))
+}
+
+func TestKubectlGetTinkerbellMachineNamesError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error from execute"))
+
+	_, err := tt.k.GetTinkerbellMachineNames(tt.ctx, "foo/bar", tt.namespace, "test-cluster")
+	tt.Expect(err).To(MatchError(ContainSubstring("getting tinkerbell machines of cluster test-cluster")))
+}
+
+func TestKubectlGetTinkerbellHardwareSuccess(t *testing.T) {
+	newKubectlGetterTest(t).withResourceType(
+		"hardware.tinkerbell.org",
+	).withGetter(func(tt *kubectlGetterTest) (client.Object, error) {
+		return tt.k.GetTinkerbellHardware(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
+	}).withJson(
+		`{"apiVersion":"tinkerbell.org/v1alpha1","kind":"Hardware","metadata":{"name":"hw1","resourceVersion":"12"}}`,
+	).andWant(
+		&tinkv1alpha1.Hardware{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "tinkerbell.org/v1alpha1",
+				Kind:       "Hardware",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "hw1",
+				ResourceVersion: "12",
+			},
+		},
+	).testSuccess()
+}
+
+func TestKubectlGetTinkerbellHardwareError(t *testing.T) {
+	newKubectlGetterTest(t).withResourceType(
+		"hardware.tinkerbell.org",
+	).withGetter(func(tt *kubectlGetterTest) (client.Object, error) {
+		return tt.k.GetTinkerbellHardware(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
+	}).testError()
+}
+
+func TestKubectlPatchTinkerbellHardware(t *testing.T) {
+	tt := newKubectlTest(t)
+	kubeconfig := "foo/bar"
+	patch := `{"metadata":{"resourceVersion":"12","labels":{"a":"b"}}}`
+
+	params := []string{
+		"patch", "hardware.tinkerbell.org", "hw1",
+		"--type", "merge",
+		"-p", patch,
+		"-o", "json",
+		"--kubeconfig", kubeconfig,
+		"--namespace", tt.namespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Eq(params)).Return(
+		*bytes.NewBufferString(`{"metadata":{"name":"hw1","resourceVersion":"13","labels":{"a":"b"}}}`), nil,
+	)
+
+	hw, err := tt.k.PatchTinkerbellHardware(tt.ctx, "hw1", tt.namespace, kubeconfig, []byte(patch))
+	tt.Expect(err).To(Succeed())
+	tt.Expect(hw.ResourceVersion).To(Equal("13"))
+	tt.Expect(hw.Labels).To(HaveKeyWithValue("a", "b"))
+}
+
+func TestKubectlPatchTinkerbellHardwareError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("the object has been modified"))
+
+	_, err := tt.k.PatchTinkerbellHardware(tt.ctx, "hw1", tt.namespace, "foo/bar", []byte("{}"))
+	tt.Expect(err).To(MatchError(ContainSubstring("patching hardware hw1: the object has been modified")))
+}
+
+func TestKubectlGetTinkerbellWorkflowSuccess(t *testing.T) {
+	newKubectlGetterTest(t).withResourceType(
+		"workflows.tinkerbell.org",
+	).withGetter(func(tt *kubectlGetterTest) (client.Object, error) {
+		return tt.k.GetTinkerbellWorkflow(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
+	}).withJson(
+		`{"apiVersion":"tinkerbell.org/v1alpha1","kind":"Workflow","metadata":{"name":"wipe-hw1"},"status":{"state":"STATE_SUCCESS"}}`,
+	).andWant(
+		&tinkv1alpha1.Workflow{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "tinkerbell.org/v1alpha1",
+				Kind:       "Workflow",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "wipe-hw1",
+			},
+			Status: tinkv1alpha1.WorkflowStatus{
+				State: tinkv1alpha1.WorkflowStateSuccess,
+			},
+		},
+	).testSuccess()
+}
+
+func TestKubectlGetTinkerbellWorkflowError(t *testing.T) {
+	newKubectlGetterTest(t).withResourceType(
+		"workflows.tinkerbell.org",
+	).withGetter(func(tt *kubectlGetterTest) (client.Object, error) {
+		return tt.k.GetTinkerbellWorkflow(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
+	}).testError()
+}
+
+func TestKubectlGetRufioBMCJobSuccess(t *testing.T) {
+	newKubectlGetterTest(t).withResourceType(
+		"bmcjobs.bmc.tinkerbell.org",
+	).withGetter(func(tt *kubectlGetterTest) (client.Object, error) {
+		return tt.k.GetRufioBMCJob(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
+	}).withJson(
+		`{"apiVersion":"bmc.tinkerbell.org/v1alpha1","kind":"BMCJob","metadata":{"name":"power-off-hw1"},"status":{"conditions":[{"type":"Completed","status":"True"}]}}`,
+	).andWant(
+		&rufiov1alpha1.BMCJob{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "bmc.tinkerbell.org/v1alpha1",
+				Kind:       "BMCJob",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "power-off-hw1",
+			},
+			Status: rufiov1alpha1.BMCJobStatus{
+				Conditions: []rufiov1alpha1.BMCJobCondition{
+					{Type: rufiov1alpha1.JobCompleted, Status: rufiov1alpha1.ConditionTrue},
+				},
+			},
+		},
+	).testSuccess()
+}
+
+func TestKubectlGetRufioBMCJobError(t *testing.T) {
+	newKubectlGetterTest(t).withResourceType(
+		"bmcjobs.bmc.tinkerbell.org",
+	).withGetter(func(tt *kubectlGetterTest) (client.Object, error) {
+		return tt.k.GetRufioBMCJob(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
+	}).testError()
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/executables (interfaces: Executable,DockerClient,DockerContainer)
+// Source: github.com/aws/eks-anywhere/pkg/executables (interfaces: Executable,DockerClient,DockerContainer,KubernetesAPIClient)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -8,9 +8,12 @@ import (
 	bytes "bytes"
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	kubernetes "github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	executables "github.com/aws/eks-anywhere/pkg/executables"
 	gomock "github.com/golang/mock/gomock"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // MockExecutable is a mock of Executable interface.
@@ -251,3 +254,138 @@ func (mr *MockDockerContainerMockRecorder) Init(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockDockerContainer)(nil).Init), arg0)
 }
+
+// MockKubernetesAPIClient is a mock of KubernetesAPIClient interface.
+type MockKubernetesAPIClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubernetesAPIClientMockRecorder
+}
+
+// MockKubernetesAPIClientMockRecorder is the mock recorder for MockKubernetesAPIClient.
+type MockKubernetesAPIClientMockRecorder struct {
+	mock *MockKubernetesAPIClient
+}
+
+// NewMockKubernetesAPIClient creates a new mock instance.
+func NewMockKubernetesAPIClient(ctrl *gomock.Controller) *MockKubernetesAPIClient {
+	mock := &MockKubernetesAPIClient{ctrl: ctrl}
+	mock.recorder = &MockKubernetesAPIClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubernetesAPIClient) EXPECT() *MockKubernetesAPIClientMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockKubernetesAPIClient) Apply(arg0 context.Context, arg1 string, arg2 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockKubernetesAPIClientMockRecorder) Apply(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockKubernetesAPIClient)(nil).Apply), arg0, arg1, arg2)
+}
+
+// ApplyManifest mocks base method.
+func (m *MockKubernetesAPIClient) ApplyManifest(arg0 context.Context, arg1 string, arg2 []byte, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyManifest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyManifest indicates an expected call of ApplyManifest.
+func (mr *MockKubernetesAPIClientMockRecorder) ApplyManifest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyManifest", reflect.TypeOf((*MockKubernetesAPIClient)(nil).ApplyManifest), arg0, arg1, arg2, arg3)
+}
+
+// ApplyManifestForce mocks base method.
+func (m *MockKubernetesAPIClient) ApplyManifestForce(arg0 context.Context, arg1 string, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyManifestForce", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyManifestForce indicates an expected call of ApplyManifestForce.
+func (mr *MockKubernetesAPIClientMockRecorder) ApplyManifestForce(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyManifestForce", reflect.TypeOf((*MockKubernetesAPIClient)(nil).ApplyManifestForce), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockKubernetesAPIClient) Delete(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKubernetesAPIClientMockRecorder) Delete(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKubernetesAPIClient)(nil).Delete), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteManifest mocks base method.
+func (m *MockKubernetesAPIClient) DeleteManifest(arg0 context.Context, arg1 string, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteManifest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteManifest indicates an expected call of DeleteManifest.
+func (mr *MockKubernetesAPIClientMockRecorder) DeleteManifest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManifest", reflect.TypeOf((*MockKubernetesAPIClient)(nil).DeleteManifest), arg0, arg1, arg2)
+}
+
+// GetObject mocks base method.
+func (m *MockKubernetesAPIClient) GetObject(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockKubernetesAPIClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockKubernetesAPIClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// ListObjects mocks base method.
+func (m *MockKubernetesAPIClient) ListObjects(arg0 context.Context, arg1, arg2, arg3 string, arg4 kubernetes.ObjectList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockKubernetesAPIClientMockRecorder) ListObjects(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockKubernetesAPIClient)(nil).ListObjects), arg0, arg1, arg2, arg3, arg4)
+}
+
+// WaitForCondition mocks base method.
+func (m *MockKubernetesAPIClient) WaitForCondition(arg0 context.Context, arg1 string, arg2 time.Duration, arg3, arg4, arg5, arg6 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForCondition", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForCondition indicates an expected call of WaitForCondition.
+func (mr *MockKubernetesAPIClientMockRecorder) WaitForCondition(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCondition", reflect.TypeOf((*MockKubernetesAPIClient)(nil).WaitForCondition), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
@@ -1,15 +1,19 @@
 package features
 
 const (
-	CloudStackProviderEnvVar        = "CLOUDSTACK_PROVIDER"
-	CloudStackKubeVipDisabledEnvVar = "CLOUDSTACK_KUBE_VIP_DISABLED"
-	SnowProviderEnvVar              = "SNOW_PROVIDER"
-	FullLifecycleAPIEnvVar          = "FULL_LIFECYCLE_API"
-	FullLifecycleGate               = "FullLifecycleAPI"
-	CheckpointEnabledEnvVar         = "CHECKPOINT_ENABLED"
-	NutanixProviderEnvVar           = "NUTANIX_PROVIDER"
-	UseNewWorkflowsEnvVar           = "USE_NEW_WORKFLOWS"
-	K8s124SupportEnvVar             = "K8S_1_24_SUPPORT"
+	CloudStackProviderEnvVar         = "CLOUDSTACK_PROVIDER"
+	CloudStackKubeVipDisabledEnvVar  = "CLOUDSTACK_KUBE_VIP_DISABLED"
+	SnowProviderEnvVar               = "SNOW_PROVIDER"
+	FullLifecycleAPIEnvVar           = "FULL_LIFECYCLE_API"
+	FullLifecycleGate                = "FullLifecycleAPI"
+	CheckpointEnabledEnvVar          = "CHECKPOINT_ENABLED"
+	NutanixProviderEnvVar            = "NUTANIX_PROVIDER"
+	UseNewWorkflowsEnvVar            = "USE_NEW_WORKFLOWS"
+	K8s124SupportEnvVar              = "K8S_1_24_SUPPORT"
+	VSphereNativeClientEnvVar        = "VSPHERE_NATIVE_CLIENT"
+	InProcessKubectlEnvVar           = "KUBECTL_IN_PROCESS"
+	CloudStackNativeClientEnvVar     = "CLOUDSTACK_NATIVE_CLIENT"
+	StrictArtifactVerificationEnvVar = "STRICT_ARTIFACT_VERIFICATION"
 )
 
 func FeedGates(featureGates []string) {
@@ -86,3 +90,40 @@ func UseNewWorkflows() Feature {
 		IsActive: globalFeatures.isActiveForEnvVar(UseNewWorkflowsEnvVar),
 	}
 }
+
+// VSphereNativeClient returns a feature that is active if the VSPHERE_NATIVE_CLIENT environment variable is true.
+// When active, vSphere operations use the vSphere APIs directly instead of the govc executable.
+func VSphereNativeClient() Feature {
+	return Feature{
+		Name:     "Native vSphere client",
+		IsActive: globalFeatures.isActiveForEnvVar(VSphereNativeClientEnvVar),
+	}
+}
+
+// InProcessKubectl returns a feature that is active if the KUBECTL_IN_PROCESS environment variable is true.
+// When active, the CLI talks to the kube API server directly for the subset of kubectl operations
+// supported by executables.KubernetesAPIClient and still runs kubectl for the rest.
+func InProcessKubectl() Feature {
+	return Feature{
+		Name:     "Experimental partial in-process kubectl",
+		IsActive: globalFeatures.isActiveForEnvVar(InProcessKubectlEnvVar),
+	}
+}
+
+// CloudStackNativeClient returns a feature that is active if the CLOUDSTACK_NATIVE_CLIENT environment variable is true.
+// When active, CloudStack operations use the CloudStack API directly instead of the cmk executable.
+func CloudStackNativeClient() Feature {
+	return Feature{
+		Name:     "Native CloudStack client",
+		IsActive: globalFeatures.isActiveForEnvVar(CloudStackNativeClientEnvVar),
+	}
+}
+
+// StrictArtifactVerification returns a feature that is active if the STRICT_ARTIFACT_VERIFICATION environment variable is true.
+// When active, artifacts without published digests fail verification instead of being used unverified.
+func StrictArtifactVerification() Feature {
+	return Feature{
+		Name:     "Strict artifact verification",
+		IsActive: globalFeatures.isActiveForEnvVar(StrictArtifactVerificationEnvVar),
+	}
+}
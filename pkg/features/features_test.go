@@ -91,3 +91,11 @@ func TestWithK8s124FeatureFlag(t *testing.T) {
 	g.Expect(os.Setenv(K8s124SupportEnvVar, "true")).To(Succeed())
 	g.Expect(IsActive(K8s124Support())).To(BeTrue())
 }
+
+func TestWithStrictArtifactVerificationFeatureFlag(t *testing.T) {
+	g := NewWithT(t)
+	setupContext(t)
+
+	g.Expect(os.Setenv(StrictArtifactVerificationEnvVar, "true")).To(Succeed())
+	g.Expect(IsActive(StrictArtifactVerification())).To(BeTrue())
+}
@@ -0,0 +1,136 @@
+package files
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	sha256Algorithm = "sha256"
+	sha512Algorithm = "sha512"
+)
+
+// Checksums holds the hex encoded digests of a file.
+type Checksums struct {
+	SHA256 string `json:"sha256,omitempty"`
+	SHA512 string `json:"sha512,omitempty"`
+}
+
+// ArchiveChecksums returns the checksums published in the bundle for an archive.
+func ArchiveChecksums(archive releasev1.Archive) Checksums {
+	return Checksums{
+		SHA256: archive.SHA256,
+		SHA512: archive.SHA512,
+	}
+}
+
+// IsEmpty returns true if none of the digests is set.
+func (c Checksums) IsEmpty() bool {
+	return c.SHA256 == "" && c.SHA512 == ""
+}
+
+// ChecksumMismatchError is returned when the digest of a file doesn't match the expected one.
+type ChecksumMismatchError struct {
+	URI       string
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for [%s]: expected %s %s, got %s", e.URI, e.Algorithm, e.Expected, e.Actual)
+}
+
+// MissingChecksumsError is returned in strict mode when an artifact has no published digests to be verified against.
+type MissingChecksumsError struct {
+	URI string
+}
+
+func (e *MissingChecksumsError) Error() string {
+	return fmt.Sprintf("no published checksum to verify [%s] against, refusing to use it unverified with %s enabled", e.URI, features.StrictArtifactVerificationEnvVar)
+}
+
+// AllowUnverified is called for artifacts without published digests. With strict artifact verification enabled,
+// it returns a MissingChecksumsError. Otherwise, it logs a warning naming the artifact, which is used unverified.
+func AllowUnverified(uri string) error {
+	if features.IsActive(features.StrictArtifactVerification()) {
+		return &MissingChecksumsError{URI: uri}
+	}
+
+	logger.Info("Warning: no published checksum found, using artifact without verifying it", "artifact", uri)
+	return nil
+}
+
+// Verify reads src until EOF, copying it to dst if not nil, and verifies its digests against the expected ones.
+// Only the expected digests that are set are verified; if none is set, AllowUnverified decides whether the file
+// can be used. It returns all the computed digests.
+func Verify(uri string, src io.Reader, dst io.Writer, expected Checksums) (Checksums, error) {
+	actual, err := Compute(uri, src, dst)
+	if err != nil {
+		return Checksums{}, err
+	}
+
+	return actual, CompareChecksums(uri, expected, actual)
+}
+
+// Compute reads src until EOF, copying it to dst if not nil, and returns its digests.
+func Compute(uri string, src io.Reader, dst io.Writer) (Checksums, error) {
+	sha256Hash := sha256.New()
+	sha512Hash := sha512.New()
+	writers := []io.Writer{sha256Hash, sha512Hash}
+	if dst != nil {
+		writers = append(writers, dst)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), src); err != nil {
+		return Checksums{}, fmt.Errorf("reading [%s]: %v", uri, err)
+	}
+
+	return Checksums{
+		SHA256: hexDigest(sha256Hash),
+		SHA512: hexDigest(sha512Hash),
+	}, nil
+}
+
+// CompareChecksums verifies the actual digests of a file against the expected ones.
+// Only the expected digests that are set are verified; if none is set, AllowUnverified decides whether the file
+// can be used.
+func CompareChecksums(uri string, expected, actual Checksums) error {
+	if expected.IsEmpty() {
+		return AllowUnverified(uri)
+	}
+
+	if err := compareDigest(uri, sha256Algorithm, expected.SHA256, actual.SHA256); err != nil {
+		return err
+	}
+
+	return compareDigest(uri, sha512Algorithm, expected.SHA512, actual.SHA512)
+}
+
+func hexDigest(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// compareDigest compares a single digest. A digest that is not published for one algorithm is skipped, as long
+// as another one is, which CompareChecksums ensures.
+func compareDigest(uri, algorithm, expected, actual string) error {
+	if expected == "" || strings.EqualFold(expected, actual) {
+		return nil
+	}
+
+	return &ChecksumMismatchError{
+		URI:       uri,
+		Algorithm: algorithm,
+		Expected:  expected,
+		Actual:    actual,
+	}
+}
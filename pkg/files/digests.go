@@ -0,0 +1,85 @@
+package files
+
+import (
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DigestManifestFileName is the name of the digest manifest written alongside downloaded artifacts.
+const DigestManifestFileName = "artifact-digests.yaml"
+
+// DigestManifest records the digests of a set of downloaded artifacts, computed while downloading them, so their
+// integrity can be audited after they are transferred, for example into an air-gapped environment.
+type DigestManifest struct {
+	Artifacts []ArtifactDigest `json:"artifacts"`
+}
+
+// ArtifactDigest holds the digests of a single artifact.
+type ArtifactDigest struct {
+	// Path of the artifact, relative to the digest manifest.
+	Path string `json:"path"`
+	// URI the artifact was downloaded from.
+	URI string `json:"uri,omitempty"`
+	// Unverified is set when the artifact has no published digests, so the recorded ones were computed
+	// while downloading it without being verified against a trusted source.
+	Unverified bool `json:"unverified,omitempty"`
+	Checksums
+}
+
+// Add records the digests of an artifact, replacing any previous record for the same path.
+func (m *DigestManifest) Add(artifact ArtifactDigest) {
+	for i, a := range m.Artifacts {
+		if a.Path == artifact.Path {
+			m.Artifacts[i] = artifact
+			return
+		}
+	}
+	m.Artifacts = append(m.Artifacts, artifact)
+}
+
+// Get returns the digests recorded for path.
+func (m *DigestManifest) Get(path string) (ArtifactDigest, bool) {
+	for _, a := range m.Artifacts {
+		if a.Path == path {
+			return a, true
+		}
+	}
+	return ArtifactDigest{}, false
+}
+
+// Marshal returns the yaml representation of the manifest, with the artifacts sorted by path.
+func (m *DigestManifest) Marshal() ([]byte, error) {
+	sort.Slice(m.Artifacts, func(i, j int) bool {
+		return m.Artifacts[i].Path < m.Artifacts[j].Path
+	})
+
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling digest manifest: %v", err)
+	}
+
+	return content, nil
+}
+
+// ParseDigestManifest parses a digest manifest from its yaml representation.
+func ParseDigestManifest(content []byte) (*DigestManifest, error) {
+	m := &DigestManifest{}
+	if err := yaml.UnmarshalStrict(content, m); err != nil {
+		return nil, fmt.Errorf("parsing digest manifest: %v", err)
+	}
+
+	return m, nil
+}
+
+// Unverified returns the artifacts that were recorded without being verified.
+func (m *DigestManifest) Unverified() []ArtifactDigest {
+	var unverified []ArtifactDigest
+	for _, a := range m.Artifacts {
+		if a.Unverified {
+			unverified = append(unverified, a)
+		}
+	}
+	return unverified
+}
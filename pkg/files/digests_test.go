@@ -0,0 +1,60 @@
+package files_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/files"
+)
+
+func TestDigestManifestMarshalAndParse(t *testing.T) {
+	g := NewWithT(t)
+	m := &files.DigestManifest{}
+	m.Add(files.ArtifactDigest{
+		Path:      "bundle-release.yaml",
+		Checksums: files.Checksums{SHA256: "b"},
+	})
+	m.Add(files.ArtifactDigest{
+		Path:       "1.23/cluster-api/core-components.yaml",
+		URI:        "https://assets/core-components.yaml",
+		Unverified: true,
+		Checksums:  files.Checksums{SHA256: "a", SHA512: "a512"},
+	})
+	m.Add(files.ArtifactDigest{
+		Path:      "bundle-release.yaml",
+		Checksums: files.Checksums{SHA256: "c"},
+	})
+
+	content, err := m.Marshal()
+	g.Expect(err).To(BeNil())
+	g.Expect(string(content)).To(Equal(`artifacts:
+- path: 1.23/cluster-api/core-components.yaml
+  sha256: a
+  sha512: a512
+  unverified: true
+  uri: https://assets/core-components.yaml
+- path: bundle-release.yaml
+  sha256: c
+`))
+
+	parsed, err := files.ParseDigestManifest(content)
+	g.Expect(err).To(BeNil())
+	g.Expect(parsed).To(Equal(m))
+
+	artifact, ok := parsed.Get("bundle-release.yaml")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(artifact.SHA256).To(Equal("c"))
+	_, ok = parsed.Get("missing.yaml")
+	g.Expect(ok).To(BeFalse())
+
+	unverified := parsed.Unverified()
+	g.Expect(unverified).To(HaveLen(1))
+	g.Expect(unverified[0].Path).To(Equal("1.23/cluster-api/core-components.yaml"))
+}
+
+func TestParseDigestManifestError(t *testing.T) {
+	g := NewWithT(t)
+	_, err := files.ParseDigestManifest([]byte("artifacts:\n- path: a\n  md5: b\n"))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing digest manifest")))
+}
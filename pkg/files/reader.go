@@ -3,13 +3,16 @@ package files
 import (
 	"embed"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 )
 
 const (
+	httpScheme  = "http"
 	httpsScheme = "https"
 	embedScheme = "embed"
 )
@@ -102,3 +105,73 @@ func readLocalFile(filename string) ([]byte, error) {
 
 	return data, nil
 }
+
+// Download streams the file at uri to dst, verifying its digests against the expected ones on the fly.
+// Only the expected digests that are set are verified; if none is set, AllowUnverified decides whether the file
+// can be used. It returns all the computed digests.
+// Contrary to ReadFile, both http and https urls are downloaded.
+func (r *Reader) Download(uri string, dst io.Writer, expected Checksums) (Checksums, error) {
+	src, err := r.open(uri)
+	if err != nil {
+		return Checksums{}, err
+	}
+	defer src.Close()
+
+	return Verify(uri, src, dst, expected)
+}
+
+// DownloadUnverified streams the file at uri to dst and returns its digests, without verifying them.
+// It's meant for artifacts that are never published with digests, so the computed ones can be recorded.
+func (r *Reader) DownloadUnverified(uri string, dst io.Writer) (Checksums, error) {
+	src, err := r.open(uri)
+	if err != nil {
+		return Checksums{}, err
+	}
+	defer src.Close()
+
+	return Compute(uri, src, dst)
+}
+
+func (r *Reader) open(uri string) (io.ReadCloser, error) {
+	url, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file url [%s]: %v", uri, err)
+	}
+
+	switch url.Scheme {
+	case httpScheme, httpsScheme:
+		return r.openHttpFile(uri)
+	case embedScheme:
+		f, err := r.embedFS.Open(strings.TrimPrefix(url.Path, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("failed opening embed file [%s]: %v", url.Path, err)
+		}
+		return f, nil
+	default:
+		f, err := os.Open(uri)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening local file [%s]: %v", uri, err)
+		}
+		return f, nil
+	}
+}
+
+func (r *Reader) openHttpFile(uri string) (io.ReadCloser, error) {
+	request, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating http GET request for downloading file: %v", err)
+	}
+
+	request.Header.Set("User-Agent", r.userAgent)
+	resp, err := r.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed downloading file from url [%s]: %v", uri, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed downloading file from url [%s]: unexpected status %s", uri, resp.Status)
+	}
+
+	return resp.Body, nil
+}
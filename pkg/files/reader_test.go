@@ -1,12 +1,18 @@
 package files_test
 
 import (
+	"bytes"
 	"embed"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/files"
 )
 
@@ -71,3 +77,125 @@ func TestReaderReadFileSuccess(t *testing.T) {
 		})
 	}
 }
+
+const (
+	fileSHA256 = "b701870861d6ff0565b7078ee799ae7362323298a814d7af4d2dce6cb8d8b674"
+	fileSHA512 = "99bf27ab8057e318135770854cd298474e50390f8224c7ddefc5397b495f912989fcdf097ef5563bd68e6e023462f03f77d4f5ae8ec396af521a342fbe5818f7"
+)
+
+func TestReaderDownloadSuccess(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		testName string
+		uri      string
+		expected files.Checksums
+	}{
+		{
+			testName: "local file",
+			uri:      "testdata/file.yaml",
+			expected: files.Checksums{SHA256: fileSHA256},
+		},
+		{
+			testName: "embed file",
+			uri:      "embed:///testdata/file.yaml",
+			expected: files.Checksums{SHA512: fileSHA512},
+		},
+		{
+			testName: "http file",
+			uri:      server.URL + "/file.yaml",
+			expected: files.Checksums{SHA256: strings.ToUpper(fileSHA256), SHA512: fileSHA512},
+		},
+		{
+			testName: "no expected checksums",
+			uri:      "testdata/file.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			r := files.NewReader(files.WithEmbedFS(testdataFS))
+			dst := &bytes.Buffer{}
+			got, err := r.Download(tt.uri, dst, tt.expected)
+			g.Expect(err).To(BeNil())
+			g.Expect(got).To(Equal(files.Checksums{SHA256: fileSHA256, SHA512: fileSHA512}))
+			test.AssertContentToFile(t, dst.String(), "testdata/file.yaml")
+		})
+	}
+}
+
+func TestReaderDownloadChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+	r := files.NewReader()
+	_, err := r.Download("testdata/file.yaml", &bytes.Buffer{}, files.Checksums{SHA256: fileSHA256, SHA512: "invalid"})
+
+	mismatch := &files.ChecksumMismatchError{}
+	g.Expect(errors.As(err, &mismatch)).To(BeTrue())
+	g.Expect(mismatch.Algorithm).To(Equal("sha512"))
+	g.Expect(err).To(MatchError("checksum mismatch for [testdata/file.yaml]: expected sha512 invalid, got " + fileSHA512))
+}
+
+func TestReaderDownloadMissingChecksumsStrict(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(features.StrictArtifactVerificationEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	r := files.NewReader()
+	_, err := r.Download("testdata/file.yaml", &bytes.Buffer{}, files.Checksums{})
+
+	missing := &files.MissingChecksumsError{}
+	g.Expect(errors.As(err, &missing)).To(BeTrue())
+	g.Expect(missing.URI).To(Equal("testdata/file.yaml"))
+}
+
+func TestReaderDownloadUnverified(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(features.StrictArtifactVerificationEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	r := files.NewReader()
+	dst := &bytes.Buffer{}
+
+	got, err := r.DownloadUnverified("testdata/file.yaml", dst)
+	g.Expect(err).To(BeNil())
+	g.Expect(got).To(Equal(files.Checksums{SHA256: fileSHA256, SHA512: fileSHA512}))
+	test.AssertContentToFile(t, dst.String(), "testdata/file.yaml")
+}
+
+func TestReaderDownloadError(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		testName string
+		uri      string
+	}{
+		{
+			testName: "missing local file",
+			uri:      "fake-local-file.yaml",
+		},
+		{
+			testName: "missing embed file",
+			uri:      "embed:///fake-local-file.yaml",
+		},
+		{
+			testName: "missing http file",
+			uri:      server.URL + "/fake-local-file.yaml",
+		},
+		{
+			testName: "invalid uri",
+			uri:      ":domain.com/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			r := files.NewReader(files.WithEmbedFS(testdataFS))
+			_, err := r.Download(tt.uri, &bytes.Buffer{}, files.Checksums{})
+			g.Expect(err).NotTo(BeNil())
+		})
+	}
+}
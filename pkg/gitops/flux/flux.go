@@ -256,6 +256,7 @@ func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []val
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "Flux path",
+				Category:    validations.CategoryGitOps,
 				Remediation: "Please provide a different path or different cluster name",
 				Err:         fc.validateRemoteConfigPathDoesNotExist(ctx),
 			}
@@ -0,0 +1,44 @@
+package govmomi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vmware/govmomi/find"
+)
+
+// NotFoundError is returned when a vSphere object doesn't exist at the given path.
+type NotFoundError struct {
+	Kind string
+	Path string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s '%s' not found", e.Kind, e.Path)
+}
+
+// MultipleFoundError is returned when a vSphere object name or path suffix matches more than one object.
+type MultipleFoundError struct {
+	Kind string
+	Name string
+	Root string
+}
+
+func (e *MultipleFoundError) Error() string {
+	return fmt.Sprintf("specified %s '%s' maps to multiple paths within '%s'", e.Kind, e.Name, e.Root)
+}
+
+// IsNotFound returns true if the error, or any error it wraps, is a NotFoundError.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
+
+// notFoundOr converts govmomi finder not found errors into a NotFoundError, leaving any other error untouched.
+func notFoundOr(err error, kind, path string) error {
+	var findNotFound *find.NotFoundError
+	if errors.As(err, &findNotFound) {
+		return &NotFoundError{Kind: kind, Path: path}
+	}
+	return err
+}
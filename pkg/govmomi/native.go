@@ -0,0 +1,378 @@
+package govmomi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	govcUsernameKey   = "GOVC_USERNAME"
+	govcPasswordKey   = "GOVC_PASSWORD"
+	govcURLKey        = "GOVC_URL"
+	govcInsecureKey   = "GOVC_INSECURE"
+	govcDatacenterKey = "GOVC_DATACENTER"
+	vSphereServerKey  = "VSPHERE_SERVER"
+
+	maxRetries    = 5
+	backOffPeriod = 5 * time.Second
+)
+
+// NativeClient talks to vCenter through the vSphere SOAP and REST APIs. It reads the connection settings
+// from the same env vars as govc, so it can be used as a drop-in replacement of the govc executable.
+type NativeClient struct {
+	*retrier.Retrier
+	envMap      map[string]string
+	thumbprints map[string]string
+	session     *nativeSession
+}
+
+type NativeClientOpt func(*NativeClient)
+
+// WithNativeClientEnvMap makes the client read its connection settings from the given map instead of the process env.
+func WithNativeClientEnvMap(envMap map[string]string) NativeClientOpt {
+	return func(c *NativeClient) {
+		c.envMap = envMap
+	}
+}
+
+// WithNativeClientRetrier overrides the retrier used for the vCenter calls.
+func WithNativeClientRetrier(r *retrier.Retrier) NativeClientOpt {
+	return func(c *NativeClient) {
+		c.Retrier = r
+	}
+}
+
+func NewNativeClient(opts ...NativeClientOpt) *NativeClient {
+	c := &NativeClient{
+		Retrier:     retrier.New(time.Duration(math.MaxInt64), retrier.WithRetryPolicy(retryPolicy)),
+		thumbprints: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// retryPolicy retries failed calls up to maxRetries times, except when the object doesn't exist.
+func retryPolicy(totalRetries int, err error) (retry bool, wait time.Duration) {
+	if IsNotFound(err) || totalRetries >= maxRetries {
+		return false, 0
+	}
+	return true, backOffPeriod
+}
+
+type credentials struct {
+	server     string
+	username   string
+	password   string
+	insecure   bool
+	datacenter string
+}
+
+func (c credentials) key() string {
+	return strings.Join([]string{c.server, c.username, c.password, strconv.FormatBool(c.insecure), c.datacenter}, "|")
+}
+
+type nativeSession struct {
+	key    string
+	vim    *govmomi.Client
+	rest   *rest.Client
+	user   *url.Userinfo
+	finder *find.Finder
+}
+
+func (c *NativeClient) lookupEnv(key string) string {
+	if c.envMap != nil {
+		return c.envMap[key]
+	}
+	return os.Getenv(key)
+}
+
+func (c *NativeClient) firstEnv(keys ...string) (string, error) {
+	for _, key := range keys {
+		if value := c.lookupEnv(key); value != "" {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not set or is empty", keys[len(keys)-1])
+}
+
+func (c *NativeClient) credentials() (*credentials, error) {
+	username, err := c.firstEnv(config.EksavSphereUsernameKey, govcUsernameKey)
+	if err != nil {
+		return nil, err
+	}
+	password, err := c.firstEnv(config.EksavSpherePasswordKey, govcPasswordKey)
+	if err != nil {
+		return nil, err
+	}
+	server, err := c.firstEnv(vSphereServerKey, govcURLKey)
+	if err != nil {
+		return nil, err
+	}
+
+	insecure := false
+	if value := c.lookupEnv(govcInsecureKey); value != "" {
+		if insecure, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid %s value %s: %v", govcInsecureKey, value, err)
+		}
+	}
+
+	return &credentials{
+		server:     server,
+		username:   username,
+		password:   password,
+		insecure:   insecure,
+		datacenter: c.lookupEnv(govcDatacenterKey),
+	}, nil
+}
+
+// getSession returns a logged in session for the current connection settings, reusing the existing one
+// if the settings haven't changed since it was created.
+func (c *NativeClient) getSession(ctx context.Context) (*nativeSession, error) {
+	creds, err := c.credentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed vSphere client validations: %v", err)
+	}
+
+	key := creds.key() + "|" + c.thumbprints[creds.server]
+	if c.session != nil && c.session.key == key {
+		return c.session, nil
+	}
+
+	if err = c.Close(ctx); err != nil {
+		logger.V(4).Info("Failed closing previous vSphere session", "error", err)
+	}
+
+	s, err := c.login(ctx, creds, creds.insecure)
+	if err != nil {
+		return nil, err
+	}
+	s.key = key
+	c.session = s
+
+	return s, nil
+}
+
+func (c *NativeClient) soapClient(server string, insecure bool) (*soap.Client, *url.URL, error) {
+	u, err := soap.ParseURL(server)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing vSphere server url %s: %v", server, err)
+	}
+
+	sc := soap.NewClient(u, insecure)
+	if thumbprint, ok := c.thumbprints[server]; ok {
+		sc.SetThumbprint(u.Host, thumbprint)
+		// Pin the certificate by its thumbprint instead of verifying it against the system roots.
+		tlsConfig := sc.DefaultTransport().TLSClientConfig
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = verifyThumbprint(u.Host, thumbprint)
+	}
+
+	return sc, u, nil
+}
+
+func verifyThumbprint(host, thumbprint string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("host %s didn't present any certificate", host)
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("parsing certificate from host %s: %v", host, err)
+		}
+		if peer := soap.ThumbprintSHA1(cert); peer != thumbprint {
+			return fmt.Errorf("host %s thumbprint %s does not match %s", host, peer, thumbprint)
+		}
+		return nil
+	}
+}
+
+func (c *NativeClient) login(ctx context.Context, creds *credentials, insecure bool) (*nativeSession, error) {
+	sc, u, err := c.soapClient(creds.server, insecure)
+	if err != nil {
+		return nil, err
+	}
+
+	vc, err := vim25.NewClient(ctx, sc)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to vSphere server %s: %v", creds.server, err)
+	}
+
+	user := url.UserPassword(creds.username, creds.password)
+	client := &govmomi.Client{Client: vc, SessionManager: session.NewManager(vc)}
+	if err = client.Login(ctx, user); err != nil {
+		return nil, fmt.Errorf("logging in to vSphere server %s: %v", u.Host, err)
+	}
+
+	finder := find.NewFinder(vc, true)
+	if creds.datacenter != "" {
+		dc, err := finder.Datacenter(ctx, creds.datacenter)
+		if err != nil {
+			logger.V(4).Info("Default datacenter not found, using absolute paths only", "datacenter", creds.datacenter, "error", err)
+		} else {
+			finder.SetDatacenter(dc)
+		}
+	}
+
+	return &nativeSession{vim: client, user: user, finder: finder}, nil
+}
+
+// restClient returns the vSphere automation API client of the session, logging in on first use.
+func (s *nativeSession) restClient(ctx context.Context) (*rest.Client, error) {
+	if s.rest != nil {
+		return s.rest, nil
+	}
+
+	rc := rest.NewClient(s.vim.Client)
+	if err := rc.Login(ctx, s.user); err != nil {
+		return nil, fmt.Errorf("logging in to vSphere automation API: %v", err)
+	}
+	s.rest = rc
+
+	return rc, nil
+}
+
+// datacenterFinder returns a finder that resolves relative paths against the given datacenter.
+func (s *nativeSession) datacenterFinder(ctx context.Context, datacenter string) (*find.Finder, error) {
+	finder := find.NewFinder(s.vim.Client, true)
+	dc, err := finder.Datacenter(ctx, datacenter)
+	if err != nil {
+		return nil, notFoundOr(err, "datacenter", datacenter)
+	}
+	return finder.SetDatacenter(dc), nil
+}
+
+func (s *nativeSession) logout(ctx context.Context) error {
+	if s.rest != nil {
+		if err := s.rest.Logout(ctx); err != nil {
+			return err
+		}
+	}
+	return s.vim.Logout(ctx)
+}
+
+// Close logs out from the current vSphere session, if any.
+func (c *NativeClient) Close(ctx context.Context) error {
+	if c == nil || c.session == nil {
+		return nil
+	}
+
+	logger.V(3).Info("Logging out from current vSphere session")
+	s := c.session
+	c.session = nil
+	if err := s.logout(ctx); err != nil {
+		return fmt.Errorf("logging out from vSphere: %v", err)
+	}
+
+	return nil
+}
+
+func (c *NativeClient) ValidateVCenterConnection(ctx context.Context, server string) error {
+	skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
+	skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	client := &http.Client{Transport: skipVerifyTransport}
+
+	if _, err := client.Get("https://" + server); err != nil {
+		return fmt.Errorf("failed to reach server %s: %v", server, err)
+	}
+
+	return nil
+}
+
+func (c *NativeClient) ValidateVCenterAuthentication(ctx context.Context) error {
+	creds, err := c.credentials()
+	if err != nil {
+		return fmt.Errorf("failed vSphere client validations: %v", err)
+	}
+
+	err = c.Retry(func() error {
+		s, err := c.login(ctx, creds, true)
+		if err != nil {
+			return err
+		}
+		return s.logout(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("vSphere authentication failed: %v", err)
+	}
+
+	return nil
+}
+
+// IsCertSelfSigned returns true if the vCenter certificate can't be verified with the system roots
+// or a configured thumbprint.
+func (c *NativeClient) IsCertSelfSigned(ctx context.Context) bool {
+	creds, err := c.credentials()
+	if err != nil {
+		return true
+	}
+
+	sc, _, err := c.soapClient(creds.server, false)
+	if err != nil {
+		return true
+	}
+
+	_, err = vim25.NewClient(ctx, sc)
+	return err != nil
+}
+
+func (c *NativeClient) GetCertThumbprint(ctx context.Context) (string, error) {
+	creds, err := c.credentials()
+	if err != nil {
+		return "", fmt.Errorf("failed vSphere client validations: %v", err)
+	}
+
+	u, err := soap.ParseURL(creds.server)
+	if err != nil {
+		return "", fmt.Errorf("parsing vSphere server url %s: %v", creds.server, err)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(host, "443")
+	}
+
+	dialer := &tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve thumbprint: %v", err)
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", fmt.Errorf("unable to retrieve thumbprint: server %s didn't present any certificate", host)
+	}
+
+	return soap.ThumbprintSHA1(certs[0]), nil
+}
+
+// ConfigureCertThumbprint makes the client trust the certificate with the given thumbprint for the server.
+func (c *NativeClient) ConfigureCertThumbprint(ctx context.Context, server, thumbprint string) error {
+	c.thumbprints[server] = thumbprint
+	return nil
+}
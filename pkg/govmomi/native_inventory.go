@@ -0,0 +1,509 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const byteToGiB = 1073741824.0
+
+type folderType string
+
+const (
+	datastoreFolder folderType = "datastore"
+	vmFolder        folderType = "vm"
+)
+
+func (c *NativeClient) SearchTemplate(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig) (string, error) {
+	var paths []string
+	err := c.Retry(func() error {
+		s, err := c.getSession(ctx)
+		if err != nil {
+			return err
+		}
+		paths, err = s.findPathsByName(ctx, "/"+datacenter, "VirtualMachine", filepath.Base(machineConfig.Spec.Template))
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting template: %w", err)
+	}
+
+	template, err := matchPathSuffix(paths, machineConfig.Spec.Template, "template", datacenter)
+	if err != nil {
+		return "", err
+	}
+	if template == "" {
+		logger.V(2).Info(fmt.Sprintf("Template '%s' not found", machineConfig.Spec.Template))
+	}
+
+	return template, nil
+}
+
+func (c *NativeClient) TemplateHasSnapshot(ctx context.Context, template string) (bool, error) {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	vm, err := s.finder.VirtualMachine(ctx, template)
+	if err != nil {
+		return false, fmt.Errorf("failed to get snapshot details: %w", notFoundOr(err, "template", template))
+	}
+
+	var props mo.VirtualMachine
+	if err = vm.Properties(ctx, vm.Reference(), []string{"snapshot"}, &props); err != nil {
+		return false, fmt.Errorf("failed to get snapshot details: %w", err)
+	}
+
+	return props.Snapshot != nil && len(props.Snapshot.RootSnapshotList) > 0, nil
+}
+
+func (c *NativeClient) GetWorkloadAvailableSpace(ctx context.Context, datastore string) (float64, error) {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ds, err := s.finder.Datastore(ctx, datastore)
+	if err != nil {
+		return 0, fmt.Errorf("getting datastore info: %w", notFoundOr(err, "datastore", datastore))
+	}
+
+	var props mo.Datastore
+	if err = ds.Properties(ctx, ds.Reference(), []string{"summary"}, &props); err != nil {
+		return 0, fmt.Errorf("getting datastore info: %w", err)
+	}
+
+	return float64(props.Summary.FreeSpace) / byteToGiB, nil
+}
+
+func (c *NativeClient) DatacenterExists(ctx context.Context, datacenter string) (bool, error) {
+	exists := false
+	err := c.Retry(func() error {
+		s, err := c.getSession(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.finder.Datacenter(ctx, datacenter)
+		if err == nil {
+			exists = true
+			return nil
+		}
+		if IsNotFound(notFoundOr(err, "datacenter", datacenter)) {
+			exists = false
+			return nil
+		}
+
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get datacenter: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (c *NativeClient) NetworkExists(ctx context.Context, network string) (bool, error) {
+	exists := false
+	err := c.Retry(func() error {
+		s, err := c.getSession(ctx)
+		if err != nil {
+			return err
+		}
+
+		exists, err = s.networkExists(ctx, network)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed checking if network '%s' exists: %w", network, err)
+	}
+
+	return exists, nil
+}
+
+func (c *NativeClient) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, _ *bool) error {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	datacenter := datacenterConfig.Spec.Datacenter
+	machineConfig.Spec.Datastore, err = prependPath(datastoreFolder, machineConfig.Spec.Datastore, datacenter)
+	if err != nil {
+		return err
+	}
+	err = c.Retry(func() error {
+		if _, err := s.finder.Datastore(ctx, machineConfig.Spec.Datastore); err != nil {
+			if s.isValidPath(ctx, filepath.Dir(machineConfig.Spec.Datastore)) {
+				return fmt.Errorf("valid path, but '%s' is not a datastore", filepath.Base(machineConfig.Spec.Datastore))
+			}
+			return notFoundOr(err, "datastore", machineConfig.Spec.Datastore)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get datastore: %w", err)
+	}
+	logger.MarkPass("Datastore validated")
+
+	if len(machineConfig.Spec.Folder) > 0 {
+		machineConfig.Spec.Folder, err = prependPath(vmFolder, machineConfig.Spec.Folder, datacenter)
+		if err != nil {
+			return err
+		}
+		err = c.Retry(func() error {
+			if err := s.ensureFolder(ctx, machineConfig.Spec.Folder); err != nil {
+				currPath := "/" + datacenter + "/"
+				dirs := strings.Split(machineConfig.Spec.Folder, "/")
+				for _, dir := range dirs[2:] {
+					currPath += dir + "/"
+					if !s.isValidPath(ctx, currPath) {
+						return &NotFoundError{Kind: "intermediate directory", Path: currPath}
+					}
+				}
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get folder: %w", err)
+		}
+		logger.MarkPass("Folder validated")
+	}
+
+	machineConfig.Spec.ResourcePool, err = c.findResourcePool(ctx, s, "/"+datacenter, machineConfig.Spec.ResourcePool, datacenter)
+	if err != nil {
+		return err
+	}
+	logger.MarkPass("Resource pool validated")
+
+	return nil
+}
+
+func (c *NativeClient) ValidateVCenterSetupFailureDomain(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, failureDomain *v1alpha1.VSphereFailureDomain) error {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Retry(func() error {
+		_, err := s.finder.ClusterComputeResource(ctx, failureDomain.ComputeCluster)
+		return notFoundOr(err, "compute cluster", failureDomain.ComputeCluster)
+	})
+	if err != nil {
+		return fmt.Errorf("getting compute cluster: %w", err)
+	}
+
+	err = c.Retry(func() error {
+		_, err := s.finder.Datastore(ctx, failureDomain.Datastore)
+		return notFoundOr(err, "datastore", failureDomain.Datastore)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get datastore: %w", err)
+	}
+
+	failureDomain.ResourcePool, err = c.findResourcePool(ctx, s, failureDomain.ComputeCluster, failureDomain.ResourcePool, datacenterConfig.Spec.Datacenter)
+	if err != nil {
+		return err
+	}
+
+	if failureDomain.Network != "" {
+		exists, err := c.NetworkExists(ctx, failureDomain.Network)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return &NotFoundError{Kind: "network", Path: failureDomain.Network}
+		}
+	}
+
+	return nil
+}
+
+// CreateVMAntiAffinityRule creates a DRS rule that keeps the given VMs in different hosts of the compute cluster,
+// replacing any existing rule with the same name.
+func (c *NativeClient) CreateVMAntiAffinityRule(ctx context.Context, computeCluster, name string, vms []string) error {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := s.finder.ClusterComputeResource(ctx, computeCluster)
+	if err != nil {
+		return fmt.Errorf("listing DRS rules in compute cluster %s: %w", computeCluster, notFoundOr(err, "compute cluster", computeCluster))
+	}
+
+	clusterConfig, err := cluster.Configuration(ctx)
+	if err != nil {
+		return fmt.Errorf("listing DRS rules in compute cluster %s: %w", computeCluster, err)
+	}
+
+	vmRefs := make([]types.ManagedObjectReference, 0, len(vms))
+	for _, vmPath := range vms {
+		vm, err := s.finder.VirtualMachine(ctx, vmPath)
+		if err != nil {
+			return fmt.Errorf("creating DRS rule %s: %w", name, notFoundOr(err, "vm", vmPath))
+		}
+		vmRefs = append(vmRefs, vm.Reference())
+	}
+
+	spec := &types.ClusterConfigSpecEx{}
+	for _, rule := range clusterConfig.Rule {
+		if info := rule.GetClusterRuleInfo(); info.Name == name {
+			spec.RulesSpec = append(spec.RulesSpec, types.ClusterRuleSpec{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationRemove, RemoveKey: info.Key},
+			})
+		}
+	}
+
+	enabled := true
+	spec.RulesSpec = append(spec.RulesSpec, types.ClusterRuleSpec{
+		ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+		Info: &types.ClusterAntiAffinityRuleSpec{
+			ClusterRuleInfo: types.ClusterRuleInfo{Name: name, Enabled: &enabled},
+			Vm:              vmRefs,
+		},
+	})
+
+	task, err := cluster.Reconfigure(ctx, spec, true)
+	if err != nil {
+		return fmt.Errorf("creating DRS rule %s: %w", name, err)
+	}
+	if err = task.Wait(ctx); err != nil {
+		return fmt.Errorf("creating DRS rule %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// CleanupVms powers off and deletes all the VMs of the GOVC_DATACENTER datacenter whose name starts with
+// the cluster name. With dryRun, the VMs are only listed.
+func (c *NativeClient) CleanupVms(ctx context.Context, clusterName string, dryRun bool) error {
+	creds, err := c.credentials()
+	if err != nil {
+		return fmt.Errorf("failed vSphere client validations: %v", err)
+	}
+	if creds.datacenter == "" {
+		return fmt.Errorf("%s is not set or is empty", govcDatacenterKey)
+	}
+
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	err = c.Retry(func() error {
+		paths, err = s.findPathsByName(ctx, "/"+creds.datacenter, "VirtualMachine", clusterName+"*")
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("getting vm list: %w", err)
+	}
+
+	for _, vmPath := range paths {
+		if dryRun {
+			logger.Info("Found ", "vm_name", vmPath)
+			continue
+		}
+
+		vm, err := s.finder.VirtualMachine(ctx, vmPath)
+		if err != nil {
+			logger.Info("WARN: Failed to get vm ", "vm_name", vmPath, "error", err)
+			continue
+		}
+
+		if err = powerOffVM(ctx, vm); err != nil {
+			logger.Info("WARN: Failed to power off vm ", "vm_name", vmPath, "error", err)
+		}
+
+		task, err := vm.Destroy(ctx)
+		if err == nil {
+			err = task.Wait(ctx)
+		}
+		if err != nil {
+			logger.Info("WARN: Failed to delete vm ", "vm_name", vmPath, "error", err)
+		} else {
+			logger.Info("Deleted ", "vm_name", vmPath)
+		}
+	}
+
+	return nil
+}
+
+// powerOffVM powers off the VM if it's not already powered off.
+func powerOffVM(ctx context.Context, vm *object.VirtualMachine) error {
+	state, err := vm.PowerState(ctx)
+	if err != nil {
+		return err
+	}
+	if state == types.VirtualMachinePowerStatePoweredOff {
+		return nil
+	}
+
+	task, err := vm.PowerOff(ctx)
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
+
+func (c *NativeClient) findResourcePool(ctx context.Context, s *nativeSession, root, resourcePool, datacenter string) (string, error) {
+	var paths []string
+	err := c.Retry(func() error {
+		var err error
+		paths, err = s.findPathsByName(ctx, root, "ResourcePool", filepath.Base(resourcePool))
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting resource pool: %w", err)
+	}
+
+	resourcePool = strings.TrimPrefix(resourcePool, "*/")
+	pool, err := matchPathSuffix(paths, resourcePool, "resource pool", datacenter)
+	if err != nil {
+		return "", err
+	}
+	if pool == "" {
+		return "", &NotFoundError{Kind: "resource pool", Path: resourcePool}
+	}
+
+	return pool, nil
+}
+
+// findPathsByName returns the inventory paths of all the objects of the given type and name under root.
+func (s *nativeSession) findPathsByName(ctx context.Context, root, kind, name string) ([]string, error) {
+	container, err := s.finder.ManagedObjectList(ctx, root)
+	if err != nil {
+		return nil, notFoundOr(err, "inventory path", root)
+	}
+	if len(container) == 0 {
+		return nil, &NotFoundError{Kind: "inventory path", Path: root}
+	}
+
+	v, err := view.NewManager(s.vim.Client).CreateContainerView(ctx, container[0].Object.Reference(), []string{kind}, true)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = v.Destroy(ctx)
+	}()
+
+	refs, err := v.Find(ctx, []string{kind}, property.Filter{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		p, err := find.InventoryPath(ctx, s.vim.Client, ref)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+
+	return paths, nil
+}
+
+// matchPathSuffix returns the only path ending with suffix, or an empty string if none does.
+func matchPathSuffix(paths []string, suffix, kind, datacenter string) (string, error) {
+	found := ""
+	for _, p := range paths {
+		if !strings.HasSuffix(p, suffix) {
+			continue
+		}
+		if found != "" {
+			return "", &MultipleFoundError{Kind: kind, Name: suffix, Root: datacenter}
+		}
+		found = p
+	}
+
+	return found, nil
+}
+
+func (s *nativeSession) networkExists(ctx context.Context, network string) (bool, error) {
+	_, err := s.finder.Network(ctx, network)
+	if err == nil {
+		return true, nil
+	}
+	if IsNotFound(notFoundOr(err, "network", network)) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (s *nativeSession) isValidPath(ctx context.Context, path string) bool {
+	objects, err := s.finder.ManagedObjectList(ctx, path)
+	return err == nil && len(objects) > 0
+}
+
+// ensureFolder creates the folder at path if it doesn't exist. The parent folder must exist.
+func (s *nativeSession) ensureFolder(ctx context.Context, path string) error {
+	if _, err := s.finder.Folder(ctx, path); err == nil {
+		return nil
+	} else if !IsNotFound(notFoundOr(err, "folder", path)) {
+		return err
+	}
+
+	parent, err := s.finder.Folder(ctx, filepath.Dir(path))
+	if err != nil {
+		return notFoundOr(err, "folder", filepath.Dir(path))
+	}
+
+	if _, err = parent.CreateFolder(ctx, filepath.Base(path)); err != nil {
+		if soap.IsSoapFault(err) {
+			if _, exists := soap.ToSoapFault(err).VimFault().(types.DuplicateName); exists {
+				return nil
+			}
+		}
+		return fmt.Errorf("creating folder %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// objectReference returns the managed object at the given inventory path.
+func (s *nativeSession) objectReference(ctx context.Context, path string) (mo.Reference, error) {
+	objects, err := s.finder.ManagedObjectList(ctx, path)
+	if err != nil {
+		return nil, notFoundOr(err, "object", path)
+	}
+	switch len(objects) {
+	case 0:
+		return nil, &NotFoundError{Kind: "object", Path: path}
+	case 1:
+		return objects[0].Object, nil
+	default:
+		return nil, &MultipleFoundError{Kind: "object", Name: path, Root: "/"}
+	}
+}
+
+func prependPath(folder folderType, folderPath string, datacenter string) (string, error) {
+	prefix := fmt.Sprintf("/%s", datacenter)
+	if !strings.HasPrefix(folderPath, prefix) {
+		modPath := fmt.Sprintf("%s/%s/%s", prefix, folder, folderPath)
+		logger.V(4).Info(fmt.Sprintf("Relative %s path specified, using path %s", folder, modPath))
+		return modPath, nil
+	}
+	prefix += fmt.Sprintf("/%s", folder)
+	if !strings.HasPrefix(folderPath, prefix) {
+		return folderPath, fmt.Errorf("invalid folder type, expected path under %s", prefix)
+	}
+	return folderPath, nil
+}
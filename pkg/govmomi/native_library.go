@@ -0,0 +1,393 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/library/finder"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	templateSnapshotName      = "root"
+	libraryUpdatePollInterval = 3 * time.Second
+)
+
+func (c *NativeClient) LibraryElementExists(ctx context.Context, library string) (bool, error) {
+	results, err := c.findLibraryElements(ctx, library)
+	if err != nil {
+		return false, fmt.Errorf("failed getting library to check if it exists: %w", err)
+	}
+
+	return len(results) > 0, nil
+}
+
+// GetLibraryElementContentVersion returns the content version of a library item, or "-1" if it doesn't exist.
+func (c *NativeClient) GetLibraryElementContentVersion(ctx context.Context, element string) (string, error) {
+	results, err := c.findLibraryElements(ctx, element)
+	if err != nil {
+		return "", fmt.Errorf("failed getting library element info: %w", err)
+	}
+	if len(results) == 0 {
+		return "-1", nil
+	}
+
+	item, ok := results[0].GetResult().(library.Item)
+	if !ok {
+		return "", fmt.Errorf("library element %s is not a library item", element)
+	}
+
+	return item.ContentVersion, nil
+}
+
+func (c *NativeClient) DeleteLibraryElement(ctx context.Context, element string) error {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+	rc, err := s.restClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	m := library.NewManager(rc)
+	result, err := findLibraryElement(ctx, m, element)
+	if err != nil {
+		return fmt.Errorf("failed deleting library item: %w", err)
+	}
+
+	switch e := result.GetResult().(type) {
+	case library.Item:
+		err = m.DeleteLibraryItem(ctx, &e)
+	case library.Library:
+		err = m.DeleteLibrary(ctx, &e)
+	default:
+		err = fmt.Errorf("%s is a %T", element, e)
+	}
+	if err != nil {
+		return fmt.Errorf("failed deleting library item: %w", err)
+	}
+
+	return nil
+}
+
+func (c *NativeClient) CreateLibrary(ctx context.Context, datastore, libraryName string) error {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	ds, err := s.finder.Datastore(ctx, datastore)
+	if err != nil {
+		return fmt.Errorf("creating library %s: %w", libraryName, notFoundOr(err, "datastore", datastore))
+	}
+
+	rc, err := s.restClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = library.NewManager(rc).CreateLibrary(ctx, library.Library{
+		Name: libraryName,
+		Type: "LOCAL",
+		Storage: []library.StorageBackings{
+			{
+				DatastoreID: ds.Reference().Value,
+				Type:        "DATASTORE",
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating library %s: %w", libraryName, err)
+	}
+
+	return nil
+}
+
+// ImportTemplate makes vCenter pull the OVA from the given URL into a new item of the library.
+// vCenter verifies the sha256 of the OVA once it's received, if set, failing the import on mismatch.
+// Without checksums, files.AllowUnverified decides whether the OVA can be imported.
+func (c *NativeClient) ImportTemplate(ctx context.Context, libraryName, ovaURL, name string, checksums files.Checksums) error {
+	if checksums.IsEmpty() {
+		if err := files.AllowUnverified(ovaURL); err != nil {
+			return fmt.Errorf("importing template: %w", err)
+		}
+	}
+	logger.V(4).Info("Importing template", "ova", ovaURL, "templateName", name)
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+	rc, err := s.restClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	m := library.NewManager(rc)
+	result, err := findLibraryElement(ctx, m, libraryName)
+	if err != nil {
+		return fmt.Errorf("importing template: %w", err)
+	}
+	lib, ok := result.GetResult().(library.Library)
+	if !ok {
+		return fmt.Errorf("importing template: %s is not a library", libraryName)
+	}
+
+	itemID, err := m.CreateLibraryItem(ctx, library.Item{
+		Name:      name,
+		Type:      library.ItemTypeOVF,
+		LibraryID: lib.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("importing template: %w", err)
+	}
+
+	session, err := m.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return fmt.Errorf("importing template: %w", err)
+	}
+
+	if err = addLibraryItemFileFromURI(ctx, m, rc, session, filepath.Base(ovaURL), ovaURL, checksums); err != nil {
+		return fmt.Errorf("importing template: %w", err)
+	}
+
+	if err = m.WaitOnLibraryItemUpdateSession(ctx, session, libraryUpdatePollInterval, nil); err != nil {
+		return fmt.Errorf("importing template: %w", err)
+	}
+
+	return nil
+}
+
+// addLibraryItemFileFromURI works like library.Manager.AddLibraryItemFileFromURI, also setting the
+// checksum of the file so vCenter verifies it.
+func addLibraryItemFileFromURI(ctx context.Context, m *library.Manager, rc *rest.Client, session, fileName, uri string, checksums files.Checksums) error {
+	resp, err := rc.Head(uri)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	var fingerprint string
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		fingerprint = rc.Thumbprint(resp.Request.URL.Host)
+		if fingerprint == "" && rc.DefaultTransport().TLSClientConfig.InsecureSkipVerify {
+			fingerprint = soap.ThumbprintSHA1(resp.TLS.PeerCertificates[0])
+		}
+	}
+
+	file := library.UpdateFile{
+		Name:       fileName,
+		SourceType: "PULL",
+		Size:       resp.ContentLength,
+		SourceEndpoint: &library.TransferEndpoint{
+			URI:                      uri,
+			SSLCertificateThumbprint: fingerprint,
+		},
+	}
+	if checksums.SHA256 != "" {
+		file.Checksum = &library.Checksum{
+			Algorithm: "SHA256",
+			Checksum:  checksums.SHA256,
+		}
+	}
+
+	if _, err = m.AddLibraryItemFile(ctx, session, file); err != nil {
+		return err
+	}
+
+	return m.CompleteLibraryItemUpdateSession(ctx, session)
+}
+
+// DeployTemplateFromLibrary deploys the library item as a VM, optionally resizes its disk, takes the root
+// snapshot needed for linked clones and marks it as template.
+func (c *NativeClient) DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, libraryName, datacenter, datastore, network, resourcePool string, resizeBRDisk bool) error {
+	logger.V(4).Info("Deploying template", "dir", templateDir, "templateName", templateName)
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	vm, err := c.deployTemplate(ctx, s, libraryName, templateName, templateDir, datacenter, datastore, network, resourcePool)
+	if err != nil {
+		return fmt.Errorf("deploying template: %w", err)
+	}
+
+	if resizeBRDisk {
+		if err = resizeTemplateDisk(ctx, vm, templateName); err != nil {
+			return err
+		}
+	}
+
+	logger.V(4).Info("Taking template snapshot", "templateName", filepath.Join(templateDir, templateName))
+	task, err := vm.CreateSnapshot(ctx, templateSnapshotName, "", false, false)
+	if err == nil {
+		err = task.Wait(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed taking vm snapshot: %w", err)
+	}
+
+	logger.V(4).Info("Marking vm as template", "templateName", filepath.Join(templateDir, templateName))
+	if err = vm.MarkAsTemplate(ctx); err != nil {
+		return fmt.Errorf("marking VM as template: %w", err)
+	}
+
+	return nil
+}
+
+func (c *NativeClient) deployTemplate(ctx context.Context, s *nativeSession, libraryName, templateName, deployFolder, datacenter, datastore, network, resourcePool string) (*object.VirtualMachine, error) {
+	rc, err := s.restClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templateInLibraryPath := filepath.Join(libraryName, templateName)
+	if !filepath.IsAbs(templateInLibraryPath) {
+		templateInLibraryPath = fmt.Sprintf("/%s", templateInLibraryPath)
+	}
+
+	result, err := findLibraryElement(ctx, library.NewManager(rc), templateInLibraryPath)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := result.GetResult().(library.Item)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a library item", templateInLibraryPath)
+	}
+
+	f, err := s.datacenterFinder(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := f.Datastore(ctx, datastore)
+	if err != nil {
+		return nil, notFoundOr(err, "datastore", datastore)
+	}
+	pool, err := f.ResourcePool(ctx, resourcePool)
+	if err != nil {
+		return nil, notFoundOr(err, "resource pool", resourcePool)
+	}
+	net, err := f.Network(ctx, network)
+	if err != nil {
+		return nil, notFoundOr(err, "network", network)
+	}
+
+	err = c.Retry(func() error {
+		return s.ensureFolder(ctx, deployFolder)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating folder: %w", err)
+	}
+	folder, err := f.Folder(ctx, deployFolder)
+	if err != nil {
+		return nil, notFoundOr(err, "folder", deployFolder)
+	}
+
+	ref, err := vcenter.NewManager(rc).DeployLibraryItem(ctx, item.ID, vcenter.Deploy{
+		DeploymentSpec: vcenter.DeploymentSpec{
+			Name:               templateName,
+			DefaultDatastoreID: ds.Reference().Value,
+			AcceptAllEULA:      true,
+			NetworkMappings: []vcenter.NetworkMapping{
+				{
+					Key:   "nic0", // needed for Ubuntu
+					Value: net.Reference().Value,
+				},
+				{
+					Key:   "VM Network", // needed for Bottlerocket
+					Value: net.Reference().Value,
+				},
+			},
+			StorageProvisioning: "thin",
+		},
+		Target: vcenter.Target{
+			ResourcePoolID: pool.Reference().Value,
+			FolderID:       folder.Reference().Value,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return object.NewVirtualMachine(s.vim.Client, *ref), nil
+}
+
+// resizeTemplateDisk grows the Bottlerocket data disk. Templates with two disks get the second one resized
+// to 20G, templates with a single disk get it resized to 22G.
+func resizeTemplateDisk(ctx context.Context, vm *object.VirtualMachine, templateName string) error {
+	logger.V(4).Info("Getting devices info for template")
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return fmt.Errorf("getting devices info for template %s: %w", templateName, err)
+	}
+
+	var disk1, disk2 *types.VirtualDisk
+	for _, device := range devices.SelectByType((*types.VirtualDisk)(nil)) {
+		label := device.GetVirtualDevice().DeviceInfo.GetDescription().Label
+		if strings.EqualFold(label, "Hard disk 1") {
+			disk1 = device.(*types.VirtualDisk)
+		} else if strings.EqualFold(label, "Hard disk 2") {
+			disk2 = device.(*types.VirtualDisk)
+		}
+	}
+
+	disk := disk2
+	diskSizeInGB := int64(20)
+	if disk == nil {
+		disk = disk1
+		diskSizeInGB = 22
+	}
+	if disk == nil {
+		return fmt.Errorf("template %v is not valid as there are no associated disks", templateName)
+	}
+
+	logger.V(4).Info("Resizing template disk", "disk", disk.DeviceInfo.GetDescription().Label, "sizeInGB", diskSizeInGB)
+	disk.CapacityInKB = diskSizeInGB * 1024 * 1024
+	disk.CapacityInBytes = disk.CapacityInKB * 1024
+	if err = vm.EditDevice(ctx, disk); err != nil {
+		return fmt.Errorf("resizing disk %v to %dG: %w", disk.DeviceInfo.GetDescription().Label, diskSizeInGB, err)
+	}
+
+	return nil
+}
+
+func (c *NativeClient) findLibraryElements(ctx context.Context, path string) ([]finder.FindResult, error) {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := s.restClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return finder.NewFinder(library.NewManager(rc)).Find(ctx, path)
+}
+
+// findLibraryElement returns the only library or library item at the given path.
+func findLibraryElement(ctx context.Context, m *library.Manager, path string) (finder.FindResult, error) {
+	results, err := finder.NewFinder(m).Find(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	switch len(results) {
+	case 0:
+		return nil, &NotFoundError{Kind: "library element", Path: path}
+	case 1:
+		return results[0], nil
+	default:
+		return nil, &MultipleFoundError{Kind: "library element", Name: path, Root: "content library"}
+	}
+}
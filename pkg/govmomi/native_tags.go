@@ -0,0 +1,138 @@
+package govmomi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/vapi/tags"
+)
+
+const (
+	categoryCardinalitySingle = "SINGLE"
+	categoryTypeVM            = "VirtualMachine"
+)
+
+func (c *NativeClient) tagManager(ctx context.Context) (*tags.Manager, *nativeSession, error) {
+	s, err := c.getSession(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := s.restClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tags.NewManager(rc), s, nil
+}
+
+// GetTags returns the names of the tags attached to the object at the given inventory path.
+func (c *NativeClient) GetTags(ctx context.Context, path string) ([]string, error) {
+	var attached []tags.Tag
+	err := c.Retry(func() error {
+		m, s, err := c.tagManager(ctx)
+		if err != nil {
+			return err
+		}
+		ref, err := s.objectReference(ctx, path)
+		if err != nil {
+			return err
+		}
+		attached, err = m.GetAttachedTags(ctx, ref)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing tags for %s: %w", path, err)
+	}
+
+	return tagNames(attached), nil
+}
+
+func (c *NativeClient) ListTags(ctx context.Context) ([]string, error) {
+	m, _, err := c.tagManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := m.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+
+	return tagNames(all), nil
+}
+
+func (c *NativeClient) AddTag(ctx context.Context, path, tag string) error {
+	m, s, err := c.tagManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	ref, err := s.objectReference(ctx, path)
+	if err != nil {
+		return fmt.Errorf("attaching tag to %s: %w", path, err)
+	}
+
+	if err = m.AttachTag(ctx, tag, ref); err != nil {
+		return fmt.Errorf("attaching tag to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *NativeClient) CreateTag(ctx context.Context, tag, category string) error {
+	m, _, err := c.tagManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = m.CreateTag(ctx, &tags.Tag{Name: tag, CategoryID: category}); err != nil {
+		return fmt.Errorf("creating tag %s: %w", tag, err)
+	}
+
+	return nil
+}
+
+func (c *NativeClient) ListCategories(ctx context.Context) ([]string, error) {
+	m, _, err := c.tagManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := m.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing categories: %w", err)
+	}
+
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+
+	return names, nil
+}
+
+// CreateCategoryForVM creates a single cardinality tag category that can only be associated with VMs.
+func (c *NativeClient) CreateCategoryForVM(ctx context.Context, name string) error {
+	m, _, err := c.tagManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.CreateCategory(ctx, &tags.Category{
+		Name:            name,
+		Cardinality:     categoryCardinalitySingle,
+		AssociableTypes: []string{categoryTypeVM},
+	})
+	if err != nil {
+		return fmt.Errorf("creating category %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func tagNames(t []tags.Tag) []string {
+	names := make([]string, 0, len(t))
+	for _, tag := range t {
+		names = append(names, tag.Name)
+	}
+	return names
+}
@@ -0,0 +1,314 @@
+package govmomi_test
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	vim "github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+	"github.com/vmware/govmomi/vim25/soap"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/govmomi"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+type nativeClientTest struct {
+	*WithT
+	ctx    context.Context
+	server *simulator.Server
+	envMap map[string]string
+	client *govmomi.NativeClient
+}
+
+func newNativeClientTest(t *testing.T) *nativeClientTest {
+	model := simulator.VPX()
+	if err := model.Create(); err != nil {
+		t.Fatalf("creating vcsim model: %v", err)
+	}
+	model.Service.TLS = &tls.Config{}
+	model.Service.RegisterEndpoints = true
+	server := model.Service.NewServer()
+	t.Cleanup(func() {
+		server.Close()
+		model.Remove()
+	})
+
+	password, _ := server.URL.User.Password()
+	envMap := map[string]string{
+		"GOVC_URL":        server.URL.Host,
+		"GOVC_USERNAME":   server.URL.User.Username(),
+		"GOVC_PASSWORD":   password,
+		"GOVC_INSECURE":   "true",
+		"GOVC_DATACENTER": "DC0",
+	}
+
+	tt := &nativeClientTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		server: server,
+		envMap: envMap,
+		client: govmomi.NewNativeClient(
+			govmomi.WithNativeClientEnvMap(envMap),
+			govmomi.WithNativeClientRetrier(retrier.NewWithMaxRetries(1, 0)),
+		),
+	}
+	t.Cleanup(func() {
+		tt.Expect(tt.client.Close(tt.ctx)).To(Succeed())
+	})
+
+	return tt
+}
+
+func (tt *nativeClientTest) finder() *find.Finder {
+	c, err := vim.NewClient(tt.ctx, tt.server.URL, true)
+	tt.Expect(err).NotTo(HaveOccurred())
+	f := find.NewFinder(c.Client, true)
+	dc, err := f.Datacenter(tt.ctx, "DC0")
+	tt.Expect(err).NotTo(HaveOccurred())
+	return f.SetDatacenter(dc)
+}
+
+func TestNativeClientMissingEnv(t *testing.T) {
+	g := NewWithT(t)
+	c := govmomi.NewNativeClient(
+		govmomi.WithNativeClientEnvMap(map[string]string{"GOVC_URL": "vsphere.local"}),
+		govmomi.WithNativeClientRetrier(retrier.NewWithMaxRetries(1, 0)),
+	)
+	_, err := c.DatacenterExists(context.Background(), "DC0")
+	g.Expect(err).To(MatchError(ContainSubstring("GOVC_USERNAME is not set or is empty")))
+}
+
+func TestNativeClientValidateVCenterAuthentication(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.Expect(tt.client.ValidateVCenterAuthentication(tt.ctx)).To(Succeed())
+}
+
+func TestNativeClientCertThumbprint(t *testing.T) {
+	tt := newNativeClientTest(t)
+	thumbprint, err := tt.client.GetCertThumbprint(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(thumbprint).To(Equal(soap.ThumbprintSHA1(tt.server.Certificate())))
+
+	tt.Expect(tt.client.IsCertSelfSigned(tt.ctx)).To(BeTrue())
+	tt.Expect(tt.client.ConfigureCertThumbprint(tt.ctx, tt.server.URL.Host, thumbprint)).To(Succeed())
+	tt.Expect(tt.client.IsCertSelfSigned(tt.ctx)).To(BeFalse())
+}
+
+func TestNativeClientDatacenterExists(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.Expect(tt.client.DatacenterExists(tt.ctx, "DC0")).To(BeTrue())
+	tt.Expect(tt.client.DatacenterExists(tt.ctx, "DC1")).To(BeFalse())
+}
+
+func TestNativeClientNetworkExists(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.Expect(tt.client.NetworkExists(tt.ctx, "/DC0/network/VM Network")).To(BeTrue())
+	tt.Expect(tt.client.NetworkExists(tt.ctx, "/DC0/network/missing")).To(BeFalse())
+}
+
+func TestNativeClientSearchTemplate(t *testing.T) {
+	tt := newNativeClientTest(t)
+	machineConfig := &v1alpha1.VSphereMachineConfig{
+		Spec: v1alpha1.VSphereMachineConfigSpec{Template: "/DC0/vm/DC0_H0_VM0"},
+	}
+	tt.Expect(tt.client.SearchTemplate(tt.ctx, "DC0", machineConfig)).To(Equal("/DC0/vm/DC0_H0_VM0"))
+
+	machineConfig.Spec.Template = "/DC0/vm/missing"
+	tt.Expect(tt.client.SearchTemplate(tt.ctx, "DC0", machineConfig)).To(BeEmpty())
+}
+
+func TestNativeClientSearchTemplateMissingDatacenter(t *testing.T) {
+	tt := newNativeClientTest(t)
+	machineConfig := &v1alpha1.VSphereMachineConfig{
+		Spec: v1alpha1.VSphereMachineConfigSpec{Template: "/DC1/vm/DC0_H0_VM0"},
+	}
+	_, err := tt.client.SearchTemplate(tt.ctx, "DC1", machineConfig)
+	tt.Expect(govmomi.IsNotFound(err)).To(BeTrue())
+}
+
+func TestNativeClientTemplateHasSnapshot(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.Expect(tt.client.TemplateHasSnapshot(tt.ctx, "/DC0/vm/DC0_H0_VM0")).To(BeFalse())
+
+	vm, err := tt.finder().VirtualMachine(tt.ctx, "/DC0/vm/DC0_H0_VM0")
+	tt.Expect(err).NotTo(HaveOccurred())
+	task, err := vm.CreateSnapshot(tt.ctx, "root", "", false, false)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(task.Wait(tt.ctx)).To(Succeed())
+
+	tt.Expect(tt.client.TemplateHasSnapshot(tt.ctx, "/DC0/vm/DC0_H0_VM0")).To(BeTrue())
+
+	_, err = tt.client.TemplateHasSnapshot(tt.ctx, "/DC0/vm/missing")
+	tt.Expect(govmomi.IsNotFound(err)).To(BeTrue())
+}
+
+func TestNativeClientGetWorkloadAvailableSpace(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.Expect(tt.client.GetWorkloadAvailableSpace(tt.ctx, "/DC0/datastore/LocalDS_0")).To(BeNumerically(">", 0))
+}
+
+func TestNativeClientValidateVCenterSetupMachineConfig(t *testing.T) {
+	tt := newNativeClientTest(t)
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "DC0"},
+	}
+	machineConfig := &v1alpha1.VSphereMachineConfig{
+		Spec: v1alpha1.VSphereMachineConfigSpec{
+			Datastore:    "LocalDS_0",
+			Folder:       "eksa",
+			ResourcePool: "DC0_C0/Resources",
+		},
+	}
+
+	tt.Expect(tt.client.ValidateVCenterSetupMachineConfig(tt.ctx, datacenterConfig, machineConfig, nil)).To(Succeed())
+	tt.Expect(machineConfig.Spec.Datastore).To(Equal("/DC0/datastore/LocalDS_0"))
+	tt.Expect(machineConfig.Spec.Folder).To(Equal("/DC0/vm/eksa"))
+	tt.Expect(machineConfig.Spec.ResourcePool).To(Equal("/DC0/host/DC0_C0/Resources"))
+
+	_, err := tt.finder().Folder(tt.ctx, "/DC0/vm/eksa")
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestNativeClientValidateVCenterSetupMachineConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.VSphereMachineConfigSpec
+		wantErr string
+	}{
+		{
+			name:    "missing datastore",
+			spec:    v1alpha1.VSphereMachineConfigSpec{Datastore: "missing", ResourcePool: "DC0_C0/Resources"},
+			wantErr: "failed to get datastore: valid path, but 'missing' is not a datastore",
+		},
+		{
+			name:    "missing intermediate folder",
+			spec:    v1alpha1.VSphereMachineConfigSpec{Datastore: "LocalDS_0", Folder: "missing/eksa", ResourcePool: "DC0_C0/Resources"},
+			wantErr: "failed to get folder: intermediate directory '/DC0/vm/missing/' not found",
+		},
+		{
+			name:    "missing resource pool",
+			spec:    v1alpha1.VSphereMachineConfigSpec{Datastore: "LocalDS_0", ResourcePool: "DC0_C0/missing"},
+			wantErr: "resource pool 'DC0_C0/missing' not found",
+		},
+		{
+			name:    "ambiguous resource pool",
+			spec:    v1alpha1.VSphereMachineConfigSpec{Datastore: "LocalDS_0", ResourcePool: "*/Resources"},
+			wantErr: "specified resource pool 'Resources' maps to multiple paths within 'DC0'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tt := newNativeClientTest(t)
+			datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+				Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "DC0"},
+			}
+			machineConfig := &v1alpha1.VSphereMachineConfig{Spec: test.spec}
+			tt.Expect(tt.client.ValidateVCenterSetupMachineConfig(tt.ctx, datacenterConfig, machineConfig, nil)).To(MatchError(test.wantErr))
+		})
+	}
+}
+
+func TestNativeClientValidateVCenterSetupFailureDomain(t *testing.T) {
+	tt := newNativeClientTest(t)
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "DC0"},
+	}
+	failureDomain := &v1alpha1.VSphereFailureDomain{
+		Name:           "fd-1",
+		ComputeCluster: "/DC0/host/DC0_C0",
+		ResourcePool:   "Resources",
+		Datastore:      "/DC0/datastore/LocalDS_0",
+		Network:        "/DC0/network/DC0_DVPG0",
+	}
+
+	tt.Expect(tt.client.ValidateVCenterSetupFailureDomain(tt.ctx, datacenterConfig, failureDomain)).To(Succeed())
+	tt.Expect(failureDomain.ResourcePool).To(Equal("/DC0/host/DC0_C0/Resources"))
+
+	failureDomain.ComputeCluster = "/DC0/host/missing"
+	err := tt.client.ValidateVCenterSetupFailureDomain(tt.ctx, datacenterConfig, failureDomain)
+	tt.Expect(err).To(MatchError("getting compute cluster: compute cluster '/DC0/host/missing' not found"))
+	tt.Expect(govmomi.IsNotFound(err)).To(BeTrue())
+}
+
+func TestNativeClientCreateVMAntiAffinityRule(t *testing.T) {
+	tt := newNativeClientTest(t)
+	vms := []string{"/DC0/vm/DC0_C0_RP0_VM0", "/DC0/vm/DC0_C0_RP0_VM1"}
+
+	tt.Expect(tt.client.CreateVMAntiAffinityRule(tt.ctx, "/DC0/host/DC0_C0", "test-control-plane-anti-affinity", vms)).To(Succeed())
+	tt.Expect(tt.client.CreateVMAntiAffinityRule(tt.ctx, "/DC0/host/DC0_C0", "test-control-plane-anti-affinity", vms)).To(Succeed())
+
+	cluster, err := tt.finder().ClusterComputeResource(tt.ctx, "/DC0/host/DC0_C0")
+	tt.Expect(err).NotTo(HaveOccurred())
+	config, err := cluster.Configuration(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.Rule).To(HaveLen(1))
+	tt.Expect(config.Rule[0].GetClusterRuleInfo().Name).To(Equal("test-control-plane-anti-affinity"))
+}
+
+func TestNativeClientCreateVMAntiAffinityRuleMissingVM(t *testing.T) {
+	tt := newNativeClientTest(t)
+	err := tt.client.CreateVMAntiAffinityRule(tt.ctx, "/DC0/host/DC0_C0", "rule", []string{"/DC0/vm/missing"})
+	tt.Expect(govmomi.IsNotFound(err)).To(BeTrue())
+}
+
+func TestNativeClientCleanupVms(t *testing.T) {
+	tt := newNativeClientTest(t)
+	vms := func() []string {
+		list, err := tt.finder().VirtualMachineList(tt.ctx, "/DC0/vm/*")
+		tt.Expect(err).NotTo(HaveOccurred())
+		names := make([]string, 0, len(list))
+		for _, vm := range list {
+			names = append(names, vm.Name())
+		}
+		return names
+	}
+
+	tt.Expect(tt.client.CleanupVms(tt.ctx, "DC0_H0", true)).To(Succeed())
+	tt.Expect(vms()).To(ContainElements("DC0_H0_VM0", "DC0_H0_VM1"))
+
+	tt.Expect(tt.client.CleanupVms(tt.ctx, "DC0_H0", false)).To(Succeed())
+	tt.Expect(vms()).NotTo(ContainElement(HavePrefix("DC0_H0")))
+	tt.Expect(vms()).To(ContainElement("DC0_C0_RP0_VM0"))
+}
+
+func TestNativeClientCleanupVmsMissingDatacenter(t *testing.T) {
+	tt := newNativeClientTest(t)
+	delete(tt.envMap, "GOVC_DATACENTER")
+	tt.Expect(tt.client.CleanupVms(tt.ctx, "test", false)).To(MatchError(ContainSubstring("GOVC_DATACENTER is not set or is empty")))
+}
+
+func TestNativeClientTags(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.Expect(tt.client.CreateCategoryForVM(tt.ctx, "eksdRelease")).To(Succeed())
+	tt.Expect(tt.client.ListCategories(tt.ctx)).To(ConsistOf("eksdRelease"))
+
+	tt.Expect(tt.client.CreateTag(tt.ctx, "eksdRelease:kubernetes-1-23-eks-4", "eksdRelease")).To(Succeed())
+	tt.Expect(tt.client.ListTags(tt.ctx)).To(ConsistOf("eksdRelease:kubernetes-1-23-eks-4"))
+
+	tt.Expect(tt.client.GetTags(tt.ctx, "/DC0/vm/DC0_H0_VM0")).To(BeEmpty())
+	tt.Expect(tt.client.AddTag(tt.ctx, "/DC0/vm/DC0_H0_VM0", "eksdRelease:kubernetes-1-23-eks-4")).To(Succeed())
+	tt.Expect(tt.client.GetTags(tt.ctx, "/DC0/vm/DC0_H0_VM0")).To(ConsistOf("eksdRelease:kubernetes-1-23-eks-4"))
+
+	_, err := tt.client.GetTags(tt.ctx, "/DC0/vm/missing")
+	tt.Expect(govmomi.IsNotFound(err)).To(BeTrue())
+}
+
+func TestNativeClientLibrary(t *testing.T) {
+	tt := newNativeClientTest(t)
+	tt.Expect(tt.client.LibraryElementExists(tt.ctx, "eks-a-templates")).To(BeFalse())
+
+	tt.Expect(tt.client.CreateLibrary(tt.ctx, "LocalDS_0", "eks-a-templates")).To(Succeed())
+	tt.Expect(tt.client.LibraryElementExists(tt.ctx, "eks-a-templates")).To(BeTrue())
+	tt.Expect(tt.client.GetLibraryElementContentVersion(tt.ctx, "/eks-a-templates/ubuntu")).To(Equal("-1"))
+
+	tt.Expect(tt.client.DeleteLibraryElement(tt.ctx, "/eks-a-templates")).To(Succeed())
+	tt.Expect(tt.client.LibraryElementExists(tt.ctx, "eks-a-templates")).To(BeFalse())
+
+	err := tt.client.DeleteLibraryElement(tt.ctx, "/eks-a-templates")
+	tt.Expect(govmomi.IsNotFound(err)).To(BeTrue())
+}
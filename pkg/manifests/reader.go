@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/manifests/releases"
+	"github.com/aws/eks-anywhere/pkg/manifests/signature"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -18,6 +19,7 @@ type FileReader interface {
 type Reader struct {
 	FileReader
 	releasesManifestURL string
+	verifier            *signature.Verifier
 }
 
 type ReaderOpt func(*Reader)
@@ -28,6 +30,14 @@ func WithReleasesManifest(manifestURL string) ReaderOpt {
 	}
 }
 
+// WithSignatureVerifier sets the verifier used to check the signatures of the releases and bundles manifests.
+// By default, the signatures are verified against the public key embedded in the CLI and the user configured ones.
+func WithSignatureVerifier(verifier *signature.Verifier) ReaderOpt {
+	return func(r *Reader) {
+		r.verifier = verifier
+	}
+}
+
 func NewReader(filereader FileReader, opts ...ReaderOpt) *Reader {
 	r := &Reader{
 		FileReader:          filereader,
@@ -41,7 +51,12 @@ func NewReader(filereader FileReader, opts ...ReaderOpt) *Reader {
 }
 
 func (r *Reader) ReadBundlesForVersion(version string) (*releasev1.Bundles, error) {
-	rls, err := releases.ReadReleasesFromURL(r, r.releasesManifestURL)
+	signedReader, err := r.signedReader()
+	if err != nil {
+		return nil, err
+	}
+
+	rls, err := releases.ReadReleasesFromURL(signedReader, r.releasesManifestURL)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +69,29 @@ func (r *Reader) ReadBundlesForVersion(version string) (*releasev1.Bundles, erro
 		return nil, fmt.Errorf("invalid version %s, no matching release found", version)
 	}
 
-	return releases.ReadBundlesForRelease(r, release)
+	return releases.ReadBundlesForRelease(signedReader, release)
+}
+
+// ReadBundles reads the bundles manifest in url, verifying its signature.
+func (r *Reader) ReadBundles(url string) (*releasev1.Bundles, error) {
+	signedReader, err := r.signedReader()
+	if err != nil {
+		return nil, err
+	}
+
+	return bundles.Read(signedReader, url)
+}
+
+func (r *Reader) signedReader() (*signature.Reader, error) {
+	if r.verifier == nil {
+		verifier, err := signature.NewDefaultVerifier()
+		if err != nil {
+			return nil, err
+		}
+		r.verifier = verifier
+	}
+
+	return signature.NewReader(r.FileReader, r.verifier), nil
 }
 
 func (r *Reader) ReadEKSD(eksaVersion, kubeVersion string) (*eksdv1.Release, error) {
@@ -1,6 +1,12 @@
 package manifests_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -10,6 +16,7 @@ import (
 	"github.com/aws/eks-anywhere/internal/test/mocks"
 	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/manifests/releases"
+	"github.com/aws/eks-anywhere/pkg/manifests/signature"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -47,7 +54,7 @@ metadata:
 
 	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(bundlesManifest), nil)
 
-	r := manifests.NewReader(reader)
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(signature.NewSkipVerifier()))
 	g.Expect(r.ReadBundlesForVersion("v0.0.1")).To(Equal(wantBundles))
 }
 
@@ -68,7 +75,7 @@ spec:
       version: v0.0.1`
 	reader.EXPECT().ReadFile(releasesURL).Return([]byte(releasesManifest), nil)
 
-	r := manifests.NewReader(reader)
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(signature.NewSkipVerifier()))
 	_, err := r.ReadBundlesForVersion("v0.0.2")
 	g.Expect(err).To(MatchError(ContainSubstring("invalid version v0.0.2, no matching release found")))
 }
@@ -105,7 +112,7 @@ spec:
 	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(bundlesManifest), nil)
 	reader.EXPECT().ReadFile("https://distro.eks.amazonaws.com/kubernetes-1-21/kubernetes-1-21-eks-7.yaml").Return([]byte(bundlesManifest), nil)
 
-	r := manifests.NewReader(reader)
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(signature.NewSkipVerifier()))
 	_, err := r.ReadEKSD("v0.0.1", "1.21")
 	g.Expect(err).ToNot(HaveOccurred())
 }
@@ -141,7 +148,7 @@ spec:
 
 	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(bundlesManifest), nil)
 
-	r := manifests.NewReader(reader)
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(signature.NewSkipVerifier()))
 	_, err := r.ReadEKSD("v0.0.1", "1.22")
 	g.Expect(err).To(MatchError(ContainSubstring("kubernetes version 1.22 is not supported by bundles manifest 0")))
 }
@@ -178,7 +185,7 @@ spec:
 	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(bundlesManifest), nil)
 	reader.EXPECT().ReadFile("https://distro.eks.amazonaws.com/kubernetes-1-21/kubernetes-1-21-eks-7.yaml").Return([]byte(bundlesManifest), nil)
 
-	r := manifests.NewReader(reader)
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(signature.NewSkipVerifier()))
 	_, err := r.ReadImages("v0.0.1")
 	g.Expect(err).ToNot(HaveOccurred())
 }
@@ -207,8 +214,49 @@ metadata:
 
 	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(bundlesManifest), nil)
 
-	r := manifests.NewReader(reader)
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(signature.NewSkipVerifier()))
 	charts, err := r.ReadCharts("v0.0.1")
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(charts).To(BeEmpty())
 }
+
+func TestReaderReadBundlesForVersionInvalidSignature(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockReader(ctrl)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	verifier, err := signature.NewVerifier(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	releasesURL := releases.ManifestURL()
+	reader.EXPECT().ReadFile(releasesURL).Return([]byte("kind: Release"), nil)
+	reader.EXPECT().ReadFile(releasesURL+".sig").Return([]byte("MEUCIQ=="), nil)
+
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(verifier))
+	_, err = r.ReadBundlesForVersion("v0.0.1")
+	g.Expect(err).To(MatchError(ContainSubstring("verifying signature for manifest")))
+}
+
+func TestReaderReadBundlesMissingSignature(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockReader(ctrl)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	verifier, err := signature.NewVerifier(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	reader.EXPECT().ReadFile("bundles-override.yaml").Return([]byte("kind: Bundles"), nil)
+	reader.EXPECT().ReadFile("bundles-override.yaml.sig").Return(nil, errors.New("file not found"))
+
+	r := manifests.NewReader(reader, manifests.WithSignatureVerifier(verifier))
+	_, err = r.ReadBundles("bundles-override.yaml")
+	g.Expect(err).To(MatchError("reading signature for manifest [bundles-override.yaml]: file not found"))
+}
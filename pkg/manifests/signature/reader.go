@@ -0,0 +1,57 @@
+package signature
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type FileReader interface {
+	ReadFile(url string) ([]byte, error)
+}
+
+// Reader reads manifests and verifies their detached signatures before returning them.
+// The signature of a manifest is read from the same url with the SignatureSuffix appended.
+type Reader struct {
+	FileReader
+	verifier *Verifier
+}
+
+func NewReader(fileReader FileReader, verifier *Verifier) *Reader {
+	return &Reader{
+		FileReader: fileReader,
+		verifier:   verifier,
+	}
+}
+
+// ReadFile reads the manifest in url and verifies its signature. It fails if the verifier doesn't trust
+// any public key, unless the verifier explicitly opts out of the verification.
+func (r *Reader) ReadFile(url string) ([]byte, error) {
+	content, err := r.FileReader.ReadFile(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.verifier.Skipped() {
+		logger.V(2).Info("Warning: manifest signature verification is disabled", "url", url)
+		return content, nil
+	}
+
+	if !r.verifier.Enabled() {
+		return nil, fmt.Errorf("verifying signature for manifest [%s]: no manifest signing public keys configured, set %s or opt out with %s=true",
+			url, config.EksaManifestSigningPublicKeysEnv, config.EksaSkipManifestSignatureVerificationEnv)
+	}
+
+	signature, err := r.FileReader.ReadFile(SignatureURL(url))
+	if err != nil {
+		return nil, fmt.Errorf("reading signature for manifest [%s]: %v", url, err)
+	}
+
+	if err = r.verifier.Verify(content, signature); err != nil {
+		return nil, fmt.Errorf("verifying signature for manifest [%s]: %v", url, err)
+	}
+
+	logger.V(4).Info("Verified manifest signature", "url", url)
+	return content, nil
+}
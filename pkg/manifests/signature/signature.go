@@ -0,0 +1,136 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/config"
+)
+
+// SignatureSuffix is appended to the url of a manifest to build the url of its detached signature.
+const SignatureSuffix = ".sig"
+
+// publicKey holds the base64 encoded PEM of the public key used to sign the eksa releases and bundles manifests.
+// This is injected at build time. When empty, only the keys configured by the user are trusted.
+var publicKey = ""
+
+// SignatureURL returns the url of the detached signature for the manifest in manifestURL.
+func SignatureURL(manifestURL string) string {
+	return manifestURL + SignatureSuffix
+}
+
+// Verifier verifies detached ECDSA signatures, in the format produced by `cosign sign-blob`,
+// against a set of trusted public keys.
+type Verifier struct {
+	keys []*ecdsa.PublicKey
+	skip bool
+}
+
+// NewVerifier builds a Verifier that trusts the given PEM encoded public keys.
+func NewVerifier(publicKeys ...[]byte) (*Verifier, error) {
+	v := &Verifier{}
+	for _, k := range publicKeys {
+		key, err := parsePublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = append(v.keys, key)
+	}
+
+	return v, nil
+}
+
+// NewSkipVerifier builds a Verifier that explicitly opts out of signature verification.
+func NewSkipVerifier() *Verifier {
+	return &Verifier{skip: true}
+}
+
+// NewDefaultVerifier builds a Verifier that trusts the public key embedded in the CLI plus the keys
+// in the files listed in the EKSA_MANIFEST_SIGNING_PUBLIC_KEYS env var, separated by commas.
+// Setting EKSA_SKIP_MANIFEST_SIGNATURE_VERIFICATION to true opts out of the verification.
+func NewDefaultVerifier() (*Verifier, error) {
+	if skip, _ := strconv.ParseBool(os.Getenv(config.EksaSkipManifestSignatureVerificationEnv)); skip {
+		return NewSkipVerifier(), nil
+	}
+
+	keys := [][]byte{}
+	if publicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("decoding embedded manifest signing public key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+
+	for _, file := range strings.Split(os.Getenv(config.EksaManifestSigningPublicKeysEnv), ",") {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		key, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest signing public key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return NewVerifier(keys...)
+}
+
+// Enabled returns true if the Verifier trusts at least one public key.
+func (v *Verifier) Enabled() bool {
+	return len(v.keys) > 0
+}
+
+// Skipped returns true if the Verifier was configured to opt out of signature verification.
+func (v *Verifier) Skipped() bool {
+	return v.skip
+}
+
+// Verify checks that signature, a base64 encoded ASN.1 ECDSA signature of the sha256 digest of content,
+// was produced by one of the trusted keys.
+func (v *Verifier) Verify(content, signature []byte) error {
+	if !v.Enabled() {
+		return fmt.Errorf("no trusted public keys configured")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("decoding signature: %v", err)
+	}
+
+	digest := sha256.Sum256(content)
+	for _, key := range v.keys {
+		if ecdsa.VerifyASN1(key, digest[:], sig) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("signature doesn't match any of the trusted public keys")
+}
+
+func parsePublicKey(content []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key: no PEM data found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %v", err)
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid public key: only ECDSA keys are supported, got %T", key)
+	}
+
+	return ecdsaKey, nil
+}
@@ -0,0 +1,276 @@
+package signature_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/manifests/signature"
+)
+
+type signingKey struct {
+	private *ecdsa.PrivateKey
+	public  []byte
+}
+
+func newSigningKey(t *testing.T) *signingKey {
+	t.Helper()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	return &signingKey{
+		private: private,
+		public:  encodePublicKey(t, &private.PublicKey),
+	}
+}
+
+func encodePublicKey(t *testing.T, key interface{}) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("marshalling public key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func (k *signingKey) sign(t *testing.T, content []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, k.private, digest[:])
+	if err != nil {
+		t.Fatalf("signing content: %v", err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig))
+}
+
+func TestVerifierVerifySuccess(t *testing.T) {
+	g := NewWithT(t)
+	key := newSigningKey(t)
+	otherKey := newSigningKey(t)
+	content := []byte("kind: Bundles")
+
+	v, err := signature.NewVerifier(otherKey.public, key.public)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(v.Enabled()).To(BeTrue())
+	g.Expect(v.Verify(content, key.sign(t, content))).To(Succeed())
+}
+
+func TestVerifierVerifyError(t *testing.T) {
+	key := newSigningKey(t)
+	content := []byte("kind: Bundles")
+
+	tests := []struct {
+		testName  string
+		keys      [][]byte
+		content   []byte
+		signature []byte
+		wantErr   string
+	}{
+		{
+			testName:  "no keys",
+			content:   content,
+			signature: key.sign(t, content),
+			wantErr:   "no trusted public keys configured",
+		},
+		{
+			testName:  "tampered content",
+			keys:      [][]byte{key.public},
+			content:   []byte("kind: Bundles\nmalicious: true"),
+			signature: key.sign(t, content),
+			wantErr:   "signature doesn't match any of the trusted public keys",
+		},
+		{
+			testName:  "untrusted key",
+			keys:      [][]byte{newSigningKey(t).public},
+			content:   content,
+			signature: key.sign(t, content),
+			wantErr:   "signature doesn't match any of the trusted public keys",
+		},
+		{
+			testName:  "invalid signature encoding",
+			keys:      [][]byte{key.public},
+			content:   content,
+			signature: []byte("not-base64!"),
+			wantErr:   "decoding signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			v, err := signature.NewVerifier(tt.keys...)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(v.Verify(tt.content, tt.signature)).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestNewVerifierInvalidKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	tests := []struct {
+		testName string
+		key      []byte
+		wantErr  string
+	}{
+		{
+			testName: "no PEM data",
+			key:      []byte("invalid"),
+			wantErr:  "invalid public key: no PEM data found",
+		},
+		{
+			testName: "invalid PEM content",
+			key:      pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("invalid")}),
+			wantErr:  "parsing public key",
+		},
+		{
+			testName: "rsa key",
+			key:      encodePublicKey(t, &rsaKey.PublicKey),
+			wantErr:  "invalid public key: only ECDSA keys are supported, got *rsa.PublicKey",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := signature.NewVerifier(tt.key)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestNewDefaultVerifierUserKeys(t *testing.T) {
+	g := NewWithT(t)
+	key := newSigningKey(t)
+	keyFile := filepath.Join(t.TempDir(), "mirror.pub")
+	g.Expect(os.WriteFile(keyFile, key.public, 0o644)).To(Succeed())
+	t.Setenv(config.EksaManifestSigningPublicKeysEnv, " "+keyFile+",")
+
+	content := []byte("kind: Release")
+	v, err := signature.NewDefaultVerifier()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(v.Verify(content, key.sign(t, content))).To(Succeed())
+}
+
+func TestNewDefaultVerifierMissingKeyFile(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(config.EksaManifestSigningPublicKeysEnv, filepath.Join(t.TempDir(), "missing.pub"))
+
+	_, err := signature.NewDefaultVerifier()
+	g.Expect(err).To(MatchError(ContainSubstring("reading manifest signing public key")))
+}
+
+func TestNewDefaultVerifierNoKeys(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(config.EksaManifestSigningPublicKeysEnv, "")
+
+	v, err := signature.NewDefaultVerifier()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(v.Enabled()).To(BeFalse())
+}
+
+type fakeFileReader map[string][]byte
+
+func (f fakeFileReader) ReadFile(url string) ([]byte, error) {
+	content, ok := f[url]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return content, nil
+}
+
+func TestReaderReadFile(t *testing.T) {
+	key := newSigningKey(t)
+	content := []byte("kind: Bundles")
+	url := "https://mirror/bundles.yaml"
+
+	tests := []struct {
+		testName string
+		files    fakeFileReader
+		keys     [][]byte
+		wantErr  string
+	}{
+		{
+			testName: "valid signature",
+			files:    fakeFileReader{url: content, url + ".sig": key.sign(t, content)},
+			keys:     [][]byte{key.public},
+		},
+		{
+			testName: "no trusted keys",
+			files:    fakeFileReader{url: content},
+			wantErr:  "verifying signature for manifest [https://mirror/bundles.yaml]: no manifest signing public keys configured, set EKSA_MANIFEST_SIGNING_PUBLIC_KEYS or opt out with EKSA_SKIP_MANIFEST_SIGNATURE_VERIFICATION=true",
+		},
+		{
+			testName: "missing manifest",
+			files:    fakeFileReader{},
+			keys:     [][]byte{key.public},
+			wantErr:  "file not found",
+		},
+		{
+			testName: "missing signature",
+			files:    fakeFileReader{url: content},
+			keys:     [][]byte{key.public},
+			wantErr:  "reading signature for manifest [https://mirror/bundles.yaml]: file not found",
+		},
+		{
+			testName: "tampered manifest",
+			files:    fakeFileReader{url: []byte("kind: Bundles\nmalicious: true"), url + ".sig": key.sign(t, content)},
+			keys:     [][]byte{key.public},
+			wantErr:  "verifying signature for manifest [https://mirror/bundles.yaml]: signature doesn't match any of the trusted public keys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			v, err := signature.NewVerifier(tt.keys...)
+			g.Expect(err).NotTo(HaveOccurred())
+			r := signature.NewReader(tt.files, v)
+
+			got, err := r.ReadFile(url)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.files[url]))
+		})
+	}
+}
+
+func TestReaderReadFileSkipVerification(t *testing.T) {
+	g := NewWithT(t)
+	url := "https://mirror/bundles.yaml"
+	files := fakeFileReader{url: []byte("kind: Bundles")}
+	r := signature.NewReader(files, signature.NewSkipVerifier())
+
+	got, err := r.ReadFile(url)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(files[url]))
+}
+
+func TestNewDefaultVerifierSkipVerification(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(config.EksaSkipManifestSignatureVerificationEnv, "true")
+
+	v, err := signature.NewDefaultVerifier()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(v.Skipped()).To(BeTrue())
+}
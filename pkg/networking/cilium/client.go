@@ -15,6 +15,9 @@ const (
 	PreflightDaemonSetName  = "cilium-pre-flight-check"
 	DeploymentName          = "cilium-operator"
 	PreflightDeploymentName = "cilium-pre-flight-check"
+	ConfigMapName           = "cilium-config"
+	HubbleRelayName         = "hubble-relay"
+	HubbleUIName            = "hubble-ui"
 )
 
 type Client interface {
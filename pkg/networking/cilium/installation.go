@@ -1,11 +1,17 @@
 package cilium
 
-import appsv1 "k8s.io/api/apps/v1"
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
 
 // Installation represents the Cilium components installed in a cluster
 type Installation struct {
-	DaemonSet *appsv1.DaemonSet
-	Operator  *appsv1.Deployment
+	DaemonSet   *appsv1.DaemonSet
+	Operator    *appsv1.Deployment
+	ConfigMap   *corev1.ConfigMap
+	HubbleRelay *appsv1.Deployment
+	HubbleUI    *appsv1.Deployment
 }
 
 // Installed determines if all Cilium components are present
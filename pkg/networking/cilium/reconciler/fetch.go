@@ -4,6 +4,7 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,12 +23,43 @@ func getInstallation(ctx context.Context, client client.Client) (*cilium.Install
 		return nil, err
 	}
 
+	configMap, err := getCiliumConfigMap(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	hubbleRelay, err := getDeployment(ctx, client, cilium.HubbleRelayName, "kube-system")
+	if err != nil {
+		return nil, err
+	}
+
+	hubbleUI, err := getDeployment(ctx, client, cilium.HubbleUIName, "kube-system")
+	if err != nil {
+		return nil, err
+	}
+
 	return &cilium.Installation{
-		DaemonSet: ds,
-		Operator:  operator,
+		DaemonSet:   ds,
+		Operator:    operator,
+		ConfigMap:   configMap,
+		HubbleRelay: hubbleRelay,
+		HubbleUI:    hubbleUI,
 	}, nil
 }
 
+func getCiliumConfigMap(ctx context.Context, client client.Client) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	err := client.Get(ctx, types.NamespacedName{Name: cilium.ConfigMapName, Namespace: "kube-system"}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cm, nil
+}
+
 func getCiliumDaemonSet(ctx context.Context, client client.Client) (*appsv1.DaemonSet, error) {
 	return getDaemonSet(ctx, client, cilium.DaemonSetName, "kube-system")
 }
@@ -2,14 +2,21 @@ package reconciler
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
@@ -19,7 +26,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/utils/oci"
 )
 
-const defaultRequeueTime = time.Second * 10
+const (
+	defaultRequeueTime = time.Second * 10
+	kubeProxyName      = "kube-proxy"
+)
 
 type Templater interface {
 	GenerateUpgradePreflightManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error)
@@ -47,7 +57,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client c
 	}
 
 	if !installation.Installed() {
-		return r.install(ctx, logger, client, spec)
+		if result, err := r.install(ctx, logger, client, spec); err != nil || result.Return() {
+			return result, err
+		}
+		return r.deleteKubeProxyIfReplaced(ctx, logger, client, spec)
 	}
 
 	logger.Info("Cilium is already installed, checking if it needs upgrade")
@@ -64,7 +77,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client c
 		}
 	}
 
-	return r.deletePreflightIfExists(ctx, client, spec)
+	if result, err := r.deletePreflightIfExists(ctx, client, spec); err != nil || result.Return() {
+		return result, err
+	}
+
+	return r.deleteKubeProxyIfReplaced(ctx, logger, client, spec)
 }
 
 func (r *Reconciler) install(ctx context.Context, log logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
@@ -169,6 +186,31 @@ func (r *Reconciler) deletePreflightIfExists(ctx context.Context, client client.
 	return controller.Result{}, nil
 }
 
+// deleteKubeProxyIfReplaced removes the kube-proxy addon installed by kubeadm when Cilium
+// is configured to fully replace it.
+func (r *Reconciler) deleteKubeProxyIfReplaced(ctx context.Context, log logr.Logger, c client.Client, spec *cluster.Spec) (controller.Result, error) {
+	cni := spec.Cluster.Spec.ClusterNetwork.CNIConfig
+	if cni == nil || cni.Cilium == nil || cni.Cilium.KubeProxyReplacement != anywherev1.CiliumKubeProxyReplacementStrict {
+		return controller.Result{}, nil
+	}
+
+	kubeProxy := []client.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: kubeProxyName, Namespace: constants.KubeSystemNamespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: kubeProxyName, Namespace: constants.KubeSystemNamespace}},
+	}
+	for _, obj := range kubeProxy {
+		if err := c.Delete(ctx, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return controller.Result{}, errors.Wrap(err, "deleting kube-proxy")
+		}
+		log.Info("Deleted kube-proxy object, replaced by Cilium", "name", obj.GetName(), "type", fmt.Sprintf("%T", obj))
+	}
+
+	return controller.Result{}, nil
+}
+
 func (r *Reconciler) installPreflight(ctx context.Context, client client.Client, spec *cluster.Spec) error {
 	preflight, err := r.templater.GenerateUpgradePreflightManifest(ctx, spec)
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
@@ -42,6 +43,38 @@ func TestReconcilerReconcileInstall(t *testing.T) {
 	tt.expectOperatorSemanticallyEqual(operator)
 }
 
+func TestReconcilerReconcileInstallKubeProxyReplacementStrict(t *testing.T) {
+	kubeProxy := simpleDaemonSet("kube-proxy", "kube-proxy:v1.22.5-eks-1-22-9")
+	kubeProxyConfig := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-proxy",
+			Namespace: "kube-system",
+		},
+	}
+	tt := newReconcileTest(t).withObjects(kubeProxy, kubeProxyConfig)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+		Cilium: &anywherev1.CiliumConfig{
+			KubeProxyReplacement: anywherev1.CiliumKubeProxyReplacementStrict,
+		},
+	}
+	ds := ciliumDaemonSet()
+	operator := ciliumOperator()
+	manifest := buildManifest(tt.WithT, ds, operator)
+	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec).Return(manifest, nil)
+
+	tt.Expect(
+		tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), tt.client, tt.spec),
+	).To(Equal(controller.Result{}))
+	tt.expectDaemonSetSemanticallyEqual(ds)
+	tt.expectDSToNotExist(kubeProxy.Name, kubeProxy.Namespace)
+	err := tt.env.APIReader().Get(tt.ctx, types.NamespacedName{Name: kubeProxyConfig.Name, Namespace: kubeProxyConfig.Namespace}, &corev1.ConfigMap{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "kube-proxy ConfigMap should not exist")
+}
+
 func TestReconcilerReconcileInstallErrorGeneratingManifest(t *testing.T) {
 	tt := newReconcileTest(t)
 	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec).Return(nil, errors.New("generating manifest"))
@@ -142,6 +175,24 @@ func TestReconcilerReconcileUpgradeButCiliumDaemonSetNotReady(t *testing.T) {
 	)
 }
 
+func TestReconcilerReconcileUpgradeConfigChangedButCiliumDaemonSetNotReady(t *testing.T) {
+	ds := ciliumDaemonSet()
+	operator := ciliumOperator()
+	cm := ciliumConfigMap(map[string]string{
+		"egress-masquerade-interfaces": "eth0",
+	})
+	tt := newReconcileTest(t).withObjects(ds, operator, cm)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+		Cilium: &anywherev1.CiliumConfig{
+			EgressMasqueradeInterfaces: "eth1",
+		},
+	}
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), tt.client, tt.spec)).To(
+		Equal(controller.ResultWithRequeue(10 * time.Second)),
+	)
+}
+
 func TestReconcilerReconcileUpgradeNeedsPreflightAndPreflightDaemonSetNotAvailable(t *testing.T) {
 	ds := ciliumDaemonSet()
 	operator := ciliumOperator()
@@ -379,6 +430,7 @@ func newReconcileTest(t *testing.T) *reconcileTest {
 func (tt *reconcileTest) cleanup() {
 	tt.Expect(tt.client.DeleteAllOf(tt.ctx, &appsv1.DaemonSet{}, client.InNamespace("kube-system")))
 	tt.Expect(tt.client.DeleteAllOf(tt.ctx, &appsv1.Deployment{}, client.InNamespace("kube-system")))
+	tt.Expect(tt.client.DeleteAllOf(tt.ctx, &corev1.ConfigMap{}, client.InNamespace("kube-system")))
 }
 
 func (tt *reconcileTest) withObjects(objs ...envtest.Object) *reconcileTest {
@@ -534,6 +586,20 @@ func ciliumPreflightDeployment() *appsv1.Deployment {
 	return simpleDeployment(cilium.PreflightDeploymentName, "cilium-pre-flight-check:1.10.1-eksa-1")
 }
 
+func ciliumConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cilium.ConfigMapName,
+			Namespace: "kube-system",
+		},
+		Data: data,
+	}
+}
+
 func simpleDeployment(name, image string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -4,6 +4,8 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,8 +20,9 @@ import (
 var networkPolicyAllowAll string
 
 const (
-	maxRetries           = 10
-	defaultBackOffPeriod = 5 * time.Second
+	maxRetries              = 10
+	defaultBackOffPeriod    = 5 * time.Second
+	defaultControlPlanePort = 6443
 )
 
 type Helm interface {
@@ -217,9 +220,45 @@ func templateValues(spec *cluster.Spec) values {
 		val["operator"].(values)["replicas"] = 1
 	}
 
-	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode != "" {
-		val["policyEnforcementMode"] = spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode
+	ciliumConfig := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium
+	if ciliumConfig.PolicyEnforcementMode != "" {
+		val["policyEnforcementMode"] = ciliumConfig.PolicyEnforcementMode
 	}
+
+	if ciliumConfig.Hubble != nil {
+		val.set(ciliumConfig.Hubble.Enabled, "hubble", "enabled")
+		val.set(ciliumConfig.Hubble.Relay, "hubble", "relay", "enabled")
+		val.set(ciliumConfig.Hubble.UI, "hubble", "ui", "enabled")
+	}
+
+	if ciliumConfig.KubeProxyReplacement != "" {
+		val["kubeProxyReplacement"] = ciliumConfig.KubeProxyReplacement
+		// Without kube-proxy, the agents can't rely on the kubernetes service to reach the API server
+		endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint
+		if ciliumConfig.KubeProxyReplacement == anywherev1.CiliumKubeProxyReplacementStrict && endpoint != nil && endpoint.Host != "" {
+			host, port := controlPlaneHostPort(endpoint.Host)
+			val["k8sServiceHost"] = host
+			val["k8sServicePort"] = port
+		}
+	}
+
+	if ciliumConfig.EgressMasqueradeInterfaces != "" {
+		val["egressMasqueradeInterfaces"] = ciliumConfig.EgressMasqueradeInterfaces
+	}
+
+	if ciliumConfig.RoutingMode == anywherev1.CiliumRoutingModeNative {
+		val["tunnel"] = "disabled"
+		val["autoDirectNodeRoutes"] = true
+		if len(spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks) > 0 {
+			val["ipv4NativeRoutingCIDR"] = spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks[0]
+		}
+	}
+
+	// Overrides are applied last so they take precedence over the values set by EKS Anywhere
+	for key, value := range ciliumConfig.HelmValueOverrides() {
+		val.set(value, strings.Split(key, ".")...)
+	}
+
 	return val
 }
 
@@ -245,3 +284,19 @@ func getKubeVersionString(spec *cluster.Spec) (string, error) {
 	}
 	return fmt.Sprintf("%d.%d", k8sVersion.Major, k8sVersion.Minor), nil
 }
+
+// controlPlaneHostPort splits the control plane endpoint host into host and port,
+// defaulting to the kube-apiserver port when the endpoint doesn't specify one.
+func controlPlaneHostPort(endpoint string) (string, int) {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint, defaultControlPlanePort
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return host, defaultControlPlanePort
+	}
+
+	return host, port
+}
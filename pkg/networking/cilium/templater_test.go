@@ -229,6 +229,120 @@ func TestTemplaterGenerateManifestPolicyEnforcementModeSuccess(t *testing.T) {
 	test.AssertContentToFile(t, string(gotManifest), "testdata/manifest_network_policy.yaml")
 }
 
+func TestTemplaterGenerateManifestCiliumOptionsSuccess(t *testing.T) {
+	wantValues := map[string]interface{}{
+		"cni": map[string]interface{}{
+			"chainingMode": "portmap",
+		},
+		"ipam": map[string]interface{}{
+			"mode": "kubernetes",
+		},
+		"identityAllocationMode": "crd",
+		"prometheus": map[string]interface{}{
+			"enabled": true,
+		},
+		"rollOutCiliumPods": true,
+		"tunnel":            "disabled",
+		"image": map[string]interface{}{
+			"repository": "public.ecr.aws/isovalent/cilium",
+			"tag":        "v1.9.11-eksa.1",
+		},
+		"operator": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "public.ecr.aws/isovalent/operator",
+				"tag":        "v1.9.11-eksa.1",
+			},
+			"prometheus": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+		"hubble": map[string]interface{}{
+			"enabled": true,
+			"relay": map[string]interface{}{
+				"enabled": true,
+			},
+			"ui": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+		"kubeProxyReplacement":       "strict",
+		"k8sServiceHost":             "1.2.3.4",
+		"k8sServicePort":             float64(6443),
+		"egressMasqueradeInterfaces": "eth0",
+		"autoDirectNodeRoutes":       false,
+		"ipv4NativeRoutingCIDR":      "192.168.0.0/16",
+		"bpf": map[string]interface{}{
+			"masquerade": true,
+		},
+		"loadBalancer": map[string]interface{}{
+			"algorithm": "maglev",
+		},
+	}
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium = &v1alpha1.CiliumConfig{
+		Hubble: &v1alpha1.CiliumHubbleConfig{
+			Enabled: true,
+			Relay:   true,
+		},
+		KubeProxyReplacement:       v1alpha1.CiliumKubeProxyReplacementStrict,
+		EgressMasqueradeInterfaces: "eth0",
+		RoutingMode:                v1alpha1.CiliumRoutingModeNative,
+		HelmValues: map[string]string{
+			"autoDirectNodeRoutes":   "false",
+			"bpf.masquerade":         "true",
+			"loadBalancer.algorithm": "maglev",
+		},
+	}
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestKubeProxyReplacementEndpointPort(t *testing.T) {
+	wantValues := map[string]interface{}{
+		"cni": map[string]interface{}{
+			"chainingMode": "portmap",
+		},
+		"ipam": map[string]interface{}{
+			"mode": "kubernetes",
+		},
+		"identityAllocationMode": "crd",
+		"prometheus": map[string]interface{}{
+			"enabled": true,
+		},
+		"rollOutCiliumPods": true,
+		"tunnel":            "geneve",
+		"image": map[string]interface{}{
+			"repository": "public.ecr.aws/isovalent/cilium",
+			"tag":        "v1.9.11-eksa.1",
+		},
+		"operator": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "public.ecr.aws/isovalent/operator",
+				"tag":        "v1.9.11-eksa.1",
+			},
+			"prometheus": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+		"kubeProxyReplacement": "strict",
+		"k8sServiceHost":       "1.2.3.4",
+		"k8sServicePort":       float64(8443),
+	}
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4:8443"}
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium = &v1alpha1.CiliumConfig{
+		KubeProxyReplacement: v1alpha1.CiliumKubeProxyReplacementStrict,
+	}
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
 func TestTemplaterGenerateManifestError(t *testing.T) {
 	expectedAttempts := 2
 	tt := newtemplaterTest(t)
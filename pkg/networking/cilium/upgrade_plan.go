@@ -2,11 +2,14 @@ package cilium
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
@@ -14,12 +17,13 @@ import (
 type UpgradePlan struct {
 	DaemonSet ComponentUpgradePlan
 	Operator  ComponentUpgradePlan
+	Config    ConfigUpgradePlan
 }
 
 // Needed determines if an upgrade is needed or not
 // Returns true if any of the installation components needs an upgrade
 func (c UpgradePlan) Needed() bool {
-	return c.DaemonSet.Needed() || c.Operator.Needed()
+	return c.DaemonSet.Needed() || c.Operator.Needed() || c.Config.Needed()
 }
 
 // Reason returns the reason why an upgrade might be needed
@@ -30,13 +34,16 @@ func (c UpgradePlan) Reason() string {
 		return ""
 	}
 
-	s := make([]string, 0, 2)
+	s := make([]string, 0, 3)
 	if c.DaemonSet.UpgradeReason != "" {
 		s = append(s, c.DaemonSet.UpgradeReason)
 	}
 	if c.Operator.UpgradeReason != "" {
 		s = append(s, c.Operator.UpgradeReason)
 	}
+	if c.Config.UpgradeReason != "" {
+		s = append(s, c.Config.UpgradeReason)
+	}
 
 	return strings.Join(s, " - ")
 }
@@ -53,12 +60,23 @@ func (c ComponentUpgradePlan) Needed() bool {
 	return c.UpgradeReason != ""
 }
 
+// ConfigUpgradePlan contains upgrade information for the Cilium configuration
+type ConfigUpgradePlan struct {
+	UpgradeReason string
+}
+
+// Needed determines if an upgrade is needed or not
+func (c ConfigUpgradePlan) Needed() bool {
+	return c.UpgradeReason != ""
+}
+
 // BuildUpgradePlan generates the upgrade plan information for a cilium installation by comparing it
 // with a desired cluster Spec
 func BuildUpgradePlan(installation *Installation, clusterSpec *cluster.Spec) UpgradePlan {
 	return UpgradePlan{
 		DaemonSet: daemonSetUpgradePlan(installation.DaemonSet, clusterSpec),
 		Operator:  operatorUpgradePlan(installation.Operator, clusterSpec),
+		Config:    configUpgradePlan(installation, clusterSpec),
 	}
 }
 
@@ -116,3 +134,95 @@ func operatorUpgradePlan(operator *appsv1.Deployment, clusterSpec *cluster.Spec)
 
 	return info
 }
+
+// helmValuesConfigKeys maps the Cilium Helm chart values that can be overridden to the
+// cilium-config ConfigMap keys they are rendered to.
+var helmValuesConfigKeys = map[string]string{
+	"autoDirectNodeRoutes":    "auto-direct-node-routes",
+	"bandwidthManager":        "enable-bandwidth-manager",
+	"bpf.lbExternalClusterIP": "bpf-lb-external-clusterip",
+	"bpf.masquerade":          "enable-bpf-masquerade",
+	"enableIPv4Masquerade":    "enable-ipv4-masquerade",
+	"endpointRoutes.enabled":  "enable-endpoint-routes",
+	"ipv4NativeRoutingCIDR":   "ipv4-native-routing-cidr",
+	"loadBalancer.algorithm":  "bpf-lb-algorithm",
+	"loadBalancer.mode":       "bpf-lb-mode",
+	"localRedirectPolicy":     "enable-local-redirect-policy",
+	"monitorAggregation":      "monitor-aggregation",
+}
+
+// desiredConfig returns the cilium-config ConfigMap entries expected for the Cilium options set in the cluster.
+// Options not set in the Spec are left to the chart defaults and not included.
+func desiredConfig(ciliumConfig *anywherev1.CiliumConfig) map[string]string {
+	config := map[string]string{}
+
+	if ciliumConfig.PolicyEnforcementMode != "" {
+		config["enable-policy"] = string(ciliumConfig.PolicyEnforcementMode)
+	}
+	if ciliumConfig.Hubble != nil {
+		config["enable-hubble"] = strconv.FormatBool(ciliumConfig.Hubble.Enabled)
+	}
+	if ciliumConfig.KubeProxyReplacement != "" {
+		config["kube-proxy-replacement"] = string(ciliumConfig.KubeProxyReplacement)
+	}
+	if ciliumConfig.EgressMasqueradeInterfaces != "" {
+		config["egress-masquerade-interfaces"] = ciliumConfig.EgressMasqueradeInterfaces
+	}
+	switch ciliumConfig.RoutingMode {
+	case anywherev1.CiliumRoutingModeNative:
+		config["tunnel"] = "disabled"
+	case anywherev1.CiliumRoutingModeTunnel:
+		config["tunnel"] = "geneve"
+	}
+	for value, override := range ciliumConfig.HelmValueOverrides() {
+		if key, ok := helmValuesConfigKeys[value]; ok {
+			config[key] = fmt.Sprint(override)
+		}
+	}
+
+	return config
+}
+
+func configUpgradePlan(installation *Installation, clusterSpec *cluster.Spec) ConfigUpgradePlan {
+	info := ConfigUpgradePlan{}
+
+	cniConfig := clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig
+	if cniConfig == nil || cniConfig.Cilium == nil {
+		return info
+	}
+
+	hubble := cniConfig.Cilium.Hubble
+	if hubble != nil && hubble.Relay && installation.HubbleRelay == nil {
+		info.UpgradeReason = "Hubble relay deployment doesn't exist"
+		return info
+	}
+	if hubble != nil && hubble.UI && installation.HubbleUI == nil {
+		info.UpgradeReason = "Hubble UI deployment doesn't exist"
+		return info
+	}
+
+	config := desiredConfig(cniConfig.Cilium)
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var data map[string]string
+	if installation.ConfigMap != nil {
+		data = installation.ConfigMap.Data
+	}
+	for _, key := range keys {
+		current, ok := data[key]
+		// The chart omits some boolean options when they are disabled
+		if !ok && config[key] == "false" {
+			continue
+		}
+		if current != config[key] {
+			info.UpgradeReason = fmt.Sprintf("Cilium config %s doesn't match the desired value", key)
+			return info
+		}
+	}
+
+	return info
+}
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 )
@@ -176,6 +177,107 @@ func TestBuildUpgradePlan(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "config up to date",
+			installation: &cilium.Installation{
+				DaemonSet:   daemonSet("cilium:v1.0.0"),
+				Operator:    deployment("cilium-operator:v1.0.0"),
+				HubbleRelay: deployment("hubble-relay:v1.0.0"),
+				ConfigMap: configMap(map[string]string{
+					"enable-hubble":          "true",
+					"kube-proxy-replacement": "partial",
+					"tunnel":                 "disabled",
+				}),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundle.Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundle.Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Cilium: &v1alpha1.CiliumConfig{
+						Hubble:               &v1alpha1.CiliumHubbleConfig{Enabled: true, Relay: true},
+						KubeProxyReplacement: v1alpha1.CiliumKubeProxyReplacementPartial,
+						RoutingMode:          v1alpha1.CiliumRoutingModeNative,
+						HelmValues: map[string]string{
+							"bandwidthManager": "false",
+						},
+					},
+				}
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.ComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.ComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+			},
+		},
+		{
+			name: "config value changed",
+			installation: &cilium.Installation{
+				DaemonSet: daemonSet("cilium:v1.0.0"),
+				Operator:  deployment("cilium-operator:v1.0.0"),
+				ConfigMap: configMap(map[string]string{
+					"egress-masquerade-interfaces": "eth0",
+				}),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundle.Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundle.Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Cilium: &v1alpha1.CiliumConfig{
+						EgressMasqueradeInterfaces: "eth1",
+					},
+				}
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.ComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.ComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+				Config: cilium.ConfigUpgradePlan{
+					UpgradeReason: "Cilium config egress-masquerade-interfaces doesn't match the desired value",
+				},
+			},
+		},
+		{
+			name: "hubble relay not installed",
+			installation: &cilium.Installation{
+				DaemonSet: daemonSet("cilium:v1.0.0"),
+				Operator:  deployment("cilium-operator:v1.0.0"),
+				ConfigMap: configMap(map[string]string{
+					"enable-hubble": "true",
+				}),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundle.Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundle.Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Cilium: &v1alpha1.CiliumConfig{
+						Hubble: &v1alpha1.CiliumHubbleConfig{Enabled: true, Relay: true},
+					},
+				}
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.ComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.ComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+				Config: cilium.ConfigUpgradePlan{
+					UpgradeReason: "Hubble relay deployment doesn't exist",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -211,6 +313,12 @@ func deployment(image string, opts ...deploymentOpt) *appsv1.Deployment {
 	return d
 }
 
+func configMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		Data: data,
+	}
+}
+
 type dsOpt func(*appsv1.DaemonSet)
 
 func daemonSet(image string, opts ...dsOpt) *appsv1.DaemonSet {
@@ -311,6 +419,15 @@ func TestUpgradePlanNeeded(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "config needed",
+			info: cilium.UpgradePlan{
+				Config: cilium.ConfigUpgradePlan{
+					UpgradeReason: "config changed",
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -366,6 +483,18 @@ func TestUpgradePlanReason(t *testing.T) {
 			},
 			want: "ds old version - operator old version",
 		},
+		{
+			name: "ds and config needed",
+			info: cilium.UpgradePlan{
+				DaemonSet: cilium.ComponentUpgradePlan{
+					UpgradeReason: "ds old version",
+				},
+				Config: cilium.ConfigUpgradePlan{
+					UpgradeReason: "config changed",
+				},
+			},
+			want: "ds old version - config changed",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
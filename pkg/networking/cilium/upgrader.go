@@ -143,19 +143,14 @@ func ChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 }
 
 func ciliumHelmChartValuesChanged(currentSpec, newSpec *cluster.Spec) bool {
+	newConfig := newSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium
 	if currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig == nil || currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium == nil {
 		// this is for clusters created using 0.7 and lower versions, they won't have these fields initialized
-		// in these cases, a non-default PolicyEnforcementMode in the newSpec will be considered a change
-		if newSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode != v1alpha1.CiliumPolicyModeDefault {
-			return true
-		}
-	} else {
-		if newSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode != currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode {
-			return true
-		}
-	}
-	// we can add comparisons for more values here as we start accepting them from cluster spec
-	return false
+		// in these cases, a non-default PolicyEnforcementMode or any other option set in the newSpec will be considered a change
+		return !newConfig.Equal(&v1alpha1.CiliumConfig{PolicyEnforcementMode: v1alpha1.CiliumPolicyModeDefault})
+	}
+
+	return !newConfig.Equal(currentSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium)
 }
 
 func (u *Upgrader) RunPostControlPlaneUpgradeSetup(ctx context.Context, cluster *types.Cluster) error {
@@ -0,0 +1,111 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+type requiredResources struct {
+	vms       int64
+	cpus      int64
+	cpuMhz    int64
+	memoryMiB int64
+}
+
+// validateAvailabilityZoneResources checks that both the account quota and the zone capacity can fit the given
+// number of machines for each compute offering.
+func (v *Validator) validateAvailabilityZoneResources(ctx context.Context, profile string, zoneId string, domainId string, account string, machines map[anywherev1.CloudStackResourceIdentifier]int) error {
+	required := requiredResources{}
+	for offeringRef, count := range machines {
+		offering, err := v.cmk.GetServiceOffering(ctx, profile, zoneId, offeringRef)
+		if err != nil {
+			return err
+		}
+		n := int64(count)
+		required.vms += n
+		required.cpus += n * int64(offering.CpuNumber)
+		required.cpuMhz += n * int64(offering.CpuNumber) * int64(offering.CpuSpeed)
+		required.memoryMiB += n * int64(offering.Memory)
+	}
+
+	if err := v.validateAccountQuota(ctx, profile, domainId, account, required); err != nil {
+		return err
+	}
+
+	return v.validateZoneCapacity(ctx, profile, zoneId, required)
+}
+
+// validateAccountQuota checks the quota of the given account. When no account is provided, it checks the quota
+// of the only account visible to the api key. If the api key can see more than one account, there is no way to
+// know which one the machines will be created in, so the check is skipped.
+func (v *Validator) validateAccountQuota(ctx context.Context, profile string, domainId string, account string, required requiredResources) error {
+	accounts, err := v.cmk.ListAccounts(ctx, profile, account, domainId)
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return fmt.Errorf("account %s not found", account)
+	} else if len(accounts) > 1 && account == "" {
+		logger.Info("Warning: skipping account quota validation, the credentials have access to multiple accounts and no account is specified in the availability zone", "profile", profile, "accounts", len(accounts))
+		return nil
+	} else if len(accounts) > 1 {
+		return fmt.Errorf("duplicate account %s found", account)
+	}
+	a := accounts[0]
+
+	checks := []struct {
+		resource  string
+		available string
+		required  int64
+	}{
+		{resource: "vms", available: a.VmAvailable, required: required.vms},
+		{resource: "cpus", available: a.CpuAvailable, required: required.cpus},
+		{resource: "memory (MiB)", available: a.MemoryAvailable, required: required.memoryMiB},
+	}
+	for _, check := range checks {
+		if check.available == "" || strings.EqualFold(check.available, decoder.CloudStackUnlimited) {
+			continue
+		}
+		available, err := strconv.ParseInt(check.available, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing available %s for account %s: %v", check.resource, a.Name, err)
+		}
+		if available < check.required {
+			return fmt.Errorf("account %s doesn't have enough quota: %d %s required, %d available", a.Name, check.required, check.resource, available)
+		}
+	}
+
+	return nil
+}
+
+// validateZoneCapacity checks the free cpu and memory of the zone. Listing the zone capacity requires root admin
+// permissions, so the check is skipped with a warning when the api key can't list it.
+func (v *Validator) validateZoneCapacity(ctx context.Context, profile string, zoneId string, required requiredResources) error {
+	capacities, err := v.cmk.ListZoneCapacity(ctx, profile, zoneId)
+	if err != nil {
+		logger.Info("Warning: skipping zone capacity validation, unable to list the zone capacity", "zone", zoneId, "error", err)
+		return nil
+	}
+
+	for _, capacity := range capacities {
+		available := capacity.Total - capacity.Used
+		switch capacity.Type {
+		case decoder.CloudStackCapacityTypeMemory:
+			if requiredBytes := required.memoryMiB * 1024 * 1024; available < requiredBytes {
+				return fmt.Errorf("zone %s doesn't have enough memory: %d bytes required, %d available", zoneId, requiredBytes, available)
+			}
+		case decoder.CloudStackCapacityTypeCPU:
+			if available < required.cpuMhz {
+				return fmt.Errorf("zone %s doesn't have enough cpu: %d MHz required, %d available", zoneId, required.cpuMhz, available)
+			}
+		}
+	}
+
+	return nil
+}
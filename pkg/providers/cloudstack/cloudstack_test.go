@@ -80,6 +80,9 @@ func givenWildcardCmk(mockCtrl *gomock.Controller) ProviderCmkClient {
 	cmk.EXPECT().ValidateDomainAndGetId(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	cmk.EXPECT().ValidateAccountPresent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	cmk.EXPECT().ValidateNetworkPresent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	cmk.EXPECT().GetServiceOffering(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(&decoder.CloudStackServiceOffering{}, nil)
+	cmk.EXPECT().ListAccounts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]decoder.CloudStackAccount{{Name: "admin"}}, nil)
+	cmk.EXPECT().ListZoneCapacity(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	cmk.EXPECT().GetManagementApiEndpoint(gomock.Any()).AnyTimes().Return("http://127.16.0.1:8080/client/api", nil)
 	return cmk
 }
@@ -204,7 +207,7 @@ func newProviderWithKubectl(t *testing.T, datacenterConfig *v1alpha1.CloudStackD
 
 func newProvider(t *testing.T, datacenterConfig *v1alpha1.CloudStackDatacenterConfig, machineConfigs map[string]*v1alpha1.CloudStackMachineConfig, clusterConfig *v1alpha1.Cluster, kubectl ProviderKubectlClient, cmk ProviderCmkClient) *cloudstackProvider {
 	_, writer := test.NewWriter(t)
-	return NewProvider(datacenterConfig, machineConfigs, clusterConfig, kubectl, cmk, writer, test.FakeNow, true, test.NewNullLogger())
+	return NewProvider(datacenterConfig, machineConfigs, clusterConfig, kubectl, cmk, writer, test.FakeNow, test.NewNullLogger())
 }
 
 func TestProviderGenerateCAPISpecForCreate(t *testing.T) {
@@ -1631,7 +1634,7 @@ func TestClusterNeedsNewWorkloadTemplateFalse(t *testing.T) {
 	dcConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
 	machineConfig := givenMachineConfigs(t, testClusterConfigMainFilename)[cc.MachineConfigRefs()[0].Name]
 
-	assert.False(t, NeedsNewWorkloadTemplate(clusterSpec, clusterSpec, dcConfig, dcConfig, machineConfig, machineConfig, test.NewNullLogger()), "expected no spec change to be detected")
+	assert.False(t, NeedsNewWorkloadTemplate(clusterSpec, clusterSpec, clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], dcConfig, dcConfig, machineConfig, machineConfig, test.NewNullLogger()), "expected no spec change to be detected")
 }
 
 func TestClusterUpgradeNeededDatacenterConfigChanged(t *testing.T) {
@@ -1867,14 +1870,23 @@ func TestNeedsNewWorkloadTemplateK8sVersion(t *testing.T) {
 	oldSpec := givenClusterSpec(t, testClusterConfigMainFilename)
 	newK8sSpec := oldSpec.DeepCopy()
 	newK8sSpec.Cluster.Spec.KubernetesVersion = "1.25"
-	assert.True(t, NeedsNewWorkloadTemplate(oldSpec, newK8sSpec, nil, nil, nil, nil, test.NewNullLogger()))
+	assert.True(t, NeedsNewWorkloadTemplate(oldSpec, newK8sSpec, oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], newK8sSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], nil, nil, nil, nil, test.NewNullLogger()))
+}
+
+func TestNeedsNewWorkloadTemplateWorkerNodeGroupK8sVersionUnchanged(t *testing.T) {
+	oldSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	kubeVersion := oldSpec.Cluster.Spec.KubernetesVersion
+	oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubernetesVersion = &kubeVersion
+	newK8sSpec := oldSpec.DeepCopy()
+	newK8sSpec.Cluster.Spec.KubernetesVersion = "1.25"
+	assert.False(t, NeedsNewWorkloadTemplate(oldSpec, newK8sSpec, oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], newK8sSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], nil, nil, nil, nil, test.NewNullLogger()))
 }
 
 func TestNeedsNewWorkloadTemplateBundleNumber(t *testing.T) {
 	oldSpec := givenClusterSpec(t, testClusterConfigMainFilename)
 	newK8sSpec := oldSpec.DeepCopy()
 	newK8sSpec.Bundles.Spec.Number = 10000
-	assert.True(t, NeedsNewWorkloadTemplate(oldSpec, newK8sSpec, nil, nil, nil, nil, test.NewNullLogger()))
+	assert.True(t, NeedsNewWorkloadTemplate(oldSpec, newK8sSpec, oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], newK8sSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0], nil, nil, nil, nil, test.NewNullLogger()))
 }
 
 func TestProviderUpdateSecrets(t *testing.T) {
@@ -0,0 +1,35 @@
+package decoder
+
+const (
+	// CloudStackCapacityTypeMemory is the CloudStack capacity type for memory, reported in bytes.
+	CloudStackCapacityTypeMemory = 0
+	// CloudStackCapacityTypeCPU is the CloudStack capacity type for cpu, reported in MHz.
+	CloudStackCapacityTypeCPU = 1
+	// CloudStackUnlimited is the value CloudStack reports for resource limits that are not set.
+	CloudStackUnlimited = "unlimited"
+)
+
+// CloudStackServiceOffering holds the compute resources a service offering allocates to each machine.
+type CloudStackServiceOffering struct {
+	CpuNumber int
+	// CpuSpeed is the speed of each cpu in MHz.
+	CpuSpeed int
+	// Memory is in MiB.
+	Memory int
+}
+
+// CloudStackAccount holds the resources an account can still allocate. Each value is either a number
+// or CloudStackUnlimited.
+type CloudStackAccount struct {
+	Name            string
+	VmAvailable     string
+	CpuAvailable    string
+	MemoryAvailable string
+}
+
+// CloudStackCapacity holds the total and used capacity of a zone for one capacity type.
+type CloudStackCapacity struct {
+	Type  int
+	Total int64
+	Used  int64
+}
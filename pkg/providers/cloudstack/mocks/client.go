@@ -10,6 +10,7 @@ import (
 
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	executables "github.com/aws/eks-anywhere/pkg/executables"
+	decoder "github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
@@ -56,6 +57,51 @@ func (mr *MockProviderCmkClientMockRecorder) GetManagementApiEndpoint(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagementApiEndpoint", reflect.TypeOf((*MockProviderCmkClient)(nil).GetManagementApiEndpoint), arg0)
 }
 
+// GetServiceOffering mocks base method.
+func (m *MockProviderCmkClient) GetServiceOffering(arg0 context.Context, arg1, arg2 string, arg3 v1alpha1.CloudStackResourceIdentifier) (*decoder.CloudStackServiceOffering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceOffering", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*decoder.CloudStackServiceOffering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceOffering indicates an expected call of GetServiceOffering.
+func (mr *MockProviderCmkClientMockRecorder) GetServiceOffering(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceOffering", reflect.TypeOf((*MockProviderCmkClient)(nil).GetServiceOffering), arg0, arg1, arg2, arg3)
+}
+
+// ListAccounts mocks base method.
+func (m *MockProviderCmkClient) ListAccounts(arg0 context.Context, arg1, arg2, arg3 string) ([]decoder.CloudStackAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]decoder.CloudStackAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockProviderCmkClientMockRecorder) ListAccounts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockProviderCmkClient)(nil).ListAccounts), arg0, arg1, arg2, arg3)
+}
+
+// ListZoneCapacity mocks base method.
+func (m *MockProviderCmkClient) ListZoneCapacity(arg0 context.Context, arg1, arg2 string) ([]decoder.CloudStackCapacity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZoneCapacity", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]decoder.CloudStackCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZoneCapacity indicates an expected call of ListZoneCapacity.
+func (mr *MockProviderCmkClientMockRecorder) ListZoneCapacity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZoneCapacity", reflect.TypeOf((*MockProviderCmkClient)(nil).ListZoneCapacity), arg0, arg1, arg2)
+}
+
 // ValidateAccountPresent mocks base method.
 func (m *MockProviderCmkClient) ValidateAccountPresent(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
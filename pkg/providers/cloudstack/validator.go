@@ -14,9 +14,8 @@ import (
 )
 
 type Validator struct {
-	cmk         ProviderCmkClient
-	netClient   networkutils.NetClient
-	skipIpCheck bool
+	cmk       ProviderCmkClient
+	netClient networkutils.NetClient
 }
 
 // Taken from https://github.com/shapeblue/cloudstack/blob/08bb4ad9fea7e422c3d3ac6d52f4670b1e89eed7/api/src/main/java/com/cloud/vm/VmDetailConstants.java
@@ -31,11 +30,10 @@ var restrictedUserCustomDetails = [...]string{
 	"keypairnames", "controlNodeLoginUser",
 }
 
-func NewValidator(cmk ProviderCmkClient, netClient networkutils.NetClient, skipIpCheck bool) *Validator {
+func NewValidator(cmk ProviderCmkClient, netClient networkutils.NetClient) *Validator {
 	return &Validator{
-		cmk:         cmk,
-		netClient:   netClient,
-		skipIpCheck: skipIpCheck,
+		cmk:       cmk,
+		netClient: netClient,
 	}
 }
 
@@ -56,6 +54,9 @@ type ProviderCmkClient interface {
 	ValidateNetworkPresent(ctx context.Context, profile string, domainId string, network anywherev1.CloudStackResourceIdentifier, zoneId string, account string) error
 	ValidateDomainAndGetId(ctx context.Context, profile string, domain string) (string, error)
 	ValidateAccountPresent(ctx context.Context, profile string, account string, domainId string) error
+	GetServiceOffering(ctx context.Context, profile string, zoneId string, serviceOffering anywherev1.CloudStackResourceIdentifier) (*decoder.CloudStackServiceOffering, error)
+	ListAccounts(ctx context.Context, profile string, account string, domainId string) ([]decoder.CloudStackAccount, error)
+	ListZoneCapacity(ctx context.Context, profile string, zoneId string) ([]decoder.CloudStackCapacity, error)
 }
 
 func (v *Validator) validateCloudStackAccess(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error {
@@ -143,6 +144,58 @@ func generateLocalAvailabilityZones(ctx context.Context, datacenterConfig *anywh
 	return localAvailabilityZones, nil
 }
 
+// ValidateResourcesAvailable checks that every availability zone has enough quota and capacity to host its share
+// of the cluster machines. Machines are assumed to be spread evenly across the availability zones.
+func (v *Validator) ValidateResourcesAvailable(ctx context.Context, cloudStackClusterSpec *Spec) error {
+	machineCounts := map[string]int{}
+	cluster := cloudStackClusterSpec.Cluster
+	machineCounts[cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] += cluster.Spec.ControlPlaneConfiguration.Count
+	if cluster.Spec.ExternalEtcdConfiguration != nil && cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef != nil {
+		machineCounts[cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name] += cluster.Spec.ExternalEtcdConfiguration.Count
+	}
+	for _, workerNodeGroupConfiguration := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroupConfiguration.MachineGroupRef == nil {
+			continue
+		}
+		count := workerNodeGroupConfiguration.Count
+		if workerNodeGroupConfiguration.AutoScalingConfiguration != nil && workerNodeGroupConfiguration.AutoScalingConfiguration.MaxCount > count {
+			count = workerNodeGroupConfiguration.AutoScalingConfiguration.MaxCount
+		}
+		machineCounts[workerNodeGroupConfiguration.MachineGroupRef.Name] += count
+	}
+
+	availabilityZones := cloudStackClusterSpec.datacenterConfig.Spec.AvailabilityZones
+	if len(availabilityZones) == 0 {
+		return fmt.Errorf("CloudStackDatacenterConfig domain or availabilityZones is not set or is empty")
+	}
+
+	for _, az := range availabilityZones {
+		machines := map[anywherev1.CloudStackResourceIdentifier]int{}
+		for machineConfigName, count := range machineCounts {
+			machineConfig, ok := cloudStackClusterSpec.machineConfigsLookup[machineConfigName]
+			if !ok {
+				return fmt.Errorf("cannot find CloudStackMachineConfig %v", machineConfigName)
+			}
+			machines[machineConfig.Spec.ComputeOffering] += (count + len(availabilityZones) - 1) / len(availabilityZones)
+		}
+
+		zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.Zone)
+		if err != nil {
+			return err
+		}
+		domainId, err := v.cmk.ValidateDomainAndGetId(ctx, az.CredentialsRef, az.Domain)
+		if err != nil {
+			return err
+		}
+		if err = v.validateAvailabilityZoneResources(ctx, az.CredentialsRef, zoneId, domainId, az.Account, machines); err != nil {
+			return fmt.Errorf("validating resources available in availability zone %s: %v", az.Name, err)
+		}
+	}
+
+	logger.MarkPass("Resources available validated")
+	return nil
+}
+
 // TODO: dry out machine configs validations
 func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, cloudStackClusterSpec *Spec) error {
 	if len(cloudStackClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host) <= 0 {
@@ -233,10 +286,6 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, cloudStac
 }
 
 func (v *Validator) ValidateControlPlaneEndpointUniqueness(endpoint string) error {
-	if v.skipIpCheck {
-		logger.Info("Skipping control plane endpoint uniqueness check")
-		return nil
-	}
 	host, port, err := net.SplitHostPort(endpoint)
 	if err != nil {
 		return fmt.Errorf("invalid endpoint - not in host:port format: %v", err)
@@ -14,6 +14,8 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
 )
 
@@ -55,7 +57,7 @@ func TestValidateCloudStackDatacenterConfig(t *testing.T) {
 	ctx := context.Background()
 	setupContext(t)
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
@@ -74,7 +76,7 @@ func TestValidateCloudStackDatacenterConfigWithAZ(t *testing.T) {
 	ctx := context.Background()
 	setupContext(t)
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainWithAZsFilename))
 	if err != nil {
@@ -92,7 +94,7 @@ func TestValidateCloudStackDatacenterConfigWithAZ(t *testing.T) {
 func TestValidateCloudStackConnection(t *testing.T) {
 	ctx := context.Background()
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -107,7 +109,7 @@ func TestValidateCloudStackConnection(t *testing.T) {
 func TestValidateCloudStackConnectionFailure(t *testing.T) {
 	ctx := context.Background()
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -118,31 +120,23 @@ func TestValidateCloudStackConnectionFailure(t *testing.T) {
 	thenErrorExpected(t, "validating connection to cloudstack global: exception", err)
 }
 
-func TestValidateSkipControlPlaneIpCheck(t *testing.T) {
-	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
-	if err := validator.ValidateControlPlaneEndpointUniqueness("invalid_url_skip_check"); err != nil {
-		t.Fatalf("expected no error, validation should be skipped")
-	}
-}
-
 func TestValidateControlPlaneIpCheck(t *testing.T) {
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, false)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	err := validator.ValidateControlPlaneEndpointUniqueness("255.255.255.255:6443")
 	thenErrorExpected(t, "endpoint <255.255.255.255:6443> is already in use", err)
 }
 
 func TestValidateControlPlaneIpCheckInvalidPort(t *testing.T) {
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, false)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	err := validator.ValidateControlPlaneEndpointUniqueness("255.255.255.255")
 	thenErrorExpected(t, "invalid endpoint - not in host:port format: address 255.255.255.255: missing port in address", err)
 }
 
 func TestValidateControlPlaneIpCheckUniqueIpSuccess(t *testing.T) {
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, false)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	if err := validator.ValidateControlPlaneEndpointUniqueness("1.1.1.1:6443"); err != nil {
 		t.Fatalf("Expected endpoint to be valid and unused")
 	}
@@ -151,7 +145,7 @@ func TestValidateControlPlaneIpCheckUniqueIpSuccess(t *testing.T) {
 func TestValidateMachineConfigsNoControlPlaneEndpointIP(t *testing.T) {
 	ctx := context.Background()
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
@@ -178,7 +172,7 @@ func TestValidateMachineConfigsInvalidControlPlanePort(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, false)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -211,7 +205,7 @@ func TestValidateDatacenterConfigsNoNetwork(t *testing.T) {
 		datacenterConfig:     datacenterConfig,
 		machineConfigsLookup: nil,
 	}
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig.Spec.AvailabilityZones[0].Zone.Network.Id = ""
 	datacenterConfig.Spec.AvailabilityZones[0].Zone.Network.Name = ""
 	setupMockForAvailabilityZonesValidation(cmk, ctx, datacenterConfig.Spec.AvailabilityZones)
@@ -234,7 +228,7 @@ func TestValidateDatacenterBadManagementEndpoint(t *testing.T) {
 		datacenterConfig:     datacenterConfig,
 		machineConfigsLookup: nil,
 	}
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	setupMockForAvailabilityZonesValidation(cmk, ctx, datacenterConfig.Spec.AvailabilityZones)
 
 	datacenterConfig.Spec.AvailabilityZones[0].ManagementApiEndpoint = ":1234.5234"
@@ -257,7 +251,7 @@ func TestValidateDatacenterInconsistentManagementEndpoints(t *testing.T) {
 		datacenterConfig:     datacenterConfig,
 		machineConfigsLookup: nil,
 	}
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	setupMockForAvailabilityZonesValidation(cmk, ctx, datacenterConfig.Spec.AvailabilityZones)
 
 	datacenterConfig.Spec.AvailabilityZones[0].ManagementApiEndpoint = "abcefg.com"
@@ -274,7 +268,7 @@ func TestSetupAndValidateDiskOfferingEmpty(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -313,7 +307,7 @@ func TestSetupAndValidateValidDiskOffering(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -360,7 +354,7 @@ func TestSetupAndValidateInvalidDiskOfferingNotPresent(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -406,7 +400,7 @@ func TestSetupAndValidateInValidDiskOfferingBadMountPath(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -450,7 +444,7 @@ func TestSetupAndValidateInValidDiskOfferingEmptyDevice(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -494,7 +488,7 @@ func TestSetupAndValidateInValidDiskOfferingEmptyFilesystem(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -538,7 +532,7 @@ func TestSetupAndValidateInValidDiskOfferingEmptyLabel(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -582,7 +576,7 @@ func TestSetupAndValidateUsersNil(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -621,7 +615,7 @@ func TestSetupAndValidateRestrictedUserDetails(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -654,7 +648,7 @@ func TestSetupAndValidateSshAuthorizedKeysNil(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -713,7 +707,7 @@ func TestSetupAndValidateCreateClusterCPMachineGroupRefNil(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -739,7 +733,7 @@ func TestSetupAndValidateCreateClusterWorkerMachineGroupRefNil(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -765,7 +759,7 @@ func TestSetupAndValidateCreateClusterEtcdMachineGroupRefNil(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -791,7 +785,7 @@ func TestSetupAndValidateCreateClusterCPMachineGroupRefNonexistent(t *testing.T)
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -817,7 +811,7 @@ func TestSetupAndValidateCreateClusterWorkerMachineGroupRefNonexistent(t *testin
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -843,7 +837,7 @@ func TestSetupAndValidateCreateClusterEtcdMachineGroupRefNonexistent(t *testing.
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -869,7 +863,7 @@ func TestSetupAndValidateCreateClusterTemplateDifferent(t *testing.T) {
 		t.Fatalf("unable to get machine configs from file %s", testClusterConfigMainFilename)
 	}
 	clusterSpec := test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename))
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainFilename))
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
@@ -905,7 +899,7 @@ func TestValidateMachineConfigsHappyCase(t *testing.T) {
 		datacenterConfig:     datacenterConfig,
 		machineConfigsLookup: machineConfigs,
 	}
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	setupMockForAvailabilityZonesValidation(cmk, ctx, datacenterConfig.Spec.AvailabilityZones)
 
 	cmk.EXPECT().ValidateTemplatePresent(ctx, gomock.Any(), gomock.Any(),
@@ -931,7 +925,7 @@ func TestValidateCloudStackMachineConfig(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get datacenter config from file")
 	}
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 
 	cmk.EXPECT().ValidateZoneAndGetId(ctx, gomock.Any(), gomock.Any()).Times(3).Return("4e3b338d-87a6-4189-b931-a1747edeea82", nil)
 	cmk.EXPECT().ValidateTemplatePresent(ctx, gomock.Any(), gomock.Any(),
@@ -974,7 +968,7 @@ func TestValidateMachineConfigsWithAffinity(t *testing.T) {
 		machineConfig.Spec.AffinityGroupIds = []string{}
 	}
 
-	validator := NewValidator(cmk, &DummyNetClient{}, true)
+	validator := NewValidator(cmk, &DummyNetClient{})
 	cmk.EXPECT().ValidateZoneAndGetId(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return("4e3b338d-87a6-4189-b931-a1747edeea8f", nil)
 	cmk.EXPECT().ValidateDomainAndGetId(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	cmk.EXPECT().ValidateAccountPresent(ctx, gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
@@ -1003,3 +997,148 @@ func TestValidateMachineConfigsWithAffinity(t *testing.T) {
 	assert.NotNil(t, err)
 	cloudStackClusterSpec.controlPlaneMachineConfig().Spec.Affinity = originalValue
 }
+
+func givenResourcesValidationSpec(t *testing.T, fileName string) *Spec {
+	machineConfigs, err := v1alpha1.GetCloudStackMachineConfigs(path.Join(testDataDir, fileName))
+	if err != nil {
+		t.Fatalf("unable to get machine configs from file %s", fileName)
+	}
+	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, fileName))
+	if err != nil {
+		t.Fatalf("unable to get datacenter config from file")
+	}
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, fileName))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file")
+	}
+	return &Spec{
+		Spec:                 &cluster.Spec{Config: &cluster.Config{Cluster: clusterConfig}},
+		datacenterConfig:     datacenterConfig,
+		machineConfigsLookup: machineConfigs,
+	}
+}
+
+var testServiceOffering = &decoder.CloudStackServiceOffering{CpuNumber: 2, CpuSpeed: 1000, Memory: 2048}
+
+func TestValidateResourcesAvailableSpreadAcrossAvailabilityZones(t *testing.T) {
+	ctx := context.Background()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	validator := NewValidator(cmk, &DummyNetClient{})
+	cloudStackClusterSpec := givenResourcesValidationSpec(t, testClusterConfigMainWithAZsFilename)
+	cloudStackClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{
+		MinCount: 3,
+		MaxCount: 5,
+	}
+
+	// 3 control plane, 3 etcd and up to 5 workers spread across 2 availability zones: 7 machines per zone
+	accounts := []decoder.CloudStackAccount{{Name: "admin", VmAvailable: "7", CpuAvailable: "14", MemoryAvailable: "14336"}}
+	capacities := []decoder.CloudStackCapacity{
+		{Type: decoder.CloudStackCapacityTypeMemory, Total: 14336 << 20},
+		{Type: decoder.CloudStackCapacityTypeCPU, Total: 14000},
+	}
+	for _, zone := range []string{"zone1", "zone2"} {
+		zoneId := zone + "-id"
+		domain := "domain" + zone[len(zone)-1:]
+		cmk.EXPECT().ValidateZoneAndGetId(ctx, zone, gomock.Any()).Return(zoneId, nil)
+		cmk.EXPECT().ValidateDomainAndGetId(ctx, zone, domain).Return(domain+"-id", nil)
+		cmk.EXPECT().GetServiceOffering(ctx, zone, zoneId, testOffering).Return(testServiceOffering, nil)
+		cmk.EXPECT().ListAccounts(ctx, zone, "admin", domain+"-id").Return(accounts, nil)
+		cmk.EXPECT().ListZoneCapacity(ctx, zone, zoneId).Return(capacities, nil)
+	}
+
+	assert.Nil(t, validator.ValidateResourcesAvailable(ctx, cloudStackClusterSpec))
+}
+
+func TestValidateResourcesAvailable(t *testing.T) {
+	tests := []struct {
+		name          string
+		account       string
+		accounts      []decoder.CloudStackAccount
+		capacities    []decoder.CloudStackCapacity
+		capacityError error
+		wantErr       string
+	}{
+		{
+			name:     "enough resources",
+			account:  "admin",
+			accounts: []decoder.CloudStackAccount{{Name: "admin", VmAvailable: "Unlimited", CpuAvailable: "18", MemoryAvailable: "18432"}},
+			capacities: []decoder.CloudStackCapacity{
+				{Type: decoder.CloudStackCapacityTypeMemory, Total: 64 << 30, Used: 32 << 30},
+				{Type: decoder.CloudStackCapacityTypeCPU, Total: 100000, Used: 50000},
+			},
+		},
+		{
+			name:     "not enough account quota",
+			account:  "admin",
+			accounts: []decoder.CloudStackAccount{{Name: "admin", VmAvailable: "8", CpuAvailable: "Unlimited", MemoryAvailable: "Unlimited"}},
+			wantErr:  "validating resources available in availability zone default-az-0: account admin doesn't have enough quota: 9 vms required, 8 available",
+		},
+		{
+			name:     "not enough zone capacity",
+			account:  "admin",
+			accounts: []decoder.CloudStackAccount{{Name: "admin"}},
+			capacities: []decoder.CloudStackCapacity{
+				{Type: decoder.CloudStackCapacityTypeMemory, Total: 64 << 30},
+				{Type: decoder.CloudStackCapacityTypeCPU, Total: 20000, Used: 5000},
+			},
+			wantErr: "validating resources available in availability zone default-az-0: zone zone-id doesn't have enough cpu: 18000 MHz required, 15000 available",
+		},
+		{
+			name:          "zone capacity not listable",
+			account:       "admin",
+			accounts:      []decoder.CloudStackAccount{{Name: "admin"}},
+			capacityError: errors.New("not allowed"),
+		},
+		{
+			name:     "account not found",
+			account:  "admin",
+			accounts: []decoder.CloudStackAccount{},
+			wantErr:  "validating resources available in availability zone default-az-0: account admin not found",
+		},
+		{
+			name:    "multiple accounts without account name",
+			account: "",
+			accounts: []decoder.CloudStackAccount{
+				{Name: "admin", VmAvailable: "0"},
+				{Name: "dev", VmAvailable: "0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+			validator := NewValidator(cmk, &DummyNetClient{})
+			cloudStackClusterSpec := givenResourcesValidationSpec(t, testClusterConfigMainFilename)
+			cloudStackClusterSpec.datacenterConfig.Spec.AvailabilityZones[0].Account = tt.account
+
+			cmk.EXPECT().ValidateZoneAndGetId(ctx, gomock.Any(), gomock.Any()).Return("zone-id", nil)
+			cmk.EXPECT().ValidateDomainAndGetId(ctx, gomock.Any(), gomock.Any()).Return("domain-id", nil)
+			cmk.EXPECT().GetServiceOffering(ctx, gomock.Any(), "zone-id", testOffering).Return(testServiceOffering, nil)
+			cmk.EXPECT().ListAccounts(ctx, gomock.Any(), tt.account, "domain-id").Return(tt.accounts, nil)
+			cmk.EXPECT().ListZoneCapacity(ctx, gomock.Any(), "zone-id").Return(tt.capacities, tt.capacityError).MaxTimes(1)
+
+			err := validator.ValidateResourcesAvailable(ctx, cloudStackClusterSpec)
+			if tt.wantErr == "" {
+				assert.Nil(t, err)
+			} else {
+				thenErrorExpected(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateResourcesAvailableOfferingNotFound(t *testing.T) {
+	ctx := context.Background()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	validator := NewValidator(cmk, &DummyNetClient{})
+	cloudStackClusterSpec := givenResourcesValidationSpec(t, testClusterConfigMainFilename)
+
+	cmk.EXPECT().ValidateZoneAndGetId(ctx, gomock.Any(), gomock.Any()).Return("zone-id", nil)
+	cmk.EXPECT().ValidateDomainAndGetId(ctx, gomock.Any(), gomock.Any()).Return("domain-id", nil)
+	cmk.EXPECT().GetServiceOffering(ctx, gomock.Any(), "zone-id", testOffering).Return(nil, errors.New("service offering m4-large not found"))
+
+	err := validator.ValidateResourcesAvailable(ctx, cloudStackClusterSpec)
+	thenErrorExpected(t, "validating resources available in availability zone default-az-0: service offering m4-large not found", err)
+}
@@ -0,0 +1,192 @@
+package common
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	sysctlConfigPath   = "/etc/sysctl.d/99-eksa.conf"
+	journaldConfigPath = "/etc/systemd/journald.conf.d/99-eksa.conf"
+	ubuntuCACertsDir   = "/usr/local/share/ca-certificates"
+	redHatCACertsDir   = "/etc/pki/ca-trust/source/anchors"
+)
+
+// HostOSFile is a file written on the host before kubeadm runs.
+type HostOSFile struct {
+	Path    string
+	Content string
+}
+
+// HostOSConfigTemplateValues returns the template values used to render the host OS configuration of a machine
+// as cloud-config ntp settings, files and preKubeadmCommands.
+func HostOSConfigTemplateValues(hostOSConfig *v1alpha1.HostOSConfiguration, osFamily v1alpha1.OSFamily) map[string]interface{} {
+	values := map[string]interface{}{}
+	// Bottlerocket settings are passed to the kernel on boot, see BottlerocketBootconfig.
+	if hostOSConfig == nil || osFamily == v1alpha1.Bottlerocket {
+		return values
+	}
+
+	if hostOSConfig.NTPConfiguration != nil && len(hostOSConfig.NTPConfiguration.Servers) > 0 {
+		values["ntpServers"] = hostOSConfig.NTPConfiguration.Servers
+	}
+
+	files, commands := hostOSFilesAndCommands(hostOSConfig, osFamily)
+	if len(files) > 0 {
+		values["hostOSFiles"] = files
+	}
+	if len(commands) > 0 {
+		values["hostOSCommands"] = commands
+	}
+
+	return values
+}
+
+func hostOSFilesAndCommands(hostOSConfig *v1alpha1.HostOSConfiguration, osFamily v1alpha1.OSFamily) ([]HostOSFile, []string) {
+	var files []HostOSFile
+	var commands []string
+
+	if len(hostOSConfig.CertBundles) > 0 {
+		certsDir, updateCommand := ubuntuCACertsDir, "update-ca-certificates"
+		if osFamily == v1alpha1.RedHat {
+			certsDir, updateCommand = redHatCACertsDir, "update-ca-trust extract"
+		}
+		for _, bundle := range hostOSConfig.CertBundles {
+			files = append(files, HostOSFile{
+				Path:    fmt.Sprintf("%s/%s.crt", certsDir, bundle.Name),
+				Content: strings.TrimSpace(bundle.Data),
+			})
+		}
+		commands = append(commands, updateCommand)
+	}
+
+	if len(hostOSConfig.SysctlSettings) > 0 {
+		keys := make([]string, 0, len(hostOSConfig.SysctlSettings))
+		for key := range hostOSConfig.SysctlSettings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		lines := make([]string, 0, len(keys))
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s = %s", key, hostOSConfig.SysctlSettings[key]))
+		}
+		files = append(files, HostOSFile{
+			Path:    sysctlConfigPath,
+			Content: strings.Join(lines, "\n"),
+		})
+		commands = append(commands, "sysctl --system")
+	}
+
+	if journald := hostOSConfig.JournaldConfiguration; journald != nil {
+		lines := []string{"[Journal]"}
+		if journald.SystemMaxUse != "" {
+			lines = append(lines, "SystemMaxUse="+journald.SystemMaxUse)
+		}
+		if journald.SystemMaxFileSize != "" {
+			lines = append(lines, "SystemMaxFileSize="+journald.SystemMaxFileSize)
+		}
+		if journald.MaxRetentionSec != "" {
+			lines = append(lines, "MaxRetentionSec="+journald.MaxRetentionSec)
+		}
+		if len(lines) > 1 {
+			files = append(files, HostOSFile{
+				Path:    journaldConfigPath,
+				Content: strings.Join(lines, "\n"),
+			})
+			commands = append(commands, "systemctl restart systemd-journald")
+		}
+	}
+
+	return files, commands
+}
+
+// BottlerocketBootconfig returns the bottlerocket boot configuration passing the sysctl settings and the boot
+// kernel parameters of the host OS configuration to the kernel.
+func BottlerocketBootconfig(hostOSConfig *v1alpha1.HostOSConfiguration) string {
+	params := map[string][]string{}
+	if hostOSConfig != nil {
+		for key, value := range hostOSConfig.SysctlSettings {
+			params["sysctl."+key] = []string{value}
+		}
+		if c := hostOSConfig.BottlerocketConfiguration; c != nil && c.Boot != nil {
+			for key, values := range c.Boot.BootKernelParameters {
+				params[key] = values
+			}
+		}
+	}
+
+	if len(params) == 0 {
+		return "kernel {}"
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := []string{"kernel {"}
+	for _, key := range keys {
+		if len(params[key]) == 0 {
+			lines = append(lines, "    "+key)
+			continue
+		}
+		values := make([]string, 0, len(params[key]))
+		for _, value := range params[key] {
+			values = append(values, `"`+value+`"`)
+		}
+		lines = append(lines, fmt.Sprintf("    %s = %s", key, strings.Join(values, ", ")))
+	}
+	lines = append(lines, "}")
+
+	return strings.Join(lines, "\n")
+}
+
+// HostOSConfigFromRendered rebuilds the host OS configuration of a machine from the ntp servers
+// and files rendered in its kubeadm config.
+func HostOSConfigFromRendered(ntpServers []string, files []HostOSFile) *v1alpha1.HostOSConfiguration {
+	config := &v1alpha1.HostOSConfiguration{}
+	if len(ntpServers) > 0 {
+		config.NTPConfiguration = &v1alpha1.NTPConfiguration{Servers: ntpServers}
+	}
+
+	for _, file := range files {
+		switch {
+		case file.Path == sysctlConfigPath:
+			config.SysctlSettings = map[string]string{}
+			for _, line := range strings.Split(file.Content, "\n") {
+				key, value, found := strings.Cut(line, "=")
+				if !found {
+					continue
+				}
+				config.SysctlSettings[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		case file.Path == journaldConfigPath:
+			config.JournaldConfiguration = &v1alpha1.JournaldConfiguration{}
+			for _, line := range strings.Split(file.Content, "\n") {
+				key, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+				switch key {
+				case "SystemMaxUse":
+					config.JournaldConfiguration.SystemMaxUse = value
+				case "SystemMaxFileSize":
+					config.JournaldConfiguration.SystemMaxFileSize = value
+				case "MaxRetentionSec":
+					config.JournaldConfiguration.MaxRetentionSec = value
+				}
+			}
+		case strings.HasSuffix(file.Path, ".crt") &&
+			(strings.HasPrefix(file.Path, ubuntuCACertsDir+"/") || strings.HasPrefix(file.Path, redHatCACertsDir+"/")):
+			config.CertBundles = append(config.CertBundles, v1alpha1.CertBundle{
+				Name: strings.TrimSuffix(path.Base(file.Path), ".crt"),
+				Data: file.Content,
+			})
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,116 @@
+package common_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
+)
+
+func testHostOSConfig() *v1alpha1.HostOSConfiguration {
+	return &v1alpha1.HostOSConfiguration{
+		NTPConfiguration: &v1alpha1.NTPConfiguration{Servers: []string{"time.example.com"}},
+		CertBundles:      []v1alpha1.CertBundle{{Name: "corp-ca", Data: "-----BEGIN CERTIFICATE-----\nMIIBAQ==\n-----END CERTIFICATE-----"}},
+		SysctlSettings:   map[string]string{"vm.max_map_count": "262144", "net.ipv4.ip_forward": "1"},
+		JournaldConfiguration: &v1alpha1.JournaldConfiguration{
+			SystemMaxUse: "1G",
+		},
+	}
+}
+
+func TestHostOSConfigTemplateValuesNil(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(common.HostOSConfigTemplateValues(nil, v1alpha1.Ubuntu)).To(BeEmpty())
+}
+
+func TestHostOSConfigTemplateValuesUbuntu(t *testing.T) {
+	g := NewWithT(t)
+	values := common.HostOSConfigTemplateValues(testHostOSConfig(), v1alpha1.Ubuntu)
+	g.Expect(values["ntpServers"]).To(Equal([]string{"time.example.com"}))
+	g.Expect(values["hostOSFiles"]).To(Equal([]common.HostOSFile{
+		{
+			Path:    "/usr/local/share/ca-certificates/corp-ca.crt",
+			Content: "-----BEGIN CERTIFICATE-----\nMIIBAQ==\n-----END CERTIFICATE-----",
+		},
+		{
+			Path:    "/etc/sysctl.d/99-eksa.conf",
+			Content: "net.ipv4.ip_forward = 1\nvm.max_map_count = 262144",
+		},
+		{
+			Path:    "/etc/systemd/journald.conf.d/99-eksa.conf",
+			Content: "[Journal]\nSystemMaxUse=1G",
+		},
+	}))
+	g.Expect(values["hostOSCommands"]).To(Equal([]string{
+		"update-ca-certificates",
+		"sysctl --system",
+		"systemctl restart systemd-journald",
+	}))
+	g.Expect(values).NotTo(HaveKey("certBundles"))
+}
+
+func TestHostOSConfigTemplateValuesRedHat(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.HostOSConfiguration{
+		CertBundles: testHostOSConfig().CertBundles,
+	}
+	values := common.HostOSConfigTemplateValues(config, v1alpha1.RedHat)
+	g.Expect(values["hostOSFiles"]).To(Equal([]common.HostOSFile{
+		{
+			Path:    "/etc/pki/ca-trust/source/anchors/corp-ca.crt",
+			Content: "-----BEGIN CERTIFICATE-----\nMIIBAQ==\n-----END CERTIFICATE-----",
+		},
+	}))
+	g.Expect(values["hostOSCommands"]).To(Equal([]string{"update-ca-trust extract"}))
+}
+
+func TestHostOSConfigTemplateValuesBottlerocket(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.HostOSConfiguration{
+		SysctlSettings: map[string]string{"vm.max_map_count": "262144"},
+	}
+	g.Expect(common.HostOSConfigTemplateValues(config, v1alpha1.Bottlerocket)).To(BeEmpty())
+}
+
+func TestBottlerocketBootconfigEmpty(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(common.BottlerocketBootconfig(nil)).To(Equal("kernel {}"))
+	g.Expect(common.BottlerocketBootconfig(&v1alpha1.HostOSConfiguration{})).To(Equal("kernel {}"))
+}
+
+func TestBottlerocketBootconfig(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.HostOSConfiguration{
+		SysctlSettings: map[string]string{"vm.max_map_count": "262144"},
+		BottlerocketConfiguration: &v1alpha1.BottlerocketConfiguration{
+			Boot: &v1alpha1.BottlerocketBootSettings{
+				BootKernelParameters: map[string][]string{
+					"console": {"tty0", "ttyS0,115200n8"},
+					"quiet":   nil,
+				},
+			},
+		},
+	}
+	g.Expect(common.BottlerocketBootconfig(config)).To(Equal(`kernel {
+    console = "tty0", "ttyS0,115200n8"
+    quiet
+    sysctl.vm.max_map_count = "262144"
+}`))
+}
+
+func TestHostOSConfigFromRendered(t *testing.T) {
+	g := NewWithT(t)
+	config := testHostOSConfig()
+	values := common.HostOSConfigTemplateValues(config, v1alpha1.Ubuntu)
+	files := values["hostOSFiles"].([]common.HostOSFile)
+	// rendered files get a trailing new line from the yaml block scalar
+	for i := range files {
+		files[i].Content += "\n"
+	}
+	files = append(files, common.HostOSFile{Path: "/etc/kubernetes/audit-policy.yaml", Content: "policy"})
+
+	got := common.HostOSConfigFromRendered(values["ntpServers"].([]string), files)
+	g.Expect(got.Equal(config)).To(BeTrue())
+}
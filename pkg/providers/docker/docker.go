@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"fmt"
 	"os"
+	"reflect"
 	"regexp"
 
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
@@ -40,6 +41,13 @@ var eksaDockerResourceType = fmt.Sprintf("dockerdatacenterconfigs.%s", v1alpha1.
 
 type ProviderClient interface {
 	GetDockerLBPort(ctx context.Context, clusterName string) (port string, err error)
+	Run(ctx context.Context, image string, name string, cmd []string, flags ...string) error
+	ForceRemove(ctx context.Context, name string) error
+	CheckContainerExistence(ctx context.Context, name string) (bool, error)
+	WriteFileToContainer(ctx context.Context, name, path string, content []byte) error
+	KillContainer(ctx context.Context, name, signal string) error
+	GetContainerImage(ctx context.Context, name string) (string, error)
+	GetContainerPortBindings(ctx context.Context, name string) ([]string, error)
 }
 
 type provider struct {
@@ -59,6 +67,8 @@ type ProviderKubectlClient interface {
 	GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*controlplanev1.KubeadmControlPlane, error)
 	GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*etcdv1.EtcdadmCluster, error)
 	UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error
+	GetEksaDockerDatacenterConfig(ctx context.Context, dockerDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.DockerDatacenterConfig, error)
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
 }
 
 func NewProvider(providerConfig *v1alpha1.DockerDatacenterConfig, docker ProviderClient, providerKubectlClient ProviderKubectlClient, now types.NowFunc) providers.Provider {
@@ -96,6 +106,18 @@ func (p *provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 	return nil
 }
 
+func (p *provider) PreMachinesRemoval(_ context.Context, _ *cluster.Spec, _ *types.Cluster) error {
+	return nil
+}
+
+func (p *provider) PostControlPlaneReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	return nil
+}
+
+func (p *provider) PostWorkerNodesReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	return p.reconcileIngress(ctx, clusterSpec, managementCluster)
+}
+
 func (p *provider) Name() string {
 	return constants.DockerProviderName
 }
@@ -112,6 +134,10 @@ func (p *provider) DeleteResources(_ context.Context, _ *cluster.Spec) error {
 	return nil
 }
 
+func (p *provider) PostClusterDelete(ctx context.Context, cluster *types.Cluster) error {
+	return p.deleteIngress(ctx, cluster.Name)
+}
+
 func (p *provider) PostClusterDeleteValidate(_ context.Context, _ *types.Cluster) error {
 	// No validations
 	return nil
@@ -124,6 +150,11 @@ func (p *provider) PostMoveManagementToBootstrap(_ context.Context, _ *types.Clu
 
 func (p *provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
 	logger.Info("Warning: The docker infrastructure provider is meant for local development and testing only")
+	return validatePortMappings(clusterSpec)
+}
+
+func (p *provider) CreateClusterChecks(_ context.Context, _ *cluster.Spec) []providers.Check {
+	// No checks
 	return nil
 }
 
@@ -131,7 +162,12 @@ func (p *provider) SetupAndValidateDeleteCluster(ctx context.Context, _ *types.C
 	return nil
 }
 
-func (p *provider) SetupAndValidateUpgradeCluster(ctx context.Context, _ *types.Cluster, _ *cluster.Spec, _ *cluster.Spec) error {
+func (p *provider) SetupAndValidateUpgradeCluster(ctx context.Context, _ *types.Cluster, clusterSpec *cluster.Spec, _ *cluster.Spec) error {
+	return validatePortMappings(clusterSpec)
+}
+
+func (p *provider) UpgradeClusterChecks(_ context.Context, _ *cluster.Spec) []providers.Check {
+	// No checks
 	return nil
 }
 
@@ -220,6 +256,11 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 		"haproxyImageTag":            bundle.Haproxy.Image.Tag(),
 	}
 
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = true
+		values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
 		values["externalEtcd"] = true
 		values["externalEtcdReplicas"] = clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Count
@@ -230,11 +271,13 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 
 	values["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
 
+	populateDatacenterValues(values, clusterSpec.DockerDatacenter)
+
 	return values
 }
 
 func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
-	bundle := clusterSpec.VersionsBundle
+	bundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
@@ -251,19 +294,66 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		"autoscalingConfig":     workerNodeGroupConfiguration.AutoScalingConfiguration,
 	}
 
+	if workerNodeGroupConfiguration.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = true
+		values["maxSurge"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
+	}
+
+	populateDatacenterValues(values, clusterSpec.DockerDatacenter)
+
 	return values
 }
 
+func populateDatacenterValues(values map[string]interface{}, datacenterConfig *v1alpha1.DockerDatacenterConfig) {
+	if datacenterConfig == nil {
+		return
+	}
+
+	values["extraMounts"] = datacenterConfig.Spec.ExtraMounts
+	if mirror := datacenterConfig.Spec.RegistryMirror; mirror != nil {
+		values["registryMirror"] = true
+		values["registryMirrorEndpoint"] = mirror.Endpoint
+		values["registryMirrorHost"] = mirror.Host()
+		values["registryMirrorRegistries"] = mirror.MirroredRegistries()
+		values["registryMirrorInsecureSkipVerify"] = mirror.InsecureSkipVerify
+	}
+}
+
+// ExtraMountsChanged returns true if the extra mounts of the node containers differ between two datacenter configs.
+func ExtraMountsChanged(current, new *v1alpha1.DockerDatacenterConfig) bool {
+	return !reflect.DeepEqual(datacenterSpec(current).ExtraMounts, datacenterSpec(new).ExtraMounts)
+}
+
+// RegistryMirrorChanged returns true if the registry mirror configured in the nodes differs between two datacenter configs.
+func RegistryMirrorChanged(current, new *v1alpha1.DockerDatacenterConfig) bool {
+	return !reflect.DeepEqual(datacenterSpec(current).RegistryMirror, datacenterSpec(new).RegistryMirror)
+}
+
+func datacenterSpec(datacenterConfig *v1alpha1.DockerDatacenterConfig) v1alpha1.DockerDatacenterConfigSpec {
+	if datacenterConfig == nil {
+		return v1alpha1.DockerDatacenterConfigSpec{}
+	}
+	spec := datacenterConfig.Spec
+	if len(spec.ExtraMounts) == 0 {
+		spec.ExtraMounts = nil
+	}
+	if len(spec.PortMappings) == 0 {
+		spec.PortMappings = nil
+	}
+	return spec
+}
+
 func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec) bool {
 	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
 }
 
-func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec) bool {
+func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldWorkerNodeGroup, newWorkerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) bool {
 	if !v1alpha1.WorkerNodeGroupConfigurationSliceTaintsEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) ||
 		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
 		return true
 	}
-	return (oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
+	return (oldSpec.Cluster.WorkerNodeGroupKubernetesVersion(oldWorkerNodeGroup) != newSpec.Cluster.WorkerNodeGroupKubernetesVersion(newWorkerNodeGroup)) || (oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number)
 }
 
 func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration) bool {
@@ -279,7 +369,14 @@ func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 	var controlPlaneTemplateName, workloadTemplateName, kubeadmconfigTemplateName, etcdTemplateName string
 	var needsNewEtcdTemplate bool
 
-	needsNewControlPlaneTemplate := NeedsNewControlPlaneTemplate(currentSpec, newClusterSpec)
+	currentDatacenterConfig, err := p.providerKubectlClient.GetEksaDockerDatacenterConfig(ctx, newClusterSpec.Cluster.Spec.DatacenterRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	extraMountsChanged := ExtraMountsChanged(currentDatacenterConfig, newClusterSpec.DockerDatacenter)
+	registryMirrorChanged := RegistryMirrorChanged(currentDatacenterConfig, newClusterSpec.DockerDatacenter)
+
+	needsNewControlPlaneTemplate := NeedsNewControlPlaneTemplate(currentSpec, newClusterSpec) || extraMountsChanged
 	if !needsNewControlPlaneTemplate {
 		cp, err := p.providerKubectlClient.GetKubeadmControlPlane(ctx, workloadCluster, workloadCluster.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
 		if err != nil {
@@ -299,10 +396,12 @@ func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 		if err != nil {
 			return nil, nil, err
 		}
+		needsNewWorkloadTemplate = needsNewWorkloadTemplate || extraMountsChanged
 		needsNewKubeadmConfigTemplate, err := p.needsNewKubeadmConfigTemplate(workerNodeGroupConfiguration, previousWorkerNodeGroupConfigs)
 		if err != nil {
 			return nil, nil, err
 		}
+		needsNewKubeadmConfigTemplate = needsNewKubeadmConfigTemplate || registryMirrorChanged
 
 		if !needsNewKubeadmConfigTemplate {
 			mdName := machineDeploymentName(newClusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name)
@@ -332,7 +431,7 @@ func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 	}
 
 	if newClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
-		needsNewEtcdTemplate = NeedsNewEtcdTemplate(currentSpec, newClusterSpec)
+		needsNewEtcdTemplate = NeedsNewEtcdTemplate(currentSpec, newClusterSpec) || extraMountsChanged
 		if !needsNewEtcdTemplate {
 			etcdadmCluster, err := p.providerKubectlClient.GetEtcdadmCluster(ctx, workloadCluster, newClusterSpec.Cluster.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
 			if err != nil {
@@ -372,8 +471,8 @@ func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 }
 
 func (p *provider) needsNewMachineTemplate(currentSpec, newClusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration) (bool, error) {
-	if _, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
-		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec)
+	if prevWorkerNodeGroupConfig, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
+		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, prevWorkerNodeGroupConfig, workerNodeGroupConfiguration)
 		return needsNewWorkloadTemplate, nil
 	}
 	return true, nil
@@ -514,8 +613,13 @@ func (p *provider) RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpe
 	return nil
 }
 
-func (p *provider) UpgradeNeeded(_ context.Context, _, _ *cluster.Spec, _ *types.Cluster) (bool, error) {
-	return false, nil
+func (p *provider) UpgradeNeeded(ctx context.Context, newSpec, _ *cluster.Spec, cluster *types.Cluster) (bool, error) {
+	currentDatacenterConfig, err := p.providerKubectlClient.GetEksaDockerDatacenterConfig(ctx, newSpec.Cluster.Spec.DatacenterRef.Name, cluster.KubeconfigFile, newSpec.Cluster.Namespace)
+	if err != nil {
+		return false, err
+	}
+
+	return !reflect.DeepEqual(datacenterSpec(currentDatacenterConfig), datacenterSpec(newSpec.DockerDatacenter)), nil
 }
 
 func (p *provider) RunPostControlPlaneCreation(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
@@ -337,6 +337,7 @@ func TestProviderGenerateDeploymentFileSuccessUpdateMachineTemplate(t *testing.T
 					gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster)),
 					gomock.AssignableToTypeOf(executables.WithNamespace(constants.EksaSystemNamespace))).Return(md, nil)
 			}
+			kubectl.EXPECT().GetEksaDockerDatacenterConfig(ctx, tt.clusterSpec.Cluster.Spec.DatacenterRef.Name, cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(&v1alpha1.DockerDatacenterConfig{}, nil)
 			kubectl.EXPECT().UpdateAnnotation(ctx, "etcdadmcluster", fmt.Sprintf("%s-etcd", tt.clusterSpec.Cluster.Name),
 				map[string]string{etcdv1.UpgradeInProgressAnnotation: "true"}, gomock.Any(), gomock.Any())
 			cpContent, mdContent, err := p.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, currentSpec, tt.clusterSpec)
@@ -399,6 +400,7 @@ func TestProviderGenerateDeploymentFileSuccessUpdateKubeadmConfigTemplate(t *tes
 		},
 	}
 
+	kubectl.EXPECT().GetEksaDockerDatacenterConfig(ctx, clusterSpec.Cluster.Spec.DatacenterRef.Name, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(&v1alpha1.DockerDatacenterConfig{}, nil)
 	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(cp, nil)
 	kubectl.EXPECT().GetEtcdadmCluster(ctx, cluster, cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(etcdadm, nil)
 
@@ -456,6 +458,7 @@ func TestProviderGenerateDeploymentFileSuccessNotUpdateMachineTemplate(t *testin
 	}
 	machineDeploymentName := fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Name)
 
+	kubectl.EXPECT().GetEksaDockerDatacenterConfig(ctx, clusterSpec.Cluster.Spec.DatacenterRef.Name, cluster.KubeconfigFile, clusterSpec.Cluster.Namespace).Return(&v1alpha1.DockerDatacenterConfig{}, nil)
 	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(cp, nil)
 	kubectl.EXPECT().GetMachineDeployment(ctx, machineDeploymentName, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(md, nil).Times(2)
 
@@ -652,3 +655,325 @@ func TestProviderGenerateCAPISpecForCreateWithStackedEtcd(t *testing.T) {
 	}
 	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_stacked_etcd_expected.yaml")
 }
+
+func TestNeedsNewWorkloadTemplateWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	g := NewWithT(t)
+	kube122 := v1alpha1.Kube122
+	oldSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube122
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{Name: "md-0"},
+			{Name: "md-1", KubernetesVersion: &kube122},
+		}
+	})
+	newSpec := oldSpec.DeepCopy()
+	newSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube123
+
+	oldGroups := oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations
+	newGroups := newSpec.Cluster.Spec.WorkerNodeGroupConfigurations
+	g.Expect(docker.NeedsNewWorkloadTemplate(oldSpec, newSpec, oldGroups[0], newGroups[0])).To(BeTrue())
+	g.Expect(docker.NeedsNewWorkloadTemplate(oldSpec, newSpec, oldGroups[1], newGroups[1])).To(BeFalse())
+}
+
+func TestProviderGenerateCAPISpecForCreateWithExtraMountsAndRegistryMirror(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.KubernetesVersion = "1.19"
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.VersionsBundle = versionsBundle
+		s.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", Count: 3, MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}}}
+		s.DockerDatacenter = &v1alpha1.DockerDatacenterConfig{
+			Spec: v1alpha1.DockerDatacenterConfigSpec{
+				ExtraMounts: []v1alpha1.DockerMount{
+					{HostPath: "/data", ContainerPath: "/mnt/data"},
+					{HostPath: "/etc/certs", ContainerPath: "/etc/certs", ReadOnly: true},
+				},
+				RegistryMirror: &v1alpha1.DockerRegistryMirror{
+					Endpoint:           "https://192.168.1.2:5000",
+					Registries:         []string{"public.ecr.aws", "docker.io"},
+					InsecureSkipVerify: true,
+				},
+			},
+		}
+	})
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_extra_mounts_registry_mirror_expected.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/valid_deployment_md_extra_mounts_registry_mirror_expected.yaml")
+}
+
+func TestProviderSetupAndValidateCreateClusterPortMappingUnknownWorkerNodeGroup(t *testing.T) {
+	tt := newTest(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", Count: 1}}
+		s.DockerDatacenter = &v1alpha1.DockerDatacenterConfig{
+			Spec: v1alpha1.DockerDatacenterConfigSpec{
+				PortMappings: []v1alpha1.DockerPortMapping{{WorkerNodeGroup: "md-1", HostPort: 8080, NodePort: 30080}},
+			},
+		}
+	})
+
+	tt.Expect(tt.provider.SetupAndValidateCreateCluster(context.Background(), clusterSpec)).To(
+		MatchError(ContainSubstring("references worker node group md-1, which doesn't exist")),
+	)
+}
+
+func ingressTestSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.VersionsBundle = versionsBundle
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", Count: 2}, {Name: "md-1", Count: 1}}
+		s.DockerDatacenter = &v1alpha1.DockerDatacenterConfig{
+			Spec: v1alpha1.DockerDatacenterConfigSpec{
+				PortMappings: []v1alpha1.DockerPortMapping{
+					{WorkerNodeGroup: "md-0", HostPort: 8080, NodePort: 30080},
+					{WorkerNodeGroup: "md-1", HostPort: 8443, NodePort: 30443, ListenAddress: "0.0.0.0"},
+				},
+			},
+		}
+	})
+}
+
+func ingressTestMachines() []types.Machine {
+	machine := func(name, machineDeployment string) types.Machine {
+		m := types.Machine{}
+		m.Metadata.Name = name
+		m.Metadata.Labels = map[string]string{}
+		if machineDeployment != "" {
+			m.Metadata.Labels[clusterv1.MachineDeploymentLabelName] = machineDeployment
+		}
+		return m
+	}
+	return []types.Machine{
+		machine("test-cluster-md-0-bbbbb", "test-cluster-md-0"),
+		machine("test-cluster-control-plane-xxxxx", ""),
+		machine("test-cluster-md-1-ccccc", "test-cluster-md-1"),
+		machine("test-cluster-md-0-aaaaa", "test-cluster-md-0"),
+	}
+}
+
+func (tt *dockerTest) expectConfigureIngress(t *testing.T, ctx context.Context) {
+	tt.dockerClient.EXPECT().WriteFileToContainer(ctx, "test-cluster-ingress", "/usr/local/etc/haproxy/haproxy.cfg", gomock.Any()).Do(
+		func(_ context.Context, _, _ string, content []byte) {
+			test.AssertContentToFile(t, string(content), "testdata/expected_results_ingress.cfg")
+		},
+	)
+	tt.dockerClient.EXPECT().KillContainer(ctx, "test-cluster-ingress", "SIGHUP")
+}
+
+func TestProviderPostWorkerNodesReadyCreatesIngress(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	mgmtCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	clusterSpec := ingressTestSpec()
+	image := versionsBundle.Haproxy.Image.VersionedImage()
+
+	tt.kubectl.EXPECT().GetMachines(ctx, mgmtCluster, "test-cluster").Return(ingressTestMachines(), nil)
+	tt.dockerClient.EXPECT().CheckContainerExistence(ctx, "test-cluster-ingress").Return(false, nil).Times(2)
+	tt.dockerClient.EXPECT().Run(ctx, image, "test-cluster-ingress", nil,
+		"--network", "kind", "--restart", "unless-stopped", "-p", "127.0.0.1:8080:8080", "-p", "0.0.0.0:8443:8443",
+	)
+	tt.expectConfigureIngress(t, ctx)
+
+	tt.Expect(tt.provider.PostWorkerNodesReady(ctx, clusterSpec, mgmtCluster)).To(Succeed())
+}
+
+func TestProviderPostWorkerNodesReadyUpdatesIngressInPlace(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	mgmtCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	clusterSpec := ingressTestSpec()
+	image := versionsBundle.Haproxy.Image.VersionedImage()
+
+	tt.kubectl.EXPECT().GetMachines(ctx, mgmtCluster, "test-cluster").Return(ingressTestMachines(), nil)
+	tt.dockerClient.EXPECT().CheckContainerExistence(ctx, "test-cluster-ingress").Return(true, nil)
+	tt.dockerClient.EXPECT().GetContainerImage(ctx, "test-cluster-ingress").Return(image, nil)
+	tt.dockerClient.EXPECT().GetContainerPortBindings(ctx, "test-cluster-ingress").Return([]string{"0.0.0.0:8443:8443/tcp", "127.0.0.1:8080:8080/tcp"}, nil)
+	tt.expectConfigureIngress(t, ctx)
+
+	tt.Expect(tt.provider.PostWorkerNodesReady(ctx, clusterSpec, mgmtCluster)).To(Succeed())
+}
+
+func TestProviderPostWorkerNodesReadyRecreatesIngressPortsChanged(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	mgmtCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	clusterSpec := ingressTestSpec()
+	image := versionsBundle.Haproxy.Image.VersionedImage()
+
+	tt.kubectl.EXPECT().GetMachines(ctx, mgmtCluster, "test-cluster").Return(ingressTestMachines(), nil)
+	tt.dockerClient.EXPECT().CheckContainerExistence(ctx, "test-cluster-ingress").Return(true, nil).Times(2)
+	tt.dockerClient.EXPECT().GetContainerImage(ctx, "test-cluster-ingress").Return(image, nil)
+	tt.dockerClient.EXPECT().GetContainerPortBindings(ctx, "test-cluster-ingress").Return([]string{"127.0.0.1:8080:8080/tcp"}, nil)
+	tt.dockerClient.EXPECT().ForceRemove(ctx, "test-cluster-ingress")
+	tt.dockerClient.EXPECT().Run(ctx, image, "test-cluster-ingress", nil,
+		"--network", "kind", "--restart", "unless-stopped", "-p", "127.0.0.1:8080:8080", "-p", "0.0.0.0:8443:8443",
+	)
+	tt.expectConfigureIngress(t, ctx)
+
+	tt.Expect(tt.provider.PostWorkerNodesReady(ctx, clusterSpec, mgmtCluster)).To(Succeed())
+}
+
+func TestProviderPostWorkerNodesReadyRecreatesIngressImageChanged(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	mgmtCluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+	clusterSpec := ingressTestSpec()
+	image := versionsBundle.Haproxy.Image.VersionedImage()
+
+	tt.kubectl.EXPECT().GetMachines(ctx, mgmtCluster, "test-cluster").Return(ingressTestMachines(), nil)
+	tt.dockerClient.EXPECT().CheckContainerExistence(ctx, "test-cluster-ingress").Return(true, nil).Times(2)
+	tt.dockerClient.EXPECT().GetContainerImage(ctx, "test-cluster-ingress").Return("haproxy:old", nil)
+	tt.dockerClient.EXPECT().ForceRemove(ctx, "test-cluster-ingress")
+	tt.dockerClient.EXPECT().Run(ctx, image, "test-cluster-ingress", nil,
+		"--network", "kind", "--restart", "unless-stopped", "-p", "127.0.0.1:8080:8080", "-p", "0.0.0.0:8443:8443",
+	)
+	tt.expectConfigureIngress(t, ctx)
+
+	tt.Expect(tt.provider.PostWorkerNodesReady(ctx, clusterSpec, mgmtCluster)).To(Succeed())
+}
+
+func TestProviderPostWorkerNodesReadyNoPortMappings(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	mgmtCluster := &types.Cluster{Name: "mgmt"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.DockerDatacenter = &v1alpha1.DockerDatacenterConfig{}
+	})
+
+	tt.dockerClient.EXPECT().CheckContainerExistence(ctx, "test-cluster-ingress").Return(false, nil)
+
+	tt.Expect(tt.provider.PostWorkerNodesReady(ctx, clusterSpec, mgmtCluster)).To(Succeed())
+}
+
+func TestProviderUpgradeNeededDatacenterChanged(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{Name: "test-cluster", KubeconfigFile: "test.kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Namespace = "default"
+		s.Cluster.Spec.DatacenterRef = v1alpha1.Ref{Kind: v1alpha1.DockerDatacenterKind, Name: "test-cluster"}
+		s.DockerDatacenter = &v1alpha1.DockerDatacenterConfig{
+			Spec: v1alpha1.DockerDatacenterConfigSpec{
+				ExtraMounts: []v1alpha1.DockerMount{{HostPath: "/data", ContainerPath: "/mnt/data"}},
+			},
+		}
+	})
+
+	tt.kubectl.EXPECT().GetEksaDockerDatacenterConfig(ctx, "test-cluster", "test.kubeconfig", "default").Return(&v1alpha1.DockerDatacenterConfig{}, nil)
+	tt.Expect(tt.provider.UpgradeNeeded(ctx, clusterSpec, clusterSpec, workloadCluster)).To(BeTrue())
+
+	tt.kubectl.EXPECT().GetEksaDockerDatacenterConfig(ctx, "test-cluster", "test.kubeconfig", "default").Return(clusterSpec.DockerDatacenter.DeepCopy(), nil)
+	tt.Expect(tt.provider.UpgradeNeeded(ctx, clusterSpec, clusterSpec, workloadCluster)).To(BeFalse())
+}
+
+func TestProviderPostClusterDeleteRemovesIngress(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{Name: "test-cluster"}
+
+	tt.dockerClient.EXPECT().CheckContainerExistence(ctx, "test-cluster-ingress").Return(true, nil)
+	tt.dockerClient.EXPECT().ForceRemove(ctx, "test-cluster-ingress")
+
+	tt.Expect(tt.provider.PostClusterDelete(ctx, workloadCluster)).To(Succeed())
+}
+
+func TestProviderSetupAndValidateDeleteClusterKeepsIngress(t *testing.T) {
+	tt := newTest(t)
+
+	tt.Expect(tt.provider.SetupAndValidateDeleteCluster(context.Background(), &types.Cluster{Name: "test-cluster"}, nil)).To(Succeed())
+}
+
+func haUpgradeTestSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.KubernetesVersion = "1.19"
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.VersionsBundle = versionsBundle
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: 1, MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"}}
+	})
+}
+
+func haUpgradeMachineDeployment() *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap:         clusterv1.Bootstrap{ConfigRef: &v1.ObjectReference{Name: "test-cluster-md-0-original"}},
+					InfrastructureRef: v1.ObjectReference{Name: "test-cluster-md-0-original"},
+				},
+			},
+		},
+	}
+}
+
+func TestProviderGenerateCAPISpecForUpgradeHAControlPlaneKubernetesVersion(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{Name: "test-cluster"}
+	bootstrapCluster := &types.Cluster{Name: "bootstrap-test"}
+	currentSpec := haUpgradeTestSpec()
+	newSpec := haUpgradeTestSpec()
+	newSpec.Cluster.Spec.KubernetesVersion = "1.20"
+
+	tt.kubectl.EXPECT().GetEksaDockerDatacenterConfig(ctx, newSpec.Cluster.Spec.DatacenterRef.Name, workloadCluster.KubeconfigFile, newSpec.Cluster.Namespace).Return(&v1alpha1.DockerDatacenterConfig{}, nil)
+	tt.kubectl.EXPECT().GetMachineDeployment(ctx, "test-cluster-md-0", gomock.Any(), gomock.Any()).Return(haUpgradeMachineDeployment(), nil)
+
+	cp, _, err := tt.provider.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, workloadCluster, currentSpec, newSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	// All the control plane replicas are rolled out with a new template, behind the same load balancer
+	tt.Expect(string(cp)).To(ContainSubstring("name: test-cluster-control-plane-template-1234567890000"))
+	tt.Expect(string(cp)).To(ContainSubstring("replicas: 3"))
+	tt.Expect(string(cp)).To(ContainSubstring("loadBalancer:"))
+}
+
+func TestProviderGenerateCAPISpecForUpgradeScaleToHAControlPlane(t *testing.T) {
+	tt := newTest(t)
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{Name: "test-cluster"}
+	bootstrapCluster := &types.Cluster{Name: "bootstrap-test"}
+	currentSpec := haUpgradeTestSpec()
+	currentSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	newSpec := haUpgradeTestSpec()
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: v1.ObjectReference{Name: "test-cluster-control-plane-template-original"},
+			},
+		},
+	}
+
+	tt.kubectl.EXPECT().GetEksaDockerDatacenterConfig(ctx, newSpec.Cluster.Spec.DatacenterRef.Name, workloadCluster.KubeconfigFile, newSpec.Cluster.Namespace).Return(&v1alpha1.DockerDatacenterConfig{}, nil)
+	tt.kubectl.EXPECT().GetKubeadmControlPlane(ctx, workloadCluster, workloadCluster.Name, gomock.Any(), gomock.Any()).Return(kcp, nil)
+	tt.kubectl.EXPECT().GetMachineDeployment(ctx, "test-cluster-md-0", gomock.Any(), gomock.Any()).Return(haUpgradeMachineDeployment(), nil).Times(2)
+
+	cp, _, err := tt.provider.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, workloadCluster, currentSpec, newSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	// Scaling the control plane reuses the current template, so existing nodes are not rolled out
+	tt.Expect(string(cp)).To(ContainSubstring("name: test-cluster-control-plane-template-original"))
+	tt.Expect(string(cp)).NotTo(ContainSubstring("test-cluster-control-plane-template-1234567890000"))
+	tt.Expect(string(cp)).To(ContainSubstring("replicas: 3"))
+}
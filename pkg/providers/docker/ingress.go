@@ -0,0 +1,174 @@
+package docker
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"reflect"
+	"sort"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// dockerNetwork is the network CAPD creates the node containers in.
+	dockerNetwork     = "kind"
+	ingressConfigPath = "/usr/local/etc/haproxy/haproxy.cfg"
+)
+
+//go:embed config/template-ingress.cfg
+var ingressConfigTemplate string
+
+type ingressBackend struct {
+	HostPort int
+	NodePort int
+	Nodes    []string
+}
+
+func ingressContainerName(clusterName string) string {
+	return fmt.Sprintf("%s-ingress", clusterName)
+}
+
+// reconcileIngress makes sure the HAProxy container publishes the port mappings of the worker node groups
+// on the host, forwarding the traffic to the current node containers of each group. The configuration of
+// an existing container is reloaded in place, the container is only recreated when its image or published
+// ports change, since those can't be updated on a running container.
+func (p *provider) reconcileIngress(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	mappings := datacenterSpec(clusterSpec.DockerDatacenter).PortMappings
+	if len(mappings) == 0 {
+		return p.deleteIngress(ctx, clusterSpec.Cluster.Name)
+	}
+
+	machines, err := p.providerKubectlClient.GetMachines(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return fmt.Errorf("getting machines for ingress: %v", err)
+	}
+
+	config, err := buildIngressConfig(clusterSpec.Cluster.Name, mappings, machines)
+	if err != nil {
+		return err
+	}
+
+	name := ingressContainerName(clusterSpec.Cluster.Name)
+	image := clusterSpec.VersionsBundle.Haproxy.Image.VersionedImage()
+	upToDate, err := p.ingressUpToDate(ctx, name, image, mappings)
+	if err != nil {
+		return err
+	}
+
+	if !upToDate {
+		if err = p.deleteIngress(ctx, clusterSpec.Cluster.Name); err != nil {
+			return err
+		}
+
+		flags := []string{"--network", dockerNetwork, "--restart", "unless-stopped"}
+		for _, m := range mappings {
+			flags = append(flags, "-p", fmt.Sprintf("%s:%d:%d", m.Address(), m.HostPort, m.HostPort))
+		}
+
+		logger.V(3).Info("Creating ingress container", "name", name)
+		if err = p.docker.Run(ctx, image, name, nil, flags...); err != nil {
+			return fmt.Errorf("creating ingress container: %v", err)
+		}
+	}
+
+	logger.V(3).Info("Configuring ingress container", "name", name)
+	if err = p.docker.WriteFileToContainer(ctx, name, ingressConfigPath, config); err != nil {
+		return fmt.Errorf("configuring ingress container: %v", err)
+	}
+	if err = p.docker.KillContainer(ctx, name, "SIGHUP"); err != nil {
+		return fmt.Errorf("reloading ingress container configuration: %v", err)
+	}
+
+	return nil
+}
+
+// ingressUpToDate returns true if the ingress container exists and was created with the image and
+// published ports the cluster spec requires.
+func (p *provider) ingressUpToDate(ctx context.Context, name, image string, mappings []v1alpha1.DockerPortMapping) (bool, error) {
+	exists, err := p.docker.CheckContainerExistence(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+
+	currentImage, err := p.docker.GetContainerImage(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if currentImage != image {
+		return false, nil
+	}
+
+	bindings, err := p.docker.GetContainerPortBindings(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(bindings, ingressPortBindings(mappings)), nil
+}
+
+// ingressPortBindings returns the port bindings of the ingress container for mappings with the format
+// used by docker inspect, sorted.
+func ingressPortBindings(mappings []v1alpha1.DockerPortMapping) []string {
+	bindings := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		bindings = append(bindings, fmt.Sprintf("%s:%d:%d/tcp", m.Address(), m.HostPort, m.HostPort))
+	}
+	sort.Strings(bindings)
+	return bindings
+}
+
+func (p *provider) deleteIngress(ctx context.Context, clusterName string) error {
+	name := ingressContainerName(clusterName)
+	exists, err := p.docker.CheckContainerExistence(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	logger.V(3).Info("Deleting ingress container", "name", name)
+	return p.docker.ForceRemove(ctx, name)
+}
+
+func buildIngressConfig(clusterName string, mappings []v1alpha1.DockerPortMapping, machines []types.Machine) ([]byte, error) {
+	backends := make([]ingressBackend, 0, len(mappings))
+	for _, m := range mappings {
+		mdName := machineDeploymentName(clusterName, m.WorkerNodeGroup)
+		var nodes []string
+		for _, machine := range machines {
+			// CAPD names the node containers after the machines
+			if machine.Metadata.Labels[clusterv1.MachineDeploymentLabelName] == mdName {
+				nodes = append(nodes, machine.Metadata.Name)
+			}
+		}
+		sort.Strings(nodes)
+		backends = append(backends, ingressBackend{HostPort: m.HostPort, NodePort: m.NodePort, Nodes: nodes})
+	}
+
+	config, err := templater.Execute(ingressConfigTemplate, map[string]interface{}{"backends": backends})
+	if err != nil {
+		return nil, fmt.Errorf("generating ingress configuration: %v", err)
+	}
+	return config, nil
+}
+
+func validatePortMappings(clusterSpec *cluster.Spec) error {
+	nodeGroups := cluster.BuildMapForWorkerNodeGroupsByName(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations)
+	for _, m := range datacenterSpec(clusterSpec.DockerDatacenter).PortMappings {
+		if _, ok := nodeGroups[m.WorkerNodeGroup]; !ok {
+			return fmt.Errorf("port mapping for host port %d references worker node group %s, which doesn't exist", m.HostPort, m.WorkerNodeGroup)
+		}
+	}
+	return nil
+}
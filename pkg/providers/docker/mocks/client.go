@@ -40,6 +40,65 @@ func (m *MockProviderClient) EXPECT() *MockProviderClientMockRecorder {
 	return m.recorder
 }
 
+// CheckContainerExistence mocks base method.
+func (m *MockProviderClient) CheckContainerExistence(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckContainerExistence", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckContainerExistence indicates an expected call of CheckContainerExistence.
+func (mr *MockProviderClientMockRecorder) CheckContainerExistence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckContainerExistence", reflect.TypeOf((*MockProviderClient)(nil).CheckContainerExistence), arg0, arg1)
+}
+
+// ForceRemove mocks base method.
+func (m *MockProviderClient) ForceRemove(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceRemove", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceRemove indicates an expected call of ForceRemove.
+func (mr *MockProviderClientMockRecorder) ForceRemove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceRemove", reflect.TypeOf((*MockProviderClient)(nil).ForceRemove), arg0, arg1)
+}
+
+// GetContainerImage mocks base method.
+func (m *MockProviderClient) GetContainerImage(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContainerImage", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContainerImage indicates an expected call of GetContainerImage.
+func (mr *MockProviderClientMockRecorder) GetContainerImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainerImage", reflect.TypeOf((*MockProviderClient)(nil).GetContainerImage), arg0, arg1)
+}
+
+// GetContainerPortBindings mocks base method.
+func (m *MockProviderClient) GetContainerPortBindings(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContainerPortBindings", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContainerPortBindings indicates an expected call of GetContainerPortBindings.
+func (mr *MockProviderClientMockRecorder) GetContainerPortBindings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainerPortBindings", reflect.TypeOf((*MockProviderClient)(nil).GetContainerPortBindings), arg0, arg1)
+}
+
 // GetDockerLBPort mocks base method.
 func (m *MockProviderClient) GetDockerLBPort(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +114,53 @@ func (mr *MockProviderClientMockRecorder) GetDockerLBPort(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDockerLBPort", reflect.TypeOf((*MockProviderClient)(nil).GetDockerLBPort), arg0, arg1)
 }
 
+// KillContainer mocks base method.
+func (m *MockProviderClient) KillContainer(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillContainer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KillContainer indicates an expected call of KillContainer.
+func (mr *MockProviderClientMockRecorder) KillContainer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillContainer", reflect.TypeOf((*MockProviderClient)(nil).KillContainer), arg0, arg1, arg2)
+}
+
+// Run mocks base method.
+func (m *MockProviderClient) Run(arg0 context.Context, arg1, arg2 string, arg3 []string, arg4 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Run", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockProviderClientMockRecorder) Run(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockProviderClient)(nil).Run), varargs...)
+}
+
+// WriteFileToContainer mocks base method.
+func (m *MockProviderClient) WriteFileToContainer(arg0 context.Context, arg1, arg2 string, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteFileToContainer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteFileToContainer indicates an expected call of WriteFileToContainer.
+func (mr *MockProviderClientMockRecorder) WriteFileToContainer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFileToContainer", reflect.TypeOf((*MockProviderClient)(nil).WriteFileToContainer), arg0, arg1, arg2, arg3)
+}
+
 // MockProviderKubectlClient is a mock of ProviderKubectlClient interface.
 type MockProviderKubectlClient struct {
 	ctrl     *gomock.Controller
@@ -93,6 +199,21 @@ func (mr *MockProviderKubectlClientMockRecorder) GetEksaCluster(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaCluster", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetEksaCluster), arg0, arg1, arg2)
 }
 
+// GetEksaDockerDatacenterConfig mocks base method.
+func (m *MockProviderKubectlClient) GetEksaDockerDatacenterConfig(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha1.DockerDatacenterConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEksaDockerDatacenterConfig", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha1.DockerDatacenterConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEksaDockerDatacenterConfig indicates an expected call of GetEksaDockerDatacenterConfig.
+func (mr *MockProviderKubectlClientMockRecorder) GetEksaDockerDatacenterConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaDockerDatacenterConfig", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetEksaDockerDatacenterConfig), arg0, arg1, arg2, arg3)
+}
+
 // GetEtcdadmCluster mocks base method.
 func (m *MockProviderKubectlClient) GetEtcdadmCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string, arg3 ...executables.KubectlOpt) (*v1beta1.EtcdadmCluster, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +274,21 @@ func (mr *MockProviderKubectlClientMockRecorder) GetMachineDeployment(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineDeployment", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachineDeployment), varargs...)
 }
 
+// GetMachines mocks base method.
+func (m *MockProviderKubectlClient) GetMachines(arg0 context.Context, arg1 *types.Cluster, arg2 string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockProviderKubectlClientMockRecorder) GetMachines(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachines), arg0, arg1, arg2)
+}
+
 // UpdateAnnotation mocks base method.
 func (m *MockProviderKubectlClient) UpdateAnnotation(arg0 context.Context, arg1, arg2 string, arg3 map[string]string, arg4 ...executables.KubectlOpt) error {
 	m.ctrl.T.Helper()
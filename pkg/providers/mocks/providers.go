@@ -68,6 +68,20 @@ func (mr *MockProviderMockRecorder) ChangeDiff(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeDiff", reflect.TypeOf((*MockProvider)(nil).ChangeDiff), arg0, arg1)
 }
 
+// CreateClusterChecks mocks base method.
+func (m *MockProvider) CreateClusterChecks(arg0 context.Context, arg1 *cluster.Spec) []providers.Check {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClusterChecks", arg0, arg1)
+	ret0, _ := ret[0].([]providers.Check)
+	return ret0
+}
+
+// CreateClusterChecks indicates an expected call of CreateClusterChecks.
+func (mr *MockProviderMockRecorder) CreateClusterChecks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterChecks", reflect.TypeOf((*MockProvider)(nil).CreateClusterChecks), arg0, arg1)
+}
+
 // DatacenterConfig mocks base method.
 func (m *MockProvider) DatacenterConfig(arg0 *cluster.Spec) providers.DatacenterConfig {
 	m.ctrl.T.Helper()
@@ -297,6 +311,20 @@ func (mr *MockProviderMockRecorder) PostBootstrapSetupUpgrade(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostBootstrapSetupUpgrade", reflect.TypeOf((*MockProvider)(nil).PostBootstrapSetupUpgrade), arg0, arg1, arg2)
 }
 
+// PostClusterDelete mocks base method.
+func (m *MockProvider) PostClusterDelete(arg0 context.Context, arg1 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostClusterDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostClusterDelete indicates an expected call of PostClusterDelete.
+func (mr *MockProviderMockRecorder) PostClusterDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostClusterDelete", reflect.TypeOf((*MockProvider)(nil).PostClusterDelete), arg0, arg1)
+}
+
 // PostClusterDeleteValidate mocks base method.
 func (m *MockProvider) PostClusterDeleteValidate(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -311,6 +339,20 @@ func (mr *MockProviderMockRecorder) PostClusterDeleteValidate(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostClusterDeleteValidate", reflect.TypeOf((*MockProvider)(nil).PostClusterDeleteValidate), arg0, arg1)
 }
 
+// PostControlPlaneReady mocks base method.
+func (m *MockProvider) PostControlPlaneReady(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostControlPlaneReady", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostControlPlaneReady indicates an expected call of PostControlPlaneReady.
+func (mr *MockProviderMockRecorder) PostControlPlaneReady(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostControlPlaneReady", reflect.TypeOf((*MockProvider)(nil).PostControlPlaneReady), arg0, arg1, arg2)
+}
+
 // PostMoveManagementToBootstrap mocks base method.
 func (m *MockProvider) PostMoveManagementToBootstrap(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -325,6 +367,20 @@ func (mr *MockProviderMockRecorder) PostMoveManagementToBootstrap(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMoveManagementToBootstrap", reflect.TypeOf((*MockProvider)(nil).PostMoveManagementToBootstrap), arg0, arg1)
 }
 
+// PostWorkerNodesReady mocks base method.
+func (m *MockProvider) PostWorkerNodesReady(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostWorkerNodesReady", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostWorkerNodesReady indicates an expected call of PostWorkerNodesReady.
+func (mr *MockProviderMockRecorder) PostWorkerNodesReady(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostWorkerNodesReady", reflect.TypeOf((*MockProvider)(nil).PostWorkerNodesReady), arg0, arg1, arg2)
+}
+
 // PostWorkloadInit mocks base method.
 func (m *MockProvider) PostWorkloadInit(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
 	m.ctrl.T.Helper()
@@ -353,6 +409,20 @@ func (mr *MockProviderMockRecorder) PreCAPIInstallOnBootstrap(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreCAPIInstallOnBootstrap", reflect.TypeOf((*MockProvider)(nil).PreCAPIInstallOnBootstrap), arg0, arg1, arg2)
 }
 
+// PreMachinesRemoval mocks base method.
+func (m *MockProvider) PreMachinesRemoval(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreMachinesRemoval", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreMachinesRemoval indicates an expected call of PreMachinesRemoval.
+func (mr *MockProviderMockRecorder) PreMachinesRemoval(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreMachinesRemoval", reflect.TypeOf((*MockProvider)(nil).PreMachinesRemoval), arg0, arg1, arg2)
+}
+
 // RunPostControlPlaneUpgrade mocks base method.
 func (m *MockProvider) RunPostControlPlaneUpgrade(arg0 context.Context, arg1, arg2 *cluster.Spec, arg3, arg4 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -437,6 +507,20 @@ func (mr *MockProviderMockRecorder) UpdateSecrets(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecrets", reflect.TypeOf((*MockProvider)(nil).UpdateSecrets), arg0, arg1, arg2)
 }
 
+// UpgradeClusterChecks mocks base method.
+func (m *MockProvider) UpgradeClusterChecks(arg0 context.Context, arg1 *cluster.Spec) []providers.Check {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeClusterChecks", arg0, arg1)
+	ret0, _ := ret[0].([]providers.Check)
+	return ret0
+}
+
+// UpgradeClusterChecks indicates an expected call of UpgradeClusterChecks.
+func (mr *MockProviderMockRecorder) UpgradeClusterChecks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeClusterChecks", reflect.TypeOf((*MockProvider)(nil).UpgradeClusterChecks), arg0, arg1)
+}
+
 // UpgradeNeeded mocks base method.
 func (m *MockProvider) UpgradeNeeded(arg0 context.Context, arg1, arg2 *cluster.Spec, arg3 *types.Cluster) (bool, error) {
 	m.ctrl.T.Helper()
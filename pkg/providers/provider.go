@@ -9,11 +9,32 @@ import (
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+// IDs of the provider checks.
+const (
+	ControlPlaneIPCheckID = "control-plane-ip-in-use"
+	TinkerbellIPCheckID   = "tinkerbell-ip-in-use"
+	TemplateTagsCheckID   = "template-tags"
+	BMCReachableCheckID   = "bmc-reachable"
+)
+
+// Check is a provider validation that can be skipped or downgraded to a warning on its own,
+// identified by its ID.
+type Check struct {
+	ID          string
+	Name        string
+	Remediation string
+	Validate    func() error
+}
+
 type Provider interface {
 	Name() string
 	SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error
+	// CreateClusterChecks returns the checks to run once SetupAndValidateCreateCluster succeeds.
+	CreateClusterChecks(ctx context.Context, clusterSpec *cluster.Spec) []Check
 	SetupAndValidateDeleteCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 	SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, currentSpec *cluster.Spec) error
+	// UpgradeClusterChecks returns the checks to run once SetupAndValidateUpgradeCluster succeeds.
+	UpgradeClusterChecks(ctx context.Context, clusterSpec *cluster.Spec) []Check
 	UpdateSecrets(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 	GenerateCAPISpecForCreate(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error)
 	GenerateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currrentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error)
@@ -25,6 +46,13 @@ type Provider interface {
 	PostBootstrapSetupUpgrade(ctx context.Context, clusterConfig *v1alpha1.Cluster, cluster *types.Cluster) error
 	// PostWorkloadInit is called after the workload cluster is created and initialized with a CNI. This allows us to do provider specific configuration on the workload cluster.
 	PostWorkloadInit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	// PreMachinesRemoval is called once an upgrade or a delete passed its validations and proceeds,
+	// before any machine of the cluster is removed.
+	PreMachinesRemoval(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error
+	// PostControlPlaneReady is called once the control plane and etcd machines of a cluster are ready after a create or upgrade.
+	PostControlPlaneReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error
+	// PostWorkerNodesReady is called once the worker node machines of a cluster are created after a create or ready after an upgrade.
+	PostWorkerNodesReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error
 	BootstrapClusterOpts(clusterSpec *cluster.Spec) ([]bootstrapper.BootstrapClusterOption, error)
 	UpdateKubeConfig(content *[]byte, clusterName string) error
 	Version(clusterSpec *cluster.Spec) string
@@ -41,6 +69,9 @@ type Provider interface {
 	UpgradeNeeded(ctx context.Context, newSpec, currentSpec *cluster.Spec, cluster *types.Cluster) (bool, error)
 	DeleteResources(ctx context.Context, clusterSpec *cluster.Spec) error
 	InstallCustomProviderComponents(ctx context.Context, kubeconfigFile string) error
+	// PostClusterDelete is called after the CAPI cluster is deleted, to clean up the provider resources
+	// that are not managed by CAPI.
+	PostClusterDelete(ctx context.Context, cluster *types.Cluster) error
 	PostClusterDeleteValidate(ctx context.Context, managementCluster *types.Cluster) error
 	// PostMoveManagementToBootstrap is called after the CAPI management is moved back to the bootstrap cluster.
 	PostMoveManagementToBootstrap(ctx context.Context, bootstrapCluster *types.Cluster) error
@@ -12,6 +12,7 @@ type AwsClient interface {
 	EC2ImportKeyPair(ctx context.Context, keyName string, keyMaterial []byte) error
 	IsSnowballDeviceUnlocked(ctx context.Context) (bool, error)
 	SnowballDeviceSoftwareVersion(ctx context.Context) (string, error)
+	SnowballDeviceCapacities(ctx context.Context) (map[string]int64, error)
 }
 
 type AwsClientMap map[string]AwsClient
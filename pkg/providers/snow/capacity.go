@@ -0,0 +1,315 @@
+package snow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	vCPUCapacityName      = "vCPU"
+	memoryCapacityName    = "Memory"
+	ipAddressCapacityName = "IP Address"
+
+	gib = int64(1024 * 1024 * 1024)
+)
+
+// capacityNames are the device capacities checked for the machines, in the order they are checked.
+var capacityNames = []string{vCPUCapacityName, memoryCapacityName, ipAddressCapacityName}
+
+// machineResources is the amount of device resources used by one or more machines.
+type machineResources struct {
+	vCPU        int64
+	memory      int64
+	ipAddresses int64
+}
+
+var instanceTypeResources = map[v1alpha1.SnowInstanceType]machineResources{
+	v1alpha1.SbeCLarge:   {vCPU: 2, memory: 8 * gib, ipAddresses: 1},
+	v1alpha1.SbeCXLarge:  {vCPU: 4, memory: 16 * gib, ipAddresses: 1},
+	v1alpha1.SbeC2XLarge: {vCPU: 8, memory: 32 * gib, ipAddresses: 1},
+	v1alpha1.SbeC4XLarge: {vCPU: 16, memory: 64 * gib, ipAddresses: 1},
+}
+
+// sub returns the resources left after subtracting o, with no resource going below zero.
+func (r machineResources) sub(o machineResources) machineResources {
+	return machineResources{
+		vCPU:        nonNegative(r.vCPU - o.vCPU),
+		memory:      nonNegative(r.memory - o.memory),
+		ipAddresses: nonNegative(r.ipAddresses - o.ipAddresses),
+	}
+}
+
+func (r machineResources) isZero() bool {
+	return r == machineResources{}
+}
+
+// fits returns true if the resources fit in the available ones.
+func (r machineResources) fits(available machineResources) bool {
+	return r.vCPU <= available.vCPU && r.memory <= available.memory && r.ipAddresses <= available.ipAddresses
+}
+
+// lessThan orders resources by vCPU, then memory, then IP addresses.
+func (r machineResources) lessThan(o machineResources) bool {
+	if r.vCPU != o.vCPU {
+		return r.vCPU < o.vCPU
+	}
+	if r.memory != o.memory {
+		return r.memory < o.memory
+	}
+	return r.ipAddresses < o.ipAddresses
+}
+
+func (r machineResources) byCapacityName() map[string]int64 {
+	return map[string]int64{
+		vCPUCapacityName:      r.vCPU,
+		memoryCapacityName:    r.memory,
+		ipAddressCapacityName: r.ipAddresses,
+	}
+}
+
+func nonNegative(i int64) int64 {
+	if i < 0 {
+		return 0
+	}
+	return i
+}
+
+// availableResources returns the resources available on a device from the capacities it reports.
+// Capacity names are matched case insensitively and a capacity the device doesn't report is an error.
+func availableResources(capacities map[string]int64) (machineResources, error) {
+	byName := make(map[string]int64, len(capacities))
+	reported := make([]string, 0, len(capacities))
+	for name, amount := range capacities {
+		byName[strings.ToLower(name)] = amount
+		reported = append(reported, name)
+	}
+	sort.Strings(reported)
+
+	amounts := make(map[string]int64, len(capacityNames))
+	for _, name := range capacityNames {
+		amount, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return machineResources{}, fmt.Errorf("%s capacity not reported by the device, reported capacities: [%s]", name, strings.Join(reported, ", "))
+		}
+		amounts[name] = amount
+	}
+
+	return machineResources{
+		vCPU:        amounts[vCPUCapacityName],
+		memory:      amounts[memoryCapacityName],
+		ipAddresses: amounts[ipAddressCapacityName],
+	}, nil
+}
+
+// machineGroup is a set of machines of the cluster sharing the same machine config,
+// like the control plane or a worker node group.
+type machineGroup struct {
+	machineConfigName string
+	count             int
+	// workerNodeGroup is the name of the worker node group, empty for the control plane and etcd machines.
+	workerNodeGroup string
+}
+
+func machineGroups(c *v1alpha1.Cluster) map[string]machineGroup {
+	groups := map[string]machineGroup{}
+	if ref := c.Spec.ControlPlaneConfiguration.MachineGroupRef; ref != nil {
+		groups["control plane"] = machineGroup{machineConfigName: ref.Name, count: c.Spec.ControlPlaneConfiguration.Count}
+	}
+
+	if etcd := c.Spec.ExternalEtcdConfiguration; etcd != nil && etcd.MachineGroupRef != nil {
+		groups["etcd"] = machineGroup{machineConfigName: etcd.MachineGroupRef.Name, count: etcd.Count}
+	}
+
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef == nil {
+			continue
+		}
+		groups["worker node group "+w.Name] = machineGroup{machineConfigName: w.MachineGroupRef.Name, count: w.Count, workerNodeGroup: w.Name}
+	}
+
+	return groups
+}
+
+// groupMachine returns the machine config of the group, the devices its machines are placed on and
+// the resources used by each of them.
+func groupMachine(config *cluster.Config, group machineGroup) (*v1alpha1.SnowMachineConfig, []string, machineResources, error) {
+	m, ok := config.SnowMachineConfigs[group.machineConfigName]
+	if !ok {
+		return nil, nil, machineResources{}, fmt.Errorf("SnowMachineConfig [%s] not found", group.machineConfigName)
+	}
+
+	perMachine, ok := instanceTypeResources[m.Spec.InstanceType]
+	if !ok {
+		return nil, nil, machineResources{}, fmt.Errorf("unknown resources for instance type [%s] of SnowMachineConfig [%s]", m.Spec.InstanceType, m.Name)
+	}
+
+	devices := m.Spec.Devices
+	if group.workerNodeGroup != "" {
+		devices = workerNodeGroupDevices(config.Cluster, m, group.workerNodeGroup)
+	}
+
+	return m, devices, perMachine, nil
+}
+
+// machineRequirement is the resources needed by one new machine of a machine group. CAPAS places
+// each machine on a single device, one of the devices of its group.
+type machineRequirement struct {
+	group     string
+	devices   []string
+	resources machineResources
+}
+
+// deviceRequirements returns the machines of the new config that need resources on the devices on top
+// of what is already used on them. Without a current config every machine is new. Otherwise, when a group
+// stays on the same devices, only the machines added to the group are new, plus one rolling update machine
+// and, for each other machine replaced, the resources it uses on top of the machine it replaces, in every
+// group whose machines get replaced.
+func deviceRequirements(newConfig, currentConfig *cluster.Config) ([]machineRequirement, error) {
+	var currentGroups map[string]machineGroup
+	if currentConfig != nil {
+		currentGroups = machineGroups(currentConfig.Cluster)
+	}
+
+	newGroups := machineGroups(newConfig.Cluster)
+	names := make([]string, 0, len(newGroups))
+	for name := range newGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var requirements []machineRequirement
+	for _, name := range names {
+		group := newGroups[name]
+		newMachineConfig, devices, perMachine, err := groupMachine(newConfig, group)
+		if err != nil {
+			return nil, fmt.Errorf("computing resources for %s: %v", name, err)
+		}
+
+		newMachines := group.count
+		replacedMachines := 0
+		if currentGroup, ok := currentGroups[name]; ok {
+			currentMachineConfig, currentDevices, currentPerMachine, err := groupMachine(currentConfig, currentGroup)
+			if err != nil {
+				return nil, fmt.Errorf("computing current resources for %s: %v", name, err)
+			}
+
+			// The resources of the current machines are only freed for the new ones on the same devices.
+			if sameDevices(devices, currentDevices) {
+				newMachines = nonNegativeInt(group.count - currentGroup.count)
+				if machinesReplaced(newConfig, currentConfig, newMachineConfig, currentMachineConfig) {
+					newMachines++
+					replacedMachines = nonNegativeInt(minInt(group.count, currentGroup.count) - 1)
+				}
+
+				delta := perMachine.sub(currentPerMachine)
+				for i := 0; i < replacedMachines && !delta.isZero(); i++ {
+					requirements = append(requirements, machineRequirement{group: name, devices: devices, resources: delta})
+				}
+			}
+		}
+
+		for i := 0; i < newMachines; i++ {
+			requirements = append(requirements, machineRequirement{group: name, devices: devices, resources: perMachine})
+		}
+	}
+
+	return requirements, nil
+}
+
+func machinesReplaced(newConfig, currentConfig *cluster.Config, newMachineConfig, currentMachineConfig *v1alpha1.SnowMachineConfig) bool {
+	return newConfig.Cluster.Spec.KubernetesVersion != currentConfig.Cluster.Spec.KubernetesVersion ||
+		newMachineConfig.Name != currentMachineConfig.Name ||
+		!equality.Semantic.DeepDerivative(newMachineConfig.Spec, currentMachineConfig.Spec)
+}
+
+func sameDevices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	devices := make(map[string]bool, len(a))
+	for _, device := range a {
+		devices[device] = true
+	}
+	for _, device := range b {
+		if !devices[device] {
+			return false
+		}
+	}
+	return true
+}
+
+func nonNegativeInt(i int) int {
+	if i < 0 {
+		return 0
+	}
+	return i
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// packMachines checks every machine fits on one of its devices, with the resources left on each device
+// once the machines placed before are subtracted. The machines with the fewest devices to choose from
+// are placed first, then the largest ones, each on the device with the most resources left.
+func packMachines(machines []machineRequirement, available map[string]machineResources) error {
+	sorted := make([]machineRequirement, len(machines))
+	copy(sorted, machines)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if len(sorted[i].devices) != len(sorted[j].devices) {
+			return len(sorted[i].devices) < len(sorted[j].devices)
+		}
+		return sorted[j].resources.lessThan(sorted[i].resources)
+	})
+
+	left := make(map[string]machineResources, len(available))
+	for device, resources := range available {
+		left[device] = resources
+	}
+
+	for _, machine := range sorted {
+		device := ""
+		for _, candidate := range machine.devices {
+			if !machine.resources.fits(left[candidate]) {
+				continue
+			}
+			if device == "" || left[device].lessThan(left[candidate]) {
+				device = candidate
+			}
+		}
+
+		if device == "" {
+			return fmt.Errorf("validating capacity for %s: %v", machine.group, noDeviceFitsError(machine, left))
+		}
+
+		left[device] = left[device].sub(machine.resources)
+	}
+
+	return nil
+}
+
+func noDeviceFitsError(machine machineRequirement, left map[string]machineResources) error {
+	required := machine.resources.byCapacityName()
+	shortages := make([]string, 0, len(machine.devices))
+	for _, device := range machine.devices {
+		leftAmounts := left[device].byCapacityName()
+		for _, name := range capacityNames {
+			if required[name] > leftAmounts[name] {
+				shortages = append(shortages, fmt.Sprintf("device [%s] has %d %s left", device, leftAmounts[name], name))
+				break
+			}
+		}
+	}
+
+	return fmt.Errorf("no device in [%s] has enough capacity for a machine requiring %d vCPU, %d Memory and %d IP Address: %s",
+		strings.Join(machine.devices, ", "), required[vCPUCapacityName], required[memoryCapacityName], required[ipAddressCapacityName], strings.Join(shortages, ", "))
+}
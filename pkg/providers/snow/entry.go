@@ -35,6 +35,12 @@ func (cm *ConfigManager) SetDefaultsAndValidate(ctx context.Context, config *clu
 	return nil
 }
 
+// ValidateDevicesCapacity validates the devices have enough capacity to run the machines of the new config.
+// During upgrades, the current config is used to only account for the machines added to the devices.
+func (cm *ConfigManager) ValidateDevicesCapacity(ctx context.Context, newConfig, currentConfig *cluster.Config) error {
+	return cm.validator.ValidateDevicesCapacity(ctx, newConfig, currentConfig)
+}
+
 func (cm *ConfigManager) snowEntry(ctx context.Context) *cluster.ConfigManagerEntry {
 	return &cluster.ConfigManagerEntry{
 		Defaulters: []cluster.Defaulter{
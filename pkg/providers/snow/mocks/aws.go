@@ -93,6 +93,21 @@ func (mr *MockAwsClientMockRecorder) IsSnowballDeviceUnlocked(ctx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSnowballDeviceUnlocked", reflect.TypeOf((*MockAwsClient)(nil).IsSnowballDeviceUnlocked), ctx)
 }
 
+// SnowballDeviceCapacities mocks base method.
+func (m *MockAwsClient) SnowballDeviceCapacities(ctx context.Context) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnowballDeviceCapacities", ctx)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnowballDeviceCapacities indicates an expected call of SnowballDeviceCapacities.
+func (mr *MockAwsClientMockRecorder) SnowballDeviceCapacities(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnowballDeviceCapacities", reflect.TypeOf((*MockAwsClient)(nil).SnowballDeviceCapacities), ctx)
+}
+
 // SnowballDeviceSoftwareVersion mocks base method.
 func (m *MockAwsClient) SnowballDeviceSoftwareVersion(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -82,7 +82,9 @@ func WorkersMachineAndConfigTemplate(ctx context.Context, kubeClient kubernetes.
 		}
 
 		// build worker machineTemplate with new clusterSpec
-		newMachineTemplate := SnowMachineTemplate(clusterapi.WorkerMachineTemplateName(clusterSpec, workerNodeGroupConfig), clusterSpec.SnowMachineConfigs[workerNodeGroupConfig.MachineGroupRef.Name])
+		machineConfig := clusterSpec.SnowMachineConfigs[workerNodeGroupConfig.MachineGroupRef.Name]
+		newMachineTemplate := SnowMachineTemplate(clusterapi.WorkerMachineTemplateName(clusterSpec, workerNodeGroupConfig), machineConfig)
+		newMachineTemplate.Spec.Template.Spec.Devices = workerNodeGroupDevices(clusterSpec.Cluster, machineConfig, workerNodeGroupConfig.Name)
 
 		// build worker kubeadmConfigTemplate with new clusterSpec
 		newConfigTemplate, err := KubeadmConfigTemplate(clusterSpec, workerNodeGroupConfig)
@@ -14,6 +14,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
@@ -681,6 +682,30 @@ func TestKubeadmConfigTemplatesWithProxyConfig(t *testing.T) {
 	}
 }
 
+func TestWorkersMachineAndConfigTemplatePinnedPlacement(t *testing.T) {
+	g := newSnowTest(t)
+	for _, name := range []string{"snow-test-md-0", "snow-test-md-1"} {
+		g.kubeconfigClient.EXPECT().
+			Get(
+				g.ctx,
+				name,
+				constants.EksaSystemNamespace,
+				&clusterv1.MachineDeployment{},
+			).
+			Return(apierrors.NewNotFound(schema.GroupResource{Group: "", Resource: ""}, ""))
+	}
+
+	md1 := *g.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].DeepCopy()
+	md1.Name = "md-1"
+	g.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = append(g.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations, md1)
+	g.clusterSpec.SnowMachineConfigs["test-wn"].Spec.PlacementStrategy = v1alpha1.PinnedPlacement
+
+	got, _, err := snow.WorkersMachineAndConfigTemplate(g.ctx, g.kubeconfigClient, g.clusterSpec)
+	g.Expect(err).To(Succeed())
+	g.Expect(got["md-0"].Spec.Template.Spec.Devices).To(Equal([]string{"1.2.3.4"}))
+	g.Expect(got["md-1"].Spec.Template.Spec.Devices).To(Equal([]string{"1.2.3.5"}))
+}
+
 func TestNewMachineTemplateName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,28 @@
+package snow
+
+import (
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// workerNodeGroupDevices returns the devices the machines of a worker node group are placed on.
+// With the "pinned" strategy, the machines of each worker node group using the machine config are
+// placed on a single device: the n-th worker node group using it, in the order of the cluster spec,
+// on the n-th device of the machine config, wrapping around. Otherwise they are spread across all
+// the devices of the machine config.
+func workerNodeGroupDevices(c *v1alpha1.Cluster, m *v1alpha1.SnowMachineConfig, workerNodeGroupName string) []string {
+	if m.Spec.PlacementStrategy != v1alpha1.PinnedPlacement || len(m.Spec.Devices) == 0 {
+		return m.Spec.Devices
+	}
+
+	index := 0
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		if w.Name == workerNodeGroupName {
+			break
+		}
+		if w.MachineGroupRef != nil && w.MachineGroupRef.Name == m.Name {
+			index++
+		}
+	}
+
+	return []string{m.Spec.Devices[index%len(m.Spec.Devices)]}
+}
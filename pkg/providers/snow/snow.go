@@ -13,7 +13,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providerValidator "github.com/aws/eks-anywhere/pkg/providers/validator"
@@ -41,7 +40,6 @@ type SnowProvider struct {
 	kubeUnAuthClient KubeUnAuthClient
 	retrier          *retrier.Retrier
 	configManager    *ConfigManager
-	skipIpCheck      bool
 }
 
 type KubeUnAuthClient interface {
@@ -50,13 +48,12 @@ type KubeUnAuthClient interface {
 	Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error
 }
 
-func NewProvider(kubeUnAuthClient KubeUnAuthClient, configManager *ConfigManager, skipIpCheck bool) *SnowProvider {
+func NewProvider(kubeUnAuthClient KubeUnAuthClient, configManager *ConfigManager) *SnowProvider {
 	retrier := retrier.NewWithMaxRetries(maxRetries, backOffPeriod)
 	return &SnowProvider{
 		kubeUnAuthClient: kubeUnAuthClient,
 		retrier:          retrier,
 		configManager:    configManager,
-		skipIpCheck:      skipIpCheck,
 	}
 }
 
@@ -68,20 +65,37 @@ func (p *SnowProvider) SetupAndValidateCreateCluster(ctx context.Context, cluste
 	if err := p.configManager.SetDefaultsAndValidate(ctx, clusterSpec.Config); err != nil {
 		return fmt.Errorf("setting defaults and validate snow config: %v", err)
 	}
-	if !p.skipIpCheck {
-		if err := providerValidator.ValidateControlPlaneIpUniqueness(clusterSpec.Cluster, &networkutils.DefaultNetClient{}); err != nil {
-			return err
-		}
-	} else {
-		logger.Info("Skipping check for whether control plane ip is in use")
+	if err := p.configManager.ValidateDevicesCapacity(ctx, clusterSpec.Config, nil); err != nil {
+		return fmt.Errorf("validating snow devices capacity: %v", err)
 	}
 	return nil
 }
 
-func (p *SnowProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, _ *cluster.Spec) error {
+func (p *SnowProvider) CreateClusterChecks(_ context.Context, clusterSpec *cluster.Spec) []providers.Check {
+	return []providers.Check{
+		{
+			ID:          providers.ControlPlaneIPCheckID,
+			Name:        "validate control plane ip is not in use",
+			Remediation: "provide an unused IP for controlPlaneConfiguration.endpoint.host",
+			Validate: func() error {
+				return providerValidator.ValidateControlPlaneIpUniqueness(clusterSpec.Cluster, &networkutils.DefaultNetClient{})
+			},
+		},
+	}
+}
+
+func (p *SnowProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, currentSpec *cluster.Spec) error {
 	if err := p.configManager.SetDefaultsAndValidate(ctx, clusterSpec.Config); err != nil {
 		return fmt.Errorf("setting defaults and validate snow config: %v", err)
 	}
+	if err := p.configManager.ValidateDevicesCapacity(ctx, clusterSpec.Config, currentSpec.Config); err != nil {
+		return fmt.Errorf("validating snow devices capacity: %v", err)
+	}
+	return nil
+}
+
+func (p *SnowProvider) UpgradeClusterChecks(_ context.Context, _ *cluster.Spec) []providers.Check {
+	// No checks
 	return nil
 }
 
@@ -169,6 +183,18 @@ func (p *SnowProvider) PostWorkloadInit(ctx context.Context, cluster *types.Clus
 	return nil
 }
 
+func (p *SnowProvider) PreMachinesRemoval(_ context.Context, _ *cluster.Spec, _ *types.Cluster) error {
+	return nil
+}
+
+func (p *SnowProvider) PostControlPlaneReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	return nil
+}
+
+func (p *SnowProvider) PostWorkerNodesReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	return nil
+}
+
 func (p *SnowProvider) BootstrapClusterOpts(_ *cluster.Spec) ([]bootstrapper.BootstrapClusterOption, error) {
 	return nil, nil
 }
@@ -284,6 +310,11 @@ func (p *SnowProvider) DeleteResources(ctx context.Context, clusterSpec *cluster
 	return p.kubeUnAuthClient.Delete(ctx, clusterSpec.SnowDatacenter.GetName(), clusterSpec.SnowDatacenter.GetNamespace(), clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.SnowDatacenter)
 }
 
+func (p *SnowProvider) PostClusterDelete(_ context.Context, _ *types.Cluster) error {
+	// NOOP
+	return nil
+}
+
 func (p *SnowProvider) PostClusterDeleteValidate(_ context.Context, _ *types.Cluster) error {
 	// No validations
 	return nil
@@ -250,7 +250,6 @@ func newProvider(ctx context.Context, t *testing.T, kubeUnAuthClient snow.KubeUn
 	return snow.NewProvider(
 		kubeUnAuthClient,
 		configManager,
-		false,
 	)
 }
 
@@ -295,6 +294,7 @@ func TestSetupAndValidateCreateClusterSuccess(t *testing.T) {
 	tt.aws.EXPECT().EC2KeyNameExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
 	tt.aws.EXPECT().IsSnowballDeviceUnlocked(tt.ctx).Return(true, nil).Times(4)
 	tt.aws.EXPECT().SnowballDeviceSoftwareVersion(tt.ctx).Return("102", nil).Times(4)
+	tt.aws.EXPECT().SnowballDeviceCapacities(tt.ctx).Return(map[string]int64{"vCPU": 104, "Memory": 416000000000, "IP Address": 30}, nil).Times(2)
 	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
 	tt.Expect(tt.clusterSpec.SnowCredentialsSecret).To(Equal(wantEksaCredentialsSecretWithEnvCreds()))
 	tt.Expect(err).To(Succeed())
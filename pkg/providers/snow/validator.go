@@ -3,8 +3,10 @@ package snow
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
 const (
@@ -125,3 +127,56 @@ func (v *AwsClientValidator) ValidateDeviceSoftware(ctx context.Context, m *v1al
 
 	return nil
 }
+
+// ValidateDevicesCapacity checks that the devices have enough available vCPU, memory and IP address
+// capacity for the machines of the new config. CAPAS places each machine on a single device, one of
+// the devices of its machine group, so every machine has to fit on one device with the resources left
+// once the other machines are placed. When the current config is set, only the capacity needed to
+// upgrade from it is checked. A device not reporting one of the capacities fails the validation.
+func (v *AwsClientValidator) ValidateDevicesCapacity(ctx context.Context, newConfig, currentConfig *cluster.Config) error {
+	machines, err := deviceRequirements(newConfig, currentConfig)
+	if err != nil {
+		return err
+	}
+	if len(machines) == 0 {
+		return nil
+	}
+
+	deviceSet := map[string]bool{}
+	for _, machine := range machines {
+		for _, device := range machine.devices {
+			deviceSet[device] = true
+		}
+	}
+
+	devices := make([]string, 0, len(deviceSet))
+	for ip := range deviceSet {
+		devices = append(devices, ip)
+	}
+	sort.Strings(devices)
+
+	clientMap, err := v.clientRegistry.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	available := make(map[string]machineResources, len(devices))
+	for _, ip := range devices {
+		client, ok := clientMap[ip]
+		if !ok {
+			return fmt.Errorf("credentials not found for device [%s]", ip)
+		}
+
+		capacities, err := client.SnowballDeviceCapacities(ctx)
+		if err != nil {
+			return fmt.Errorf("checking capacity for device [%s]: %v", ip, err)
+		}
+
+		available[ip], err = availableResources(capacities)
+		if err != nil {
+			return fmt.Errorf("checking capacity for device [%s]: %v", ip, err)
+		}
+	}
+
+	return packMachines(machines, available)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
@@ -37,6 +38,7 @@ func newConfigManagerTest(t *testing.T) *configManagerTest {
 		"device-1": mockaws,
 		"device-2": mockaws,
 		"device-3": mockaws,
+		"device-4": mockaws,
 	}
 	mockClientRegistry := mocks.NewMockClientRegistry(ctrl)
 	mockClientRegistry.EXPECT().Get(ctx).Return(awsClients, nil).AnyTimes()
@@ -252,3 +254,241 @@ func TestValidateDeviceSoftwareNotFoundInClientMapError(t *testing.T) {
 	err := g.validator.ValidateDeviceSoftware(g.ctx, g.machineConfig)
 	g.Expect(err).To(MatchError(ContainSubstring("credentials not found for device")))
 }
+
+func givenCapacityConfig() *cluster.Config {
+	return &cluster.Config{
+		Cluster: &v1alpha1.Cluster{
+			Spec: v1alpha1.ClusterSpec{
+				KubernetesVersion: "1.21",
+				ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+					Count: 3,
+					MachineGroupRef: &v1alpha1.Ref{
+						Kind: v1alpha1.SnowMachineConfigKind,
+						Name: "cp-machine",
+					},
+				},
+				WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+					{
+						Name:  "md-0",
+						Count: 2,
+						MachineGroupRef: &v1alpha1.Ref{
+							Kind: v1alpha1.SnowMachineConfigKind,
+							Name: "worker-machine",
+						},
+					},
+				},
+			},
+		},
+		SnowMachineConfigs: map[string]*v1alpha1.SnowMachineConfig{
+			"cp-machine": {
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cp-machine",
+				},
+				Spec: v1alpha1.SnowMachineConfigSpec{
+					InstanceType: v1alpha1.SbeCLarge,
+					Devices:      []string{"device-1", "device-2"},
+				},
+			},
+			"worker-machine": {
+				ObjectMeta: v1.ObjectMeta{
+					Name: "worker-machine",
+				},
+				Spec: v1alpha1.SnowMachineConfigSpec{
+					InstanceType: v1alpha1.SbeCXLarge,
+					Devices:      []string{"device-3"},
+				},
+			},
+		},
+	}
+}
+
+func deviceCapacities(vCPU, memoryGiB, ipAddresses int64) map[string]int64 {
+	return map[string]int64{
+		"vCPU":       vCPU,
+		"Memory":     memoryGiB * 1024 * 1024 * 1024,
+		"IP Address": ipAddresses,
+	}
+}
+
+func TestValidateDevicesCapacity(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(8, 32, 4), nil).Times(3)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).To(Succeed())
+}
+
+func TestValidateDevicesCapacityCaseInsensitiveNames(t *testing.T) {
+	g := newConfigManagerTest(t)
+	capacities := map[string]int64{"vcpu": 8, "memory": 32 * 1024 * 1024 * 1024, "IP address": 4}
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(capacities, nil).Times(3)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).To(Succeed())
+}
+
+func TestValidateDevicesCapacityNotEnoughVCPU(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(2, 32, 4), nil).Times(3)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).To(MatchError("validating capacity for worker node group md-0: no device in [device-3] has enough capacity for a machine requiring 4 vCPU, 17179869184 Memory and 1 IP Address: device [device-3] has 2 vCPU left"))
+}
+
+func TestValidateDevicesCapacityNotEnoughMemory(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(8, 16, 4), nil).Times(3)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("validating capacity for worker node group md-0: no device in [device-3] has enough capacity for a machine requiring 4 vCPU, 17179869184 Memory and 1 IP Address: device [device-3] has 0 Memory left")))
+}
+
+func TestValidateDevicesCapacityNotEnoughIPAddresses(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(8, 32, 1), nil).Times(3)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("device [device-3] has 0 IP Address left")))
+}
+
+func TestValidateDevicesCapacitySharedDevicesNotEnough(t *testing.T) {
+	g := newConfigManagerTest(t)
+	config := givenCapacityConfig()
+	config.SnowMachineConfigs["worker-machine"].Spec.Devices = []string{"device-2"}
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(2, 32, 4), nil)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(8, 32, 4), nil)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, config, nil)
+	g.Expect(err).To(MatchError("validating capacity for control plane: no device in [device-1, device-2] has enough capacity for a machine requiring 2 vCPU, 8589934592 Memory and 1 IP Address: device [device-1] has 0 vCPU left, device [device-2] has 0 vCPU left"))
+}
+
+func TestValidateDevicesCapacityMachineSplitAcrossDevices(t *testing.T) {
+	g := newConfigManagerTest(t)
+	config := givenCapacityConfig()
+	config.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = 1
+	config.SnowMachineConfigs["worker-machine"].Spec.InstanceType = v1alpha1.SbeC2XLarge
+	config.SnowMachineConfigs["worker-machine"].Spec.Devices = []string{"device-3", "device-4"}
+	config.SnowMachineConfigs["cp-machine"].Spec.Devices = []string{"device-1"}
+	config.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(4, 64, 4), nil).Times(3)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, config, nil)
+	g.Expect(err).To(MatchError("validating capacity for worker node group md-0: no device in [device-3, device-4] has enough capacity for a machine requiring 8 vCPU, 34359738368 Memory and 1 IP Address: device [device-3] has 4 vCPU left, device [device-4] has 4 vCPU left"))
+}
+
+func TestValidateDevicesCapacityOverlappingDevices(t *testing.T) {
+	g := newConfigManagerTest(t)
+	config := givenCapacityConfig()
+	config.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	config.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = 1
+	config.SnowMachineConfigs["cp-machine"].Spec.InstanceType = v1alpha1.SbeCXLarge
+	config.SnowMachineConfigs["cp-machine"].Spec.Devices = []string{"device-1", "device-2"}
+	config.SnowMachineConfigs["worker-machine"].Spec.Devices = []string{"device-2"}
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(4, 32, 4), nil).Times(2)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, config, nil)
+	g.Expect(err).To(Succeed())
+}
+
+func TestValidateDevicesCapacityOverlappingDevicesNotEnough(t *testing.T) {
+	g := newConfigManagerTest(t)
+	config := givenCapacityConfig()
+	config.Cluster.Spec.ControlPlaneConfiguration.Count = 2
+	config.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = 1
+	config.SnowMachineConfigs["cp-machine"].Spec.InstanceType = v1alpha1.SbeCXLarge
+	config.SnowMachineConfigs["cp-machine"].Spec.Devices = []string{"device-1", "device-2"}
+	config.SnowMachineConfigs["worker-machine"].Spec.Devices = []string{"device-2"}
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(6, 32, 4), nil).Times(2)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, config, nil)
+	g.Expect(err).To(MatchError("validating capacity for control plane: no device in [device-1, device-2] has enough capacity for a machine requiring 4 vCPU, 17179869184 Memory and 1 IP Address: device [device-1] has 2 vCPU left, device [device-2] has 2 vCPU left"))
+}
+
+func TestValidateDevicesCapacityPinnedWorkerNodeGroups(t *testing.T) {
+	g := newConfigManagerTest(t)
+	config := givenCapacityConfig()
+	config.Cluster.Spec.WorkerNodeGroupConfigurations = append(config.Cluster.Spec.WorkerNodeGroupConfigurations, v1alpha1.WorkerNodeGroupConfiguration{
+		Name:  "md-1",
+		Count: 2,
+		MachineGroupRef: &v1alpha1.Ref{
+			Kind: v1alpha1.SnowMachineConfigKind,
+			Name: "worker-machine",
+		},
+	})
+	config.SnowMachineConfigs["worker-machine"].Spec.Devices = []string{"device-3", "device-4"}
+	config.SnowMachineConfigs["worker-machine"].Spec.PlacementStrategy = v1alpha1.PinnedPlacement
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(8, 32, 4), nil).Times(3)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(4, 32, 4), nil)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, config, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("validating capacity for worker node group md-1: no device in [device-4] has enough capacity")))
+}
+
+func TestValidateDevicesCapacityNotReported(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(map[string]int64{"vCPU": 8, "Memory": 32 * 1024 * 1024 * 1024}, nil)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).To(MatchError("checking capacity for device [device-1]: IP Address capacity not reported by the device, reported capacities: [Memory, vCPU]"))
+}
+
+func TestValidateDevicesCapacityUpgradeNoChanges(t *testing.T) {
+	g := newConfigManagerTest(t)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), givenCapacityConfig())
+	g.Expect(err).To(Succeed())
+}
+
+func TestValidateDevicesCapacityUpgradeScaleUp(t *testing.T) {
+	g := newConfigManagerTest(t)
+	newConfig := givenCapacityConfig()
+	newConfig.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(3, 32, 4), nil)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, newConfig, givenCapacityConfig())
+	g.Expect(err).To(MatchError(ContainSubstring("validating capacity for worker node group md-0: no device in [device-3] has enough capacity for a machine requiring 4 vCPU")))
+}
+
+func TestValidateDevicesCapacityUpgradeRollingReplacement(t *testing.T) {
+	g := newConfigManagerTest(t)
+	newConfig := givenCapacityConfig()
+	newConfig.Cluster.Spec.KubernetesVersion = "1.22"
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(4, 16, 1), nil).Times(3)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, newConfig, givenCapacityConfig())
+	g.Expect(err).To(Succeed())
+}
+
+func TestValidateDevicesCapacityUpgradeLargerInstanceType(t *testing.T) {
+	g := newConfigManagerTest(t)
+	newConfig := givenCapacityConfig()
+	newConfig.SnowMachineConfigs["worker-machine"].Spec.InstanceType = v1alpha1.SbeC2XLarge
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(8, 64, 4), nil)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, newConfig, givenCapacityConfig())
+	g.Expect(err).To(MatchError(ContainSubstring("validating capacity for worker node group md-0: no device in [device-3] has enough capacity for a machine requiring 4 vCPU")))
+}
+
+func TestValidateDevicesCapacityUpgradeNewDevices(t *testing.T) {
+	g := newConfigManagerTest(t)
+	newConfig := givenCapacityConfig()
+	newConfig.SnowMachineConfigs["worker-machine"].Spec.Devices = []string{"device-1"}
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(6, 32, 4), nil)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, newConfig, givenCapacityConfig())
+	g.Expect(err).To(MatchError(ContainSubstring("validating capacity for worker node group md-0: no device in [device-1] has enough capacity for a machine requiring 4 vCPU, 17179869184 Memory and 1 IP Address: device [device-1] has 2 vCPU left")))
+}
+
+func TestValidateDevicesCapacityMachineConfigNotFound(t *testing.T) {
+	g := newConfigManagerTest(t)
+	config := givenCapacityConfig()
+	delete(config.SnowMachineConfigs, "worker-machine")
+	err := g.validator.ValidateDevicesCapacity(g.ctx, config, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("SnowMachineConfig [worker-machine] not found")))
+}
+
+func TestValidateDevicesCapacityError(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(nil, errors.New("error"))
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).To(MatchError(ContainSubstring("checking capacity for device [device-1]")))
+}
+
+func TestValidateDevicesCapacityClientMapError(t *testing.T) {
+	g := newConfigManagerTestClientMapError(t)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, givenCapacityConfig(), nil)
+	g.Expect(err).NotTo(Succeed())
+}
+
+func TestValidateDevicesCapacityNotFoundInClientMapError(t *testing.T) {
+	g := newConfigManagerTest(t)
+	config := givenCapacityConfig()
+	config.SnowMachineConfigs["worker-machine"].Spec.Devices = []string{"device-5"}
+	g.aws.EXPECT().SnowballDeviceCapacities(g.ctx).Return(deviceCapacities(8, 32, 4), nil).Times(2)
+	err := g.validator.ValidateDevicesCapacity(g.ctx, config, nil)
+	g.Expect(err).To(MatchError("credentials not found for device [device-5]"))
+}
@@ -1,13 +1,21 @@
 package tinkerbell
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"path"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 // TODO(chrisdoherty) Add worker node group assertions
@@ -130,6 +138,61 @@ func AssertPortsNotInUse(client networkutils.NetClient) ClusterSpecAssertion {
 	}
 }
 
+// ArtifactDownloader downloads artifacts verifying their digests on the fly.
+type ArtifactDownloader interface {
+	Download(uri string, dst io.Writer, expected files.Checksums) (files.Checksums, error)
+}
+
+// AssertMirroredImagesMatchBundle ensures the hook and Bottlerocket OS images served from the locations
+// overridden in the datacenter config match the digests published in the bundle. Images are only verified
+// when their file name matches the bundle's one, since custom images don't have published digests.
+func AssertMirroredImagesMatchBundle(downloader ArtifactDownloader) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		if spec.VersionsBundle == nil {
+			return nil
+		}
+
+		if hookPath := spec.DatacenterConfig.Spec.HookImagesURLPath; hookPath != "" {
+			hook := spec.VersionsBundle.Tinkerbell.TinkerbellStack.Hook
+			for _, archive := range []releasev1alpha1.Archive{hook.Vmlinuz.Amd, hook.Initramfs.Amd} {
+				if archive.URI == "" {
+					continue
+				}
+				mirrorURL := strings.TrimSuffix(hookPath, "/") + "/" + path.Base(archive.URI)
+				if err := verifyMirroredImage(downloader, mirrorURL, archive); err != nil {
+					return err
+				}
+			}
+		}
+
+		osImage := spec.VersionsBundle.EksD.Raw.Bottlerocket
+		if osImageURL := spec.DatacenterConfig.Spec.OSImageURL; osImageURL != "" && osImage.URI != "" && path.Base(osImageURL) == path.Base(osImage.URI) {
+			if err := verifyMirroredImage(downloader, osImageURL, osImage); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func verifyMirroredImage(downloader ArtifactDownloader, mirrorURL string, archive releasev1alpha1.Archive) error {
+	checksums := files.ArchiveChecksums(archive)
+	if checksums.IsEmpty() {
+		if err := files.AllowUnverified(mirrorURL); err != nil {
+			return fmt.Errorf("verifying mirrored image: %v", err)
+		}
+		return nil
+	}
+
+	logger.V(4).Info("Verifying mirrored image checksums", "url", mirrorURL)
+	if _, err := downloader.Download(mirrorURL, io.Discard, checksums); err != nil {
+		return fmt.Errorf("verifying mirrored image: %v", err)
+	}
+
+	return nil
+}
+
 // HardwareSatisfiesOnlyOneSelectorAssertion ensures hardware in catalogue only satisfies 1
 // of the MachineConfig's HardwareSelector's from the spec.
 func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) ClusterSpecAssertion {
@@ -143,6 +206,36 @@ func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) Cl
 	}
 }
 
+// BMCChecker verifies the BMC of a machine answers with the given credentials.
+type BMCChecker interface {
+	CheckConnection(ctx context.Context, conn bmc.Connection) error
+}
+
+// AssertBMCsReachable ensures the BMC of every catalogue hardware selected by the MachineConfigs
+// in spec answers with the credentials from the catalogue. Hardware without BMC is ignored.
+func AssertBMCsReachable(ctx context.Context, checker BMCChecker, catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		selectors, err := selectorsFromClusterSpec(spec)
+		if err != nil {
+			return err
+		}
+
+		connections := map[string]bmc.Connection{}
+		for _, h := range catalogue.AllHardware() {
+			if h.Spec.BMCRef == nil || len(getMatchingHardwareSelectors(h, selectors)) == 0 {
+				continue
+			}
+			conn, err := bmcConnectionFromCatalogue(catalogue, h.Spec.BMCRef.Name)
+			if err != nil {
+				return fmt.Errorf("hardware %s: %v", h.Name, err)
+			}
+			connections[h.Name] = conn
+		}
+
+		return validateBMCsReachable(ctx, checker, connections)
+	}
+}
+
 // selectorsFromClusterSpec extracts all selectors specified on MachineConfig's from spec.
 func selectorsFromClusterSpec(spec *ClusterSpec) (selectorSet, error) {
 	selectors := selectorSet{}
@@ -314,18 +407,36 @@ func ExtraHardwareAvailableAssertion(catalogue *hardware.Catalogue, maxSurge int
 		// will account for the same selector being specified on different groups.
 		requirements := minimumHardwareRequirements{}
 
+		controlPlaneMaxSurge := maxSurge
+		if rolloutStrategy := spec.ControlPlaneConfiguration().UpgradeRolloutStrategy; rolloutStrategy != nil {
+			controlPlaneMaxSurge = rolloutStrategy.RollingUpdate.MaxSurge
+		}
+		// Hardware released by machines with a deprovision policy is held until it's wiped after the
+		// upgrade, so each machine rolled out needs new hardware.
+		if spec.ControlPlaneMachineConfig().Spec.DeprovisionPolicy != nil {
+			controlPlaneMaxSurge = spec.ControlPlaneConfiguration().Count
+		}
+
 		err := requirements.Add(
 			spec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-			maxSurge,
+			controlPlaneMaxSurge,
 		)
 		if err != nil {
 			return fmt.Errorf("for rolling upgrade, %v", err)
 		}
 
 		for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
+			workerMaxSurge := maxSurge
+			if nodeGroup.UpgradeRolloutStrategy != nil {
+				workerMaxSurge = nodeGroup.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+			}
+			if spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.DeprovisionPolicy != nil {
+				workerMaxSurge = nodeGroup.Count
+			}
+
 			err := requirements.Add(
 				spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector,
-				maxSurge,
+				workerMaxSurge,
 			)
 			if err != nil {
 				return fmt.Errorf("for rolling upgrade, %v", err)
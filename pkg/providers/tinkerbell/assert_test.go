@@ -1,20 +1,32 @@
 package tinkerbell_test
 
 import (
+	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/onsi/gomega"
 	"github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/networkutils/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	tinkerbellmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 func TestAssertMachineConfigsValid_ValidSucceds(t *testing.T) {
@@ -23,6 +35,27 @@ func TestAssertMachineConfigsValid_ValidSucceds(t *testing.T) {
 	g.Expect(tinkerbell.AssertMachineConfigsValid(clusterSpec)).To(gomega.Succeed())
 }
 
+func TestAssertMachineConfigsValid_DiskSelectionSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	minSize := resource.MustParse("400Gi")
+	clusterSpec.ControlPlaneMachineConfig().Spec.InstallDisk = &eksav1alpha1.DiskSelector{MinSize: &minSize}
+	clusterSpec.ControlPlaneMachineConfig().Spec.AdditionalDisks = []eksav1alpha1.AdditionalDisk{
+		{MountPath: "/var/lib/containerd", FSType: "xfs"},
+	}
+	g.Expect(tinkerbell.AssertMachineConfigsValid(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertMachineConfigsValid_DeprovisionPolicySucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.ControlPlaneMachineConfig().Spec.DeprovisionPolicy = &eksav1alpha1.DeprovisionPolicy{
+		WipeMethod: eksav1alpha1.SecureEraseDiskWipe,
+		WipeImage:  "public.ecr.aws/l0g8r8j6/disk-wipe:latest",
+	}
+	g.Expect(tinkerbell.AssertMachineConfigsValid(clusterSpec)).To(gomega.Succeed())
+}
+
 func TestAssertMachineConfigsValid_InvalidFails(t *testing.T) {
 	// Invalidate the namespace check.
 	for name, mutate := range map[string]func(*tinkerbell.ClusterSpec){
@@ -38,6 +71,40 @@ func TestAssertMachineConfigsValid_InvalidFails(t *testing.T) {
 				"baz": "qux",
 			}
 		},
+		"InstallDiskMinSizeGreaterThanMaxSize": func(clusterSpec *tinkerbell.ClusterSpec) {
+			minSize, maxSize := resource.MustParse("2Ti"), resource.MustParse("1Ti")
+			clusterSpec.ControlPlaneMachineConfig().Spec.InstallDisk = &eksav1alpha1.DiskSelector{MinSize: &minSize, MaxSize: &maxSize}
+		},
+		"RelativeAdditionalDiskMountPath": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.AdditionalDisks = []eksav1alpha1.AdditionalDisk{{MountPath: "var/lib/containerd"}}
+		},
+		"RootAdditionalDiskMountPath": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.AdditionalDisks = []eksav1alpha1.AdditionalDisk{{MountPath: "/"}}
+		},
+		"DuplicateAdditionalDiskMountPath": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.AdditionalDisks = []eksav1alpha1.AdditionalDisk{
+				{MountPath: "/var/lib/containerd"},
+				{MountPath: "/var/lib/containerd/"},
+			}
+		},
+		"UnsupportedAdditionalDiskFSType": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.AdditionalDisks = []eksav1alpha1.AdditionalDisk{{MountPath: "/data", FSType: "btrfs"}}
+		},
+		"AdditionalDisksWithBottlerocket": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.OSFamily = eksav1alpha1.Bottlerocket
+			clusterSpec.ControlPlaneMachineConfig().Spec.AdditionalDisks = []eksav1alpha1.AdditionalDisk{{MountPath: "/data"}}
+		},
+		"UnsupportedDeprovisionWipeMethod": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.DeprovisionPolicy = &eksav1alpha1.DeprovisionPolicy{
+				WipeMethod: "shred",
+				WipeImage:  "public.ecr.aws/l0g8r8j6/disk-wipe:latest",
+			}
+		},
+		"MissingDeprovisionWipeImage": func(clusterSpec *tinkerbell.ClusterSpec) {
+			clusterSpec.ControlPlaneMachineConfig().Spec.DeprovisionPolicy = &eksav1alpha1.DeprovisionPolicy{
+				WipeMethod: eksav1alpha1.ZeroDiskWipe,
+			}
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
@@ -400,6 +467,38 @@ func TestMinimumHardwareAvailableAssertionForCreate_InsufficientFailsWithoutExte
 	g.Expect(assertion(clusterSpec)).ToNot(gomega.Succeed())
 }
 
+func TestExtraHardwareAvailableAssertion_DeprovisionPolicyRequiresHardwarePerMachine(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	clusterSpec.WorkerNodeGroupConfigurations()[0].Count = 2
+	workerMachineConfig := clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0])
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "cp",
+			Labels: clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
+		},
+	})).To(gomega.Succeed())
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "worker",
+			Labels: workerMachineConfig.Spec.HardwareSelector,
+		},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.ExtraHardwareAvailableAssertion(catalogue, 1)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+
+	workerMachineConfig.Spec.DeprovisionPolicy = &eksav1alpha1.DeprovisionPolicy{
+		WipeMethod: eksav1alpha1.ZeroDiskWipe,
+		WipeImage:  "wipe:latest",
+	}
+	g.Expect(assertion(clusterSpec)).ToNot(gomega.Succeed())
+}
+
 func TestHardwareSatisfiesOnlyOneSelectorAssertion_MeetsOnlyOneSelector(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -455,6 +554,123 @@ func TestHardwareSatisfiesOnlyOneSelectorAssertion_NoLabelsMeetsNothing(t *testi
 	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
 }
 
+func givenMirroredImagesClusterSpec(mirrorURL string) *tinkerbell.ClusterSpec {
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.DatacenterConfig.Spec.HookImagesURLPath = mirrorURL + "/hook/"
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = mirrorURL + "/bottlerocket.img.gz"
+
+	bundle := &releasev1alpha1.VersionsBundle{}
+	bundle.Tinkerbell.TinkerbellStack.Hook.Vmlinuz.Amd = releasev1alpha1.Archive{
+		URI:    "https://assets/hook/vmlinuz-x86_64",
+		SHA256: sha256Hex("vmlinuz"),
+	}
+	bundle.Tinkerbell.TinkerbellStack.Hook.Initramfs.Amd = releasev1alpha1.Archive{
+		URI:    "https://assets/hook/initramfs-x86_64",
+		SHA256: sha256Hex("initramfs"),
+	}
+	bundle.EksD.Raw.Bottlerocket = releasev1alpha1.Archive{
+		URI:    "https://assets/raw/bottlerocket.img.gz",
+		SHA256: sha256Hex("bottlerocket"),
+	}
+	clusterSpec.VersionsBundle = &cluster.VersionsBundle{VersionsBundle: bundle}
+
+	return clusterSpec
+}
+
+func sha256Hex(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
+
+func newImagesMirror(t *testing.T, images map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := images[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAssertMirroredImagesMatchBundle_Succeeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mirror := newImagesMirror(t, map[string]string{
+		"/hook/vmlinuz-x86_64":   "vmlinuz",
+		"/hook/initramfs-x86_64": "initramfs",
+		"/bottlerocket.img.gz":   "bottlerocket",
+	})
+	clusterSpec := givenMirroredImagesClusterSpec(mirror.URL)
+
+	assertion := tinkerbell.AssertMirroredImagesMatchBundle(files.NewReader())
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertMirroredImagesMatchBundle_HookMismatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mirror := newImagesMirror(t, map[string]string{
+		"/hook/vmlinuz-x86_64":   "vmlinuz",
+		"/hook/initramfs-x86_64": "corrupted",
+		"/bottlerocket.img.gz":   "bottlerocket",
+	})
+	clusterSpec := givenMirroredImagesClusterSpec(mirror.URL)
+
+	assertion := tinkerbell.AssertMirroredImagesMatchBundle(files.NewReader())
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("checksum mismatch for [" + mirror.URL + "/hook/initramfs-x86_64]")))
+}
+
+func TestAssertMirroredImagesMatchBundle_OSImageMissing(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mirror := newImagesMirror(t, map[string]string{
+		"/hook/vmlinuz-x86_64":   "vmlinuz",
+		"/hook/initramfs-x86_64": "initramfs",
+	})
+	clusterSpec := givenMirroredImagesClusterSpec(mirror.URL)
+
+	assertion := tinkerbell.AssertMirroredImagesMatchBundle(files.NewReader())
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("404 Not Found")))
+}
+
+func TestAssertMirroredImagesMatchBundle_CustomOSImageSkipped(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mirror := newImagesMirror(t, map[string]string{
+		"/hook/vmlinuz-x86_64":   "vmlinuz",
+		"/hook/initramfs-x86_64": "initramfs",
+	})
+	clusterSpec := givenMirroredImagesClusterSpec(mirror.URL)
+	clusterSpec.DatacenterConfig.Spec.OSImageURL = mirror.URL + "/ubuntu.gz"
+
+	assertion := tinkerbell.AssertMirroredImagesMatchBundle(files.NewReader())
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertMirroredImagesMatchBundle_MissingChecksumsWarns(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mirror := newImagesMirror(t, map[string]string{})
+	clusterSpec := givenMirroredImagesClusterSpec(mirror.URL)
+	clusterSpec.VersionsBundle.Tinkerbell.TinkerbellStack.Hook.Vmlinuz.Amd.SHA256 = ""
+	clusterSpec.VersionsBundle.Tinkerbell.TinkerbellStack.Hook.Initramfs.Amd.SHA256 = ""
+	clusterSpec.VersionsBundle.EksD.Raw.Bottlerocket.SHA256 = ""
+
+	assertion := tinkerbell.AssertMirroredImagesMatchBundle(files.NewReader())
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertMirroredImagesMatchBundle_MissingChecksumsStrict(t *testing.T) {
+	g := gomega.NewWithT(t)
+	t.Setenv(features.StrictArtifactVerificationEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	mirror := newImagesMirror(t, map[string]string{})
+	clusterSpec := givenMirroredImagesClusterSpec(mirror.URL)
+	clusterSpec.VersionsBundle.Tinkerbell.TinkerbellStack.Hook.Vmlinuz.Amd.SHA256 = ""
+
+	assertion := tinkerbell.AssertMirroredImagesMatchBundle(files.NewReader())
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("no published checksum to verify [" + mirror.URL + "/hook/vmlinuz-x86_64]")))
+}
+
 // mergeHardwareSelectors merges m1 with m2. Values already in m1 will be overwritten by m2.
 func mergeHardwareSelectors(m1, m2 map[string]string) map[string]string {
 	for name, value := range m2 {
@@ -462,3 +678,69 @@ func mergeHardwareSelectors(m1, m2 map[string]string) map[string]string {
 	}
 	return m1
 }
+
+func givenBMCCatalogue(t *testing.T, machines ...hardware.Machine) *hardware.Catalogue {
+	catalogue := hardware.NewCatalogue(hardware.WithBMCNameIndex(), hardware.WithSecretNameIndex())
+	writer := hardware.NewMachineCatalogueWriter(catalogue)
+	for _, m := range machines {
+		if err := writer.Write(m); err != nil {
+			t.Fatalf("writing machine to catalogue: %v", err)
+		}
+	}
+	return catalogue
+}
+
+func TestAssertBMCsReachable_ChecksSelectedHardware(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	checker := tinkerbellmocks.NewMockBMCChecker(gomock.NewController(t))
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	catalogue := givenBMCCatalogue(t,
+		hardware.Machine{
+			Hostname:     "cp",
+			Labels:       hardware.Labels(clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector),
+			BMCIPAddress: "192.168.0.10",
+			BMCUsername:  "admin",
+			BMCPassword:  "password",
+		},
+		hardware.Machine{
+			Hostname: "worker",
+			Labels:   hardware.Labels(clusterSpec.WorkerNodeGroupMachineConfig(clusterSpec.WorkerNodeGroupConfigurations()[0]).Spec.HardwareSelector),
+		},
+		hardware.Machine{
+			Hostname:     "spare",
+			Labels:       hardware.Labels{"type": "spare"},
+			BMCIPAddress: "192.168.0.12",
+			BMCUsername:  "admin",
+			BMCPassword:  "password",
+		},
+	)
+
+	checker.EXPECT().CheckConnection(ctx, bmc.Connection{Host: "192.168.0.10", Username: "admin", Password: "password"})
+
+	assertion := tinkerbell.AssertBMCsReachable(ctx, checker, catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestAssertBMCsReachable_Unreachable(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	checker := tinkerbellmocks.NewMockBMCChecker(gomock.NewController(t))
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	catalogue := givenBMCCatalogue(t,
+		hardware.Machine{
+			Hostname:     "cp",
+			Labels:       hardware.Labels(clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector),
+			BMCIPAddress: "192.168.0.10",
+			BMCUsername:  "admin",
+			BMCPassword:  "wrong",
+		},
+	)
+
+	checker.EXPECT().CheckConnection(ctx, gomock.Any()).Return(errors.New("401 unauthorized"))
+
+	assertion := tinkerbell.AssertBMCsReachable(ctx, checker, catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError("BMC not reachable for hardware: cp (401 unauthorized)"))
+}
@@ -0,0 +1,172 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib"
+)
+
+const (
+	// PowerOn is the power state of a running machine.
+	PowerOn = "on"
+	// PowerOff is the power state of a stopped machine.
+	PowerOff = "off"
+
+	powerCycle     = "cycle"
+	pxeBootDevice  = "pxe"
+	ipmiPort       = "623"
+	defaultTimeout = 30 * time.Second
+)
+
+// Connection holds the details to connect to a BMC.
+type Connection struct {
+	Host     string
+	Username string
+	Password string
+}
+
+// Client is a connection to a BMC able to manage the power and boot of its machine.
+type Client interface {
+	Open(ctx context.Context) error
+	Close(ctx context.Context) error
+	GetPowerState(ctx context.Context) (string, error)
+	SetPowerState(ctx context.Context, state string) (bool, error)
+	SetBootDevice(ctx context.Context, bootDevice string, setPersistent, efiBoot bool) (bool, error)
+}
+
+// ClientFactory builds a Client for a BMC connection.
+type ClientFactory func(Connection) Client
+
+// NewClient returns a Client that talks to the BMC with any of the protocols supported by bmclib,
+// including IPMI and Redfish.
+func NewClient(c Connection) Client {
+	return bmclib.NewClient(c.Host, ipmiPort, c.Username, c.Password)
+}
+
+// Manager performs power and boot operations on machines through their BMCs.
+type Manager struct {
+	newClient ClientFactory
+	timeout   time.Duration
+}
+
+// ManagerOpt configures a Manager.
+type ManagerOpt func(*Manager)
+
+// WithClientFactory sets the factory used to build the BMC clients.
+func WithClientFactory(f ClientFactory) ManagerOpt {
+	return func(m *Manager) {
+		m.newClient = f
+	}
+}
+
+// WithTimeout sets the timeout of every operation on a BMC, connection included.
+func WithTimeout(timeout time.Duration) ManagerOpt {
+	return func(m *Manager) {
+		m.timeout = timeout
+	}
+}
+
+// NewManager returns a Manager that uses bmclib to talk to the BMCs.
+func NewManager(opts ...ManagerOpt) *Manager {
+	m := &Manager{
+		newClient: NewClient,
+		timeout:   defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// CheckConnection verifies the BMC answers with the connection credentials.
+func (m *Manager) CheckConnection(ctx context.Context, c Connection) error {
+	_, err := m.PowerStatus(ctx, c)
+	return err
+}
+
+// PowerStatus returns the power state of the machine, usually PowerOn or PowerOff.
+func (m *Manager) PowerStatus(ctx context.Context, c Connection) (string, error) {
+	var state string
+	err := m.withClient(ctx, c, func(ctx context.Context, client Client) error {
+		var err error
+		state, err = powerState(ctx, client)
+		return err
+	})
+	return state, err
+}
+
+// PowerOn powers on the machine.
+func (m *Manager) PowerOn(ctx context.Context, c Connection) error {
+	return m.withClient(ctx, c, func(ctx context.Context, client Client) error {
+		return setPowerState(ctx, client, PowerOn)
+	})
+}
+
+// PowerOff powers off the machine without waiting for the operating system to shut down.
+func (m *Manager) PowerOff(ctx context.Context, c Connection) error {
+	return m.withClient(ctx, c, func(ctx context.Context, client Client) error {
+		return setPowerState(ctx, client, PowerOff)
+	})
+}
+
+// PowerCycle restarts the machine, or powers it on if it's off.
+func (m *Manager) PowerCycle(ctx context.Context, c Connection) error {
+	return m.withClient(ctx, c, powerCycleOrOn)
+}
+
+// PXEBoot sets the machine to boot from the network on the next boot only and restarts it.
+func (m *Manager) PXEBoot(ctx context.Context, c Connection, efiBoot bool) error {
+	return m.withClient(ctx, c, func(ctx context.Context, client Client) error {
+		if _, err := client.SetBootDevice(ctx, pxeBootDevice, false, efiBoot); err != nil {
+			return fmt.Errorf("setting next boot device to %s: %v", pxeBootDevice, err)
+		}
+		return powerCycleOrOn(ctx, client)
+	})
+}
+
+func (m *Manager) withClient(ctx context.Context, c Connection, fn func(context.Context, Client) error) error {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	client := m.newClient(c)
+	if err := client.Open(ctx); err != nil {
+		return fmt.Errorf("connecting to BMC %s: %v", c.Host, err)
+	}
+	defer client.Close(ctx)
+
+	return fn(ctx, client)
+}
+
+func powerCycleOrOn(ctx context.Context, client Client) error {
+	state, err := powerState(ctx, client)
+	if err != nil {
+		return err
+	}
+	if state == PowerOff {
+		return setPowerState(ctx, client, PowerOn)
+	}
+	return setPowerState(ctx, client, powerCycle)
+}
+
+func powerState(ctx context.Context, client Client) (string, error) {
+	state, err := client.GetPowerState(ctx)
+	if err != nil {
+		return "", fmt.Errorf("getting power state: %v", err)
+	}
+	// Providers report the state with different casing and details, such as "On" or "Chassis Power is on".
+	words := strings.Fields(strings.ToLower(state))
+	if len(words) == 0 {
+		return "", fmt.Errorf("getting power state: empty state")
+	}
+	return words[len(words)-1], nil
+}
+
+func setPowerState(ctx context.Context, client Client, state string) error {
+	if _, err := client.SetPowerState(ctx, state); err != nil {
+		return fmt.Errorf("setting power state to %s: %v", state, err)
+	}
+	return nil
+}
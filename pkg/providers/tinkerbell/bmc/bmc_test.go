@@ -0,0 +1,129 @@
+package bmc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc/mocks"
+)
+
+type bmcTest struct {
+	*WithT
+	ctx     context.Context
+	conn    bmc.Connection
+	client  *mocks.MockClient
+	manager *bmc.Manager
+}
+
+func newBMCTest(t *testing.T) *bmcTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	conn := bmc.Connection{Host: "192.168.0.10", Username: "admin", Password: "password"}
+	return &bmcTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		conn:   conn,
+		client: client,
+		manager: bmc.NewManager(bmc.WithClientFactory(func(c bmc.Connection) bmc.Client {
+			if c != conn {
+				t.Fatalf("unexpected BMC connection %v", c)
+			}
+			return client
+		})),
+	}
+}
+
+func (tt *bmcTest) expectOpen() {
+	tt.client.EXPECT().Open(gomock.Any())
+	tt.client.EXPECT().Close(gomock.Any())
+}
+
+func TestManagerPowerStatus(t *testing.T) {
+	tests := []struct {
+		reported string
+		want     string
+	}{
+		{reported: "On", want: bmc.PowerOn},
+		{reported: "Chassis Power is off\n", want: bmc.PowerOff},
+		{reported: "PoweringOn", want: "poweringon"},
+	}
+	for _, test := range tests {
+		t.Run(test.reported, func(t *testing.T) {
+			tt := newBMCTest(t)
+			tt.expectOpen()
+			tt.client.EXPECT().GetPowerState(gomock.Any()).Return(test.reported, nil)
+
+			tt.Expect(tt.manager.PowerStatus(tt.ctx, tt.conn)).To(Equal(test.want))
+		})
+	}
+}
+
+func TestManagerPowerOn(t *testing.T) {
+	tt := newBMCTest(t)
+	tt.expectOpen()
+	tt.client.EXPECT().SetPowerState(gomock.Any(), "on").Return(true, nil)
+
+	tt.Expect(tt.manager.PowerOn(tt.ctx, tt.conn)).To(Succeed())
+}
+
+func TestManagerPowerOffError(t *testing.T) {
+	tt := newBMCTest(t)
+	tt.expectOpen()
+	tt.client.EXPECT().SetPowerState(gomock.Any(), "off").Return(false, errors.New("unsupported"))
+
+	tt.Expect(tt.manager.PowerOff(tt.ctx, tt.conn)).To(MatchError(ContainSubstring("setting power state to off: unsupported")))
+}
+
+func TestManagerPowerCycleOn(t *testing.T) {
+	tt := newBMCTest(t)
+	tt.expectOpen()
+	gomock.InOrder(
+		tt.client.EXPECT().GetPowerState(gomock.Any()).Return("On", nil),
+		tt.client.EXPECT().SetPowerState(gomock.Any(), "cycle").Return(true, nil),
+	)
+
+	tt.Expect(tt.manager.PowerCycle(tt.ctx, tt.conn)).To(Succeed())
+}
+
+func TestManagerPowerCycleOff(t *testing.T) {
+	tt := newBMCTest(t)
+	tt.expectOpen()
+	gomock.InOrder(
+		tt.client.EXPECT().GetPowerState(gomock.Any()).Return("Off", nil),
+		tt.client.EXPECT().SetPowerState(gomock.Any(), "on").Return(true, nil),
+	)
+
+	tt.Expect(tt.manager.PowerCycle(tt.ctx, tt.conn)).To(Succeed())
+}
+
+func TestManagerPXEBoot(t *testing.T) {
+	tt := newBMCTest(t)
+	tt.expectOpen()
+	gomock.InOrder(
+		tt.client.EXPECT().SetBootDevice(gomock.Any(), "pxe", false, true).Return(true, nil),
+		tt.client.EXPECT().GetPowerState(gomock.Any()).Return("On", nil),
+		tt.client.EXPECT().SetPowerState(gomock.Any(), "cycle").Return(true, nil),
+	)
+
+	tt.Expect(tt.manager.PXEBoot(tt.ctx, tt.conn, true)).To(Succeed())
+}
+
+func TestManagerPXEBootSetBootDeviceError(t *testing.T) {
+	tt := newBMCTest(t)
+	tt.expectOpen()
+	tt.client.EXPECT().SetBootDevice(gomock.Any(), "pxe", false, false).Return(false, errors.New("not supported"))
+
+	tt.Expect(tt.manager.PXEBoot(tt.ctx, tt.conn, false)).To(MatchError(ContainSubstring("setting next boot device to pxe")))
+}
+
+func TestManagerCheckConnectionOpenError(t *testing.T) {
+	tt := newBMCTest(t)
+	tt.client.EXPECT().Open(gomock.Any()).Return(errors.New("401 unauthorized"))
+
+	tt.Expect(tt.manager.CheckConnection(tt.ctx, tt.conn)).To(MatchError("connecting to BMC 192.168.0.10: 401 unauthorized"))
+}
@@ -0,0 +1,167 @@
+package bmc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/bmc-toolbox/bmclib"
+	"github.com/bmc-toolbox/bmclib/providers/redfish"
+	"github.com/go-logr/logr"
+	"github.com/jacobweinstock/registrar"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+)
+
+// fakeRedfish is a minimal Redfish service with a single system and session based authentication.
+type fakeRedfish struct {
+	*httptest.Server
+	username, password string
+
+	mu         sync.Mutex
+	powerState string
+	resets     []string
+}
+
+func newFakeRedfish(t *testing.T, username, password, powerState string) *fakeRedfish {
+	f := &fakeRedfish{username: username, password: password, powerState: powerState}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redfish/v1/", f.serviceRoot)
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", f.sessions)
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/1", f.authenticated(func(http.ResponseWriter, *http.Request) {}))
+	mux.HandleFunc("/redfish/v1/Systems", f.authenticated(f.systems))
+	mux.HandleFunc("/redfish/v1/Systems/1", f.authenticated(f.system))
+	mux.HandleFunc("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", f.authenticated(f.reset))
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeRedfish) serviceRoot(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/redfish/v1/" {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"@odata.id": "/redfish/v1/",
+		"Systems":   map[string]string{"@odata.id": "/redfish/v1/Systems"},
+		"Links": map[string]interface{}{
+			"Sessions": map[string]string{"@odata.id": "/redfish/v1/SessionService/Sessions"},
+		},
+	})
+}
+
+func (f *fakeRedfish) sessions(w http.ResponseWriter, r *http.Request) {
+	credentials := struct{ UserName, Password string }{}
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil || credentials.UserName != f.username || credentials.Password != f.password {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("X-Auth-Token", "token")
+	w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (f *fakeRedfish) authenticated(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (f *fakeRedfish) systems(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"Members":             []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}},
+		"Members@odata.count": 1,
+	})
+}
+
+func (f *fakeRedfish) system(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writeJSON(w, map[string]interface{}{
+		"@odata.id":  "/redfish/v1/Systems/1",
+		"Id":         "1",
+		"PowerState": f.powerState,
+		"Actions": map[string]interface{}{
+			"#ComputerSystem.Reset": map[string]string{"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"},
+		},
+	})
+}
+
+func (f *fakeRedfish) reset(w http.ResponseWriter, r *http.Request) {
+	body := struct{ ResetType string }{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resets = append(f.resets, body.ResetType)
+	switch body.ResetType {
+	case "On", "ForceRestart":
+		f.powerState = "On"
+	case "ForceOff":
+		f.powerState = "Off"
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeRedfish) resetTypes() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.resets
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// newRedfishManager returns a Manager whose clients only talk Redfish, so the tests don't depend on
+// the ipmitool binary being installed.
+func newRedfishManager(server *fakeRedfish) *bmc.Manager {
+	return bmc.NewManager(bmc.WithClientFactory(func(c bmc.Connection) bmc.Client {
+		registry := registrar.NewRegistry()
+		driver := redfish.New(c.Host, "", c.Username, c.Password, logr.Discard(), redfish.WithHTTPClient(server.Client()))
+		registry.Register(redfish.ProviderName, redfish.ProviderProtocol, redfish.Features, nil, driver)
+		return bmclib.NewClient(c.Host, "", c.Username, c.Password, bmclib.WithRegistry(registry))
+	}))
+}
+
+func TestManagerRedfishPowerOperations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := newFakeRedfish(t, "admin", "password", "Off")
+	manager := newRedfishManager(server)
+	conn := bmc.Connection{Host: server.URL, Username: "admin", Password: "password"}
+
+	g.Expect(manager.CheckConnection(ctx, conn)).To(Succeed())
+	g.Expect(manager.PowerStatus(ctx, conn)).To(Equal(bmc.PowerOff))
+
+	g.Expect(manager.PowerCycle(ctx, conn)).To(Succeed())
+	g.Expect(manager.PowerStatus(ctx, conn)).To(Equal(bmc.PowerOn))
+
+	g.Expect(manager.PowerCycle(ctx, conn)).To(Succeed())
+	g.Expect(manager.PowerOff(ctx, conn)).To(Succeed())
+	g.Expect(manager.PowerStatus(ctx, conn)).To(Equal(bmc.PowerOff))
+
+	g.Expect(server.resetTypes()).To(Equal([]string{"On", "ForceRestart", "ForceOff"}))
+}
+
+func TestManagerRedfishCheckConnectionInvalidCredentials(t *testing.T) {
+	g := NewWithT(t)
+	server := newFakeRedfish(t, "admin", "password", "On")
+	manager := newRedfishManager(server)
+	conn := bmc.Connection{Host: server.URL, Username: "admin", Password: "wrong"}
+
+	g.Expect(manager.CheckConnection(context.Background(), conn)).To(MatchError(ContainSubstring("connecting to BMC " + server.URL)))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -101,6 +102,27 @@ func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 	return nil
 }
 
+// PreMachinesRemoval records the hardware of the cluster with a deprovision policy and holds it, so
+// the hardware released by the upgrade or delete can be wiped before it's provisioned again.
+func (p *Provider) PreMachinesRemoval(ctx context.Context, _ *cluster.Spec, managementCluster *types.Cluster) error {
+	return p.collectDeprovisionHardware(ctx, managementCluster.KubeconfigFile)
+}
+
+func (p *Provider) PostControlPlaneReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	return nil
+}
+
+func (p *Provider) PostWorkerNodesReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	// Wipe the hardware that left the cluster during an upgrade, nothing is recorded on create. Then
+	// hold the hardware provisioned for the cluster so it isn't reused before it's wiped, even when
+	// the controller releases it.
+	if err := p.deprovisionReleasedHardware(ctx, managementCluster); err != nil {
+		return err
+	}
+
+	return p.collectDeprovisionHardware(ctx, managementCluster.KubeconfigFile)
+}
+
 func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
 		return ErrExternalEtcdUnsupported
@@ -143,11 +165,8 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	)
 
 	clusterSpecValidator.Register(AssertPortsNotInUse(p.netClient))
+	clusterSpecValidator.Register(AssertMirroredImagesMatchBundle(p.artifactDownloader))
 
-	if !p.skipIpCheck {
-		clusterSpecValidator.Register(NewIPNotInUseAssertion(p.netClient))
-		clusterSpecValidator.Register(AssertTinkerbellIPNotInUse(p.netClient))
-	}
 	// Validate must happen last beacuse we depend on the catalogue entries for some checks.
 	if err := clusterSpecValidator.Validate(spec); err != nil {
 		return err
@@ -156,13 +175,43 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	return nil
 }
 
+func (p *Provider) CreateClusterChecks(ctx context.Context, clusterSpec *cluster.Spec) []providers.Check {
+	spec := NewClusterSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)
+	return []providers.Check{
+		{
+			ID:          providers.ControlPlaneIPCheckID,
+			Name:        "validate control plane ip is not in use",
+			Remediation: "provide an unused IP for controlPlaneConfiguration.endpoint.host",
+			Validate: func() error {
+				return NewIPNotInUseAssertion(p.netClient)(spec)
+			},
+		},
+		{
+			ID:          providers.TinkerbellIPCheckID,
+			Name:        "validate tinkerbell ip is not in use",
+			Remediation: "provide an unused IP for tinkerbellIP",
+			Validate: func() error {
+				return AssertTinkerbellIPNotInUse(p.netClient)(spec)
+			},
+		},
+		{
+			ID:          providers.BMCReachableCheckID,
+			Name:        "validate machine BMCs are reachable",
+			Remediation: "make sure the BMC of every selected machine is reachable with the credentials from the hardware CSV",
+			Validate: func() error {
+				return AssertBMCsReachable(ctx, p.bmcChecker, p.catalogue)(spec)
+			},
+		},
+	}
+}
+
 func (p *Provider) readCSVToCatalogue() error {
 	// Create a catalogue writer used to write hardware to the catalogue.
 	catalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
 
-	// Combine disk extraction with catalogue writing. Disk extraction will be used for rendering
-	// templates.
-	writer := hardware.MultiMachineWriter(catalogueWriter, &p.diskExtractor)
+	// Combine disk, netplan and disk layout extraction with catalogue writing. All will be used for
+	// rendering templates.
+	writer := hardware.MultiMachineWriter(catalogueWriter, &p.diskExtractor, p.netplanExtractor, p.diskLayoutExtractor)
 
 	machineValidator := hardware.NewDefaultMachineValidator()
 
@@ -183,10 +232,14 @@ func (p *Provider) readCSVToCatalogue() error {
 
 // selectorsFromMachineConfigs extracts all selectors from TinkerbellMachineConfigs returning them
 // as a slice. It doesn't need the map, it only accepts that for ease as that's how we manage them
-// in the provider construct.
+// in the provider construct. Selectors of machine configs selecting their install disk by
+// attributes are excluded as their hardware may use different disks.
 func selectorsFromMachineConfigs(configs map[string]*v1alpha1.TinkerbellMachineConfig) []v1alpha1.HardwareSelector {
 	selectors := make([]v1alpha1.HardwareSelector, 0, len(configs))
 	for _, s := range configs {
+		if s.Spec.InstallDisk != nil {
+			continue
+		}
 		selectors = append(selectors, s.Spec.HardwareSelector)
 	}
 	return selectors
@@ -21,7 +21,16 @@ func (p *Provider) DeleteResources(ctx context.Context, clusterSpec *cluster.Spe
 	return p.providerKubectlClient.DeleteEksaDatacenterConfig(ctx, eksaTinkerbellMachineResourceType, p.datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, p.datacenterConfig.Namespace)
 }
 
+func (p *Provider) PostClusterDelete(_ context.Context, _ *types.Cluster) error {
+	// NOOP
+	return nil
+}
+
 func (p *Provider) PostClusterDeleteValidate(ctx context.Context, managementCluster *types.Cluster) error {
+	if err := p.deprovisionReleasedHardware(ctx, managementCluster); err != nil {
+		return err
+	}
+
 	if err := p.stackInstaller.UninstallLocal(ctx); err != nil {
 		return err
 	}
@@ -0,0 +1,537 @@
+package tinkerbell
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// hardwareOwnerNameLabel and hardwareOwnerNamespaceLabel are set by CAPT on the hardware it
+	// provisions. Hardware without an owner is available for provisioning.
+	hardwareOwnerNameLabel      = "v1alpha1.tinkerbell.org/ownerName"
+	hardwareOwnerNamespaceLabel = "v1alpha1.tinkerbell.org/ownerNamespace"
+
+	// deprovisioningOwner owns hardware while its disks are wiped so CAPT doesn't select it for
+	// provisioning. Hardware whose wipe fails keeps this owner until an operator releases it.
+	deprovisioningOwner = "eksa-deprovisioning"
+
+	// deprovisionHoldLabel is set to the cluster name on the hardware of the cluster selected by a
+	// machine config with a deprovision policy, before any of its machines is deleted. The hardware
+	// affinity of the machine templates excludes hardware with this label, so hardware released by
+	// CAPT, whether by the CLI or by the controller, isn't provisioned again until it's wiped.
+	deprovisionHoldLabel = "anywhere.eks.amazonaws.com/deprovision-hold"
+
+	// diskWipeActionTimeout is the timeout in seconds of the action wiping a single disk.
+	diskWipeActionTimeout = 6 * 60 * 60
+
+	workflowPollTimeout  = 12 * time.Hour
+	workflowPollInterval = 30 * time.Second
+	bmcJobPollTimeout    = 10 * time.Minute
+	bmcJobPollInterval   = 5 * time.Second
+)
+
+// errDeprovisionFailed is returned when a wipe workflow or a BMC job fails, which stops polling it.
+var errDeprovisionFailed = errors.New("deprovisioning failed")
+
+// deprovisioner wipes the disks of hardware that left a cluster and powers it off through its BMC
+// before returning it to the pool of available hardware.
+type deprovisioner struct {
+	kubectl ProviderKubectlClient
+	// workflowRetrier polls the wipe workflows until they complete.
+	workflowRetrier *retrier.Retrier
+	// bmcJobRetrier polls the BMC jobs until they complete.
+	bmcJobRetrier *retrier.Retrier
+}
+
+func newDeprovisioner(kubectl ProviderKubectlClient) *deprovisioner {
+	return &deprovisioner{
+		kubectl:         kubectl,
+		workflowRetrier: retrier.New(workflowPollTimeout, retrier.WithRetryPolicy(pollPolicy(workflowPollInterval))),
+		bmcJobRetrier:   retrier.New(bmcJobPollTimeout, retrier.WithRetryPolicy(pollPolicy(bmcJobPollInterval))),
+	}
+}
+
+func pollPolicy(interval time.Duration) retrier.RetryPolicy {
+	return func(_ int, err error) (bool, time.Duration) {
+		return !errors.Is(err, errDeprovisionFailed), interval
+	}
+}
+
+// deprovisionPolicy returns the deprovision policy of the machine config whose hardware selector
+// matches the hardware, or nil if none does.
+func (p *Provider) deprovisionPolicy(hardware tinkv1alpha1.Hardware) *v1alpha1.DeprovisionPolicy {
+	names := make([]string, 0, len(p.machineConfigs))
+	for name := range p.machineConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec := p.machineConfigs[name].Spec
+		if spec.DeprovisionPolicy == nil || len(spec.HardwareSelector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(labels.Set(spec.HardwareSelector)).Matches(labels.Set(hardware.Labels)) {
+			return spec.DeprovisionPolicy
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) hasDeprovisionPolicy() bool {
+	for _, mc := range p.machineConfigs {
+		if mc.Spec.DeprovisionPolicy != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// collectDeprovisionHardware records the hardware of the cluster that is selected by a machine
+// config with a deprovision policy so it can be wiped once it leaves the cluster, and holds it so
+// CAPT doesn't provision it again once released. The hardware of the cluster is the hardware
+// provisioned for it and the hardware it still holds, released since the last collection, for
+// example by a scale down driven by the controller.
+func (p *Provider) collectDeprovisionHardware(ctx context.Context, kubeconfig string) error {
+	if !p.hasDeprovisionPolicy() {
+		return nil
+	}
+
+	owners, err := p.providerKubectlClient.GetTinkerbellMachineNames(ctx, kubeconfig, constants.EksaSystemNamespace, p.clusterConfig.Name)
+	if err != nil {
+		return err
+	}
+	owned := make(map[string]bool, len(owners))
+	for _, owner := range owners {
+		owned[owner] = true
+	}
+
+	provisioned, err := p.providerKubectlClient.GetProvisionedTinkerbellHardware(ctx, kubeconfig, constants.EksaSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("retrieving provisioned hardware: %v", err)
+	}
+
+	unprovisioned, err := p.providerKubectlClient.GetUnprovisionedTinkerbellHardware(ctx, kubeconfig, constants.EksaSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("retrieving unprovisioned hardware: %v", err)
+	}
+
+	p.deprovisionHardware = map[string]v1alpha1.DeprovisionPolicy{}
+	for _, hw := range append(provisioned, unprovisioned...) {
+		held := hw.Labels[deprovisionHoldLabel] == p.clusterConfig.Name
+		if !held && !owned[hw.Labels[hardwareOwnerNameLabel]] {
+			continue
+		}
+
+		policy := p.deprovisionPolicy(hw)
+		if policy == nil {
+			continue
+		}
+
+		if err := validateDeprovisionableHardware(hw); err != nil {
+			return err
+		}
+
+		if !held {
+			if err := p.deprovisioner.hold(ctx, kubeconfig, hw, p.clusterConfig.Name); err != nil {
+				return fmt.Errorf("holding hardware %s: %v", hw.Name, err)
+			}
+		}
+
+		p.deprovisionHardware[hw.Name] = *policy
+	}
+
+	return nil
+}
+
+func validateDeprovisionableHardware(hw tinkv1alpha1.Hardware) error {
+	if hw.Spec.BMCRef == nil {
+		return fmt.Errorf("hardware %s has a deprovision policy but no BMC to power it off", hw.Name)
+	}
+
+	if len(hw.Spec.Disks) == 0 {
+		return fmt.Errorf("hardware %s has a deprovision policy but no disks to wipe", hw.Name)
+	}
+
+	if len(hw.Spec.Interfaces) == 0 || hw.Spec.Interfaces[0].DHCP == nil || hw.Spec.Interfaces[0].DHCP.MAC == "" {
+		return fmt.Errorf("hardware %s has a deprovision policy but no MAC address to run the wipe workflow", hw.Name)
+	}
+
+	return nil
+}
+
+// deprovisionReleasedHardware wipes and powers off the recorded hardware that is no longer
+// provisioned for the cluster. Hardware still provisioned for the cluster stays recorded.
+func (p *Provider) deprovisionReleasedHardware(ctx context.Context, cluster *types.Cluster) error {
+	if len(p.deprovisionHardware) == 0 {
+		return nil
+	}
+
+	unprovisioned, err := p.providerKubectlClient.GetUnprovisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("retrieving unprovisioned hardware: %v", err)
+	}
+
+	// Hardware held by a previous attempt that failed is still owned by the deprovisioner.
+	provisioned, err := p.providerKubectlClient.GetProvisionedTinkerbellHardware(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("retrieving provisioned hardware: %v", err)
+	}
+
+	var released []tinkv1alpha1.Hardware
+	for _, hw := range append(unprovisioned, provisioned...) {
+		if _, ok := p.deprovisionHardware[hw.Name]; !ok {
+			continue
+		}
+		if owner, ok := hw.Labels[hardwareOwnerNameLabel]; ok && owner != deprovisioningOwner {
+			continue
+		}
+		released = append(released, hw)
+	}
+	sort.Slice(released, func(i, j int) bool { return released[i].Name < released[j].Name })
+
+	if len(released) == 0 {
+		return nil
+	}
+
+	logger.Info("Wiping disks of hardware leaving the cluster", "hardware", len(released))
+	results := make([]error, len(released))
+	var wg sync.WaitGroup
+	for i, hw := range released {
+		wg.Add(1)
+		go func(i int, hw tinkv1alpha1.Hardware, policy v1alpha1.DeprovisionPolicy) {
+			defer wg.Done()
+			results[i] = p.deprovisioner.deprovision(ctx, cluster, hw, policy)
+		}(i, hw, p.deprovisionHardware[hw.Name])
+	}
+	wg.Wait()
+
+	var errs []error
+	for i, hw := range released {
+		if results[i] != nil {
+			errs = append(errs, fmt.Errorf("deprovisioning hardware %s: %v", hw.Name, results[i]))
+			continue
+		}
+		delete(p.deprovisionHardware, hw.Name)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// deprovision owns the held hardware, PXE boots it to run a workflow wiping its disks, powers it
+// off and returns it to the pool. The hardware stays owned and held if any step fails.
+func (d *deprovisioner) deprovision(ctx context.Context, cluster *types.Cluster, hw tinkv1alpha1.Hardware, policy v1alpha1.DeprovisionPolicy) error {
+	// CAPT disables netboot once the hardware is provisioned, the wipe workflow needs it back.
+	err := d.patchHardware(ctx, cluster.KubeconfigFile, hw.Name, hw.Namespace, func(current tinkv1alpha1.Hardware) hardwarePatch {
+		patch := hardwarePatch{}
+		patch.Metadata.Labels = map[string]*string{
+			hardwareOwnerNameLabel:      labelValue(deprovisioningOwner),
+			hardwareOwnerNamespaceLabel: labelValue(current.Namespace),
+		}
+		patch.Spec = &hardwarePatchSpec{Interfaces: allowNetboot(current.Spec.Interfaces)}
+		return patch
+	})
+	if err != nil {
+		return fmt.Errorf("owning hardware: %v", err)
+	}
+
+	name := wipeWorkflowName(hw)
+	template, err := newWipeTemplate(name, hw, policy)
+	if err != nil {
+		return err
+	}
+	if err := d.kubectl.Apply(ctx, cluster.KubeconfigFile, template); err != nil {
+		return fmt.Errorf("applying wipe template: %v", err)
+	}
+
+	if err := d.recreateWorkflow(ctx, cluster, newWipeWorkflow(name, hw)); err != nil {
+		return err
+	}
+
+	pxeBoot := newBMCJob(name+"-pxe-boot", hw,
+		rufiov1alpha1.Task{PowerAction: powerAction(rufiov1alpha1.HardPowerOff)},
+		rufiov1alpha1.Task{OneTimeBootDeviceAction: &rufiov1alpha1.OneTimeBootDeviceAction{
+			Devices: []rufiov1alpha1.BootDevice{rufiov1alpha1.PXE},
+			EFIBoot: hw.Spec.Interfaces[0].DHCP.UEFI,
+		}},
+		rufiov1alpha1.Task{PowerAction: powerAction(rufiov1alpha1.PowerOn)},
+	)
+	if err := d.runBMCJob(ctx, cluster, pxeBoot); err != nil {
+		return err
+	}
+
+	if err := d.waitForWorkflow(ctx, cluster, name, hw.Namespace); err != nil {
+		return err
+	}
+
+	powerOff := newBMCJob(name+"-power-off", hw, rufiov1alpha1.Task{PowerAction: powerAction(rufiov1alpha1.HardPowerOff)})
+	if err := d.runBMCJob(ctx, cluster, powerOff); err != nil {
+		return err
+	}
+
+	err = d.patchHardware(ctx, cluster.KubeconfigFile, hw.Name, hw.Namespace, func(tinkv1alpha1.Hardware) hardwarePatch {
+		patch := hardwarePatch{}
+		patch.Metadata.Labels = map[string]*string{
+			hardwareOwnerNameLabel:      nil,
+			hardwareOwnerNamespaceLabel: nil,
+			deprovisionHoldLabel:        nil,
+		}
+		return patch
+	})
+	if err != nil {
+		return fmt.Errorf("releasing hardware: %v", err)
+	}
+
+	return nil
+}
+
+// hardwarePatch is a JSON merge patch of a Hardware. Labels set to nil are removed.
+type hardwarePatch struct {
+	Metadata struct {
+		ResourceVersion string             `json:"resourceVersion"`
+		Labels          map[string]*string `json:"labels,omitempty"`
+	} `json:"metadata"`
+	Spec *hardwarePatchSpec `json:"spec,omitempty"`
+}
+
+type hardwarePatchSpec struct {
+	Interfaces []tinkv1alpha1.Interface `json:"interfaces"`
+}
+
+func labelValue(value string) *string {
+	return &value
+}
+
+// hold sets the hold label of the hardware to the cluster name.
+func (d *deprovisioner) hold(ctx context.Context, kubeconfig string, hw tinkv1alpha1.Hardware, clusterName string) error {
+	return d.patchHardware(ctx, kubeconfig, hw.Name, hw.Namespace, func(tinkv1alpha1.Hardware) hardwarePatch {
+		patch := hardwarePatch{}
+		patch.Metadata.Labels = map[string]*string{deprovisionHoldLabel: labelValue(clusterName)}
+		return patch
+	})
+}
+
+// patchHardware reads the hardware and patches it with the patch built from it. The patch is
+// conditioned on the version read so it fails instead of overwriting changes made in between, for
+// example by CAPT.
+func (d *deprovisioner) patchHardware(ctx context.Context, kubeconfig, name, namespace string, build func(tinkv1alpha1.Hardware) hardwarePatch) error {
+	current, err := d.kubectl.GetTinkerbellHardware(ctx, name, namespace, kubeconfig)
+	if err != nil {
+		return fmt.Errorf("getting hardware: %v", err)
+	}
+
+	patch := build(*current)
+	patch.Metadata.ResourceVersion = current.ResourceVersion
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("marshalling hardware patch: %v", err)
+	}
+
+	if _, err := d.kubectl.PatchTinkerbellHardware(ctx, name, namespace, kubeconfig, data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// allowNetboot returns a copy of interfaces allowing PXE boot and workflows on all of them.
+func allowNetboot(interfaces []tinkv1alpha1.Interface) []tinkv1alpha1.Interface {
+	allowed := make([]tinkv1alpha1.Interface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		iface := *iface.DeepCopy()
+		if iface.Netboot == nil {
+			iface.Netboot = &tinkv1alpha1.Netboot{}
+		}
+		allow := true
+		iface.Netboot.AllowPXE = &allow
+		iface.Netboot.AllowWorkflow = &allow
+		allowed = append(allowed, iface)
+	}
+	return allowed
+}
+
+// recreateWorkflow deletes the workflow left by a previous attempt, which tink doesn't run again,
+// before applying the new one.
+func (d *deprovisioner) recreateWorkflow(ctx context.Context, cluster *types.Cluster, workflow *tinkv1alpha1.Workflow) error {
+	_, err := d.kubectl.GetTinkerbellWorkflow(ctx, workflow.Name, workflow.Namespace, cluster.KubeconfigFile)
+	switch {
+	case err == nil:
+		if err := d.kubectl.Delete(ctx, executables.TinkerbellWorkflowResourceType, workflow.Name, workflow.Namespace, cluster.KubeconfigFile); err != nil {
+			return fmt.Errorf("deleting previous wipe workflow: %v", err)
+		}
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf("getting previous wipe workflow: %v", err)
+	}
+
+	if err := d.kubectl.Apply(ctx, cluster.KubeconfigFile, workflow); err != nil {
+		return fmt.Errorf("applying wipe workflow: %v", err)
+	}
+
+	return nil
+}
+
+func (d *deprovisioner) waitForWorkflow(ctx context.Context, cluster *types.Cluster, name, namespace string) error {
+	err := d.workflowRetrier.Retry(func() error {
+		workflow, err := d.kubectl.GetTinkerbellWorkflow(ctx, name, namespace, cluster.KubeconfigFile)
+		if err != nil {
+			return err
+		}
+
+		switch workflow.Status.State {
+		case tinkv1alpha1.WorkflowStateSuccess:
+			return nil
+		case tinkv1alpha1.WorkflowStateFailed, tinkv1alpha1.WorkflowStateTimeout:
+			return fmt.Errorf("%w: wipe workflow %s finished with state %s", errDeprovisionFailed, name, workflow.Status.State)
+		default:
+			return fmt.Errorf("wipe workflow %s in state %s", name, workflow.Status.State)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for wipe workflow: %v", err)
+	}
+
+	return nil
+}
+
+// runBMCJob deletes the BMC job left by a previous attempt, applies the job and waits for it to complete.
+func (d *deprovisioner) runBMCJob(ctx context.Context, cluster *types.Cluster, job *rufiov1alpha1.BMCJob) error {
+	_, err := d.kubectl.GetRufioBMCJob(ctx, job.Name, job.Namespace, cluster.KubeconfigFile)
+	switch {
+	case err == nil:
+		if err := d.kubectl.Delete(ctx, executables.RufioBMCJobResourceType, job.Name, job.Namespace, cluster.KubeconfigFile); err != nil {
+			return fmt.Errorf("deleting previous bmc job %s: %v", job.Name, err)
+		}
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf("getting previous bmc job %s: %v", job.Name, err)
+	}
+
+	if err := d.kubectl.Apply(ctx, cluster.KubeconfigFile, job); err != nil {
+		return fmt.Errorf("applying bmc job %s: %v", job.Name, err)
+	}
+
+	err = d.bmcJobRetrier.Retry(func() error {
+		current, err := d.kubectl.GetRufioBMCJob(ctx, job.Name, job.Namespace, cluster.KubeconfigFile)
+		if err != nil {
+			return err
+		}
+
+		if current.HasCondition(rufiov1alpha1.JobFailed, rufiov1alpha1.ConditionTrue) {
+			return fmt.Errorf("%w: bmc job %s failed", errDeprovisionFailed, job.Name)
+		}
+		if !current.HasCondition(rufiov1alpha1.JobCompleted, rufiov1alpha1.ConditionTrue) {
+			return fmt.Errorf("bmc job %s not completed", job.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for bmc job: %v", err)
+	}
+
+	return nil
+}
+
+func wipeWorkflowName(hw tinkv1alpha1.Hardware) string {
+	return fmt.Sprintf("%s-wipe", hw.Name)
+}
+
+// wipeCommand returns the command wiping a disk with the given method.
+func wipeCommand(method v1alpha1.DiskWipeMethod, disk string) []string {
+	if method == v1alpha1.SecureEraseDiskWipe {
+		return []string{"blkdiscard", "--secure", disk}
+	}
+	return []string{"blkdiscard", "--zeroout", disk}
+}
+
+func newWipeTemplate(name string, hw tinkv1alpha1.Hardware, policy v1alpha1.DeprovisionPolicy) (*tinkv1alpha1.Template, error) {
+	actions := make([]tinkerbell.Action, 0, len(hw.Spec.Disks))
+	for i, disk := range hw.Spec.Disks {
+		actions = append(actions, tinkerbell.Action{
+			Name:    fmt.Sprintf("wipe-disk-%d", i),
+			Image:   policy.WipeImage,
+			Timeout: diskWipeActionTimeout,
+			Command: wipeCommand(policy.WipeMethod, disk.Device),
+		})
+	}
+
+	data, err := yaml.Marshal(tinkerbell.Workflow{
+		Version:       "0.1",
+		Name:          name,
+		GlobalTimeout: diskWipeActionTimeout * len(actions),
+		Tasks: []tinkerbell.Task{{
+			Name:       name,
+			WorkerAddr: "{{.device_1}}",
+			Actions:    actions,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling wipe template: %v", err)
+	}
+	template := string(data)
+
+	return &tinkv1alpha1.Template{
+		TypeMeta: metav1.TypeMeta{APIVersion: tinkv1alpha1.GroupVersion.String(), Kind: "Template"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: hw.Namespace,
+		},
+		Spec: tinkv1alpha1.TemplateSpec{Data: &template},
+	}, nil
+}
+
+func newWipeWorkflow(name string, hw tinkv1alpha1.Hardware) *tinkv1alpha1.Workflow {
+	return &tinkv1alpha1.Workflow{
+		TypeMeta: metav1.TypeMeta{APIVersion: tinkv1alpha1.GroupVersion.String(), Kind: "Workflow"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: hw.Namespace,
+		},
+		Spec: tinkv1alpha1.WorkflowSpec{
+			TemplateRef: name,
+			HardwareRef: hw.Name,
+			HardwareMap: map[string]string{"device_1": hw.Spec.Interfaces[0].DHCP.MAC},
+		},
+	}
+}
+
+func newBMCJob(name string, hw tinkv1alpha1.Hardware, tasks ...rufiov1alpha1.Task) *rufiov1alpha1.BMCJob {
+	return &rufiov1alpha1.BMCJob{
+		TypeMeta: metav1.TypeMeta{APIVersion: rufiov1alpha1.GroupVersion.String(), Kind: "BMCJob"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: hw.Namespace,
+		},
+		Spec: rufiov1alpha1.BMCJobSpec{
+			BaseboardManagementRef: rufiov1alpha1.BaseboardManagementRef{
+				Name:      hw.Spec.BMCRef.Name,
+				Namespace: hw.Namespace,
+			},
+			Tasks: tasks,
+		},
+	}
+}
+
+func powerAction(action rufiov1alpha1.PowerAction) *rufiov1alpha1.PowerAction {
+	return &action
+}
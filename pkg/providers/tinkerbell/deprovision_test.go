@@ -0,0 +1,387 @@
+package tinkerbell
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	filewritermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
+	stackmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type deprovisionTest struct {
+	*WithT
+	ctx            context.Context
+	provider       *Provider
+	kubectl        *mocks.MockProviderKubectlClient
+	stackInstaller *stackmocks.MockStackInstaller
+	cluster        *types.Cluster
+	clusterSpec    *cluster.Spec
+	applied        []runtime.Object
+	patches        []hardwarePatch
+}
+
+func newDeprovisionTest(t *testing.T, policy *v1alpha1.DeprovisionPolicy) *deprovisionTest {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file: %v", err)
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = clusterConfig
+	})
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	machineConfigs["test-md"].Spec.DeprovisionPolicy = policy
+
+	provider := newProvider(
+		givenDatacenterConfig(t, clusterSpecManifest),
+		machineConfigs,
+		clusterSpec.Cluster,
+		filewritermocks.NewMockFileWriter(mockCtrl),
+		stackmocks.NewMockDocker(mockCtrl),
+		stackmocks.NewMockHelm(mockCtrl),
+		kubectl,
+		false,
+	)
+	provider.stackInstaller = stackInstaller
+	provider.deprovisioner.workflowRetrier = retrier.New(time.Minute, retrier.WithRetryPolicy(pollPolicy(time.Millisecond)))
+	provider.deprovisioner.bmcJobRetrier = retrier.New(time.Minute, retrier.WithRetryPolicy(pollPolicy(time.Millisecond)))
+
+	return &deprovisionTest{
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		provider:       provider,
+		kubectl:        kubectl,
+		stackInstaller: stackInstaller,
+		cluster:        &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"},
+		clusterSpec:    clusterSpec,
+	}
+}
+
+func (tt *deprovisionTest) expectApply(times int) {
+	tt.kubectl.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, obj runtime.Object) error {
+			tt.applied = append(tt.applied, obj)
+			return nil
+		},
+	).Times(times)
+}
+
+func (tt *deprovisionTest) expectCollect(owners []string, unprovisioned []tinkv1alpha1.Hardware, provisioned ...tinkv1alpha1.Hardware) {
+	tt.kubectl.EXPECT().GetTinkerbellMachineNames(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace, "test").Return(owners, nil)
+	tt.kubectl.EXPECT().GetProvisionedTinkerbellHardware(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(provisioned, nil)
+	tt.kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(unprovisioned, nil)
+}
+
+// expectPatch expects the hardware to be read and patched times, recording the patches.
+func (tt *deprovisionTest) expectPatch(hw tinkv1alpha1.Hardware, times int) {
+	hw.ResourceVersion = "10"
+	tt.kubectl.EXPECT().GetTinkerbellHardware(tt.ctx, hw.Name, hw.Namespace, tt.cluster.KubeconfigFile).Return(&hw, nil).Times(times)
+	tt.kubectl.EXPECT().PatchTinkerbellHardware(tt.ctx, hw.Name, hw.Namespace, tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _ string, data []byte) (*tinkv1alpha1.Hardware, error) {
+			patch := hardwarePatch{}
+			tt.Expect(json.Unmarshal(data, &patch)).To(Succeed())
+			tt.patches = append(tt.patches, patch)
+			return &hw, nil
+		},
+	).Times(times)
+}
+
+func (tt *deprovisionTest) expectReleased(unprovisioned, provisioned []tinkv1alpha1.Hardware) {
+	tt.kubectl.EXPECT().GetUnprovisionedTinkerbellHardware(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(unprovisioned, nil)
+	tt.kubectl.EXPECT().GetProvisionedTinkerbellHardware(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(provisioned, nil)
+}
+
+func (tt *deprovisionTest) expectBMCJob(name string, conditionType rufiov1alpha1.BMCJobConditionType) {
+	tt.kubectl.EXPECT().GetRufioBMCJob(tt.ctx, name, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(nil, notFound(name))
+	tt.kubectl.EXPECT().GetRufioBMCJob(tt.ctx, name, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(
+		&rufiov1alpha1.BMCJob{Status: rufiov1alpha1.BMCJobStatus{Conditions: []rufiov1alpha1.BMCJobCondition{
+			{Type: conditionType, Status: rufiov1alpha1.ConditionTrue},
+		}}}, nil,
+	)
+}
+
+func (tt *deprovisionTest) expectWorkflow(name string, states ...tinkv1alpha1.WorkflowState) {
+	tt.kubectl.EXPECT().GetTinkerbellWorkflow(tt.ctx, name, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(nil, notFound(name))
+	for _, state := range states {
+		tt.kubectl.EXPECT().GetTinkerbellWorkflow(tt.ctx, name, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(
+			&tinkv1alpha1.Workflow{Status: tinkv1alpha1.WorkflowStatus{State: state}}, nil,
+		)
+	}
+}
+
+func notFound(name string) error {
+	return apierrors.NewNotFound(schema.GroupResource{}, name)
+}
+
+func heldHardware(name, hardwareType, owner string) tinkv1alpha1.Hardware {
+	hw := deprovisionHardware(name, hardwareType, owner)
+	hw.Labels[deprovisionHoldLabel] = "test"
+	return hw
+}
+
+func deprovisionHardware(name, hardwareType, owner string) tinkv1alpha1.Hardware {
+	hw := tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{"type": hardwareType},
+		},
+		Spec: tinkv1alpha1.HardwareSpec{
+			BMCRef: &corev1.TypedLocalObjectReference{Name: "bmc-" + name, Kind: "BaseboardManagement"},
+			Disks:  []tinkv1alpha1.Disk{{Device: "/dev/sda"}, {Device: "/dev/nvme0n1"}},
+			Interfaces: []tinkv1alpha1.Interface{
+				{DHCP: &tinkv1alpha1.DHCP{MAC: "00:00:00:00:00:01", UEFI: true}},
+			},
+		},
+	}
+	if owner != "" {
+		hw.Labels[hardwareOwnerNameLabel] = owner
+		hw.Labels[hardwareOwnerNamespaceLabel] = constants.EksaSystemNamespace
+	}
+	return hw
+}
+
+func TestDeprovisionWithoutPolicyIsNoop(t *testing.T) {
+	tt := newDeprovisionTest(t, nil)
+	tt.stackInstaller.EXPECT().UninstallLocal(tt.ctx)
+
+	tt.Expect(tt.provider.PreMachinesRemoval(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+	tt.Expect(tt.provider.PostClusterDeleteValidate(tt.ctx, tt.cluster)).To(Succeed())
+}
+
+func TestSetupAndValidateDeleteClusterDoesNotHoldHardware(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+
+	tt.Expect(tt.provider.SetupAndValidateDeleteCluster(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+	tt.Expect(tt.provider.deprovisionHardware).To(BeEmpty())
+}
+
+func TestDeleteClusterWipesAndReleasesHardware(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	tt.expectCollect(
+		[]string{"test-md-abc"},
+		[]tinkv1alpha1.Hardware{deprovisionHardware("hw4", "worker", "")},
+		deprovisionHardware("hw1", "worker", "test-md-abc"),
+		deprovisionHardware("hw2", "worker", "other-md-abc"),
+		deprovisionHardware("hw3", "cp", "test-md-abc"),
+	)
+	tt.expectPatch(deprovisionHardware("hw1", "worker", "test-md-abc"), 1)
+	tt.Expect(tt.provider.PreMachinesRemoval(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+	tt.Expect(tt.provider.deprovisionHardware).To(HaveLen(1))
+
+	hold := tt.patches[0]
+	tt.Expect(hold.Metadata.ResourceVersion).To(Equal("10"))
+	tt.Expect(hold.Metadata.Labels).To(HaveKeyWithValue(deprovisionHoldLabel, Equal(labelValue("test"))))
+	tt.Expect(hold.Spec).To(BeNil())
+
+	tt.expectReleased([]tinkv1alpha1.Hardware{heldHardware("hw1", "worker", ""), deprovisionHardware("hw2", "worker", "")}, nil)
+	tt.expectPatch(heldHardware("hw1", "worker", ""), 2)
+	tt.expectApply(4)
+	tt.expectWorkflow("hw1-wipe", tinkv1alpha1.WorkflowStateRunning, tinkv1alpha1.WorkflowStateSuccess)
+	tt.expectBMCJob("hw1-wipe-pxe-boot", rufiov1alpha1.JobCompleted)
+	tt.expectBMCJob("hw1-wipe-power-off", rufiov1alpha1.JobCompleted)
+	tt.stackInstaller.EXPECT().UninstallLocal(tt.ctx)
+
+	tt.Expect(tt.provider.PostClusterDeleteValidate(tt.ctx, tt.cluster)).To(Succeed())
+	tt.Expect(tt.provider.deprovisionHardware).To(BeEmpty())
+
+	own := tt.patches[1]
+	tt.Expect(own.Metadata.ResourceVersion).To(Equal("10"))
+	tt.Expect(own.Metadata.Labels).To(HaveKeyWithValue(hardwareOwnerNameLabel, Equal(labelValue(deprovisioningOwner))))
+	tt.Expect(own.Metadata.Labels).To(HaveKeyWithValue(hardwareOwnerNamespaceLabel, Equal(labelValue(constants.EksaSystemNamespace))))
+	tt.Expect(own.Spec.Interfaces).To(HaveLen(1))
+	tt.Expect(own.Spec.Interfaces[0].DHCP.MAC).To(Equal("00:00:00:00:00:01"))
+	tt.Expect(*own.Spec.Interfaces[0].Netboot.AllowPXE).To(BeTrue())
+	tt.Expect(*own.Spec.Interfaces[0].Netboot.AllowWorkflow).To(BeTrue())
+
+	template := tt.applied[0].(*tinkv1alpha1.Template)
+	workflow := &tinkerbell.Workflow{}
+	tt.Expect(yaml.Unmarshal([]byte(*template.Spec.Data), workflow)).To(Succeed())
+	tt.Expect(workflow.Tasks).To(HaveLen(1))
+	tt.Expect(workflow.Tasks[0].WorkerAddr).To(Equal("{{.device_1}}"))
+	tt.Expect(workflow.Tasks[0].Actions).To(HaveLen(2))
+	tt.Expect(workflow.Tasks[0].Actions[0].Image).To(Equal("wipe:latest"))
+	tt.Expect(workflow.Tasks[0].Actions[0].Command).To(Equal([]string{"blkdiscard", "--zeroout", "/dev/sda"}))
+	tt.Expect(workflow.Tasks[0].Actions[1].Command).To(Equal([]string{"blkdiscard", "--zeroout", "/dev/nvme0n1"}))
+
+	wf := tt.applied[1].(*tinkv1alpha1.Workflow)
+	tt.Expect(wf.Spec.TemplateRef).To(Equal("hw1-wipe"))
+	tt.Expect(wf.Spec.HardwareRef).To(Equal("hw1"))
+	tt.Expect(wf.Spec.HardwareMap).To(Equal(map[string]string{"device_1": "00:00:00:00:00:01"}))
+
+	pxeBoot := tt.applied[2].(*rufiov1alpha1.BMCJob)
+	tt.Expect(pxeBoot.Spec.BaseboardManagementRef.Name).To(Equal("bmc-hw1"))
+	tt.Expect(pxeBoot.Spec.Tasks).To(HaveLen(3))
+	tt.Expect(pxeBoot.Spec.Tasks[1].OneTimeBootDeviceAction.Devices).To(Equal([]rufiov1alpha1.BootDevice{rufiov1alpha1.PXE}))
+	tt.Expect(pxeBoot.Spec.Tasks[1].OneTimeBootDeviceAction.EFIBoot).To(BeTrue())
+
+	powerOff := tt.applied[3].(*rufiov1alpha1.BMCJob)
+	tt.Expect(*powerOff.Spec.Tasks[0].PowerAction).To(Equal(rufiov1alpha1.HardPowerOff))
+
+	release := tt.patches[2]
+	tt.Expect(release.Metadata.ResourceVersion).To(Equal("10"))
+	tt.Expect(release.Metadata.Labels).To(HaveKeyWithValue(hardwareOwnerNameLabel, BeNil()))
+	tt.Expect(release.Metadata.Labels).To(HaveKeyWithValue(hardwareOwnerNamespaceLabel, BeNil()))
+	tt.Expect(release.Metadata.Labels).To(HaveKeyWithValue(deprovisionHoldLabel, BeNil()))
+	tt.Expect(release.Spec).To(BeNil())
+}
+
+func TestDeleteClusterWipesHardwareReleasedByController(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	tt.expectCollect(nil, []tinkv1alpha1.Hardware{heldHardware("hw1", "worker", ""), deprovisionHardware("hw2", "worker", "")})
+	tt.Expect(tt.provider.PreMachinesRemoval(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+	tt.Expect(tt.provider.deprovisionHardware).To(HaveLen(1))
+	tt.Expect(tt.provider.deprovisionHardware).To(HaveKey("hw1"))
+
+	tt.expectReleased([]tinkv1alpha1.Hardware{heldHardware("hw1", "worker", "")}, nil)
+	tt.expectPatch(heldHardware("hw1", "worker", ""), 2)
+	tt.expectApply(4)
+	tt.expectWorkflow("hw1-wipe", tinkv1alpha1.WorkflowStateSuccess)
+	tt.expectBMCJob("hw1-wipe-pxe-boot", rufiov1alpha1.JobCompleted)
+	tt.expectBMCJob("hw1-wipe-power-off", rufiov1alpha1.JobCompleted)
+	tt.stackInstaller.EXPECT().UninstallLocal(tt.ctx)
+
+	tt.Expect(tt.provider.PostClusterDeleteValidate(tt.ctx, tt.cluster)).To(Succeed())
+	tt.Expect(tt.provider.deprovisionHardware).To(BeEmpty())
+}
+
+func TestPreMachinesRemovalHoldError(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	hw := deprovisionHardware("hw1", "worker", "test-md-abc")
+	tt.expectCollect([]string{"test-md-abc"}, nil, hw)
+	tt.kubectl.EXPECT().GetTinkerbellHardware(tt.ctx, "hw1", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(&hw, nil)
+	tt.kubectl.EXPECT().PatchTinkerbellHardware(tt.ctx, "hw1", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile, gomock.Any()).Return(nil, errors.New("conflict"))
+
+	tt.Expect(tt.provider.PreMachinesRemoval(tt.ctx, tt.clusterSpec, tt.cluster)).To(
+		MatchError(ContainSubstring("holding hardware hw1: conflict")),
+	)
+}
+
+func TestDeleteClusterKeepsHardwareHeldWhenWipeFails(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.SecureEraseDiskWipe, WipeImage: "wipe:latest"})
+	tt.expectCollect([]string{"test-md-abc"}, nil, heldHardware("hw1", "worker", "test-md-abc"))
+	tt.Expect(tt.provider.PreMachinesRemoval(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+
+	tt.expectReleased([]tinkv1alpha1.Hardware{heldHardware("hw1", "worker", "")}, nil)
+	tt.expectPatch(heldHardware("hw1", "worker", ""), 1)
+	tt.expectApply(3)
+	tt.expectWorkflow("hw1-wipe", tinkv1alpha1.WorkflowStateFailed)
+	tt.expectBMCJob("hw1-wipe-pxe-boot", rufiov1alpha1.JobCompleted)
+
+	tt.Expect(tt.provider.PostClusterDeleteValidate(tt.ctx, tt.cluster)).To(
+		MatchError(ContainSubstring("deprovisioning hardware hw1: waiting for wipe workflow")),
+	)
+	tt.Expect(tt.provider.deprovisionHardware).To(HaveKey("hw1"))
+
+	template := tt.applied[0].(*tinkv1alpha1.Template)
+	tt.Expect(*template.Spec.Data).To(ContainSubstring("--secure"))
+	tt.Expect(tt.patches).To(HaveLen(1))
+	tt.Expect(tt.patches[0].Metadata.Labels).To(HaveKeyWithValue(hardwareOwnerNameLabel, Equal(labelValue(deprovisioningOwner))))
+	tt.Expect(tt.patches[0].Metadata.Labels).ToNot(HaveKey(deprovisionHoldLabel))
+}
+
+func TestDeleteClusterRetriesHardwareHeldByPreviousAttempt(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	tt.provider.deprovisionHardware = map[string]v1alpha1.DeprovisionPolicy{
+		"hw1": {WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"},
+	}
+
+	tt.expectReleased(nil, []tinkv1alpha1.Hardware{heldHardware("hw1", "worker", deprovisioningOwner)})
+	tt.expectPatch(heldHardware("hw1", "worker", deprovisioningOwner), 2)
+	tt.expectApply(4)
+	tt.kubectl.EXPECT().GetTinkerbellWorkflow(tt.ctx, "hw1-wipe", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(&tinkv1alpha1.Workflow{}, nil)
+	tt.kubectl.EXPECT().Delete(tt.ctx, "workflows.tinkerbell.org", "hw1-wipe", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile)
+	tt.kubectl.EXPECT().GetTinkerbellWorkflow(tt.ctx, "hw1-wipe", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(
+		&tinkv1alpha1.Workflow{Status: tinkv1alpha1.WorkflowStatus{State: tinkv1alpha1.WorkflowStateSuccess}}, nil,
+	)
+	tt.expectBMCJob("hw1-wipe-pxe-boot", rufiov1alpha1.JobCompleted)
+	tt.expectBMCJob("hw1-wipe-power-off", rufiov1alpha1.JobCompleted)
+	tt.stackInstaller.EXPECT().UninstallLocal(tt.ctx)
+
+	tt.Expect(tt.provider.PostClusterDeleteValidate(tt.ctx, tt.cluster)).To(Succeed())
+	tt.Expect(tt.provider.deprovisionHardware).To(BeEmpty())
+}
+
+func TestDeprovisionBMCJobFails(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	tt.provider.deprovisionHardware = map[string]v1alpha1.DeprovisionPolicy{
+		"hw1": {WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"},
+	}
+
+	tt.expectReleased([]tinkv1alpha1.Hardware{heldHardware("hw1", "worker", "")}, nil)
+	tt.expectPatch(heldHardware("hw1", "worker", ""), 1)
+	tt.expectApply(3)
+	tt.expectWorkflow("hw1-wipe")
+	tt.expectBMCJob("hw1-wipe-pxe-boot", rufiov1alpha1.JobFailed)
+
+	tt.Expect(tt.provider.PostWorkerNodesReady(tt.ctx, tt.clusterSpec, tt.cluster)).To(
+		MatchError(ContainSubstring("bmc job hw1-wipe-pxe-boot failed")),
+	)
+}
+
+func TestUpgradeSkipsHardwareStillProvisioned(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	tt.provider.deprovisionHardware = map[string]v1alpha1.DeprovisionPolicy{
+		"hw1": {WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"},
+	}
+
+	tt.expectReleased(nil, []tinkv1alpha1.Hardware{heldHardware("hw1", "worker", "test-md-def")})
+	tt.expectCollect(
+		[]string{"test-md-def", "test-md-ghi"},
+		nil,
+		heldHardware("hw1", "worker", "test-md-def"),
+		deprovisionHardware("hw2", "worker", "test-md-ghi"),
+	)
+	tt.expectPatch(deprovisionHardware("hw2", "worker", "test-md-ghi"), 1)
+
+	tt.Expect(tt.provider.PostWorkerNodesReady(tt.ctx, tt.clusterSpec, tt.cluster)).To(Succeed())
+	tt.Expect(tt.provider.deprovisionHardware).To(HaveLen(2))
+	tt.Expect(tt.provider.deprovisionHardware).To(HaveKey("hw1"))
+	tt.Expect(tt.provider.deprovisionHardware).To(HaveKey("hw2"))
+	tt.Expect(tt.patches[0].Metadata.Labels).To(HaveKeyWithValue(deprovisionHoldLabel, Equal(labelValue("test"))))
+}
+
+func TestPreMachinesRemovalHardwareWithoutBMC(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	hw := deprovisionHardware("hw1", "worker", "test-md-abc")
+	hw.Spec.BMCRef = nil
+	tt.expectCollect([]string{"test-md-abc"}, nil, hw)
+
+	tt.Expect(tt.provider.PreMachinesRemoval(tt.ctx, tt.clusterSpec, tt.cluster)).To(
+		MatchError(ContainSubstring("hardware hw1 has a deprovision policy but no BMC")),
+	)
+}
+
+func TestPreMachinesRemovalGetMachinesError(t *testing.T) {
+	tt := newDeprovisionTest(t, &v1alpha1.DeprovisionPolicy{WipeMethod: v1alpha1.ZeroDiskWipe, WipeImage: "wipe:latest"})
+	tt.kubectl.EXPECT().GetTinkerbellMachineNames(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace, "test").Return(nil, errors.New("error"))
+
+	tt.Expect(tt.provider.PreMachinesRemoval(tt.ctx, tt.clusterSpec, tt.cluster)).ToNot(Succeed())
+}
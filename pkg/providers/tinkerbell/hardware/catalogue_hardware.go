@@ -3,6 +3,7 @@ package hardware
 import (
 	"fmt"
 	"math"
+	"net"
 
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -252,17 +253,20 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 	// the hardware.
 	allow := true
 
+	network := m.Network()
+
 	// TODO(chrisdoherty4) Set the namespace to the CAPT namespace.
 	return &tinkv1alpha1.Hardware{
 		TypeMeta: newHardwareTypeMeta(),
 		ObjectMeta: v1.ObjectMeta{
-			Name:      m.Hostname,
-			Namespace: constants.EksaSystemNamespace,
-			Labels:    m.Labels,
+			Name:        m.Hostname,
+			Namespace:   constants.EksaSystemNamespace,
+			Labels:      m.Labels,
+			Annotations: hardwareAnnotations(networkAnnotations(network), disksAnnotations(m.Disks)),
 		},
 		Spec: tinkv1alpha1.HardwareSpec{
 			BMCRef: newBMCRefFromMachine(m),
-			Disks:  []tinkv1alpha1.Disk{{Device: m.Disk}},
+			Disks:  hardwareDisks(m),
 			Metadata: &tinkv1alpha1.HardwareMetadata{
 				Facility: &tinkv1alpha1.MetadataFacility{
 					FacilityCode: "onprem",
@@ -271,7 +275,7 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 				Instance: &tinkv1alpha1.MetadataInstance{
 					ID:       m.MACAddress,
 					Hostname: m.Hostname,
-					Ips: []*tinkv1alpha1.MetadataInstanceIP{
+					Ips: append([]*tinkv1alpha1.MetadataInstanceIP{
 						{
 							Address: m.IPAddress,
 							Netmask: m.Netmask,
@@ -279,7 +283,7 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 							Family:  4,
 							Public:  true,
 						},
-					},
+					}, vlanInstanceIPs(network)...),
 					// TODO(chrisdoherty4) Fix upstream. The OperatingSystem is used in boots to
 					// detect what iPXE scripts should be served. The Kubernetes back-end nilifies
 					// its response to retrieving the OS data and the handling code doesn't check
@@ -290,9 +294,10 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 					AllowPxe:        true,
 					AlwaysPxe:       true,
 				},
-				State: "provisioning",
+				State:       "provisioning",
+				BondingMode: network.bondingMode(),
 			},
-			Interfaces: []tinkv1alpha1.Interface{
+			Interfaces: append([]tinkv1alpha1.Interface{
 				{
 					Netboot: &tinkv1alpha1.Netboot{
 						AllowPXE:      &allow,
@@ -315,13 +320,83 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 						NameServers: m.Nameservers,
 						UEFI:        true,
 						VLANID:      m.VLANID,
+						IfaceName:   pxeInterfaceName(network, m.MACAddress),
 					},
 				},
-			},
+			}, secondaryInterfaces(network, m.MACAddress)...),
 		},
 	}
 }
 
+// hardwareAnnotations merges annotations returning nil when there are none.
+func hardwareAnnotations(annotations ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, a := range annotations {
+		for k, v := range a {
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// pxeInterfaceName returns the name of the interface with the PXE MAC address in network, if any.
+func pxeInterfaceName(network Network, mac string) string {
+	for _, i := range network.Interfaces {
+		if i.MAC == mac {
+			return i.Name
+		}
+	}
+	return ""
+}
+
+// secondaryInterfaces returns the Hardware interfaces of network other than the PXE interface.
+// They can't be used to netboot so the machine only PXE boots from a single interface.
+func secondaryInterfaces(network Network, pxeMAC string) []tinkv1alpha1.Interface {
+	var interfaces []tinkv1alpha1.Interface
+	for _, i := range network.Interfaces {
+		if i.MAC == pxeMAC {
+			continue
+		}
+
+		// deny is necessary to allocate memory so we can get a bool pointer required by
+		// the hardware.
+		deny := false
+		interfaces = append(interfaces, tinkv1alpha1.Interface{
+			Netboot: &tinkv1alpha1.Netboot{
+				AllowPXE:      &deny,
+				AllowWorkflow: &deny,
+			},
+			DHCP: &tinkv1alpha1.DHCP{
+				Arch:      "x86_64",
+				MAC:       i.MAC,
+				IfaceName: i.Name,
+				UEFI:      true,
+			},
+		})
+	}
+	return interfaces
+}
+
+// vlanInstanceIPs returns the metadata IPs of the VLANs of network with a static address.
+func vlanInstanceIPs(network Network) []*tinkv1alpha1.MetadataInstanceIP {
+	var ips []*tinkv1alpha1.MetadataInstanceIP
+	for _, v := range network.VLANs {
+		ip, ipNet, err := net.ParseCIDR(v.Address)
+		if err != nil {
+			continue
+		}
+		ips = append(ips, &tinkv1alpha1.MetadataInstanceIP{
+			Address: ip.String(),
+			Netmask: net.IP(ipNet.Mask).String(),
+			Family:  4,
+		})
+	}
+	return ips
+}
+
 // newBMCRefFromMachine returns a BMCRef pointer for Hardware.
 func newBMCRefFromMachine(m Machine) *corev1.TypedLocalObjectReference {
 	if m.HasBMC() {
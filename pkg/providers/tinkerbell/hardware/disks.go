@@ -0,0 +1,366 @@
+package hardware
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// DisksAnnotation is the Hardware annotation holding the JSON serialized Disks of a machine.
+const DisksAnnotation = "anywhere.eks.amazonaws.com/disks"
+
+// DefaultFSType is the file system additional disks are formatted with when they don't specify one.
+const DefaultFSType = "ext4"
+
+// Disk describes a disk of a machine and the attributes it can be selected by.
+type Disk struct {
+	Device     string             `json:"device"`
+	Size       *resource.Quantity `json:"size,omitempty"`
+	Model      string             `json:"model,omitempty"`
+	Rotational bool               `json:"rotational,omitempty"`
+	// ID is the /dev/disk/by-id path of the disk.
+	ID string `json:"id,omitempty"`
+}
+
+// DisksSeparator separates the disks of Disks.
+const DisksSeparator = "|"
+
+// DiskAttributesSeparator separates the device and attributes of a disk.
+const DiskAttributesSeparator = ";"
+
+// Disks is a custom type that can unmarshal a CSV representation of disks formatted as a device
+// followed by key=value attributes, e.g. "/dev/sda;size=480Gi;model=SAMSUNG;rotational=false|/dev/sdb;size=4Ti".
+// The supported attributes are size, model, rotational and id.
+type Disks []Disk
+
+// UnmarshalCSV unmarshalls s where s is a list of disks separated by DisksSeparator.
+func (d *Disks) UnmarshalCSV(s string) error {
+	for _, entry := range strings.Split(s, DisksSeparator) {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		fields := strings.Split(entry, DiskAttributesSeparator)
+		disk := Disk{Device: strings.TrimSpace(fields[0])}
+		for _, field := range fields[1:] {
+			key, value, ok := cutPair(field, "=")
+			if !ok {
+				return fmt.Errorf("badly formatted disk attribute, expected key=value: %v", field)
+			}
+
+			switch key {
+			case "size":
+				size, err := resource.ParseQuantity(value)
+				if err != nil {
+					return fmt.Errorf("disk %v: size: %v", disk.Device, err)
+				}
+				disk.Size = &size
+			case "model":
+				disk.Model = value
+			case "rotational":
+				rotational, err := strconv.ParseBool(value)
+				if err != nil {
+					return fmt.Errorf("disk %v: rotational: %v", disk.Device, err)
+				}
+				disk.Rotational = rotational
+			case "id":
+				disk.ID = value
+			default:
+				return fmt.Errorf("disk %v: unknown attribute %v", disk.Device, key)
+			}
+		}
+		*d = append(*d, disk)
+	}
+	return nil
+}
+
+// MarshalCSV marshalls Disks into a list of disks separated by DisksSeparator.
+func (d *Disks) MarshalCSV() (string, error) {
+	entries := make([]string, 0, len(*d))
+	for _, disk := range *d {
+		fields := []string{disk.Device}
+		if disk.Size != nil {
+			fields = append(fields, "size="+disk.Size.String())
+		}
+		if disk.Model != "" {
+			fields = append(fields, "model="+disk.Model)
+		}
+		fields = append(fields, "rotational="+strconv.FormatBool(disk.Rotational))
+		if disk.ID != "" {
+			fields = append(fields, "id="+disk.ID)
+		}
+		entries = append(entries, strings.Join(fields, DiskAttributesSeparator))
+	}
+	return strings.Join(entries, DisksSeparator), nil
+}
+
+// validateDisks ensures the disks of m are valid Linux devices.
+func validateDisks(m Machine) error {
+	devices := map[string]bool{}
+	for _, d := range m.Disks {
+		if !linuxPathValidation.MatchString(d.Device) {
+			return fmt.Errorf("disks: device must be a valid linux path (\"%v\"): %v", linuxPathRegex, d.Device)
+		}
+		if devices[d.Device] {
+			return fmt.Errorf("disks: duplicate device %v", d.Device)
+		}
+		devices[d.Device] = true
+		if d.ID != "" && !strings.HasPrefix(d.ID, "/dev/disk/by-id/") {
+			return fmt.Errorf("disks: %v: id must be a /dev/disk/by-id path", d.Device)
+		}
+	}
+	return nil
+}
+
+func disksAnnotations(disks Disks) map[string]string {
+	if len(disks) == 0 {
+		return nil
+	}
+	// Disk only contains strings, bools and quantities so it can't fail to marshal.
+	raw, _ := json.Marshal(disks)
+	return map[string]string{DisksAnnotation: string(raw)}
+}
+
+// hardwareDisks returns the disks of the Hardware of m, the Disk of m first.
+func hardwareDisks(m Machine) []tinkv1alpha1.Disk {
+	disks := []tinkv1alpha1.Disk{}
+	if m.Disk != "" {
+		disks = append(disks, tinkv1alpha1.Disk{Device: m.Disk})
+	}
+	for _, d := range m.Disks {
+		if d.Device != m.Disk {
+			disks = append(disks, tinkv1alpha1.Disk{Device: d.Device})
+		}
+	}
+	return disks
+}
+
+// DisksFromHardware returns the Disks described by the DisksAnnotation of hardware. It returns no
+// Disks if hardware doesn't have the annotation.
+func DisksFromHardware(hardware *tinkv1alpha1.Hardware) (Disks, error) {
+	var disks Disks
+	raw, ok := hardware.Annotations[DisksAnnotation]
+	if !ok {
+		return disks, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &disks); err != nil {
+		return nil, fmt.Errorf("parsing %s annotation of hardware %s: %v", DisksAnnotation, hardware.Name, err)
+	}
+	return disks, nil
+}
+
+// DiskMatchesSelector returns true if disk has all the attributes of selector.
+func DiskMatchesSelector(disk Disk, selector eksav1alpha1.DiskSelector) bool {
+	if selector.MinSize != nil && (disk.Size == nil || disk.Size.Cmp(*selector.MinSize) < 0) {
+		return false
+	}
+	if selector.MaxSize != nil && (disk.Size == nil || disk.Size.Cmp(*selector.MaxSize) > 0) {
+		return false
+	}
+	if selector.Model != "" && !strings.Contains(disk.Model, selector.Model) {
+		return false
+	}
+	if selector.Rotational != nil && disk.Rotational != *selector.Rotational {
+		return false
+	}
+	if selector.ByID != "" {
+		if matched, _ := path.Match(selector.ByID, disk.ID); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// DiskLayout is the resolved disks of a machine.
+type DiskLayout struct {
+	// InstallDisk is the device the operating system is installed on.
+	InstallDisk string
+	// AdditionalDisks are the disks to format and mount.
+	AdditionalDisks []ResolvedAdditionalDisk
+}
+
+// ResolvedAdditionalDisk is an additional disk resolved to a device of a machine.
+type ResolvedAdditionalDisk struct {
+	Device    string
+	MountPath string
+	FSType    string
+}
+
+// ResolveDiskLayout selects the disks of hardware for installDisk and additional. Each selector
+// picks the first matching disk, in the order of the hardware disks, not already picked by a
+// previous selector. When installDisk is nil the first hardware disk is the install disk.
+// Additional disks are referred to by their /dev/disk/by-id path when known as it's stable across
+// reboots.
+func ResolveDiskLayout(hardware *tinkv1alpha1.Hardware, installDisk *eksav1alpha1.DiskSelector, additional []eksav1alpha1.AdditionalDisk) (DiskLayout, error) {
+	disks, err := DisksFromHardware(hardware)
+	if err != nil {
+		return DiskLayout{}, err
+	}
+
+	used := map[string]bool{}
+	pick := func(selector eksav1alpha1.DiskSelector) (Disk, bool) {
+		for _, d := range disks {
+			if !used[d.Device] && DiskMatchesSelector(d, selector) {
+				used[d.Device] = true
+				return d, true
+			}
+		}
+		return Disk{}, false
+	}
+
+	var layout DiskLayout
+	switch {
+	case installDisk != nil:
+		d, ok := pick(*installDisk)
+		if !ok {
+			return DiskLayout{}, fmt.Errorf("hardware %s: no disk matches the install disk selector", hardware.Name)
+		}
+		layout.InstallDisk = d.Device
+		if installDisk.ByID != "" {
+			layout.InstallDisk = d.ID
+		}
+	case len(hardware.Spec.Disks) > 0:
+		layout.InstallDisk = hardware.Spec.Disks[0].Device
+		used[layout.InstallDisk] = true
+	default:
+		return DiskLayout{}, fmt.Errorf("hardware %s: no install disk", hardware.Name)
+	}
+
+	for _, a := range additional {
+		d, ok := pick(a.Selector)
+		if !ok {
+			return DiskLayout{}, fmt.Errorf("hardware %s: no unused disk matches the selector of additional disk %s", hardware.Name, a.MountPath)
+		}
+		device := d.Device
+		if d.ID != "" {
+			device = d.ID
+		}
+		fsType := a.FSType
+		if fsType == "" {
+			fsType = DefaultFSType
+		}
+		layout.AdditionalDisks = append(layout.AdditionalDisks, ResolvedAdditionalDisk{
+			Device:    device,
+			MountPath: a.MountPath,
+			FSType:    fsType,
+		})
+	}
+
+	return layout, nil
+}
+
+// DiskLayoutExtractor caches the hardware matching registered hardware selectors so the disk layout
+// of each machine can be resolved when rendering the provisioning workflows.
+type DiskLayoutExtractor struct {
+	selectors map[string]eksav1alpha1.HardwareSelector
+	hardware  map[string][]*tinkv1alpha1.Hardware
+}
+
+// NewDiskLayoutExtractor creates a DiskLayoutExtractor instance.
+func NewDiskLayoutExtractor() *DiskLayoutExtractor {
+	return &DiskLayoutExtractor{
+		selectors: make(map[string]eksav1alpha1.HardwareSelector),
+		hardware:  make(map[string][]*tinkv1alpha1.Hardware),
+	}
+}
+
+// Register registers selector with e such that hardware can be cached when machines are written
+// to Write().
+func (e *DiskLayoutExtractor) Register(selector eksav1alpha1.HardwareSelector) error {
+	key, err := serializeHardwareSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	e.selectors[key] = selector
+	return nil
+}
+
+// Write caches m for every registered selector m matches.
+func (e *DiskLayoutExtractor) Write(m Machine) error {
+	return e.InsertHardware(hardwareFromMachine(m))
+}
+
+// InsertHardware caches hardware for every registered selector hardware matches.
+func (e *DiskLayoutExtractor) InsertHardware(hardware *tinkv1alpha1.Hardware) error {
+	for key, selector := range e.selectors {
+		if LabelsMatchSelector(selector, hardware.Labels) {
+			e.hardware[key] = append(e.hardware[key], hardware)
+		}
+	}
+	return nil
+}
+
+// GetDiskLayouts resolves the disk layout of every machine matching selector, indexed by the MAC
+// address of their PXE interface.
+func (e *DiskLayoutExtractor) GetDiskLayouts(selector eksav1alpha1.HardwareSelector, installDisk *eksav1alpha1.DiskSelector, additional []eksav1alpha1.AdditionalDisk) (map[string]DiskLayout, error) {
+	key, err := serializeHardwareSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(e.hardware[key]) == 0 {
+		return nil, fmt.Errorf("no hardware matches selector %v", key)
+	}
+
+	layouts := make(map[string]DiskLayout, len(e.hardware[key]))
+	for _, h := range e.hardware[key] {
+		if len(h.Spec.Interfaces) == 0 || h.Spec.Interfaces[0].DHCP == nil {
+			return nil, fmt.Errorf("hardware %s: missing PXE interface", h.Name)
+		}
+		layout, err := ResolveDiskLayout(h, installDisk, additional)
+		if err != nil {
+			return nil, err
+		}
+		layouts[h.Spec.Interfaces[0].DHCP.MAC] = layout
+	}
+
+	return layouts, nil
+}
+
+// PerMachineValue returns a Tinkerbell template rendering the value of values, indexed by MAC
+// address, of the machine running the workflow, or fallback for any other machine such as
+// machines added to the pool later. It returns the value itself when all machines and fallback
+// share the same value.
+func PerMachineValue(values map[string]string, fallback string) string {
+	macs := make([]string, 0, len(values))
+	distinct := map[string]bool{fallback: true}
+	multiline := strings.Contains(fallback, "\n")
+	for mac, value := range values {
+		macs = append(macs, mac)
+		distinct[value] = true
+		multiline = multiline || strings.Contains(value, "\n")
+	}
+	sort.Strings(macs)
+
+	if len(distinct) == 1 {
+		return fallback
+	}
+
+	// Multiline values are rendered as blocks trimming the template lines, single line values are
+	// rendered inline so they can be embedded in other values.
+	open, end := "{{ %s eq .device_1 `%s` }}%s", "{{ else }}%s{{ end }}"
+	if multiline {
+		open, end = "{{- %s eq .device_1 `%s` }}\n%s", "{{- else }}\n%s{{- end }}\n"
+	}
+
+	var b strings.Builder
+	for i, mac := range macs {
+		keyword := "if"
+		if i > 0 {
+			keyword = "else if"
+		}
+		fmt.Fprintf(&b, open, keyword, mac, values[mac])
+	}
+	fmt.Fprintf(&b, end, fallback)
+
+	return b.String()
+}
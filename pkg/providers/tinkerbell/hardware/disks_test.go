@@ -0,0 +1,194 @@
+package hardware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+const multiDiskMachineCSV = `hostname,mac,ip_address,netmask,gateway,nameservers,labels,disk,disks
+worker1,00:00:00:00:00:01,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=worker,,/dev/sda;size=4Ti;model=ST4000;rotational=true|/dev/nvme0n1;size=480Gi;model=SAMSUNG MZ7;id=/dev/disk/by-id/nvme-SAMSUNG_1|/dev/nvme1n1;size=1920Gi;id=/dev/disk/by-id/nvme-SAMSUNG_2
+`
+
+func quantity(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func newMultiDiskMachine() hardware.Machine {
+	return hardware.Machine{
+		Hostname:    "worker1",
+		IPAddress:   "10.10.10.10",
+		Netmask:     "255.255.255.0",
+		Gateway:     "10.10.10.1",
+		Nameservers: hardware.Nameservers{"1.1.1.1"},
+		MACAddress:  "00:00:00:00:00:01",
+		Labels:      hardware.Labels{"type": "worker"},
+		Disks: hardware.Disks{
+			{Device: "/dev/sda", Size: quantity("4Ti"), Model: "ST4000", Rotational: true},
+			{Device: "/dev/nvme0n1", Size: quantity("480Gi"), Model: "SAMSUNG MZ7", ID: "/dev/disk/by-id/nvme-SAMSUNG_1"},
+			{Device: "/dev/nvme1n1", Size: quantity("1920Gi"), ID: "/dev/disk/by-id/nvme-SAMSUNG_2"},
+		},
+	}
+}
+
+func TestCSVReaderReadsDisks(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	reader, err := hardware.NewCSVReader(strings.NewReader(multiDiskMachineCSV))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine.Disks).To(gomega.HaveLen(3))
+	for i, disk := range newMultiDiskMachine().Disks {
+		g.Expect(machine.Disks[i].Device).To(gomega.Equal(disk.Device))
+		g.Expect(machine.Disks[i].Size.Cmp(*disk.Size)).To(gomega.BeZero())
+		g.Expect(machine.Disks[i].Model).To(gomega.Equal(disk.Model))
+		g.Expect(machine.Disks[i].Rotational).To(gomega.Equal(disk.Rotational))
+		g.Expect(machine.Disks[i].ID).To(gomega.Equal(disk.ID))
+	}
+	g.Expect(hardware.StaticMachineAssertions()(machine)).To(gomega.Succeed())
+}
+
+func TestDisksUnmarshalCSVInvalid(t *testing.T) {
+	for name, value := range map[string]string{
+		"MissingValue":      "/dev/sda;size",
+		"InvalidSize":       "/dev/sda;size=big",
+		"InvalidRotational": "/dev/sda;rotational=maybe",
+		"UnknownAttribute":  "/dev/sda;speed=fast",
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var disks hardware.Disks
+			g.Expect(disks.UnmarshalCSV(value)).ToNot(gomega.Succeed())
+		})
+	}
+}
+
+func TestStaticMachineAssertionsInvalidDisks(t *testing.T) {
+	for name, mutate := range map[string]func(*hardware.Machine){
+		"NoDiskNorDisks": func(m *hardware.Machine) {
+			m.Disks = nil
+		},
+		"InvalidDevice": func(m *hardware.Machine) {
+			m.Disks[0].Device = "sda"
+		},
+		"DuplicateDevice": func(m *hardware.Machine) {
+			m.Disks[1].Device = m.Disks[0].Device
+		},
+		"IDNotByID": func(m *hardware.Machine) {
+			m.Disks[1].ID = "/dev/disk/by-uuid/1234"
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			machine := newMultiDiskMachine()
+			mutate(&machine)
+			g.Expect(hardware.StaticMachineAssertions()(machine)).ToNot(gomega.Succeed())
+		})
+	}
+}
+
+func TestResolveDiskLayout(t *testing.T) {
+	rotational := false
+	tests := []struct {
+		name        string
+		disk        string
+		installDisk *v1alpha1.DiskSelector
+		additional  []v1alpha1.AdditionalDisk
+		want        hardware.DiskLayout
+	}{
+		{
+			name: "NoSelectors",
+			disk: "/dev/sda",
+			want: hardware.DiskLayout{InstallDisk: "/dev/sda"},
+		},
+		{
+			name:        "MaxSize",
+			installDisk: &v1alpha1.DiskSelector{MaxSize: quantity("1Ti")},
+			want:        hardware.DiskLayout{InstallDisk: "/dev/nvme0n1"},
+		},
+		{
+			name:        "ModelAndRotational",
+			installDisk: &v1alpha1.DiskSelector{Model: "SAMSUNG", Rotational: &rotational},
+			want:        hardware.DiskLayout{InstallDisk: "/dev/nvme0n1"},
+		},
+		{
+			name:        "ByID",
+			installDisk: &v1alpha1.DiskSelector{ByID: "/dev/disk/by-id/nvme-*"},
+			want:        hardware.DiskLayout{InstallDisk: "/dev/disk/by-id/nvme-SAMSUNG_1"},
+		},
+		{
+			name:        "AdditionalDisks",
+			installDisk: &v1alpha1.DiskSelector{Rotational: &rotational},
+			additional: []v1alpha1.AdditionalDisk{
+				{Selector: v1alpha1.DiskSelector{Rotational: &rotational}, MountPath: "/var/lib/containerd"},
+				{Selector: v1alpha1.DiskSelector{MinSize: quantity("2Ti")}, MountPath: "/data", FSType: "xfs"},
+			},
+			want: hardware.DiskLayout{
+				InstallDisk: "/dev/nvme0n1",
+				AdditionalDisks: []hardware.ResolvedAdditionalDisk{
+					{Device: "/dev/disk/by-id/nvme-SAMSUNG_2", MountPath: "/var/lib/containerd", FSType: "ext4"},
+					{Device: "/dev/sda", MountPath: "/data", FSType: "xfs"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			machine := newMultiDiskMachine()
+			machine.Disk = tt.disk
+
+			extractor := hardware.NewDiskLayoutExtractor()
+			g.Expect(extractor.Register(v1alpha1.HardwareSelector{"type": "worker"})).To(gomega.Succeed())
+			g.Expect(extractor.Write(machine)).To(gomega.Succeed())
+
+			layouts, err := extractor.GetDiskLayouts(v1alpha1.HardwareSelector{"type": "worker"}, tt.installDisk, tt.additional)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(layouts).To(gomega.Equal(map[string]hardware.DiskLayout{"00:00:00:00:00:01": tt.want}))
+		})
+	}
+}
+
+func TestResolveDiskLayoutNoMatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	extractor := hardware.NewDiskLayoutExtractor()
+	g.Expect(extractor.Register(v1alpha1.HardwareSelector{"type": "worker"})).To(gomega.Succeed())
+	g.Expect(extractor.Write(newMultiDiskMachine())).To(gomega.Succeed())
+
+	_, err := extractor.GetDiskLayouts(v1alpha1.HardwareSelector{"type": "worker"}, &v1alpha1.DiskSelector{MinSize: quantity("8Ti")}, nil)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no disk matches the install disk selector")))
+
+	// The only disk larger than 2Ti is already the install disk.
+	_, err = extractor.GetDiskLayouts(
+		v1alpha1.HardwareSelector{"type": "worker"},
+		&v1alpha1.DiskSelector{MinSize: quantity("2Ti")},
+		[]v1alpha1.AdditionalDisk{{Selector: v1alpha1.DiskSelector{MinSize: quantity("2Ti")}, MountPath: "/data"}},
+	)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no unused disk matches")))
+
+	_, err = extractor.GetDiskLayouts(v1alpha1.HardwareSelector{"type": "control-plane"}, nil, nil)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestPerMachineValue(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(hardware.PerMachineValue(map[string]string{"00:00:00:00:00:01": "/dev/sda", "00:00:00:00:00:02": "/dev/sda"}, "/dev/sda")).
+		To(gomega.Equal("/dev/sda"))
+	g.Expect(hardware.PerMachineValue(map[string]string{"00:00:00:00:00:01": "/dev/sdb", "00:00:00:00:00:02": "/dev/sdb"}, "/dev/sda")).
+		To(gomega.Equal("{{ if eq .device_1 `00:00:00:00:00:01` }}/dev/sdb{{ else if eq .device_1 `00:00:00:00:00:02` }}/dev/sdb{{ else }}/dev/sda{{ end }}"))
+	g.Expect(hardware.PerMachineValue(map[string]string{"00:00:00:00:00:02": "/dev/sdb", "00:00:00:00:00:01": "/dev/sda"}, "/dev/sda")).
+		To(gomega.Equal("{{ if eq .device_1 `00:00:00:00:00:01` }}/dev/sda{{ else if eq .device_1 `00:00:00:00:00:02` }}/dev/sdb{{ else }}/dev/sda{{ end }}"))
+	g.Expect(hardware.PerMachineValue(map[string]string{"00:00:00:00:00:01": "a: 1\n", "00:00:00:00:00:02": "a: 2\n"}, "a: 0\n")).
+		To(gomega.Equal("{{- if eq .device_1 `00:00:00:00:00:01` }}\na: 1\n{{- else if eq .device_1 `00:00:00:00:00:02` }}\na: 2\n{{- else }}\na: 0\n{{- end }}\n"))
+}
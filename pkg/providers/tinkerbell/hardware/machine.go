@@ -18,8 +18,12 @@ type Machine struct {
 	// Disk used to populate the default workflow actions.
 	// Currently needs to be the same for all hardware residing in the same group where a group
 	// is either: control plane hardware, external etcd hard, or the definable worker node groups.
+	// It may be empty when Disks is set and the machine config selects the install disk.
 	Disk string `csv:"disk"`
 
+	// Disks of the machine with the attributes install and additional disks are selected by.
+	Disks Disks `csv:"disks, omitempty"`
+
 	// Labels to be applied to the Hardware resource.
 	Labels Labels `csv:"labels"`
 
@@ -27,6 +31,13 @@ type Machine struct {
 	BMCUsername  string `csv:"bmc_username, omitempty"`
 	BMCPassword  string `csv:"bmc_password, omitempty"`
 	VLANID       string `csv:"vlan_id, omitempty"`
+
+	// Network layout for machines with multiple interfaces. See Network.
+	Interfaces       NetworkInterfaces `csv:"interfaces, omitempty"`
+	Bonds            Bonds             `csv:"bonds, omitempty"`
+	BondMode         string            `csv:"bond_mode, omitempty"`
+	VLANs            VLANs             `csv:"vlans, omitempty"`
+	PrimaryInterface string            `csv:"primary_interface, omitempty"`
 }
 
 // HasBMC determines if m has a BMC configuration. A BMC configuration is present if any of the BMC fields
@@ -0,0 +1,613 @@
+package hardware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	"sigs.k8s.io/yaml"
+
+	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// NetworkAnnotation is the Hardware annotation holding the JSON serialized Network of a machine
+// with more than a single network interface.
+const NetworkAnnotation = "anywhere.eks.amazonaws.com/network"
+
+// DefaultBondMode is the bonding mode used when a bond doesn't specify one.
+const DefaultBondMode = "802.3ad"
+
+// interfaceNameValidation matches Linux interface names, which are limited to 15 characters.
+var interfaceNameValidation = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+
+// bondModes maps the Linux bonding modes to their numeric value used by Tinkerbell.
+var bondModes = map[string]int64{
+	"balance-rr":    0,
+	"active-backup": 1,
+	"balance-xor":   2,
+	"broadcast":     3,
+	"802.3ad":       4,
+	"balance-tlb":   5,
+	"balance-alb":   6,
+}
+
+// Network describes the network interfaces of a machine beyond the PXE interface identified by
+// the Machine MAC address.
+type Network struct {
+	// Interfaces are the physical interfaces of the machine. The PXE interface must be one of them.
+	Interfaces []NetworkInterface `json:"interfaces,omitempty"`
+	// Bonds aggregate physical interfaces.
+	Bonds []Bond `json:"bonds,omitempty"`
+	// VLANs are VLAN sub-interfaces of physical interfaces or bonds.
+	VLANs []VLAN `json:"vlans,omitempty"`
+	// PrimaryInterface is the name of the interface, bond or VLAN configured with the Machine IP address,
+	// netmask, gateway and nameservers. It defaults to the bond containing the PXE interface, or the
+	// PXE interface itself.
+	PrimaryInterface string `json:"primaryInterface,omitempty"`
+}
+
+// NetworkInterface is a physical network interface.
+type NetworkInterface struct {
+	Name string `json:"name"`
+	MAC  string `json:"mac"`
+}
+
+// Bond aggregates physical interfaces into a single logical interface.
+type Bond struct {
+	Name       string   `json:"name"`
+	Mode       string   `json:"mode,omitempty"`
+	Interfaces []string `json:"interfaces"`
+}
+
+// VLAN is a VLAN sub-interface of Link, optionally configured with a static address in CIDR notation.
+type VLAN struct {
+	ID      int    `json:"id"`
+	Link    string `json:"link"`
+	Address string `json:"address,omitempty"`
+}
+
+// Name returns the interface name of v.
+func (v VLAN) Name() string {
+	return fmt.Sprintf("%s.%d", v.Link, v.ID)
+}
+
+// IsEmpty returns true if n doesn't describe any interface.
+func (n Network) IsEmpty() bool {
+	return len(n.Interfaces) == 0 && len(n.Bonds) == 0 && len(n.VLANs) == 0
+}
+
+// Network returns the network layout of m described by its Interfaces, Bonds, BondMode, VLANs and
+// PrimaryInterface fields.
+func (m *Machine) Network() Network {
+	n := Network{
+		Interfaces:       m.Interfaces,
+		VLANs:            m.VLANs,
+		PrimaryInterface: m.PrimaryInterface,
+	}
+
+	for _, b := range m.Bonds {
+		b.Mode = m.BondMode
+		n.Bonds = append(n.Bonds, b)
+	}
+
+	return n
+}
+
+// NetworkInterfacesSeparator separates the interfaces of NetworkInterfaces, Bonds and VLANs.
+const NetworkInterfacesSeparator = "|"
+
+// NetworkInterfaces is a custom type that can unmarshal a CSV representation of interfaces
+// formatted as name=mac pairs, e.g. "eno1=00:00:00:00:00:01|eno2=00:00:00:00:00:02".
+type NetworkInterfaces []NetworkInterface
+
+// UnmarshalCSV unmarshalls s where s is a list of name=mac pairs separated by NetworkInterfacesSeparator.
+func (n *NetworkInterfaces) UnmarshalCSV(s string) error {
+	for _, pair := range splitNetworkColumn(s) {
+		name, mac, ok := cutPair(pair, "=")
+		if !ok {
+			return fmt.Errorf("badly formatted interface, expected name=mac: %v", pair)
+		}
+		*n = append(*n, NetworkInterface{Name: name, MAC: mac})
+	}
+	return nil
+}
+
+// MarshalCSV marshalls NetworkInterfaces into a list of name=mac pairs separated by NetworkInterfacesSeparator.
+func (n *NetworkInterfaces) MarshalCSV() (string, error) {
+	pairs := make([]string, 0, len(*n))
+	for _, i := range *n {
+		pairs = append(pairs, i.Name+"="+i.MAC)
+	}
+	return strings.Join(pairs, NetworkInterfacesSeparator), nil
+}
+
+// BondInterfacesSeparator separates the interfaces of a bond.
+const BondInterfacesSeparator = "+"
+
+// Bonds is a custom type that can unmarshal a CSV representation of bonds formatted as
+// name=interface+interface entries, e.g. "bond0=eno1+eno2".
+type Bonds []Bond
+
+// UnmarshalCSV unmarshalls s where s is a list of bonds separated by NetworkInterfacesSeparator.
+func (b *Bonds) UnmarshalCSV(s string) error {
+	for _, entry := range splitNetworkColumn(s) {
+		name, interfaces, ok := cutPair(entry, "=")
+		if !ok {
+			return fmt.Errorf("badly formatted bond, expected name=interface+interface: %v", entry)
+		}
+		bond := Bond{Name: name}
+		for _, i := range strings.Split(interfaces, BondInterfacesSeparator) {
+			bond.Interfaces = append(bond.Interfaces, strings.TrimSpace(i))
+		}
+		*b = append(*b, bond)
+	}
+	return nil
+}
+
+// MarshalCSV marshalls Bonds into a list of bonds separated by NetworkInterfacesSeparator.
+func (b *Bonds) MarshalCSV() (string, error) {
+	entries := make([]string, 0, len(*b))
+	for _, bond := range *b {
+		entries = append(entries, bond.Name+"="+strings.Join(bond.Interfaces, BondInterfacesSeparator))
+	}
+	return strings.Join(entries, NetworkInterfacesSeparator), nil
+}
+
+// VLANs is a custom type that can unmarshal a CSV representation of VLAN sub-interfaces formatted
+// as link.id entries with an optional address, e.g. "bond0.100=10.0.100.5/24|bond0.200".
+type VLANs []VLAN
+
+// UnmarshalCSV unmarshalls s where s is a list of VLANs separated by NetworkInterfacesSeparator.
+func (v *VLANs) UnmarshalCSV(s string) error {
+	for _, entry := range splitNetworkColumn(s) {
+		name, address, _ := cutPair(entry, "=")
+		dot := strings.LastIndex(name, ".")
+		if dot <= 0 {
+			return fmt.Errorf("badly formatted vlan, expected link.id[=address]: %v", entry)
+		}
+		id, err := strconv.Atoi(name[dot+1:])
+		if err != nil {
+			return fmt.Errorf("badly formatted vlan id: %v", entry)
+		}
+		*v = append(*v, VLAN{ID: id, Link: name[:dot], Address: address})
+	}
+	return nil
+}
+
+// MarshalCSV marshalls VLANs into a list of VLANs separated by NetworkInterfacesSeparator.
+func (v *VLANs) MarshalCSV() (string, error) {
+	entries := make([]string, 0, len(*v))
+	for _, vlan := range *v {
+		entry := vlan.Name()
+		if vlan.Address != "" {
+			entry += "=" + vlan.Address
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, NetworkInterfacesSeparator), nil
+}
+
+func splitNetworkColumn(s string) []string {
+	var entries []string
+	for _, entry := range strings.Split(s, NetworkInterfacesSeparator) {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func cutPair(s, sep string) (key, value string, ok bool) {
+	split := strings.SplitN(s, sep, 2)
+	if len(split) != 2 {
+		return strings.TrimSpace(s), "", false
+	}
+	return strings.TrimSpace(split[0]), strings.TrimSpace(split[1]), true
+}
+
+// validateNetwork ensures the network layout of m is consistent.
+func validateNetwork(m Machine) error {
+	n := m.Network()
+	if n.IsEmpty() && n.PrimaryInterface == "" {
+		return nil
+	}
+
+	if len(n.Interfaces) == 0 {
+		return errors.New("Interfaces: must be set when using bonds, vlans or a primary interface")
+	}
+
+	names := map[string]bool{}
+	addName := func(name string) error {
+		if !interfaceNameValidation.MatchString(name) {
+			return fmt.Errorf("invalid interface name: %v", name)
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate interface name: %v", name)
+		}
+		names[name] = true
+		return nil
+	}
+
+	var hasPXEInterface bool
+	macs := map[string]bool{}
+	for _, i := range n.Interfaces {
+		if err := addName(i.Name); err != nil {
+			return err
+		}
+		if _, err := net.ParseMAC(i.MAC); err != nil {
+			return fmt.Errorf("Interfaces: %v: %v", i.Name, err)
+		}
+		if macs[i.MAC] {
+			return fmt.Errorf("duplicate interface MACAddress: %v", i.MAC)
+		}
+		macs[i.MAC] = true
+		hasPXEInterface = hasPXEInterface || i.MAC == m.MACAddress
+	}
+	if !hasPXEInterface {
+		return fmt.Errorf("Interfaces: must contain the PXE interface MACAddress %v", m.MACAddress)
+	}
+
+	bonded := map[string]bool{}
+	for _, b := range n.Bonds {
+		if err := addName(b.Name); err != nil {
+			return err
+		}
+		if _, ok := bondModes[b.bondMode()]; !ok {
+			return fmt.Errorf("BondMode: unsupported bonding mode %v", b.bondMode())
+		}
+		if len(b.Interfaces) == 0 {
+			return fmt.Errorf("bond %v: must contain at least 1 interface", b.Name)
+		}
+		for _, i := range b.Interfaces {
+			if !containsInterface(n.Interfaces, i) {
+				return fmt.Errorf("bond %v: unknown interface %v", b.Name, i)
+			}
+			if bonded[i] {
+				return fmt.Errorf("bond %v: interface %v already belongs to a bond", b.Name, i)
+			}
+			bonded[i] = true
+		}
+	}
+
+	for _, v := range n.VLANs {
+		// Valid VLAN IDs are between 1 and 4094 - https://en.m.wikipedia.org/wiki/VLAN#IEEE_802.1Q
+		if v.ID < 1 || v.ID > 4094 {
+			return fmt.Errorf("vlan %v: id must be between 1 and 4094", v.Name())
+		}
+		if !names[v.Link] || bonded[v.Link] {
+			return fmt.Errorf("vlan %v: link must be an unbonded interface or a bond", v.Name())
+		}
+		if err := addName(v.Name()); err != nil {
+			return err
+		}
+		if v.Address != "" {
+			if _, _, err := net.ParseCIDR(v.Address); err != nil {
+				return fmt.Errorf("vlan %v: address must be in CIDR notation: %v", v.Name(), err)
+			}
+		}
+	}
+
+	if n.PrimaryInterface != "" {
+		if !names[n.PrimaryInterface] || bonded[n.PrimaryInterface] {
+			return fmt.Errorf("PrimaryInterface: %v must be an unbonded interface, a bond or a vlan", n.PrimaryInterface)
+		}
+	}
+
+	return nil
+}
+
+func (b Bond) bondMode() string {
+	if b.Mode == "" {
+		return DefaultBondMode
+	}
+	return b.Mode
+}
+
+func containsInterface(interfaces []NetworkInterface, name string) bool {
+	for _, i := range interfaces {
+		if i.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryInterface returns the name of the interface configured with the machine address.
+func (n Network) primaryInterface(pxeMAC string) string {
+	if n.PrimaryInterface != "" {
+		return n.PrimaryInterface
+	}
+
+	var pxe string
+	for _, i := range n.Interfaces {
+		if i.MAC == pxeMAC {
+			pxe = i.Name
+		}
+	}
+	for _, b := range n.Bonds {
+		for _, i := range b.Interfaces {
+			if i == pxe {
+				return b.Name
+			}
+		}
+	}
+	return pxe
+}
+
+// bondingMode returns the numeric bonding mode of the first bond of n, or 0 if n has no bonds.
+func (n Network) bondingMode() int64 {
+	if len(n.Bonds) == 0 {
+		return 0
+	}
+	return bondModes[n.Bonds[0].bondMode()]
+}
+
+// NetworkFromHardware returns the Network described by the NetworkAnnotation of hardware. It returns
+// an empty Network if hardware doesn't have the annotation.
+func NetworkFromHardware(hardware *tinkv1alpha1.Hardware) (Network, error) {
+	var n Network
+	raw, ok := hardware.Annotations[NetworkAnnotation]
+	if !ok {
+		return n, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &n); err != nil {
+		return n, fmt.Errorf("parsing %s annotation of hardware %s: %v", NetworkAnnotation, hardware.Name, err)
+	}
+	return n, nil
+}
+
+func networkAnnotations(n Network) map[string]string {
+	if n.IsEmpty() {
+		return nil
+	}
+	// Network only contains strings and ints so it can't fail to marshal.
+	raw, _ := json.Marshal(n)
+	return map[string]string{NetworkAnnotation: string(raw)}
+}
+
+// netplan is the subset of the netplan configuration format used to configure machines.
+// See https://netplan.io/reference.
+type netplan struct {
+	Network netplanNetwork `json:"network"`
+}
+
+type netplanNetwork struct {
+	Version   int                         `json:"version"`
+	Renderer  string                      `json:"renderer"`
+	Ethernets map[string]netplanInterface `json:"ethernets,omitempty"`
+	Bonds     map[string]netplanInterface `json:"bonds,omitempty"`
+	VLANs     map[string]netplanInterface `json:"vlans,omitempty"`
+}
+
+type netplanInterface struct {
+	Match       *netplanMatch       `json:"match,omitempty"`
+	SetName     string              `json:"set-name,omitempty"`
+	DHCP4       bool                `json:"dhcp4"`
+	Interfaces  []string            `json:"interfaces,omitempty"`
+	Parameters  *netplanBondParams  `json:"parameters,omitempty"`
+	ID          *int                `json:"id,omitempty"`
+	Link        string              `json:"link,omitempty"`
+	Addresses   []string            `json:"addresses,omitempty"`
+	Routes      []netplanRoute      `json:"routes,omitempty"`
+	Nameservers *netplanNameservers `json:"nameservers,omitempty"`
+}
+
+type netplanMatch struct {
+	MACAddress string `json:"macaddress"`
+}
+
+type netplanBondParams struct {
+	Mode string `json:"mode"`
+}
+
+type netplanRoute struct {
+	To  string `json:"to"`
+	Via string `json:"via"`
+}
+
+type netplanNameservers struct {
+	Addresses []string `json:"addresses"`
+}
+
+// NetplanConfig renders the netplan configuration of hardware from its NetworkAnnotation and the
+// address of its PXE interface. It returns false if hardware has no NetworkAnnotation.
+func NetplanConfig(hardware *tinkv1alpha1.Hardware) (string, bool, error) {
+	n, err := NetworkFromHardware(hardware)
+	if err != nil || n.IsEmpty() {
+		return "", false, err
+	}
+
+	if len(hardware.Spec.Interfaces) == 0 || hardware.Spec.Interfaces[0].DHCP == nil || hardware.Spec.Interfaces[0].DHCP.IP == nil {
+		return "", false, fmt.Errorf("hardware %s: missing PXE interface address", hardware.Name)
+	}
+	dhcp := hardware.Spec.Interfaces[0].DHCP
+
+	prefix, err := netmaskPrefix(dhcp.IP.Netmask)
+	if err != nil {
+		return "", false, fmt.Errorf("hardware %s: %v", hardware.Name, err)
+	}
+
+	config := netplanNetwork{
+		Version:   2,
+		Renderer:  "networkd",
+		Ethernets: map[string]netplanInterface{},
+	}
+
+	for _, i := range n.Interfaces {
+		config.Ethernets[i.Name] = netplanInterface{
+			Match:   &netplanMatch{MACAddress: i.MAC},
+			SetName: i.Name,
+		}
+	}
+
+	if len(n.Bonds) > 0 {
+		config.Bonds = map[string]netplanInterface{}
+	}
+	for _, b := range n.Bonds {
+		config.Bonds[b.Name] = netplanInterface{
+			Interfaces: b.Interfaces,
+			Parameters: &netplanBondParams{Mode: b.bondMode()},
+		}
+	}
+
+	if len(n.VLANs) > 0 {
+		config.VLANs = map[string]netplanInterface{}
+	}
+	for _, v := range n.VLANs {
+		id := v.ID
+		vlan := netplanInterface{ID: &id, Link: v.Link}
+		if v.Address != "" {
+			vlan.Addresses = []string{v.Address}
+		}
+		config.VLANs[v.Name()] = vlan
+	}
+
+	primary := n.primaryInterface(dhcp.MAC)
+	if err := configurePrimaryInterface(&config, primary, func(i *netplanInterface) {
+		i.Addresses = append([]string{fmt.Sprintf("%s/%d", dhcp.IP.Address, prefix)}, i.Addresses...)
+		if dhcp.IP.Gateway != "" {
+			i.Routes = []netplanRoute{{To: "0.0.0.0/0", Via: dhcp.IP.Gateway}}
+		}
+		if len(dhcp.NameServers) > 0 {
+			i.Nameservers = &netplanNameservers{Addresses: dhcp.NameServers}
+		}
+	}); err != nil {
+		return "", false, fmt.Errorf("hardware %s: %v", hardware.Name, err)
+	}
+
+	raw, err := yaml.Marshal(netplan{Network: config})
+	if err != nil {
+		return "", false, fmt.Errorf("hardware %s: marshalling netplan: %v", hardware.Name, err)
+	}
+
+	return string(raw), true, nil
+}
+
+func configurePrimaryInterface(config *netplanNetwork, name string, configure func(*netplanInterface)) error {
+	for _, interfaces := range []map[string]netplanInterface{config.Ethernets, config.Bonds, config.VLANs} {
+		if i, ok := interfaces[name]; ok {
+			configure(&i)
+			interfaces[name] = i
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown primary interface %v", name)
+}
+
+func netmaskPrefix(netmask string) (int, error) {
+	ip := net.ParseIP(netmask).To4()
+	if ip == nil {
+		return 0, fmt.Errorf("invalid netmask %v", netmask)
+	}
+	ones, bits := net.IPMask(ip).Size()
+	if bits == 0 {
+		return 0, fmt.Errorf("invalid netmask %v", netmask)
+	}
+	return ones, nil
+}
+
+// NetplanExtractor collects the netplan configurations of the machines matching registered hardware
+// selectors so they can be written by the provisioning workflow of each machine group.
+type NetplanExtractor struct {
+	selectors map[string]eksav1alpha1.HardwareSelector
+	configs   map[string]map[string]string
+}
+
+// NewNetplanExtractor creates a NetplanExtractor instance.
+func NewNetplanExtractor() *NetplanExtractor {
+	return &NetplanExtractor{
+		selectors: make(map[string]eksav1alpha1.HardwareSelector),
+		configs:   make(map[string]map[string]string),
+	}
+}
+
+// Register registers selector with e such that netplan configurations can be cached when machines
+// are written to Write().
+func (e *NetplanExtractor) Register(selector eksav1alpha1.HardwareSelector) error {
+	key, err := serializeHardwareSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	e.selectors[key] = selector
+	return nil
+}
+
+// Write caches the netplan configuration of m for every registered selector m matches.
+func (e *NetplanExtractor) Write(m Machine) error {
+	return e.InsertHardware(hardwareFromMachine(m))
+}
+
+// InsertHardware caches the netplan configuration of hardware for every registered selector
+// hardware matches. Hardware without a NetworkAnnotation is ignored.
+func (e *NetplanExtractor) InsertHardware(hardware *tinkv1alpha1.Hardware) error {
+	config, ok, err := NetplanConfig(hardware)
+	if err != nil || !ok {
+		return err
+	}
+
+	mac := hardware.Spec.Interfaces[0].DHCP.MAC
+	for key, selector := range e.selectors {
+		if LabelsMatchSelector(selector, hardware.Labels) {
+			if e.configs[key] == nil {
+				e.configs[key] = make(map[string]string)
+			}
+			e.configs[key][mac] = config
+		}
+	}
+
+	return nil
+}
+
+// NetplanTemplate holds the Tinkerbell templates of the write-netplan action environment for the
+// machines of a hardware selector. Both values are chosen by the MAC address of the machine running
+// the workflow.
+type NetplanTemplate struct {
+	// Contents renders the netplan configuration of the machines with a network layout.
+	Contents string
+	// StaticNetplan renders false for the machines with a network layout and true for any other
+	// machine, including machines added after the template was generated, so they keep the static
+	// configuration of their PXE interface.
+	StaticNetplan string
+}
+
+// GetNetplanTemplate returns the NetplanTemplate of the machines matching selector. It returns false
+// if no machine matching selector has a network layout.
+func (e *NetplanExtractor) GetNetplanTemplate(selector eksav1alpha1.HardwareSelector) (NetplanTemplate, bool, error) {
+	key, err := serializeHardwareSelector(selector)
+	if err != nil {
+		return NetplanTemplate{}, false, err
+	}
+
+	configs := e.configs[key]
+	if len(configs) == 0 {
+		return NetplanTemplate{}, false, nil
+	}
+
+	macs := make([]string, 0, len(configs))
+	for mac := range configs {
+		macs = append(macs, mac)
+	}
+	sort.Strings(macs)
+
+	var contents, static strings.Builder
+	for i, mac := range macs {
+		keyword := "if"
+		if i > 0 {
+			keyword = "else if"
+		}
+		// Raw strings keep the template valid whatever the quoting style of the enclosing YAML.
+		fmt.Fprintf(&contents, "{{- %s eq .device_1 `%s` }}\n%s", keyword, mac, configs[mac])
+		fmt.Fprintf(&static, "{{- %s eq .device_1 `%s` }}false", keyword, mac)
+	}
+	contents.WriteString("{{- end }}\n")
+	static.WriteString("{{- else }}true{{- end }}")
+
+	return NetplanTemplate{Contents: contents.String(), StaticNetplan: static.String()}, true, nil
+}
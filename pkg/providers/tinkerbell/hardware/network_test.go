@@ -0,0 +1,224 @@
+package hardware_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+const bondedMachineCSV = `hostname,mac,ip_address,netmask,gateway,nameservers,labels,disk,interfaces,bonds,bond_mode,vlans
+worker1,00:00:00:00:00:01,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1|8.8.8.8,type=worker,/dev/sda,eno1=00:00:00:00:00:01|eno2=00:00:00:00:00:02,bond0=eno1+eno2,active-backup,bond0.100=10.0.100.10/24|bond0.200
+`
+
+func newBondedMachine() hardware.Machine {
+	return hardware.Machine{
+		Hostname:    "worker1",
+		IPAddress:   "10.10.10.10",
+		Netmask:     "255.255.255.0",
+		Gateway:     "10.10.10.1",
+		Nameservers: hardware.Nameservers{"1.1.1.1", "8.8.8.8"},
+		MACAddress:  "00:00:00:00:00:01",
+		Disk:        "/dev/sda",
+		Labels:      hardware.Labels{"type": "worker"},
+		Interfaces: hardware.NetworkInterfaces{
+			{Name: "eno1", MAC: "00:00:00:00:00:01"},
+			{Name: "eno2", MAC: "00:00:00:00:00:02"},
+		},
+		Bonds:    hardware.Bonds{{Name: "bond0", Interfaces: []string{"eno1", "eno2"}}},
+		BondMode: "active-backup",
+		VLANs: hardware.VLANs{
+			{ID: 100, Link: "bond0", Address: "10.0.100.10/24"},
+			{ID: 200, Link: "bond0"},
+		},
+	}
+}
+
+func TestCSVReaderReadsNetworkLayout(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	reader, err := hardware.NewCSVReader(strings.NewReader(bondedMachineCSV))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine).To(gomega.Equal(newBondedMachine()))
+	g.Expect(hardware.StaticMachineAssertions()(machine)).To(gomega.Succeed())
+}
+
+func TestNetworkInterfacesUnmarshalCSVInvalid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var interfaces hardware.NetworkInterfaces
+	g.Expect(interfaces.UnmarshalCSV("eno1")).To(gomega.MatchError(gomega.ContainSubstring("expected name=mac")))
+
+	var vlans hardware.VLANs
+	g.Expect(vlans.UnmarshalCSV("bond0.abc")).To(gomega.MatchError(gomega.ContainSubstring("badly formatted vlan id")))
+}
+
+func TestHardwareCatalogueWriterNetworkLayout(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(hardware.NewHardwareCatalogueWriter(catalogue).Write(newBondedMachine())).To(gomega.Succeed())
+
+	hw := catalogue.AllHardware()[0]
+	g.Expect(hw.Spec.Interfaces).To(gomega.HaveLen(2))
+	g.Expect(hw.Spec.Interfaces[0].DHCP.MAC).To(gomega.Equal("00:00:00:00:00:01"))
+	g.Expect(hw.Spec.Interfaces[0].DHCP.IfaceName).To(gomega.Equal("eno1"))
+	g.Expect(*hw.Spec.Interfaces[0].Netboot.AllowPXE).To(gomega.BeTrue())
+	g.Expect(hw.Spec.Interfaces[1].DHCP.MAC).To(gomega.Equal("00:00:00:00:00:02"))
+	g.Expect(hw.Spec.Interfaces[1].DHCP.IP).To(gomega.BeNil())
+	g.Expect(*hw.Spec.Interfaces[1].Netboot.AllowPXE).To(gomega.BeFalse())
+	g.Expect(hw.Spec.Metadata.BondingMode).To(gomega.BeEquivalentTo(1))
+	g.Expect(hw.Spec.Metadata.Instance.Ips).To(gomega.HaveLen(2))
+	g.Expect(hw.Spec.Metadata.Instance.Ips[1].Address).To(gomega.Equal("10.0.100.10"))
+	g.Expect(hw.Spec.Metadata.Instance.Ips[1].Netmask).To(gomega.Equal("255.255.255.0"))
+
+	network, err := hardware.NetworkFromHardware(hw)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(network.Bonds).To(gomega.Equal([]hardware.Bond{{Name: "bond0", Mode: "active-backup", Interfaces: []string{"eno1", "eno2"}}}))
+}
+
+func TestHardwareCatalogueWriterSingleInterface(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(hardware.NewHardwareCatalogueWriter(catalogue).Write(NewValidMachine())).To(gomega.Succeed())
+
+	hw := catalogue.AllHardware()[0]
+	g.Expect(hw.Annotations).To(gomega.BeEmpty())
+	g.Expect(hw.Spec.Interfaces).To(gomega.HaveLen(1))
+
+	_, ok, err := hardware.NetplanConfig(hw)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(ok).To(gomega.BeFalse())
+}
+
+const bondedMachineNetplan = `network:
+  bonds:
+    bond0:
+      addresses:
+      - 10.10.10.10/24
+      dhcp4: false
+      interfaces:
+      - eno1
+      - eno2
+      nameservers:
+        addresses:
+        - 1.1.1.1
+        - 8.8.8.8
+      parameters:
+        mode: active-backup
+      routes:
+      - to: 0.0.0.0/0
+        via: 10.10.10.1
+  ethernets:
+    eno1:
+      dhcp4: false
+      match:
+        macaddress: "00:00:00:00:00:01"
+      set-name: eno1
+    eno2:
+      dhcp4: false
+      match:
+        macaddress: "00:00:00:00:00:02"
+      set-name: eno2
+  renderer: networkd
+  version: 2
+  vlans:
+    bond0.100:
+      addresses:
+      - 10.0.100.10/24
+      dhcp4: false
+      id: 100
+      link: bond0
+    bond0.200:
+      dhcp4: false
+      id: 200
+      link: bond0
+`
+
+func TestNetplanConfig(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(hardware.NewHardwareCatalogueWriter(catalogue).Write(newBondedMachine())).To(gomega.Succeed())
+
+	config, ok, err := hardware.NetplanConfig(catalogue.AllHardware()[0])
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(config).To(gomega.Equal(bondedMachineNetplan))
+}
+
+func TestNetplanConfigPrimaryVLAN(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	machine := newBondedMachine()
+	machine.PrimaryInterface = "bond0.200"
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(hardware.NewHardwareCatalogueWriter(catalogue).Write(machine)).To(gomega.Succeed())
+
+	config, _, err := hardware.NetplanConfig(catalogue.AllHardware()[0])
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.ContainSubstring(`    bond0.200:
+      addresses:
+      - 10.10.10.10/24`))
+}
+
+func TestNetplanExtractorTemplateRendersMachineConfig(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	selector := v1alpha1.HardwareSelector{"type": "worker"}
+	extractor := hardware.NewNetplanExtractor()
+	g.Expect(extractor.Register(selector)).To(gomega.Succeed())
+
+	worker2 := newBondedMachine()
+	worker2.Hostname = "worker2"
+	worker2.MACAddress = "00:00:00:00:00:03"
+	worker2.IPAddress = "10.10.10.11"
+	worker2.Interfaces = hardware.NetworkInterfaces{
+		{Name: "eno1", MAC: "00:00:00:00:00:03"},
+		{Name: "eno2", MAC: "00:00:00:00:00:04"},
+	}
+	for _, m := range []hardware.Machine{newBondedMachine(), worker2, NewValidMachine()} {
+		g.Expect(extractor.Write(m)).To(gomega.Succeed())
+	}
+
+	netplan, ok, err := extractor.GetNetplanTemplate(selector)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(ok).To(gomega.BeTrue())
+
+	// The template is rendered by Tinkerbell with the MAC address of the machine running the workflow.
+	tmpl := template.Must(template.New("netplan").Option("missingkey=error").Parse(netplan.Contents))
+	var rendered bytes.Buffer
+	g.Expect(tmpl.Execute(&rendered, map[string]interface{}{"device_1": "00:00:00:00:00:01"})).To(gomega.Succeed())
+	g.Expect(rendered.String()).To(gomega.Equal("\n" + bondedMachineNetplan))
+
+	rendered.Reset()
+	g.Expect(tmpl.Execute(&rendered, map[string]interface{}{"device_1": "00:00:00:00:00:03"})).To(gomega.Succeed())
+	g.Expect(rendered.String()).To(gomega.ContainSubstring("10.10.10.11/24"))
+
+	static := template.Must(template.New("static").Option("missingkey=error").Parse(netplan.StaticNetplan))
+	for mac, want := range map[string]string{
+		"00:00:00:00:00:01": "false",
+		"00:00:00:00:00:03": "false",
+		// Machines without a network layout or unknown when the template was generated
+		"00:00:00:00:00:00": "true",
+		"00:00:00:00:00:99": "true",
+	} {
+		rendered.Reset()
+		g.Expect(static.Execute(&rendered, map[string]interface{}{"device_1": mac})).To(gomega.Succeed())
+		g.Expect(rendered.String()).To(gomega.Equal(want), mac)
+	}
+
+	_, ok, err = extractor.GetNetplanTemplate(v1alpha1.HardwareSelector{"type": "cp"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(ok).To(gomega.BeFalse())
+}
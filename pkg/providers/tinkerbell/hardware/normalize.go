@@ -46,9 +46,19 @@ func (n *Normalizer) Register(fn NormalizerFunc) {
 	n.normalizers = append(n.normalizers, fn)
 }
 
-// LowercaseMACAddress ensures m's MACAddress field has lower chase characters.
+// LowercaseMACAddress ensures m's MACAddress field and interface MAC addresses have lower chase characters.
 func LowercaseMACAddress(m Machine) Machine {
 	m.MACAddress = strings.ToLower(m.MACAddress)
+
+	if m.Interfaces != nil {
+		interfaces := make(NetworkInterfaces, 0, len(m.Interfaces))
+		for _, i := range m.Interfaces {
+			i.MAC = strings.ToLower(i.MAC)
+			interfaces = append(interfaces, i)
+		}
+		m.Interfaces = interfaces
+	}
+
 	return m
 }
 
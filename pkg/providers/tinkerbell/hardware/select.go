@@ -0,0 +1,45 @@
+package hardware
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectMachines reads all the Machine entries from reader and returns the ones with a hostname in
+// hostnames or with labels matching selector. A nil selector doesn't match any Machine. Every hostname
+// must belong to a Machine.
+func SelectMachines(reader MachineReader, hostnames []string, selector labels.Selector) ([]Machine, error) {
+	wanted := make(map[string]bool, len(hostnames))
+	for _, h := range hostnames {
+		wanted[h] = false
+	}
+
+	var selected []Machine
+	for {
+		m, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read: %v", err)
+		}
+
+		_, byHostname := wanted[m.Hostname]
+		if byHostname {
+			wanted[m.Hostname] = true
+		}
+		if byHostname || (selector != nil && selector.Matches(m.Labels)) {
+			selected = append(selected, m)
+		}
+	}
+
+	for _, h := range hostnames {
+		if !wanted[h] {
+			return nil, fmt.Errorf("hardware %s not found", h)
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,77 @@
+package hardware_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware/mocks"
+)
+
+func expectMachines(reader *mocks.MockMachineReader, machines ...hardware.Machine) {
+	calls := make([]*gomock.Call, 0, len(machines)+1)
+	for _, m := range machines {
+		calls = append(calls, reader.EXPECT().Read().Return(m, nil))
+	}
+	calls = append(calls, reader.EXPECT().Read().Return(hardware.Machine{}, io.EOF))
+	gomock.InOrder(calls...)
+}
+
+func TestSelectMachinesByHostnameAndSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	cp := hardware.Machine{Hostname: "cp", Labels: hardware.Labels{"type": "cp"}}
+	worker1 := hardware.Machine{Hostname: "worker1", Labels: hardware.Labels{"type": "worker"}}
+	worker2 := hardware.Machine{Hostname: "worker2", Labels: hardware.Labels{"type": "worker"}}
+	expectMachines(reader, cp, worker1, worker2)
+
+	selector, err := labels.Parse("type=worker")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machines, err := hardware.SelectMachines(reader, []string{"cp", "worker1"}, selector)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machines).To(gomega.Equal([]hardware.Machine{cp, worker1, worker2}))
+}
+
+func TestSelectMachinesNilSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	worker1 := hardware.Machine{Hostname: "worker1"}
+	worker2 := hardware.Machine{Hostname: "worker2"}
+	expectMachines(reader, worker1, worker2)
+
+	machines, err := hardware.SelectMachines(reader, []string{"worker2"}, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machines).To(gomega.Equal([]hardware.Machine{worker2}))
+}
+
+func TestSelectMachinesUnknownHostname(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	expectMachines(reader, hardware.Machine{Hostname: "worker1"})
+
+	_, err := hardware.SelectMachines(reader, []string{"worker3"}, nil)
+	g.Expect(err).To(gomega.MatchError("hardware worker3 not found"))
+}
+
+func TestSelectMachinesReadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	reader.EXPECT().Read().Return(hardware.Machine{}, errors.New("bad csv"))
+
+	_, err := hardware.SelectMachines(reader, nil, labels.Everything())
+	g.Expect(err).To(gomega.MatchError("read: bad csv"))
+}
@@ -101,7 +101,7 @@ func StaticMachineAssertions() MachineAssertion {
 			return fmt.Errorf("invalid hostname: %v: %v", m.Hostname, errs)
 		}
 
-		if !linuxPathValidation.MatchString(m.Disk) {
+		if (m.Disk != "" || len(m.Disks) == 0) && !linuxPathValidation.MatchString(m.Disk) {
 			return fmt.Errorf(
 				"disk must be a valid linux path (\"%v\")",
 				linuxPathRegex,
@@ -152,6 +152,14 @@ func StaticMachineAssertions() MachineAssertion {
 			}
 		}
 
+		if err := validateNetwork(m); err != nil {
+			return err
+		}
+
+		if err := validateDisks(m); err != nil {
+			return err
+		}
+
 		return nil
 	}
 }
@@ -215,6 +215,34 @@ func TestStaticMachineAssertions_InvalidMachines(t *testing.T) {
 		"NonIntVLAN": func(h *hardware.Machine) {
 			h.VLANID = "im not an int"
 		},
+		"BondWithoutInterfaces": func(h *hardware.Machine) {
+			h.Bonds = hardware.Bonds{{Name: "bond0", Interfaces: []string{"eno1"}}}
+		},
+		"InterfacesWithoutPXEInterface": func(h *hardware.Machine) {
+			h.Interfaces = hardware.NetworkInterfaces{{Name: "eno1", MAC: "00:00:00:00:00:01"}}
+		},
+		"BondWithUnknownInterface": func(h *hardware.Machine) {
+			h.Interfaces = hardware.NetworkInterfaces{{Name: "eno1", MAC: "00:00:00:00:00:00"}}
+			h.Bonds = hardware.Bonds{{Name: "bond0", Interfaces: []string{"eno1", "eno2"}}}
+		},
+		"UnsupportedBondMode": func(h *hardware.Machine) {
+			h.Interfaces = hardware.NetworkInterfaces{{Name: "eno1", MAC: "00:00:00:00:00:00"}}
+			h.Bonds = hardware.Bonds{{Name: "bond0", Interfaces: []string{"eno1"}}}
+			h.BondMode = "unknown"
+		},
+		"VLANOnBondedInterface": func(h *hardware.Machine) {
+			h.Interfaces = hardware.NetworkInterfaces{{Name: "eno1", MAC: "00:00:00:00:00:00"}}
+			h.Bonds = hardware.Bonds{{Name: "bond0", Interfaces: []string{"eno1"}}}
+			h.VLANs = hardware.VLANs{{ID: 100, Link: "eno1"}}
+		},
+		"VLANWithInvalidAddress": func(h *hardware.Machine) {
+			h.Interfaces = hardware.NetworkInterfaces{{Name: "eno1", MAC: "00:00:00:00:00:00"}}
+			h.VLANs = hardware.VLANs{{ID: 100, Link: "eno1", Address: "10.0.0.1"}}
+		},
+		"UnknownPrimaryInterface": func(h *hardware.Machine) {
+			h.Interfaces = hardware.NetworkInterfaces{{Name: "eno1", MAC: "00:00:00:00:00:00"}}
+			h.PrimaryInterface = "eno2"
+		},
 	}
 
 	validate := hardware.StaticMachineAssertions()
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/providers/tinkerbell (interfaces: ProviderKubectlClient,SSHAuthKeyGenerator)
+// Source: github.com/aws/eks-anywhere/pkg/providers/tinkerbell (interfaces: ProviderKubectlClient,SSHAuthKeyGenerator,BMCChecker)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -11,11 +11,14 @@ import (
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	executables "github.com/aws/eks-anywhere/pkg/executables"
 	filewriter "github.com/aws/eks-anywhere/pkg/filewriter"
+	bmc "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
-	v1alpha10 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	v1alpha10 "github.com/tinkerbell/rufio/api/v1alpha1"
+	v1alpha11 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
 	v1beta11 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 )
@@ -43,6 +46,20 @@ func (m *MockProviderKubectlClient) EXPECT() *MockProviderKubectlClientMockRecor
 	return m.recorder
 }
 
+// Apply mocks base method.
+func (m *MockProviderKubectlClient) Apply(arg0 context.Context, arg1 string, arg2 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockProviderKubectlClientMockRecorder) Apply(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockProviderKubectlClient)(nil).Apply), arg0, arg1, arg2)
+}
+
 // ApplyKubeSpec mocks base method.
 func (m *MockProviderKubectlClient) ApplyKubeSpec(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -71,6 +88,20 @@ func (mr *MockProviderKubectlClientMockRecorder) ApplyKubeSpecFromBytesForce(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesForce", reflect.TypeOf((*MockProviderKubectlClient)(nil).ApplyKubeSpecFromBytesForce), arg0, arg1, arg2)
 }
 
+// Delete mocks base method.
+func (m *MockProviderKubectlClient) Delete(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProviderKubectlClientMockRecorder) Delete(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProviderKubectlClient)(nil).Delete), arg0, arg1, arg2, arg3, arg4)
+}
+
 // DeleteEksaDatacenterConfig mocks base method.
 func (m *MockProviderKubectlClient) DeleteEksaDatacenterConfig(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
 	m.ctrl.T.Helper()
@@ -205,10 +236,10 @@ func (mr *MockProviderKubectlClientMockRecorder) GetMachineDeployment(arg0, arg1
 }
 
 // GetProvisionedTinkerbellHardware mocks base method.
-func (m *MockProviderKubectlClient) GetProvisionedTinkerbellHardware(arg0 context.Context, arg1, arg2 string) ([]v1alpha10.Hardware, error) {
+func (m *MockProviderKubectlClient) GetProvisionedTinkerbellHardware(arg0 context.Context, arg1, arg2 string) ([]v1alpha11.Hardware, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetProvisionedTinkerbellHardware", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]v1alpha10.Hardware)
+	ret0, _ := ret[0].([]v1alpha11.Hardware)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -219,6 +250,21 @@ func (mr *MockProviderKubectlClientMockRecorder) GetProvisionedTinkerbellHardwar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionedTinkerbellHardware", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetProvisionedTinkerbellHardware), arg0, arg1, arg2)
 }
 
+// GetRufioBMCJob mocks base method.
+func (m *MockProviderKubectlClient) GetRufioBMCJob(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha10.BMCJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRufioBMCJob", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha10.BMCJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRufioBMCJob indicates an expected call of GetRufioBMCJob.
+func (mr *MockProviderKubectlClientMockRecorder) GetRufioBMCJob(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRufioBMCJob", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetRufioBMCJob), arg0, arg1, arg2, arg3)
+}
+
 // GetSecret mocks base method.
 func (m *MockProviderKubectlClient) GetSecret(arg0 context.Context, arg1 string, arg2 ...executables.KubectlOpt) (*v1.Secret, error) {
 	m.ctrl.T.Helper()
@@ -239,11 +285,56 @@ func (mr *MockProviderKubectlClientMockRecorder) GetSecret(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetSecret), varargs...)
 }
 
+// GetTinkerbellHardware mocks base method.
+func (m *MockProviderKubectlClient) GetTinkerbellHardware(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha11.Hardware, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTinkerbellHardware", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha11.Hardware)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTinkerbellHardware indicates an expected call of GetTinkerbellHardware.
+func (mr *MockProviderKubectlClientMockRecorder) GetTinkerbellHardware(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTinkerbellHardware", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetTinkerbellHardware), arg0, arg1, arg2, arg3)
+}
+
+// GetTinkerbellMachineNames mocks base method.
+func (m *MockProviderKubectlClient) GetTinkerbellMachineNames(arg0 context.Context, arg1, arg2, arg3 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTinkerbellMachineNames", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTinkerbellMachineNames indicates an expected call of GetTinkerbellMachineNames.
+func (mr *MockProviderKubectlClientMockRecorder) GetTinkerbellMachineNames(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTinkerbellMachineNames", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetTinkerbellMachineNames), arg0, arg1, arg2, arg3)
+}
+
+// GetTinkerbellWorkflow mocks base method.
+func (m *MockProviderKubectlClient) GetTinkerbellWorkflow(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha11.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTinkerbellWorkflow", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha11.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTinkerbellWorkflow indicates an expected call of GetTinkerbellWorkflow.
+func (mr *MockProviderKubectlClientMockRecorder) GetTinkerbellWorkflow(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTinkerbellWorkflow", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetTinkerbellWorkflow), arg0, arg1, arg2, arg3)
+}
+
 // GetUnprovisionedTinkerbellHardware mocks base method.
-func (m *MockProviderKubectlClient) GetUnprovisionedTinkerbellHardware(arg0 context.Context, arg1, arg2 string) ([]v1alpha10.Hardware, error) {
+func (m *MockProviderKubectlClient) GetUnprovisionedTinkerbellHardware(arg0 context.Context, arg1, arg2 string) ([]v1alpha11.Hardware, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUnprovisionedTinkerbellHardware", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]v1alpha10.Hardware)
+	ret0, _ := ret[0].([]v1alpha11.Hardware)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -254,6 +345,21 @@ func (mr *MockProviderKubectlClientMockRecorder) GetUnprovisionedTinkerbellHardw
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnprovisionedTinkerbellHardware", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetUnprovisionedTinkerbellHardware), arg0, arg1, arg2)
 }
 
+// PatchTinkerbellHardware mocks base method.
+func (m *MockProviderKubectlClient) PatchTinkerbellHardware(arg0 context.Context, arg1, arg2, arg3 string, arg4 []byte) (*v1alpha11.Hardware, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTinkerbellHardware", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*v1alpha11.Hardware)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchTinkerbellHardware indicates an expected call of PatchTinkerbellHardware.
+func (mr *MockProviderKubectlClientMockRecorder) PatchTinkerbellHardware(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTinkerbellHardware", reflect.TypeOf((*MockProviderKubectlClient)(nil).PatchTinkerbellHardware), arg0, arg1, arg2, arg3, arg4)
+}
+
 // UpdateAnnotation mocks base method.
 func (m *MockProviderKubectlClient) UpdateAnnotation(arg0 context.Context, arg1, arg2 string, arg3 map[string]string, arg4 ...executables.KubectlOpt) error {
 	m.ctrl.T.Helper()
@@ -338,3 +444,40 @@ func (mr *MockSSHAuthKeyGeneratorMockRecorder) GenerateSSHAuthKey(arg0 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSSHAuthKey", reflect.TypeOf((*MockSSHAuthKeyGenerator)(nil).GenerateSSHAuthKey), arg0)
 }
+
+// MockBMCChecker is a mock of BMCChecker interface.
+type MockBMCChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBMCCheckerMockRecorder
+}
+
+// MockBMCCheckerMockRecorder is the mock recorder for MockBMCChecker.
+type MockBMCCheckerMockRecorder struct {
+	mock *MockBMCChecker
+}
+
+// NewMockBMCChecker creates a new mock instance.
+func NewMockBMCChecker(ctrl *gomock.Controller) *MockBMCChecker {
+	mock := &MockBMCChecker{ctrl: ctrl}
+	mock.recorder = &MockBMCCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBMCChecker) EXPECT() *MockBMCCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckConnection mocks base method.
+func (m *MockBMCChecker) CheckConnection(arg0 context.Context, arg1 bmc.Connection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckConnection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckConnection indicates an expected call of CheckConnection.
+func (mr *MockBMCCheckerMockRecorder) CheckConnection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConnection", reflect.TypeOf((*MockBMCChecker)(nil).CheckConnection), arg0, arg1)
+}
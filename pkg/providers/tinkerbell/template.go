@@ -3,8 +3,10 @@ package tinkerbell
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
@@ -12,6 +14,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -34,6 +37,9 @@ var defaultClusterConfigMD string
 const (
 	TinkerbellMachineTemplateKind = "TinkerbellMachineTemplate"
 	defaultRegistry               = "public.ecr.aws"
+	writeNetplanAction            = "write-netplan"
+	writeBootconfigAction         = "write-bootconfig"
+	additionalDisksAction         = "add-additional-disks-cloud-init-config"
 )
 
 type TemplateBuilder struct {
@@ -42,6 +48,8 @@ type TemplateBuilder struct {
 	WorkerNodeGroupMachineSpecs map[string]v1alpha1.TinkerbellMachineConfigSpec
 	etcdMachineSpec             *v1alpha1.TinkerbellMachineConfigSpec
 	diskExtractor               *hardware.DiskExtractor
+	netplanExtractor            *hardware.NetplanExtractor
+	diskLayoutExtractor         *hardware.DiskLayoutExtractor
 	tinkerbellIp                string
 	now                         types.NowFunc
 }
@@ -61,15 +69,10 @@ func NewTemplateBuilder(datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec,
 func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
 	cpTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[tb.controlPlaneMachineSpec.TemplateRef.Name]
 	if cpTemplateConfig == nil {
-		disk, err := tb.diskExtractor.GetDisk(tb.controlPlaneMachineSpec.HardwareSelector)
+		cpTemplateConfig, err = tb.defaultTemplateConfig(clusterSpec, tb.controlPlaneMachineSpec, "control plane")
 		if err != nil {
-			disk, err = tb.diskExtractor.GetDiskProvisionedHardware(tb.controlPlaneMachineSpec.HardwareSelector)
-			if err != nil {
-				return nil, fmt.Errorf("getting control plane disk type of the hardware selector: %v", err)
-			}
+			return nil, err
 		}
-		versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily)
 	}
 
 	cpTemplateString, err := cpTemplateConfig.ToTemplateString()
@@ -83,15 +86,10 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 		etcdMachineSpec = *tb.etcdMachineSpec
 		etcdTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[tb.etcdMachineSpec.TemplateRef.Name]
 		if etcdTemplateConfig == nil {
-			disk, err := tb.diskExtractor.GetDisk(tb.etcdMachineSpec.HardwareSelector)
+			etcdTemplateConfig, err = tb.defaultTemplateConfig(clusterSpec, tb.etcdMachineSpec, "etcd")
 			if err != nil {
-				disk, err = tb.diskExtractor.GetDiskProvisionedHardware(tb.etcdMachineSpec.HardwareSelector)
-				if err != nil {
-					return nil, fmt.Errorf("getting etcd disk type of the hardware selector: %v", err)
-				}
+				return nil, err
 			}
-			versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily)
 		}
 		etcdTemplateString, err = etcdTemplateConfig.ToTemplateString()
 		if err != nil {
@@ -116,15 +114,10 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 		workerNodeMachineSpec := tb.WorkerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name]
 		wTemplateConfig := clusterSpec.TinkerbellTemplateConfigs[workerNodeMachineSpec.TemplateRef.Name]
 		if wTemplateConfig == nil {
-			disk, err := tb.diskExtractor.GetDisk(tb.WorkerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name].HardwareSelector)
+			wTemplateConfig, err = tb.defaultTemplateConfig(clusterSpec, &workerNodeMachineSpec, "worker node group "+workerNodeGroupConfiguration.Name)
 			if err != nil {
-				disk, err = tb.diskExtractor.GetDiskProvisionedHardware(tb.WorkerNodeGroupMachineSpecs[workerNodeGroupConfiguration.MachineGroupRef.Name].HardwareSelector)
-				if err != nil {
-					return nil, fmt.Errorf("getting worker node disk type of the hardware selector: %v", err)
-				}
+				return nil, err
 			}
-			versionBundle := clusterSpec.VersionsBundle.VersionsBundle
-			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily)
 		}
 
 		wTemplateString, err := wTemplateConfig.ToTemplateString()
@@ -156,6 +149,234 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 	return templater.AppendYamlResources(workerSpecs...), nil
 }
 
+// defaultTemplateConfig creates the default template of the machines matching the selector of
+// machineSpec. role identifies the machines in errors.
+func (tb *TemplateBuilder) defaultTemplateConfig(clusterSpec *cluster.Spec, machineSpec *v1alpha1.TinkerbellMachineConfigSpec, role string) (*v1alpha1.TinkerbellTemplateConfig, error) {
+	versionBundle := clusterSpec.VersionsBundle.VersionsBundle
+	newTemplateConfig := func(disk string) *v1alpha1.TinkerbellTemplateConfig {
+		return v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, machineSpec.OSFamily)
+	}
+
+	disk, err := tb.diskExtractor.GetDisk(machineSpec.HardwareSelector)
+	if err != nil {
+		disk, err = tb.diskExtractor.GetDiskProvisionedHardware(machineSpec.HardwareSelector)
+		if err != nil {
+			return nil, fmt.Errorf("getting %s disk type of the hardware selector: %v", role, err)
+		}
+	}
+
+	var templateConfig *v1alpha1.TinkerbellTemplateConfig
+	if machineSpec.InstallDisk == nil && len(machineSpec.AdditionalDisks) == 0 {
+		templateConfig = newTemplateConfig(disk)
+	} else {
+		templateConfig, err = tb.diskLayoutTemplateConfig(machineSpec, disk, newTemplateConfig)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", role, err)
+		}
+	}
+
+	if err := tb.applyNetplanTemplate(templateConfig, machineSpec); err != nil {
+		return nil, fmt.Errorf("%s: %v", role, err)
+	}
+
+	if machineSpec.OSFamily == v1alpha1.Bottlerocket {
+		applyBottlerocketBootconfig(templateConfig, machineSpec)
+	}
+
+	return templateConfig, nil
+}
+
+// applyBottlerocketBootconfig makes the write-bootconfig action of a default template pass the host OS
+// settings of machineSpec to the kernel, as the bottlerocket user-data can't carry them.
+func applyBottlerocketBootconfig(templateConfig *v1alpha1.TinkerbellTemplateConfig, machineSpec *v1alpha1.TinkerbellMachineConfigSpec) {
+	for _, task := range templateConfig.Spec.Template.Tasks {
+		for _, action := range task.Actions {
+			if action.Name == writeBootconfigAction {
+				action.Environment["BOOTCONFIG_CONTENTS"] = common.BottlerocketBootconfig(machineSpec.HostOSConfiguration)
+			}
+		}
+	}
+}
+
+// diskLayoutTemplateConfig creates a default template for each machine matching the selector of
+// machineSpec with the install disk and additional disks resolved for the machine, and merges them
+// into a single template choosing the values of each machine by its MAC address. Any other machine,
+// such as machines added to the pool later, is installed on defaultDisk, the disk of the hardware,
+// without additional disks. When the hardware has no disk, the install disk of the first machine is
+// used instead.
+func (tb *TemplateBuilder) diskLayoutTemplateConfig(machineSpec *v1alpha1.TinkerbellMachineConfigSpec, defaultDisk string, newTemplateConfig func(disk string) *v1alpha1.TinkerbellTemplateConfig) (*v1alpha1.TinkerbellTemplateConfig, error) {
+	if machineSpec.OSFamily == v1alpha1.Bottlerocket && len(machineSpec.AdditionalDisks) > 0 {
+		return nil, fmt.Errorf("additional disks are not supported with %s", v1alpha1.Bottlerocket)
+	}
+
+	if tb.diskLayoutExtractor == nil {
+		return nil, errors.New("disk layouts of the hardware are unknown")
+	}
+
+	layouts, err := tb.diskLayoutExtractor.GetDiskLayouts(machineSpec.HardwareSelector, machineSpec.InstallDisk, machineSpec.AdditionalDisks)
+	if err != nil {
+		return nil, fmt.Errorf("resolving disks: %v", err)
+	}
+
+	if defaultDisk == "" {
+		macs := make([]string, 0, len(layouts))
+		for mac := range layouts {
+			macs = append(macs, mac)
+		}
+		sort.Strings(macs)
+		defaultDisk = layouts[macs[0]].InstallDisk
+	}
+
+	withDisks := func(disk string, additional []hardware.ResolvedAdditionalDisk) (*v1alpha1.TinkerbellTemplateConfig, error) {
+		templateConfig := newTemplateConfig(disk)
+		if len(machineSpec.AdditionalDisks) == 0 {
+			return templateConfig, nil
+		}
+		if err := addAdditionalDisksAction(templateConfig, additional); err != nil {
+			return nil, err
+		}
+		return templateConfig, nil
+	}
+
+	templateConfigs := make(map[string]*v1alpha1.TinkerbellTemplateConfig, len(layouts))
+	for mac, layout := range layouts {
+		templateConfig, err := withDisks(layout.InstallDisk, layout.AdditionalDisks)
+		if err != nil {
+			return nil, err
+		}
+		templateConfigs[mac] = templateConfig
+	}
+
+	defaultTemplateConfig, err := withDisks(defaultDisk, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeTemplateConfigs(templateConfigs, defaultTemplateConfig)
+}
+
+// addAdditionalDisksAction adds an action writing a cloud-init configuration that formats and
+// mounts disks on first boot before the action rebooting into the installed operating system.
+// The configuration is empty when there are no disks.
+func addAdditionalDisksAction(templateConfig *v1alpha1.TinkerbellTemplateConfig, disks []hardware.ResolvedAdditionalDisk) error {
+	task := &templateConfig.Spec.Template.Tasks[0]
+	var netplan *tinkerbell.Action
+	for i := range task.Actions {
+		if task.Actions[i].Name == writeNetplanAction {
+			netplan = &task.Actions[i]
+		}
+	}
+	if netplan == nil {
+		return fmt.Errorf("template has no %s action to write additional disks configuration next to", writeNetplanAction)
+	}
+
+	var contents strings.Builder
+	if len(disks) == 0 {
+		contents.WriteString("fs_setup: []\nmounts: []\n")
+	} else {
+		contents.WriteString("fs_setup:\n")
+		for _, d := range disks {
+			fmt.Fprintf(&contents, "- device: %s\n  filesystem: %s\n  partition: none\n  overwrite: true\n", d.Device, d.FSType)
+		}
+		contents.WriteString("mounts:\n")
+		for _, d := range disks {
+			fmt.Fprintf(&contents, "- [%s, %s, %s, \"defaults,nofail\", \"0\", \"2\"]\n", d.Device, d.MountPath, d.FSType)
+		}
+	}
+
+	action := tinkerbell.Action{
+		Name:    additionalDisksAction,
+		Image:   netplan.Image,
+		Timeout: 90,
+		Environment: map[string]string{
+			"CONTENTS":  contents.String(),
+			"DEST_DISK": netplan.Environment["DEST_DISK"],
+			"DEST_PATH": "/etc/cloud/cloud.cfg.d/20_eks_anywhere_disks.cfg",
+			"DIRMODE":   "0700",
+			"FS_TYPE":   "ext4",
+			"GID":       "0",
+			"MODE":      "0600",
+			"UID":       "0",
+		},
+	}
+
+	last := len(task.Actions) - 1
+	task.Actions = append(task.Actions[:last], action, task.Actions[last])
+
+	return nil
+}
+
+// mergeTemplateConfigs merges templateConfigs, indexed by MAC address, into a single template
+// rendering the action environments of the machine running the workflow, or the action
+// environments of defaultTemplateConfig for any other machine. The templates must only differ by
+// the values of their action environments.
+func mergeTemplateConfigs(templateConfigs map[string]*v1alpha1.TinkerbellTemplateConfig, defaultTemplateConfig *v1alpha1.TinkerbellTemplateConfig) (*v1alpha1.TinkerbellTemplateConfig, error) {
+	macs := make([]string, 0, len(templateConfigs))
+	for mac := range templateConfigs {
+		macs = append(macs, mac)
+	}
+	sort.Strings(macs)
+
+	merged := templateConfigs[macs[0]]
+	for t, task := range merged.Spec.Template.Tasks {
+		for a, action := range task.Actions {
+			values := make(map[string]map[string]string, len(action.Environment))
+			for _, mac := range macs {
+				tasks := templateConfigs[mac].Spec.Template.Tasks
+				if len(tasks) != len(merged.Spec.Template.Tasks) || len(tasks[t].Actions) != len(task.Actions) ||
+					tasks[t].Actions[a].Name != action.Name || len(tasks[t].Actions[a].Environment) != len(action.Environment) {
+					return nil, fmt.Errorf("disks of hardware %s require different provisioning actions than hardware %s, use distinct hardware selectors", mac, macs[0])
+				}
+				for key, value := range tasks[t].Actions[a].Environment {
+					if _, ok := action.Environment[key]; !ok {
+						return nil, fmt.Errorf("disks of hardware %s require different provisioning actions than hardware %s, use distinct hardware selectors", mac, macs[0])
+					}
+					if values[key] == nil {
+						values[key] = make(map[string]string, len(macs))
+					}
+					values[key][mac] = value
+				}
+			}
+			defaults := defaultTemplateConfig.Spec.Template.Tasks[t].Actions[a].Environment
+			for key := range action.Environment {
+				action.Environment[key] = hardware.PerMachineValue(values[key], defaults[key])
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// applyNetplanTemplate makes the write-netplan action of a default template write the netplan
+// configuration of each machine matching the selector of machineSpec that has a network layout,
+// instead of a static configuration of the PXE interface. Any other machine, including machines
+// added to the pool later, keeps the static configuration.
+func (tb *TemplateBuilder) applyNetplanTemplate(templateConfig *v1alpha1.TinkerbellTemplateConfig, machineSpec *v1alpha1.TinkerbellMachineConfigSpec) error {
+	if tb.netplanExtractor == nil {
+		return nil
+	}
+
+	netplan, ok, err := tb.netplanExtractor.GetNetplanTemplate(machineSpec.HardwareSelector)
+	if err != nil || !ok {
+		return err
+	}
+
+	if machineSpec.OSFamily == v1alpha1.Bottlerocket {
+		return fmt.Errorf("hardware with multiple interfaces, bonds or vlans is not supported with %s", v1alpha1.Bottlerocket)
+	}
+
+	for _, task := range templateConfig.Spec.Template.Tasks {
+		for _, action := range task.Actions {
+			if action.Name == writeNetplanAction {
+				action.Environment["STATIC_NETPLAN"] = netplan.StaticNetplan
+				action.Environment["CONTENTS"] = netplan.Contents
+			}
+		}
+	}
+
+	return nil
+}
+
 func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := newClusterSpec.Cluster.Name
 	var controlPlaneTemplateName, workloadTemplateName, kubeadmconfigTemplateName, etcdTemplateName string
@@ -190,12 +411,17 @@ func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 	workloadTemplateNames := make(map[string]string, len(newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
 	kubeadmconfigTemplateNames := make(map[string]string, len(newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
 	for _, workerNodeGroupConfiguration := range newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
-		needsNewWorkloadTemplate, err := p.needsNewMachineTemplate(ctx, workloadCluster, currentSpec, newClusterSpec, workerNodeGroupConfiguration, vdc, previousWorkerNodeGroupConfigs)
+		oldWorkerMachineConfig, newWorkerMachineConfig, err := p.getWorkerNodeMachineConfigs(ctx, workloadCluster, newClusterSpec, workerNodeGroupConfiguration, previousWorkerNodeGroupConfigs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		needsNewWorkloadTemplate, err := p.needsNewMachineTemplate(currentSpec, newClusterSpec, workerNodeGroupConfiguration, vdc, previousWorkerNodeGroupConfigs, oldWorkerMachineConfig, newWorkerMachineConfig)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		needsNewKubeadmConfigTemplate, err := p.needsNewKubeadmConfigTemplate(workerNodeGroupConfiguration, previousWorkerNodeGroupConfigs)
+		needsNewKubeadmConfigTemplate, err := p.needsNewKubeadmConfigTemplate(workerNodeGroupConfiguration, previousWorkerNodeGroupConfigs, oldWorkerMachineConfig, newWorkerMachineConfig)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -341,23 +567,30 @@ func (p *Provider) GenerateStorageClass() []byte {
 	return nil
 }
 
-func (p *Provider) needsNewMachineTemplate(ctx context.Context, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, vdc *v1alpha1.TinkerbellDatacenterConfig, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration) (bool, error) {
+func (p *Provider) getWorkerNodeMachineConfigs(ctx context.Context, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration) (*v1alpha1.TinkerbellMachineConfig, *v1alpha1.TinkerbellMachineConfig, error) {
 	if _, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
-		workerMachineConfig := p.machineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name]
-		workerTmc, err := p.providerKubectlClient.GetEksaTinkerbellMachineConfig(ctx, workerNodeGroupConfiguration.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
+		newWorkerMachineConfig := p.machineConfigs[workerNodeGroupConfiguration.MachineGroupRef.Name]
+		oldWorkerMachineConfig, err := p.providerKubectlClient.GetEksaTinkerbellMachineConfig(ctx, workerNodeGroupConfiguration.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Cluster.Namespace)
 		if err != nil {
-			return false, err
+			return nil, nil, err
 		}
-		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, workerTmc, workerMachineConfig)
+		return oldWorkerMachineConfig, newWorkerMachineConfig, nil
+	}
+	return nil, nil, nil
+}
+
+func (p *Provider) needsNewMachineTemplate(currentSpec, newClusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, vdc *v1alpha1.TinkerbellDatacenterConfig, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration, oldWorkerMachineConfig, newWorkerMachineConfig *v1alpha1.TinkerbellMachineConfig) (bool, error) {
+	if prevWorkerNodeGroupConfig, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
+		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, prevWorkerNodeGroupConfig, workerNodeGroupConfiguration, vdc, p.datacenterConfig, oldWorkerMachineConfig, newWorkerMachineConfig)
 		return needsNewWorkloadTemplate, nil
 	}
 	return true, nil
 }
 
-func (p *Provider) needsNewKubeadmConfigTemplate(workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration) (bool, error) {
+func (p *Provider) needsNewKubeadmConfigTemplate(workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration, oldWorkerMachineConfig, newWorkerMachineConfig *v1alpha1.TinkerbellMachineConfig) (bool, error) {
 	if _, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
 		existingWorkerNodeGroupConfig := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]
-		return NeedsNewKubeadmConfigTemplate(&workerNodeGroupConfiguration, &existingWorkerNodeGroupConfig), nil
+		return NeedsNewKubeadmConfigTemplate(&workerNodeGroupConfiguration, &existingWorkerNodeGroupConfig, oldWorkerMachineConfig, newWorkerMachineConfig), nil
 	}
 	return true, nil
 }
@@ -407,10 +640,16 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
 		"kubeletExtraArgs":              kubeletExtraArgs.ToPartialYaml(),
 		"hardwareSelector":              controlPlaneMachineSpec.HardwareSelector,
+		"deprovisionHoldLabel":          deprovisionHoldLabel,
 		"controlPlaneTaints":            clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 		"workerNodeGroupConfigurations": clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations,
 	}
 
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = true
+		values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
 		values = populateRegistryMirrorValues(clusterSpec, values)
 		// Replace public.ecr.aws endpoint with the endpoint given in the cluster config file
@@ -441,11 +680,15 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		values["awsIamAuth"] = true
 	}
 
+	for key, value := range common.HostOSConfigTemplateValues(controlPlaneMachineSpec.HostOSConfiguration, controlPlaneMachineSpec.OSFamily) {
+		values[key] = value
+	}
+
 	return values
 }
 
 func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1alpha1.TinkerbellMachineConfigSpec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, workerTemplateOverride string) map[string]interface{} {
-	bundle := clusterSpec.VersionsBundle
+	bundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	format := "cloud-config"
 
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
@@ -462,9 +705,16 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys,
 		"workerSshUsername":      workerNodeGroupMachineSpec.Users[0].Name,
 		"hardwareSelector":       workerNodeGroupMachineSpec.HardwareSelector,
+		"deprovisionHoldLabel":   deprovisionHoldLabel,
 		"workerNodeGroupTaints":  workerNodeGroupConfiguration.Taints,
 	}
 
+	if workerNodeGroupConfiguration.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = true
+		values["maxSurge"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
+	}
+
 	if workerNodeGroupMachineSpec.OSFamily == v1alpha1.Bottlerocket {
 		values["format"] = string(v1alpha1.Bottlerocket)
 		values["pauseRepository"] = bundle.KubeDistro.Pause.Image()
@@ -482,6 +732,10 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 
 	values["workertemplateOverride"] = workerTemplateOverride
 
+	for key, value := range common.HostOSConfigTemplateValues(workerNodeGroupMachineSpec.HostOSConfiguration, workerNodeGroupMachineSpec.OSFamily) {
+		values[key] = value
+	}
+
 	return values
 }
 
@@ -0,0 +1,317 @@
+package tinkerbell
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// diskEnvVars are the action environment variables holding the disk or partition an action works on.
+var diskEnvVars = []string{"DEST_DISK", "BLOCK_DEVICE"}
+
+// partitionSuffix matches the suffix of a partition of a disk, e.g. 2 for /dev/sda2, p2 for
+// /dev/nvme0n1p2 or -part2 for /dev/disk/by-id/ata-A-part2.
+var partitionSuffix = regexp.MustCompile(`^(p|-part)?[0-9]+$`)
+
+// actionImage describes the environment required by a Tinkerbell action image of the bundle.
+type actionImage struct {
+	name     string
+	required []string
+	// oneOf lists variables of which at least one must be set.
+	oneOf []string
+}
+
+func bundleActionImages(bundle *releasev1alpha1.VersionsBundle) map[string]actionImage {
+	actions := bundle.Tinkerbell.TinkerbellStack.Actions
+	return map[string]actionImage{
+		actions.ImageToDisk.URI: {name: "image2disk", required: []string{"DEST_DISK", "IMG_URL"}},
+		actions.WriteFile.URI: {
+			name:     "writefile",
+			required: []string{"DEST_DISK", "DEST_PATH", "FS_TYPE"},
+			oneOf:    []string{"CONTENTS", "STATIC_NETPLAN", "BOOTCONFIG_CONTENTS", "HEGEL_URLS"},
+		},
+		actions.Kexec.URI:  {name: "kexec", required: []string{"BLOCK_DEVICE", "FS_TYPE"}},
+		actions.Reboot.URI: {name: "reboot"},
+	}
+}
+
+// NewDefaultTemplateConfig renders the default TinkerbellTemplateConfig for machineConfig using the
+// disks and network layout of the machines matching its hardware selector. The template is named
+// after the template referenced by machineConfig, or machineConfig itself when it has none.
+func NewDefaultTemplateConfig(spec *ClusterSpec, machineConfig *v1alpha1.TinkerbellMachineConfig, machines []hardware.Machine, tinkerbellIP string) (*v1alpha1.TinkerbellTemplateConfig, error) {
+	diskExtractor := hardware.NewDiskExtractor()
+	netplanExtractor := hardware.NewNetplanExtractor()
+	diskLayoutExtractor := hardware.NewDiskLayoutExtractor()
+	writer := hardware.MultiMachineWriter(diskExtractor, netplanExtractor, diskLayoutExtractor)
+
+	if err := diskExtractor.Register(machineConfig.Spec.HardwareSelector); err != nil {
+		return nil, err
+	}
+	if err := netplanExtractor.Register(machineConfig.Spec.HardwareSelector); err != nil {
+		return nil, err
+	}
+	if err := diskLayoutExtractor.Register(machineConfig.Spec.HardwareSelector); err != nil {
+		return nil, err
+	}
+	for _, m := range machines {
+		if err := writer.Write(m); err != nil {
+			return nil, err
+		}
+	}
+
+	tb := &TemplateBuilder{
+		datacenterSpec:      &spec.DatacenterConfig.Spec,
+		diskExtractor:       diskExtractor,
+		netplanExtractor:    netplanExtractor,
+		diskLayoutExtractor: diskLayoutExtractor,
+		tinkerbellIp:        tinkerbellIP,
+	}
+
+	templateConfig, err := tb.defaultTemplateConfig(spec.Spec, &machineConfig.Spec, "machine config "+machineConfig.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	templateConfig.Name = machineConfig.Name
+	if machineConfig.Spec.TemplateRef.Name != "" {
+		templateConfig.Name = machineConfig.Spec.TemplateRef.Name
+	}
+	templateConfig.Namespace = machineConfig.Namespace
+
+	return templateConfig, nil
+}
+
+// ValidateTemplateConfigs validates the TinkerbellTemplateConfigs of spec, see ValidateTemplateConfig.
+// Templates referenced by machine configs are validated against the machines matching the hardware
+// selector of every machine config referencing them.
+func ValidateTemplateConfigs(spec *ClusterSpec, machines []hardware.Machine) error {
+	var allErrs []error
+	machineConfigNames := make([]string, 0, len(spec.MachineConfigs))
+	for name := range spec.MachineConfigs {
+		machineConfigNames = append(machineConfigNames, name)
+	}
+	sort.Strings(machineConfigNames)
+
+	referenced := map[string][]hardware.Machine{}
+	for _, name := range machineConfigNames {
+		machineConfig := spec.MachineConfigs[name]
+		templateName := machineConfig.Spec.TemplateRef.Name
+		if templateName == "" {
+			continue
+		}
+		if _, ok := spec.TinkerbellTemplateConfigs[templateName]; !ok {
+			allErrs = append(allErrs, fmt.Errorf("TinkerbellMachineConfig %s: TinkerbellTemplateConfig %s not found", name, templateName))
+			continue
+		}
+		for _, m := range machines {
+			if hardware.LabelsMatchSelector(machineConfig.Spec.HardwareSelector, m.Labels) {
+				referenced[templateName] = append(referenced[templateName], m)
+			}
+		}
+	}
+
+	templateNames := make([]string, 0, len(spec.TinkerbellTemplateConfigs))
+	for name := range spec.TinkerbellTemplateConfigs {
+		templateNames = append(templateNames, name)
+	}
+	sort.Strings(templateNames)
+
+	for _, name := range templateNames {
+		templateConfig := spec.TinkerbellTemplateConfigs[name]
+		if err := ValidateTemplateConfig(templateConfig, spec.VersionsBundle.VersionsBundle, spec.Cluster.Spec.RegistryMirrorConfiguration, referenced[name]); err != nil {
+			allErrs = append(allErrs, fmt.Errorf("TinkerbellTemplateConfig %s: %v", name, err))
+		}
+	}
+
+	return utilerrors.NewAggregate(allErrs)
+}
+
+// ValidateTemplateConfig checks templateConfig can provision machines: every action image must be a
+// Tinkerbell action image of bundle, or be hosted in registryMirror when configured; actions using
+// the bundle images must set the environment variables those images require; and the disks actions
+// write to must be disks, or partitions of disks, of each machine. The template is rendered for each
+// machine as Tinkerbell does before checking its disks.
+func ValidateTemplateConfig(templateConfig *v1alpha1.TinkerbellTemplateConfig, bundle *releasev1alpha1.VersionsBundle, registryMirror *v1alpha1.RegistryMirrorConfiguration, machines []hardware.Machine) error {
+	workflow := templateConfig.Spec.Template
+	if len(workflow.Tasks) == 0 || len(workflow.Tasks[0].Actions) == 0 {
+		return errors.New("template has no actions")
+	}
+
+	var allErrs []error
+	images := bundleActionImages(bundle)
+	for _, task := range workflow.Tasks {
+		for _, action := range task.Actions {
+			image, err := resolveActionImage(action.Image, images, registryMirror)
+			if err != nil {
+				allErrs = append(allErrs, fmt.Errorf("action %s: %v", action.Name, err))
+				continue
+			}
+			if err := validateActionEnvironment(action, image); err != nil {
+				allErrs = append(allErrs, fmt.Errorf("action %s: %v", action.Name, err))
+			}
+		}
+	}
+
+	for _, m := range machines {
+		rendered, err := renderWorkflowForMachine(templateConfig, m)
+		if err != nil {
+			allErrs = append(allErrs, fmt.Errorf("hardware %s: %v", m.Hostname, err))
+			continue
+		}
+		for _, task := range rendered.Tasks {
+			for _, action := range task.Actions {
+				if err := validateActionDisks(action, m); err != nil {
+					allErrs = append(allErrs, fmt.Errorf("hardware %s: action %s: %v", m.Hostname, action.Name, err))
+				}
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(allErrs)
+}
+
+// resolveActionImage returns the bundle action image matching image, pulled from its original
+// registry or from registryMirror. Images outside the bundle are only accepted when hosted in
+// registryMirror, with no requirements on their environment.
+func resolveActionImage(image string, images map[string]actionImage, registryMirror *v1alpha1.RegistryMirrorConfiguration) (actionImage, error) {
+	if a, ok := images[image]; ok {
+		return a, nil
+	}
+
+	if registryMirror == nil {
+		return actionImage{}, fmt.Errorf("image %s is not a Tinkerbell action image of the bundle", image)
+	}
+
+	mirror := net.JoinHostPort(registryMirror.Endpoint, registryMirror.Port)
+	if a, ok := images[strings.Replace(image, mirror, defaultRegistry, 1)]; ok {
+		return a, nil
+	}
+	if strings.HasPrefix(image, mirror+"/") {
+		return actionImage{}, nil
+	}
+
+	return actionImage{}, fmt.Errorf("image %s is neither a Tinkerbell action image of the bundle nor hosted in registry mirror %s", image, mirror)
+}
+
+func validateActionEnvironment(action tinkerbell.Action, image actionImage) error {
+	var missing []string
+	for _, env := range image.required {
+		if action.Environment[env] == "" {
+			missing = append(missing, env)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing environment variables required by %s: %s", image.name, strings.Join(missing, ", "))
+	}
+
+	if len(image.oneOf) == 0 {
+		return nil
+	}
+	for _, env := range image.oneOf {
+		if action.Environment[env] != "" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s requires one of the environment variables: %s", image.name, strings.Join(image.oneOf, ", "))
+}
+
+// validateActionDisks checks the disks action works on are disks of m or partitions of them.
+func validateActionDisks(action tinkerbell.Action, m hardware.Machine) error {
+	devices := machineDevices(m)
+	for _, env := range diskEnvVars {
+		value, ok := action.Environment[env]
+		if !ok {
+			continue
+		}
+		if !isDeviceOrPartition(value, devices) {
+			return fmt.Errorf("%s %s is not a disk of the hardware: %s", env, value, strings.Join(devices, ", "))
+		}
+	}
+
+	return nil
+}
+
+func machineDevices(m hardware.Machine) []string {
+	var devices []string
+	if m.Disk != "" {
+		devices = append(devices, m.Disk)
+	}
+	for _, d := range m.Disks {
+		devices = append(devices, d.Device)
+		if d.ID != "" {
+			devices = append(devices, d.ID)
+		}
+	}
+	return devices
+}
+
+func isDeviceOrPartition(value string, devices []string) bool {
+	for _, device := range devices {
+		if value == device || (strings.HasPrefix(value, device) && partitionSuffix.MatchString(strings.TrimPrefix(value, device))) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderWorkflowForMachine renders the workflow of templateConfig with the data Tinkerbell provides
+// when running it on m.
+func renderWorkflowForMachine(templateConfig *v1alpha1.TinkerbellTemplateConfig, m hardware.Machine) (*tinkerbell.Workflow, error) {
+	templateString, err := templateConfig.ToTemplateString()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("workflow").Funcs(workflowTemplateFuncs).Option("missingkey=error").Parse(templateString)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %v", err)
+	}
+
+	disks := make([]map[string]string, 0, len(machineDevices(m)))
+	for _, device := range machineDevices(m) {
+		disks = append(disks, map[string]string{"Device": device})
+	}
+	data := map[string]interface{}{
+		"device_1": strings.ToLower(m.MACAddress),
+		"Hardware": map[string]interface{}{"Disks": disks},
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("rendering template: %v", err)
+	}
+
+	workflow := &tinkerbell.Workflow{}
+	if err := yaml.Unmarshal(rendered.Bytes(), workflow); err != nil {
+		return nil, fmt.Errorf("parsing rendered template: %v", err)
+	}
+
+	return workflow, nil
+}
+
+// workflowTemplateFuncs are the functions Tinkerbell makes available to workflow templates.
+var workflowTemplateFuncs = template.FuncMap{
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"formatPartition": func(dev string, partition int) string {
+		if strings.HasPrefix(dev, "/dev/nvme") {
+			return fmt.Sprintf("%sp%d", dev, partition)
+		}
+		return fmt.Sprintf("%s%d", dev, partition)
+	},
+}
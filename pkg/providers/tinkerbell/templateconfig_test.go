@@ -0,0 +1,246 @@
+package tinkerbell
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func templateConfigVersionsBundle() *releasev1alpha1.VersionsBundle {
+	bundle := &releasev1alpha1.VersionsBundle{}
+	bundle.EksD.Raw.Bottlerocket.URI = "http://tinkerbell-example:8080/bottlerocket.img.gz"
+	actions := &bundle.Tinkerbell.TinkerbellStack.Actions
+	actions.ImageToDisk.URI = "public.ecr.aws/eks-anywhere/image2disk:latest"
+	actions.WriteFile.URI = "public.ecr.aws/eks-anywhere/writefile:latest"
+	actions.Kexec.URI = "public.ecr.aws/eks-anywhere/kexec:latest"
+	actions.Reboot.URI = "public.ecr.aws/eks-anywhere/reboot:latest"
+	return bundle
+}
+
+func newTemplateConfigClusterSpec(machineConfigs ...*v1alpha1.TinkerbellMachineConfig) *ClusterSpec {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test"
+		s.VersionsBundle.VersionsBundle = templateConfigVersionsBundle()
+	})
+	configs := make(map[string]*v1alpha1.TinkerbellMachineConfig, len(machineConfigs))
+	for _, c := range machineConfigs {
+		configs[c.Name] = c
+	}
+	return NewClusterSpec(clusterSpec, configs, &v1alpha1.TinkerbellDatacenterConfig{
+		Spec: v1alpha1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "5.6.7.8"},
+	})
+}
+
+func newTemplateConfigMachineConfig(name string, osFamily v1alpha1.OSFamily) *v1alpha1.TinkerbellMachineConfig {
+	return &v1alpha1.TinkerbellMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.TinkerbellMachineConfigSpec{
+			HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+			OSFamily:         osFamily,
+		},
+	}
+}
+
+func templateConfigWorker(hostname, mac, disk string) hardware.Machine {
+	return hardware.Machine{
+		Hostname:   hostname,
+		MACAddress: mac,
+		Disk:       disk,
+		Labels:     hardware.Labels{"type": "worker"},
+	}
+}
+
+func TestNewDefaultTemplateConfig(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := newTemplateConfigMachineConfig("test-md", v1alpha1.Ubuntu)
+	machineConfig.Spec.TemplateRef.Name = "test-md-template"
+	spec := newTemplateConfigClusterSpec(machineConfig)
+	machines := []hardware.Machine{templateConfigWorker("worker1", "00:00:00:00:00:01", "/dev/sda")}
+
+	templateConfig, err := NewDefaultTemplateConfig(spec, machineConfig, machines, "1.2.3.4")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(templateConfig.Name).To(Equal("test-md-template"))
+
+	actions := templateConfig.Spec.Template.Tasks[0].Actions
+	g.Expect(actions[0].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sda"))
+	g.Expect(actions[len(actions)-1].Name).To(Equal("kexec-image"))
+	g.Expect(ValidateTemplateConfig(templateConfig, spec.VersionsBundle.VersionsBundle, nil, machines)).To(Succeed())
+}
+
+func TestNewDefaultTemplateConfigBottlerocket(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := newTemplateConfigMachineConfig("test-md", v1alpha1.Bottlerocket)
+	spec := newTemplateConfigClusterSpec(machineConfig)
+	machines := []hardware.Machine{templateConfigWorker("worker1", "00:00:00:00:00:01", "/dev/nvme0n1")}
+
+	templateConfig, err := NewDefaultTemplateConfig(spec, machineConfig, machines, "1.2.3.4")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(templateConfig.Name).To(Equal("test-md"))
+	g.Expect(templateConfig.Spec.Template.Tasks[0].Actions[1].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/nvme0n1p12"))
+	g.Expect(ValidateTemplateConfig(templateConfig, spec.VersionsBundle.VersionsBundle, nil, machines)).To(Succeed())
+}
+
+func TestNewDefaultTemplateConfigNoHardware(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := newTemplateConfigMachineConfig("test-md", v1alpha1.Ubuntu)
+	spec := newTemplateConfigClusterSpec(machineConfig)
+
+	_, err := NewDefaultTemplateConfig(spec, machineConfig, nil, "1.2.3.4")
+	g.Expect(err).To(MatchError(ContainSubstring("getting machine config test-md disk type of the hardware selector")))
+}
+
+func TestValidateTemplateConfigPerMachineDisks(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := newTemplateConfigMachineConfig("test-md", v1alpha1.Ubuntu)
+	machineConfig.Spec.InstallDisk = &v1alpha1.DiskSelector{}
+	spec := newTemplateConfigClusterSpec(machineConfig)
+	machines := []hardware.Machine{
+		multiDiskWorker("00:00:00:00:00:01", hardware.Disk{Device: "/dev/sda"}),
+		multiDiskWorker("00:00:00:00:00:02", hardware.Disk{Device: "/dev/sdb"}),
+	}
+
+	templateConfig, err := NewDefaultTemplateConfig(spec, machineConfig, machines, "1.2.3.4")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ValidateTemplateConfig(templateConfig, spec.VersionsBundle.VersionsBundle, nil, machines)).To(Succeed())
+
+	// The template of the first machine doesn't match the disk of the second one.
+	machines[1].Disk, machines[1].Disks = "/dev/sdc", hardware.Disks{{Device: "/dev/sdc"}}
+	err = ValidateTemplateConfig(templateConfig, spec.VersionsBundle.VersionsBundle, nil, machines)
+	g.Expect(err).To(MatchError(ContainSubstring("hardware worker-000000000002: action stream-image: DEST_DISK /dev/sdb is not a disk of the hardware: /dev/sdc")))
+}
+
+func TestValidateTemplateConfigErrors(t *testing.T) {
+	bundle := templateConfigVersionsBundle()
+	machines := []hardware.Machine{templateConfigWorker("worker1", "00:00:00:00:00:01", "/dev/sda")}
+	mirror := &v1alpha1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4", Port: "443"}
+
+	tests := []struct {
+		name           string
+		modify         func(*v1alpha1.TinkerbellTemplateConfig)
+		registryMirror *v1alpha1.RegistryMirrorConfiguration
+		wantErr        string
+	}{
+		{
+			name:   "valid",
+			modify: func(*v1alpha1.TinkerbellTemplateConfig) {},
+		},
+		{
+			name: "no actions",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions = nil
+			},
+			wantErr: "template has no actions",
+		},
+		{
+			name: "image not in bundle",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions[0].Image = "quay.io/tinkerbell-actions/image2disk:v1.0.0"
+			},
+			wantErr: "action stream-image: image quay.io/tinkerbell-actions/image2disk:v1.0.0 is not a Tinkerbell action image of the bundle",
+		},
+		{
+			name: "bundle image from registry mirror",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions[0].Image = "1.2.3.4:443/eks-anywhere/image2disk:latest"
+			},
+			registryMirror: mirror,
+		},
+		{
+			name: "bundle image from registry mirror missing environment",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions[0].Image = "1.2.3.4:443/eks-anywhere/image2disk:latest"
+				delete(c.Spec.Template.Tasks[0].Actions[0].Environment, "IMG_URL")
+			},
+			registryMirror: mirror,
+			wantErr:        "action stream-image: missing environment variables required by image2disk: IMG_URL",
+		},
+		{
+			name: "custom image from registry mirror",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions[0].Image = "1.2.3.4:443/custom/image2disk:v1.0.0"
+				c.Spec.Template.Tasks[0].Actions[0].Environment = nil
+			},
+			registryMirror: mirror,
+		},
+		{
+			name: "image not in registry mirror",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions[0].Image = "quay.io/tinkerbell-actions/image2disk:v1.0.0"
+			},
+			registryMirror: mirror,
+			wantErr:        "image quay.io/tinkerbell-actions/image2disk:v1.0.0 is neither a Tinkerbell action image of the bundle nor hosted in registry mirror 1.2.3.4:443",
+		},
+		{
+			name: "missing contents",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				delete(c.Spec.Template.Tasks[0].Actions[1].Environment, "STATIC_NETPLAN")
+			},
+			wantErr: "action write-netplan: writefile requires one of the environment variables: CONTENTS, STATIC_NETPLAN, BOOTCONFIG_CONTENTS, HEGEL_URLS",
+		},
+		{
+			name: "disk not in hardware",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions[0].Environment["DEST_DISK"] = "/dev/sdb"
+			},
+			wantErr: "hardware worker1: action stream-image: DEST_DISK /dev/sdb is not a disk of the hardware: /dev/sda",
+		},
+		{
+			name: "partition not in hardware",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].Actions[5].Environment["BLOCK_DEVICE"] = "/dev/sdb2"
+			},
+			wantErr: "hardware worker1: action kexec-image: BLOCK_DEVICE /dev/sdb2 is not a disk of the hardware: /dev/sda",
+		},
+		{
+			name: "invalid template",
+			modify: func(c *v1alpha1.TinkerbellTemplateConfig) {
+				c.Spec.Template.Tasks[0].WorkerAddr = "{{.unknown}}"
+			},
+			wantErr: "hardware worker1: rendering template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			templateConfig := v1alpha1.NewDefaultTinkerbellTemplateConfigCreate("test", *bundle, "/dev/sda", "", "1.2.3.4", "5.6.7.8", v1alpha1.Ubuntu)
+			tt.modify(templateConfig)
+
+			err := ValidateTemplateConfig(templateConfig, bundle, tt.registryMirror, machines)
+			if tt.wantErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateTemplateConfigs(t *testing.T) {
+	g := NewWithT(t)
+	cp := newTemplateConfigMachineConfig("test-cp", v1alpha1.Ubuntu)
+	cp.Spec.HardwareSelector = v1alpha1.HardwareSelector{"type": "cp"}
+	cp.Spec.TemplateRef.Name = "missing"
+	worker := newTemplateConfigMachineConfig("test-md", v1alpha1.Ubuntu)
+	worker.Spec.TemplateRef.Name = "worker-template"
+	spec := newTemplateConfigClusterSpec(cp, worker)
+	spec.TinkerbellTemplateConfigs = map[string]*v1alpha1.TinkerbellTemplateConfig{
+		"worker-template": v1alpha1.NewDefaultTinkerbellTemplateConfigCreate("test", *spec.VersionsBundle.VersionsBundle, "/dev/sdb", "", "1.2.3.4", "5.6.7.8", v1alpha1.Ubuntu),
+	}
+	machines := []hardware.Machine{
+		templateConfigWorker("worker1", "00:00:00:00:00:01", "/dev/sda"),
+		{Hostname: "cp1", MACAddress: "00:00:00:00:00:02", Disk: "/dev/sdb", Labels: hardware.Labels{"type": "cp"}},
+	}
+
+	err := ValidateTemplateConfigs(spec, machines)
+	g.Expect(err).To(MatchError(ContainSubstring("TinkerbellMachineConfig test-cp: TinkerbellTemplateConfig missing not found")))
+	g.Expect(err).To(MatchError(ContainSubstring("TinkerbellTemplateConfig worker-template: [hardware worker1: action stream-image: DEST_DISK /dev/sdb is not a disk of the hardware: /dev/sda")))
+	g.Expect(err.Error()).ToNot(ContainSubstring("cp1"))
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
@@ -16,10 +18,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
 	"github.com/aws/eks-anywhere/pkg/retrier"
@@ -56,21 +60,34 @@ type Provider struct {
 	hardwareCSVFile string
 	catalogue       *hardware.Catalogue
 	diskExtractor   hardware.DiskExtractor
-	tinkerbellIp    string
+	// netplanExtractor collects the network configuration of hardware with multiple interfaces.
+	netplanExtractor *hardware.NetplanExtractor
+	// diskLayoutExtractor collects hardware to resolve install and additional disks selected by attributes.
+	diskLayoutExtractor *hardware.DiskLayoutExtractor
+	tinkerbellIp        string
 
 	// TODO(chrisdoheryt4) Temporarily depend on the netclient until the validator can be injected.
 	// This is already a dependency, just uncached, because we require it during the initializing
 	// constructor call for constructing the validator in-line.
 	netClient networkutils.NetClient
 
+	artifactDownloader ArtifactDownloader
+	bmcChecker         BMCChecker
+
+	// deprovisioner wipes and powers off the hardware recorded in deprovisionHardware, the hardware
+	// of the cluster with a deprovision policy, once it leaves the cluster.
+	deprovisioner       *deprovisioner
+	deprovisionHardware map[string]v1alpha1.DeprovisionPolicy
+
 	forceCleanup bool
-	skipIpCheck  bool
 	retrier      *retrier.Retrier
 }
 
 type ProviderKubectlClient interface {
+	Apply(ctx context.Context, kubeconfig string, obj runtime.Object) error
 	ApplyKubeSpec(ctx context.Context, cluster *types.Cluster, spec string) error
 	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
+	Delete(ctx context.Context, resourceType, name, namespace, kubeconfig string) error
 	DeleteEksaDatacenterConfig(ctx context.Context, eksaTinkerbellDatacenterResourceType string, tinkerbellDatacenterConfigName string, kubeconfigFile string, namespace string) error
 	DeleteEksaMachineConfig(ctx context.Context, eksaTinkerbellMachineResourceType string, tinkerbellMachineConfigName string, kubeconfigFile string, namespace string) error
 	GetMachineDeployment(ctx context.Context, machineDeploymentName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
@@ -84,6 +101,11 @@ type ProviderKubectlClient interface {
 	WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error
 	GetUnprovisionedTinkerbellHardware(_ context.Context, kubeconfig, namespace string) ([]tinkv1alpha1.Hardware, error)
 	GetProvisionedTinkerbellHardware(_ context.Context, kubeconfig, namespace string) ([]tinkv1alpha1.Hardware, error)
+	GetTinkerbellMachineNames(ctx context.Context, kubeconfig, namespace, clusterName string) ([]string, error)
+	GetTinkerbellHardware(ctx context.Context, name, namespace, kubeconfig string) (*tinkv1alpha1.Hardware, error)
+	PatchTinkerbellHardware(ctx context.Context, name, namespace, kubeconfig string, patch []byte) (*tinkv1alpha1.Hardware, error)
+	GetTinkerbellWorkflow(ctx context.Context, name, namespace, kubeconfig string) (*tinkv1alpha1.Workflow, error)
+	GetRufioBMCJob(ctx context.Context, name, namespace, kubeconfig string) (*rufiov1alpha1.BMCJob, error)
 	WaitForBaseboardManagements(ctx context.Context, cluster *types.Cluster, timeout string, condition string, namespace string) error
 }
 
@@ -104,9 +126,10 @@ func NewProvider(
 	tinkerbellIp string,
 	now types.NowFunc,
 	forceCleanup bool,
-	skipIpCheck bool,
 ) (*Provider, error) {
 	diskExtractor := hardware.NewDiskExtractor()
+	netplanExtractor := hardware.NewNetplanExtractor()
+	diskLayoutExtractor := hardware.NewDiskLayoutExtractor()
 	var controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
 	if clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] != nil {
 		controlPlaneMachineSpec = &machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec
@@ -114,6 +137,12 @@ func NewProvider(
 		if err != nil {
 			return nil, err
 		}
+		if err := netplanExtractor.Register(controlPlaneMachineSpec.HardwareSelector); err != nil {
+			return nil, err
+		}
+		if err := diskLayoutExtractor.Register(controlPlaneMachineSpec.HardwareSelector); err != nil {
+			return nil, err
+		}
 	}
 	workerNodeGroupMachineSpecs := make(map[string]v1alpha1.TinkerbellMachineConfigSpec, len(machineConfigs))
 	for _, wnConfig := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
@@ -124,6 +153,12 @@ func NewProvider(
 			if err != nil {
 				return nil, err
 			}
+			if err := netplanExtractor.Register(workerNodeGroupMachineSpecs[wnConfig.MachineGroupRef.Name].HardwareSelector); err != nil {
+				return nil, err
+			}
+			if err := diskLayoutExtractor.Register(workerNodeGroupMachineSpecs[wnConfig.MachineGroupRef.Name].HardwareSelector); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
@@ -133,6 +168,12 @@ func NewProvider(
 			if err != nil {
 				return nil, err
 			}
+			if err := netplanExtractor.Register(etcdMachineSpec.HardwareSelector); err != nil {
+				return nil, err
+			}
+			if err := diskLayoutExtractor.Register(etcdMachineSpec.HardwareSelector); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -155,6 +196,8 @@ func NewProvider(
 			WorkerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
 			etcdMachineSpec:             etcdMachineSpec,
 			diskExtractor:               diskExtractor,
+			netplanExtractor:            netplanExtractor,
+			diskLayoutExtractor:         diskLayoutExtractor,
 			tinkerbellIp:                tinkerbellIp,
 			now:                         now,
 		},
@@ -168,17 +211,21 @@ func NewProvider(
 			hardware.WithBMCNameIndex(),
 			hardware.WithSecretNameIndex(),
 		),
-		diskExtractor: *diskExtractor,
-		tinkerbellIp:  tinkerbellIp,
-		netClient:     &networkutils.DefaultNetClient{},
-		retrier:       retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		diskExtractor:       *diskExtractor,
+		netplanExtractor:    netplanExtractor,
+		diskLayoutExtractor: diskLayoutExtractor,
+		tinkerbellIp:        tinkerbellIp,
+		netClient:           &networkutils.DefaultNetClient{},
+		artifactDownloader:  files.NewReader(),
+		bmcChecker:          bmc.NewManager(),
+		retrier:             retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		deprovisioner:       newDeprovisioner(providerKubectlClient),
 		// (chrisdoherty4) We're hard coding the dependency and monkey patching in testing because the provider
 		// isn't very testable right now and we already have tests in the `tinkerbell` package so can monkey patch
 		// directly. This is very much a hack for testability.
 		keyGenerator: common.SshAuthKeyGenerator{},
 		// Behavioral flags.
 		forceCleanup: forceCleanup,
-		skipIpCheck:  skipIpCheck,
 	}, nil
 }
 
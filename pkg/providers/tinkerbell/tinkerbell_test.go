@@ -1,21 +1,33 @@
 package tinkerbell
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"path"
+	"strings"
 	"testing"
+	"text/template"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	filewritermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
 	stackmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const (
@@ -57,15 +69,56 @@ func newProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineC
 		testIP,
 		test.FakeNow,
 		forceCleanup,
-		false,
 	)
 	if err != nil {
 		panic(err)
 	}
+	provider.bmcChecker = reachableBMCs{}
 
 	return provider
 }
 
+// reachableBMCs is a BMCChecker for which every BMC answers.
+type reachableBMCs struct{}
+
+func (reachableBMCs) CheckConnection(context.Context, bmc.Connection) error {
+	return nil
+}
+
+// unreachableBMCs is a BMCChecker for which no BMC answers.
+type unreachableBMCs struct{}
+
+func (unreachableBMCs) CheckConnection(context.Context, bmc.Connection) error {
+	return errors.New("connection refused")
+}
+
+func TestTinkerbellProviderCreateClusterChecksBMCReachable(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = clusterConfig
+	})
+	provider := newProvider(givenDatacenterConfig(t, clusterSpecManifest), givenMachineConfigs(t, clusterSpecManifest), clusterConfig,
+		filewritermocks.NewMockFileWriter(mockCtrl), stackmocks.NewMockDocker(mockCtrl), stackmocks.NewMockHelm(mockCtrl), mocks.NewMockProviderKubectlClient(mockCtrl), false)
+	g.Expect(provider.readCSVToCatalogue()).To(Succeed())
+	provider.bmcChecker = unreachableBMCs{}
+
+	var bmcCheck *providers.Check
+	for _, check := range provider.CreateClusterChecks(ctx, clusterSpec) {
+		if check.ID == providers.BMCReachableCheckID {
+			bmcCheck = &check
+		}
+	}
+
+	g.Expect(bmcCheck).NotTo(BeNil())
+	g.Expect(bmcCheck.Validate()).To(MatchError(ContainSubstring("BMC not reachable for hardware")))
+}
+
 func TestTinkerbellProviderGenerateDeploymentFileWithExternalEtcd(t *testing.T) {
 	t.Skip("External etcd unsupported for GA")
 	clusterSpecManifest := "cluster_tinkerbell_external_etcd.yaml"
@@ -648,3 +701,363 @@ func TestProviderGenerateDeploymentFileForSingleNodeCluster(t *testing.T) {
 	}
 	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp_single_node.yaml")
 }
+
+func newNetplanTemplateBuilder(t *testing.T, machines ...hardware.Machine) *TemplateBuilder {
+	extractor := hardware.NewNetplanExtractor()
+	if err := extractor.Register(v1alpha1.HardwareSelector{"type": "worker"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range machines {
+		if err := extractor.Write(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &TemplateBuilder{netplanExtractor: extractor}
+}
+
+func bondedWorker() hardware.Machine {
+	return hardware.Machine{
+		Hostname:    "worker1",
+		IPAddress:   "10.10.10.10",
+		Netmask:     "255.255.255.0",
+		Gateway:     "10.10.10.1",
+		Nameservers: hardware.Nameservers{"1.1.1.1"},
+		MACAddress:  "00:00:00:00:00:01",
+		Labels:      hardware.Labels{"type": "worker"},
+		Interfaces: hardware.NetworkInterfaces{
+			{Name: "eno1", MAC: "00:00:00:00:00:01"},
+			{Name: "eno2", MAC: "00:00:00:00:00:02"},
+		},
+		Bonds: hardware.Bonds{{Name: "bond0", Interfaces: []string{"eno1", "eno2"}}},
+	}
+}
+
+func TestTemplateBuilderApplyNetplanTemplate(t *testing.T) {
+	g := NewWithT(t)
+	tb := newNetplanTemplateBuilder(t, bondedWorker())
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{
+		HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		OSFamily:         v1alpha1.Ubuntu,
+	}
+	templateConfig := v1alpha1.NewDefaultTinkerbellTemplateConfigCreate("test", releasev1alpha1.VersionsBundle{}, "/dev/sda", "", "1.2.3.4", "5.6.7.8", v1alpha1.Ubuntu)
+
+	g.Expect(tb.applyNetplanTemplate(templateConfig, machineSpec)).To(Succeed())
+
+	// Render the template as Tinkerbell does for the machine running the workflow.
+	templateString, err := templateConfig.ToTemplateString()
+	g.Expect(err).ToNot(HaveOccurred())
+	var rendered bytes.Buffer
+	g.Expect(template.Must(template.New("workflow").Parse(templateString)).Execute(&rendered, map[string]string{"device_1": "00:00:00:00:00:01"})).To(Succeed())
+
+	workflow := tinkerbell.Workflow{}
+	g.Expect(yaml.Unmarshal(rendered.Bytes(), &workflow)).To(Succeed())
+	var netplan tinkerbell.Action
+	for _, action := range workflow.Tasks[0].Actions {
+		if action.Name == writeNetplanAction {
+			netplan = action
+		}
+	}
+	g.Expect(netplan.Environment).To(HaveKeyWithValue("STATIC_NETPLAN", "false"))
+	g.Expect(netplan.Environment["CONTENTS"]).To(ContainSubstring("bond0:"))
+	g.Expect(netplan.Environment["CONTENTS"]).To(ContainSubstring("- 10.10.10.10/24"))
+}
+
+func TestTemplateBuilderApplyNetplanTemplateMixedGroup(t *testing.T) {
+	g := NewWithT(t)
+	singleInterfaceWorker := bondedWorker()
+	singleInterfaceWorker.Hostname = "worker2"
+	singleInterfaceWorker.IPAddress = "10.10.10.11"
+	singleInterfaceWorker.MACAddress = "00:00:00:00:00:03"
+	singleInterfaceWorker.Interfaces, singleInterfaceWorker.Bonds = nil, nil
+	tb := newNetplanTemplateBuilder(t, bondedWorker(), singleInterfaceWorker)
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{
+		HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		OSFamily:         v1alpha1.Ubuntu,
+	}
+	templateConfig := v1alpha1.NewDefaultTinkerbellTemplateConfigCreate("test", releasev1alpha1.VersionsBundle{}, "/dev/sda", "", "1.2.3.4", "5.6.7.8", v1alpha1.Ubuntu)
+
+	g.Expect(tb.applyNetplanTemplate(templateConfig, machineSpec)).To(Succeed())
+	templateString, err := templateConfig.ToTemplateString()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Render the template as Tinkerbell does for each machine running the workflow.
+	renderNetplan := func(mac string) tinkerbell.Action {
+		var rendered bytes.Buffer
+		g.Expect(template.Must(template.New("workflow").Parse(templateString)).Execute(&rendered, map[string]string{"device_1": mac})).To(Succeed())
+		workflow := tinkerbell.Workflow{}
+		g.Expect(yaml.Unmarshal(rendered.Bytes(), &workflow)).To(Succeed())
+		for _, action := range workflow.Tasks[0].Actions {
+			if action.Name == writeNetplanAction {
+				return action
+			}
+		}
+		t.Fatalf("no %s action for %s", writeNetplanAction, mac)
+		return tinkerbell.Action{}
+	}
+
+	bonded := renderNetplan("00:00:00:00:00:01")
+	g.Expect(bonded.Environment).To(HaveKeyWithValue("STATIC_NETPLAN", "false"))
+	g.Expect(bonded.Environment["CONTENTS"]).To(ContainSubstring("bond0:"))
+
+	// Machines without a network layout, including machines added to the pool later, keep the static netplan.
+	for _, mac := range []string{"00:00:00:00:00:03", "00:00:00:00:00:99"} {
+		single := renderNetplan(mac)
+		g.Expect(single.Environment).To(HaveKeyWithValue("STATIC_NETPLAN", "true"), mac)
+		g.Expect(single.Environment["CONTENTS"]).To(BeEmpty(), mac)
+	}
+}
+
+func TestTemplateBuilderApplyNetplanTemplateSingleInterface(t *testing.T) {
+	g := NewWithT(t)
+	worker := bondedWorker()
+	worker.Interfaces, worker.Bonds = nil, nil
+	tb := newNetplanTemplateBuilder(t, worker)
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"}}
+	templateConfig := v1alpha1.NewDefaultTinkerbellTemplateConfigCreate("test", releasev1alpha1.VersionsBundle{}, "/dev/sda", "", "1.2.3.4", "5.6.7.8", v1alpha1.Ubuntu)
+
+	g.Expect(tb.applyNetplanTemplate(templateConfig, machineSpec)).To(Succeed())
+	g.Expect(templateConfig.Spec.Template.Tasks[0].Actions[1].Environment).To(HaveKeyWithValue("STATIC_NETPLAN", "true"))
+}
+
+func TestTemplateBuilderApplyNetplanTemplateBottlerocket(t *testing.T) {
+	g := NewWithT(t)
+	tb := newNetplanTemplateBuilder(t, bondedWorker())
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{
+		HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		OSFamily:         v1alpha1.Bottlerocket,
+	}
+	templateConfig := v1alpha1.NewDefaultTinkerbellTemplateConfigCreate("test", releasev1alpha1.VersionsBundle{}, "/dev/sda", "", "1.2.3.4", "5.6.7.8", v1alpha1.Bottlerocket)
+
+	g.Expect(tb.applyNetplanTemplate(templateConfig, machineSpec)).To(MatchError(ContainSubstring("not supported with bottlerocket")))
+}
+
+func newDiskLayoutTemplateBuilder(t *testing.T, machines ...hardware.Machine) *TemplateBuilder {
+	diskExtractor := hardware.NewDiskExtractor()
+	extractor := hardware.NewDiskLayoutExtractor()
+	if err := diskExtractor.Register(v1alpha1.HardwareSelector{"type": "worker"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractor.Register(v1alpha1.HardwareSelector{"type": "worker"}); err != nil {
+		t.Fatal(err)
+	}
+	writer := hardware.MultiMachineWriter(diskExtractor, extractor)
+	for _, m := range machines {
+		if err := writer.Write(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &TemplateBuilder{
+		datacenterSpec:      &v1alpha1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "5.6.7.8"},
+		tinkerbellIp:        "1.2.3.4",
+		diskExtractor:       diskExtractor,
+		diskLayoutExtractor: extractor,
+	}
+}
+
+func multiDiskWorker(mac string, disks ...hardware.Disk) hardware.Machine {
+	var disk string
+	if len(disks) > 0 {
+		disk = disks[0].Device
+	}
+	return hardware.Machine{
+		Hostname:    "worker-" + strings.ReplaceAll(mac, ":", ""),
+		IPAddress:   "10.10.10.10",
+		Netmask:     "255.255.255.0",
+		Gateway:     "10.10.10.1",
+		Nameservers: hardware.Nameservers{"1.1.1.1"},
+		MACAddress:  mac,
+		Labels:      hardware.Labels{"type": "worker"},
+		Disk:        disk,
+		Disks:       disks,
+	}
+}
+
+// renderWorkflow renders templateConfig as Tinkerbell does for the machine with the PXE MAC address mac.
+func renderWorkflow(t *testing.T, templateConfig *v1alpha1.TinkerbellTemplateConfig, mac string) map[string]tinkerbell.Action {
+	templateString, err := templateConfig.ToTemplateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rendered bytes.Buffer
+	if err := template.Must(template.New("workflow").Parse(templateString)).Execute(&rendered, map[string]string{"device_1": mac}); err != nil {
+		t.Fatal(err)
+	}
+	workflow := tinkerbell.Workflow{}
+	if err := yaml.Unmarshal(rendered.Bytes(), &workflow); err != nil {
+		t.Fatal(err)
+	}
+	actions := map[string]tinkerbell.Action{}
+	for _, action := range workflow.Tasks[0].Actions {
+		actions[action.Name] = action
+	}
+	return actions
+}
+
+func TestTemplateBuilderDefaultTemplateConfigDiskLayout(t *testing.T) {
+	g := NewWithT(t)
+	small, large := resource.MustParse("480Gi"), resource.MustParse("2Ti")
+	tb := newDiskLayoutTemplateBuilder(t,
+		multiDiskWorker("00:00:00:00:00:01",
+			hardware.Disk{Device: "/dev/sda", Size: &large},
+			hardware.Disk{Device: "/dev/sdb", Size: &small},
+		),
+		multiDiskWorker("00:00:00:00:00:02",
+			hardware.Disk{Device: "/dev/sdc", Size: &small, ID: "/dev/disk/by-id/ata-A"},
+			hardware.Disk{Device: "/dev/sdd", Size: &large, ID: "/dev/disk/by-id/ata-B"},
+		),
+	)
+	maxSize := resource.MustParse("1Ti")
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{
+		HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		OSFamily:         v1alpha1.Ubuntu,
+		InstallDisk:      &v1alpha1.DiskSelector{MaxSize: &maxSize},
+		AdditionalDisks:  []v1alpha1.AdditionalDisk{{MountPath: "/var/lib/containerd"}},
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test"
+	})
+
+	templateConfig, err := tb.defaultTemplateConfig(clusterSpec, machineSpec, "worker node group md-0")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	actions := renderWorkflow(t, templateConfig, "00:00:00:00:00:01")
+	g.Expect(actions["stream-image"].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sdb"))
+	g.Expect(actions[writeNetplanAction].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sdb2"))
+	g.Expect(actions[additionalDisksAction].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sdb2"))
+	g.Expect(actions[additionalDisksAction].Environment["CONTENTS"]).To(ContainSubstring("- [/dev/sda, /var/lib/containerd, ext4, "))
+
+	actions = renderWorkflow(t, templateConfig, "00:00:00:00:00:02")
+	g.Expect(actions["stream-image"].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sdc"))
+	g.Expect(actions[writeNetplanAction].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sdc2"))
+	g.Expect(actions[additionalDisksAction].Environment["CONTENTS"]).To(ContainSubstring("- device: /dev/disk/by-id/ata-B"))
+
+	// Machines added to the pool later are installed on the disk of their hardware without additional disks.
+	actions = renderWorkflow(t, templateConfig, "00:00:00:00:00:99")
+	g.Expect(actions["stream-image"].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sda"))
+	g.Expect(actions[writeNetplanAction].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sda2"))
+	g.Expect(actions[additionalDisksAction].Environment).To(HaveKeyWithValue("DEST_DISK", "/dev/sda2"))
+	g.Expect(actions[additionalDisksAction].Environment).To(HaveKeyWithValue("CONTENTS", "fs_setup: []\nmounts: []\n"))
+}
+
+func TestTemplateBuilderDefaultTemplateConfigDiskLayoutDifferentActions(t *testing.T) {
+	g := NewWithT(t)
+	tb := newDiskLayoutTemplateBuilder(t,
+		multiDiskWorker("00:00:00:00:00:01", hardware.Disk{Device: "/dev/sda"}),
+		multiDiskWorker("00:00:00:00:00:02", hardware.Disk{Device: "/dev/nvme0n1"}),
+	)
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{
+		HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		OSFamily:         v1alpha1.Ubuntu,
+		InstallDisk:      &v1alpha1.DiskSelector{},
+	}
+	clusterSpec := test.NewClusterSpec()
+
+	// Ubuntu on NVMe disks reboots instead of using kexec.
+	_, err := tb.defaultTemplateConfig(clusterSpec, machineSpec, "worker node group md-0")
+	g.Expect(err).To(MatchError(ContainSubstring("require different provisioning actions")))
+}
+
+func TestTemplateBuilderDefaultTemplateConfigAdditionalDisksBottlerocket(t *testing.T) {
+	g := NewWithT(t)
+	tb := newDiskLayoutTemplateBuilder(t, multiDiskWorker("00:00:00:00:00:01", hardware.Disk{Device: "/dev/sda"}, hardware.Disk{Device: "/dev/sdb"}))
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{
+		HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		OSFamily:         v1alpha1.Bottlerocket,
+		AdditionalDisks:  []v1alpha1.AdditionalDisk{{MountPath: "/data"}},
+	}
+
+	_, err := tb.defaultTemplateConfig(test.NewClusterSpec(), machineSpec, "worker node group md-0")
+	g.Expect(err).To(MatchError(ContainSubstring("not supported with bottlerocket")))
+}
+
+func TestTemplateBuilderDefaultTemplateConfigBottlerocketHostOSConfig(t *testing.T) {
+	g := NewWithT(t)
+	tb := newDiskLayoutTemplateBuilder(t, multiDiskWorker("00:00:00:00:00:01", hardware.Disk{Device: "/dev/sda"}))
+	machineSpec := &v1alpha1.TinkerbellMachineConfigSpec{
+		HardwareSelector: v1alpha1.HardwareSelector{"type": "worker"},
+		OSFamily:         v1alpha1.Bottlerocket,
+		HostOSConfiguration: &v1alpha1.HostOSConfiguration{
+			SysctlSettings: map[string]string{"vm.max_map_count": "262144"},
+			BottlerocketConfiguration: &v1alpha1.BottlerocketConfiguration{
+				Boot: &v1alpha1.BottlerocketBootSettings{
+					BootKernelParameters: map[string][]string{"console": {"tty0"}},
+				},
+			},
+		},
+	}
+
+	templateConfig, err := tb.defaultTemplateConfig(test.NewClusterSpec(), machineSpec, "worker node group md-0")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	actions := renderWorkflow(t, templateConfig, "00:00:00:00:00:01")
+	g.Expect(actions[writeBootconfigAction].Environment).To(HaveKeyWithValue("BOOTCONFIG_CONTENTS", "kernel {\n    console = \"tty0\"\n    sysctl.vm.max_map_count = \"262144\"\n}"))
+}
+
+func TestBottlerocketBootconfigChanged(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := func(osFamily v1alpha1.OSFamily, sysctl map[string]string) *v1alpha1.TinkerbellMachineConfig {
+		return &v1alpha1.TinkerbellMachineConfig{
+			Spec: v1alpha1.TinkerbellMachineConfigSpec{
+				OSFamily:            osFamily,
+				HostOSConfiguration: &v1alpha1.HostOSConfiguration{SysctlSettings: sysctl},
+			},
+		}
+	}
+	old := machineConfig(v1alpha1.Bottlerocket, map[string]string{"vm.max_map_count": "1"})
+
+	g.Expect(bottlerocketBootconfigChanged(old, machineConfig(v1alpha1.Bottlerocket, map[string]string{"vm.max_map_count": "2"}))).To(BeTrue())
+	g.Expect(bottlerocketBootconfigChanged(old, machineConfig(v1alpha1.Bottlerocket, map[string]string{"vm.max_map_count": "1"}))).To(BeFalse())
+	g.Expect(bottlerocketBootconfigChanged(old, machineConfig(v1alpha1.Ubuntu, map[string]string{"vm.max_map_count": "2"}))).To(BeFalse())
+	g.Expect(bottlerocketBootconfigChanged(nil, old)).To(BeFalse())
+}
+
+func TestWorkerNodeGroupKubernetesVersionsChanged(t *testing.T) {
+	kube122 := v1alpha1.Kube122
+	kube121 := v1alpha1.Kube121
+	specWithWorkers := func(workers ...v1alpha1.WorkerNodeGroupConfiguration) *cluster.Spec {
+		return test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Spec.KubernetesVersion = v1alpha1.Kube123
+			s.Cluster.Spec.WorkerNodeGroupConfigurations = workers
+		})
+	}
+	tests := []struct {
+		name        string
+		currentSpec *cluster.Spec
+		newSpec     *cluster.Spec
+		want        bool
+	}{
+		{
+			name:        "no changes",
+			currentSpec: specWithWorkers(v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0", KubernetesVersion: &kube122}),
+			newSpec:     specWithWorkers(v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0", KubernetesVersion: &kube122}),
+			want:        false,
+		},
+		{
+			name:        "group upgraded to the cluster version",
+			currentSpec: specWithWorkers(v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0", KubernetesVersion: &kube122}),
+			newSpec:     specWithWorkers(v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}),
+			want:        true,
+		},
+		{
+			name:        "group version changed",
+			currentSpec: specWithWorkers(v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0", KubernetesVersion: &kube121}),
+			newSpec:     specWithWorkers(v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0", KubernetesVersion: &kube122}),
+			want:        true,
+		},
+		{
+			name:        "new group with a different version",
+			currentSpec: specWithWorkers(v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}),
+			newSpec: specWithWorkers(
+				v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"},
+				v1alpha1.WorkerNodeGroupConfiguration{Name: "md-1", KubernetesVersion: &kube122},
+			),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(workerNodeGroupKubernetesVersionsChanged(tt.currentSpec, tt.newSpec)).To(Equal(tt.want))
+		})
+	}
+}
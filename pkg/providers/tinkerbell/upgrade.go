@@ -8,6 +8,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -25,12 +27,15 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if bottlerocketBootconfigChanged(oldTmc, newTmc) {
+		return true
+	}
 
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldTmc, newTmc)
 }
 
-func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.TinkerbellDatacenterConfig, oldTmc, newTmc *v1alpha1.TinkerbellMachineConfig) bool {
-	if oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
+func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldWorkerNodeGroup, newWorkerNodeGroup v1alpha1.WorkerNodeGroupConfiguration, oldVdc, newVdc *v1alpha1.TinkerbellDatacenterConfig, oldTmc, newTmc *v1alpha1.TinkerbellMachineConfig) bool {
+	if oldSpec.Cluster.WorkerNodeGroupKubernetesVersion(oldWorkerNodeGroup) != newSpec.Cluster.WorkerNodeGroupKubernetesVersion(newWorkerNodeGroup) {
 		return true
 	}
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
@@ -40,11 +45,24 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1
 		!v1alpha1.WorkerNodeGroupConfigurationsLabelsMapEqual(oldSpec.Cluster.Spec.WorkerNodeGroupConfigurations, newSpec.Cluster.Spec.WorkerNodeGroupConfigurations) {
 		return true
 	}
+	if bottlerocketBootconfigChanged(oldTmc, newTmc) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldTmc, newTmc)
 }
 
-func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration) bool {
-	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.LabelsMapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels)
+// bottlerocketBootconfigChanged returns true if the boot configuration written on bottlerocket machines changes,
+// which is part of the workflow template of the machines and not of their kubeadm config.
+func bottlerocketBootconfigChanged(oldTmc, newTmc *v1alpha1.TinkerbellMachineConfig) bool {
+	if oldTmc == nil || newTmc == nil || newTmc.Spec.OSFamily != v1alpha1.Bottlerocket {
+		return false
+	}
+	return common.BottlerocketBootconfig(oldTmc.Spec.HostOSConfiguration) != common.BottlerocketBootconfig(newTmc.Spec.HostOSConfiguration)
+}
+
+func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeTmc *v1alpha1.TinkerbellMachineConfig, newWorkerNodeTmc *v1alpha1.TinkerbellMachineConfig) bool {
+	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.LabelsMapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels) ||
+		!oldWorkerNodeTmc.Spec.HostOSConfiguration.Equal(newWorkerNodeTmc.Spec.HostOSConfiguration)
 }
 
 func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.TinkerbellDatacenterConfig, oldTmc, newTmc *v1alpha1.TinkerbellMachineConfig) bool {
@@ -61,6 +79,11 @@ func AnyImmutableFieldChanged(oldVdc, newVdc *v1alpha1.TinkerbellDatacenterConfi
 	return false
 }
 
+func (p *Provider) UpgradeClusterChecks(_ context.Context, _ *cluster.Spec) []providers.Check {
+	// No checks
+	return nil
+}
+
 func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, currentClusterSpec *cluster.Spec) error {
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
 		return ErrExternalEtcdUnsupported
@@ -75,7 +98,7 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 	if p.hardwareCSVIsProvided() {
 		machineCatalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
 
-		writer := hardware.MultiMachineWriter(machineCatalogueWriter, &p.diskExtractor)
+		writer := hardware.MultiMachineWriter(machineCatalogueWriter, &p.diskExtractor, p.netplanExtractor, p.diskLayoutExtractor)
 
 		machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile)
 		if err != nil {
@@ -105,12 +128,22 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 		return fmt.Errorf("retrieving unprovisioned hardware: %v", err)
 	}
 	for i := range hardware {
+		// Hardware held for deprovisioning can't be selected until it's wiped.
+		if _, ok := hardware[i].Labels[deprovisionHoldLabel]; ok {
+			continue
+		}
 		if err := p.catalogue.InsertHardware(&hardware[i]); err != nil {
 			return err
 		}
 		if err := p.diskExtractor.InsertDisks(&hardware[i]); err != nil {
 			return err
 		}
+		if err := p.netplanExtractor.InsertHardware(&hardware[i]); err != nil {
+			return err
+		}
+		if err := p.diskLayoutExtractor.InsertHardware(&hardware[i]); err != nil {
+			return err
+		}
 	}
 
 	// Retrieve all provisioned hardware from the existing cluster and populate diskExtractors's
@@ -127,6 +160,12 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 		if err := p.diskExtractor.InsertProvisionedHardwareDisks(&hardware[i]); err != nil {
 			return err
 		}
+		if err := p.netplanExtractor.InsertHardware(&hardware[i]); err != nil {
+			return err
+		}
+		if err := p.diskLayoutExtractor.InsertHardware(&hardware[i]); err != nil {
+			return err
+		}
 	}
 
 	// Remove all the provisioned hardware from the existing cluster if repeated from the hardware csv input.
@@ -143,7 +182,7 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 	)
 
 	rollingUpgrade := false
-	if currentSpec.Cluster.Spec.KubernetesVersion != newClusterSpec.Cluster.Spec.KubernetesVersion {
+	if currentSpec.Cluster.Spec.KubernetesVersion != newClusterSpec.Cluster.Spec.KubernetesVersion || workerNodeGroupKubernetesVersionsChanged(currentSpec, newClusterSpec) {
 		clusterSpecValidator.Register(ExtraHardwareAvailableAssertionForRollingUpgrade(p.catalogue, maxSurgeForRollingUpgrade))
 		rollingUpgrade = true
 	}
@@ -159,6 +198,23 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 	return nil
 }
 
+// workerNodeGroupKubernetesVersionsChanged returns true if the Kubernetes version of any existing
+// worker node group changes, which rolls out new machines for that group.
+func workerNodeGroupKubernetesVersionsChanged(currentSpec, newClusterSpec *cluster.Spec) bool {
+	currentVersions := make(map[string]v1alpha1.KubernetesVersion, len(currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		currentVersions[w.Name] = currentSpec.Cluster.WorkerNodeGroupKubernetesVersion(w)
+	}
+
+	for _, w := range newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if currentVersion, ok := currentVersions[w.Name]; ok && currentVersion != newClusterSpec.Cluster.WorkerNodeGroupKubernetesVersion(w) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p *Provider) PostBootstrapDeleteForUpgrade(ctx context.Context) error {
 	if err := p.stackInstaller.UninstallLocal(ctx); err != nil {
 		return err
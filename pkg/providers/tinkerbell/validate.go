@@ -1,15 +1,20 @@
 package tinkerbell
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path"
+	"sort"
 	"strings"
+	"sync"
 
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/bmc"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
 
@@ -59,6 +64,86 @@ func validateMachineConfig(config *v1alpha1.TinkerbellMachineConfig) error {
 		)
 	}
 
+	if err := v1alpha1.ValidateHostOSConfig(config.Spec.HostOSConfiguration, config.Spec.OSFamily); err != nil {
+		return fmt.Errorf("TinkerbellMachineConfig: %v: %v", err, config.Name)
+	}
+
+	if err := validateDiskSelection(config.Spec); err != nil {
+		return fmt.Errorf("TinkerbellMachineConfig: %v: %v", err, config.Name)
+	}
+
+	if err := validateDeprovisionPolicy(config.Spec.DeprovisionPolicy); err != nil {
+		return fmt.Errorf("TinkerbellMachineConfig: %v: %v", err, config.Name)
+	}
+
+	return nil
+}
+
+func validateDeprovisionPolicy(policy *v1alpha1.DeprovisionPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	if policy.WipeMethod != v1alpha1.SecureEraseDiskWipe && policy.WipeMethod != v1alpha1.ZeroDiskWipe {
+		return fmt.Errorf(
+			"spec.deprovisionPolicy: unsupported wipeMethod (%v); Please use one of the following: %s, %s",
+			policy.WipeMethod,
+			v1alpha1.SecureEraseDiskWipe,
+			v1alpha1.ZeroDiskWipe,
+		)
+	}
+
+	if policy.WipeImage == "" {
+		return errors.New("spec.deprovisionPolicy: missing wipeImage")
+	}
+
+	return nil
+}
+
+func validateDiskSelection(spec v1alpha1.TinkerbellMachineConfigSpec) error {
+	if spec.InstallDisk != nil {
+		if err := validateDiskSelector(*spec.InstallDisk); err != nil {
+			return fmt.Errorf("spec.installDisk: %v", err)
+		}
+	}
+
+	if len(spec.AdditionalDisks) > 0 && spec.OSFamily == v1alpha1.Bottlerocket {
+		return fmt.Errorf("spec.additionalDisks is not supported with %s", v1alpha1.Bottlerocket)
+	}
+
+	mountPaths := make(map[string]bool, len(spec.AdditionalDisks))
+	for _, disk := range spec.AdditionalDisks {
+		if !path.IsAbs(disk.MountPath) || path.Clean(disk.MountPath) == "/" {
+			return fmt.Errorf("spec.additionalDisks: mountPath must be an absolute path other than /: %v", disk.MountPath)
+		}
+		if mountPaths[path.Clean(disk.MountPath)] {
+			return fmt.Errorf("spec.additionalDisks: duplicate mountPath %v", disk.MountPath)
+		}
+		mountPaths[path.Clean(disk.MountPath)] = true
+
+		if disk.FSType != "" && disk.FSType != "ext4" && disk.FSType != "xfs" {
+			return fmt.Errorf("spec.additionalDisks: unsupported fsType (%v); Please use one of the following: ext4, xfs", disk.FSType)
+		}
+
+		if err := validateDiskSelector(disk.Selector); err != nil {
+			return fmt.Errorf("spec.additionalDisks: %v: %v", disk.MountPath, err)
+		}
+	}
+
+	return nil
+}
+
+func validateDiskSelector(selector v1alpha1.DiskSelector) error {
+	if selector.MinSize != nil && selector.MaxSize != nil && selector.MinSize.Cmp(*selector.MaxSize) > 0 {
+		return fmt.Errorf("minSize %v is greater than maxSize %v", selector.MinSize, selector.MaxSize)
+	}
+
+	if selector.ByID != "" {
+		if _, err := path.Match(selector.ByID, ""); err != nil {
+			return fmt.Errorf("byID: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -270,3 +355,59 @@ func getHardwareSelectorsAsStrings(selectors []v1alpha1.HardwareSelector) ([]str
 	}
 	return slctrs, nil
 }
+
+// bmcConnectionFromCatalogue builds the connection to the catalogued BMC named name using the
+// credentials from its catalogued secret.
+func bmcConnectionFromCatalogue(catalogue *hardware.Catalogue, name string) (bmc.Connection, error) {
+	bmcs, err := catalogue.LookupBMC(hardware.BMCNameIndex, name)
+	if err != nil {
+		return bmc.Connection{}, err
+	}
+	if len(bmcs) == 0 {
+		return bmc.Connection{}, fmt.Errorf("BMC %s not found", name)
+	}
+	connection := bmcs[0].Spec.Connection
+
+	secrets, err := catalogue.LookupSecret(hardware.SecretNameIndex, connection.AuthSecretRef.Name)
+	if err != nil {
+		return bmc.Connection{}, err
+	}
+	if len(secrets) == 0 {
+		return bmc.Connection{}, fmt.Errorf("BMC secret %s not found", connection.AuthSecretRef.Name)
+	}
+
+	return bmc.Connection{
+		Host:     connection.Host,
+		Username: string(secrets[0].Data["username"]),
+		Password: string(secrets[0].Data["password"]),
+	}, nil
+}
+
+// validateBMCsReachable checks all the BMC connections, indexed by hardware name, concurrently and
+// returns an error listing the hardware whose BMC didn't answer.
+func validateBMCsReachable(ctx context.Context, checker BMCChecker, connections map[string]bmc.Connection) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []string
+	)
+	for name, conn := range connections {
+		wg.Add(1)
+		go func(name string, conn bmc.Connection) {
+			defer wg.Done()
+			if err := checker.CheckConnection(ctx, conn); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				failures = append(failures, fmt.Sprintf("%s (%v)", name, err))
+			}
+		}(name, conn)
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("BMC not reachable for hardware: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
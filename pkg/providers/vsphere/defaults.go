@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/templates"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -74,7 +75,7 @@ func (d *Defaulter) setDefaultTemplateIfMissing(ctx context.Context, spec *Spec,
 
 func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) error {
 	osFamily := machineConfig.Spec.OSFamily
-	eksd := spec.VersionsBundle.EksD
+	eksd := machineConfigVersionsBundle(spec.Spec, machineConfig).EksD
 	var ova releasev1.Archive
 	switch osFamily {
 	case anywherev1.Bottlerocket:
@@ -92,7 +93,7 @@ func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machin
 	templateFactory := templates.NewFactory(d.govc, spec.datacenterConfig.Spec.Datacenter, machineConfig.Spec.Datastore, spec.datacenterConfig.Spec.Network, machineConfig.Spec.ResourcePool, defaultTemplateLibrary)
 
 	// TODO: remove the factory's dependency on a machineConfig
-	if err := templateFactory.CreateIfMissing(ctx, spec.datacenterConfig.Spec.Datacenter, machineConfig, ova.URI, tags); err != nil {
+	if err := templateFactory.CreateIfMissing(ctx, spec.datacenterConfig.Spec.Datacenter, machineConfig, ova.URI, files.ArchiveChecksums(ova), tags); err != nil {
 		return err
 	}
 
@@ -0,0 +1,212 @@
+package vsphere
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+//go:embed config/template-failure-domains.yaml
+var defaultFailureDomainsConfig string
+
+const (
+	controlPlaneAntiAffinityRule = "control-plane-anti-affinity"
+	etcdAntiAffinityRule         = "etcd-anti-affinity"
+)
+
+type failureDomainValues struct {
+	Name           string
+	ComputeCluster string
+	ResourcePool   string
+	Datastore      string
+	Folder         string
+	Network        string
+	ControlPlane   bool
+}
+
+// FailureDomainName returns the name of the CAPV VSphereFailureDomain and VSphereDeploymentZone
+// created for a failure domain of the cluster. Both objects are cluster scoped, so the name is prefixed
+// with the cluster name to avoid clashes between clusters.
+func FailureDomainName(clusterName, failureDomain string) string {
+	return fmt.Sprintf("%s-%s", clusterName, failureDomain)
+}
+
+func workerFailureDomain(clusterName string, machineSpec v1alpha1.VSphereMachineConfigSpec) string {
+	if len(machineSpec.FailureDomains) != 1 {
+		return ""
+	}
+	return FailureDomainName(clusterName, machineSpec.FailureDomains[0])
+}
+
+func buildFailureDomainsValues(clusterName string, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec v1alpha1.VSphereMachineConfigSpec) []failureDomainValues {
+	controlPlaneFailureDomains := make(map[string]struct{}, len(controlPlaneMachineSpec.FailureDomains))
+	for _, name := range controlPlaneMachineSpec.FailureDomains {
+		controlPlaneFailureDomains[name] = struct{}{}
+	}
+
+	values := make([]failureDomainValues, 0, len(datacenterSpec.FailureDomains))
+	for _, fd := range datacenterSpec.FailureDomains {
+		_, controlPlane := controlPlaneFailureDomains[fd.Name]
+		values = append(values, failureDomainValues{
+			Name:           FailureDomainName(clusterName, fd.Name),
+			ComputeCluster: fd.ComputeCluster,
+			ResourcePool:   fd.ResourcePool,
+			Datastore:      fd.Datastore,
+			Folder:         fd.Folder,
+			Network:        fd.Network,
+			ControlPlane:   controlPlane,
+		})
+	}
+
+	return values
+}
+
+// generateFailureDomainsSpec renders the CAPV failure domains and deployment zones for the datacenter config failure domains.
+// It returns nil if the datacenter config doesn't define any failure domain.
+func generateFailureDomainsSpec(clusterName string, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec v1alpha1.VSphereMachineConfigSpec) ([]byte, error) {
+	if len(datacenterSpec.FailureDomains) == 0 {
+		return nil, nil
+	}
+
+	values := map[string]interface{}{
+		"clusterName":         clusterName,
+		"vsphereDatacenter":   datacenterSpec.Datacenter,
+		"vsphereServer":       datacenterSpec.Server,
+		"failureDomainRegion": datacenterSpec.Datacenter,
+		"failureDomains":      buildFailureDomainsValues(clusterName, datacenterSpec, controlPlaneMachineSpec),
+	}
+
+	return templater.Execute(defaultFailureDomainsConfig, values)
+}
+
+// generateFailureDomainsSpec renders the failure domains of the provider datacenter config for the cluster.
+// It returns nil if the datacenter config doesn't define any failure domain.
+func (p *vsphereProvider) generateFailureDomainsSpec(clusterSpec *cluster.Spec) ([]byte, error) {
+	if p.datacenterConfig == nil || len(p.datacenterConfig.Spec.FailureDomains) == 0 {
+		return nil, nil
+	}
+
+	var controlPlaneMachineSpec v1alpha1.VSphereMachineConfigSpec
+	if ref := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef; ref != nil {
+		if machineConfig, ok := p.machineConfigs[ref.Name]; ok && machineConfig != nil {
+			controlPlaneMachineSpec = machineConfig.Spec
+		}
+	}
+
+	return generateFailureDomainsSpec(clusterSpec.Cluster.Name, p.datacenterConfig.Spec, controlPlaneMachineSpec)
+}
+
+func validateMachineConfigFailureDomains(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig) error {
+	seen := make(map[string]struct{}, len(machineConfig.Spec.FailureDomains))
+	for _, name := range machineConfig.Spec.FailureDomains {
+		if datacenterConfig.FailureDomain(name) == nil {
+			return fmt.Errorf("VSphereMachineConfig %s references failure domain %s which is not defined in VSphereDatacenterConfig %s", machineConfig.Name, name, datacenterConfig.Name)
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("VSphereMachineConfig %s references failure domain %s more than once", machineConfig.Name, name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	return nil
+}
+
+func (p *vsphereProvider) createAntiAffinityRules(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	machines, err := p.providerKubectlClient.GetMachines(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return fmt.Errorf("getting machines for anti-affinity rules: %v", err)
+	}
+
+	controlPlaneMachineConfig := p.machineConfigs[clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	if err := p.createAntiAffinityRule(ctx, clusterSpec.Cluster.Name, controlPlaneAntiAffinityRule, machines, clusterv1.MachineControlPlaneLabelName, controlPlaneMachineConfig); err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdMachineConfig := p.machineConfigs[clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
+		if err := p.createAntiAffinityRule(ctx, clusterSpec.Cluster.Name, etcdAntiAffinityRule, machines, clusterv1.MachineEtcdClusterLabelName, etcdMachineConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createAntiAffinityRule creates one DRS VM-VM anti-affinity rule per compute cluster for the VMs of the machines with the given label.
+func (p *vsphereProvider) createAntiAffinityRule(ctx context.Context, clusterName, rule string, machines []types.Machine, label string, machineConfig *v1alpha1.VSphereMachineConfig) error {
+	vmsByComputeCluster := map[string][]string{}
+	for _, machine := range machines {
+		if !machine.HasAnyLabel([]string{label}) {
+			continue
+		}
+		computeCluster, folder := p.machinePlacement(clusterName, machine, machineConfig)
+		if computeCluster == "" {
+			logger.V(3).Info("Skipping anti-affinity rule for machine, unable to determine its compute cluster", "machine", machine.Metadata.Name)
+			continue
+		}
+		vmsByComputeCluster[computeCluster] = append(vmsByComputeCluster[computeCluster], path.Join(folder, machine.Metadata.Name))
+	}
+
+	computeClusters := make([]string, 0, len(vmsByComputeCluster))
+	for computeCluster := range vmsByComputeCluster {
+		computeClusters = append(computeClusters, computeCluster)
+	}
+	sort.Strings(computeClusters)
+
+	name := fmt.Sprintf("%s-%s", clusterName, rule)
+	for _, computeCluster := range computeClusters {
+		vms := vmsByComputeCluster[computeCluster]
+		if len(vms) < 2 {
+			continue
+		}
+		sort.Strings(vms)
+		logger.V(3).Info("Creating DRS anti-affinity rule", "rule", name, "computeCluster", computeCluster)
+		if err := p.providerGovcClient.CreateVMAntiAffinityRule(ctx, computeCluster, name, vms); err != nil {
+			return fmt.Errorf("creating anti-affinity rule %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// machinePlacement returns the compute cluster and VM folder of a machine's VM.
+func (p *vsphereProvider) machinePlacement(clusterName string, machine types.Machine, machineConfig *v1alpha1.VSphereMachineConfig) (computeCluster, folder string) {
+	folder = machineConfig.Spec.Folder
+	if folder == "" {
+		folder = fmt.Sprintf("/%s/vm", p.datacenterConfig.Spec.Datacenter)
+	}
+
+	if machine.Spec.FailureDomain != "" {
+		fd := p.datacenterConfig.FailureDomain(strings.TrimPrefix(machine.Spec.FailureDomain, clusterName+"-"))
+		if fd == nil {
+			return "", folder
+		}
+		if fd.Folder != "" {
+			folder = fd.Folder
+		}
+		return fd.ComputeCluster, folder
+	}
+
+	return computeClusterFromResourcePool(machineConfig.Spec.ResourcePool), folder
+}
+
+// computeClusterFromResourcePool returns the compute cluster path of a resource pool inventory path,
+// i.e. /<datacenter>/host/<cluster> for /<datacenter>/host/<cluster>/Resources/<pool>.
+func computeClusterFromResourcePool(resourcePool string) string {
+	computeCluster, _, found := strings.Cut(resourcePool, "/Resources")
+	if !found || !strings.Contains(computeCluster, "/host/") {
+		return ""
+	}
+	return computeCluster
+}
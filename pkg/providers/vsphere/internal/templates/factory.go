@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/internal/tags"
 )
@@ -30,7 +31,7 @@ type GovcClient interface {
 	CreateLibrary(ctx context.Context, datastore, library string) error
 	DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, library, datacenter, datastore, network, resourcePool string, resizeBRDisk bool) error
 	SearchTemplate(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig) (string, error)
-	ImportTemplate(ctx context.Context, library, ovaURL, name string) error
+	ImportTemplate(ctx context.Context, library, ovaURL, name string, checksums files.Checksums) error
 	LibraryElementExists(ctx context.Context, library string) (bool, error)
 	GetLibraryElementContentVersion(ctx context.Context, element string) (string, error)
 	DeleteLibraryElement(ctx context.Context, element string) error
@@ -53,7 +54,7 @@ func NewFactory(client GovcClient, datacenter, datastore, network, resourcePool,
 	}
 }
 
-func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig, ovaURL string, tagsByCategory map[string][]string) error {
+func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig, ovaURL string, ovaChecksums files.Checksums, tagsByCategory map[string][]string) error {
 	templateFullPath, err := f.client.SearchTemplate(ctx, datacenter, machineConfig)
 	if err != nil {
 		return fmt.Errorf("checking for template: %v", err)
@@ -67,7 +68,7 @@ func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machin
 	logger.V(2).Info("Template not available. Creating", "template", machineConfig.Spec.Template)
 
 	osFamily := machineConfig.Spec.OSFamily
-	if err = f.createTemplate(ctx, machineConfig.Spec.Template, ovaURL, string(osFamily)); err != nil {
+	if err = f.createTemplate(ctx, machineConfig.Spec.Template, ovaURL, ovaChecksums, string(osFamily)); err != nil {
 		return err
 	}
 
@@ -77,7 +78,7 @@ func (f *Factory) CreateIfMissing(ctx context.Context, datacenter string, machin
 	return nil
 }
 
-func (f *Factory) createTemplate(ctx context.Context, templatePath, ovaURL, osFamily string) error {
+func (f *Factory) createTemplate(ctx context.Context, templatePath, ovaURL string, ovaChecksums files.Checksums, osFamily string) error {
 	if err := f.createLibraryIfMissing(ctx); err != nil {
 		return err
 	}
@@ -86,7 +87,7 @@ func (f *Factory) createTemplate(ctx context.Context, templatePath, ovaURL, osFa
 	templateName := filepath.Base(templatePath)
 	templateDir := filepath.Dir(templatePath)
 
-	if err := f.importOVAIfMissing(ctx, templateName, ovaURL); err != nil {
+	if err := f.importOVAIfMissing(ctx, templateName, ovaURL, ovaChecksums); err != nil {
 		return err
 	}
 
@@ -117,7 +118,7 @@ func (f *Factory) createLibraryIfMissing(ctx context.Context) error {
 	return nil
 }
 
-func (f *Factory) importOVAIfMissing(ctx context.Context, templateName, ovaURL string) error {
+func (f *Factory) importOVAIfMissing(ctx context.Context, templateName, ovaURL string, ovaChecksums files.Checksums) error {
 	contentVersion, err := f.client.GetLibraryElementContentVersion(ctx, filepath.Join(f.templateLibrary, templateName))
 	if err != nil {
 		return fmt.Errorf("failed to validate template in library for new template: %v", err)
@@ -133,7 +134,7 @@ func (f *Factory) importOVAIfMissing(ctx context.Context, templateName, ovaURL s
 
 	if contentVersion == libraryContentDoesNotExist {
 		logger.V(2).Info("Importing template from ova url", "ova", ovaURL)
-		if err = f.client.ImportTemplate(ctx, f.templateLibrary, ovaURL, templateName); err != nil {
+		if err = f.client.ImportTemplate(ctx, f.templateLibrary, ovaURL, templateName, ovaChecksums); err != nil {
 			return fmt.Errorf("failed importing template into library: %v", err)
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	files "github.com/aws/eks-anywhere/pkg/files"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -135,17 +136,17 @@ func (mr *MockGovcClientMockRecorder) GetLibraryElementContentVersion(ctx, eleme
 }
 
 // ImportTemplate mocks base method.
-func (m *MockGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
+func (m *MockGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name string, checksums files.Checksums) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ImportTemplate", ctx, library, ovaURL, name)
+	ret := m.ctrl.Call(m, "ImportTemplate", ctx, library, ovaURL, name, checksums)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ImportTemplate indicates an expected call of ImportTemplate.
-func (mr *MockGovcClientMockRecorder) ImportTemplate(ctx, library, ovaURL, name interface{}) *gomock.Call {
+func (mr *MockGovcClientMockRecorder) ImportTemplate(ctx, library, ovaURL, name, checksums interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockGovcClient)(nil).ImportTemplate), ctx, library, ovaURL, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockGovcClient)(nil).ImportTemplate), ctx, library, ovaURL, name, checksums)
 }
 
 // LibraryElementExists mocks base method.
@@ -10,6 +10,7 @@ import (
 
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	executables "github.com/aws/eks-anywhere/pkg/executables"
+	files "github.com/aws/eks-anywhere/pkg/files"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
@@ -111,6 +112,20 @@ func (mr *MockProviderGovcClientMockRecorder) CreateTag(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockProviderGovcClient)(nil).CreateTag), arg0, arg1, arg2)
 }
 
+// CreateVMAntiAffinityRule mocks base method.
+func (m *MockProviderGovcClient) CreateVMAntiAffinityRule(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVMAntiAffinityRule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVMAntiAffinityRule indicates an expected call of CreateVMAntiAffinityRule.
+func (mr *MockProviderGovcClientMockRecorder) CreateVMAntiAffinityRule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVMAntiAffinityRule", reflect.TypeOf((*MockProviderGovcClient)(nil).CreateVMAntiAffinityRule), arg0, arg1, arg2, arg3)
+}
+
 // DatacenterExists mocks base method.
 func (m *MockProviderGovcClient) DatacenterExists(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -215,17 +230,17 @@ func (mr *MockProviderGovcClientMockRecorder) GetWorkloadAvailableSpace(arg0, ar
 }
 
 // ImportTemplate mocks base method.
-func (m *MockProviderGovcClient) ImportTemplate(arg0 context.Context, arg1, arg2, arg3 string) error {
+func (m *MockProviderGovcClient) ImportTemplate(arg0 context.Context, arg1, arg2, arg3 string, arg4 files.Checksums) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ImportTemplate", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "ImportTemplate", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ImportTemplate indicates an expected call of ImportTemplate.
-func (mr *MockProviderGovcClientMockRecorder) ImportTemplate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockProviderGovcClientMockRecorder) ImportTemplate(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockProviderGovcClient)(nil).ImportTemplate), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockProviderGovcClient)(nil).ImportTemplate), arg0, arg1, arg2, arg3, arg4)
 }
 
 // IsCertSelfSigned mocks base method.
@@ -360,6 +375,20 @@ func (mr *MockProviderGovcClientMockRecorder) ValidateVCenterConnection(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterConnection", reflect.TypeOf((*MockProviderGovcClient)(nil).ValidateVCenterConnection), arg0, arg1)
 }
 
+// ValidateVCenterSetupFailureDomain mocks base method.
+func (m *MockProviderGovcClient) ValidateVCenterSetupFailureDomain(arg0 context.Context, arg1 *v1alpha1.VSphereDatacenterConfig, arg2 *v1alpha1.VSphereFailureDomain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVCenterSetupFailureDomain", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVCenterSetupFailureDomain indicates an expected call of ValidateVCenterSetupFailureDomain.
+func (mr *MockProviderGovcClientMockRecorder) ValidateVCenterSetupFailureDomain(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterSetupFailureDomain", reflect.TypeOf((*MockProviderGovcClient)(nil).ValidateVCenterSetupFailureDomain), arg0, arg1, arg2)
+}
+
 // ValidateVCenterSetupMachineConfig mocks base method.
 func (m *MockProviderGovcClient) ValidateVCenterSetupMachineConfig(arg0 context.Context, arg1 *v1alpha1.VSphereDatacenterConfig, arg2 *v1alpha1.VSphereMachineConfig, arg3 *bool) error {
 	m.ctrl.T.Helper()
@@ -467,6 +496,20 @@ func (mr *MockProviderKubectlClientMockRecorder) DeleteEksaMachineConfig(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEksaMachineConfig", reflect.TypeOf((*MockProviderKubectlClient)(nil).DeleteEksaMachineConfig), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DeleteKubeSpecFromBytes mocks base method.
+func (m *MockProviderKubectlClient) DeleteKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKubeSpecFromBytes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKubeSpecFromBytes indicates an expected call of DeleteKubeSpecFromBytes.
+func (mr *MockProviderKubectlClientMockRecorder) DeleteKubeSpecFromBytes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKubeSpecFromBytes", reflect.TypeOf((*MockProviderKubectlClient)(nil).DeleteKubeSpecFromBytes), arg0, arg1, arg2)
+}
+
 // GetEksaCluster mocks base method.
 func (m *MockProviderKubectlClient) GetEksaCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -572,6 +615,21 @@ func (mr *MockProviderKubectlClientMockRecorder) GetMachineDeployment(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineDeployment", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachineDeployment), varargs...)
 }
 
+// GetMachines mocks base method.
+func (m *MockProviderKubectlClient) GetMachines(arg0 context.Context, arg1 *types.Cluster, arg2 string) ([]types.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachines", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachines indicates an expected call of GetMachines.
+func (mr *MockProviderKubectlClientMockRecorder) GetMachines(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachines", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachines), arg0, arg1, arg2)
+}
+
 // GetSecretFromNamespace mocks base method.
 func (m *MockProviderKubectlClient) GetSecretFromNamespace(arg0 context.Context, arg1, arg2, arg3 string) (*v1.Secret, error) {
 	m.ctrl.T.Helper()
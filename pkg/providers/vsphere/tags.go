@@ -21,7 +21,19 @@ func requiredTemplateTags(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSp
 func requiredTemplateTagsByCategory(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSphereMachineConfig) map[string][]string {
 	osFamily := machineConfig.Spec.OSFamily
 	return map[string][]string{
-		"eksdRelease": {fmt.Sprintf("eksdRelease:%s", clusterSpec.VersionsBundle.EksD.Name)},
+		"eksdRelease": {fmt.Sprintf("eksdRelease:%s", machineConfigVersionsBundle(clusterSpec, machineConfig).EksD.Name)},
 		"os":          {fmt.Sprintf("os:%s", strings.ToLower(string(osFamily)))},
 	}
 }
+
+// machineConfigVersionsBundle returns the VersionsBundle for the Kubernetes version of the machines
+// using the machine config: the one of the worker node group referencing it or, otherwise, the cluster one.
+func machineConfigVersionsBundle(clusterSpec *cluster.Spec, machineConfig *v1alpha1.VSphereMachineConfig) *cluster.VersionsBundle {
+	for _, w := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if w.MachineGroupRef != nil && w.MachineGroupRef.Name == machineConfig.Name {
+			return clusterSpec.WorkerNodeGroupVersionsBundle(w)
+		}
+	}
+
+	return clusterSpec.VersionsBundle
+}
@@ -87,6 +87,16 @@ func (v *Validator) ValidateVCenterConfig(ctx context.Context, datacenterConfig
 	}
 	logger.MarkPass("Network validated")
 
+	for i := range datacenterConfig.Spec.FailureDomains {
+		failureDomain := &datacenterConfig.Spec.FailureDomains[i]
+		if err := v.govc.ValidateVCenterSetupFailureDomain(ctx, datacenterConfig, failureDomain); err != nil {
+			return fmt.Errorf("validating vCenter setup for failure domain %s: %v", failureDomain.Name, err)
+		}
+	}
+	if len(datacenterConfig.Spec.FailureDomains) > 0 {
+		logger.MarkPass("Failure domains validated")
+	}
+
 	return nil
 }
 
@@ -134,10 +144,17 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		if controlPlaneMachineConfig.Spec.OSFamily != workerNodeGroupMachineConfig.Spec.OSFamily {
 			return errors.New("control plane and worker nodes must have the same osFamily specified")
 		}
+		if vsphereClusterSpec.Cluster.WorkerNodeGroupKubernetesVersion(workerNodeGroupConfiguration) != vsphereClusterSpec.Cluster.Spec.KubernetesVersion {
+			continue
+		}
 		if controlPlaneMachineConfig.Spec.Template != workerNodeGroupMachineConfig.Spec.Template {
 			return errors.New("control plane and worker nodes must have the same template specified")
 		}
 	}
+
+	if err := validateMachineConfigKubernetesVersions(vsphereClusterSpec); err != nil {
+		return err
+	}
 	if vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
 		etcdMachineConfig = vsphereClusterSpec.etcdMachineConfig()
 		if etcdMachineConfig == nil {
@@ -151,6 +168,10 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		}
 	}
 
+	if err := v.validateFailureDomains(vsphereClusterSpec, controlPlaneMachineConfig, workerNodeGroupMachineConfigs, etcdMachineConfig); err != nil {
+		return err
+	}
+
 	// TODO: move this to api Cluster validations
 	if err := v.validateControlPlaneIp(vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host); err != nil {
 		return err
@@ -201,6 +222,16 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		logger.V(1).Info("Control plane template validation failed.")
 		return err
 	}
+
+	for _, workerNodeGroupConfiguration := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if vsphereClusterSpec.Cluster.WorkerNodeGroupKubernetesVersion(workerNodeGroupConfiguration) == vsphereClusterSpec.Cluster.Spec.KubernetesVersion {
+			continue
+		}
+		if err := v.validateTemplate(ctx, vsphereClusterSpec, vsphereClusterSpec.workerMachineConfig(workerNodeGroupConfiguration)); err != nil {
+			logger.V(1).Info("Worker node group template validation failed.", "workerNodeGroup", workerNodeGroupConfiguration.Name)
+			return err
+		}
+	}
 	logger.MarkPass("Control plane and Workload templates validated")
 
 	if etcdMachineConfig != nil {
@@ -212,6 +243,49 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 	return v.validateDatastoreUsage(ctx, vsphereClusterSpec, controlPlaneMachineConfig, etcdMachineConfig)
 }
 
+func (v *Validator) validateFailureDomains(spec *Spec, controlPlaneMachineConfig *anywherev1.VSphereMachineConfig, workerNodeGroupMachineConfigs []*anywherev1.VSphereMachineConfig, etcdMachineConfig *anywherev1.VSphereMachineConfig) error {
+	if err := validateMachineConfigFailureDomains(spec.datacenterConfig, controlPlaneMachineConfig); err != nil {
+		return err
+	}
+
+	for _, machineConfig := range workerNodeGroupMachineConfigs {
+		if err := validateMachineConfigFailureDomains(spec.datacenterConfig, machineConfig); err != nil {
+			return err
+		}
+		// A MachineDeployment can only be placed in a single failure domain.
+		// Worker nodes are spread across failure domains by using one worker node group per failure domain.
+		if len(machineConfig.Spec.FailureDomains) > 1 {
+			return fmt.Errorf("VSphereMachineConfig %s for worker nodes can only reference one failure domain, use a worker node group per failure domain instead", machineConfig.Name)
+		}
+	}
+
+	if etcdMachineConfig != nil && len(etcdMachineConfig.Spec.FailureDomains) > 0 {
+		return fmt.Errorf("VSphereMachineConfig %s for etcd machines can't reference failure domains", etcdMachineConfig.Name)
+	}
+
+	return nil
+}
+
+// validateMachineConfigKubernetesVersions checks that machine configs are not shared between machines
+// running different Kubernetes versions since their template is specific to a Kubernetes version.
+func validateMachineConfigKubernetesVersions(spec *Spec) error {
+	versions := map[string]anywherev1.KubernetesVersion{}
+	versions[spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] = spec.Cluster.Spec.KubernetesVersion
+	if etcd := spec.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil && etcd.MachineGroupRef != nil {
+		versions[etcd.MachineGroupRef.Name] = spec.Cluster.Spec.KubernetesVersion
+	}
+
+	for _, w := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		kubeVersion := spec.Cluster.WorkerNodeGroupKubernetesVersion(w)
+		if v, ok := versions[w.MachineGroupRef.Name]; ok && v != kubeVersion {
+			return fmt.Errorf("VSphereMachineConfig %s can't be used by machines with different Kubernetes versions %s and %s", w.MachineGroupRef.Name, v, kubeVersion)
+		}
+		versions[w.MachineGroupRef.Name] = kubeVersion
+	}
+
+	return nil
+}
+
 func (v *Validator) validateControlPlaneIp(ip string) error {
 	// check if controlPlaneEndpointIp is valid
 	parsedIp := net.ParseIP(ip)
@@ -229,12 +303,23 @@ func (v *Validator) validateSSHUsername(machineConfig *anywherev1.VSphereMachine
 }
 
 func (v *Validator) validateTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) error {
-	if err := v.validateTemplatePresence(ctx, spec.datacenterConfig.Spec.Datacenter, machineConfig); err != nil {
+	return v.validateTemplatePresence(ctx, spec.datacenterConfig.Spec.Datacenter, machineConfig)
+}
+
+// ValidateTemplateTags validates the templates of the control plane and of the worker node groups
+// with their own Kubernetes version have the tags required by the cluster spec.
+func (v *Validator) ValidateTemplateTags(ctx context.Context, vsphereClusterSpec *Spec) error {
+	if err := v.validateTemplateTags(ctx, vsphereClusterSpec, vsphereClusterSpec.controlPlaneMachineConfig()); err != nil {
 		return err
 	}
 
-	if err := v.validateTemplateTags(ctx, spec, machineConfig); err != nil {
-		return err
+	for _, workerNodeGroupConfiguration := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if vsphereClusterSpec.Cluster.WorkerNodeGroupKubernetesVersion(workerNodeGroupConfiguration) == vsphereClusterSpec.Cluster.Spec.KubernetesVersion {
+			continue
+		}
+		if err := v.validateTemplateTags(ctx, vsphereClusterSpec, vsphereClusterSpec.workerMachineConfig(workerNodeGroupConfiguration)); err != nil {
+			return fmt.Errorf("worker node group %s: %v", workerNodeGroupConfiguration.Name, err)
+		}
 	}
 
 	return nil
@@ -9,9 +9,13 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/govmomi/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 func TestValidatorValidatePrivs(t *testing.T) {
@@ -131,3 +135,146 @@ func TestValidatorValidatePrivsBadJson(t *testing.T) {
 	_, err := v.validatePrivs(ctx, objects, vsc)
 	g.Expect(err).To(MatchError(ContainSubstring(errMsg)))
 }
+
+func TestValidatorValidateFailureDomains(t *testing.T) {
+	machineConfig := func(name string, failureDomains ...string) *anywherev1.VSphereMachineConfig {
+		m := &anywherev1.VSphereMachineConfig{}
+		m.Name = name
+		m.Spec.FailureDomains = failureDomains
+		return m
+	}
+	tests := []struct {
+		name         string
+		controlPlane *anywherev1.VSphereMachineConfig
+		workers      []*anywherev1.VSphereMachineConfig
+		etcd         *anywherev1.VSphereMachineConfig
+		wantErr      string
+	}{
+		{
+			name:         "valid",
+			controlPlane: machineConfig("cp", "fd-1", "fd-2"),
+			workers:      []*anywherev1.VSphereMachineConfig{machineConfig("md-0", "fd-1"), machineConfig("md-1", "fd-2")},
+			etcd:         machineConfig("etcd"),
+		},
+		{
+			name:         "undefined failure domain",
+			controlPlane: machineConfig("cp", "fd-1", "fd-3"),
+			wantErr:      "VSphereMachineConfig cp references failure domain fd-3 which is not defined in VSphereDatacenterConfig dc",
+		},
+		{
+			name:         "duplicated failure domain",
+			controlPlane: machineConfig("cp", "fd-1", "fd-1"),
+			wantErr:      "VSphereMachineConfig cp references failure domain fd-1 more than once",
+		},
+		{
+			name:         "worker with multiple failure domains",
+			controlPlane: machineConfig("cp"),
+			workers:      []*anywherev1.VSphereMachineConfig{machineConfig("md-0", "fd-1", "fd-2")},
+			wantErr:      "VSphereMachineConfig md-0 for worker nodes can only reference one failure domain",
+		},
+		{
+			name:         "etcd with failure domains",
+			controlPlane: machineConfig("cp"),
+			etcd:         machineConfig("etcd", "fd-1"),
+			wantErr:      "VSphereMachineConfig etcd for etcd machines can't reference failure domains",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			datacenterConfig := &anywherev1.VSphereDatacenterConfig{}
+			datacenterConfig.Name = "dc"
+			datacenterConfig.Spec.FailureDomains = []anywherev1.VSphereFailureDomain{{Name: "fd-1"}, {Name: "fd-2"}}
+			v := Validator{}
+
+			err := v.validateFailureDomains(&Spec{datacenterConfig: datacenterConfig}, tt.controlPlane, tt.workers, tt.etcd)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateMachineConfigKubernetesVersions(t *testing.T) {
+	kube122 := anywherev1.Kube122
+	tests := []struct {
+		name    string
+		workers []anywherev1.WorkerNodeGroupConfiguration
+		wantErr string
+	}{
+		{
+			name: "worker node groups with own machine configs",
+			workers: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", MachineGroupRef: &anywherev1.Ref{Name: "md-0"}},
+				{Name: "md-1", MachineGroupRef: &anywherev1.Ref{Name: "md-1"}, KubernetesVersion: &kube122},
+			},
+		},
+		{
+			name: "machine config shared with the control plane by a group with the cluster version",
+			workers: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", MachineGroupRef: &anywherev1.Ref{Name: "cp"}},
+			},
+		},
+		{
+			name: "machine config shared with the control plane by a group with a different version",
+			workers: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", MachineGroupRef: &anywherev1.Ref{Name: "cp"}, KubernetesVersion: &kube122},
+			},
+			wantErr: "VSphereMachineConfig cp can't be used by machines with different Kubernetes versions 1.23 and 1.22",
+		},
+		{
+			name: "machine config shared by groups with different versions",
+			workers: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", MachineGroupRef: &anywherev1.Ref{Name: "md"}},
+				{Name: "md-1", MachineGroupRef: &anywherev1.Ref{Name: "md"}, KubernetesVersion: &kube122},
+			},
+			wantErr: "VSphereMachineConfig md can't be used by machines with different Kubernetes versions 1.23 and 1.22",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := &Spec{Spec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = anywherev1.Kube123
+				s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Name: "cp"}
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = tt.workers
+			})}
+
+			err := validateMachineConfigKubernetesVersions(spec)
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestRequiredTemplateTagsWorkerNodeGroupKubernetesVersion(t *testing.T) {
+	g := NewWithT(t)
+	kube122 := anywherev1.Kube122
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = anywherev1.Kube123
+		s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Name: "cp"}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+			{Name: "md-0", MachineGroupRef: &anywherev1.Ref{Name: "md-0"}, KubernetesVersion: &kube122},
+		}
+		s.VersionsBundle.EksD.Name = "kubernetes-1-23-eks-1"
+		s.WorkerNodeGroupVersionsBundles = map[anywherev1.KubernetesVersion]*cluster.VersionsBundle{
+			anywherev1.Kube122: {
+				VersionsBundle: &releasev1.VersionsBundle{EksD: releasev1.EksDRelease{Name: "kubernetes-1-22-eks-1"}},
+			},
+		}
+	})
+	machineConfig := func(name string) *anywherev1.VSphereMachineConfig {
+		m := &anywherev1.VSphereMachineConfig{}
+		m.Name = name
+		m.Spec.OSFamily = anywherev1.Bottlerocket
+		return m
+	}
+
+	g.Expect(requiredTemplateTags(spec, machineConfig("cp"))).To(ConsistOf("eksdRelease:kubernetes-1-23-eks-1", "os:bottlerocket"))
+	g.Expect(requiredTemplateTags(spec, machineConfig("md-0"))).To(ConsistOf("eksdRelease:kubernetes-1-22-eks-1", "os:bottlerocket"))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -84,7 +85,6 @@ type vsphereProvider struct {
 	workerSshAuthKey       string
 	etcdSshAuthKey         string
 	templateBuilder        *VsphereTemplateBuilder
-	skipIpCheck            bool
 	resourceSetManager     ClusterResourceSetManager
 	Retrier                *retrier.Retrier
 	validator              *Validator
@@ -108,23 +108,27 @@ type ProviderGovcClient interface {
 	NetworkExists(ctx context.Context, network string) (bool, error)
 	CreateLibrary(ctx context.Context, datastore, library string) error
 	DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, library, datacenter, datastore, network, resourcePool string, resizeDisk2 bool) error
-	ImportTemplate(ctx context.Context, library, ovaURL, name string) error
+	ImportTemplate(ctx context.Context, library, ovaURL, name string, checksums files.Checksums) error
 	GetTags(ctx context.Context, path string) (tags []string, err error)
 	ListTags(ctx context.Context) ([]string, error)
 	CreateTag(ctx context.Context, tag, category string) error
 	AddTag(ctx context.Context, path, tag string) error
 	ListCategories(ctx context.Context) ([]string, error)
 	CreateCategoryForVM(ctx context.Context, name string) error
+	ValidateVCenterSetupFailureDomain(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, failureDomain *v1alpha1.VSphereFailureDomain) error
+	CreateVMAntiAffinityRule(ctx context.Context, computeCluster, name string, vms []string) error
 }
 
 type ProviderKubectlClient interface {
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	CreateNamespaceIfNotPresent(ctx context.Context, kubeconfig string, namespace string) error
 	LoadSecret(ctx context.Context, secretObject string, secretObjType string, secretObjectName string, kubeConfFile string) error
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
 	GetEksaVSphereDatacenterConfig(ctx context.Context, vsphereDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
 	GetEksaVSphereMachineConfig(ctx context.Context, vsphereMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error)
 	GetMachineDeployment(ctx context.Context, machineDeploymentName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
 	GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*controlplanev1.KubeadmControlPlane, error)
 	GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*etcdv1.EtcdadmCluster, error)
 	GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error)
@@ -142,7 +146,7 @@ type ClusterResourceSetManager interface {
 	ForceUpdate(ctx context.Context, name, namespace string, managementCluster, workloadCluster *types.Cluster) error
 }
 
-func NewProvider(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfigs map[string]*v1alpha1.VSphereMachineConfig, clusterConfig *v1alpha1.Cluster, providerGovcClient ProviderGovcClient, providerKubectlClient ProviderKubectlClient, writer filewriter.FileWriter, now types.NowFunc, skipIpCheck bool, resourceSetManager ClusterResourceSetManager) *vsphereProvider {
+func NewProvider(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfigs map[string]*v1alpha1.VSphereMachineConfig, clusterConfig *v1alpha1.Cluster, providerGovcClient ProviderGovcClient, providerKubectlClient ProviderKubectlClient, writer filewriter.FileWriter, now types.NowFunc, resourceSetManager ClusterResourceSetManager) *vsphereProvider {
 	netClient := &networkutils.DefaultNetClient{}
 	vcb := govmomi.NewVMOMIClientBuilder()
 	v := NewValidator(
@@ -160,13 +164,12 @@ func NewProvider(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConf
 		writer,
 		netClient,
 		now,
-		skipIpCheck,
 		resourceSetManager,
 		v,
 	)
 }
 
-func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfigs map[string]*v1alpha1.VSphereMachineConfig, clusterConfig *v1alpha1.Cluster, providerGovcClient ProviderGovcClient, providerKubectlClient ProviderKubectlClient, writer filewriter.FileWriter, netClient networkutils.NetClient, now types.NowFunc, skipIpCheck bool, resourceSetManager ClusterResourceSetManager, v *Validator) *vsphereProvider {
+func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfigs map[string]*v1alpha1.VSphereMachineConfig, clusterConfig *v1alpha1.Cluster, providerGovcClient ProviderGovcClient, providerKubectlClient ProviderKubectlClient, writer filewriter.FileWriter, netClient networkutils.NetClient, now types.NowFunc, resourceSetManager ClusterResourceSetManager, v *Validator) *vsphereProvider {
 	var controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.VSphereMachineConfigSpec
 	if clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] != nil {
 		controlPlaneMachineSpec = &machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec
@@ -195,7 +198,6 @@ func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, ma
 			etcdMachineSpec:             etcdMachineSpec,
 			now:                         now,
 		},
-		skipIpCheck:        skipIpCheck,
 		resourceSetManager: resourceSetManager,
 		Retrier:            retrier,
 		validator:          v,
@@ -357,6 +359,16 @@ func (p *vsphereProvider) setupSSHAuthKeysForUpgrade() error {
 }
 
 func (p *vsphereProvider) DeleteResources(ctx context.Context, clusterSpec *cluster.Spec) error {
+	failureDomains, err := p.generateFailureDomainsSpec(clusterSpec)
+	if err != nil {
+		return fmt.Errorf("generating failure domains: %v", err)
+	}
+	if failureDomains != nil {
+		if err := p.providerKubectlClient.DeleteKubeSpecFromBytes(ctx, clusterSpec.ManagementCluster, failureDomains); err != nil {
+			return fmt.Errorf("deleting failure domains: %v", err)
+		}
+	}
+
 	for _, mc := range p.machineConfigs {
 		if err := p.providerKubectlClient.DeleteEksaMachineConfig(ctx, eksaVSphereMachineResourceType, mc.Name, clusterSpec.ManagementCluster.KubeconfigFile, mc.Namespace); err != nil {
 			return err
@@ -365,6 +377,11 @@ func (p *vsphereProvider) DeleteResources(ctx context.Context, clusterSpec *clus
 	return p.providerKubectlClient.DeleteEksaDatacenterConfig(ctx, eksaVSphereDatacenterResourceType, p.datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, p.datacenterConfig.Namespace)
 }
 
+func (p *vsphereProvider) PostClusterDelete(_ context.Context, _ *types.Cluster) error {
+	// NOOP
+	return nil
+}
+
 func (p *vsphereProvider) PostClusterDeleteValidate(_ context.Context, _ *types.Cluster) error {
 	// No validations
 	return nil
@@ -431,14 +448,6 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 		}
 	}
 
-	if !p.skipIpCheck {
-		if err := p.validator.validateControlPlaneIpUniqueness(vSphereClusterSpec); err != nil {
-			return err
-		}
-	} else {
-		logger.Info("Skipping check for whether control plane ip is in use")
-	}
-
 	var passed bool
 	var err error
 	vuc := config.NewVsphereUserConfig()
@@ -471,6 +480,36 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 	return nil
 }
 
+func (p *vsphereProvider) CreateClusterChecks(ctx context.Context, clusterSpec *cluster.Spec) []providers.Check {
+	vSphereClusterSpec := NewSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)
+	return []providers.Check{
+		{
+			ID:          providers.ControlPlaneIPCheckID,
+			Name:        "validate control plane ip is not in use",
+			Remediation: "provide an unused IP for controlPlaneConfiguration.endpoint.host",
+			Validate: func() error {
+				return p.validator.validateControlPlaneIpUniqueness(vSphereClusterSpec)
+			},
+		},
+		p.templateTagsCheck(ctx, vSphereClusterSpec),
+	}
+}
+
+func (p *vsphereProvider) UpgradeClusterChecks(ctx context.Context, clusterSpec *cluster.Spec) []providers.Check {
+	return []providers.Check{p.templateTagsCheck(ctx, NewSpec(clusterSpec, p.machineConfigs, p.datacenterConfig))}
+}
+
+func (p *vsphereProvider) templateTagsCheck(ctx context.Context, vSphereClusterSpec *Spec) providers.Check {
+	return providers.Check{
+		ID:          providers.TemplateTagsCheckID,
+		Name:        "validate template tags",
+		Remediation: "tag the templates with the OS family and EKS-D release they were built for",
+		Validate: func() error {
+			return p.validator.ValidateTemplateTags(ctx, vSphereClusterSpec)
+		},
+	}
+}
+
 func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, _ *cluster.Spec) error {
 	if err := SetupEnvVars(p.datacenterConfig); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
@@ -579,8 +618,8 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
-func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
-	if oldSpec.Cluster.Spec.KubernetesVersion != newSpec.Cluster.Spec.KubernetesVersion {
+func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldWorkerNodeGroup, newWorkerNodeGroup v1alpha1.WorkerNodeGroupConfiguration, oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
+	if oldSpec.Cluster.WorkerNodeGroupKubernetesVersion(oldWorkerNodeGroup) != newSpec.Cluster.WorkerNodeGroupKubernetesVersion(newWorkerNodeGroup) {
 		return true
 	}
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
@@ -595,7 +634,8 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1
 
 func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeVmc *v1alpha1.VSphereMachineConfig, newWorkerNodeVmc *v1alpha1.VSphereMachineConfig) bool {
 	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.LabelsMapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels) ||
-		!v1alpha1.UsersSliceEqual(oldWorkerNodeVmc.Spec.Users, newWorkerNodeVmc.Spec.Users)
+		!v1alpha1.UsersSliceEqual(oldWorkerNodeVmc.Spec.Users, newWorkerNodeVmc.Spec.Users) ||
+		!oldWorkerNodeVmc.Spec.HostOSConfiguration.Equal(newWorkerNodeVmc.Spec.HostOSConfiguration)
 }
 
 func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
@@ -675,6 +715,14 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *clus
 		return nil, err
 	}
 
+	failureDomains, err := generateFailureDomainsSpec(clusterSpec.Cluster.Name, *vs.datacenterSpec, *vs.controlPlaneMachineSpec)
+	if err != nil {
+		return nil, err
+	}
+	if failureDomains != nil {
+		bytes = templater.AppendYamlResources(bytes, failureDomains)
+	}
+
 	return bytes, nil
 }
 
@@ -788,6 +836,11 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		"eksaCSIPassword":                      vuc.EksaVsphereCSIPassword,
 	}
 
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = true
+		values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
 		values["registryMirrorConfiguration"] = net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
 		if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
@@ -846,11 +899,15 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		values["awsIamAuth"] = true
 	}
 
+	for key, value := range common.HostOSConfigTemplateValues(controlPlaneMachineSpec.HostOSConfiguration, controlPlaneMachineSpec.OSFamily) {
+		values[key] = value
+	}
+
 	return values
 }
 
 func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, workerNodeGroupMachineSpec v1alpha1.VSphereMachineConfigSpec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
-	bundle := clusterSpec.VersionsBundle
+	bundle := clusterSpec.WorkerNodeGroupVersionsBundle(workerNodeGroupConfiguration)
 	format := "cloud-config"
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
@@ -880,6 +937,13 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		"workerNodeGroupName":            fmt.Sprintf("%s-%s", clusterSpec.Cluster.Name, workerNodeGroupConfiguration.Name),
 		"workerNodeGroupTaints":          workerNodeGroupConfiguration.Taints,
 		"autoscalingConfig":              workerNodeGroupConfiguration.AutoScalingConfiguration,
+		"workerFailureDomain":            workerFailureDomain(clusterSpec.Cluster.Name, workerNodeGroupMachineSpec),
+	}
+
+	if workerNodeGroupConfiguration.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = true
+		values["maxSurge"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+		values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
 	}
 
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
@@ -919,6 +983,10 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketBootstrap.Bootstrap.Tag()
 	}
 
+	for key, value := range common.HostOSConfigTemplateValues(workerNodeGroupMachineSpec.HostOSConfiguration, workerNodeGroupMachineSpec.OSFamily) {
+		values[key] = value
+	}
+
 	return values
 }
 
@@ -1131,6 +1199,32 @@ func (p *vsphereProvider) PostBootstrapSetupUpgrade(ctx context.Context, cluster
 }
 
 func (p *vsphereProvider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	// Failure domains and deployment zones are cluster scoped and not moved by clusterctl,
+	// so they need to be created in the workload cluster before it becomes the management cluster.
+	failureDomains, err := p.generateFailureDomainsSpec(clusterSpec)
+	if err != nil {
+		return fmt.Errorf("generating failure domains: %v", err)
+	}
+	if failureDomains == nil {
+		return nil
+	}
+
+	if err = p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, failureDomains); err != nil {
+		return fmt.Errorf("applying failure domains: %v", err)
+	}
+
+	return nil
+}
+
+func (p *vsphereProvider) PreMachinesRemoval(_ context.Context, _ *cluster.Spec, _ *types.Cluster) error {
+	return nil
+}
+
+func (p *vsphereProvider) PostControlPlaneReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
+	return p.createAntiAffinityRules(ctx, clusterSpec, managementCluster)
+}
+
+func (p *vsphereProvider) PostWorkerNodesReady(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster) error {
 	return nil
 }
 
@@ -1274,8 +1368,8 @@ func (p *vsphereProvider) getWorkerNodeMachineConfigs(ctx context.Context, workl
 }
 
 func (p *vsphereProvider) needsNewMachineTemplate(currentSpec, newClusterSpec *cluster.Spec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration, vdc *v1alpha1.VSphereDatacenterConfig, prevWorkerNodeGroupConfigs map[string]v1alpha1.WorkerNodeGroupConfiguration, oldWorkerMachineConfig *v1alpha1.VSphereMachineConfig, newWorkerMachineConfig *v1alpha1.VSphereMachineConfig) (bool, error) {
-	if _, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
-		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, oldWorkerMachineConfig, newWorkerMachineConfig)
+	if prevWorkerNodeGroupConfig, ok := prevWorkerNodeGroupConfigs[workerNodeGroupConfiguration.Name]; ok {
+		needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, prevWorkerNodeGroupConfig, workerNodeGroupConfiguration, vdc, p.datacenterConfig, oldWorkerMachineConfig, newWorkerMachineConfig)
 		return needsNewWorkloadTemplate, nil
 	}
 	return true, nil
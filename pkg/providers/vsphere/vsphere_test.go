@@ -24,7 +24,9 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -32,8 +34,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	govmomi_mocks "github.com/aws/eks-anywhere/pkg/govmomi/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -136,7 +140,7 @@ func (pc *DummyProviderGovcClient) ResizeDisk(ctx context.Context, template, dis
 	return nil
 }
 
-func (pc *DummyProviderGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name string) error {
+func (pc *DummyProviderGovcClient) ImportTemplate(ctx context.Context, library, ovaURL, name string, checksums files.Checksums) error {
 	return nil
 }
 
@@ -164,6 +168,14 @@ func (pc *DummyProviderGovcClient) CreateCategoryForVM(ctx context.Context, name
 	return nil
 }
 
+func (pc *DummyProviderGovcClient) ValidateVCenterSetupFailureDomain(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, failureDomain *v1alpha1.VSphereFailureDomain) error {
+	return nil
+}
+
+func (pc *DummyProviderGovcClient) CreateVMAntiAffinityRule(ctx context.Context, computeCluster, name string, vms []string) error {
+	return nil
+}
+
 type DummyNetClient struct{}
 
 func (n *DummyNetClient) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
@@ -395,7 +407,6 @@ func TestNewProvider(t *testing.T) {
 	resourceSetManager := mocks.NewMockClusterResourceSetManager(mockCtrl)
 	govc := NewDummyProviderGovcClient()
 	_, writer := test.NewWriter(t)
-	skipIpCheck := true
 
 	provider := NewProvider(
 		datacenterConfig,
@@ -405,7 +416,6 @@ func TestNewProvider(t *testing.T) {
 		kubectl,
 		writer,
 		time.Now,
-		skipIpCheck,
 		resourceSetManager,
 	)
 
@@ -514,7 +524,6 @@ func newProvider(t *testing.T, datacenterConfig *v1alpha1.VSphereDatacenterConfi
 		writer,
 		netClient,
 		test.FakeNow,
-		false,
 		resourceSetManager,
 		v,
 	)
@@ -1942,20 +1951,6 @@ func TestSetupAndValidateCreateClusterBogusIp(t *testing.T) {
 	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host is invalid: bogus", err)
 }
 
-func TestSetupAndValidateCreateClusterUsedIp(t *testing.T) {
-	ctx := context.Background()
-	clusterSpec := givenEmptyClusterSpec()
-	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
-	provider := givenProvider(t)
-	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "255.255.255.255"
-	var tctx testContext
-	tctx.SaveContext()
-
-	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
-
-	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host <255.255.255.255> is already in use, please provide a unique IP", err)
-}
-
 func TestSetupAndValidateForCreateSSHAuthorizedKeyInvalidCP(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := givenEmptyClusterSpec()
@@ -2405,50 +2400,6 @@ func TestSetupAndValidateCreateClusterErrorCheckingTemplate(t *testing.T) {
 	thenErrorExpected(t, "failed setting default values for vsphere machine configs: setting template full path: "+errorMessage, err)
 }
 
-func TestSetupAndValidateCreateClusterTemplateMissingTags(t *testing.T) {
-	tt := newProviderTest(t)
-
-	tt.setExpectationForSetup()
-	tt.setExpectationsForDefaultDiskGovcCalls()
-	tt.setExpectationForVCenterValidation()
-	tt.setExpectationsForMachineConfigsVCenterValidation()
-
-	for _, mc := range tt.machineConfigs {
-		tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, mc).Return(mc.Spec.Template, nil)
-	}
-	controlPlaneMachineConfigName := tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
-	controlPlaneMachineConfig := tt.machineConfigs[controlPlaneMachineConfigName]
-
-	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig).Return(controlPlaneMachineConfig.Spec.Template, nil)
-	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, nil)
-
-	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
-
-	thenErrorPrefixExpected(t, "template "+testTemplate+" is missing tag ", err)
-}
-
-func TestSetupAndValidateCreateClusterErrorGettingTags(t *testing.T) {
-	tt := newProviderTest(t)
-	errorMessage := "failed getting tags"
-
-	controlPlaneMachineConfigName := tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
-	controlPlaneMachineConfig := tt.machineConfigs[controlPlaneMachineConfigName]
-
-	tt.setExpectationForSetup()
-	tt.setExpectationsForDefaultDiskGovcCalls()
-	tt.setExpectationForVCenterValidation()
-	tt.setExpectationsForMachineConfigsVCenterValidation()
-	for _, mc := range tt.machineConfigs {
-		tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, mc).Return(mc.Spec.Template, nil)
-	}
-	tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, controlPlaneMachineConfig).Return(controlPlaneMachineConfig.Spec.Template, nil)
-	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, errors.New(errorMessage))
-
-	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
-
-	thenErrorExpected(t, "validating template tags: failed getting tags", err)
-}
-
 func TestSetupAndValidateCreateClusterDefaultTemplate(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
@@ -2962,6 +2913,113 @@ func TestProviderGenerateCAPISpecForCreateWithCustomResolvConf(t *testing.T) {
 	test.AssertContentToFile(t, string(cp), "testdata/expected_results_custom_resolv_conf.yaml")
 }
 
+func TestProviderGenerateCAPISpecForCreateWithHostOSConfiguration(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+	ctx := context.Background()
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	for _, machineConfig := range machineConfigs {
+		machineConfig.Spec.HostOSConfiguration = &v1alpha1.HostOSConfiguration{
+			NTPConfiguration: &v1alpha1.NTPConfiguration{
+				Servers: []string{"0.pool.ntp.org", "time.example.com"},
+			},
+			CertBundles: []v1alpha1.CertBundle{
+				{
+					Name: "corp-ca",
+					Data: "-----BEGIN CERTIFICATE-----\nMIIBAQ==\n-----END CERTIFICATE-----\n",
+				},
+			},
+			SysctlSettings: map[string]string{"vm.max_map_count": "262144"},
+			JournaldConfiguration: &v1alpha1.JournaldConfiguration{
+				SystemMaxUse: "1G",
+			},
+		}
+	}
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_host_os_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_host_os_config_md.yaml")
+
+	// The rendered settings must be part of the CAPI types, otherwise they would be pruned by the API server.
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(yaml.UnmarshalStrict(findManifest(t, cp, "KubeadmControlPlane"), kcp)).To(Succeed())
+	assertHostOSConfigRendered(g, kcp.Spec.KubeadmConfigSpec)
+
+	kct := &bootstrapv1.KubeadmConfigTemplate{}
+	g.Expect(yaml.UnmarshalStrict(findManifest(t, md, "KubeadmConfigTemplate"), kct)).To(Succeed())
+	assertHostOSConfigRendered(g, kct.Spec.Template.Spec)
+}
+
+func findManifest(t *testing.T, manifests []byte, kind string) []byte {
+	for _, m := range strings.Split(string(manifests), "\n---\n") {
+		obj := &metav1.TypeMeta{}
+		if err := yaml.Unmarshal([]byte(m), obj); err != nil {
+			t.Fatalf("failed to unmarshal manifest: %v", err)
+		}
+		if obj.Kind == kind {
+			return []byte(m)
+		}
+	}
+	t.Fatalf("manifest with kind %s not found", kind)
+	return nil
+}
+
+func assertHostOSConfigRendered(g *WithT, spec bootstrapv1.KubeadmConfigSpec) {
+	g.Expect(spec.NTP).NotTo(BeNil())
+	g.Expect(spec.NTP.Servers).To(Equal([]string{"0.pool.ntp.org", "time.example.com"}))
+	g.Expect(spec.Files).To(ContainElements(
+		bootstrapv1.File{
+			Path:    "/usr/local/share/ca-certificates/corp-ca.crt",
+			Owner:   "root:root",
+			Content: "-----BEGIN CERTIFICATE-----\nMIIBAQ==\n-----END CERTIFICATE-----\n",
+		},
+		bootstrapv1.File{
+			Path:    "/etc/sysctl.d/99-eksa.conf",
+			Owner:   "root:root",
+			Content: "vm.max_map_count = 262144\n",
+		},
+	))
+	g.Expect(spec.PreKubeadmCommands).To(ContainElements("update-ca-certificates", "sysctl --system", "systemctl restart systemd-journald"))
+}
+
+func TestNeedsNewKubeadmConfigTemplateHostOSConfigurationChanged(t *testing.T) {
+	g := NewWithT(t)
+	workerNodeGroup := &v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}
+	oldVmc := &v1alpha1.VSphereMachineConfig{}
+	newVmc := &v1alpha1.VSphereMachineConfig{
+		Spec: v1alpha1.VSphereMachineConfigSpec{
+			HostOSConfiguration: &v1alpha1.HostOSConfiguration{
+				NTPConfiguration: &v1alpha1.NTPConfiguration{Servers: []string{"time.example.com"}},
+			},
+		},
+	}
+
+	g.Expect(NeedsNewKubeadmConfigTemplate(workerNodeGroup, workerNodeGroup, oldVmc, oldVmc)).To(BeFalse())
+	g.Expect(NeedsNewKubeadmConfigTemplate(workerNodeGroup, workerNodeGroup, oldVmc, newVmc)).To(BeTrue())
+}
+
 func TestProviderGenerateCAPISpecForCreateVersion121(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	var tctx testContext
@@ -3211,3 +3269,230 @@ func TestProviderGenerateCAPISpecForCreateMultipleCredentials(t *testing.T) {
 		})
 	}
 }
+
+func givenFailureDomains() []v1alpha1.VSphereFailureDomain {
+	return []v1alpha1.VSphereFailureDomain{
+		{
+			Name:           "fd-1",
+			ComputeCluster: "/SDDC-Datacenter/host/Cluster-1",
+			ResourcePool:   "/SDDC-Datacenter/host/Cluster-1/Resources",
+			Datastore:      "/SDDC-Datacenter/datastore/Datastore-1",
+			Network:        "/SDDC-Datacenter/network/network-1",
+		},
+		{
+			Name:           "fd-2",
+			ComputeCluster: "/SDDC-Datacenter/host/Cluster-2",
+			ResourcePool:   "/SDDC-Datacenter/host/Cluster-2/Resources",
+			Datastore:      "/SDDC-Datacenter/datastore/Datastore-2",
+			Folder:         "/SDDC-Datacenter/vm/fd-2",
+		},
+	}
+}
+
+func TestProviderGenerateCAPISpecForCreateWithFailureDomains(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+	ctx := context.Background()
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	datacenterConfig.Spec.FailureDomains = givenFailureDomains()
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	machineConfigs["test-cp"].Spec.FailureDomains = []string{"fd-1", "fd-2"}
+	machineConfigs["test-wn"].Spec.FailureDomains = []string{"fd-2"}
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_failure_domains_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_failure_domains_md.yaml")
+}
+
+func TestPostWorkloadInitAppliesFailureDomains(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.datacenterConfig.Spec.FailureDomains = givenFailureDomains()
+	tt.machineConfigs["test-cp"].Spec.FailureDomains = []string{"fd-1", "fd-2"}
+
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.workloadCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("kind: VSphereFailureDomain"))
+			tt.Expect(string(data)).To(ContainSubstring("name: test-fd-2"))
+			return nil
+		},
+	)
+
+	tt.Expect(tt.provider.PostWorkloadInit(tt.ctx, tt.workloadCluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestPostWorkloadInitNoFailureDomains(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.Expect(tt.provider.PostWorkloadInit(tt.ctx, tt.workloadCluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestProviderDeleteResourcesWithFailureDomains(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.datacenterConfig.Spec.FailureDomains = givenFailureDomains()
+	tt.clusterSpec.ManagementCluster = tt.managementCluster
+
+	tt.kubectl.EXPECT().DeleteKubeSpecFromBytes(tt.ctx, tt.managementCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: test-fd-1"))
+			return nil
+		},
+	)
+	tt.kubectl.EXPECT().DeleteEksaMachineConfig(tt.ctx, eksaVSphereMachineResourceType, gomock.Any(), tt.managementCluster.KubeconfigFile, gomock.Any()).Return(nil).Times(len(tt.machineConfigs))
+	tt.kubectl.EXPECT().DeleteEksaDatacenterConfig(tt.ctx, eksaVSphereDatacenterResourceType, tt.datacenterConfig.Name, tt.managementCluster.KubeconfigFile, tt.datacenterConfig.Namespace).Return(nil)
+
+	tt.Expect(tt.provider.DeleteResources(tt.ctx, tt.clusterSpec)).To(Succeed())
+}
+
+func TestProviderDeleteResourcesNoFailureDomains(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.ManagementCluster = tt.managementCluster
+	// The control plane machine config is not needed when the datacenter doesn't define failure domains.
+	delete(tt.machineConfigs, tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
+
+	tt.kubectl.EXPECT().DeleteEksaMachineConfig(tt.ctx, eksaVSphereMachineResourceType, gomock.Any(), tt.managementCluster.KubeconfigFile, gomock.Any()).Return(nil).Times(len(tt.machineConfigs))
+	tt.kubectl.EXPECT().DeleteEksaDatacenterConfig(tt.ctx, eksaVSphereDatacenterResourceType, tt.datacenterConfig.Name, tt.managementCluster.KubeconfigFile, tt.datacenterConfig.Namespace).Return(nil)
+
+	tt.Expect(tt.provider.DeleteResources(tt.ctx, tt.clusterSpec)).To(Succeed())
+}
+
+func TestPostControlPlaneReadyCreatesAntiAffinityRules(t *testing.T) {
+	tt := newProviderTest(t)
+	for _, name := range []string{"test-cp", "test-etcd"} {
+		tt.machineConfigs[name].Spec.ResourcePool = "/SDDC-Datacenter/host/Cluster-1/Resources"
+		tt.machineConfigs[name].Spec.Folder = "/SDDC-Datacenter/vm/eksa"
+	}
+	machines := []types.Machine{
+		{Metadata: types.MachineMetadata{Name: "test-abcde", Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}},
+		{Metadata: types.MachineMetadata{Name: "test-fghij", Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}}},
+		{Metadata: types.MachineMetadata{Name: "test-etcd-abcde", Labels: map[string]string{clusterv1.MachineEtcdClusterLabelName: "test-etcd"}}},
+		{Metadata: types.MachineMetadata{Name: "test-etcd-fghij", Labels: map[string]string{clusterv1.MachineEtcdClusterLabelName: "test-etcd"}}},
+		{Metadata: types.MachineMetadata{Name: "test-etcd-klmno", Labels: map[string]string{clusterv1.MachineEtcdClusterLabelName: "test-etcd"}}},
+		{Metadata: types.MachineMetadata{Name: "test-md-0-abcde", Labels: map[string]string{clusterv1.MachineDeploymentLabelName: "test-md-0"}}},
+	}
+
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test").Return(machines, nil)
+	tt.govc.EXPECT().CreateVMAntiAffinityRule(tt.ctx, "/SDDC-Datacenter/host/Cluster-1", "test-control-plane-anti-affinity",
+		[]string{"/SDDC-Datacenter/vm/eksa/test-abcde", "/SDDC-Datacenter/vm/eksa/test-fghij"})
+	tt.govc.EXPECT().CreateVMAntiAffinityRule(tt.ctx, "/SDDC-Datacenter/host/Cluster-1", "test-etcd-anti-affinity",
+		[]string{"/SDDC-Datacenter/vm/eksa/test-etcd-abcde", "/SDDC-Datacenter/vm/eksa/test-etcd-fghij", "/SDDC-Datacenter/vm/eksa/test-etcd-klmno"})
+
+	tt.Expect(tt.provider.PostControlPlaneReady(tt.ctx, tt.clusterSpec, tt.managementCluster)).To(Succeed())
+}
+
+func TestPostControlPlaneReadyAntiAffinityRulesPerFailureDomain(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	tt.datacenterConfig.Spec.FailureDomains = givenFailureDomains()
+	tt.machineConfigs["test-cp"].Spec.FailureDomains = []string{"fd-1", "fd-2"}
+	tt.machineConfigs["test-cp"].Spec.Folder = "/SDDC-Datacenter/vm/eksa"
+	machines := []types.Machine{
+		{
+			Metadata: types.MachineMetadata{Name: "test-abcde", Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}},
+			Spec:     types.MachineSpec{FailureDomain: "test-fd-1"},
+		},
+		{
+			Metadata: types.MachineMetadata{Name: "test-fghij", Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}},
+			Spec:     types.MachineSpec{FailureDomain: "test-fd-2"},
+		},
+		{
+			Metadata: types.MachineMetadata{Name: "test-klmno", Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}},
+			Spec:     types.MachineSpec{FailureDomain: "test-fd-1"},
+		},
+	}
+
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test").Return(machines, nil)
+	tt.govc.EXPECT().CreateVMAntiAffinityRule(tt.ctx, "/SDDC-Datacenter/host/Cluster-1", "test-control-plane-anti-affinity",
+		[]string{"/SDDC-Datacenter/vm/eksa/test-abcde", "/SDDC-Datacenter/vm/eksa/test-klmno"})
+
+	tt.Expect(tt.provider.PostControlPlaneReady(tt.ctx, tt.clusterSpec, tt.managementCluster)).To(Succeed())
+}
+
+func TestPostControlPlaneReadyGetMachinesError(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test").Return(nil, errors.New("error getting machines"))
+
+	tt.Expect(tt.provider.PostControlPlaneReady(tt.ctx, tt.clusterSpec, tt.managementCluster)).To(MatchError(ContainSubstring("error getting machines")))
+}
+
+func TestProviderCreateClusterChecks(t *testing.T) {
+	g := NewWithT(t)
+	provider := givenProvider(t)
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	checks := provider.CreateClusterChecks(context.Background(), clusterSpec)
+	g.Expect(checks).To(HaveLen(2))
+	g.Expect(checks[0].ID).To(Equal(providers.ControlPlaneIPCheckID))
+	g.Expect(checks[1].ID).To(Equal(providers.TemplateTagsCheckID))
+}
+
+func TestProviderUpgradeClusterChecks(t *testing.T) {
+	g := NewWithT(t)
+	provider := givenProvider(t)
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+
+	checks := provider.UpgradeClusterChecks(context.Background(), clusterSpec)
+	g.Expect(checks).To(HaveLen(1))
+	g.Expect(checks[0].ID).To(Equal(providers.TemplateTagsCheckID))
+}
+
+func findCheck(checks []providers.Check, id string) providers.Check {
+	for _, c := range checks {
+		if c.ID == id {
+			return c
+		}
+	}
+	return providers.Check{}
+}
+
+func TestCreateClusterChecksUsedIp(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	provider := givenProvider(t)
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "255.255.255.255"
+
+	err := findCheck(provider.CreateClusterChecks(ctx, clusterSpec), providers.ControlPlaneIPCheckID).Validate()
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host <255.255.255.255> is already in use, please provide a unique IP", err)
+}
+
+func TestCreateClusterChecksTemplateMissingTags(t *testing.T) {
+	tt := newProviderTest(t)
+	controlPlaneMachineConfig := tt.machineConfigs[tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, nil)
+
+	err := findCheck(tt.provider.CreateClusterChecks(tt.ctx, tt.clusterSpec), providers.TemplateTagsCheckID).Validate()
+
+	thenErrorPrefixExpected(t, "template "+testTemplate+" is missing tag ", err)
+}
+
+func TestCreateClusterChecksErrorGettingTags(t *testing.T) {
+	tt := newProviderTest(t)
+	controlPlaneMachineConfig := tt.machineConfigs[tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	tt.govc.EXPECT().GetTags(tt.ctx, controlPlaneMachineConfig.Spec.Template).Return(nil, errors.New("failed getting tags"))
+
+	err := findCheck(tt.provider.CreateClusterChecks(tt.ctx, tt.clusterSpec), providers.TemplateTagsCheckID).Validate()
+
+	thenErrorExpected(t, "validating template tags: failed getting tags", err)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
@@ -32,6 +33,7 @@ type CommandContext struct {
 	ClusterManager     interfaces.ClusterManager
 	GitOpsManager      interfaces.GitOpsManager
 	Validations        interfaces.Validator
+	ValidationOpts     []validations.RunnerOpt
 	Writer             filewriter.FileWriter
 	EksdInstaller      interfaces.EksdInstaller
 	PackageInstaller   interfaces.PackageInstaller
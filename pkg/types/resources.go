@@ -10,6 +10,7 @@ type Deployment struct {
 
 type Machine struct {
 	Metadata MachineMetadata `json:"metadata"`
+	Spec     MachineSpec     `json:"spec"`
 	Status   MachineStatus   `json:"status"`
 }
 
@@ -28,9 +29,14 @@ type MachineStatus struct {
 }
 
 type MachineMetadata struct {
+	Name   string            `json:"name,omitempty"`
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+type MachineSpec struct {
+	FailureDomain string `json:"failureDomain,omitempty"`
+}
+
 type ResourceRef struct {
 	APIVersion string `json:"apiVersion"`
 	Kind       string `json:"kind"`
@@ -19,24 +19,27 @@ type ValidationManager struct {
 	gitOpsFlux        *flux.Flux
 	createValidations Validator
 	dockerExec        validations.DockerExecutable
+	runnerOpts        []validations.RunnerOpt
 }
 
 type Validator interface {
 	BuildValidations(ctx context.Context) []validations.Validation
 }
 
-func NewValidations(clusterSpec *cluster.Spec, provider providers.Provider, gitOpsFlux *flux.Flux, createValidations Validator, dockerExec validations.DockerExecutable) *ValidationManager {
+// NewValidations creates a ValidationManager that runs the validations with runnerOpts.
+func NewValidations(clusterSpec *cluster.Spec, provider providers.Provider, gitOpsFlux *flux.Flux, createValidations Validator, dockerExec validations.DockerExecutable, runnerOpts ...validations.RunnerOpt) *ValidationManager {
 	return &ValidationManager{
 		clusterSpec:       clusterSpec,
 		provider:          provider,
 		gitOpsFlux:        gitOpsFlux,
 		createValidations: createValidations,
 		dockerExec:        dockerExec,
+		runnerOpts:        runnerOpts,
 	}
 }
 
 func (v *ValidationManager) Validate(ctx context.Context) error {
-	runner := validations.NewRunner()
+	runner := validations.NewRunner(v.runnerOpts...)
 	runner.Register(v.generateCreateValidations(ctx)...)
 	runner.Register(v.gitOpsFlux.Validations(ctx, v.clusterSpec)...)
 	err := runner.Run()
@@ -48,40 +51,45 @@ func (v *ValidationManager) generateCreateValidations(ctx context.Context) []val
 	vs := []validations.Validation{
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
-				Name:   "validate docker executable",
-				Err:    validations.ValidateDockerExecutable(ctx, v.dockerExec, runtime.GOOS),
-				Silent: true,
+				Name:     "validate docker executable",
+				Category: validations.CategoryEnvironment,
+				Err:      validations.ValidateDockerExecutable(ctx, v.dockerExec, runtime.GOOS),
+				Silent:   true,
 			}
 		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
-				Name:   "validate kubeconfig path",
-				Err:    kubeconfig.ValidateKubeconfigPath(v.clusterSpec.Cluster.Name),
-				Silent: true,
+				Name:     "validate kubeconfig path",
+				Category: validations.CategoryEnvironment,
+				Err:      kubeconfig.ValidateKubeconfigPath(v.clusterSpec.Cluster.Name),
+				Silent:   true,
 			}
 		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
-				Name:   "validate cluster",
-				Err:    cluster.ValidateConfig(v.clusterSpec.Config),
-				Silent: true,
+				Name:     "validate cluster",
+				Category: validations.CategoryClusterConfig,
+				Err:      cluster.ValidateConfig(v.clusterSpec.Config),
+				Silent:   true,
 			}
 		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
-				Name:   "validate supported provider",
-				Err:    validator.ValidateSupportedProviderCreate(v.provider),
-				Silent: true,
-			}
-		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("validate %s Provider", v.provider.Name()),
-				Err:  v.provider.SetupAndValidateCreateCluster(ctx, v.clusterSpec),
+				Name:     "validate supported provider",
+				Category: validations.CategoryProvider,
+				Err:      validator.ValidateSupportedProviderCreate(v.provider),
+				Silent:   true,
 			}
 		},
 	}
 
+	vs = append(vs, validations.ProviderValidations(
+		fmt.Sprintf("validate %s Provider", v.provider.Name()),
+		func() error {
+			return v.provider.SetupAndValidateCreateCluster(ctx, v.clusterSpec)
+		},
+		v.provider.CreateClusterChecks(ctx, v.clusterSpec),
+	)...)
 	vs = append(vs, v.createValidations.BuildValidations(ctx)...)
 
 	return vs
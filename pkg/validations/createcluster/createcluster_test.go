@@ -93,6 +93,7 @@ func (c *createClusterValidationTest) expectValidDockerClusterSpec() {
 
 func (c *createClusterValidationTest) expectValidProvider() {
 	c.provider.EXPECT().SetupAndValidateCreateCluster(c.ctx, c.clusterSpec).Return(nil).AnyTimes()
+	c.provider.EXPECT().CreateClusterChecks(c.ctx, c.clusterSpec).Return(nil).AnyTimes()
 	c.provider.EXPECT().Name().Return("docker").AnyTimes()
 }
 
@@ -12,13 +12,10 @@ import (
 )
 
 func (v *CreateValidations) PreflightValidations(ctx context.Context) (err error) {
-	vs := v.BuildValidations(ctx)
-	results := make([]validations.ValidationResult, 0, len(vs))
-	for _, validation := range vs {
-		results = append(results, *validation())
-	}
+	runner := validations.NewRunner(v.Opts.RunnerOpts()...)
+	runner.Register(v.BuildValidations(ctx)...)
 
-	return validations.ProcessValidationResults(results)
+	return validations.ProcessValidationResults(runner.Evaluate())
 }
 
 func (v *CreateValidations) BuildValidations(ctx context.Context) []validations.Validation {
@@ -54,39 +51,52 @@ func (v *CreateValidations) BuildValidations(ctx context.Context) []validations.
 		},
 	}
 
+	if v.Opts.DockerExecutable != nil {
+		createValidations = append(createValidations, func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate docker memory",
+				ID:          validations.DockerMemoryID,
+				Category:    validations.CategoryEnvironment,
+				Severity:    validations.SeverityWarning,
+				Remediation: "allocate at least 6GB of memory to Docker, not allocating enough memory can cause problems while creating the cluster",
+				Err:         validations.ValidateDockerAllocatedMemory(ctx, v.Opts.DockerExecutable),
+			}
+		})
+	}
+
 	if v.Opts.Spec.Cluster.IsManaged() {
 		createValidations = append(
 			createValidations,
 			func() *validations.ValidationResult {
 				return &validations.ValidationResult{
 					Name:        "validate cluster name",
-					Remediation: "",
+					Remediation: fmt.Sprintf("choose a cluster name that is not already used by a cluster managed by %s", v.Opts.ManagementCluster.Name),
 					Err:         ValidateClusterNameIsUnique(ctx, k, targetCluster, v.Opts.Spec.Cluster.Name),
 				}
 			},
 			func() *validations.ValidationResult {
 				return &validations.ValidationResult{
 					Name:        "validate gitops",
-					Remediation: "",
+					Remediation: "ensure the gitops configuration matches the one of the management cluster",
 					Err:         ValidateGitOps(ctx, k, v.Opts.ManagementCluster, v.Opts.Spec),
 				}
 			},
 			func() *validations.ValidationResult {
 				return &validations.ValidationResult{
 					Name:        "validate identity providers' name",
-					Remediation: "",
+					Remediation: "choose identity provider names that are not already used in the management cluster",
 					Err:         ValidateIdentityProviderNameIsUnique(ctx, k, targetCluster, v.Opts.Spec),
 				}
 			},
 			func() *validations.ValidationResult {
 				return &validations.ValidationResult{
 					Name:        "validate management cluster has eksa crds",
-					Remediation: "",
+					Remediation: fmt.Sprintf("ensure %s is an EKS Anywhere management cluster", v.Opts.ManagementCluster.Name),
 					Err:         ValidateManagementCluster(ctx, k, targetCluster),
 				}
 			},
 		)
 	}
 
-	return createValidations
+	return validations.WithCategory(validations.CategoryPreflight, createValidations...)
 }
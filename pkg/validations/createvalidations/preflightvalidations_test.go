@@ -63,3 +63,20 @@ func TestPreFlightValidationsWorkloadCluster(t *testing.T) {
 
 	tt.Expect(tt.c.PreflightValidations(tt.ctx)).To(Succeed())
 }
+
+func TestBuildValidationsDockerMemoryWarning(t *testing.T) {
+	tt := newPreflightValidationsTest(t)
+	docker := mocks.NewMockDockerExecutable(gomock.NewController(t))
+	docker.EXPECT().AllocatedMemory(tt.ctx).Return(uint64(4000000000), nil)
+	tt.c.Opts.DockerExecutable = docker
+
+	var dockerMemory *validations.ValidationResult
+	for _, v := range tt.c.BuildValidations(tt.ctx) {
+		if result := v(); result.ID == validations.DockerMemoryID {
+			dockerMemory = result
+		}
+	}
+	tt.Expect(dockerMemory).NotTo(BeNil())
+	tt.Expect(dockerMemory.Err).To(HaveOccurred())
+	tt.Expect(dockerMemory.Severity).To(Equal(validations.SeverityWarning))
+}
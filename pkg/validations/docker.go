@@ -7,7 +7,6 @@ import (
 	"os/exec"
 	"strings"
 
-	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/semver"
 )
 
@@ -35,15 +34,16 @@ func CheckMinimumDockerVersion(ctx context.Context, dockerExecutable DockerExecu
 	return nil
 }
 
-func CheckDockerAllocatedMemory(ctx context.Context, dockerExecutable DockerExecutable) {
+// ValidateDockerAllocatedMemory returns an error if less memory than recommended is allocated to Docker.
+func ValidateDockerAllocatedMemory(ctx context.Context, dockerExecutable DockerExecutable) error {
 	totalMemoryAllocated, err := dockerExecutable.AllocatedMemory(ctx)
 	if err != nil {
-		logger.Error(err, "Failed to validate docker memory: error while reading memory allocated to Docker")
-		return
+		return fmt.Errorf("reading memory allocated to Docker: %v", err)
 	}
 	if totalMemoryAllocated < recommendedTotalMemory {
-		logger.V(3).Info("Warning: recommended memory to be allocated for Docker is 6 GB, please be aware that not allocating enough memory can cause problems while cluster creation")
+		return fmt.Errorf("%d bytes of memory are allocated to Docker, %d are recommended", totalMemoryAllocated, uint64(recommendedTotalMemory))
 	}
+	return nil
 }
 
 func CheckDockerDesktopVersion(ctx context.Context, dockerExecutable DockerExecutable) error {
@@ -109,7 +109,6 @@ func ValidateDockerExecutable(ctx context.Context, docker DockerExecutable, os s
 			return fmt.Errorf("failed to validate docker desktop: %v", err)
 		}
 	}
-	CheckDockerAllocatedMemory(ctx, docker)
 
 	return nil
 }
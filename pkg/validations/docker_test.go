@@ -2,6 +2,7 @@ package validations_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -164,3 +165,44 @@ func TestValidateDockerExecutable(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDockerAllocatedMemory(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		memory  uint64
+		err     error
+		wantErr string
+	}{
+		{
+			name:   "Enough",
+			memory: 6200000001,
+		},
+		{
+			name:    "NotEnough",
+			memory:  4000000000,
+			wantErr: "4000000000 bytes of memory are allocated to Docker, 6200000000 are recommended",
+		},
+		{
+			name:    "Error",
+			err:     errors.New("docker not running"),
+			wantErr: "reading memory allocated to Docker: docker not running",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			dockerExecutableMock := mocks.NewMockDockerExecutable(mockCtrl)
+			dockerExecutableMock.EXPECT().AllocatedMemory(ctx).Return(tc.memory, tc.err)
+
+			err := validations.ValidateDockerAllocatedMemory(ctx, dockerExecutableMock)
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("ValidateDockerAllocatedMemory() error = %v, want nil", err)
+			}
+			if tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Errorf("ValidateDockerAllocatedMemory() error = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+}
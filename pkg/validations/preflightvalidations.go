@@ -3,9 +3,12 @@ package validations
 func ProcessValidationResults(validations []ValidationResult) error {
 	var errs []string
 	for _, validation := range validations {
-		if validation.Err != nil {
+		switch {
+		case validation.Failed():
 			errs = append(errs, validation.Err.Error())
-		} else if !validation.Silent {
+		case validation.Skipped, validation.Err != nil:
+			validation.Report()
+		case !validation.Silent:
 			validation.LogPass()
 		}
 	}
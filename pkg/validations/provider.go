@@ -0,0 +1,40 @@
+package validations
+
+import "github.com/aws/eks-anywhere/pkg/providers"
+
+// ProviderValidations returns the validation of the provider setup, named name and run by setup,
+// followed by a validation for each of checks. The checks only run once the setup succeeds and are
+// reported as skipped otherwise.
+func ProviderValidations(name string, setup func() error, checks []providers.Check) []Validation {
+	var setupErr error
+	vs := []Validation{
+		func() *ValidationResult {
+			setupErr = setup()
+			return &ValidationResult{
+				Name:     name,
+				Category: CategoryProvider,
+				Err:      setupErr,
+			}
+		},
+	}
+
+	for _, check := range checks {
+		check := check
+		vs = append(vs, func() *ValidationResult {
+			result := &ValidationResult{
+				Name:        check.Name,
+				ID:          check.ID,
+				Category:    CategoryProvider,
+				Remediation: check.Remediation,
+			}
+			if setupErr != nil {
+				result.Skipped = true
+				return result
+			}
+			result.Err = check.Validate()
+			return result
+		})
+	}
+
+	return vs
+}
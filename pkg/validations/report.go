@@ -0,0 +1,194 @@
+package validations
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ReportFormat is a machine readable format of a Report.
+type ReportFormat string
+
+const (
+	ReportFormatJSON  ReportFormat = "json"
+	ReportFormatJUnit ReportFormat = "junit"
+)
+
+// ReportFormats are the supported report formats.
+var ReportFormats = []ReportFormat{ReportFormatJSON, ReportFormatJUnit}
+
+// ParseReportFormat returns the ReportFormat named s.
+func ParseReportFormat(s string) (ReportFormat, error) {
+	for _, f := range ReportFormats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported report format %q, must be one of %v", s, ReportFormats)
+}
+
+// Status is the outcome of a validation in a Report.
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusWarning Status = "warning"
+	StatusSkipped Status = "skipped"
+)
+
+// ReportResult is a validation result in a Report.
+type ReportResult struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Severity    Severity `json:"severity"`
+	Status      Status   `json:"status"`
+	Message     string   `json:"message,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// Report collects validation results to output them in a machine readable format. A nil Report
+// discards results.
+type Report struct {
+	Passed  bool           `json:"passed"`
+	Results []ReportResult `json:"results"`
+}
+
+// NewReport returns an empty Report.
+func NewReport() *Report {
+	return &Report{Passed: true, Results: []ReportResult{}}
+}
+
+// Add records results in r. Aggregated results are ignored.
+func (r *Report) Add(results ...ValidationResult) {
+	if r == nil {
+		return
+	}
+
+	for _, result := range results {
+		if result.Aggregated {
+			continue
+		}
+
+		reportResult := ReportResult{
+			ID:          result.GetID(),
+			Name:        result.Name,
+			Category:    result.GetCategory(),
+			Severity:    result.GetSeverity(),
+			Status:      StatusPassed,
+			Remediation: result.Remediation,
+		}
+		if result.Err != nil {
+			reportResult.Message = result.Err.Error()
+		}
+		switch {
+		case result.Skipped:
+			reportResult.Status = StatusSkipped
+		case result.Failed():
+			reportResult.Status = StatusFailed
+			r.Passed = false
+		case result.Err != nil:
+			reportResult.Status = StatusWarning
+		}
+		r.Results = append(r.Results, reportResult)
+	}
+}
+
+// Write writes r to w in format.
+func (r *Report) Write(w io.Writer, format ReportFormat) error {
+	switch format {
+	case ReportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case ReportFormatJUnit:
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		encoder := xml.NewEncoder(w)
+		encoder.Indent("", "  ")
+		if err := encoder.Encode(r.junit()); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junit groups the results of r in a test suite per category. Warnings are reported as passed
+// test cases with the warning in their output and skipped validations as skipped test cases.
+func (r *Report) junit() junitTestSuites {
+	suites := map[string]*junitTestSuite{}
+	for _, result := range r.Results {
+		suite, ok := suites[result.Category]
+		if !ok {
+			suite = &junitTestSuite{Name: result.Category}
+			suites[result.Category] = suite
+		}
+
+		testCase := junitTestCase{Name: result.ID, ClassName: result.Category}
+		switch result.Status {
+		case StatusFailed:
+			testCase.Failure = &junitFailure{Message: result.Message, Type: string(result.Severity), Text: result.Remediation}
+			suite.Failures++
+		case StatusWarning:
+			testCase.SystemOut = fmt.Sprintf("warning: %s", result.Message)
+		case StatusSkipped:
+			testCase.Skipped = &junitSkipped{Message: result.Message}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+	}
+
+	categories := make([]string, 0, len(suites))
+	for category := range suites {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	junit := junitTestSuites{Name: "eks-anywhere validations"}
+	for _, category := range categories {
+		junit.Suites = append(junit.Suites, *suites[category])
+		junit.Tests += suites[category].Tests
+		junit.Failures += suites[category].Failures
+	}
+	return junit
+}
@@ -0,0 +1,162 @@
+package validations_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func newTestReport() *validations.Report {
+	report := validations.NewReport()
+	report.Add(
+		validations.ValidationResult{
+			Name:     "validate kubeconfig path",
+			Category: validations.CategoryEnvironment,
+		},
+		validations.ValidationResult{
+			Name:        "validate certificate for registry mirror",
+			ID:          "registry-mirror-certificate",
+			Category:    validations.CategoryPreflight,
+			Err:         errors.New("certificate signed by unknown authority"),
+			Remediation: "provide the CA certificate in the cluster spec",
+		},
+		validations.ValidationResult{
+			Name:     "validate ntp servers",
+			Severity: validations.SeverityWarning,
+			Err:      errors.New("ntp server unreachable"),
+		},
+		validations.ValidationResult{
+			Name:       "create preflight validations pass",
+			Err:        errors.New("validations failed"),
+			Aggregated: true,
+		},
+	)
+	return report
+}
+
+func TestReportAdd(t *testing.T) {
+	g := NewWithT(t)
+	report := newTestReport()
+
+	g.Expect(report.Passed).To(BeFalse())
+	g.Expect(report.Results).To(Equal([]validations.ReportResult{
+		{
+			ID:       "validate-kubeconfig-path",
+			Name:     "validate kubeconfig path",
+			Category: validations.CategoryEnvironment,
+			Severity: validations.SeverityError,
+			Status:   validations.StatusPassed,
+		},
+		{
+			ID:          "registry-mirror-certificate",
+			Name:        "validate certificate for registry mirror",
+			Category:    validations.CategoryPreflight,
+			Severity:    validations.SeverityError,
+			Status:      validations.StatusFailed,
+			Message:     "certificate signed by unknown authority",
+			Remediation: "provide the CA certificate in the cluster spec",
+		},
+		{
+			ID:       "validate-ntp-servers",
+			Name:     "validate ntp servers",
+			Category: validations.DefaultCategory,
+			Severity: validations.SeverityWarning,
+			Status:   validations.StatusWarning,
+			Message:  "ntp server unreachable",
+		},
+	}))
+}
+
+func TestReportAddNil(t *testing.T) {
+	g := NewWithT(t)
+	var report *validations.Report
+
+	report.Add(validations.ValidationResult{Name: "validate cluster"})
+	g.Expect(report).To(BeNil())
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	g := NewWithT(t)
+	report := validations.NewReport()
+	report.Add(validations.ValidationResult{
+		Name:        "validate cluster",
+		Category:    validations.CategoryClusterConfig,
+		Err:         errors.New("invalid"),
+		Remediation: "fix it",
+	})
+
+	var b bytes.Buffer
+	g.Expect(report.Write(&b, validations.ReportFormatJSON)).To(Succeed())
+	g.Expect(b.String()).To(Equal(`{
+  "passed": false,
+  "results": [
+    {
+      "id": "validate-cluster",
+      "name": "validate cluster",
+      "category": "cluster-config",
+      "severity": "error",
+      "status": "failed",
+      "message": "invalid",
+      "remediation": "fix it"
+    }
+  ]
+}
+`))
+}
+
+func TestReportWriteJUnit(t *testing.T) {
+	g := NewWithT(t)
+	report := newTestReport()
+
+	var b bytes.Buffer
+	g.Expect(report.Write(&b, validations.ReportFormatJUnit)).To(Succeed())
+	g.Expect(b.String()).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="eks-anywhere validations" tests="3" failures="1">
+  <testsuite name="environment" tests="1" failures="0">
+    <testcase name="validate-kubeconfig-path" classname="environment"></testcase>
+  </testsuite>
+  <testsuite name="general" tests="1" failures="0">
+    <testcase name="validate-ntp-servers" classname="general">
+      <system-out>warning: ntp server unreachable</system-out>
+    </testcase>
+  </testsuite>
+  <testsuite name="preflight" tests="1" failures="1">
+    <testcase name="registry-mirror-certificate" classname="preflight">
+      <failure message="certificate signed by unknown authority" type="error">provide the CA certificate in the cluster spec</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`))
+}
+
+func TestReportSkipped(t *testing.T) {
+	g := NewWithT(t)
+	report := validations.NewReport()
+	report.Add(validations.ValidationResult{
+		Name:    "validate cluster name",
+		Err:     errors.New("cluster name already in use"),
+		Skipped: true,
+	})
+
+	g.Expect(report.Passed).To(BeTrue())
+	g.Expect(report.Results[0].Status).To(Equal(validations.StatusSkipped))
+
+	var b bytes.Buffer
+	g.Expect(report.Write(&b, validations.ReportFormatJUnit)).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring(`<skipped message="cluster name already in use"></skipped>`))
+}
+
+func TestParseReportFormat(t *testing.T) {
+	g := NewWithT(t)
+
+	format, err := validations.ParseReportFormat("junit")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(format).To(Equal(validations.ReportFormatJUnit))
+
+	_, err = validations.ParseReportFormat("yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported report format")))
+}
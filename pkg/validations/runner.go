@@ -1,17 +1,105 @@
 package validations
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
 
 var errRunnerValidation = errors.New("validations failed")
 
 type Validation func() *ValidationResult
 
+// DockerMemoryID is the ID of the validation of the memory allocated to Docker.
+const DockerMemoryID = "docker-memory"
+
+// overridableIDs are the IDs of the validations that can be skipped or downgraded to warnings.
+// Any other validation, like the provider setup, always runs with its own severity.
+var overridableIDs = map[string]struct{}{
+	DockerMemoryID:                  {},
+	providers.ControlPlaneIPCheckID: {},
+	providers.TinkerbellIPCheckID:   {},
+	providers.TemplateTagsCheckID:   {},
+	providers.BMCReachableCheckID:   {},
+}
+
+// OverridableIDs returns the sorted IDs of the validations that can be skipped or downgraded to
+// warnings.
+func OverridableIDs() []string {
+	ids := make([]string, 0, len(overridableIDs))
+	for id := range overridableIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Overrides changes the outcome of validations, identified by their IDs.
+type Overrides struct {
+	// Skip lists the validations whose failures are ignored.
+	Skip []string
+	// Warn lists the validations downgraded to SeverityWarning.
+	Warn []string
+}
+
+// Validate returns an error if o refers to validations that can't be skipped or downgraded to
+// warnings, for example because of a typo in their ID.
+func (o Overrides) Validate() error {
+	for _, id := range o.Skip {
+		if _, ok := overridableIDs[id]; !ok {
+			return fmt.Errorf("validation %s can't be skipped, must be one of %v", id, OverridableIDs())
+		}
+	}
+	for _, id := range o.Warn {
+		if _, ok := overridableIDs[id]; !ok {
+			return fmt.Errorf("validation %s can't be downgraded to a warning, must be one of %v", id, OverridableIDs())
+		}
+	}
+	return nil
+}
+
 type Runner struct {
 	validations []Validation
+	report      *Report
+	skip        map[string]struct{}
+	warn        map[string]struct{}
+}
+
+// RunnerOpt configures a Runner.
+type RunnerOpt func(*Runner)
+
+// WithReport records the results of the validations run by the Runner in report.
+func WithReport(report *Report) RunnerOpt {
+	return func(r *Runner) {
+		r.report = report
+	}
 }
 
-func NewRunner() *Runner {
-	return &Runner{validations: make([]Validation, 0)}
+// WithOverrides applies overrides to the results of the validations run by the Runner. Overrides
+// of validations that can't be overridden are ignored.
+func WithOverrides(overrides Overrides) RunnerOpt {
+	return func(r *Runner) {
+		for _, id := range overrides.Skip {
+			r.skip[id] = struct{}{}
+		}
+		for _, id := range overrides.Warn {
+			r.warn[id] = struct{}{}
+		}
+	}
+}
+
+func NewRunner(opts ...RunnerOpt) *Runner {
+	r := &Runner{
+		validations: make([]Validation, 0),
+		skip:        map[string]struct{}{},
+		warn:        map[string]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *Runner) Register(validations ...Validation) {
@@ -20,10 +108,9 @@ func (r *Runner) Register(validations ...Validation) {
 
 func (r *Runner) Run() error {
 	failed := false
-	for _, v := range r.validations {
-		result := v()
+	for _, result := range r.Evaluate() {
 		result.Report()
-		if result.Err != nil {
+		if result.Failed() {
 			failed = true
 		}
 	}
@@ -34,3 +121,31 @@ func (r *Runner) Run() error {
 
 	return nil
 }
+
+// Evaluate runs the registered validations and returns their results with the overrides applied,
+// without logging them. The results are recorded in the Runner's report.
+func (r *Runner) Evaluate() []ValidationResult {
+	results := make([]ValidationResult, 0, len(r.validations))
+	for _, v := range r.validations {
+		result := v()
+		r.override(result)
+		r.report.Add(*result)
+		results = append(results, *result)
+	}
+
+	return results
+}
+
+func (r *Runner) override(result *ValidationResult) {
+	id := result.GetID()
+	if _, ok := overridableIDs[id]; !ok {
+		return
+	}
+	if _, ok := r.skip[id]; ok {
+		result.Skipped = true
+		return
+	}
+	if _, ok := r.warn[id]; ok {
+		result.Severity = SeverityWarning
+	}
+}
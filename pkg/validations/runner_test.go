@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/gomega"
 
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
@@ -44,3 +45,182 @@ func TestRunnerRunSuccess(t *testing.T) {
 
 	g.Expect(r.Run()).To(Succeed())
 }
+
+func TestRunnerRunWarning(t *testing.T) {
+	g := NewWithT(t)
+	report := validations.NewReport()
+	r := validations.NewRunner(validations.WithReport(report))
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:     "check ntp",
+			Severity: validations.SeverityWarning,
+			Err:      errors.New("clock skew"),
+		}
+	})
+
+	g.Expect(r.Run()).To(Succeed())
+	g.Expect(report.Passed).To(BeTrue())
+	g.Expect(report.Results).To(ConsistOf(validations.ReportResult{
+		ID:       "check-ntp",
+		Name:     "check ntp",
+		Category: validations.DefaultCategory,
+		Severity: validations.SeverityWarning,
+		Status:   validations.StatusWarning,
+		Message:  "clock skew",
+	}))
+}
+
+func TestRunnerRunOverrides(t *testing.T) {
+	g := NewWithT(t)
+	report := validations.NewReport()
+	r := validations.NewRunner(
+		validations.WithReport(report),
+		validations.WithOverrides(validations.Overrides{
+			Skip: []string{providers.ControlPlaneIPCheckID},
+			Warn: []string{validations.DockerMemoryID},
+		}),
+	)
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "validate control plane ip",
+			ID:   providers.ControlPlaneIPCheckID,
+			Err:  errors.New("ip in use"),
+		}
+	})
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "validate docker memory",
+			ID:   validations.DockerMemoryID,
+			Err:  errors.New("not enough memory"),
+		}
+	})
+
+	g.Expect(r.Run()).To(Succeed())
+	g.Expect(report.Passed).To(BeTrue())
+	g.Expect(report.Results).To(HaveLen(2))
+	g.Expect(report.Results[0].Status).To(Equal(validations.StatusSkipped))
+	g.Expect(report.Results[1].Status).To(Equal(validations.StatusWarning))
+	g.Expect(report.Results[1].Severity).To(Equal(validations.SeverityWarning))
+}
+
+func TestRunnerEvaluate(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner(validations.WithOverrides(validations.Overrides{Warn: []string{providers.TemplateTagsCheckID}}))
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "validate template tags",
+			ID:   providers.TemplateTagsCheckID,
+			Err:  errors.New("missing tags"),
+		}
+	})
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "validate cluster",
+			Err:  errors.New("invalid"),
+		}
+	})
+
+	results := r.Evaluate()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Failed()).To(BeFalse())
+	g.Expect(results[1].Failed()).To(BeTrue())
+	g.Expect(validations.ProcessValidationResults(results)).To(MatchError("validation failed with 1 errors: invalid"))
+}
+
+func TestRunnerRunOverridesNotOverridable(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner(validations.WithOverrides(validations.Overrides{
+		Skip: []string{"validate-cluster-name"},
+	}))
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "validate cluster name",
+			Err:  errors.New("name in use"),
+		}
+	})
+
+	g.Expect(r.Run()).NotTo(Succeed())
+}
+
+func TestOverridesValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides validations.Overrides
+		wantErr   string
+	}{
+		{
+			name: "overridable",
+			overrides: validations.Overrides{
+				Skip: []string{providers.ControlPlaneIPCheckID},
+				Warn: []string{validations.DockerMemoryID},
+			},
+		},
+		{
+			name:      "unknown skip",
+			overrides: validations.Overrides{Skip: []string{"control-plane-ip"}},
+			wantErr:   "validation control-plane-ip can't be skipped",
+		},
+		{
+			name:      "unknown warn",
+			overrides: validations.Overrides{Warn: []string{"validate-cluster-name"}},
+			wantErr:   "validation validate-cluster-name can't be downgraded to a warning",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tt.overrides.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestProviderValidationsSetupFailure(t *testing.T) {
+	g := NewWithT(t)
+	checked := false
+	vs := validations.ProviderValidations("vsphere Provider setup is valid", func() error {
+		return errors.New("invalid datacenter")
+	}, []providers.Check{
+		{
+			ID:   providers.ControlPlaneIPCheckID,
+			Name: "validate control plane ip",
+			Validate: func() error {
+				checked = true
+				return nil
+			},
+		},
+	})
+
+	r := validations.NewRunner(validations.WithOverrides(validations.Overrides{Skip: []string{providers.ControlPlaneIPCheckID}}))
+	r.Register(vs...)
+	results := r.Evaluate()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Failed()).To(BeTrue())
+	g.Expect(results[1].Skipped).To(BeTrue())
+	g.Expect(checked).To(BeFalse())
+}
+
+func TestProviderValidationsChecks(t *testing.T) {
+	g := NewWithT(t)
+	vs := validations.ProviderValidations("vsphere Provider setup is valid", func() error { return nil }, []providers.Check{
+		{
+			ID:          providers.ControlPlaneIPCheckID,
+			Name:        "validate control plane ip",
+			Remediation: "use an unused ip",
+			Validate:    func() error { return errors.New("ip in use") },
+		},
+	})
+
+	r := validations.NewRunner()
+	r.Register(vs...)
+	results := r.Evaluate()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Failed()).To(BeFalse())
+	g.Expect(results[1].Failed()).To(BeTrue())
+	g.Expect(results[1].ID).To(Equal(providers.ControlPlaneIPCheckID))
+	g.Expect(results[1].Remediation).To(Equal("use an unused ip"))
+}
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/validations/upgradecluster/upgradecluster.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	validations "github.com/aws/eks-anywhere/pkg/validations"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockValidator is a mock of Validator interface.
+type MockValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorMockRecorder
+}
+
+// MockValidatorMockRecorder is the mock recorder for MockValidator.
+type MockValidatorMockRecorder struct {
+	mock *MockValidator
+}
+
+// NewMockValidator creates a new mock instance.
+func NewMockValidator(ctrl *gomock.Controller) *MockValidator {
+	mock := &MockValidator{ctrl: ctrl}
+	mock.recorder = &MockValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
+	return m.recorder
+}
+
+// BuildValidations mocks base method.
+func (m *MockValidator) BuildValidations(ctx context.Context) []validations.Validation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildValidations", ctx)
+	ret0, _ := ret[0].([]validations.Validation)
+	return ret0
+}
+
+// BuildValidations indicates an expected call of BuildValidations.
+func (mr *MockValidatorMockRecorder) BuildValidations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildValidations", reflect.TypeOf((*MockValidator)(nil).BuildValidations), ctx)
+}
+
+// MockClusterManager is a mock of ClusterManager interface.
+type MockClusterManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterManagerMockRecorder
+}
+
+// MockClusterManagerMockRecorder is the mock recorder for MockClusterManager.
+type MockClusterManagerMockRecorder struct {
+	mock *MockClusterManager
+}
+
+// NewMockClusterManager creates a new mock instance.
+func NewMockClusterManager(ctrl *gomock.Controller) *MockClusterManager {
+	mock := &MockClusterManager{ctrl: ctrl}
+	mock.recorder = &MockClusterManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterManager) EXPECT() *MockClusterManagerMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentClusterSpec mocks base method.
+func (m *MockClusterManager) GetCurrentClusterSpec(ctx context.Context, managementCluster *types.Cluster, clusterName string) (*cluster.Spec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentClusterSpec", ctx, managementCluster, clusterName)
+	ret0, _ := ret[0].(*cluster.Spec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentClusterSpec indicates an expected call of GetCurrentClusterSpec.
+func (mr *MockClusterManagerMockRecorder) GetCurrentClusterSpec(ctx, managementCluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentClusterSpec", reflect.TypeOf((*MockClusterManager)(nil).GetCurrentClusterSpec), ctx, managementCluster, clusterName)
+}
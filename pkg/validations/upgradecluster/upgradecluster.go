@@ -0,0 +1,81 @@
+package upgradecluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// ValidationManager runs the validations of an upgrade against a live management cluster without
+// changing it.
+type ValidationManager struct {
+	clusterSpec        *cluster.Spec
+	managementCluster  *types.Cluster
+	provider           providers.Provider
+	clusterManager     ClusterManager
+	upgradeValidations Validator
+	runnerOpts         []validations.RunnerOpt
+}
+
+type Validator interface {
+	BuildValidations(ctx context.Context) []validations.Validation
+}
+
+// ClusterManager retrieves the spec of the cluster being upgraded.
+type ClusterManager interface {
+	GetCurrentClusterSpec(ctx context.Context, managementCluster *types.Cluster, clusterName string) (*cluster.Spec, error)
+}
+
+// NewValidations creates a ValidationManager that runs the validations with runnerOpts.
+func NewValidations(clusterSpec *cluster.Spec, managementCluster *types.Cluster, provider providers.Provider, clusterManager ClusterManager, upgradeValidations Validator, runnerOpts ...validations.RunnerOpt) *ValidationManager {
+	return &ValidationManager{
+		clusterSpec:        clusterSpec,
+		managementCluster:  managementCluster,
+		provider:           provider,
+		clusterManager:     clusterManager,
+		upgradeValidations: upgradeValidations,
+		runnerOpts:         runnerOpts,
+	}
+}
+
+// Validate runs all upgrade validations, reporting the result of each, and returns an error if any
+// of them failed.
+func (v *ValidationManager) Validate(ctx context.Context) error {
+	currentSpec, err := v.clusterManager.GetCurrentClusterSpec(ctx, v.managementCluster, v.clusterSpec.Cluster.Name)
+	if err != nil {
+		return fmt.Errorf("getting current spec of cluster %s: %v", v.clusterSpec.Cluster.Name, err)
+	}
+
+	runner := validations.NewRunner(v.runnerOpts...)
+	runner.Register(v.generateUpgradeValidations(ctx, currentSpec)...)
+
+	return runner.Run()
+}
+
+func (v *ValidationManager) generateUpgradeValidations(ctx context.Context, currentSpec *cluster.Spec) []validations.Validation {
+	vs := []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:     "validate cluster",
+				Category: validations.CategoryClusterConfig,
+				Err:      cluster.ValidateConfig(v.clusterSpec.Config),
+				Silent:   true,
+			}
+		},
+	}
+
+	vs = append(vs, validations.ProviderValidations(
+		fmt.Sprintf("validate %s Provider", v.provider.Name()),
+		func() error {
+			return v.provider.SetupAndValidateUpgradeCluster(ctx, v.managementCluster, v.clusterSpec, currentSpec)
+		},
+		v.provider.UpgradeClusterChecks(ctx, v.clusterSpec),
+	)...)
+	vs = append(vs, v.upgradeValidations.BuildValidations(ctx)...)
+
+	return vs
+}
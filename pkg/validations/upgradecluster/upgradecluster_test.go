@@ -0,0 +1,151 @@
+package upgradecluster_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradecluster"
+	upgrademocks "github.com/aws/eks-anywhere/pkg/validations/upgradecluster/mocks"
+)
+
+type upgradeClusterValidationTest struct {
+	clusterSpec        *cluster.Spec
+	currentSpec        *cluster.Spec
+	managementCluster  *types.Cluster
+	ctx                context.Context
+	provider           *providermocks.MockProvider
+	clusterManager     *upgrademocks.MockClusterManager
+	upgradeValidations *upgrademocks.MockValidator
+}
+
+func newValidateTest(t *testing.T) *upgradeClusterValidationTest {
+	mockCtrl := gomock.NewController(t)
+	return &upgradeClusterValidationTest{
+		ctx:                context.Background(),
+		managementCluster:  &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		provider:           providermocks.NewMockProvider(mockCtrl),
+		clusterManager:     upgrademocks.NewMockClusterManager(mockCtrl),
+		upgradeValidations: upgrademocks.NewMockValidator(mockCtrl),
+	}
+}
+
+func (u *upgradeClusterValidationTest) expectValidDockerClusterSpec() {
+	u.clusterSpec = test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "eksa-unit-test",
+			},
+			Spec: v1alpha1.ClusterSpec{
+				ClusterNetwork: v1alpha1.ClusterNetwork{
+					CNIConfig: &v1alpha1.CNIConfig{
+						Cilium: &v1alpha1.CiliumConfig{},
+					},
+					Pods: v1alpha1.Pods{
+						CidrBlocks: []string{"192.168.0.0/16"},
+					},
+					Services: v1alpha1.Services{
+						CidrBlocks: []string{"10.96.0.0/12"},
+					},
+				},
+				ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+					Count: 1,
+				},
+				DatacenterRef: v1alpha1.Ref{
+					Kind: "DockerDatacenterConfig",
+					Name: "eksa-unit-test",
+				},
+				KubernetesVersion: v1alpha1.GetClusterDefaultKubernetesVersion(),
+				WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{{
+					Name:  "md-0",
+					Count: 1,
+				}},
+			},
+		}
+		s.DockerDatacenter = &v1alpha1.DockerDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "eksa-unit-test",
+			},
+		}
+	})
+	u.currentSpec = u.clusterSpec.DeepCopy()
+}
+
+func (u *upgradeClusterValidationTest) expectCurrentSpec() {
+	u.clusterManager.EXPECT().GetCurrentClusterSpec(u.ctx, u.managementCluster, "eksa-unit-test").Return(u.currentSpec, nil)
+}
+
+func (u *upgradeClusterValidationTest) expectProviderValidation(err error) {
+	u.provider.EXPECT().SetupAndValidateUpgradeCluster(u.ctx, u.managementCluster, u.clusterSpec, u.currentSpec).Return(err)
+	u.provider.EXPECT().UpgradeClusterChecks(u.ctx, u.clusterSpec).Return(nil)
+	u.provider.EXPECT().Name().Return("docker").AnyTimes()
+}
+
+type validation struct {
+	run bool
+}
+
+func (u *upgradeClusterValidationTest) expectBuildValidations() *validation {
+	v := &validation{}
+	u.upgradeValidations.EXPECT().BuildValidations(u.ctx).Return(
+		[]validations.Validation{
+			func() *validations.ValidationResult {
+				v.run = true
+				return &validations.ValidationResult{
+					Err: nil,
+				}
+			},
+		},
+	)
+
+	return v
+}
+
+func TestUpgradeClusterValidationsSuccess(t *testing.T) {
+	g := NewWithT(t)
+	test := newValidateTest(t)
+	test.expectValidDockerClusterSpec()
+	test.expectCurrentSpec()
+	test.expectProviderValidation(nil)
+	validationFromBuild := test.expectBuildValidations()
+
+	commandVal := upgradecluster.NewValidations(test.clusterSpec, test.managementCluster, test.provider, test.clusterManager, test.upgradeValidations)
+
+	g.Expect(commandVal.Validate(test.ctx)).To(Succeed())
+	g.Expect(validationFromBuild.run).To(BeTrue(), "validation coming from BuildValidations should be run")
+}
+
+func TestUpgradeClusterValidationsProviderFailure(t *testing.T) {
+	g := NewWithT(t)
+	test := newValidateTest(t)
+	test.expectValidDockerClusterSpec()
+	test.expectCurrentSpec()
+	test.expectProviderValidation(errors.New("invalid hardware"))
+	validationFromBuild := test.expectBuildValidations()
+
+	commandVal := upgradecluster.NewValidations(test.clusterSpec, test.managementCluster, test.provider, test.clusterManager, test.upgradeValidations)
+
+	g.Expect(commandVal.Validate(test.ctx)).NotTo(Succeed())
+	g.Expect(validationFromBuild.run).To(BeTrue(), "all validations should run even if one fails")
+}
+
+func TestUpgradeClusterValidationsCurrentSpecError(t *testing.T) {
+	g := NewWithT(t)
+	test := newValidateTest(t)
+	test.expectValidDockerClusterSpec()
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.managementCluster, "eksa-unit-test").Return(nil, errors.New("cluster not found"))
+
+	commandVal := upgradecluster.NewValidations(test.clusterSpec, test.managementCluster, test.provider, test.clusterManager, test.upgradeValidations)
+
+	g.Expect(commandVal.Validate(test.ctx)).To(MatchError(ContainSubstring("cluster not found")))
+}
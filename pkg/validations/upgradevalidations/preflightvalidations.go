@@ -14,65 +14,99 @@ import (
 )
 
 func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err error) {
+	runner := validations.NewRunner(u.Opts.RunnerOpts()...)
+	runner.Register(u.BuildValidations(ctx)...)
+
+	return validations.ProcessValidationResults(runner.Evaluate())
+}
+
+func (u *UpgradeValidations) BuildValidations(ctx context.Context) []validations.Validation {
 	k := u.Opts.Kubectl
 
 	targetCluster := &types.Cluster{
 		Name:           u.Opts.WorkloadCluster.Name,
 		KubeconfigFile: u.Opts.ManagementCluster.KubeconfigFile,
 	}
-	upgradeValidations := []validations.ValidationResult{
-		{
-			Name:        "validate certificate for registry mirror",
-			Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
-			Err:         validations.ValidateCertForRegistryMirror(u.Opts.Spec, u.Opts.TlsValidator),
+	upgradeValidations := []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate certificate for registry mirror",
+				Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
+				Err:         validations.ValidateCertForRegistryMirror(u.Opts.Spec, u.Opts.TlsValidator),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "control plane ready",
+				Remediation: fmt.Sprintf("ensure control plane nodes and pods for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
+				Err:         k.ValidateControlPlaneNodes(ctx, targetCluster, targetCluster.Name),
+			}
 		},
-		{
-			Name:        "control plane ready",
-			Remediation: fmt.Sprintf("ensure control plane nodes and pods for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
-			Err:         k.ValidateControlPlaneNodes(ctx, targetCluster, targetCluster.Name),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "worker nodes ready",
+				Remediation: fmt.Sprintf("ensure machine deployments for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
+				Err:         k.ValidateWorkerNodes(ctx, u.Opts.Spec.Cluster.Name, targetCluster.KubeconfigFile),
+			}
 		},
-		{
-			Name:        "worker nodes ready",
-			Remediation: fmt.Sprintf("ensure machine deployments for cluster %s are Ready", u.Opts.WorkloadCluster.Name),
-			Err:         k.ValidateWorkerNodes(ctx, u.Opts.Spec.Cluster.Name, targetCluster.KubeconfigFile),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "nodes ready",
+				Remediation: fmt.Sprintf("check the Status of the control plane and worker nodes in cluster %s and verify they are Ready", u.Opts.WorkloadCluster.Name),
+				Err:         k.ValidateNodes(ctx, u.Opts.WorkloadCluster.KubeconfigFile),
+			}
 		},
-		{
-			Name:        "nodes ready",
-			Remediation: fmt.Sprintf("check the Status of the control plane and worker nodes in cluster %s and verify they are Ready", u.Opts.WorkloadCluster.Name),
-			Err:         k.ValidateNodes(ctx, u.Opts.WorkloadCluster.KubeconfigFile),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "cluster CRDs ready",
+				Remediation: fmt.Sprintf("ensure the EKS Anywhere CRDs are installed in cluster %s", u.Opts.ManagementCluster.Name),
+				Err:         k.ValidateClustersCRD(ctx, u.Opts.ManagementCluster),
+			}
 		},
-		{
-			Name:        "cluster CRDs ready",
-			Remediation: "",
-			Err:         k.ValidateClustersCRD(ctx, u.Opts.ManagementCluster),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "cluster object present on workload cluster",
+				Remediation: fmt.Sprintf("ensure that the CAPI cluster object %s representing cluster %s is present", clusterv1.GroupVersion, u.Opts.WorkloadCluster.Name),
+				Err:         ValidateClusterObjectExists(ctx, k, u.Opts.ManagementCluster),
+			}
 		},
-		{
-			Name:        "cluster object present on workload cluster",
-			Remediation: fmt.Sprintf("ensure that the CAPI cluster object %s representing cluster %s is present", clusterv1.GroupVersion, u.Opts.WorkloadCluster.Name),
-			Err:         ValidateClusterObjectExists(ctx, k, u.Opts.ManagementCluster),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "upgrade cluster kubernetes version increment",
+				Remediation: "ensure that the cluster kubernetes version is incremented by one minor version exactly (e.g. 1.18 -> 1.19)",
+				Err:         ValidateServerVersionSkew(ctx, u.Opts.Spec.Cluster.Spec.KubernetesVersion, u.Opts.WorkloadCluster, k),
+			}
 		},
-		{
-			Name:        "upgrade cluster kubernetes version increment",
-			Remediation: "ensure that the cluster kubernetes version is incremented by one minor version exactly (e.g. 1.18 -> 1.19)",
-			Err:         ValidateServerVersionSkew(ctx, u.Opts.Spec.Cluster.Spec.KubernetesVersion, u.Opts.WorkloadCluster, k),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "upgrade worker node groups kubernetes version skew",
+				Remediation: "ensure that each worker node group kubernetes version is within 2 minor versions of the cluster kubernetes version and is incremented by one minor version at most",
+				Err:         ValidateWorkerNodeGroupsKubernetesVersionSkew(ctx, k, targetCluster, u.Opts.Spec),
+			}
 		},
-		{
-			Name:        "validate authentication for git provider",
-			Remediation: fmt.Sprintf("ensure %s, %s env variable are set and valid", config.EksaGitPrivateKeyTokenEnv, config.EksaGitKnownHostsFileEnv),
-			Err:         validations.ValidateAuthenticationForGitProvider(u.Opts.Spec, u.Opts.CliConfig),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate authentication for git provider",
+				Remediation: fmt.Sprintf("ensure %s, %s env variable are set and valid", config.EksaGitPrivateKeyTokenEnv, config.EksaGitKnownHostsFileEnv),
+				Err:         validations.ValidateAuthenticationForGitProvider(u.Opts.Spec, u.Opts.CliConfig),
+			}
 		},
-		{
-			Name:        "validate immutable fields",
-			Remediation: "",
-			Err:         ValidateImmutableFields(ctx, k, targetCluster, u.Opts.Spec, u.Opts.Provider),
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate immutable fields",
+				Remediation: "revert the changes to fields that can't be updated during an upgrade",
+				Err:         ValidateImmutableFields(ctx, k, targetCluster, u.Opts.Spec, u.Opts.Provider),
+			}
 		},
-		{
-			Name:        "validate kubernetes version 1.24 support",
-			Remediation: fmt.Sprintf("ensure %v env variable is set", features.K8s124SupportEnvVar),
-			Err:         validations.ValidateK8s124Support(u.Opts.Spec),
-			Silent:      true,
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate kubernetes version 1.24 support",
+				Remediation: fmt.Sprintf("ensure %v env variable is set", features.K8s124SupportEnvVar),
+				Err:         validations.ValidateK8s124Support(u.Opts.Spec),
+				Silent:      true,
+			}
 		},
 	}
 
-	return validations.ProcessValidationResults(upgradeValidations)
+	return validations.WithCategory(validations.CategoryPreflight, upgradeValidations...)
 }
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/version"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -43,3 +44,60 @@ func ValidateServerVersionSkew(ctx context.Context, compareVersion v1alpha1.Kube
 	}
 	return nil
 }
+
+// ValidateWorkerNodeGroupsKubernetesVersionSkew validates the Kubernetes version of every worker node group
+// against the new control plane version and against the version the worker node group is currently running.
+func ValidateWorkerNodeGroupsKubernetesVersionSkew(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, spec *cluster.Spec) error {
+	prevCluster, err := k.GetEksaCluster(ctx, cluster, spec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	controlPlaneVersion, err := version.ParseGeneric(string(spec.Cluster.Spec.KubernetesVersion))
+	if err != nil {
+		return fmt.Errorf("parsing cluster kubernetes version: %v", err)
+	}
+
+	prevWorkerNodeGroups := make(map[string]v1alpha1.WorkerNodeGroupConfiguration, len(prevCluster.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range prevCluster.Spec.WorkerNodeGroupConfigurations {
+		prevWorkerNodeGroups[workerNodeGroup.Name] = workerNodeGroup
+	}
+
+	for _, workerNodeGroup := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		workerVersion, err := version.ParseGeneric(string(spec.Cluster.WorkerNodeGroupKubernetesVersion(workerNodeGroup)))
+		if err != nil {
+			return fmt.Errorf("parsing kubernetes version for worker node group %s: %v", workerNodeGroup.Name, err)
+		}
+
+		if err := validateKubeletVersionSkew(controlPlaneVersion, workerVersion); err != nil {
+			return fmt.Errorf("worker node group %s: %v", workerNodeGroup.Name, err)
+		}
+
+		prevWorkerNodeGroup, ok := prevWorkerNodeGroups[workerNodeGroup.Name]
+		if !ok {
+			continue
+		}
+
+		prevWorkerVersion, err := version.ParseGeneric(string(prevCluster.WorkerNodeGroupKubernetesVersion(prevWorkerNodeGroup)))
+		if err != nil {
+			return fmt.Errorf("parsing current kubernetes version for worker node group %s: %v", workerNodeGroup.Name, err)
+		}
+
+		minorVersionDifference := int(workerVersion.Minor()) - int(prevWorkerVersion.Minor())
+		if workerVersion.Major() != prevWorkerVersion.Major() || minorVersionDifference < 0 || minorVersionDifference > supportedMinorVersionIncrement {
+			return fmt.Errorf("worker node group %s: version difference between upgrade version (%d.%d) and current version (%d.%d) do not meet the supported version increment of +%d",
+				workerNodeGroup.Name, workerVersion.Major(), workerVersion.Minor(), prevWorkerVersion.Major(), prevWorkerVersion.Minor(), supportedMinorVersionIncrement)
+		}
+	}
+
+	return nil
+}
+
+func validateKubeletVersionSkew(controlPlaneVersion, kubeletVersion *version.Version) error {
+	minorVersionDifference := int(controlPlaneVersion.Minor()) - int(kubeletVersion.Minor())
+	if controlPlaneVersion.Major() != kubeletVersion.Major() || minorVersionDifference < 0 || minorVersionDifference > v1alpha1.SupportedKubeletMinorVersionSkew {
+		return fmt.Errorf("kubelet version (%d.%d) must not be newer than the control plane version (%d.%d) nor older by more than %d minor versions",
+			kubeletVersion.Major(), kubeletVersion.Minor(), controlPlaneVersion.Major(), controlPlaneVersion.Minor(), v1alpha1.SupportedKubeletMinorVersionSkew)
+	}
+	return nil
+}
@@ -2,13 +2,20 @@ package upgradevalidations_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	clusterpkg "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
 )
 
@@ -57,3 +64,97 @@ func TestValidateVersionSkew(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateWorkerNodeGroupsKubernetesVersionSkew(t *testing.T) {
+	kube120 := v1alpha1.Kube120
+	kube121 := v1alpha1.Kube121
+	kube122 := v1alpha1.Kube122
+	tests := []struct {
+		name               string
+		wantErr            string
+		prevClusterVersion v1alpha1.KubernetesVersion
+		prevWorkerVersion  *v1alpha1.KubernetesVersion
+		newClusterVersion  v1alpha1.KubernetesVersion
+		newWorkerVersion   *v1alpha1.KubernetesVersion
+	}{
+		{
+			name:               "control plane upgraded with worker node group pinned",
+			prevClusterVersion: v1alpha1.Kube121,
+			newClusterVersion:  v1alpha1.Kube122,
+			newWorkerVersion:   &kube121,
+		},
+		{
+			name:               "worker node group upgraded after control plane",
+			prevClusterVersion: v1alpha1.Kube122,
+			prevWorkerVersion:  &kube121,
+			newClusterVersion:  v1alpha1.Kube122,
+			newWorkerVersion:   nil,
+		},
+		{
+			name:               "worker node group newer than control plane",
+			wantErr:            "worker node group md-0: kubelet version (1.23) must not be newer than the control plane version (1.22)",
+			prevClusterVersion: v1alpha1.Kube122,
+			newClusterVersion:  v1alpha1.Kube122,
+			newWorkerVersion:   versionPtr(v1alpha1.Kube123),
+		},
+		{
+			name:               "worker node group too old for control plane",
+			wantErr:            "worker node group md-0: kubelet version (1.20) must not be newer than the control plane version (1.23) nor older by more than 2 minor versions",
+			prevClusterVersion: v1alpha1.Kube122,
+			prevWorkerVersion:  &kube120,
+			newClusterVersion:  v1alpha1.Kube123,
+			newWorkerVersion:   &kube120,
+		},
+		{
+			name:               "worker node group skips a minor version",
+			wantErr:            "worker node group md-0: version difference between upgrade version (1.22) and current version (1.20) do not meet the supported version increment of +1",
+			prevClusterVersion: v1alpha1.Kube122,
+			prevWorkerVersion:  &kube120,
+			newClusterVersion:  v1alpha1.Kube122,
+			newWorkerVersion:   &kube122,
+		},
+		{
+			name:               "worker node group downgraded",
+			wantErr:            "worker node group md-0: version difference between upgrade version (1.21) and current version (1.22) do not meet the supported version increment of +1",
+			prevClusterVersion: v1alpha1.Kube122,
+			newClusterVersion:  v1alpha1.Kube122,
+			newWorkerVersion:   &kube121,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			k := mocks.NewMockKubectlClient(gomock.NewController(t))
+			cluster := &types.Cluster{KubeconfigFile: "kubeconfig"}
+			clusterSpec := test.NewClusterSpec(func(s *clusterpkg.Spec) {
+				s.Cluster.Name = testclustername
+				s.Cluster.Spec.KubernetesVersion = tc.newClusterVersion
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+					{Name: "md-0", KubernetesVersion: tc.newWorkerVersion},
+				}
+			})
+			prevCluster := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					KubernetesVersion: tc.prevClusterVersion,
+					WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+						{Name: "md-0", KubernetesVersion: tc.prevWorkerVersion},
+					},
+				},
+			}
+			k.EXPECT().GetEksaCluster(ctx, cluster, testclustername).Return(prevCluster, nil)
+
+			err := upgradevalidations.ValidateWorkerNodeGroupsKubernetesVersionSkew(ctx, k, cluster, clusterSpec)
+			if tc.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			}
+		})
+	}
+}
+
+func versionPtr(v v1alpha1.KubernetesVersion) *v1alpha1.KubernetesVersion {
+	return &v
+}
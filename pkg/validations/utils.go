@@ -1,20 +1,62 @@
 package validations
 
 import (
+	"regexp"
+	"strings"
 	"unicode"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
+// Severity defines whether a failed validation fails the action it validates.
+type Severity string
+
+const (
+	// SeverityError fails the validated action when the validation fails. It's the default.
+	SeverityError Severity = "error"
+	// SeverityWarning only reports a failed validation.
+	SeverityWarning Severity = "warning"
+)
+
+// Categories of validations.
+const (
+	// DefaultCategory is the category of validations that don't specify one.
+	DefaultCategory       = "general"
+	CategoryEnvironment   = "environment"
+	CategoryClusterConfig = "cluster-config"
+	CategoryProvider      = "provider"
+	CategoryGitOps        = "gitops"
+	CategoryPreflight     = "preflight"
+)
+
 type ValidationResult struct {
-	Name        string
+	Name string
+	// ID identifies the validation in reports. It defaults to a slug of Name.
+	ID string
+	// Category groups related validations in reports. It defaults to DefaultCategory.
+	Category string
+	// Severity defaults to SeverityError.
+	Severity    Severity
 	Err         error
 	Remediation string
 	Silent      bool
+	// Skipped marks results of validations the user chose to skip. They never fail.
+	Skipped bool
+	// Aggregated marks results summarizing other results that are reported individually. They
+	// are excluded from reports.
+	Aggregated bool
 }
 
 func (v *ValidationResult) Report() {
+	if v.Skipped {
+		logger.MarkWarning("Validation skipped", "validation", v.Name, "id", v.GetID())
+		return
+	}
 	if v.Err != nil {
+		if v.GetSeverity() == SeverityWarning {
+			logger.MarkWarning("Validation warning", "validation", v.Name, "warning", v.Err, "remediation", v.Remediation)
+			return
+		}
 		logger.MarkFail("Validation failed", "validation", v.Name, "error", v.Err, "remediation", v.Remediation)
 		return
 	}
@@ -27,6 +69,57 @@ func (v *ValidationResult) LogPass() {
 	logger.MarkPass(capitalize(v.Name))
 }
 
+// Failed returns true if the validation failed with SeverityError and wasn't skipped.
+func (v *ValidationResult) Failed() bool {
+	return v.Err != nil && !v.Skipped && v.GetSeverity() == SeverityError
+}
+
+// GetID returns the ID of the validation, or a slug of its name if it has none.
+func (v *ValidationResult) GetID() string {
+	if v.ID != "" {
+		return v.ID
+	}
+	return slugify(v.Name)
+}
+
+// GetCategory returns the category of the validation, or DefaultCategory if it has none.
+func (v *ValidationResult) GetCategory() string {
+	if v.Category != "" {
+		return v.Category
+	}
+	return DefaultCategory
+}
+
+// GetSeverity returns the severity of the validation, or SeverityError if it has none.
+func (v *ValidationResult) GetSeverity() Severity {
+	if v.Severity != "" {
+		return v.Severity
+	}
+	return SeverityError
+}
+
+// WithCategory sets category on the results of validations that don't have a category.
+func WithCategory(category string, validations ...Validation) []Validation {
+	categorized := make([]Validation, 0, len(validations))
+	for _, validation := range validations {
+		validation := validation
+		categorized = append(categorized, func() *ValidationResult {
+			result := validation()
+			if result.Category == "" {
+				result.Category = category
+			}
+			return result
+		})
+	}
+	return categorized
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(s string) string {
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
 func capitalize(s string) string {
 	if len(s) == 0 {
 		return s
@@ -16,6 +16,12 @@ type Opts struct {
 	Provider          providers.Provider
 	TlsValidator      TlsValidator
 	CliConfig         *config.CliConfig
+	// DockerExecutable validates the resources allocated to Docker when set.
+	DockerExecutable DockerExecutable
+	// Report records the result of each preflight validation when set.
+	Report *Report
+	// Overrides skips or downgrades preflight validations by ID.
+	Overrides Overrides
 }
 
 func (o *Opts) SetDefaults() {
@@ -23,3 +29,8 @@ func (o *Opts) SetDefaults() {
 		o.TlsValidator = crypto.NewTlsValidator()
 	}
 }
+
+// RunnerOpts returns the options to run the preflight validations with.
+func (o *Opts) RunnerOpts() []RunnerOpt {
+	return []RunnerOpt{WithReport(o.Report), WithOverrides(o.Overrides)}
+}
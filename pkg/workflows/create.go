@@ -24,6 +24,7 @@ type Create struct {
 	writer           filewriter.FileWriter
 	eksdInstaller    interfaces.EksdInstaller
 	packageInstaller interfaces.PackageInstaller
+	validationOpts   []validations.RunnerOpt
 }
 
 func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
@@ -42,6 +43,12 @@ func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider
 	}
 }
 
+// WithValidationOpts configures how the setup validations are run.
+func (c *Create) WithValidationOpts(opts ...validations.RunnerOpt) *Create {
+	c.validationOpts = opts
+	return c
+}
+
 func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator, forceCleanup bool) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
@@ -58,6 +65,7 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		ClusterSpec:      clusterSpec,
 		Writer:           c.writer,
 		Validations:      validator,
+		ValidationOpts:   c.validationOpts,
 		EksdInstaller:    c.eksdInstaller,
 		PackageInstaller: c.packageInstaller,
 	}
@@ -161,7 +169,7 @@ func (s *CreateBootStrapClusterTask) Checkpoint() *task.CompletedTask {
 
 func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Performing setup and validations")
-	runner := validations.NewRunner()
+	runner := validations.NewRunner(commandContext.ValidationOpts...)
 	runner.Register(s.providerValidation(ctx, commandContext)...)
 	runner.Register(commandContext.GitOpsManager.Validations(ctx, commandContext.ClusterSpec)...)
 	runner.Register(s.validations(ctx, commandContext)...)
@@ -178,22 +186,22 @@ func (s *SetAndValidateTask) validations(ctx context.Context, commandContext *ta
 	return []validations.Validation{
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
-				Name: "create preflight validations pass",
-				Err:  commandContext.Validations.PreflightValidations(ctx),
+				Name:       "create preflight validations pass",
+				Err:        commandContext.Validations.PreflightValidations(ctx),
+				Aggregated: true,
 			}
 		},
 	}
 }
 
 func (s *SetAndValidateTask) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
-	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec),
-			}
+	return validations.ProviderValidations(
+		fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()),
+		func() error {
+			return commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec)
 		},
-	}
+		commandContext.Provider.CreateClusterChecks(ctx, commandContext.ClusterSpec),
+	)
 }
 
 func (s *SetAndValidateTask) Name() string {
@@ -224,6 +232,17 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 		return &CollectDiagnosticsTask{}
 	}
 
+	// All the control plane machines only exist once RunPostCreateWorkloadCluster returns.
+	if err = commandContext.Provider.PostControlPlaneReady(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	if err = commandContext.Provider.PostWorkerNodesReady(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
 	logger.Info("Installing networking on workload cluster")
 	err = commandContext.ClusterManager.InstallNetworking(ctx, workloadCluster, commandContext.ClusterSpec, commandContext.Provider)
 	if err != nil {
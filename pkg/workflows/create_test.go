@@ -78,6 +78,7 @@ func newCreateTest(t *testing.T) *createTestSetup {
 
 func (c *createTestSetup) expectSetup() {
 	c.provider.EXPECT().SetupAndValidateCreateCluster(c.ctx, c.clusterSpec)
+	c.provider.EXPECT().CreateClusterChecks(c.ctx, c.clusterSpec)
 	c.provider.EXPECT().Name()
 	c.gitOpsManager.EXPECT().Validations(c.ctx, c.clusterSpec)
 }
@@ -108,6 +109,8 @@ func (c *createTestSetup) expectCreateWorkload() {
 		c.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
 			c.ctx, c.bootstrapCluster, c.workloadCluster, c.clusterSpec,
 		),
+		c.provider.EXPECT().PostControlPlaneReady(c.ctx, c.clusterSpec, c.bootstrapCluster),
+		c.provider.EXPECT().PostWorkerNodesReady(c.ctx, c.clusterSpec, c.bootstrapCluster),
 		c.clusterManager.EXPECT().InstallNetworking(
 			c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
 		),
@@ -138,6 +141,8 @@ func (c *createTestSetup) expectCreateWorkloadSkipCAPI() {
 		c.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
 			c.ctx, c.bootstrapCluster, c.workloadCluster, c.clusterSpec,
 		),
+		c.provider.EXPECT().PostControlPlaneReady(c.ctx, c.clusterSpec, c.bootstrapCluster),
+		c.provider.EXPECT().PostWorkerNodesReady(c.ctx, c.clusterSpec, c.bootstrapCluster),
 		c.clusterManager.EXPECT().InstallNetworking(
 			c.ctx, c.workloadCluster, c.clusterSpec, c.provider,
 		),
@@ -388,3 +393,36 @@ func TestCreateWorkloadClusterTaskRunPostCreateWorkloadClusterFailure(t *testing
 		t.Fatalf("expected error from task")
 	}
 }
+
+func TestCreateWorkloadClusterTaskPostControlPlaneReadyFailure(t *testing.T) {
+	test := newCreateTest(t)
+	commandContext := task.CommandContext{
+		BootstrapCluster: test.bootstrapCluster,
+		ClusterSpec:      test.clusterSpec,
+		Provider:         test.provider,
+		ClusterManager:   test.clusterManager,
+	}
+
+	gomock.InOrder(
+		test.clusterManager.EXPECT().CreateWorkloadCluster(
+			test.ctx, test.bootstrapCluster, test.clusterSpec, test.provider,
+		).Return(test.workloadCluster, nil),
+		test.clusterManager.EXPECT().RunPostCreateWorkloadCluster(
+			test.ctx, test.bootstrapCluster, test.workloadCluster, test.clusterSpec,
+		),
+		test.provider.EXPECT().PostControlPlaneReady(
+			test.ctx, test.clusterSpec, test.bootstrapCluster,
+		).Return(errors.New("error creating rules")),
+		test.clusterManager.EXPECT().SaveLogsManagementCluster(
+			test.ctx, test.clusterSpec, test.bootstrapCluster,
+		),
+		test.clusterManager.EXPECT().SaveLogsWorkloadCluster(
+			test.ctx, test.provider, test.clusterSpec, test.workloadCluster,
+		),
+		test.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", test.clusterSpec.Cluster.Name), gomock.Any()),
+	)
+	err := task.NewTaskRunner(&workflows.CreateWorkloadClusterTask{}, test.writer).RunTask(test.ctx, &commandContext)
+	if err == nil {
+		t.Fatalf("expected error from task")
+	}
+}
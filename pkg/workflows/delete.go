@@ -82,6 +82,15 @@ func (s *setupAndValidate) Run(ctx context.Context, commandContext *task.Command
 		commandContext.SetError(err)
 		return nil
 	}
+
+	managementCluster := commandContext.WorkloadCluster
+	if commandContext.ClusterSpec != nil && commandContext.ClusterSpec.ManagementCluster != nil {
+		managementCluster = commandContext.ClusterSpec.ManagementCluster
+	}
+	if err := commandContext.Provider.PreMachinesRemoval(ctx, commandContext.ClusterSpec, managementCluster); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
 	return &createManagementCluster{}
 }
 
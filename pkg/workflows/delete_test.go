@@ -60,6 +60,10 @@ func (c *deleteTestSetup) expectSetup() {
 	c.provider.EXPECT().SetupAndValidateDeleteCluster(c.ctx, c.workloadCluster, c.clusterSpec)
 }
 
+func (c *deleteTestSetup) expectPreMachinesRemoval(managementCluster *types.Cluster) {
+	c.provider.EXPECT().PreMachinesRemoval(c.ctx, c.clusterSpec, managementCluster)
+}
+
 func (c *deleteTestSetup) expectCreateBootstrap() {
 	opts := []bootstrapper.BootstrapClusterOption{
 		bootstrapper.WithExtraDockerMounts(),
@@ -150,6 +154,7 @@ func (c *deleteTestSetup) run() error {
 func TestDeleteRunSuccess(t *testing.T) {
 	test := newDeleteTest(t)
 	test.expectSetup()
+	test.expectPreMachinesRemoval(test.workloadCluster)
 	test.expectCreateBootstrap()
 	test.expectDeleteWorkload(test.bootstrapCluster)
 	test.expectCleanupGitRepo()
@@ -173,6 +178,7 @@ func TestDeleteWorkloadRunSuccess(t *testing.T) {
 		ExistingManagement: true,
 	}
 	test.clusterSpec.Cluster.SetManagedBy(test.clusterSpec.ManagementCluster.Name)
+	test.expectPreMachinesRemoval(test.clusterSpec.ManagementCluster)
 	test.expectDeleteWorkload(test.clusterSpec.ManagementCluster)
 	test.expectCleanupGitRepo()
 	test.expectNotToMoveManagement()
@@ -195,6 +201,7 @@ func TestDeleteWorkloadDeletePackageResourceError(t *testing.T) {
 		ExistingManagement: true,
 	}
 	test.clusterSpec.Cluster.SetManagedBy(test.clusterSpec.ManagementCluster.Name)
+	test.expectPreMachinesRemoval(test.clusterSpec.ManagementCluster)
 	test.expectDeleteWorkload(test.clusterSpec.ManagementCluster)
 	test.expectCleanupGitRepo()
 	test.expectNotToMoveManagement()
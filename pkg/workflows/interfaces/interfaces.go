@@ -75,4 +75,5 @@ type EksdUpgrader interface {
 
 type PackageInstaller interface {
 	InstallCuratedPackages(ctx context.Context) error
+	ReconcileCuratedPackages(ctx context.Context) error
 }
@@ -807,3 +807,17 @@ func (mr *MockPackageInstallerMockRecorder) InstallCuratedPackages(arg0 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCuratedPackages", reflect.TypeOf((*MockPackageInstaller)(nil).InstallCuratedPackages), arg0)
 }
+
+// ReconcileCuratedPackages mocks base method.
+func (m *MockPackageInstaller) ReconcileCuratedPackages(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileCuratedPackages", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileCuratedPackages indicates an expected call of ReconcileCuratedPackages.
+func (mr *MockPackageInstallerMockRecorder) ReconcileCuratedPackages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileCuratedPackages", reflect.TypeOf((*MockPackageInstaller)(nil).ReconcileCuratedPackages), arg0)
+}
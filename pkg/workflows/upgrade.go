@@ -26,12 +26,15 @@ type Upgrade struct {
 	capiManager       interfaces.CAPIManager
 	eksdInstaller     interfaces.EksdInstaller
 	eksdUpgrader      interfaces.EksdUpgrader
+	packageInstaller  interfaces.PackageInstaller
 	upgradeChangeDiff *types.ChangeDiff
+	validationOpts    []validations.RunnerOpt
 }
 
 func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
 	capiManager interfaces.CAPIManager,
 	clusterManager interfaces.ClusterManager, gitOpsManager interfaces.GitOpsManager, writer filewriter.FileWriter, eksdUpgrader interfaces.EksdUpgrader, eksdInstaller interfaces.EksdInstaller,
+	packageInstaller interfaces.PackageInstaller,
 ) *Upgrade {
 	upgradeChangeDiff := types.NewChangeDiff()
 	return &Upgrade{
@@ -43,10 +46,17 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 		capiManager:       capiManager,
 		eksdUpgrader:      eksdUpgrader,
 		eksdInstaller:     eksdInstaller,
+		packageInstaller:  packageInstaller,
 		upgradeChangeDiff: upgradeChangeDiff,
 	}
 }
 
+// WithValidationOpts configures how the setup validations are run.
+func (c *Upgrade) WithValidationOpts(opts ...validations.RunnerOpt) *Upgrade {
+	c.validationOpts = opts
+	return c
+}
+
 func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup bool) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
@@ -65,10 +75,12 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, management
 		WorkloadCluster:   workloadCluster,
 		ClusterSpec:       clusterSpec,
 		Validations:       validator,
+		ValidationOpts:    c.validationOpts,
 		Writer:            c.writer,
 		CAPIManager:       c.capiManager,
 		EksdInstaller:     c.eksdInstaller,
 		EksdUpgrader:      c.eksdUpgrader,
+		PackageInstaller:  c.packageInstaller,
 		UpgradeChangeDiff: c.upgradeChangeDiff,
 	}
 	if features.IsActive(features.CheckpointEnabled()) {
@@ -116,6 +128,10 @@ type resumeEksaReconcile struct {
 
 type writeClusterConfigTask struct{}
 
+type reconcileCuratedPackagesTask struct {
+	clusterUpgraded bool
+}
+
 func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Performing setup and validations")
 	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec.Cluster.Name)
@@ -124,7 +140,7 @@ func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.Co
 		return nil
 	}
 	commandContext.CurrentClusterSpec = currentSpec
-	runner := validations.NewRunner()
+	runner := validations.NewRunner(commandContext.ValidationOpts...)
 	runner.Register(s.validations(ctx, commandContext)...)
 
 	err = runner.Run()
@@ -137,20 +153,21 @@ func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.Co
 }
 
 func (s *setupAndValidateTasks) validations(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
-	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("%s provider validation", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec),
-			}
-		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: "upgrade preflight validations pass",
-				Err:  commandContext.Validations.PreflightValidations(ctx),
-			}
+	vs := validations.ProviderValidations(
+		fmt.Sprintf("%s provider validation", commandContext.Provider.Name()),
+		func() error {
+			return commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec)
 		},
-	}
+		commandContext.Provider.UpgradeClusterChecks(ctx, commandContext.ClusterSpec),
+	)
+
+	return append(vs, func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:       "upgrade preflight validations pass",
+			Err:        commandContext.Validations.PreflightValidations(ctx),
+			Aggregated: true,
+		}
+	})
 }
 
 func (s *setupAndValidateTasks) Name() string {
@@ -298,7 +315,7 @@ func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandCon
 		return nil
 	} else if upgradeNeeded {
 		logger.V(3).Info("Provider needs a cluster upgrade")
-		return &createBootstrapClusterTask{}
+		return s.proceed(ctx, commandContext)
 	}
 	diff, err := commandContext.ClusterManager.EKSAClusterSpecChanged(ctx, commandContext.ManagementCluster, newSpec)
 	if err != nil {
@@ -311,6 +328,16 @@ func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandCon
 		return &resumeEksaReconcile{}
 	}
 
+	return s.proceed(ctx, commandContext)
+}
+
+// proceed prepares the provider for the removal of machines by the upgrade.
+func (s *upgradeNeeded) proceed(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := commandContext.Provider.PreMachinesRemoval(ctx, commandContext.ClusterSpec, commandContext.ManagementCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
 	return &createBootstrapClusterTask{}
 }
 
@@ -325,6 +352,9 @@ func (s *upgradeNeeded) Checkpoint() *task.CompletedTask {
 }
 
 func (s *upgradeNeeded) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	if err := commandContext.Provider.PreMachinesRemoval(ctx, commandContext.ClusterSpec, commandContext.ManagementCluster); err != nil {
+		return nil, err
+	}
 	return &createBootstrapClusterTask{}, nil
 }
 
@@ -609,7 +639,7 @@ func (s *resumeEksaReconcile) Run(ctx context.Context, commandContext *task.Comm
 		return &writeClusterConfigTask{}
 	}
 	if !s.eksaSpecDiff {
-		return nil
+		return &reconcileCuratedPackagesTask{}
 	}
 	return &writeClusterConfigTask{}
 }
@@ -634,7 +664,7 @@ func (s *writeClusterConfigTask) Run(ctx context.Context, commandContext *task.C
 	if err != nil {
 		commandContext.SetError(err)
 	}
-	return &deleteBootstrapClusterTask{}
+	return &reconcileCuratedPackagesTask{clusterUpgraded: true}
 }
 
 func (s *writeClusterConfigTask) Name() string {
@@ -648,6 +678,35 @@ func (s *writeClusterConfigTask) Checkpoint() *task.CompletedTask {
 }
 
 func (s *writeClusterConfigTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &reconcileCuratedPackagesTask{clusterUpgraded: true}, nil
+}
+
+func (s *reconcileCuratedPackagesTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if commandContext.OriginalError == nil && commandContext.PackageInstaller != nil {
+		if err := commandContext.PackageInstaller.ReconcileCuratedPackages(ctx); err != nil {
+			logger.MarkFail("Curated packages reconcile failed; please apply the packages through eksctl anywhere apply packages command", "error", err)
+		}
+	}
+	if !s.clusterUpgraded {
+		return nil
+	}
+	return &deleteBootstrapClusterTask{}
+}
+
+func (s *reconcileCuratedPackagesTask) Name() string {
+	return "reconcile-curated-packages"
+}
+
+func (s *reconcileCuratedPackagesTask) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+func (s *reconcileCuratedPackagesTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	if !s.clusterUpgraded {
+		return nil, nil
+	}
 	return &deleteBootstrapClusterTask{}, nil
 }
 
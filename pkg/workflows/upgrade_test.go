@@ -33,6 +33,7 @@ type upgradeTestSetup struct {
 	eksdInstaller      *mocks.MockEksdInstaller
 	eksdUpgrader       *mocks.MockEksdUpgrader
 	capiManager        *mocks.MockCAPIManager
+	packageInstaller   *mocks.MockPackageInstaller
 	datacenterConfig   providers.DatacenterConfig
 	machineConfigs     []providers.MachineConfig
 	workflow           *workflows.Upgrade
@@ -58,8 +59,9 @@ func newUpgradeTest(t *testing.T) *upgradeTestSetup {
 	eksdUpgrader := mocks.NewMockEksdUpgrader(mockCtrl)
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	capiUpgrader := mocks.NewMockCAPIManager(mockCtrl)
+	packageInstaller := mocks.NewMockPackageInstaller(mockCtrl)
 	machineConfigs := []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}}
-	workflow := workflows.NewUpgrade(bootstrapper, provider, capiUpgrader, clusterManager, gitOpsManager, writer, eksdUpgrader, eksdInstaller)
+	workflow := workflows.NewUpgrade(bootstrapper, provider, capiUpgrader, clusterManager, gitOpsManager, writer, eksdUpgrader, eksdInstaller, packageInstaller)
 
 	for _, e := range featureEnvVars {
 		if err := os.Setenv(e, "true"); err != nil {
@@ -86,6 +88,7 @@ func newUpgradeTest(t *testing.T) *upgradeTestSetup {
 		eksdInstaller:    eksdInstaller,
 		eksdUpgrader:     eksdUpgrader,
 		capiManager:      capiUpgrader,
+		packageInstaller: packageInstaller,
 		datacenterConfig: datacenterConfig,
 		machineConfigs:   machineConfigs,
 		workflow:         workflow,
@@ -121,6 +124,11 @@ func newUpgradeManagedClusterTest(t *testing.T) *upgradeTestSetup {
 }
 
 func (c *upgradeTestSetup) expectSetup() {
+	c.expectSetupRestore()
+	c.provider.EXPECT().UpgradeClusterChecks(c.ctx, c.newClusterSpec)
+}
+
+func (c *upgradeTestSetup) expectSetupRestore() {
 	c.provider.EXPECT().SetupAndValidateUpgradeCluster(c.ctx, gomock.Any(), c.newClusterSpec, c.currentClusterSpec)
 	c.provider.EXPECT().Name()
 	c.clusterManager.EXPECT().GetCurrentClusterSpec(c.ctx, gomock.Any(), c.newClusterSpec.Cluster.Name).Return(c.currentClusterSpec, nil)
@@ -214,6 +222,10 @@ func (c *upgradeTestSetup) expectWriteClusterConfig() {
 	)
 }
 
+func (c *upgradeTestSetup) expectReconcileCuratedPackages() {
+	c.packageInstaller.EXPECT().ReconcileCuratedPackages(c.ctx).Return(nil)
+}
+
 func (c *upgradeTestSetup) expectDeleteBootstrap() {
 	gomock.InOrder(
 		c.bootstrapper.EXPECT().DeleteBootstrapCluster(
@@ -355,9 +367,14 @@ func (c *upgradeTestSetup) expectPostBootstrapDeleteForUpgrade() {
 func (c *upgradeTestSetup) expectVerifyClusterSpecChanged(expectedCluster *types.Cluster) {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().EKSAClusterSpecChanged(c.ctx, expectedCluster, c.newClusterSpec).Return(true, nil),
+		c.provider.EXPECT().PreMachinesRemoval(c.ctx, c.newClusterSpec, expectedCluster),
 	)
 }
 
+func (c *upgradeTestSetup) expectPreMachinesRemoval(expectedCluster *types.Cluster) {
+	c.provider.EXPECT().PreMachinesRemoval(c.ctx, c.newClusterSpec, expectedCluster)
+}
+
 func (c *upgradeTestSetup) expectSaveLogs(expectedWorkloadCluster *types.Cluster) {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().SaveLogsManagementCluster(c.ctx, c.newClusterSpec, c.bootstrapCluster).Return(nil),
@@ -380,7 +397,10 @@ func (c *upgradeTestSetup) expectProviderNoUpgradeNeeded(expectedCluster *types.
 }
 
 func (c *upgradeTestSetup) expectProviderUpgradeNeeded() {
-	c.provider.EXPECT().UpgradeNeeded(c.ctx, c.newClusterSpec, c.currentClusterSpec, c.workloadCluster).Return(true, nil)
+	gomock.InOrder(
+		c.provider.EXPECT().UpgradeNeeded(c.ctx, c.newClusterSpec, c.currentClusterSpec, c.workloadCluster).Return(true, nil),
+		c.provider.EXPECT().PreMachinesRemoval(c.ctx, c.newClusterSpec, c.workloadCluster),
+	)
 }
 
 func (c *upgradeTestSetup) expectVerifyClusterSpecNoChanges() {
@@ -416,6 +436,7 @@ func TestSkipUpgradeRunSuccess(t *testing.T) {
 	test.expectUpdateGitEksaSpec()
 	test.expectForceReconcileGitRepo(test.workloadCluster)
 	test.expectResumeGitOpsReconcile(test.workloadCluster)
+	test.expectReconcileCuratedPackages()
 	test.expectCreateBootstrapNotToBeCalled()
 
 	err := test.run()
@@ -449,6 +470,7 @@ func TestUpgradeRunSuccess(t *testing.T) {
 	test.expectUpdateGitEksaSpec()
 	test.expectForceReconcileGitRepo(test.workloadCluster)
 	test.expectResumeGitOpsReconcile(test.workloadCluster)
+	test.expectReconcileCuratedPackages()
 	test.expectPostBootstrapDeleteForUpgrade()
 
 	err := test.run()
@@ -481,6 +503,7 @@ func TestUpgradeRunProviderNeedsUpgradeSuccess(t *testing.T) {
 	test.expectUpdateGitEksaSpec()
 	test.expectForceReconcileGitRepo(test.workloadCluster)
 	test.expectResumeGitOpsReconcile(test.workloadCluster)
+	test.expectReconcileCuratedPackages()
 	test.expectPostBootstrapDeleteForUpgrade()
 
 	err := test.run()
@@ -536,6 +559,7 @@ func TestUpgradeWorkloadRunSuccess(t *testing.T) {
 	test.expectUpdateGitEksaSpec()
 	test.expectForceReconcileGitRepo(test.managementCluster)
 	test.expectResumeGitOpsReconcile(test.managementCluster)
+	test.expectReconcileCuratedPackages()
 	test.expectUpgradeWorkload(test.managementCluster, test.workloadCluster)
 
 	err := test.run()
@@ -587,7 +611,8 @@ func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
 
 	test2 := newUpgradeSelfManagedClusterTest(t)
 	test2.writer.EXPECT().TempDir().Return("testdata")
-	test2.expectSetup()
+	test2.expectSetupRestore()
+	test2.expectPreMachinesRemoval(test2.workloadCluster)
 	test2.expectUpgradeWorkload(test2.bootstrapCluster, test2.workloadCluster)
 	test2.expectMoveManagementToWorkload()
 	test2.expectWriteClusterConfig()
@@ -600,6 +625,7 @@ func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
 	test2.expectUpdateGitEksaSpec()
 	test2.expectForceReconcileGitRepo(test2.workloadCluster)
 	test2.expectResumeGitOpsReconcile(test2.workloadCluster)
+	test2.expectReconcileCuratedPackages()
 	test2.expectPostBootstrapDeleteForUpgrade()
 
 	err = test2.run()
@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-anywhere/release/pkg/aws/kms"
 	"github.com/aws/eks-anywhere/release/pkg/aws/s3"
 	"github.com/aws/eks-anywhere/release/pkg/bundles"
 	"github.com/aws/eks-anywhere/release/pkg/clients"
@@ -76,6 +77,7 @@ var releaseCmd = &cobra.Command{
 		devRelease := viper.GetBool("dev-release")
 		dryRun := viper.GetBool("dry-run")
 		weekly := viper.GetBool("weekly")
+		manifestSigningKeyId := viper.GetString("manifest-signing-key-id")
 		releaseTime := time.Now().UTC()
 		releaseDate := releaseTime.Format(constants.YYYYMMDD)
 
@@ -91,6 +93,11 @@ var releaseCmd = &cobra.Command{
 			releaseVersion = cliMaxVersion
 		}
 
+		if !devRelease && manifestSigningKeyId == "" {
+			fmt.Println("Error: --manifest-signing-key-id is required for non-dev releases")
+			os.Exit(1)
+		}
+
 		releaseConfig := &releasetypes.ReleaseConfig{
 			CliRepoSource:            cliRepoDir,
 			BuildRepoSource:          buildRepoDir,
@@ -113,6 +120,7 @@ var releaseCmd = &cobra.Command{
 			DryRun:                   dryRun,
 			Weekly:                   weekly,
 			ReleaseEnvironment:       releaseEnvironment,
+			ManifestSigningKeyId:     manifestSigningKeyId,
 		}
 
 		err := operations.SetRepoHeads(releaseConfig)
@@ -208,7 +216,7 @@ var releaseCmd = &cobra.Command{
 				}
 
 				bundleReleaseManifestKey := artifactutils.GetManifestFilepaths(releaseConfig.DevRelease, releaseConfig.Weekly, releaseConfig.BundleNumber, constants.BundlesKind, releaseConfig.BuildRepoBranchName, releaseConfig.ReleaseDate)
-				err = s3.UploadFile(bundleReleaseManifestFile, aws.String(releaseConfig.ReleaseBucket), aws.String(bundleReleaseManifestKey), releaseConfig.ReleaseClients.S3.Uploader)
+				err = uploadSignedManifest(releaseConfig, bundleReleaseManifestFile, bundleReleaseManifestKey)
 				if err != nil {
 					fmt.Printf("Error uploading bundle manifest to release bucket: %+v", err)
 					os.Exit(1)
@@ -279,7 +287,7 @@ var releaseCmd = &cobra.Command{
 			}
 
 			eksAReleaseManifestKey := artifactutils.GetManifestFilepaths(releaseConfig.DevRelease, releaseConfig.Weekly, releaseConfig.BundleNumber, constants.ReleaseKind, releaseConfig.BuildRepoBranchName, releaseConfig.ReleaseDate)
-			err = s3.UploadFile(eksAReleaseManifestFile, aws.String(releaseConfig.ReleaseBucket), aws.String(eksAReleaseManifestKey), releaseConfig.ReleaseClients.S3.Uploader)
+			err = uploadSignedManifest(releaseConfig, eksAReleaseManifestFile, eksAReleaseManifestKey)
 			if err != nil {
 				fmt.Printf("Error uploading EKS-A release manifest to release bucket: %v", err)
 				os.Exit(1)
@@ -297,6 +305,30 @@ var releaseCmd = &cobra.Command{
 	},
 }
 
+// uploadSignedManifest signs the manifest with the configured KMS key and uploads the detached signature
+// before the manifest itself, so the CLI never finds a published manifest without its signature.
+// Only dev releases can skip signing, when no key is configured.
+func uploadSignedManifest(releaseConfig *releasetypes.ReleaseConfig, manifestFile, manifestKey string) error {
+	if releaseConfig.ManifestSigningKeyId != "" {
+		signatureFile, err := kms.SignFile(manifestFile, releaseConfig.ManifestSigningKeyId, releaseConfig.ReleaseClients.KMS)
+		if err != nil {
+			return fmt.Errorf("signing manifest %s: %v", manifestFile, err)
+		}
+
+		signatureKey := manifestKey + kms.SignatureSuffix
+		err = s3.UploadFile(signatureFile, aws.String(releaseConfig.ReleaseBucket), aws.String(signatureKey), releaseConfig.ReleaseClients.S3.Uploader)
+		if err != nil {
+			return fmt.Errorf("uploading manifest signature %s: %v", signatureFile, err)
+		}
+	} else if !releaseConfig.DevRelease {
+		return fmt.Errorf("manifest signing key is required to release manifest %s", manifestFile)
+	} else {
+		fmt.Println("No manifest signing key configured, skipping manifest signing for dev release")
+	}
+
+	return s3.UploadFile(manifestFile, aws.String(releaseConfig.ReleaseBucket), aws.String(manifestKey), releaseConfig.ReleaseClients.S3.Uploader)
+}
+
 func init() {
 	rootCmd.AddCommand(releaseCmd)
 
@@ -322,4 +354,5 @@ func init() {
 	releaseCmd.Flags().String("release-environment", "", "Release environment")
 	releaseCmd.Flags().Bool("dry-run", false, "Flag to indicate if the release is a dry run")
 	releaseCmd.Flags().Bool("weekly", false, "Flag to indicate a weekly bundle release")
+	releaseCmd.Flags().String("manifest-signing-key-id", "", "The KMS key used to sign the bundles and releases manifests. Required for non-dev releases, dev release manifests are not signed if empty")
 }
@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/pkg/errors"
+)
+
+// SignatureSuffix is appended to the path of a file to build the path of its detached signature.
+const SignatureSuffix = ".sig"
+
+// SignFile signs the sha256 digest of the file with an asymmetric ECC KMS key and writes the
+// base64 encoded signature next to it, in the same format as `cosign sign-blob`.
+// It returns the path to the signature file.
+func SignFile(filePath, keyId string, kmsClient kmsiface.KMSAPI) (string, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return "", errors.Cause(err)
+	}
+
+	digest := sha256.Sum256(content)
+	out, err := kmsClient.Sign(&kms.SignInput{
+		KeyId:            aws.String(keyId),
+		Message:          digest[:],
+		MessageType:      aws.String(kms.MessageTypeDigest),
+		SigningAlgorithm: aws.String(kms.SigningAlgorithmSpecEcdsaSha256),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "signing %s with KMS key %s", filePath, keyId)
+	}
+
+	signatureFilePath := filePath + SignatureSuffix
+	signature := base64.StdEncoding.EncodeToString(out.Signature)
+	if err = ioutil.WriteFile(signatureFilePath, []byte(signature), 0o644); err != nil {
+		return "", errors.Cause(err)
+	}
+
+	return signatureFilePath, nil
+}
@@ -0,0 +1,63 @@
+package kms_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	kmssdk "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/release/pkg/aws/kms"
+)
+
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	key *ecdsa.PrivateKey
+}
+
+func (f *fakeKMS) Sign(input *kmssdk.SignInput) (*kmssdk.SignOutput, error) {
+	signature, err := ecdsa.SignASN1(rand.Reader, f.key, input.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &kmssdk.SignOutput{
+		KeyId:            input.KeyId,
+		Signature:        signature,
+		SigningAlgorithm: input.SigningAlgorithm,
+	}, nil
+}
+
+func TestSignFile(t *testing.T) {
+	g := NewWithT(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	content := []byte("kind: Bundles")
+	filePath := filepath.Join(t.TempDir(), "bundle-release.yaml")
+	g.Expect(ioutil.WriteFile(filePath, content, 0o644)).To(Succeed())
+
+	signaturePath, err := kms.SignFile(filePath, "alias/manifest-signing", &fakeKMS{key: key})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(signaturePath).To(Equal(filePath + ".sig"))
+
+	encoded, err := ioutil.ReadFile(signaturePath)
+	g.Expect(err).NotTo(HaveOccurred())
+	signature, err := base64.StdEncoding.DecodeString(string(encoded))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	digest := sha256.Sum256(content)
+	g.Expect(ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature)).To(BeTrue())
+}
+
+func TestSignFileMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	_, err := kms.SignFile(filepath.Join(t.TempDir(), "missing.yaml"), "alias/manifest-signing", &fakeKMS{})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	ecrsdk "github.com/aws/aws-sdk-go/service/ecr"
 	ecrpublicsdk "github.com/aws/aws-sdk-go/service/ecrpublic"
+	kmssdk "github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	docker "github.com/fsouza/go-dockerclient"
@@ -38,6 +39,7 @@ type SourceClients struct {
 type ReleaseClients struct {
 	S3        *ReleaseS3Clients
 	ECRPublic *ReleaseECRPublicClient
+	KMS       *kmssdk.KMS
 }
 
 type SourceS3Clients struct {
@@ -125,6 +127,7 @@ func CreateDevReleaseClients(dryRun bool) (*SourceClients, *ReleaseClients, erro
 			Client:     ecrPublicClient,
 			AuthConfig: releaseAuthConfig,
 		},
+		KMS: kmssdk.New(pdxSession),
 	}
 
 	return sourceClients, releaseClients, nil
@@ -199,6 +202,7 @@ func CreateStagingReleaseClients() (*SourceClients, *ReleaseClients, error) {
 			Client:     ecrPublicClient,
 			AuthConfig: releaseAuthConfig,
 		},
+		KMS: kmssdk.New(releaseSession),
 	}
 
 	return sourceClients, releaseClients, nil
@@ -274,6 +278,7 @@ func CreateProdReleaseClients() (*SourceClients, *ReleaseClients, error) {
 			Client:     releaseEcrPublicClient,
 			AuthConfig: releaseAuthConfig,
 		},
+		KMS: kmssdk.New(releaseSession),
 	}
 
 	return sourceClients, releaseClients, nil
@@ -48,6 +48,7 @@ type ReleaseConfig struct {
 	DryRun                   bool
 	Weekly                   bool
 	ReleaseEnvironment       string
+	ManifestSigningKeyId     string
 	SourceClients            *clients.SourceClients
 	ReleaseClients           *clients.ReleaseClients
 	BundleArtifactsTable     map[string][]Artifact
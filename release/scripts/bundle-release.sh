@@ -36,6 +36,7 @@ BUILD_REPO_BRANCH_NAME="${11?Specify eleventh argument - Build repo branch name}
 CLI_REPO_BRANCH_NAME="${12?Specify twelfth argument - CLI repo branch name}"
 BUILD_REPO_URL="${13?Specify thirteenth argument - Build repo URL}"
 CLI_REPO_URL="${14?Specify fourteenth argument - CLI repo URL}"
+MANIFEST_SIGNING_KEY_ID="${15?Specify fifteenth argument - Manifest signing KMS key id}"
 
 set_aws_config "$RELEASE_ENVIRONMENT"
 
@@ -57,4 +58,5 @@ ${BASE_DIRECTORY}/release/bin/eks-anywhere-release release \
     --dev-release=false \
     --bundle-release=true \
     --build-repo-url "${BUILD_REPO_URL}" \
-    --cli-repo-url "${CLI_REPO_URL}"
+    --cli-repo-url "${CLI_REPO_URL}" \
+    --manifest-signing-key-id "${MANIFEST_SIGNING_KEY_ID}"
@@ -33,6 +33,7 @@ RELEASE_ENVIRONMENT="${8?Specify eighth argument - Release environment}"
 CLI_REPO_BRANCH_NAME="${9?Specify ninth argument - Branch name}"
 BUILD_REPO_URL="${10?Specify tenth argument - Build repo URL}"
 CLI_REPO_URL="${11?Specify eleventh argument - CLI repo URL}"
+MANIFEST_SIGNING_KEY_ID="${12?Specify twelfth argument - Manifest signing KMS key id}"
 
 set_aws_config "$RELEASE_ENVIRONMENT"
 
@@ -51,4 +52,5 @@ ${BASE_DIRECTORY}/release/bin/eks-anywhere-release release \
     --dev-release=false \
     --bundle-release=false \
     --build-repo-url "${BUILD_REPO_URL}" \
-    --cli-repo-url "${CLI_REPO_URL}"
+    --cli-repo-url "${CLI_REPO_URL}" \
+    --manifest-signing-key-id "${MANIFEST_SIGNING_KEY_ID}"